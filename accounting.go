@@ -0,0 +1,211 @@
+package cu
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// TagUsage is the GPU time attributed to a tag.
+type TagUsage struct {
+	Tag   string
+	Spans int           // the number of launches or measured spans
+	Time  time.Duration // the GPU time between the events around them
+}
+
+// UsageReport is the GPU time of every tag, the largest first.
+type UsageReport []TagUsage
+
+// Total returns the GPU time of all the tags.
+func (r UsageReport) Total() (total time.Duration) {
+	for _, u := range r {
+		total += u.Time
+	}
+	return total
+}
+
+func (r UsageReport) String() string {
+	var buf bytes.Buffer
+	total := r.Total()
+	for _, u := range r {
+		share := 0.0
+		if total > 0 {
+			share = 100 * float64(u.Time) / float64(total)
+		}
+		fmt.Fprintf(&buf, "%-24s %8d %12v %6.2f%%\n", u.Tag, u.Spans, u.Time, share)
+	}
+	return buf.String()
+}
+
+// span is the pair of events recorded around the work of a tag.
+type span struct {
+	tag        string
+	start, end Event
+}
+
+// Accountant attributes the GPU time of launches to tags chosen by the caller, such as the request or the model the
+// work is done for, so that services shared by many tenants can tell who uses the GPU:
+//
+//	acct := cu.NewAccountant()
+//	err := acct.Launch("model-a", fn, gridX, 1, 1, blockX, 1, 1, 0, stream, args)
+//	..
+//	report, err := acct.Report()
+//
+// The time of a launch is measured by a pair of events recorded around it, on its stream. Measuring does not
+// synchronize: the events are read once they are complete, when Report or Collect are called.
+//
+// Like an EventPool, an Accountant must only be used from a thread where the context it was first used in is current.
+type Accountant struct {
+	events *EventPool
+
+	sync.Mutex
+	observe func(tag string, d time.Duration) // if not nil, called with the time of every span
+	pending []span
+	usage   map[string]*TagUsage
+}
+
+// NewAccountant creates an Accountant.
+func NewAccountant() *Accountant {
+	return &Accountant{
+		events: NewEventPool(DefaultEvent),
+		usage:  make(map[string]*TagUsage),
+	}
+}
+
+// Launch launches the kernel (see (Function).Launch), and attributes its GPU time to the tag.
+func (a *Accountant) Launch(tag string, fn Function, gridDimX, gridDimY, gridDimZ int, blockDimX, blockDimY, blockDimZ int, sharedMemBytes int, stream Stream, kernelParams []unsafe.Pointer) error {
+	return a.Measure(tag, stream, func() error {
+		return fn.Launch(gridDimX, gridDimY, gridDimZ, blockDimX, blockDimY, blockDimZ, sharedMemBytes, stream, kernelParams)
+	})
+}
+
+// Measure attributes the GPU time of the work fn enqueues on the stream to the tag. Work enqueued on other streams is
+// not measured. If fn fails, nothing is attributed.
+func (a *Accountant) Measure(tag string, stream Stream, fn func() error) error {
+	start, err := a.events.Get()
+	if err != nil {
+		return err
+	}
+	end, err := a.events.Get()
+	if err != nil {
+		a.events.Put(start)
+		return err
+	}
+	release := func() {
+		a.events.Put(start)
+		a.events.Put(end)
+	}
+	if err = start.Record(stream); err != nil {
+		release()
+		return errors.Wrap(err, "Unable to record the start of a span")
+	}
+	if err = fn(); err != nil {
+		release()
+		return err
+	}
+	if err = end.Record(stream); err != nil {
+		release()
+		return errors.Wrap(err, "Unable to record the end of a span")
+	}
+
+	a.Lock()
+	a.pending = append(a.pending, span{tag: tag, start: start, end: end})
+	a.Unlock()
+	return nil
+}
+
+// Collect attributes the time of the completed spans to their tags. It does not wait for the others.
+func (a *Accountant) Collect() error {
+	a.Lock()
+	defer a.Unlock()
+	pending := a.pending[:0]
+	var retVal error
+	for _, s := range a.pending {
+		switch err := s.end.Query(); err {
+		case nil:
+		case NotReady:
+			pending = append(pending, s)
+			continue
+		default:
+			if retVal == nil {
+				retVal = errors.Wrapf(err, "Unable to query the end of a span of %q", s.tag)
+			}
+			pending = append(pending, s)
+			continue
+		}
+		ms, err := s.start.Elapsed(s.end)
+		if err != nil && retVal == nil {
+			retVal = errors.Wrapf(err, "Unable to time a span of %q", s.tag)
+		}
+		d := time.Duration(ms * float64(time.Millisecond))
+		a.add(s.tag, d)
+		if a.observe != nil {
+			a.observe(s.tag, d)
+		}
+		a.events.Put(s.start)
+		a.events.Put(s.end)
+	}
+	for i := len(pending); i < len(a.pending); i++ {
+		a.pending[i] = span{}
+	}
+	a.pending = pending
+	return retVal
+}
+
+// add attributes the time of a span to the tag. The lock is expected to be held.
+func (a *Accountant) add(tag string, d time.Duration) {
+	u, ok := a.usage[tag]
+	if !ok {
+		u = &TagUsage{Tag: tag}
+		a.usage[tag] = u
+	}
+	u.Spans++
+	u.Time += d
+}
+
+// Report collects the completed spans, and returns the GPU time of every tag. Spans that are not complete yet are
+// left for a later report.
+func (a *Accountant) Report() (UsageReport, error) {
+	err := a.Collect()
+	a.Lock()
+	defer a.Unlock()
+	report := make(UsageReport, 0, len(a.usage))
+	for _, u := range a.usage {
+		report = append(report, *u)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Time != report[j].Time {
+			return report[i].Time > report[j].Time
+		}
+		return report[i].Tag < report[j].Tag
+	})
+	return report, err
+}
+
+// Reset forgets the time attributed so far. Pending spans are still attributed when they complete.
+func (a *Accountant) Reset() {
+	a.Lock()
+	a.usage = make(map[string]*TagUsage)
+	a.Unlock()
+}
+
+// Close waits for the pending spans, attributes them, and destroys the events of the Accountant.
+func (a *Accountant) Close() error {
+	a.Lock()
+	for _, s := range a.pending {
+		if err := s.end.Synchronize(); err != nil {
+			a.Unlock()
+			return errors.Wrap(err, "Unable to wait for a span")
+		}
+	}
+	a.Unlock()
+	if err := a.Collect(); err != nil {
+		return err
+	}
+	return a.events.Destroy()
+}
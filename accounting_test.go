@@ -0,0 +1,120 @@
+package cu
+
+import (
+	"runtime"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestAccountant_Report(t *testing.T) {
+	a := NewAccountant()
+	a.add("model-b", time.Millisecond)
+	a.add("model-a", 2*time.Millisecond)
+	a.add("model-b", 2*time.Millisecond)
+
+	report, err := a.Report()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report) != 2 || report[0] != (TagUsage{"model-b", 2, 3 * time.Millisecond}) {
+		t.Errorf("Expected model-b first. Got %v", report)
+	}
+	if report.Total() != 5*time.Millisecond {
+		t.Errorf("Expected a total of 5ms. Got %v", report.Total())
+	}
+	a.Reset()
+	if report, _ = a.Report(); len(report) != 0 {
+		t.Errorf("Expected an empty report once reset. Got %v", report)
+	}
+}
+
+func TestAccountant_Measure(t *testing.T) {
+	devices, _ := NumDevices()
+	if devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := Device(0).MakeContext(SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+
+	mem, err := MemAlloc(1 << 24)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer MemFree(mem)
+
+	a := NewAccountant()
+	for i := 0; i < 3; i++ {
+		if err = a.Measure("memset", NoStream, func() error { return MemsetD8Async(mem, 0, 1<<24, NoStream) }); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = a.Close(); err != nil {
+		t.Fatal(err)
+	}
+	report, err := a.Report()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report) != 1 || report[0].Spans != 3 || report[0].Time <= 0 {
+		t.Errorf("Expected the time of 3 memsets. Got %v", report)
+	}
+}
+
+func TestTimeKernels(t *testing.T) {
+	var observed []string
+	TimeKernels(func(name string, d time.Duration) { observed = append(observed, name) })
+	defer TimeKernels(nil)
+	if !timingKernels() {
+		t.Fatal("Expected kernels to be timed")
+	}
+	if name := (Function{}).Name(); name != "" {
+		t.Errorf("Expected no name for a function that was not looked up. Got %q", name)
+	}
+
+	devices, _ := NumDevices()
+	if devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := Device(0).MakeContext(SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+	mod, err := LoadData(add32PTX)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mod.Unload()
+	fn, err := mod.Function("add32")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, b := make([]float32, 32), make([]float32, 32)
+	memA, _ := AllocAndCopy(unsafe.Pointer(&a[0]), 128)
+	memB, _ := AllocAndCopy(unsafe.Pointer(&b[0]), 128)
+	defer MemFree(memA)
+	defer MemFree(memB)
+	size := int64(32)
+	args := []unsafe.Pointer{unsafe.Pointer(&memA), unsafe.Pointer(&memB), unsafe.Pointer(&size)}
+	if err = fn.Launch(1, 1, 1, 32, 1, 1, 0, NoStream, args); err != nil {
+		t.Fatal(err)
+	}
+	if err = Synchronize(); err != nil {
+		t.Fatal(err)
+	}
+	if err = CollectKernelTimes(); err != nil {
+		t.Fatal(err)
+	}
+	if len(observed) != 1 || observed[0] != "add32" {
+		t.Errorf("Expected the launch of add32 to be observed. Got %v", observed)
+	}
+}
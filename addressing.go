@@ -21,7 +21,7 @@ import "unsafe"
 //			Otherwise, the location chosen is arbitrary.
 //		- SetPreferredLocation:
 // 			This advice sets the preferred location for the data to be the memory belonging to device.
-// 			Passing in CU_DEVICE_CPU for device sets the preferred location as host memory.
+// 			Passing in CPU (CU_DEVICE_CPU) for device sets the preferred location as host memory.
 // 			If device is a GPU, then it must have a non-zero value for the device attribute CU_DEVICE_ATTRIBUTE_CONCURRENT_MANAGED_ACCESS.
 // 			Setting the preferred location does not cause data to migrate to that location immediately.
 //			Instead, it guides the migration policy when a fault occurs on that memory region.
@@ -36,7 +36,7 @@ import "unsafe"
 // 			Undoes the effect of SetPreferredLocation and changes the preferred location to none.
 //		- SetAccessedBy:
 //			This advice implies that the data will be accessed by device.
-// 			Passing in CU_DEVICE_CPU for device will set the advice for the CPU.
+// 			Passing in CPU (CU_DEVICE_CPU) for device will set the advice for the CPU.
 // 			If device is a GPU, then the device attribute CU_DEVICE_ATTRIBUTE_CONCURRENT_MANAGED_ACCESS must be non-zero.
 // 			This advice does not cause data migration and has no impact on the location of the data per se.
 // 			Instead, it causes the data to always be mapped in the specified processor's page tables, as long as the location of the data permits a mapping to be established.
@@ -61,7 +61,7 @@ func (d DevicePtr) MemAdvise(count int64, advice MemAdvice, dev Device) error {
 }
 
 // MemPrefetchAsync prefetches memory to the specified destination device. devPtr is the base device pointer of the memory to be prefetched and dstDevice is the destination device. count specifies the number of bytes to copy. hStream is the stream in which the operation is enqueued. The memory range must refer to managed memory allocated via cuMemAllocManaged or declared via __managed__ variables.
-// Passing in CU_DEVICE_CPU for dstDevice will prefetch the data to host memory. If dstDevice is a GPU, then the device attribute CU_DEVICE_ATTRIBUTE_CONCURRENT_MANAGED_ACCESS must be non-zero. Additionally, hStream must be associated with a device that has a non-zero value for the device attribute CU_DEVICE_ATTRIBUTE_CONCURRENT_MANAGED_ACCESS.
+// Passing in CPU (CU_DEVICE_CPU) for dstDevice will prefetch the data to host memory. If dstDevice is a GPU, then the device attribute CU_DEVICE_ATTRIBUTE_CONCURRENT_MANAGED_ACCESS must be non-zero. Additionally, hStream must be associated with a device that has a non-zero value for the device attribute CU_DEVICE_ATTRIBUTE_CONCURRENT_MANAGED_ACCESS.
 //
 // The start address and end address of the memory range will be rounded down and rounded up respectively to be aligned to CPU page size before the prefetch operation is enqueued in the stream.
 //
@@ -110,5 +110,3 @@ func (d DevicePtr) SetPtrAttribute(value unsafe.Pointer, attr PointerAttribute)
 	a := C.CUpointer_attribute(attr)
 	return result(C.cuPointerSetAttribute(value, a, devPtr))
 }
-
-// TODO: MemRange attributes
@@ -42,11 +42,6 @@ func (dev Device) Attribute(attrib DeviceAttribute) (pi int, err error) {
 	return
 }
 
-func (dev Device) ReleasePrimaryCtx() (err error) {
-	Cdev := C.CUdevice(dev)
-	return result(C.cuDevicePrimaryCtxRelease(Cdev))
-}
-
 func (dev Device) SetPrimaryCtxFlags(flags ContextFlags) (err error) {
 	Cdev := C.CUdevice(dev)
 	Cflags := C.uint(flags)
@@ -65,35 +60,6 @@ func (dev Device) PrimaryCtxState() (flags ContextFlags, active int, err error)
 	return
 }
 
-func (dev Device) ResetPrimaryCtx() (err error) {
-	Cdev := C.CUdevice(dev)
-	return result(C.cuDevicePrimaryCtxReset(Cdev))
-}
-
-func PushCurrentCtx(ctx CUContext) (err error) {
-	Cctx := ctx.c()
-	return result(C.cuCtxPushCurrent(Cctx))
-}
-
-func PopCurrentCtx() (pctx CUContext, err error) {
-	var Cpctx C.CUcontext
-	err = result(C.cuCtxPopCurrent(&Cpctx))
-	pctx = makeContext(Cpctx)
-	return
-}
-
-func SetCurrentContext(ctx CUContext) (err error) {
-	Cctx := ctx.c()
-	return result(C.cuCtxSetCurrent(Cctx))
-}
-
-func CurrentContext() (pctx CUContext, err error) {
-	var Cpctx C.CUcontext
-	err = result(C.cuCtxGetCurrent(&Cpctx))
-	pctx = makeContext(Cpctx)
-	return
-}
-
 func CurrentDevice() (device Device, err error) {
 	var Cdevice C.CUdevice
 	err = result(C.cuCtxGetDevice(&Cdevice))
@@ -175,11 +141,6 @@ func StreamPriorityRange() (leastPriority int, greatestPriority int, err error)
 	return
 }
 
-func (hmod Module) Unload() (err error) {
-	Chmod := hmod.c()
-	return result(C.cuModuleUnload(Chmod))
-}
-
 func MemInfo() (free int64, total int64, err error) {
 	var Cfree C.size_t
 	var Ctotal C.size_t
@@ -191,15 +152,6 @@ func MemInfo() (free int64, total int64, err error) {
 	return
 }
 
-func MemAlloc(bytesize int64) (dptr DevicePtr, err error) {
-	Cbytesize := C.size_t(bytesize)
-	var Cdptr C.CUdeviceptr
-	err = result(C.cuMemAlloc(&Cdptr, Cbytesize))
-	dptr = DevicePtr(Cdptr)
-
-	return
-}
-
 func MemAllocPitch(WidthInBytes int64, Height int64, ElementSizeBytes uint) (dptr DevicePtr, pPitch int64, err error) {
 	CWidthInBytes := C.size_t(WidthInBytes)
 	CHeight := C.size_t(Height)
@@ -214,11 +166,6 @@ func MemAllocPitch(WidthInBytes int64, Height int64, ElementSizeBytes uint) (dpt
 	return
 }
 
-func MemFree(dptr DevicePtr) (err error) {
-	Cdptr := C.CUdeviceptr(dptr)
-	return result(C.cuMemFree(Cdptr))
-}
-
 func MemFreeHost(p unsafe.Pointer) (err error) {
 	Cp := p
 	return result(C.cuMemFreeHost(Cp))
@@ -238,7 +185,7 @@ func Memcpy(dst DevicePtr, src DevicePtr, ByteCount int64) (err error) {
 	Cdst := C.CUdeviceptr(dst)
 	Csrc := C.CUdeviceptr(src)
 	CByteCount := C.size_t(ByteCount)
-	return result(C.cuMemcpy(Cdst, Csrc, CByteCount))
+	return counted(Unified, NoStream, ByteCount, result(C.cuMemcpy(Cdst, Csrc, CByteCount)))
 }
 
 func MemcpyPeer(dstDevice DevicePtr, dstContext CUContext, srcDevice DevicePtr, srcContext CUContext, ByteCount int64) (err error) {
@@ -247,28 +194,21 @@ func MemcpyPeer(dstDevice DevicePtr, dstContext CUContext, srcDevice DevicePtr,
 	CsrcDevice := C.CUdeviceptr(srcDevice)
 	CsrcContext := srcContext.c()
 	CByteCount := C.size_t(ByteCount)
-	return result(C.cuMemcpyPeer(CdstDevice, CdstContext, CsrcDevice, CsrcContext, CByteCount))
+	return counted(PtoP, NoStream, ByteCount, result(C.cuMemcpyPeer(CdstDevice, CdstContext, CsrcDevice, CsrcContext, CByteCount)))
 }
 
 func MemcpyHtoD(dstDevice DevicePtr, srcHost unsafe.Pointer, ByteCount int64) (err error) {
 	CdstDevice := C.CUdeviceptr(dstDevice)
 	CsrcHost := srcHost
 	CByteCount := C.size_t(ByteCount)
-	return result(C.cuMemcpyHtoD(CdstDevice, CsrcHost, CByteCount))
-}
-
-func MemcpyDtoH(dstHost unsafe.Pointer, srcDevice DevicePtr, ByteCount int64) (err error) {
-	CdstHost := dstHost
-	CsrcDevice := C.CUdeviceptr(srcDevice)
-	CByteCount := C.size_t(ByteCount)
-	return result(C.cuMemcpyDtoH(CdstHost, CsrcDevice, CByteCount))
+	return counted(HtoD, NoStream, ByteCount, result(C.cuMemcpyHtoD(CdstDevice, CsrcHost, CByteCount)))
 }
 
 func MemcpyDtoD(dstDevice DevicePtr, srcDevice DevicePtr, ByteCount int64) (err error) {
 	CdstDevice := C.CUdeviceptr(dstDevice)
 	CsrcDevice := C.CUdeviceptr(srcDevice)
 	CByteCount := C.size_t(ByteCount)
-	return result(C.cuMemcpyDtoD(CdstDevice, CsrcDevice, CByteCount))
+	return counted(DtoD, NoStream, ByteCount, result(C.cuMemcpyDtoD(CdstDevice, CsrcDevice, CByteCount)))
 }
 
 func MemcpyDtoA(dstArray Array, dstOffset int64, srcDevice DevicePtr, ByteCount int64) (err error) {
@@ -337,7 +277,7 @@ func MemcpyAsync(dst DevicePtr, src DevicePtr, ByteCount int64, hStream Stream)
 	Csrc := C.CUdeviceptr(src)
 	CByteCount := C.size_t(ByteCount)
 	ChStream := hStream.c()
-	return result(C.cuMemcpyAsync(Cdst, Csrc, CByteCount, ChStream))
+	return counted(Unified, hStream, ByteCount, checkSync("MemcpyAsync", hStream, result(C.cuMemcpyAsync(Cdst, Csrc, CByteCount, ChStream))))
 }
 
 func MemcpyPeerAsync(dstDevice DevicePtr, dstContext CUContext, srcDevice DevicePtr, srcContext CUContext, ByteCount int64, hStream Stream) (err error) {
@@ -347,7 +287,7 @@ func MemcpyPeerAsync(dstDevice DevicePtr, dstContext CUContext, srcDevice Device
 	CsrcContext := srcContext.c()
 	CByteCount := C.size_t(ByteCount)
 	ChStream := hStream.c()
-	return result(C.cuMemcpyPeerAsync(CdstDevice, CdstContext, CsrcDevice, CsrcContext, CByteCount, ChStream))
+	return counted(PtoP, hStream, ByteCount, checkSync("MemcpyPeerAsync", hStream, result(C.cuMemcpyPeerAsync(CdstDevice, CdstContext, CsrcDevice, CsrcContext, CByteCount, ChStream))))
 }
 
 func MemcpyHtoDAsync(dstDevice DevicePtr, srcHost unsafe.Pointer, ByteCount int64, hStream Stream) (err error) {
@@ -355,7 +295,7 @@ func MemcpyHtoDAsync(dstDevice DevicePtr, srcHost unsafe.Pointer, ByteCount int6
 	CsrcHost := srcHost
 	CByteCount := C.size_t(ByteCount)
 	ChStream := hStream.c()
-	return result(C.cuMemcpyHtoDAsync(CdstDevice, CsrcHost, CByteCount, ChStream))
+	return counted(HtoD, hStream, ByteCount, checkSync("MemcpyHtoDAsync", hStream, result(C.cuMemcpyHtoDAsync(CdstDevice, CsrcHost, CByteCount, ChStream))))
 }
 
 func MemcpyDtoHAsync(dstHost unsafe.Pointer, srcDevice DevicePtr, ByteCount int64, hStream Stream) (err error) {
@@ -363,7 +303,7 @@ func MemcpyDtoHAsync(dstHost unsafe.Pointer, srcDevice DevicePtr, ByteCount int6
 	CsrcDevice := C.CUdeviceptr(srcDevice)
 	CByteCount := C.size_t(ByteCount)
 	ChStream := hStream.c()
-	return result(C.cuMemcpyDtoHAsync(CdstHost, CsrcDevice, CByteCount, ChStream))
+	return counted(DtoH, hStream, ByteCount, checkSync("MemcpyDtoHAsync", hStream, result(C.cuMemcpyDtoHAsync(CdstHost, CsrcDevice, CByteCount, ChStream))))
 }
 
 func MemcpyDtoDAsync(dstDevice DevicePtr, srcDevice DevicePtr, ByteCount int64, hStream Stream) (err error) {
@@ -371,7 +311,7 @@ func MemcpyDtoDAsync(dstDevice DevicePtr, srcDevice DevicePtr, ByteCount int64,
 	CsrcDevice := C.CUdeviceptr(srcDevice)
 	CByteCount := C.size_t(ByteCount)
 	ChStream := hStream.c()
-	return result(C.cuMemcpyDtoDAsync(CdstDevice, CsrcDevice, CByteCount, ChStream))
+	return counted(DtoD, hStream, ByteCount, checkSync("MemcpyDtoDAsync", hStream, result(C.cuMemcpyDtoDAsync(CdstDevice, CsrcDevice, CByteCount, ChStream))))
 }
 
 func MemcpyHtoAAsync(dstArray Array, dstOffset int64, srcHost unsafe.Pointer, ByteCount int64, hStream Stream) (err error) {
@@ -380,7 +320,7 @@ func MemcpyHtoAAsync(dstArray Array, dstOffset int64, srcHost unsafe.Pointer, By
 	CsrcHost := srcHost
 	CByteCount := C.size_t(ByteCount)
 	ChStream := hStream.c()
-	return result(C.cuMemcpyHtoAAsync(CdstArray, CdstOffset, CsrcHost, CByteCount, ChStream))
+	return checkSync("MemcpyHtoAAsync", hStream, result(C.cuMemcpyHtoAAsync(CdstArray, CdstOffset, CsrcHost, CByteCount, ChStream)))
 }
 
 func MemcpyAtoHAsync(dstHost unsafe.Pointer, srcArray Array, srcOffset int64, ByteCount int64, hStream Stream) (err error) {
@@ -389,25 +329,25 @@ func MemcpyAtoHAsync(dstHost unsafe.Pointer, srcArray Array, srcOffset int64, By
 	CsrcOffset := C.size_t(srcOffset)
 	CByteCount := C.size_t(ByteCount)
 	ChStream := hStream.c()
-	return result(C.cuMemcpyAtoHAsync(CdstHost, CsrcArray, CsrcOffset, CByteCount, ChStream))
+	return checkSync("MemcpyAtoHAsync", hStream, result(C.cuMemcpyAtoHAsync(CdstHost, CsrcArray, CsrcOffset, CByteCount, ChStream)))
 }
 
 func Memcpy2DAsync(pCopy Memcpy2dParam, hStream Stream) (err error) {
 	CpCopy := pCopy.c()
 	ChStream := hStream.c()
-	return result(C.cuMemcpy2DAsync(CpCopy, ChStream))
+	return checkSync("Memcpy2DAsync", hStream, result(C.cuMemcpy2DAsync(CpCopy, ChStream)))
 }
 
 func Memcpy3DAsync(pCopy Memcpy3dParam, hStream Stream) (err error) {
 	CpCopy := pCopy.c()
 	ChStream := hStream.c()
-	return result(C.cuMemcpy3DAsync(CpCopy, ChStream))
+	return checkSync("Memcpy3DAsync", hStream, result(C.cuMemcpy3DAsync(CpCopy, ChStream)))
 }
 
 func Memcpy3DPeerAsync(pCopy Memcpy3dPeerParam, hStream Stream) (err error) {
 	CpCopy := pCopy.c()
 	ChStream := hStream.c()
-	return result(C.cuMemcpy3DPeerAsync(CpCopy, ChStream))
+	return checkSync("Memcpy3DPeerAsync", hStream, result(C.cuMemcpy3DPeerAsync(CpCopy, ChStream)))
 }
 
 func MemsetD8(dstDevice DevicePtr, uc byte, N int64) (err error) {
@@ -463,7 +403,7 @@ func MemsetD8Async(dstDevice DevicePtr, uc byte, N int64, hStream Stream) (err e
 	Cuc := C.uchar(uc)
 	CN := C.size_t(N)
 	ChStream := hStream.c()
-	return result(C.cuMemsetD8Async(CdstDevice, Cuc, CN, ChStream))
+	return checkSync("MemsetD8Async", hStream, result(C.cuMemsetD8Async(CdstDevice, Cuc, CN, ChStream)))
 }
 
 func MemsetD16Async(dstDevice DevicePtr, us uint16, N int64, hStream Stream) (err error) {
@@ -471,7 +411,7 @@ func MemsetD16Async(dstDevice DevicePtr, us uint16, N int64, hStream Stream) (er
 	Cus := C.ushort(us)
 	CN := C.size_t(N)
 	ChStream := hStream.c()
-	return result(C.cuMemsetD16Async(CdstDevice, Cus, CN, ChStream))
+	return checkSync("MemsetD16Async", hStream, result(C.cuMemsetD16Async(CdstDevice, Cus, CN, ChStream)))
 }
 
 func MemsetD32Async(dstDevice DevicePtr, ui uint, N int64, hStream Stream) (err error) {
@@ -479,7 +419,7 @@ func MemsetD32Async(dstDevice DevicePtr, ui uint, N int64, hStream Stream) (err
 	Cui := C.uint(ui)
 	CN := C.size_t(N)
 	ChStream := hStream.c()
-	return result(C.cuMemsetD32Async(CdstDevice, Cui, CN, ChStream))
+	return checkSync("MemsetD32Async", hStream, result(C.cuMemsetD32Async(CdstDevice, Cui, CN, ChStream)))
 }
 
 func MemsetD2D8Async(dstDevice DevicePtr, dstPitch int64, uc byte, Width int64, Height int64, hStream Stream) (err error) {
@@ -489,7 +429,7 @@ func MemsetD2D8Async(dstDevice DevicePtr, dstPitch int64, uc byte, Width int64,
 	CWidth := C.size_t(Width)
 	CHeight := C.size_t(Height)
 	ChStream := hStream.c()
-	return result(C.cuMemsetD2D8Async(CdstDevice, CdstPitch, Cuc, CWidth, CHeight, ChStream))
+	return checkSync("MemsetD2D8Async", hStream, result(C.cuMemsetD2D8Async(CdstDevice, CdstPitch, Cuc, CWidth, CHeight, ChStream)))
 }
 
 func MemsetD2D16Async(dstDevice DevicePtr, dstPitch int64, us uint16, Width int64, Height int64, hStream Stream) (err error) {
@@ -499,7 +439,7 @@ func MemsetD2D16Async(dstDevice DevicePtr, dstPitch int64, us uint16, Width int6
 	CWidth := C.size_t(Width)
 	CHeight := C.size_t(Height)
 	ChStream := hStream.c()
-	return result(C.cuMemsetD2D16Async(CdstDevice, CdstPitch, Cus, CWidth, CHeight, ChStream))
+	return checkSync("MemsetD2D16Async", hStream, result(C.cuMemsetD2D16Async(CdstDevice, CdstPitch, Cus, CWidth, CHeight, ChStream)))
 }
 
 func MemsetD2D32Async(dstDevice DevicePtr, dstPitch int64, ui uint, Width int64, Height int64, hStream Stream) (err error) {
@@ -509,7 +449,7 @@ func MemsetD2D32Async(dstDevice DevicePtr, dstPitch int64, ui uint, Width int64,
 	CWidth := C.size_t(Width)
 	CHeight := C.size_t(Height)
 	ChStream := hStream.c()
-	return result(C.cuMemsetD2D32Async(CdstDevice, CdstPitch, Cui, CWidth, CHeight, ChStream))
+	return checkSync("MemsetD2D32Async", hStream, result(C.cuMemsetD2D32Async(CdstDevice, CdstPitch, Cui, CWidth, CHeight, ChStream)))
 }
 
 func (hArray Array) Descriptor() (pArrayDescriptor ArrayDesc, err error) {
@@ -563,7 +503,7 @@ func (hStream Stream) AttachMemAsync(dptr DevicePtr, length int64, flags uint) (
 	Cdptr := C.CUdeviceptr(dptr)
 	Clength := C.size_t(length)
 	Cflags := C.uint(flags)
-	return result(C.cuStreamAttachMemAsync(ChStream, Cdptr, Clength, Cflags))
+	return checkSync("AttachMemAsync", hStream, result(C.cuStreamAttachMemAsync(ChStream, Cdptr, Clength, Cflags)))
 }
 
 func (hStream Stream) Query() (err error) {
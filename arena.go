@@ -0,0 +1,155 @@
+package cu
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// arenaAlignment is the alignment of the allocations made from an Arena. It matches the alignment of cuMemAlloc.
+const arenaAlignment = 256
+
+// ArenaStats are the statistics of an Arena. Sizes are in bytes.
+type ArenaStats struct {
+	Capacity  int64
+	Used      int64
+	HighWater int64 // largest value Used has had since the arena was created, or last reset
+	Allocs    int
+}
+
+// Arena is a bump allocator over a single block of device memory. Allocations cannot be freed individually:
+// the arena is reset as a whole, or rewound to a mark.
+//
+// Arenas suit the scratch memory of a step that is repeated, as resetting the arena costs nothing, and every
+// repetition gets the same addresses.
+type Arena struct {
+	base DevicePtr
+	pool *MemPool
+
+	sync.Mutex
+	stats ArenaStats
+}
+
+// NewArena allocates an Arena of the given capacity (in bytes).
+// If pool is not nil, the memory of the arena is allocated from (and returned to) the pool.
+func NewArena(capacity int64, pool *MemPool) (*Arena, error) {
+	if capacity <= 0 {
+		return nil, errors.Errorf("Cannot create an arena of %d bytes", capacity)
+	}
+	capacity = roundUp(capacity, arenaAlignment)
+	var base DevicePtr
+	var err error
+	if pool != nil {
+		base, err = pool.Alloc(capacity)
+	} else {
+		base, err = MemAlloc(capacity)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "NewArena")
+	}
+	return &Arena{base: base, pool: pool, stats: ArenaStats{Capacity: capacity}}, nil
+}
+
+// Alloc allocates size bytes from the arena. When uninitialized reads are being detected (see DebugUninit), the memory
+// is poisoned, and Alloc must be called from a thread where the context of the arena is current.
+func (a *Arena) Alloc(size int64) (DevicePtr, error) {
+	if size <= 0 {
+		return 0, errors.Errorf("Cannot allocate %d bytes", size)
+	}
+	size = roundUp(size, arenaAlignment)
+
+	a.Lock()
+	defer a.Unlock()
+	if a.stats.Used+size > a.stats.Capacity {
+		return 0, errors.Errorf("Arena exhausted: cannot allocate %d bytes. %d of %d bytes are used", size, a.stats.Used, a.stats.Capacity)
+	}
+	ptr := a.base.Offset(a.stats.Used)
+	a.stats.Used += size
+	a.stats.Allocs++
+	if a.stats.Used > a.stats.HighWater {
+		a.stats.HighWater = a.stats.Used
+	}
+	debugf(DebugMem, "Arena %p: allocated %v (%d bytes)", a, ptr, size)
+	return ptr, poison(ptr, size)
+}
+
+// ArenaMark is a position in an Arena.
+type ArenaMark int64
+
+// Mark returns the current position of the arena.
+func (a *Arena) Mark() ArenaMark {
+	a.Lock()
+	defer a.Unlock()
+	return ArenaMark(a.stats.Used)
+}
+
+// Rewind releases all the allocations made since the mark was taken.
+func (a *Arena) Rewind(m ArenaMark) error {
+	a.Lock()
+	defer a.Unlock()
+	if int64(m) < 0 || int64(m) > a.stats.Used {
+		return errors.Errorf("Cannot rewind the arena to %d. %d bytes are used", m, a.stats.Used)
+	}
+	a.stats.Used = int64(m)
+	return nil
+}
+
+// Reset releases all the allocations made from the arena.
+func (a *Arena) Reset() {
+	a.Lock()
+	a.stats.Used = 0
+	a.Unlock()
+	debugf(DebugMem, "Arena %p: reset", a)
+}
+
+// Stats returns the statistics of the arena.
+func (a *Arena) Stats() ArenaStats {
+	a.Lock()
+	defer a.Unlock()
+	return a.stats
+}
+
+// ResetHighWater resets the high-water mark to the number of bytes currently used.
+func (a *Arena) ResetHighWater() {
+	a.Lock()
+	a.stats.HighWater = a.stats.Used
+	a.Unlock()
+}
+
+// ArenaSnapshot is the state of an Arena at the time Snapshot was called.
+type ArenaSnapshot struct {
+	Stats ArenaStats
+}
+
+// Snapshot records the state of the arena.
+func (a *Arena) Snapshot() ArenaSnapshot { return ArenaSnapshot{Stats: a.Stats()} }
+
+// Restore returns the arena to the state recorded by the snapshot: allocations made since are released, and the
+// statistics are restored. The snapshot must have been taken from the same arena.
+func (a *Arena) Restore(s ArenaSnapshot) error {
+	a.Lock()
+	defer a.Unlock()
+	if s.Stats.Capacity != a.stats.Capacity {
+		return errors.Errorf("Cannot restore an arena of %d bytes from the snapshot of an arena of %d bytes", a.stats.Capacity, s.Stats.Capacity)
+	}
+	a.stats = s.Stats
+	return nil
+}
+
+// Close frees the memory of the arena.
+func (a *Arena) Close() error {
+	a.Lock()
+	defer a.Unlock()
+	if a.base == 0 {
+		return nil
+	}
+	var err error
+	if a.pool != nil {
+		err = a.pool.Free(a.base)
+	} else {
+		err = MemFree(a.base)
+	}
+	a.base = 0
+	a.stats = ArenaStats{}
+	return err
+}
@@ -2,7 +2,11 @@ package cu
 
 // #include <cuda.h>
 import "C"
-import "unsafe"
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
 
 // Array is the pointer to a CUDA array. The name is a bit of a misnomer,
 // as it would lead one to imply that it's rangeable. It's not.
@@ -33,7 +37,7 @@ type Array3Desc struct {
 	Width, Height, Depth uint
 	Format               Format
 	NumChannels          uint
-	Flags                uint
+	Flags                uint // ArrayFlags
 }
 
 func (desc Array3Desc) c() *C.CUDA_ARRAY3D_DESCRIPTOR {
@@ -282,3 +286,31 @@ func Make3DArray(pAllocateArray Array3Desc) (pHandle Array, err error) {
 	pHandle = Array{&CpHandle}
 	return
 }
+
+// MipmappedArray is a CUDA array with mipmap levels: each level is an array of half the extents of the previous one,
+// down to 1×1. Textures of mipmapped arrays sample the levels by their level of detail.
+type MipmappedArray struct {
+	arr C.CUmipmappedArray
+}
+
+// MakeMipmappedArray creates a mipmapped array of numLevels levels. The first level has the extents of the descriptor.
+func MakeMipmappedArray(desc Array3Desc, numLevels uint) (MipmappedArray, error) {
+	var m MipmappedArray
+	err := result(C.cuMipmappedArrayCreate(&m.arr, desc.c(), C.uint(numLevels)))
+	return m, errors.Wrap(err, "MipmappedArrayCreate")
+}
+
+// Level returns the array of a level, to copy to or from. The array belongs to the mipmapped array: it must not be
+// destroyed.
+func (m MipmappedArray) Level(level uint) (Array, error) {
+	var arr C.CUarray
+	if err := result(C.cuMipmappedArrayGetLevel(&arr, m.arr, C.uint(level))); err != nil {
+		return Array{}, errors.Wrapf(err, "MipmappedArrayGetLevel %d", level)
+	}
+	return Array{&arr}, nil
+}
+
+// Destroy destroys the mipmapped array, and the arrays of its levels.
+func (m MipmappedArray) Destroy() error {
+	return errors.Wrap(result(C.cuMipmappedArrayDestroy(m.arr)), "MipmappedArrayDestroy")
+}
@@ -0,0 +1,73 @@
+// Package cuarrow moves the columns of Apache Arrow record batches to the device, for analytics on the GPU.
+//
+// The package does not depend on the Go Arrow module: its buffers (*memory.Buffer) satisfy Buffer, and the fixed
+// width columns of a record batch are described from their array data:
+//
+//	data := arr.Data()
+//	col := cuarrow.ColumnOf(cu.DtFloat32, data.Len(), data.NullN(), data.Offset(), data.Buffers()[0], data.Buffers()[1])
+//
+// The columns of a batch are uploaded together by Upload, asynchronously. Copies from pageable memory are staged by
+// the driver, and do not overlap with the work of the host: the buffers of batches that are uploaded repeatedly, or
+// that are large, are better pinned with Pin first.
+//
+// Once uploaded, the values of a column are a DeviceTensor, and the metadata of all the columns of the batch is an
+// array in device memory (see ColumnMeta), so that a kernel can process every column of a batch in one launch.
+//
+// Variable width columns (strings, lists) and boolean columns, which Arrow packs as bitmaps, are not supported.
+package cuarrow
+
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// Buffer is a buffer of host memory. The buffers of the Go Arrow module satisfy it.
+type Buffer interface {
+	Bytes() []byte
+}
+
+// Bytes is a Buffer of a byte slice.
+type Bytes []byte
+
+// Bytes returns b.
+func (b Bytes) Bytes() []byte { return b }
+
+// Pinned is a set of host buffers that are page-locked (see cu.MemHostRegister), so that they are copied to the device
+// asynchronously. The buffers must not be released until they are unpinned.
+type Pinned struct {
+	bufs [][]byte
+}
+
+// Pin page-locks the given buffers, for all contexts. Empty buffers and nil slices are skipped.
+func Pin(bufs ...Buffer) (*Pinned, error) {
+	p := new(Pinned)
+	for _, buf := range bufs {
+		if buf == nil {
+			continue
+		}
+		b := buf.Bytes()
+		if len(b) == 0 {
+			continue
+		}
+		if err := cu.MemHostRegister(unsafe.Pointer(&b[0]), int64(len(b)), cu.HostRegisterPortable); err != nil {
+			p.Unpin()
+			return nil, errors.Wrapf(err, "Unable to pin a buffer of %d bytes", len(b))
+		}
+		p.bufs = append(p.bufs, b)
+	}
+	return p, nil
+}
+
+// Unpin unlocks the buffers. The copies from them must be done.
+func (p *Pinned) Unpin() error {
+	var retVal error
+	for _, b := range p.bufs {
+		if err := cu.MemHostUnregister(unsafe.Pointer(&b[0])); err != nil && retVal == nil {
+			retVal = err
+		}
+	}
+	p.bufs = nil
+	return retVal
+}
@@ -0,0 +1,187 @@
+package cuarrow
+
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// align is the alignment of the buffers on the device, which is the alignment Arrow recommends.
+const align = 64
+
+// Column is a fixed width column of a record batch, in host memory, laid out as Arrow lays it out.
+type Column struct {
+	Dtype  cu.Dtype
+	Len    int // the number of values
+	NullN  int // the number of nulls
+	Offset int // the index of the first value in the buffers, for slices of arrays
+
+	Validity []byte // the validity bitmap, least significant bit first. It may be nil if there are no nulls
+	Values   []byte
+}
+
+// ColumnOf describes a column from the length, the null count, the offset and the buffers of its Arrow array data.
+// The validity buffer is only read if there are nulls, as Arrow leaves it out otherwise.
+func ColumnOf(dt cu.Dtype, length, nullN, offset int, validity, values Buffer) Column {
+	c := Column{Dtype: dt, Len: length, NullN: nullN, Offset: offset, Values: values.Bytes()}
+	if nullN > 0 {
+		c.Validity = validity.Bytes()
+	}
+	return c
+}
+
+// values returns the bytes of the values of the column.
+func (c Column) values() ([]byte, error) {
+	size := int(c.Dtype.Size())
+	if size == 0 || c.Dtype == cu.DtBool {
+		return nil, errors.Errorf("Columns of %v are not supported", c.Dtype)
+	}
+	if c.Len < 0 || c.Offset < 0 || c.NullN < 0 || c.NullN > c.Len {
+		return nil, errors.Errorf("Invalid column of %d values, %d nulls, at offset %d", c.Len, c.NullN, c.Offset)
+	}
+	start, end := c.Offset*size, (c.Offset+c.Len)*size
+	if end > len(c.Values) {
+		return nil, errors.Errorf("Expected at least %d bytes of values. Got %d", end, len(c.Values))
+	}
+	return c.Values[start:end], nil
+}
+
+// validity returns the validity bitmap of the column, starting at the first value, or nil if there are no nulls.
+func (c Column) validity() ([]byte, error) {
+	if c.NullN == 0 {
+		return nil, nil
+	}
+	n := (c.Len + 7) / 8
+	if want := (c.Offset + c.Len + 7) / 8; len(c.Validity) < want {
+		return nil, errors.Errorf("Expected at least %d bytes of validity. Got %d", want, len(c.Validity))
+	}
+	if c.Offset%8 == 0 {
+		return c.Validity[c.Offset/8 : c.Offset/8+n], nil
+	}
+	return shiftBitmap(c.Validity, c.Offset, c.Len), nil
+}
+
+// shiftBitmap returns the n bits of the bitmap b from the bit offset, starting at the first bit of a new bitmap.
+func shiftBitmap(b []byte, offset, n int) []byte {
+	retVal := make([]byte, (n+7)/8)
+	for i := 0; i < n; i++ {
+		j := offset + i
+		if b[j/8]&(1<<uint(j%8)) != 0 {
+			retVal[i/8] |= 1 << uint(i%8)
+		}
+	}
+	return retVal
+}
+
+// DeviceColumn is a column of a Batch, in device memory.
+type DeviceColumn struct {
+	Dtype    cu.Dtype
+	Len      int
+	NullN    int
+	Values   cu.DevicePtr // Len values
+	Validity cu.DevicePtr // the validity bitmap of Len bits, least significant bit first, or 0 if there are no nulls
+}
+
+// Tensor returns the values of the column, as a vector. Nulls have undefined values.
+func (c DeviceColumn) Tensor() cu.DeviceTensor { return cu.NewDeviceTensor(c.Values, c.Dtype, c.Len) }
+
+// ColumnMeta is the metadata of a column of a Batch in device memory, as a kernel reads it:
+//
+//	struct ColumnMeta {
+//		void* values;
+//		const unsigned char* validity; // NULL if there are no nulls
+//		long long length;
+//		long long null_count;
+//	};
+type ColumnMeta struct {
+	Values    cu.DevicePtr
+	Validity  cu.DevicePtr
+	Length    int64
+	NullCount int64
+}
+
+// Batch is a record batch in device memory. Its columns and their metadata share one allocation.
+type Batch struct {
+	Len     int // the number of rows
+	Columns []DeviceColumn
+	Meta    cu.DevicePtr // an array of a ColumnMeta per column
+
+	mem cu.DevicePtr
+}
+
+// Upload enqueues the upload of the columns of a record batch on the stream, and returns the batch in device memory.
+// The columns must have the same number of values. The host memory of the columns must not be modified until the
+// stream is done with it.
+func Upload(cols []Column, stream cu.Stream) (*Batch, error) {
+	if len(cols) == 0 {
+		return nil, errors.New("Upload: no columns")
+	}
+
+	// lay out the metadata, then the values and the validity of each column
+	b := &Batch{Len: cols[0].Len, Columns: make([]DeviceColumn, len(cols))}
+	meta := make([]ColumnMeta, len(cols))
+	values := make([][]byte, len(cols))
+	validity := make([][]byte, len(cols))
+	size := int64(len(cols)) * int64(unsafe.Sizeof(ColumnMeta{}))
+	offsets := make([][2]int64, len(cols))
+	for i, c := range cols {
+		if c.Len != b.Len {
+			return nil, errors.Errorf("Upload: column %d has %d values, column 0 has %d", i, c.Len, b.Len)
+		}
+		var err error
+		if values[i], err = c.values(); err != nil {
+			return nil, errors.Wrapf(err, "Upload: column %d", i)
+		}
+		if validity[i], err = c.validity(); err != nil {
+			return nil, errors.Wrapf(err, "Upload: column %d", i)
+		}
+		size = alignUp(size)
+		offsets[i][0] = size
+		size += int64(len(values[i]))
+		size = alignUp(size)
+		offsets[i][1] = size
+		size += int64(len(validity[i]))
+	}
+
+	var err error
+	if b.mem, err = cu.MemAlloc(size); err != nil {
+		return nil, errors.Wrapf(err, "Upload: unable to allocate %d bytes", size)
+	}
+	b.Meta = b.mem
+	for i, c := range cols {
+		dc := DeviceColumn{Dtype: c.Dtype, Len: c.Len, NullN: c.NullN, Values: b.mem.Offset(offsets[i][0])}
+		if len(values[i]) > 0 {
+			if err = cu.MemcpyHtoDAsync(dc.Values, unsafe.Pointer(&values[i][0]), int64(len(values[i])), stream); err != nil {
+				b.Free()
+				return nil, errors.Wrapf(err, "Upload: column %d", i)
+			}
+		}
+		if len(validity[i]) > 0 {
+			dc.Validity = b.mem.Offset(offsets[i][1])
+			if err = cu.MemcpyHtoDAsync(dc.Validity, unsafe.Pointer(&validity[i][0]), int64(len(validity[i])), stream); err != nil {
+				b.Free()
+				return nil, errors.Wrapf(err, "Upload: column %d", i)
+			}
+		}
+		b.Columns[i] = dc
+		meta[i] = ColumnMeta{Values: dc.Values, Validity: dc.Validity, Length: int64(dc.Len), NullCount: int64(dc.NullN)}
+	}
+	if err = cu.MemcpyHtoDAsync(b.Meta, unsafe.Pointer(&meta[0]), int64(len(meta))*int64(unsafe.Sizeof(meta[0])), stream); err != nil {
+		b.Free()
+		return nil, errors.Wrap(err, "Upload: unable to upload the metadata")
+	}
+	return b, nil
+}
+
+// Free frees the device memory of the batch. The work that uses it must be done.
+func (b *Batch) Free() error {
+	if b.mem == 0 {
+		return nil
+	}
+	err := cu.MemFree(b.mem)
+	b.mem, b.Meta, b.Columns = 0, 0, nil
+	return err
+}
+
+func alignUp(n int64) int64 { return (n + align - 1) / align * align }
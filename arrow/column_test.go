@@ -0,0 +1,104 @@
+package cuarrow
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"gorgonia.org/cu"
+)
+
+func TestShiftBitmap(t *testing.T) {
+	// bits 3 to 12 of 0b10101000, 0b00011111
+	got := shiftBitmap([]byte{0xa8, 0x1f}, 3, 10)
+	if len(got) != 2 || got[0] != 0xf5 || got[1] != 0x03 {
+		t.Errorf("Expected [0xf5 0x03]. Got %#v", got)
+	}
+}
+
+func TestColumn_Check(t *testing.T) {
+	values := make([]byte, 16)
+	c := Column{Dtype: cu.DtFloat32, Len: 3, Offset: 1, Values: values}
+	if v, err := c.values(); err != nil || len(v) != 12 {
+		t.Errorf("Expected 12 bytes of values. Got %d, %v", len(v), err)
+	}
+	c.Len = 4
+	if _, err := c.values(); err == nil {
+		t.Error("Expected an error for values out of the buffer")
+	}
+	c.Dtype = cu.DtBool
+	if _, err := c.values(); err == nil {
+		t.Error("Expected an error for a boolean column")
+	}
+
+	c = Column{Dtype: cu.DtInt32, Len: 4, NullN: 1, Values: values}
+	if _, err := c.validity(); err == nil {
+		t.Error("Expected an error for a missing validity bitmap")
+	}
+	c.Validity = []byte{0x0b}
+	if v, err := c.validity(); err != nil || len(v) != 1 || v[0] != 0x0b {
+		t.Errorf("Expected the bitmap as is. Got %v, %v", v, err)
+	}
+}
+
+func TestUpload(t *testing.T) {
+	if devices, _ := cu.NumDevices(); devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := cu.Device(0).MakeContext(cu.SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+
+	floats := []float32{0, 1, 2, 3, 4}
+	ints := []int64{10, 20, 30, 40, 50}
+	floatBytes := Bytes((*[20]byte)(unsafe.Pointer(&floats[0]))[:])
+	intBytes := Bytes((*[40]byte)(unsafe.Pointer(&ints[0]))[:])
+	pinned, err := Pin(floatBytes, intBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pinned.Unpin()
+
+	// a slice of the last 4 rows, where the third is null
+	cols := []Column{
+		ColumnOf(cu.DtFloat32, 4, 0, 1, nil, floatBytes),
+		ColumnOf(cu.DtInt64, 4, 1, 1, Bytes{0x16}, intBytes),
+	}
+	b, err := Upload(cols, cu.NoStream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Free()
+	if err = cu.NoStream.Synchronize(); err != nil {
+		t.Fatal(err)
+	}
+
+	gotFloats := make([]float32, 4)
+	if err = cu.MemcpyDtoH(unsafe.Pointer(&gotFloats[0]), b.Columns[0].Values, 16); err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range gotFloats {
+		if v != floats[i+1] {
+			t.Errorf("Expected %v. Got %v", floats[1:], gotFloats)
+			break
+		}
+	}
+	var bitmap byte
+	if err = cu.MemcpyDtoH(unsafe.Pointer(&bitmap), b.Columns[1].Validity, 1); err != nil {
+		t.Fatal(err)
+	}
+	if bitmap != 0x0b {
+		t.Errorf("Expected the validity 0x0b. Got %#x", bitmap)
+	}
+	meta := make([]ColumnMeta, 2)
+	if err = cu.MemcpyDtoH(unsafe.Pointer(&meta[0]), b.Meta, int64(len(meta))*int64(unsafe.Sizeof(meta[0]))); err != nil {
+		t.Fatal(err)
+	}
+	if meta[0].Validity != 0 || meta[1].Values != b.Columns[1].Values || meta[1].NullCount != 1 || meta[1].Length != 4 {
+		t.Errorf("Unexpected metadata %+v", meta)
+	}
+}
@@ -0,0 +1,121 @@
+package cu
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+)
+
+// asyncErrBufLen is the number of asynchronous errors that are buffered for a reader of (*Ctx).Errors.
+const asyncErrBufLen = 16
+
+// AsyncError is an error caused by work that was issued earlier, and discovered later: at a synchronization point,
+// or when the results of a batch of calls were checked.
+type AsyncError struct {
+	Err error
+
+	// Batch is the number of the flush of the BatchedContext that failed (starting at 1), and Calls are the calls of
+	// that flush, with their results. Batch is 0 if the error did not come from a BatchedContext.
+	Batch int
+	Calls []BatchCall
+}
+
+func (e *AsyncError) Error() string {
+	if e.Batch == 0 {
+		return fmt.Sprintf("asynchronous error: %v", e.Err)
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "asynchronous error in batch %d: %v", e.Batch, e.Err)
+	for i, c := range e.Calls {
+		if c.Result != nil {
+			fmt.Fprintf(&buf, "\n\tcall %d: %v -> %v", i, c, c.Result)
+		}
+	}
+	return buf.String()
+}
+
+// Cause returns the underlying error.
+func (e *AsyncError) Cause() error { return e.Err }
+
+// IsSticky returns true if err is a sticky error: an error raised by a kernel or copy that faulted on the device.
+// Once such an error has occurred, every call made in the context fails, and the context has to be destroyed.
+func IsSticky(err error) bool {
+	type causer interface {
+		Cause() error
+	}
+	for err != nil {
+		switch err {
+		case IllegalAddress, LaunchFailed, HardwareStackError, IllegalInstruction, MisalignedAddress,
+			InvalidAddressSpace, InvalidPc, Assert, LaunchTimeout:
+			return true
+		}
+		c, ok := err.(causer)
+		if !ok {
+			return false
+		}
+		err = c.Cause()
+	}
+	return false
+}
+
+// asyncReporter is a context that can report asynchronous errors.
+type asyncReporter interface {
+	reportAsync(err error)
+}
+
+// asyncErrors is the channel of asynchronous errors of a Ctx.
+type asyncErrors struct {
+	sync.Mutex
+	c      chan error
+	closed bool
+}
+
+func newAsyncErrors() *asyncErrors { return &asyncErrors{c: make(chan error, asyncErrBufLen)} }
+
+// report sends the error without blocking. The error is dropped if the buffer is full.
+func (a *asyncErrors) report(err error) {
+	a.Lock()
+	defer a.Unlock()
+	if a.closed {
+		return
+	}
+	select {
+	case a.c <- err:
+	default:
+	}
+}
+
+func (a *asyncErrors) close() {
+	a.Lock()
+	defer a.Unlock()
+	if !a.closed {
+		a.closed = true
+		close(a.c)
+	}
+}
+
+// Errors returns a channel on which asynchronous errors are delivered: sticky device errors (see IsSticky)
+// discovered by calls made through Do, and the failures of the batches of a BatchedContext built on this Ctx
+// (see AsyncError). This allows services to notice that a context has become unusable, instead of discovering it
+// on the next call.
+//
+// Up to 16 errors are buffered; further errors are dropped until the channel is read from.
+// The channel is closed when the context is closed.
+func (ctx *Ctx) Errors() <-chan error { return ctx.async.c }
+
+func (ctx *Ctx) reportAsync(err error) { ctx.async.report(err) }
+
+// checkAsync reports err if it is sticky, and returns it.
+func (ctx *Ctx) checkAsync(err error) error {
+	if IsSticky(err) {
+		ctx.reportAsync(&AsyncError{Err: err})
+	}
+	return err
+}
+
+// setErr records the error of a call that does not return one, for Error to return.
+func (ctx *Ctx) setErr(err error) {
+	ctx.errMu.Lock()
+	ctx.err = err
+	ctx.errMu.Unlock()
+}
@@ -0,0 +1,35 @@
+package cu
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSticky(t *testing.T) {
+	assert := assert.New(t)
+	assert.True(IsSticky(IllegalAddress))
+	assert.True(IsSticky(errors.Wrap(LaunchFailed, "Launch")))
+	assert.True(IsSticky(&AsyncError{Err: MisalignedAddress}))
+	assert.False(IsSticky(NotReady))
+	assert.False(IsSticky(errors.New("foo")))
+	assert.False(IsSticky(nil))
+}
+
+func TestAsyncErrors(t *testing.T) {
+	assert := assert.New(t)
+	a := newAsyncErrors()
+	for i := 0; i < asyncErrBufLen+4; i++ {
+		a.report(IllegalAddress) // must not block once the buffer is full
+	}
+	assert.Equal(asyncErrBufLen, len(a.c))
+	a.close()
+	a.report(IllegalAddress) // reporting after close is a no-op
+	a.close()
+
+	err := &AsyncError{Err: IllegalAddress, Batch: 3}
+	assert.True(strings.HasPrefix(err.Error(), "asynchronous error in batch 3"))
+	assert.Equal(IllegalAddress, errors.Cause(err))
+}
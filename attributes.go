@@ -118,6 +118,15 @@ const (
 	PtxVersion           FunctionAttribute = C.CU_FUNC_ATTRIBUTE_PTX_VERSION           // The PTX virtual architecture version for which the function was compiled. This value is the major PTX version * 10 + the minor PTX version, so a PTX version 1.3 function would return the value 13. Note that this may return the undefined value of 0 for cubins compiled prior to CUDA 3.0.
 	BinaryVersion        FunctionAttribute = C.CU_FUNC_ATTRIBUTE_BINARY_VERSION        // The binary architecture version for which the function was compiled. This value is the major binary version * 10 + the minor binary version, so a binary version 1.3 function would return the value 13. Note that this will return a value of 10 for legacy cubins that do not have a properly-encoded binary architecture version.
 	CacheModeCa          FunctionAttribute = C.CU_FUNC_ATTRIBUTE_CACHE_MODE_CA         // The attribute to indicate whether the function has been compiled with user specified option "-Xptxas --dlcm=ca" set .
+
+	MaxDynamicSharedSizeBytes         FunctionAttribute = C.CU_FUNC_ATTRIBUTE_MAX_DYNAMIC_SHARED_SIZE_BYTES        // The maximum size in bytes of dynamically-allocated shared memory that can be used by this function. It must be set to launch with more than 48 KiB of dynamic shared memory.
+	PreferredSharedMemoryCarveout     FunctionAttribute = C.CU_FUNC_ATTRIBUTE_PREFERRED_SHARED_MEMORY_CARVEOUT     // The preferred percentage of the L1 cache and shared memory capacity used as shared memory.
+	ClusterSizeMustBeSet              FunctionAttribute = C.CU_FUNC_ATTRIBUTE_CLUSTER_SIZE_MUST_BE_SET             // Whether the function must be launched with a cluster size (see LaunchConfig.Cluster). Read only.
+	RequiredClusterWidth              FunctionAttribute = C.CU_FUNC_ATTRIBUTE_REQUIRED_CLUSTER_WIDTH               // The cluster width in blocks the function was compiled for, or 0. Read only once the function is loaded.
+	RequiredClusterHeight             FunctionAttribute = C.CU_FUNC_ATTRIBUTE_REQUIRED_CLUSTER_HEIGHT              // The cluster height in blocks the function was compiled for, or 0. Read only once the function is loaded.
+	RequiredClusterDepth              FunctionAttribute = C.CU_FUNC_ATTRIBUTE_REQUIRED_CLUSTER_DEPTH               // The cluster depth in blocks the function was compiled for, or 0. Read only once the function is loaded.
+	NonPortableClusterSizeAllowed     FunctionAttribute = C.CU_FUNC_ATTRIBUTE_NON_PORTABLE_CLUSTER_SIZE_ALLOWED    // Whether the function may be launched with clusters of more than 8 blocks, the portable cluster size.
+	ClusterSchedulingPolicyPreference FunctionAttribute = C.CU_FUNC_ATTRIBUTE_CLUSTER_SCHEDULING_POLICY_PREFERENCE // The block scheduling policy of the clusters of the function.
 )
 
 // PointerAttribute is a representation of the metadata of pointers
@@ -1,5 +1,6 @@
 #include <cuda.h>
 #include "batch.h"
+#include "currentctx.h"
 #include <stdint.h>
 #include <stdlib.h>
 #include <stdio.h>
@@ -42,11 +43,18 @@ CUresult cuAllocAndCopy(CUdeviceptr* mem, const void* src, size_t bytesize) {
 	return retVal;
 }
 
+// processFnHost is processFn, with the host pointer of the call (ptr0) passed on its own: Go may not store pointers to
+// Go memory in the fnargs_t it passes.
+CUresult processFnHost(fnargs_t* args, void* host){
+	args->ptr0 = (uintptr_t)host;
+	return processFn(args);
+}
+
 CUresult processFn(fnargs_t* args){
 	CUresult ret;
 	switch (args->fn) {
 	case fn_setCurrent:
-		ret =  cuCtxSetCurrent(args->ctx);
+		ret = cuCtxSetCurrentCached(args->ctx);
 		break;
 	case fn_mallocD:
 		// fprintf(stderr, "mallocD %d\n", args->size);
@@ -83,16 +91,16 @@ CUresult processFn(fnargs_t* args){
 		// fprintf(stderr,"ret %d\n", ret);
 		break;
 	case fn_memcpyDtoD:
-		abort();
+		ret = cuMemcpyDtoD(args->devPtr0, args->devPtr1, args->size);
 		break;
 	case fn_memcpyHtoDAsync:
-		abort();
+		ret = cuMemcpyHtoDAsync(args->devPtr0, (void*)(args->ptr0), args->size, args->stream);
 		break;
 	case fn_memcpyDtoHAsync:
-		abort();
+		ret = cuMemcpyDtoHAsync((void*)(args->ptr0), args->devPtr0, args->size, args->stream);
 		break;
 	case fn_memcpyDtoDAsync:
-		abort();
+		ret = cuMemcpyDtoDAsync(args->devPtr0, args->devPtr1, args->size, args->stream);
 		break;
 	case fn_launchKernel:
 		// fprintf(stderr, "launch kernel. Kernel Params: %p\n", args->kernelParams);
@@ -120,7 +128,8 @@ CUresult processFn(fnargs_t* args){
 }
 
 void process(CUcontext ctx, uintptr_t* args, CUresult* retVal, int count){
-	cuCtxSetCurrent(ctx);
+	// the context is usually current already, as the batch runs on the thread of the context
+	cuCtxSetCurrentCached(ctx);
 	// fprintf(stderr,"Processing: %d functions \n", count);
 	for (int i = 0; i < count; ++i) {
 		// // fprintf(stderr, "Processing function %d\n", i);
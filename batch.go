@@ -18,8 +18,11 @@ import "C"
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"log"
 	"runtime"
+	"sync"
+	"sync/atomic"
 	"unsafe"
 )
 
@@ -54,11 +57,15 @@ type fnargs struct {
 
 	size   C.size_t
 	stream C.CUstream // for async
+
+	// the fields below are not part of fnargs_t
+
+	kargs *kernelArgs // the buffers of kernelParams
 }
 
 func (fn *fnargs) String() string {
 	var buf bytes.Buffer
-	fmt.Fprintf(&buf, "%s. ", batchFnString[fn.fn])
+	fmt.Fprintf(&buf, "%v. ", BatchFn(fn.fn))
 	switch fn.fn {
 	case C.fn_setCurrent:
 		fmt.Fprintf(&buf, "Current Context %d", fn.ctx)
@@ -79,18 +86,18 @@ func (fn *fnargs) String() string {
 	case C.fn_memcpyDtoH:
 		fmt.Fprintf(&buf, "dest: 0x%x, src: 0x%x, size: %v", fn.ptr0, fn.devptr0, fn.size)
 	case C.fn_memcpyDtoD:
-
+		fmt.Fprintf(&buf, "dest: 0x%x, src: 0x%x, size: %v", fn.devptr0, fn.devptr1, fn.size)
 	case C.fn_memcpyHtoDAsync:
-
+		fmt.Fprintf(&buf, "dest: 0x%x, src: 0x%x, size: %v, stream: %p", fn.devptr0, fn.ptr0, fn.size, fn.stream)
 	case C.fn_memcpyDtoHAsync:
-
+		fmt.Fprintf(&buf, "dest: 0x%x, src: 0x%x, size: %v, stream: %p", fn.ptr0, fn.devptr0, fn.size, fn.stream)
 	case C.fn_memcpyDtoDAsync:
-
-	case C.fn_launchKernel:
-		fmt.Fprintf(&buf, "KernelParams: %v", fn.kernelParams)
+		fmt.Fprintf(&buf, "dest: 0x%x, src: 0x%x, size: %v, stream: %p", fn.devptr0, fn.devptr1, fn.size, fn.stream)
+	case C.fn_launchKernel, C.fn_launchAndSync:
+		fmt.Fprintf(&buf, "Function: %p, Grid: (%d, %d, %d), Block: (%d, %d, %d), SharedMem: %d, stream: %p, KernelParams: %v",
+			fn.f, fn.gridDimX, fn.gridDimY, fn.gridDimZ, fn.blockDimX, fn.blockDimY, fn.blockDimZ, fn.sharedMemBytes, fn.stream, fn.kernelParams)
 	case C.fn_sync:
 		fmt.Fprintf(&buf, "Current Context %d", fn.ctx)
-	case C.fn_launchAndSync:
 
 	case C.fn_allocAndCopy:
 		fmt.Fprintf(&buf, "Size: %v, src: %v", fn.size, fn.ptr0)
@@ -138,6 +145,9 @@ type BatchedContext struct {
 
 	workAvailable chan struct{} // an empty struct is sent down workAvailable when there is work
 	work          chan call     // queue of calls to exec
+	urgent        chan call     // queue of the calls of the urgent lane, which are processed first (see SubmitPriority)
+	lanes         [2]Stream     // the streams of the lanes
+	takenUrgent   int           // the number of urgent calls in the queue being processed
 
 	queue   []call
 	fns     []C.uintptr_t
@@ -145,26 +155,52 @@ type BatchedContext struct {
 	frees   []unsafe.Pointer
 	retVal  chan DevicePtr
 
-	// sync.Mutex
+	resultsMu  sync.Mutex // guards the results, which are written by DoWork and read by the callers
+	blockingMu sync.Mutex // serializes the blocking calls, which all wait on retVal
+
+	trace     io.Writer // if not nil, processed calls are written here
+	flushes   int
+	capturing int // the number of streams capturing (see BeginCapture), while which the calls are not synchronized
+
+	seqMu    sync.Mutex // serializes the counting and queueing of the calls, so that their order is that of their count
+	enqueued [2]uint64  // the number of calls queued in each lane, which are read atomically
+
+	flushMu   sync.Mutex // guards the fields below
+	flushed   *sync.Cond // broadcast when a queue has been processed
+	settled   [2]uint64  // the number of calls processed in each lane
+	lastFlush int        // the number of the last flush
+	tickets   []*Ticket  // the tickets waiting for their calls to be processed
+	highWater int        // the number of pending calls above which Submit waits
 
 	initialized bool
 }
 
-// NewBatchedContext creates a batched CUDA context.
-func NewBatchedContext(c Context, d Device) *BatchedContext {
-	return &BatchedContext{
+// NewBatchedContext creates a batched CUDA context. It uses the WithBatchSize, WithTrace, WithHighWater and WithLanes
+// options.
+func NewBatchedContext(c Context, d Device, opts ...Option) *BatchedContext {
+	o := makeOptions(opts)
+	if o.highWater <= 0 {
+		o.highWater = 4 * o.batchSize
+	}
+	ctx := &BatchedContext{
 		Context: c,
 		Device:  d,
 
 		workAvailable: make(chan struct{}, 1),
-		work:          make(chan call, workBufLen),
-		queue:         make([]call, 0, workBufLen),
-		fns:           make([]C.uintptr_t, 0, workBufLen),
-		results:       make([]C.CUresult, workBufLen),
-		frees:         make([]unsafe.Pointer, 0, 2*workBufLen),
+		work:          make(chan call, o.batchSize),
+		urgent:        make(chan call, o.batchSize),
+		lanes:         o.lanes,
+		queue:         make([]call, 0, o.batchSize),
+		fns:           make([]C.uintptr_t, 0, o.batchSize),
+		results:       make([]C.CUresult, o.batchSize),
+		frees:         make([]unsafe.Pointer, 0, 2*o.batchSize),
 		retVal:        make(chan DevicePtr),
+		trace:         o.trace,
+		highWater:     o.highWater,
 		initialized:   true,
 	}
+	ctx.flushed = sync.NewCond(&ctx.flushMu)
+	return ctx
 }
 
 func (ctx *BatchedContext) IsInitialized() bool { return ctx.initialized }
@@ -172,11 +208,27 @@ func (ctx *BatchedContext) IsInitialized() bool { return ctx.initialized }
 // enqueue puts a CUDA call into the queue (which is the `work` channel).
 //
 // Here a difference between this package and package `gl` exists.
-func (ctx *BatchedContext) enqueue(c call) (retVal DevicePtr, err error) {
-	if len(ctx.work) >= workBufLen-1 {
+func (ctx *BatchedContext) enqueue(c call) (retVal DevicePtr, err error) { return ctx.enqueueTo(Bulk, c) }
+
+// enqueueTo puts a CUDA call into the queue of the lane.
+func (ctx *BatchedContext) enqueueTo(lane Priority, c call) (retVal DevicePtr, err error) {
+	q := ctx.work
+	if lane == Urgent {
+		q = ctx.urgent
+	}
+	if c.blocking {
+		// the blocking calls all wait on retVal: they are queued one at a time, and the next is only queued once the
+		// return value of the previous one is received, so that each caller receives its own
+		ctx.blockingMu.Lock()
+		defer ctx.blockingMu.Unlock()
+	}
+	ctx.seqMu.Lock()
+	if len(q) >= cap(q)-1 {
 		ctx.workAvailable <- struct{}{}
 	}
-	ctx.work <- c
+	q <- c
+	atomic.AddUint64(&ctx.enqueued[lane], 1)
+	ctx.seqMu.Unlock()
 
 	// where in package `gl` a signal is opportunistically
 	// sent to the `workAvailable` channel, here it isn't. This is because
@@ -201,6 +253,7 @@ func (ctx *BatchedContext) WorkAvailable() <-chan struct{} { return ctx.workAvai
 // Otherwise it will be added to the batch queue.
 func (ctx *BatchedContext) DoWork() {
 	for {
+		ctx.takeUrgent()
 		select {
 		case w := <-ctx.work:
 			ctx.queue = append(ctx.queue, w)
@@ -229,18 +282,48 @@ func (ctx *BatchedContext) DoWork() {
 
 		// debug and instrumentation related stuff
 		logCaller("DoWork()")
-		logf(ctx.introspect())
+		if DEBUG {
+			logf(ctx.introspect())
+		}
 		addQueueLength(len(ctx.queue))
 		addBlockingCallers()
 
 		cctx := ctx.CUDAContext().ctx
-		ctx.results = ctx.results[:cap(ctx.results)]                         // make sure of the maximum availability for ctx.results
-		C.process(cctx, &ctx.fns[0], &ctx.results[0], C.int(len(ctx.queue))) // process the queue
-		ctx.results = ctx.results[:len(ctx.queue)]                           // then  truncate it to the len of queue for reporting purposes
+		ctx.resultsMu.Lock()
+		ctx.results = ctx.results[:cap(ctx.results)] // make sure of the maximum availability for ctx.results
+		if forceSync() && ctx.capturing == 0 {
+			// process the calls one at a time, so that each asynchronous failure is reported by the call that caused it
+			for i := range ctx.queue {
+				C.process(cctx, &ctx.fns[i], &ctx.results[i], 1)
+				if ctx.results[i] == C.CUDA_SUCCESS {
+					ctx.results[i] = C.cuCtxSynchronize()
+				}
+			}
+		} else {
+			C.process(cctx, &ctx.fns[0], &ctx.results[0], C.int(len(ctx.queue))) // process the queue
+		}
+		ctx.results = ctx.results[:len(ctx.queue)] // then  truncate it to the len of queue for reporting purposes
 
+		ctx.flushes++
+		if ctx.trace != nil {
+			ctx.writeTrace()
+		}
+		var batchErr error
+		var calls []BatchCall
 		if ctx.checkResults() {
-			log.Printf("Errors found %v", ctx.checkResults())
-			log.Printf("Errors: \n%v", ctx.errors())
+			batchErr, calls = ctx.resultErrors(), ctx.processed()
+		}
+		ctx.resultsMu.Unlock()
+		ctx.settle(ctx.takenUrgent, len(ctx.queue)-ctx.takenUrgent, batchErr)
+		ctx.takenUrgent = 0
+
+		if batchErr != nil {
+			recordError(batchErr, 0)
+			if r, ok := ctx.Context.(asyncReporter); ok {
+				r.reportAsync(&AsyncError{Err: batchErr, Batch: ctx.flushes, Calls: calls})
+			}
+			log.Printf("Errors found %v", batchErr != nil)
+			log.Printf("Errors: \n%v", batchErr)
 			log.Printf(ctx.introspect())
 		}
 
@@ -262,7 +345,11 @@ func (ctx *BatchedContext) DoWork() {
 			logf("\t[RET] %v", DevicePtr(retVal.devptr0))
 		}
 
-		// clear queue
+		// clear queue, and return the fnargs to the pool
+		for i, c := range ctx.queue {
+			c.fnargs.release()
+			ctx.queue[i] = call{}
+		}
 		ctx.queue = ctx.queue[:0]
 		ctx.fns = ctx.fns[:0]
 	}
@@ -283,7 +370,11 @@ func (ctx *BatchedContext) Run(errChan chan error) error {
 				errChan <- err
 
 			}
-		case w := <-ctx.Work():
+		case w, ok := <-ctx.Work():
+			if !ok {
+				// the context has been closed
+				return nil
+			}
 			ctx.ErrChan() <- w()
 		}
 	}
@@ -312,6 +403,8 @@ func (ctx *BatchedContext) Errors() error { return ctx.errors() }
 
 // FirstError returns the first error if there was any
 func (ctx *BatchedContext) FirstError() error {
+	ctx.resultsMu.Lock()
+	defer ctx.resultsMu.Unlock()
 	for i, v := range ctx.results {
 		if cuResult(v) != Success {
 			return result(v)
@@ -323,7 +416,8 @@ func (ctx *BatchedContext) FirstError() error {
 
 // SetCurrent sets the current context. This is usually unnecessary because SetCurrent will be called before batch processing the calls.
 func (ctx *BatchedContext) SetCurrent() {
-	fn := &fnargs{
+	fn := getFnargs()
+	*fn = fnargs{
 		fn:  C.fn_setCurrent,
 		ctx: ctx.CUDAContext().ctx,
 	}
@@ -333,16 +427,21 @@ func (ctx *BatchedContext) SetCurrent() {
 
 // MemAlloc allocates memory. It is a blocking call.
 func (ctx *BatchedContext) MemAlloc(bytesize int64) (retVal DevicePtr, err error) {
-	fn := &fnargs{
+	fn := getFnargs()
+	*fn = fnargs{
 		fn:   C.fn_mallocD,
 		size: C.size_t(bytesize),
 	}
 	c := call{fn, true}
-	return ctx.enqueue(c)
+	if retVal, err = ctx.enqueue(c); err == nil {
+		trackAlloc(retVal, bytesize)
+	}
+	return
 }
 
 func (ctx *BatchedContext) MemAllocManaged(bytesize int64, flags MemAttachFlags) (retVal DevicePtr, err error) {
-	fn := &fnargs{
+	fn := getFnargs()
+	*fn = fnargs{
 		fn:   C.fn_mallocManaged,
 		size: C.size_t(bytesize),
 	}
@@ -351,7 +450,8 @@ func (ctx *BatchedContext) MemAllocManaged(bytesize int64, flags MemAttachFlags)
 }
 
 func (ctx *BatchedContext) Memcpy(dst, src DevicePtr, byteCount int64) {
-	fn := &fnargs{
+	fn := getFnargs()
+	*fn = fnargs{
 		fn:      C.fn_memcpy,
 		devptr0: C.CUdeviceptr(dst),
 		devptr1: C.CUdeviceptr(src),
@@ -359,10 +459,12 @@ func (ctx *BatchedContext) Memcpy(dst, src DevicePtr, byteCount int64) {
 	}
 	c := call{fn, false}
 	ctx.enqueue(c)
+	countCopy(Unified, NoStream, byteCount)
 }
 
 func (ctx *BatchedContext) MemcpyHtoD(dst DevicePtr, src unsafe.Pointer, byteCount int64) {
-	fn := &fnargs{
+	fn := getFnargs()
+	*fn = fnargs{
 		fn:      C.fn_memcpyHtoD,
 		devptr0: C.CUdeviceptr(dst),
 		ptr0:    src,
@@ -370,10 +472,12 @@ func (ctx *BatchedContext) MemcpyHtoD(dst DevicePtr, src unsafe.Pointer, byteCou
 	}
 	c := call{fn, false}
 	ctx.enqueue(c)
+	countCopy(HtoD, NoStream, byteCount)
 }
 
 func (ctx *BatchedContext) MemcpyDtoH(dst unsafe.Pointer, src DevicePtr, byteCount int64) {
-	fn := &fnargs{
+	fn := getFnargs()
+	*fn = fnargs{
 		fn:      C.fn_memcpyDtoH,
 		devptr0: C.CUdeviceptr(src),
 		ptr0:    dst,
@@ -381,10 +485,13 @@ func (ctx *BatchedContext) MemcpyDtoH(dst unsafe.Pointer, src DevicePtr, byteCou
 	}
 	c := call{fn, false}
 	ctx.enqueue(c)
+	countCopy(DtoH, NoStream, byteCount)
 }
 
 func (ctx *BatchedContext) MemFree(mem DevicePtr) {
-	fn := &fnargs{
+	untrackAlloc(mem) // before the address can be reused
+	fn := getFnargs()
+	*fn = fnargs{
 		fn:      C.fn_memfreeD,
 		devptr0: C.CUdeviceptr(mem),
 	}
@@ -393,7 +500,8 @@ func (ctx *BatchedContext) MemFree(mem DevicePtr) {
 }
 
 func (ctx *BatchedContext) MemFreeHost(p unsafe.Pointer) {
-	fn := &fnargs{
+	fn := getFnargs()
+	*fn = fnargs{
 		fn:   C.fn_memfreeH,
 		ptr0: p,
 	}
@@ -402,17 +510,9 @@ func (ctx *BatchedContext) MemFreeHost(p unsafe.Pointer) {
 }
 
 func (ctx *BatchedContext) LaunchKernel(function Function, gridDimX, gridDimY, gridDimZ int, blockDimX, blockDimY, blockDimZ int, sharedMemBytes int, stream Stream, kernelParams []unsafe.Pointer) {
-	argv := C.malloc(C.size_t(len(kernelParams) * pointerSize))
-	argp := C.malloc(C.size_t(len(kernelParams) * pointerSize))
-	for i := range kernelParams {
-		*((*unsafe.Pointer)(offset(argp, i))) = offset(argv, i)       // argp[i] = &argv[i]
-		*((*uint64)(offset(argv, i))) = *((*uint64)(kernelParams[i])) // argv[i] = *kernelParams[i]
-	}
-
-	// ctx.frees = append(ctx.frees, argv)
-	// ctx.frees = append(ctx.frees, argp)
-
-	fn := &fnargs{
+	kargs := getKernelArgs(kernelParams)
+	fn := getFnargs()
+	*fn = fnargs{
 		fn:             C.fn_launchKernel,
 		f:              function.fn,
 		gridDimX:       C.uint(gridDimX),
@@ -423,15 +523,17 @@ func (ctx *BatchedContext) LaunchKernel(function Function, gridDimX, gridDimY, g
 		blockDimZ:      C.uint(blockDimZ),
 		sharedMemBytes: C.uint(sharedMemBytes),
 		stream:         stream.c(),
-		kernelParams:   (*unsafe.Pointer)(argp),
+		kernelParams:   kargs.params(),
 		extra:          (*unsafe.Pointer)(nil),
+		kargs:          kargs,
 	}
 	c := call{fn, false}
 	ctx.enqueue(c)
 }
 
 func (ctx *BatchedContext) Synchronize() {
-	fn := &fnargs{
+	fn := getFnargs()
+	*fn = fnargs{
 		fn: C.fn_sync,
 	}
 	c := call{fn, false}
@@ -444,7 +546,8 @@ func (ctx *BatchedContext) LaunchAndSync(function Function, gridDimX, gridDimY,
 }
 
 func (ctx *BatchedContext) AllocAndCopy(p unsafe.Pointer, bytesize int64) (retVal DevicePtr, err error) {
-	fn := &fnargs{
+	fn := getFnargs()
+	*fn = fnargs{
 		fn:   C.fn_allocAndCopy,
 		size: C.size_t(bytesize),
 		ptr0: p,
@@ -468,6 +571,13 @@ func (ctx *BatchedContext) checkResults() bool {
 
 // errors convert ctx.results into errors
 func (ctx *BatchedContext) errors() error {
+	ctx.resultsMu.Lock()
+	defer ctx.resultsMu.Unlock()
+	return ctx.resultErrors()
+}
+
+// resultErrors converts ctx.results into errors. The lock is expected to be held.
+func (ctx *BatchedContext) resultErrors() error {
 	if !ctx.checkResults() {
 		return nil
 	}
@@ -487,24 +597,3 @@ func (ctx *BatchedContext) introspect() string {
 	}
 	return buf.String()
 }
-
-var batchFnString = map[C.batchFn]string{
-	C.fn_setCurrent:      "setCurrent",
-	C.fn_mallocD:         "mallocD",
-	C.fn_mallocH:         "mallocH",
-	C.fn_mallocManaged:   "mallocManaged",
-	C.fn_memfreeD:        "memfreeD",
-	C.fn_memfreeH:        "memfreeH",
-	C.fn_memcpy:          "memcpy",
-	C.fn_memcpyHtoD:      "memcpyHtoD",
-	C.fn_memcpyDtoH:      "memcpyDtoH",
-	C.fn_memcpyDtoD:      "memcpyDtoD",
-	C.fn_memcpyHtoDAsync: "memcpyHtoDAsync",
-	C.fn_memcpyDtoHAsync: "memcpyDtoHAsync",
-	C.fn_memcpyDtoDAsync: "memcpyDtoDAsync",
-	C.fn_launchKernel:    "launchKernel",
-	C.fn_sync:            "sync",
-	C.fn_launchAndSync:   "lauchAndSync",
-
-	C.fn_allocAndCopy: "allocAndCopy",
-}
@@ -65,6 +65,7 @@ typedef struct fnargs {
 } fnargs_t;
 
 extern CUresult processFn(fnargs_t* args);
+extern CUresult processFnHost(fnargs_t* args, void* host);
 extern void process(CUcontext ctx, uintptr_t* args, CUresult* retVal, int count);
 // extern void process(uintptr_t* args, CUresult* retVal, int count);
 // extern CUresult batchMalloc(uintptr_t* args, CUdeviceptr* ptrs, int count);
\ No newline at end of file
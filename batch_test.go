@@ -1,6 +1,7 @@
 package cu
 
 import (
+	"bytes"
 	"log"
 	"runtime"
 	"testing"
@@ -366,3 +367,87 @@ func BenchmarkBatching(bench *testing.B) {
 	mod.Unload()
 	cuctx.Destroy()
 }
+
+func TestBatchFn_String(t *testing.T) {
+	if s := BatchMemcpyHtoD.String(); s != "memcpyHtoD" {
+		t.Errorf("Expected memcpyHtoD. Got %q", s)
+	}
+	if s := (BatchCall{Fn: BatchMallocD, Blocking: true}).String(); s != "mallocD (blocking)" {
+		t.Errorf("Expected \"mallocD (blocking)\". Got %q", s)
+	}
+	if s := BatchFn(-1).String(); s != "UnknownBatchFn:-1" {
+		t.Errorf("Expected UnknownBatchFn:-1. Got %q", s)
+	}
+}
+
+func TestNewBatchedContext_Options(t *testing.T) {
+	var buf bytes.Buffer
+	bctx := NewBatchedContext(nil, 0, WithBatchSize(16), WithTrace(&buf))
+	if cap(bctx.work) != 16 || cap(bctx.queue) != 16 || len(bctx.results) != 16 {
+		t.Errorf("Expected a batch of 16 calls. Got %d", cap(bctx.work))
+	}
+	if bctx.trace != &buf {
+		t.Error("Expected the trace writer to be set")
+	}
+	if bctx = NewBatchedContext(nil, 0, WithBatchSize(0)); cap(bctx.work) != workBufLen {
+		t.Errorf("Expected the default batch size. Got %d", cap(bctx.work))
+	}
+}
+
+func TestBatchedContext_TrySubmit(t *testing.T) {
+	bctx := NewBatchedContext(nil, 0, WithHighWater(2))
+	tk, err := bctx.TrySubmit(func() {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-tk.Done():
+	default:
+		t.Error("Expected a ticket without calls to be settled")
+	}
+
+	work := func() {
+		bctx.MemFree(0)
+		bctx.MemFree(0)
+		bctx.MemFree(0)
+	}
+	if tk, err = bctx.TrySubmit(work); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = bctx.TrySubmit(work); err != ErrQueueFull {
+		t.Errorf("Expected ErrQueueFull. Got %v", err)
+	}
+
+	bctx.settle(0, 3, nil)
+	if flush, err := tk.Wait(); flush != 0 || err != nil {
+		t.Errorf("Expected the ticket to be settled without errors. Got %d, %v", flush, err)
+	}
+	if _, err = bctx.TrySubmit(work); err != nil {
+		t.Errorf("Expected the queue to have room once processed. Got %v", err)
+	}
+}
+
+func TestBatchedContext_Lanes(t *testing.T) {
+	bctx := NewBatchedContext(nil, 0, WithBatchSize(8))
+	tk := bctx.SubmitPriority(Urgent, func(l *Lane) {
+		for i := 0; i < 7; i++ {
+			l.MemcpyDtoD(0, 0, 0)
+		}
+	})
+	bctx.MemFree(0)
+
+	bctx.takeUrgent()
+	if len(bctx.queue) != 6 || bctx.takenUrgent != 6 {
+		t.Errorf("Expected a quarter of the queue to be left to the bulk calls. Got %d urgent calls", bctx.takenUrgent)
+	}
+	bctx.settle(6, 0, nil)
+	select {
+	case <-tk.Done():
+		t.Error("Expected the ticket to wait for the last urgent call")
+	default:
+	}
+	bctx.settle(1, 1, nil)
+	if _, err := tk.Wait(); err != nil {
+		t.Error(err)
+	}
+}
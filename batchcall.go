@@ -0,0 +1,155 @@
+package cu
+
+// #include <cuda.h>
+// #include "batch.h"
+import "C"
+import (
+	"fmt"
+	"io"
+	"unsafe"
+)
+
+// BatchFn enumerates the CUDA calls that can be batched by a BatchedContext.
+type BatchFn int
+
+const (
+	BatchSetCurrent      BatchFn = C.fn_setCurrent
+	BatchMallocD         BatchFn = C.fn_mallocD
+	BatchMallocH         BatchFn = C.fn_mallocH
+	BatchMallocManaged   BatchFn = C.fn_mallocManaged
+	BatchMemfreeD        BatchFn = C.fn_memfreeD
+	BatchMemfreeH        BatchFn = C.fn_memfreeH
+	BatchMemcpy          BatchFn = C.fn_memcpy
+	BatchMemcpyHtoD      BatchFn = C.fn_memcpyHtoD
+	BatchMemcpyDtoH      BatchFn = C.fn_memcpyDtoH
+	BatchMemcpyDtoD      BatchFn = C.fn_memcpyDtoD
+	BatchMemcpyHtoDAsync BatchFn = C.fn_memcpyHtoDAsync
+	BatchMemcpyDtoHAsync BatchFn = C.fn_memcpyDtoHAsync
+	BatchMemcpyDtoDAsync BatchFn = C.fn_memcpyDtoDAsync
+	BatchLaunchKernel    BatchFn = C.fn_launchKernel
+	BatchSync            BatchFn = C.fn_sync
+	BatchLaunchAndSync   BatchFn = C.fn_launchAndSync
+	BatchAllocAndCopy    BatchFn = C.fn_allocAndCopy
+)
+
+var batchFnString = map[BatchFn]string{
+	BatchSetCurrent:      "setCurrent",
+	BatchMallocD:         "mallocD",
+	BatchMallocH:         "mallocH",
+	BatchMallocManaged:   "mallocManaged",
+	BatchMemfreeD:        "memfreeD",
+	BatchMemfreeH:        "memfreeH",
+	BatchMemcpy:          "memcpy",
+	BatchMemcpyHtoD:      "memcpyHtoD",
+	BatchMemcpyDtoH:      "memcpyDtoH",
+	BatchMemcpyDtoD:      "memcpyDtoD",
+	BatchMemcpyHtoDAsync: "memcpyHtoDAsync",
+	BatchMemcpyDtoHAsync: "memcpyDtoHAsync",
+	BatchMemcpyDtoDAsync: "memcpyDtoDAsync",
+	BatchLaunchKernel:    "launchKernel",
+	BatchSync:            "sync",
+	BatchLaunchAndSync:   "launchAndSync",
+	BatchAllocAndCopy:    "allocAndCopy",
+}
+
+func (fn BatchFn) String() string {
+	if s, ok := batchFnString[fn]; ok {
+		return s
+	}
+	return fmt.Sprintf("UnknownBatchFn:%d", int(fn))
+}
+
+// BatchCall is an introspectable representation of a call that has been queued in a BatchedContext.
+// Fields that are not used by the call are left as zero values.
+type BatchCall struct {
+	Fn       BatchFn
+	Blocking bool
+
+	DevPtr0 DevicePtr
+	DevPtr1 DevicePtr
+	Host    unsafe.Pointer
+	Size    int64
+
+	Function    Function
+	Grid, Block [3]int
+	SharedMem   int
+	Stream      Stream
+
+	// Result is the result of the call. It is only filled in for calls that have been processed.
+	Result error
+
+	args *fnargs
+}
+
+func makeBatchCall(c call) BatchCall {
+	// the fnargs are reused once the call has been processed, so the BatchCall keeps a copy
+	fn := new(fnargs)
+	*fn = *c.fnargs
+	fn.kargs = nil
+	return BatchCall{
+		Fn:       BatchFn(fn.fn),
+		Blocking: c.blocking,
+
+		DevPtr0: DevicePtr(fn.devptr0),
+		DevPtr1: DevicePtr(fn.devptr1),
+		Host:    fn.ptr0,
+		Size:    int64(fn.size),
+
+		Function:  Function{fn.f},
+		Grid:      [3]int{int(fn.gridDimX), int(fn.gridDimY), int(fn.gridDimZ)},
+		Block:     [3]int{int(fn.blockDimX), int(fn.blockDimY), int(fn.blockDimZ)},
+		SharedMem: int(fn.sharedMemBytes),
+		Stream:    Stream{fn.stream},
+
+		args: fn,
+	}
+}
+
+func (c BatchCall) String() string {
+	var blocking string
+	if c.Blocking {
+		blocking = " (blocking)"
+	}
+	if c.args == nil {
+		return c.Fn.String() + blocking
+	}
+	return c.args.String() + blocking
+}
+
+// Trace turns on tracing of the batched calls. Every time the queue is processed, the calls are written to w,
+// in the order they were made, along with their arguments and results. Passing in a nil io.Writer turns tracing off.
+//
+// Trace should be called before the BatchedContext starts doing work.
+func (ctx *BatchedContext) Trace(w io.Writer) { ctx.trace = w }
+
+// Queued returns the calls that are in the queue to be processed.
+func (ctx *BatchedContext) Queued() []BatchCall {
+	retVal := make([]BatchCall, 0, len(ctx.queue))
+	for _, c := range ctx.queue {
+		retVal = append(retVal, makeBatchCall(c))
+	}
+	return retVal
+}
+
+// processed returns the calls of the processed queue, along with their results.
+func (ctx *BatchedContext) processed() []BatchCall {
+	calls := ctx.Queued()
+	for i := range calls {
+		if i < len(ctx.results) {
+			calls[i].Result = result(ctx.results[i])
+		}
+	}
+	return calls
+}
+
+// writeTrace writes the processed queue to the trace writer.
+func (ctx *BatchedContext) writeTrace() {
+	fmt.Fprintf(ctx.trace, "Flush %d: %d calls\n", ctx.flushes, len(ctx.queue))
+	for i, c := range ctx.processed() {
+		if c.Result != nil {
+			fmt.Fprintf(ctx.trace, "\t%d: %v -> %v\n", i, c, c.Result)
+			continue
+		}
+		fmt.Fprintf(ctx.trace, "\t%d: %v\n", i, c)
+	}
+}
@@ -35,14 +35,8 @@ func (dev Device) Attributes(attrs ...DeviceAttribute) ([]int, error) {
 
 // LaunchAndSync launches the kernel and synchronizes the context
 func (fn Function) LaunchAndSync(gridDimX, gridDimY, gridDimZ, blockDimX, blockDimY, blockDimZ, sharedMemBytes int, stream Stream, kernelParams []unsafe.Pointer) error {
-	argv := C.malloc(C.size_t(len(kernelParams) * pointerSize))
-	argp := C.malloc(C.size_t(len(kernelParams) * pointerSize))
-	defer C.free(argv)
-	defer C.free(argp)
-	for i := range kernelParams {
-		*((*unsafe.Pointer)(offset(argp, i))) = offset(argv, i)       // argp[i] = &argv[i]
-		*((*uint64)(offset(argv, i))) = *((*uint64)(kernelParams[i])) // argv[i] = *kernelParams[i]
-	}
+	args := getKernelArgs(kernelParams)
+	defer args.put()
 
 	err := result(C.cuLaunchAndSync(
 		fn.fn,
@@ -54,7 +48,7 @@ func (fn Function) LaunchAndSync(gridDimX, gridDimY, gridDimZ, blockDimX, blockD
 		C.uint(blockDimZ),
 		C.uint(sharedMemBytes),
 		stream.c(),
-		(*unsafe.Pointer)(argp),
+		args.params(),
 		(*unsafe.Pointer)(nil)))
 	return err
 }
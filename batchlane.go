@@ -0,0 +1,141 @@
+package cu
+
+// #include <cuda.h>
+// #include "batch.h"
+import "C"
+import "unsafe"
+
+// Priority is the lane of the work submitted to a BatchedContext.
+type Priority int
+
+const (
+	// Bulk is the lane of the calls made directly on the BatchedContext, and of throughput work.
+	Bulk Priority = iota
+
+	// Urgent is the lane of latency sensitive work, such as inference requests. Its calls are processed ahead of the
+	// bulk calls queued before them, and are enqueued on the urgent stream (see WithLanes). So that a steady stream of
+	// urgent work does not starve the bulk work, a quarter of every flush is left to the bulk calls while there are any.
+	Urgent
+)
+
+func (p Priority) String() string {
+	if p == Urgent {
+		return "Urgent"
+	}
+	return "Bulk"
+}
+
+// Lane queues the asynchronous calls of work submitted with a priority. The copies and launches are enqueued on the
+// stream of the lane.
+type Lane struct {
+	ctx  *BatchedContext
+	prio Priority
+}
+
+// Priority returns the priority of the lane.
+func (l *Lane) Priority() Priority { return l.prio }
+
+// Stream returns the stream the calls of the lane are enqueued on.
+func (l *Lane) Stream() Stream { return l.ctx.lanes[l.prio] }
+
+// SubmitPriority is like Submit, but the calls of work are queued in the lane of the priority. The urgent calls may be
+// processed before bulk calls queued earlier, so urgent work must not depend on pending bulk work, unless it waits for
+// its ticket first. The high-water mark applies to each lane separately.
+func (ctx *BatchedContext) SubmitPriority(p Priority, work func(l *Lane)) *Ticket {
+	ctx.wait(p)
+	l := &Lane{ctx: ctx, prio: p}
+	t := ctx.submit(p, func() { work(l) })
+	if p == Urgent {
+		// urgent work does not wait for the queue to fill up
+		select {
+		case ctx.workAvailable <- struct{}{}:
+		default:
+		}
+	}
+	return t
+}
+
+// MemcpyHtoD copies from the host to the device, asynchronously. The host memory must stay valid, and be page-locked
+// for the copy to be truly asynchronous, until the ticket of the work is settled.
+func (l *Lane) MemcpyHtoD(dst DevicePtr, src unsafe.Pointer, byteCount int64) {
+	fn := getFnargs()
+	*fn = fnargs{
+		fn:      C.fn_memcpyHtoDAsync,
+		devptr0: C.CUdeviceptr(dst),
+		ptr0:    src,
+		size:    C.size_t(byteCount),
+		stream:  l.Stream().c(),
+	}
+	l.ctx.enqueueTo(l.prio, call{fn, false})
+	countCopy(HtoD, l.Stream(), byteCount)
+}
+
+// MemcpyDtoH copies from the device to the host, asynchronously. The copy is complete once the ticket of the work is
+// settled, and the stream of the lane synchronized.
+func (l *Lane) MemcpyDtoH(dst unsafe.Pointer, src DevicePtr, byteCount int64) {
+	fn := getFnargs()
+	*fn = fnargs{
+		fn:      C.fn_memcpyDtoHAsync,
+		devptr0: C.CUdeviceptr(src),
+		ptr0:    dst,
+		size:    C.size_t(byteCount),
+		stream:  l.Stream().c(),
+	}
+	l.ctx.enqueueTo(l.prio, call{fn, false})
+	countCopy(DtoH, l.Stream(), byteCount)
+}
+
+// MemcpyDtoD copies device memory, asynchronously.
+func (l *Lane) MemcpyDtoD(dst, src DevicePtr, byteCount int64) {
+	fn := getFnargs()
+	*fn = fnargs{
+		fn:      C.fn_memcpyDtoDAsync,
+		devptr0: C.CUdeviceptr(dst),
+		devptr1: C.CUdeviceptr(src),
+		size:    C.size_t(byteCount),
+		stream:  l.Stream().c(),
+	}
+	l.ctx.enqueueTo(l.prio, call{fn, false})
+	countCopy(DtoD, l.Stream(), byteCount)
+}
+
+// LaunchKernel launches the kernel on the stream of the lane.
+func (l *Lane) LaunchKernel(function Function, gridDimX, gridDimY, gridDimZ int, blockDimX, blockDimY, blockDimZ int, sharedMemBytes int, kernelParams []unsafe.Pointer) {
+	kargs := getKernelArgs(kernelParams)
+	fn := getFnargs()
+	*fn = fnargs{
+		fn:             C.fn_launchKernel,
+		f:              function.fn,
+		gridDimX:       C.uint(gridDimX),
+		gridDimY:       C.uint(gridDimY),
+		gridDimZ:       C.uint(gridDimZ),
+		blockDimX:      C.uint(blockDimX),
+		blockDimY:      C.uint(blockDimY),
+		blockDimZ:      C.uint(blockDimZ),
+		sharedMemBytes: C.uint(sharedMemBytes),
+		stream:         l.Stream().c(),
+		kernelParams:   kargs.params(),
+		kargs:          kargs,
+	}
+	l.ctx.enqueueTo(l.prio, call{fn, false})
+}
+
+// takeUrgent moves the urgent calls to the queue, ahead of the bulk ones. While bulk calls are pending, a quarter of
+// the queue is left to them; a slot is always left for the bulk call DoWork takes next.
+func (ctx *BatchedContext) takeUrgent() {
+	limit := cap(ctx.queue) - 1
+	if len(ctx.work) > 0 {
+		if reserve := cap(ctx.queue) / 4; reserve > 1 {
+			limit = cap(ctx.queue) - reserve
+		}
+	}
+	for len(ctx.queue) < limit {
+		select {
+		case c := <-ctx.urgent:
+			ctx.queue = append(ctx.queue, c)
+			ctx.takenUrgent++
+		default:
+			return
+		}
+	}
+}
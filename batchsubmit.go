@@ -0,0 +1,120 @@
+package cu
+
+import (
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+// ErrQueueFull is returned by TrySubmit when the BatchedContext has more calls pending than its high-water mark
+// (see WithHighWater).
+var ErrQueueFull = errors.New("The queue of the BatchedContext is full")
+
+// Ticket is the receipt of work submitted to a BatchedContext. It is settled once the calls queued by the work have
+// been processed.
+type Ticket struct {
+	ctx  *BatchedContext
+	lane Priority
+	seq  uint64 // the count of the last call of the work in its lane
+	done chan struct{}
+
+	flush int
+	err   error
+}
+
+// Done returns a channel that is closed once the ticket is settled.
+func (t *Ticket) Done() <-chan struct{} { return t.done }
+
+// Wait waits for the calls of the work to be processed, and returns the number of the flush that processed the last of
+// them (see BatchCall), along with the errors of that flush, if any. Like a blocking call, it has the queue processed
+// without waiting for it to fill up.
+func (t *Ticket) Wait() (flush int, err error) {
+	select {
+	case <-t.done:
+	default:
+		select {
+		case t.ctx.workAvailable <- struct{}{}:
+		default:
+		}
+		<-t.done
+	}
+	return t.flush, t.err
+}
+
+// Submit runs work, which queues calls on the BatchedContext, and returns a Ticket to wait for them with. This lets
+// producers run ahead of the GPU without blocking on every call, but not unboundedly: when more calls are pending than
+// the high-water mark (see WithHighWater), Submit waits for the queue to be processed before running work.
+//
+// The calls of other goroutines, queued while work runs, are waited for as well. Submit must not be called from the
+// thread that processes the queue.
+func (ctx *BatchedContext) Submit(work func()) *Ticket {
+	ctx.wait(Bulk)
+	return ctx.submit(Bulk, work)
+}
+
+// TrySubmit is like Submit, but it returns ErrQueueFull instead of waiting when the high-water mark is exceeded.
+func (ctx *BatchedContext) TrySubmit(work func()) (*Ticket, error) {
+	ctx.flushMu.Lock()
+	full := ctx.pending(Bulk) > ctx.highWater
+	ctx.flushMu.Unlock()
+	if full {
+		return nil, ErrQueueFull
+	}
+	return ctx.submit(Bulk, work), nil
+}
+
+// wait waits for the calls pending in the lane to be under the high-water mark.
+func (ctx *BatchedContext) wait(lane Priority) {
+	ctx.flushMu.Lock()
+	for ctx.pending(lane) > ctx.highWater {
+		select {
+		case ctx.workAvailable <- struct{}{}:
+		default:
+		}
+		ctx.flushed.Wait()
+	}
+	ctx.flushMu.Unlock()
+}
+
+func (ctx *BatchedContext) submit(lane Priority, work func()) *Ticket {
+	work()
+	t := &Ticket{ctx: ctx, lane: lane, seq: atomic.LoadUint64(&ctx.enqueued[lane]), done: make(chan struct{})}
+
+	ctx.flushMu.Lock()
+	defer ctx.flushMu.Unlock()
+	if ctx.settled[lane] >= t.seq {
+		t.flush = ctx.lastFlush
+		close(t.done)
+		return t
+	}
+	ctx.tickets = append(ctx.tickets, t)
+	return t
+}
+
+// pending returns the number of calls queued in the lane and not yet processed. The flush lock is expected to be held.
+func (ctx *BatchedContext) pending(lane Priority) int {
+	return int(atomic.LoadUint64(&ctx.enqueued[lane]) - ctx.settled[lane])
+}
+
+// settle counts the calls of a processed queue, and settles the tickets whose calls have all been processed.
+func (ctx *BatchedContext) settle(urgent, bulk int, err error) {
+	ctx.flushMu.Lock()
+	defer ctx.flushMu.Unlock()
+	ctx.settled[Urgent] += uint64(urgent)
+	ctx.settled[Bulk] += uint64(bulk)
+	ctx.lastFlush = ctx.flushes
+	waiting := ctx.tickets[:0]
+	for _, t := range ctx.tickets {
+		if t.seq > ctx.settled[t.lane] {
+			waiting = append(waiting, t)
+			continue
+		}
+		t.flush, t.err = ctx.flushes, err
+		close(t.done)
+	}
+	for i := len(waiting); i < len(ctx.tickets); i++ {
+		ctx.tickets[i] = nil
+	}
+	ctx.tickets = waiting
+	ctx.flushed.Broadcast()
+}
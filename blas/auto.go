@@ -0,0 +1,26 @@
+package cublas
+
+import (
+	"gorgonia.org/cu"
+	"gorgonia.org/cu/blas/fallback"
+)
+
+var _ fallback.Interface = &Standard{}
+
+// NewAuto creates a cuBLAS implementation if there is a GPU present. Otherwise it returns the CPU implementation from package fallback.
+//
+// The options are only applied to the cuBLAS implementation.
+func NewAuto(opts ...ConsOpt) fallback.Interface {
+	if !hasGPU() {
+		return fallback.New()
+	}
+	return New(opts...)
+}
+
+func hasGPU() bool {
+	if cu.InitErr() != nil {
+		return false
+	}
+	n, err := cu.NumDevices()
+	return err == nil && n > 0
+}
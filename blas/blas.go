@@ -50,23 +50,32 @@ func (impl *Standard) Srotm(n int, x []float32, incX int, y []float32, incY int,
 		return
 	}
 
+	if !impl.fits32("Srotm", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Srotm", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Srotm", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Srotm", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Srotm", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Srotm", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if p.Flag < blas.Identity || p.Flag > blas.Diagonal {
-		panic("blas: illegal blas.Flag value")
+		impl.shapeError("Srotm", "blas: illegal blas.Flag value %v", p.Flag)
+		return
 	}
 	if n == 0 {
 		return
@@ -99,23 +108,33 @@ func (impl *Standard) Drotm(n int, x []float64, incX int, y []float64, incY int,
 	if impl.e != nil {
 		return
 	}
+
+	if !impl.fits32("Drotm", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Drotm", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Drotm", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Drotm", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Drotm", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Drotm", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if p.Flag < blas.Identity || p.Flag > blas.Diagonal {
-		panic("blas: illegal blas.Flag value")
+		impl.shapeError("Drotm", "blas: illegal blas.Flag value %v", p.Flag)
+		return
 	}
 	if n == 0 {
 		return
@@ -131,20 +150,29 @@ func (impl *Standard) Cdotu(n int, x []complex64, incX int, y []complex64, incY
 	if impl.e != nil {
 		return
 	}
+
+	if !impl.fits32("Cdotu", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Cdotu", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Cdotu", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Cdotu", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Cdotu", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Cdotu", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return 0
@@ -157,20 +185,28 @@ func (impl *Standard) Cdotc(n int, x []complex64, incX int, y []complex64, incY
 		return
 	}
 
+	if !impl.fits32("Cdotc", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Cdotc", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Cdotc", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Cdotc", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Cdotc", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Cdotc", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return 0
@@ -183,20 +219,28 @@ func (impl *Standard) Zdotu(n int, x []complex128, incX int, y []complex128, inc
 		return
 	}
 
+	if !impl.fits32("Zdotu", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zdotu", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zdotu", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Zdotu", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zdotu", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Zdotu", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return 0
@@ -208,20 +252,29 @@ func (impl *Standard) Zdotc(n int, x []complex128, incX int, y []complex128, inc
 	if impl.e != nil {
 		return
 	}
+
+	if !impl.fits32("Zdotc", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zdotc", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zdotc", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Zdotc", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zdotc", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Zdotc", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return 0
@@ -257,7 +310,9 @@ func (impl *Standard) Ztrmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 // Generated cases ...
 
 // Snrm2 computes the Euclidean norm of a vector,
-//  sqrt(\sum_i x[i] * x[i]).
+//
+//	sqrt(\sum_i x[i] * x[i]).
+//
 // This function returns 0 if incX is negative.
 func (impl *Standard) Snrm2(n int, x []float32, incX int) (retVal float32) {
 	// declared at cublasgen.h:137:17 enum CUBLAS_STATUS { ... } cublasSnrm2 ...
@@ -265,17 +320,23 @@ func (impl *Standard) Snrm2(n int, x []float32, incX int) (retVal float32) {
 		return
 	}
 
+	if !impl.fits32("Snrm2", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Snrm2", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Snrm2", "blas: zero x index increment")
+		return
 	}
 	if incX < 0 {
 		return 0
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Snrm2", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -285,7 +346,9 @@ func (impl *Standard) Snrm2(n int, x []float32, incX int) (retVal float32) {
 }
 
 // Dnrm2 computes the Euclidean norm of a vector,
-//  sqrt(\sum_i x[i] * x[i]).
+//
+//	sqrt(\sum_i x[i] * x[i]).
+//
 // This function returns 0 if incX is negative.
 func (impl *Standard) Dnrm2(n int, x []float64, incX int) (retVal float64) {
 	// declared at cublasgen.h:143:17 enum CUBLAS_STATUS { ... } cublasDnrm2 ...
@@ -293,17 +356,23 @@ func (impl *Standard) Dnrm2(n int, x []float64, incX int) (retVal float64) {
 		return
 	}
 
+	if !impl.fits32("Dnrm2", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dnrm2", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dnrm2", "blas: zero x index increment")
+		return
 	}
 	if incX < 0 {
 		return 0
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dnrm2", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -318,17 +387,23 @@ func (impl *Standard) Scnrm2(n int, x []complex64, incX int) (retVal float32) {
 		return
 	}
 
+	if !impl.fits32("Scnrm2", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Scnrm2", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Scnrm2", "blas: zero x index increment")
+		return
 	}
 	if incX < 0 {
 		return 0
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Scnrm2", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -343,17 +418,23 @@ func (impl *Standard) Dznrm2(n int, x []complex128, incX int) (retVal float64) {
 		return
 	}
 
+	if !impl.fits32("Dznrm2", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dznrm2", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dznrm2", "blas: zero x index increment")
+		return
 	}
 	if incX < 0 {
 		return 0
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dznrm2", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -363,27 +444,36 @@ func (impl *Standard) Dznrm2(n int, x []complex128, incX int) (retVal float64) {
 }
 
 // Sdot computes the dot product of the two vectors
-//  \sum_i x[i]*y[i]
+//
+//	\sum_i x[i]*y[i]
 func (impl *Standard) Sdot(n int, x []float32, incX int, y []float32, incY int) (retVal float32) {
 	// declared at cublasgen.h:186:17 enum CUBLAS_STATUS { ... } cublasSdot ...
 	if impl.e != nil {
 		return
 	}
 
+	if !impl.fits32("Sdot", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Sdot", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Sdot", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Sdot", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Sdot", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Sdot", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -393,27 +483,36 @@ func (impl *Standard) Sdot(n int, x []float32, incX int, y []float32, incY int)
 }
 
 // Ddot computes the dot product of the two vectors
-//  \sum_i x[i]*y[i]
+//
+//	\sum_i x[i]*y[i]
 func (impl *Standard) Ddot(n int, x []float64, incX int, y []float64, incY int) (retVal float64) {
 	// declared at cublasgen.h:194:17 enum CUBLAS_STATUS { ... } cublasDdot ...
 	if impl.e != nil {
 		return
 	}
 
+	if !impl.fits32("Ddot", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ddot", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Ddot", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Ddot", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Ddot", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Ddot", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -423,7 +522,9 @@ func (impl *Standard) Ddot(n int, x []float64, incX int, y []float64, incY int)
 }
 
 // Sscal scales x by alpha.
-//  x[i] *= alpha
+//
+//	x[i] *= alpha
+//
 // Sscal has no effect if incX < 0.
 func (impl *Standard) Sscal(n int, alpha float32, x []float32, incX int) {
 	// declared at cublasgen.h:245:17 enum CUBLAS_STATUS { ... } cublasSscal ...
@@ -431,17 +532,23 @@ func (impl *Standard) Sscal(n int, alpha float32, x []float32, incX int) {
 		return
 	}
 
+	if !impl.fits32("Sscal", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Sscal", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Sscal", "blas: zero x index increment")
+		return
 	}
 	if incX < 0 {
 		return
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Sscal", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -450,7 +557,9 @@ func (impl *Standard) Sscal(n int, alpha float32, x []float32, incX int) {
 }
 
 // Dscal scales x by alpha.
-//  x[i] *= alpha
+//
+//	x[i] *= alpha
+//
 // Dscal has no effect if incX < 0.
 func (impl *Standard) Dscal(n int, alpha float64, x []float64, incX int) {
 	// declared at cublasgen.h:251:17 enum CUBLAS_STATUS { ... } cublasDscal ...
@@ -458,17 +567,23 @@ func (impl *Standard) Dscal(n int, alpha float64, x []float64, incX int) {
 		return
 	}
 
+	if !impl.fits32("Dscal", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dscal", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dscal", "blas: zero x index increment")
+		return
 	}
 	if incX < 0 {
 		return
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dscal", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -482,17 +597,23 @@ func (impl *Standard) Cscal(n int, alpha complex64, x []complex64, incX int) {
 		return
 	}
 
+	if !impl.fits32("Cscal", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Cscal", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Cscal", "blas: zero x index increment")
+		return
 	}
 	if incX < 0 {
 		return
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Cscal", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -506,17 +627,23 @@ func (impl *Standard) Csscal(n int, alpha float32, x []complex64, incX int) {
 		return
 	}
 
+	if !impl.fits32("Csscal", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Csscal", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Csscal", "blas: zero x index increment")
+		return
 	}
 	if incX < 0 {
 		return
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Csscal", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -530,17 +657,23 @@ func (impl *Standard) Zscal(n int, alpha complex128, x []complex128, incX int) {
 		return
 	}
 
+	if !impl.fits32("Zscal", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zscal", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zscal", "blas: zero x index increment")
+		return
 	}
 	if incX < 0 {
 		return
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zscal", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -554,14 +687,20 @@ func (impl *Standard) Zdscal(n int, alpha float64, x []complex128, incX int) {
 		return
 	}
 
+	if !impl.fits32("Zdscal", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zdscal", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zdscal", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zdscal", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -570,27 +709,36 @@ func (impl *Standard) Zdscal(n int, alpha float64, x []complex128, incX int) {
 }
 
 // Saxpy adds alpha times x to y
-//  y[i] += alpha * x[i] for all i
+//
+//	y[i] += alpha * x[i] for all i
 func (impl *Standard) Saxpy(n int, alpha float32, x []float32, incX int, y []float32, incY int) {
 	// declared at cublasgen.h:296:17 enum CUBLAS_STATUS { ... } cublasSaxpy ...
 	if impl.e != nil {
 		return
 	}
 
+	if !impl.fits32("Saxpy", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Saxpy", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Saxpy", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Saxpy", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Saxpy", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Saxpy", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -599,27 +747,36 @@ func (impl *Standard) Saxpy(n int, alpha float32, x []float32, incX int, y []flo
 }
 
 // Daxpy adds alpha times x to y
-//  y[i] += alpha * x[i] for all i
+//
+//	y[i] += alpha * x[i] for all i
 func (impl *Standard) Daxpy(n int, alpha float64, x []float64, incX int, y []float64, incY int) {
 	// declared at cublasgen.h:304:17 enum CUBLAS_STATUS { ... } cublasDaxpy ...
 	if impl.e != nil {
 		return
 	}
 
+	if !impl.fits32("Daxpy", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Daxpy", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Daxpy", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Daxpy", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Daxpy", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Daxpy", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -633,20 +790,28 @@ func (impl *Standard) Caxpy(n int, alpha complex64, x []complex64, incX int, y [
 		return
 	}
 
+	if !impl.fits32("Caxpy", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Caxpy", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Caxpy", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Caxpy", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Caxpy", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Caxpy", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -660,20 +825,28 @@ func (impl *Standard) Zaxpy(n int, alpha complex128, x []complex128, incX int, y
 		return
 	}
 
+	if !impl.fits32("Zaxpy", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zaxpy", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zaxpy", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Zaxpy", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zaxpy", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Zaxpy", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -682,27 +855,36 @@ func (impl *Standard) Zaxpy(n int, alpha complex128, x []complex128, incX int, y
 }
 
 // Scopy copies the elements of x into the elements of y.
-//  y[i] = x[i] for all i
+//
+//	y[i] = x[i] for all i
 func (impl *Standard) Scopy(n int, x []float32, incX int, y []float32, incY int) {
 	// declared at cublasgen.h:328:17 enum CUBLAS_STATUS { ... } cublasScopy ...
 	if impl.e != nil {
 		return
 	}
 
+	if !impl.fits32("Scopy", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Scopy", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Scopy", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Scopy", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Scopy", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Scopy", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -711,27 +893,36 @@ func (impl *Standard) Scopy(n int, x []float32, incX int, y []float32, incY int)
 }
 
 // Dcopy copies the elements of x into the elements of y.
-//  y[i] = x[i] for all i
+//
+//	y[i] = x[i] for all i
 func (impl *Standard) Dcopy(n int, x []float64, incX int, y []float64, incY int) {
 	// declared at cublasgen.h:335:17 enum CUBLAS_STATUS { ... } cublasDcopy ...
 	if impl.e != nil {
 		return
 	}
 
+	if !impl.fits32("Dcopy", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dcopy", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dcopy", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Dcopy", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dcopy", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Dcopy", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -745,20 +936,28 @@ func (impl *Standard) Ccopy(n int, x []complex64, incX int, y []complex64, incY
 		return
 	}
 
+	if !impl.fits32("Ccopy", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ccopy", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Ccopy", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Ccopy", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Ccopy", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Ccopy", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -772,20 +971,28 @@ func (impl *Standard) Zcopy(n int, x []complex128, incX int, y []complex128, inc
 		return
 	}
 
+	if !impl.fits32("Zcopy", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zcopy", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zcopy", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Zcopy", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zcopy", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Zcopy", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -794,27 +1001,36 @@ func (impl *Standard) Zcopy(n int, x []complex128, incX int, y []complex128, inc
 }
 
 // Sswap exchanges the elements of two vectors.
-//  x[i], y[i] = y[i], x[i] for all i
+//
+//	x[i], y[i] = y[i], x[i] for all i
 func (impl *Standard) Sswap(n int, x []float32, incX int, y []float32, incY int) {
 	// declared at cublasgen.h:356:17 enum CUBLAS_STATUS { ... } cublasSswap ...
 	if impl.e != nil {
 		return
 	}
 
+	if !impl.fits32("Sswap", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Sswap", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Sswap", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Sswap", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Sswap", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Sswap", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -823,27 +1039,36 @@ func (impl *Standard) Sswap(n int, x []float32, incX int, y []float32, incY int)
 }
 
 // Dswap exchanges the elements of two vectors.
-//  x[i], y[i] = y[i], x[i] for all i
+//
+//	x[i], y[i] = y[i], x[i] for all i
 func (impl *Standard) Dswap(n int, x []float64, incX int, y []float64, incY int) {
 	// declared at cublasgen.h:363:17 enum CUBLAS_STATUS { ... } cublasDswap ...
 	if impl.e != nil {
 		return
 	}
 
+	if !impl.fits32("Dswap", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dswap", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dswap", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Dswap", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dswap", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Dswap", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -857,20 +1082,28 @@ func (impl *Standard) Cswap(n int, x []complex64, incX int, y []complex64, incY
 		return
 	}
 
+	if !impl.fits32("Cswap", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Cswap", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Cswap", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Cswap", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Cswap", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Cswap", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -884,20 +1117,28 @@ func (impl *Standard) Zswap(n int, x []complex128, incX int, y []complex128, inc
 		return
 	}
 
+	if !impl.fits32("Zswap", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zswap", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zswap", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Zswap", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zswap", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Zswap", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -914,17 +1155,23 @@ func (impl *Standard) Isamax(n int, x []float32, incX int) (retVal int) {
 		return
 	}
 
+	if !impl.fits32("Isamax", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Isamax", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Isamax", "blas: zero x index increment")
+		return
 	}
 	if n == 0 || incX < 0 {
 		return -1
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Isamax", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -943,17 +1190,23 @@ func (impl *Standard) Idamax(n int, x []float64, incX int) (retVal int) {
 		return
 	}
 
+	if !impl.fits32("Idamax", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Idamax", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Idamax", "blas: zero x index increment")
+		return
 	}
 	if n == 0 || incX < 0 {
 		return -1
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Idamax", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -969,17 +1222,23 @@ func (impl *Standard) Icamax(n int, x []complex64, incX int) (retVal int) {
 		return
 	}
 
+	if !impl.fits32("Icamax", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Icamax", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Icamax", "blas: zero x index increment")
+		return
 	}
 	if n == 0 || incX < 0 {
 		return -1
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Icamax", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -995,17 +1254,23 @@ func (impl *Standard) Izamax(n int, x []complex128, incX int) (retVal int) {
 		return
 	}
 
+	if !impl.fits32("Izamax", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Izamax", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Izamax", "blas: zero x index increment")
+		return
 	}
 	if n == 0 || incX < 0 {
 		return -1
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Izamax", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -1021,20 +1286,27 @@ func (impl *Standard) Isamin(n int, x []float32, incX int) (retVal int) {
 		return
 	}
 
+	if !impl.fits32("Isamin", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Isamin", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Isamin", "blas: zero x index increment")
+		return
 	}
 	if n == 0 || incX < 0 {
 		return -1
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Isamin", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Isamin", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -1050,20 +1322,27 @@ func (impl *Standard) Idamin(n int, x []float64, incX int) (retVal int) {
 		return
 	}
 
+	if !impl.fits32("Idamin", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Idamin", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Idamin", "blas: zero x index increment")
+		return
 	}
 	if n == 0 || incX < 0 {
 		return -1
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Idamin", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Idamin", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -1079,20 +1358,27 @@ func (impl *Standard) Icamin(n int, x []complex64, incX int) (retVal int) {
 		return
 	}
 
+	if !impl.fits32("Icamin", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Icamin", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Icamin", "blas: zero x index increment")
+		return
 	}
 	if n == 0 || incX < 0 {
 		return -1
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Icamin", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Icamin", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -1108,20 +1394,27 @@ func (impl *Standard) Izamin(n int, x []complex128, incX int) (retVal int) {
 		return
 	}
 
+	if !impl.fits32("Izamin", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Izamin", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Izamin", "blas: zero x index increment")
+		return
 	}
 	if n == 0 || incX < 0 {
 		return -1
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Izamin", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Izamin", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -1132,25 +1425,33 @@ func (impl *Standard) Izamin(n int, x []complex128, incX int) (retVal int) {
 }
 
 // Sasum computes the sum of the absolute values of the elements of x.
-//  \sum_i |x[i]|
-// Sasum returns 0 if incX is negative.
+//
+//	\sum_i |x[i]|
+//
+// Sasum returns 0 if incX is negative.
 func (impl *Standard) Sasum(n int, x []float32, incX int) (retVal float32) {
 	// declared at cublasgen.h:432:17 enum CUBLAS_STATUS { ... } cublasSasum ...
 	if impl.e != nil {
 		return
 	}
 
+	if !impl.fits32("Sasum", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Sasum", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Sasum", "blas: zero x index increment")
+		return
 	}
 	if incX < 0 {
 		return 0
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Sasum", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -1160,7 +1461,9 @@ func (impl *Standard) Sasum(n int, x []float32, incX int) (retVal float32) {
 }
 
 // Dasum computes the sum of the absolute values of the elements of x.
-//  \sum_i |x[i]|
+//
+//	\sum_i |x[i]|
+//
 // Dasum returns 0 if incX is negative.
 func (impl *Standard) Dasum(n int, x []float64, incX int) (retVal float64) {
 	// declared at cublasgen.h:438:17 enum CUBLAS_STATUS { ... } cublasDasum ...
@@ -1168,17 +1471,23 @@ func (impl *Standard) Dasum(n int, x []float64, incX int) (retVal float64) {
 		return
 	}
 
+	if !impl.fits32("Dasum", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dasum", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dasum", "blas: zero x index increment")
+		return
 	}
 	if incX < 0 {
 		return 0
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dasum", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -1193,17 +1502,23 @@ func (impl *Standard) Scasum(n int, x []complex64, incX int) (retVal float32) {
 		return
 	}
 
+	if !impl.fits32("Scasum", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Scasum", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Scasum", "blas: zero x index increment")
+		return
 	}
 	if incX < 0 {
 		return 0
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Scasum", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -1218,17 +1533,23 @@ func (impl *Standard) Dzasum(n int, x []complex128, incX int) (retVal float64) {
 		return
 	}
 
+	if !impl.fits32("Dzasum", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dzasum", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dzasum", "blas: zero x index increment")
+		return
 	}
 	if incX < 0 {
 		return 0
 	}
 	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dzasum", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -1238,28 +1559,37 @@ func (impl *Standard) Dzasum(n int, x []complex128, incX int) (retVal float64) {
 }
 
 // Srot applies a plane transformation.
-//  x[i] = c * x[i] + s * y[i]
-//  y[i] = c * y[i] - s * x[i]
+//
+//	x[i] = c * x[i] + s * y[i]
+//	y[i] = c * y[i] - s * x[i]
 func (impl *Standard) Srot(n int, x []float32, incX int, y []float32, incY int, cScalar, sScalar float32) {
 	// declared at cublasgen.h:456:17 enum CUBLAS_STATUS { ... } cublasSrot ...
 	if impl.e != nil {
 		return
 	}
 
+	if !impl.fits32("Srot", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Srot", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Srot", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Srot", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Srot", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Srot", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -1268,28 +1598,37 @@ func (impl *Standard) Srot(n int, x []float32, incX int, y []float32, incY int,
 }
 
 // Drot applies a plane transformation.
-//  x[i] = c * x[i] + s * y[i]
-//  y[i] = c * y[i] - s * x[i]
+//
+//	x[i] = c * x[i] + s * y[i]
+//	y[i] = c * y[i] - s * x[i]
 func (impl *Standard) Drot(n int, x []float64, incX int, y []float64, incY int, cScalar, sScalar float64) {
 	// declared at cublasgen.h:465:17 enum CUBLAS_STATUS { ... } cublasDrot ...
 	if impl.e != nil {
 		return
 	}
 
+	if !impl.fits32("Drot", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Drot", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Drot", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Drot", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Drot", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Drot", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -1303,20 +1642,28 @@ func (impl *Standard) Crot(n int, x []complex64, incX int, y []complex64, incY i
 		return
 	}
 
+	if !impl.fits32("Crot", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Crot", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Crot", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Crot", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Crot", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Crot", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -1330,20 +1677,28 @@ func (impl *Standard) Zrot(n int, x []complex128, incX int, y []complex128, incY
 		return
 	}
 
+	if !impl.fits32("Zrot", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zrot", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zrot", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Zrot", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zrot", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Zrot", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -1352,8 +1707,10 @@ func (impl *Standard) Zrot(n int, x []complex128, incX int, y []complex128, incY
 }
 
 // Sgemv computes
-//  y = alpha * a * x + beta * y if tA = blas.NoTrans
-//  y = alpha * A^T * x + beta * y if tA = blas.Trans or blas.ConjTrans
+//
+//	y = alpha * a * x + beta * y if tA = blas.NoTrans
+//	y = alpha * A^T * x + beta * y if tA = blas.Trans or blas.ConjTrans
+//
 // where A is an m×n dense matrix, x and y are vectors, and alpha is a scalar.
 func (impl *Standard) Sgemv(tA blas.Transpose, m, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
 	// declared at cublasgen.h:567:17 enum CUBLAS_STATUS { ... } cublasSgemv ...
@@ -1361,27 +1718,37 @@ func (impl *Standard) Sgemv(tA blas.Transpose, m, n int, alpha float32, a []floa
 		return
 	}
 
+	if !impl.fits32("Sgemv", "m, n, lda, incX, incY", m, n, lda, incX, incY) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Sgemv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Sgemv", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Sgemv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Sgemv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Sgemv", "blas: zero y index increment")
+		return
 	}
 	impl.e = status(C.cublasSgemv(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), C.int(m), C.int(n), (*C.float)(&alpha), (*C.float)(&a[0]), C.int(lda), (*C.float)(&x[0]), C.int(incX), (*C.float)(&beta), (*C.float)(&y[0]), C.int(incY)))
 }
 
 // Dgemv computes
-//  y = alpha * a * x + beta * y if tA = blas.NoTrans
-//  y = alpha * A^T * x + beta * y if tA = blas.Trans or blas.ConjTrans
+//
+//	y = alpha * a * x + beta * y if tA = blas.NoTrans
+//	y = alpha * A^T * x + beta * y if tA = blas.Trans or blas.ConjTrans
+//
 // where A is an m×n dense matrix, x and y are vectors, and alpha is a scalar.
 func (impl *Standard) Dgemv(tA blas.Transpose, m, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
 	// declared at cublasgen.h:580:17 enum CUBLAS_STATUS { ... } cublasDgemv ...
@@ -1389,20 +1756,28 @@ func (impl *Standard) Dgemv(tA blas.Transpose, m, n int, alpha float64, a []floa
 		return
 	}
 
+	if !impl.fits32("Dgemv", "m, n, lda, incX, incY", m, n, lda, incX, incY) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Dgemv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Dgemv", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dgemv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dgemv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Dgemv", "blas: zero y index increment")
+		return
 	}
 	impl.e = status(C.cublasDgemv(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), C.int(m), C.int(n), (*C.double)(&alpha), (*C.double)(&a[0]), C.int(lda), (*C.double)(&x[0]), C.int(incX), (*C.double)(&beta), (*C.double)(&y[0]), C.int(incY)))
 }
@@ -1413,20 +1788,28 @@ func (impl *Standard) Cgemv(tA blas.Transpose, m, n int, alpha complex64, a []co
 		return
 	}
 
+	if !impl.fits32("Cgemv", "m, n, lda, incX, incY", m, n, lda, incX, incY) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Cgemv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Cgemv", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Cgemv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Cgemv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Cgemv", "blas: zero y index increment")
+		return
 	}
 	impl.e = status(C.cublasCgemv(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), C.int(m), C.int(n), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuComplex)(unsafe.Pointer(&beta)), (*C.cuComplex)(unsafe.Pointer(&y[0])), C.int(incY)))
 }
@@ -1437,27 +1820,37 @@ func (impl *Standard) Zgemv(tA blas.Transpose, m, n int, alpha complex128, a []c
 		return
 	}
 
+	if !impl.fits32("Zgemv", "m, n, lda, incX, incY", m, n, lda, incX, incY) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Zgemv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Zgemv", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zgemv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zgemv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Zgemv", "blas: zero y index increment")
+		return
 	}
 	impl.e = status(C.cublasZgemv(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), C.int(m), C.int(n), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuDoubleComplex)(unsafe.Pointer(&beta)), (*C.cuDoubleComplex)(unsafe.Pointer(&y[0])), C.int(incY)))
 }
 
 // Sgbmv computes
-//  y = alpha * A * x + beta * y if tA == blas.NoTrans
-//  y = alpha * A^T * x + beta * y if tA == blas.Trans or blas.ConjTrans
+//
+//	y = alpha * A * x + beta * y if tA == blas.NoTrans
+//	y = alpha * A^T * x + beta * y if tA == blas.Trans or blas.ConjTrans
+//
 // where a is an m×n band matrix kL subdiagonals and kU super-diagonals, and
 // m and n refer to the size of the full dense matrix it represents.
 // x and y are vectors, and alpha and beta are scalars.
@@ -1467,27 +1860,37 @@ func (impl *Standard) Sgbmv(tA blas.Transpose, m, n, kl, ku int, alpha float32,
 		return
 	}
 
+	if !impl.fits32("Sgbmv", "m, n, kl, ku, lda, incX, incY", m, n, kl, ku, lda, incX, incY) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Sgbmv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Sgbmv", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Sgbmv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Sgbmv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Sgbmv", "blas: zero y index increment")
+		return
 	}
 	impl.e = status(C.cublasSgbmv(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), C.int(m), C.int(n), C.int(kl), C.int(ku), (*C.float)(&alpha), (*C.float)(&a[0]), C.int(lda), (*C.float)(&x[0]), C.int(incX), (*C.float)(&beta), (*C.float)(&y[0]), C.int(incY)))
 }
 
 // Dgbmv computes
-//  y = alpha * A * x + beta * y if tA == blas.NoTrans
-//  y = alpha * A^T * x + beta * y if tA == blas.Trans or blas.ConjTrans
+//
+//	y = alpha * A * x + beta * y if tA == blas.NoTrans
+//	y = alpha * A^T * x + beta * y if tA == blas.Trans or blas.ConjTrans
+//
 // where a is an m×n band matrix kL subdiagonals and kU super-diagonals, and
 // m and n refer to the size of the full dense matrix it represents.
 // x and y are vectors, and alpha and beta are scalars.
@@ -1497,20 +1900,28 @@ func (impl *Standard) Dgbmv(tA blas.Transpose, m, n, kl, ku int, alpha float64,
 		return
 	}
 
+	if !impl.fits32("Dgbmv", "m, n, kl, ku, lda, incX, incY", m, n, kl, ku, lda, incX, incY) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Dgbmv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Dgbmv", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dgbmv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dgbmv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Dgbmv", "blas: zero y index increment")
+		return
 	}
 	impl.e = status(C.cublasDgbmv(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), C.int(m), C.int(n), C.int(kl), C.int(ku), (*C.double)(&alpha), (*C.double)(&a[0]), C.int(lda), (*C.double)(&x[0]), C.int(incX), (*C.double)(&beta), (*C.double)(&y[0]), C.int(incY)))
 }
@@ -1521,20 +1932,28 @@ func (impl *Standard) Cgbmv(tA blas.Transpose, m, n, kl, ku int, alpha complex64
 		return
 	}
 
+	if !impl.fits32("Cgbmv", "m, n, kl, ku, lda, incX, incY", m, n, kl, ku, lda, incX, incY) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Cgbmv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Cgbmv", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Cgbmv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Cgbmv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Cgbmv", "blas: zero y index increment")
+		return
 	}
 	impl.e = status(C.cublasCgbmv(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), C.int(m), C.int(n), C.int(kl), C.int(ku), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuComplex)(unsafe.Pointer(&beta)), (*C.cuComplex)(unsafe.Pointer(&y[0])), C.int(incY)))
 }
@@ -1545,27 +1964,37 @@ func (impl *Standard) Zgbmv(tA blas.Transpose, m, n, kl, ku int, alpha complex12
 		return
 	}
 
+	if !impl.fits32("Zgbmv", "m, n, kl, ku, lda, incX, incY", m, n, kl, ku, lda, incX, incY) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Zgbmv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Zgbmv", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zgbmv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zgbmv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Zgbmv", "blas: zero y index increment")
+		return
 	}
 	impl.e = status(C.cublasZgbmv(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), C.int(m), C.int(n), C.int(kl), C.int(ku), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuDoubleComplex)(unsafe.Pointer(&beta)), (*C.cuDoubleComplex)(unsafe.Pointer(&y[0])), C.int(incY)))
 }
 
 // Strmv computes
-//  x = A * x if tA == blas.NoTrans
-//  x = A^T * x if tA == blas.Trans or blas.ConjTrans
+//
+//	x = A * x if tA == blas.NoTrans
+//	x = A^T * x if tA == blas.Trans or blas.ConjTrans
+//
 // A is an n×n Triangular matrix and x is a vector.
 func (impl *Standard) Strmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float32, lda int, x []float32, incX int) {
 	// declared at cublasgen.h:680:17 enum CUBLAS_STATUS { ... } cublasStrmv ...
@@ -1573,24 +2002,33 @@ func (impl *Standard) Strmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 		return
 	}
 
+	if !impl.fits32("Strmv", "n, lda, incX", n, lda, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Strmv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Strmv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Strmv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Strmv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasStrmv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(tA), diag2cublasDiag(d), C.int(n), (*C.float)(&a[0]), C.int(lda), (*C.float)(&x[0]), C.int(incX)))
 }
 
 // Dtrmv computes
-//  x = A * x if tA == blas.NoTrans
-//  x = A^T * x if tA == blas.Trans or blas.ConjTrans
+//
+//	x = A * x if tA == blas.NoTrans
+//	x = A^T * x if tA == blas.Trans or blas.ConjTrans
+//
 // A is an n×n Triangular matrix and x is a vector.
 func (impl *Standard) Dtrmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float64, lda int, x []float64, incX int) {
 	// declared at cublasgen.h:690:17 enum CUBLAS_STATUS { ... } cublasDtrmv ...
@@ -1598,17 +2036,24 @@ func (impl *Standard) Dtrmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 		return
 	}
 
+	if !impl.fits32("Dtrmv", "n, lda, incX", n, lda, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Dtrmv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dtrmv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dtrmv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dtrmv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasDtrmv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(tA), diag2cublasDiag(d), C.int(n), (*C.double)(&a[0]), C.int(lda), (*C.double)(&x[0]), C.int(incX)))
 }
@@ -1619,17 +2064,24 @@ func (impl *Standard) Ctrmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 		return
 	}
 
+	if !impl.fits32("Ctrmv", "n, lda, incX", n, lda, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Ctrmv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ctrmv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Ctrmv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Ctrmv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasCtrmv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(tA), diag2cublasDiag(d), C.int(n), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&x[0])), C.int(incX)))
 }
@@ -1640,24 +2092,33 @@ func (impl *Standard) Ztrmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 		return
 	}
 
+	if !impl.fits32("Ztrmv", "n, lda, incX", n, lda, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Ztrmv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ztrmv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Ztrmv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Ztrmv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasZtrmv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(tA), diag2cublasDiag(d), C.int(n), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&x[0])), C.int(incX)))
 }
 
 // Stbmv computes
-//  x = A * x if tA == blas.NoTrans
-//  x = A^T * x if tA == blas.Trans or blas.ConjTrans
+//
+//	x = A * x if tA == blas.NoTrans
+//	x = A^T * x if tA == blas.Trans or blas.ConjTrans
+//
 // where A is an n×n triangular banded matrix with k diagonals, and x is a vector.
 func (impl *Standard) Stbmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []float32, lda int, x []float32, incX int) {
 	// declared at cublasgen.h:721:17 enum CUBLAS_STATUS { ... } cublasStbmv ...
@@ -1665,27 +2126,37 @@ func (impl *Standard) Stbmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k i
 		return
 	}
 
+	if !impl.fits32("Stbmv", "n, k, lda, incX", n, k, lda, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Stbmv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Stbmv", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Stbmv", "blas: k=%d < 0", k)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Stbmv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Stbmv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasStbmv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(tA), diag2cublasDiag(d), C.int(n), C.int(k), (*C.float)(&a[0]), C.int(lda), (*C.float)(&x[0]), C.int(incX)))
 }
 
 // Dtbmv computes
-//  x = A * x if tA == blas.NoTrans
-//  x = A^T * x if tA == blas.Trans or blas.ConjTrans
+//
+//	x = A * x if tA == blas.NoTrans
+//	x = A^T * x if tA == blas.Trans or blas.ConjTrans
+//
 // where A is an n×n triangular banded matrix with k diagonals, and x is a vector.
 func (impl *Standard) Dtbmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []float64, lda int, x []float64, incX int) {
 	// declared at cublasgen.h:732:17 enum CUBLAS_STATUS { ... } cublasDtbmv ...
@@ -1693,20 +2164,28 @@ func (impl *Standard) Dtbmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k i
 		return
 	}
 
+	if !impl.fits32("Dtbmv", "n, k, lda, incX", n, k, lda, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Dtbmv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dtbmv", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Dtbmv", "blas: k=%d < 0", k)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dtbmv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dtbmv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasDtbmv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(tA), diag2cublasDiag(d), C.int(n), C.int(k), (*C.double)(&a[0]), C.int(lda), (*C.double)(&x[0]), C.int(incX)))
 }
@@ -1717,20 +2196,28 @@ func (impl *Standard) Ctbmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k i
 		return
 	}
 
+	if !impl.fits32("Ctbmv", "n, k, lda, incX", n, k, lda, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Ctbmv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ctbmv", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Ctbmv", "blas: k=%d < 0", k)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Ctbmv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Ctbmv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasCtbmv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(tA), diag2cublasDiag(d), C.int(n), C.int(k), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&x[0])), C.int(incX)))
 }
@@ -1741,27 +2228,37 @@ func (impl *Standard) Ztbmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k i
 		return
 	}
 
+	if !impl.fits32("Ztbmv", "n, k, lda, incX", n, k, lda, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Ztbmv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ztbmv", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Ztbmv", "blas: k=%d < 0", k)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Ztbmv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Ztbmv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasZtbmv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(tA), diag2cublasDiag(d), C.int(n), C.int(k), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&x[0])), C.int(incX)))
 }
 
 // Stpmv computes
-//  x = A * x if tA == blas.NoTrans
-//  x = A^T * x if tA == blas.Trans or blas.ConjTrans
+//
+//	x = A * x if tA == blas.NoTrans
+//	x = A^T * x if tA == blas.Trans or blas.ConjTrans
+//
 // where A is an n×n unit triangular matrix in packed format, and x is a vector.
 func (impl *Standard) Stpmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, aP, x []float32, incX int) {
 	// declared at cublasgen.h:766:17 enum CUBLAS_STATUS { ... } cublasStpmv ...
@@ -1769,17 +2266,24 @@ func (impl *Standard) Stpmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 		return
 	}
 
+	if !impl.fits32("Stpmv", "n, incX", n, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Stpmv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Stpmv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Stpmv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Stpmv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -1788,8 +2292,10 @@ func (impl *Standard) Stpmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 }
 
 // Dtpmv computes
-//  x = A * x if tA == blas.NoTrans
-//  x = A^T * x if tA == blas.Trans or blas.ConjTrans
+//
+//	x = A * x if tA == blas.NoTrans
+//	x = A^T * x if tA == blas.Trans or blas.ConjTrans
+//
 // where A is an n×n unit triangular matrix in packed format, and x is a vector.
 func (impl *Standard) Dtpmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, aP, x []float64, incX int) {
 	// declared at cublasgen.h:775:17 enum CUBLAS_STATUS { ... } cublasDtpmv ...
@@ -1797,17 +2303,24 @@ func (impl *Standard) Dtpmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 		return
 	}
 
+	if !impl.fits32("Dtpmv", "n, incX", n, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Dtpmv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dtpmv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dtpmv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dtpmv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -1821,17 +2334,24 @@ func (impl *Standard) Ctpmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 		return
 	}
 
+	if !impl.fits32("Ctpmv", "n, incX", n, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Ctpmv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ctpmv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Ctpmv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Ctpmv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -1845,17 +2365,24 @@ func (impl *Standard) Ztpmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 		return
 	}
 
+	if !impl.fits32("Ztpmv", "n, incX", n, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Ztpmv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ztpmv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Ztpmv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Ztpmv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -1864,8 +2391,10 @@ func (impl *Standard) Ztpmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 }
 
 // Strsv solves
-//  A * x = b if tA == blas.NoTrans
-//  A^T * x = b if tA == blas.Trans or blas.ConjTrans
+//
+//	A * x = b if tA == blas.NoTrans
+//	A^T * x = b if tA == blas.Trans or blas.ConjTrans
+//
 // A is an n×n triangular matrix and x is a vector.
 // At entry to the function, x contains the values of b, and the result is
 // stored in place into x.
@@ -1878,24 +2407,33 @@ func (impl *Standard) Strsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 		return
 	}
 
+	if !impl.fits32("Strsv", "n, lda, incX", n, lda, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Strsv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Strsv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Strsv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Strsv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasStrsv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(tA), diag2cublasDiag(d), C.int(n), (*C.float)(&a[0]), C.int(lda), (*C.float)(&x[0]), C.int(incX)))
 }
 
 // Dtrsv solves
-//  A * x = b if tA == blas.NoTrans
-//  A^T * x = b if tA == blas.Trans or blas.ConjTrans
+//
+//	A * x = b if tA == blas.NoTrans
+//	A^T * x = b if tA == blas.Trans or blas.ConjTrans
+//
 // A is an n×n triangular matrix and x is a vector.
 // At entry to the function, x contains the values of b, and the result is
 // stored in place into x.
@@ -1908,17 +2446,24 @@ func (impl *Standard) Dtrsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 		return
 	}
 
+	if !impl.fits32("Dtrsv", "n, lda, incX", n, lda, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Dtrsv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dtrsv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dtrsv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dtrsv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasDtrsv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(tA), diag2cublasDiag(d), C.int(n), (*C.double)(&a[0]), C.int(lda), (*C.double)(&x[0]), C.int(incX)))
 }
@@ -1929,17 +2474,24 @@ func (impl *Standard) Ctrsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 		return
 	}
 
+	if !impl.fits32("Ctrsv", "n, lda, incX", n, lda, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Ctrsv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ctrsv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Ctrsv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Ctrsv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasCtrsv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(tA), diag2cublasDiag(d), C.int(n), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&x[0])), C.int(incX)))
 }
@@ -1950,24 +2502,33 @@ func (impl *Standard) Ztrsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 		return
 	}
 
+	if !impl.fits32("Ztrsv", "n, lda, incX", n, lda, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Ztrsv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ztrsv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Ztrsv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Ztrsv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasZtrsv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(tA), diag2cublasDiag(d), C.int(n), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&x[0])), C.int(incX)))
 }
 
 // Stpsv solves
-//  A * x = b if tA == blas.NoTrans
-//  A^T * x = b if tA == blas.Trans or blas.ConjTrans
+//
+//	A * x = b if tA == blas.NoTrans
+//	A^T * x = b if tA == blas.Trans or blas.ConjTrans
+//
 // where A is an n×n triangular matrix in packed format and x is a vector.
 // At entry to the function, x contains the values of b, and the result is
 // stored in place into x.
@@ -1980,17 +2541,24 @@ func (impl *Standard) Stpsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 		return
 	}
 
+	if !impl.fits32("Stpsv", "n, incX", n, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Stpsv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Stpsv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Stpsv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Stpsv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -1999,8 +2567,10 @@ func (impl *Standard) Stpsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 }
 
 // Dtpsv solves
-//  A * x = b if tA == blas.NoTrans
-//  A^T * x = b if tA == blas.Trans or blas.ConjTrans
+//
+//	A * x = b if tA == blas.NoTrans
+//	A^T * x = b if tA == blas.Trans or blas.ConjTrans
+//
 // where A is an n×n triangular matrix in packed format and x is a vector.
 // At entry to the function, x contains the values of b, and the result is
 // stored in place into x.
@@ -2013,17 +2583,24 @@ func (impl *Standard) Dtpsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 		return
 	}
 
+	if !impl.fits32("Dtpsv", "n, incX", n, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Dtpsv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dtpsv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dtpsv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dtpsv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -2037,17 +2614,24 @@ func (impl *Standard) Ctpsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 		return
 	}
 
+	if !impl.fits32("Ctpsv", "n, incX", n, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Ctpsv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ctpsv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Ctpsv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Ctpsv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -2061,17 +2645,24 @@ func (impl *Standard) Ztpsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 		return
 	}
 
+	if !impl.fits32("Ztpsv", "n, incX", n, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Ztpsv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ztpsv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Ztpsv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Ztpsv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -2080,7 +2671,9 @@ func (impl *Standard) Ztpsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 }
 
 // Stbsv solves
-//  A * x = b
+//
+//	A * x = b
+//
 // where A is an n×n triangular banded matrix with k diagonals in packed format,
 // and x is a vector.
 // At entry to the function, x contains the values of b, and the result is
@@ -2094,26 +2687,36 @@ func (impl *Standard) Stbsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k i
 		return
 	}
 
+	if !impl.fits32("Stbsv", "n, k, lda, incX", n, k, lda, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Stbsv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Stbsv", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Stbsv", "blas: k=%d < 0", k)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Stbsv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Stbsv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasStbsv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(tA), diag2cublasDiag(d), C.int(n), C.int(k), (*C.float)(&a[0]), C.int(lda), (*C.float)(&x[0]), C.int(incX)))
 }
 
 // Dtbsv solves
-//  A * x = b
+//
+//	A * x = b
+//
 // where A is an n×n triangular banded matrix with k diagonals in packed format,
 // and x is a vector.
 // At entry to the function, x contains the values of b, and the result is
@@ -2127,20 +2730,28 @@ func (impl *Standard) Dtbsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k i
 		return
 	}
 
+	if !impl.fits32("Dtbsv", "n, k, lda, incX", n, k, lda, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Dtbsv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dtbsv", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Dtbsv", "blas: k=%d < 0", k)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dtbsv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dtbsv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasDtbsv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(tA), diag2cublasDiag(d), C.int(n), C.int(k), (*C.double)(&a[0]), C.int(lda), (*C.double)(&x[0]), C.int(incX)))
 }
@@ -2151,20 +2762,28 @@ func (impl *Standard) Ctbsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k i
 		return
 	}
 
+	if !impl.fits32("Ctbsv", "n, k, lda, incX", n, k, lda, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Ctbsv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ctbsv", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Ctbsv", "blas: k=%d < 0", k)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Ctbsv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Ctbsv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasCtbsv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(tA), diag2cublasDiag(d), C.int(n), C.int(k), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&x[0])), C.int(incX)))
 }
@@ -2175,26 +2794,36 @@ func (impl *Standard) Ztbsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k i
 		return
 	}
 
+	if !impl.fits32("Ztbsv", "n, k, lda, incX", n, k, lda, incX) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Ztbsv", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ztbsv", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Ztbsv", "blas: k=%d < 0", k)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Ztbsv", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Ztbsv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasZtbsv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(tA), diag2cublasDiag(d), C.int(n), C.int(k), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&x[0])), C.int(incX)))
 }
 
 // Ssymv computes
-//    y = alpha * A * x + beta * y,
+//
+//	y = alpha * A * x + beta * y,
+//
 // where a is an n×n symmetric matrix, x and y are vectors, and alpha and
 // beta are scalars.
 func (impl *Standard) Ssymv(ul blas.Uplo, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
@@ -2203,26 +2832,36 @@ func (impl *Standard) Ssymv(ul blas.Uplo, n int, alpha float32, a []float32, lda
 		return
 	}
 
+	if !impl.fits32("Ssymv", "n, lda, incX, incY", n, lda, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ssymv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Ssymv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Ssymv", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Ssymv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Ssymv", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasSsymv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.float)(&alpha), (*C.float)(&a[0]), C.int(lda), (*C.float)(&x[0]), C.int(incX), (*C.float)(&beta), (*C.float)(&y[0]), C.int(incY)))
 }
 
 // Dsymv computes
-//    y = alpha * A * x + beta * y,
+//
+//	y = alpha * A * x + beta * y,
+//
 // where a is an n×n symmetric matrix, x and y are vectors, and alpha and
 // beta are scalars.
 func (impl *Standard) Dsymv(ul blas.Uplo, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
@@ -2231,20 +2870,28 @@ func (impl *Standard) Dsymv(ul blas.Uplo, n int, alpha float64, a []float64, lda
 		return
 	}
 
+	if !impl.fits32("Dsymv", "n, lda, incX, incY", n, lda, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dsymv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dsymv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Dsymv", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dsymv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Dsymv", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasDsymv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.double)(&alpha), (*C.double)(&a[0]), C.int(lda), (*C.double)(&x[0]), C.int(incX), (*C.double)(&beta), (*C.double)(&y[0]), C.int(incY)))
 }
@@ -2255,20 +2902,28 @@ func (impl *Standard) Csymv(ul blas.Uplo, n int, alpha complex64, a []complex64,
 		return
 	}
 
+	if !impl.fits32("Csymv", "n, lda, incX, incY", n, lda, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Csymv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Csymv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Csymv", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Csymv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Csymv", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasCsymv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuComplex)(unsafe.Pointer(&beta)), (*C.cuComplex)(unsafe.Pointer(&y[0])), C.int(incY)))
 }
@@ -2279,20 +2934,28 @@ func (impl *Standard) Zsymv(ul blas.Uplo, n int, alpha complex128, a []complex12
 		return
 	}
 
+	if !impl.fits32("Zsymv", "n, lda, incX, incY", n, lda, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zsymv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zsymv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Zsymv", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zsymv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Zsymv", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasZsymv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuDoubleComplex)(unsafe.Pointer(&beta)), (*C.cuDoubleComplex)(unsafe.Pointer(&y[0])), C.int(incY)))
 }
@@ -2303,20 +2966,28 @@ func (impl *Standard) Chemv(ul blas.Uplo, n int, alpha complex64, a []complex64,
 		return
 	}
 
+	if !impl.fits32("Chemv", "n, lda, incX, incY", n, lda, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Chemv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Chemv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Chemv", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Chemv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Chemv", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasChemv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuComplex)(unsafe.Pointer(&beta)), (*C.cuComplex)(unsafe.Pointer(&y[0])), C.int(incY)))
 }
@@ -2327,26 +2998,36 @@ func (impl *Standard) Zhemv(ul blas.Uplo, n int, alpha complex128, a []complex12
 		return
 	}
 
+	if !impl.fits32("Zhemv", "n, lda, incX, incY", n, lda, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zhemv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zhemv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Zhemv", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zhemv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Zhemv", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasZhemv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuDoubleComplex)(unsafe.Pointer(&beta)), (*C.cuDoubleComplex)(unsafe.Pointer(&y[0])), C.int(incY)))
 }
 
 // Ssbmv performs
-//  y = alpha * A * x + beta * y
+//
+//	y = alpha * A * x + beta * y
+//
 // where A is an n×n symmetric banded matrix, x and y are vectors, and alpha
 // and beta are scalars.
 func (impl *Standard) Ssbmv(ul blas.Uplo, n, k int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
@@ -2355,29 +3036,40 @@ func (impl *Standard) Ssbmv(ul blas.Uplo, n, k int, alpha float32, a []float32,
 		return
 	}
 
+	if !impl.fits32("Ssbmv", "n, k, lda, incX, incY", n, k, lda, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ssbmv", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Ssbmv", "blas: k=%d < 0", k)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Ssbmv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Ssbmv", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Ssbmv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Ssbmv", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasSsbmv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), C.int(k), (*C.float)(&alpha), (*C.float)(&a[0]), C.int(lda), (*C.float)(&x[0]), C.int(incX), (*C.float)(&beta), (*C.float)(&y[0]), C.int(incY)))
 }
 
 // Dsbmv performs
-//  y = alpha * A * x + beta * y
+//
+//	y = alpha * A * x + beta * y
+//
 // where A is an n×n symmetric banded matrix, x and y are vectors, and alpha
 // and beta are scalars.
 func (impl *Standard) Dsbmv(ul blas.Uplo, n, k int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
@@ -2386,23 +3078,32 @@ func (impl *Standard) Dsbmv(ul blas.Uplo, n, k int, alpha float64, a []float64,
 		return
 	}
 
+	if !impl.fits32("Dsbmv", "n, k, lda, incX, incY", n, k, lda, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dsbmv", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Dsbmv", "blas: k=%d < 0", k)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dsbmv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Dsbmv", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dsbmv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Dsbmv", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasDsbmv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), C.int(k), (*C.double)(&alpha), (*C.double)(&a[0]), C.int(lda), (*C.double)(&x[0]), C.int(incX), (*C.double)(&beta), (*C.double)(&y[0]), C.int(incY)))
 }
@@ -2413,23 +3114,32 @@ func (impl *Standard) Chbmv(ul blas.Uplo, n, k int, alpha complex64, a []complex
 		return
 	}
 
+	if !impl.fits32("Chbmv", "n, k, lda, incX, incY", n, k, lda, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Chbmv", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Chbmv", "blas: k=%d < 0", k)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Chbmv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Chbmv", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Chbmv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Chbmv", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasChbmv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), C.int(k), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuComplex)(unsafe.Pointer(&beta)), (*C.cuComplex)(unsafe.Pointer(&y[0])), C.int(incY)))
 }
@@ -2440,29 +3150,40 @@ func (impl *Standard) Zhbmv(ul blas.Uplo, n, k int, alpha complex128, a []comple
 		return
 	}
 
+	if !impl.fits32("Zhbmv", "n, k, lda, incX, incY", n, k, lda, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zhbmv", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Zhbmv", "blas: k=%d < 0", k)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zhbmv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Zhbmv", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zhbmv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Zhbmv", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasZhbmv(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), C.int(k), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuDoubleComplex)(unsafe.Pointer(&beta)), (*C.cuDoubleComplex)(unsafe.Pointer(&y[0])), C.int(incY)))
 }
 
 // Sspmv performs
-//    y = alpha * A * x + beta * y,
+//
+//	y = alpha * A * x + beta * y,
+//
 // where A is an n×n symmetric matrix in packed format, x and y are vectors
 // and alpha and beta are scalars.
 func (impl *Standard) Sspmv(ul blas.Uplo, n int, alpha float32, aP, x []float32, incX int, beta float32, y []float32, incY int) {
@@ -2471,20 +3192,28 @@ func (impl *Standard) Sspmv(ul blas.Uplo, n int, alpha float32, aP, x []float32,
 		return
 	}
 
+	if !impl.fits32("Sspmv", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Sspmv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Sspmv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Sspmv", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Sspmv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Sspmv", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -2493,7 +3222,9 @@ func (impl *Standard) Sspmv(ul blas.Uplo, n int, alpha float32, aP, x []float32,
 }
 
 // Dspmv performs
-//    y = alpha * A * x + beta * y,
+//
+//	y = alpha * A * x + beta * y,
+//
 // where A is an n×n symmetric matrix in packed format, x and y are vectors
 // and alpha and beta are scalars.
 func (impl *Standard) Dspmv(ul blas.Uplo, n int, alpha float64, aP, x []float64, incX int, beta float64, y []float64, incY int) {
@@ -2502,20 +3233,28 @@ func (impl *Standard) Dspmv(ul blas.Uplo, n int, alpha float64, aP, x []float64,
 		return
 	}
 
+	if !impl.fits32("Dspmv", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dspmv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dspmv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Dspmv", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dspmv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Dspmv", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -2529,20 +3268,28 @@ func (impl *Standard) Chpmv(ul blas.Uplo, n int, alpha complex64, aP, x []comple
 		return
 	}
 
+	if !impl.fits32("Chpmv", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Chpmv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Chpmv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Chpmv", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Chpmv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Chpmv", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -2556,20 +3303,28 @@ func (impl *Standard) Zhpmv(ul blas.Uplo, n int, alpha complex128, aP, x []compl
 		return
 	}
 
+	if !impl.fits32("Zhpmv", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zhpmv", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zhpmv", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Zhpmv", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zhpmv", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Zhpmv", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -2578,7 +3333,9 @@ func (impl *Standard) Zhpmv(ul blas.Uplo, n int, alpha complex128, aP, x []compl
 }
 
 // Sger performs the rank-one operation
-//  A += alpha * x * y^T
+//
+//	A += alpha * x * y^T
+//
 // where A is an m×n dense matrix, x and y are vectors, and alpha is a scalar.
 func (impl *Standard) Sger(m, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int) {
 	// declared at cublasgen.h:1096:17 enum CUBLAS_STATUS { ... } cublasSger ...
@@ -2586,29 +3343,40 @@ func (impl *Standard) Sger(m, n int, alpha float32, x []float32, incX int, y []f
 		return
 	}
 
+	if !impl.fits32("Sger", "m, n, incX, incY, lda", m, n, incX, incY, lda) {
+		return
+	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Sger", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Sger", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Sger", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Sger", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (m-1)*incX >= len(x)) || (incX < 0 && (1-m)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Sger", "blas: x index out of range: m=%d, incX=%d, len(x)=%d", m, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Sger", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasSger(C.cublasHandle_t(impl.h), C.int(m), C.int(n), (*C.float)(&alpha), (*C.float)(&x[0]), C.int(incX), (*C.float)(&y[0]), C.int(incY), (*C.float)(&a[0]), C.int(lda)))
 }
 
 // Dger performs the rank-one operation
-//  A += alpha * x * y^T
+//
+//	A += alpha * x * y^T
+//
 // where A is an m×n dense matrix, x and y are vectors, and alpha is a scalar.
 func (impl *Standard) Dger(m, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) {
 	// declared at cublasgen.h:1107:17 enum CUBLAS_STATUS { ... } cublasDger ...
@@ -2616,23 +3384,32 @@ func (impl *Standard) Dger(m, n int, alpha float64, x []float64, incX int, y []f
 		return
 	}
 
+	if !impl.fits32("Dger", "m, n, incX, incY, lda", m, n, incX, incY, lda) {
+		return
+	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Dger", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dger", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dger", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Dger", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (m-1)*incX >= len(x)) || (incX < 0 && (1-m)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dger", "blas: x index out of range: m=%d, incX=%d, len(x)=%d", m, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Dger", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasDger(C.cublasHandle_t(impl.h), C.int(m), C.int(n), (*C.double)(&alpha), (*C.double)(&x[0]), C.int(incX), (*C.double)(&y[0]), C.int(incY), (*C.double)(&a[0]), C.int(lda)))
 }
@@ -2643,23 +3420,32 @@ func (impl *Standard) Cgeru(m, n int, alpha complex64, x []complex64, incX int,
 		return
 	}
 
+	if !impl.fits32("Cgeru", "m, n, incX, incY, lda", m, n, incX, incY, lda) {
+		return
+	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Cgeru", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Cgeru", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Cgeru", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Cgeru", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (m-1)*incX >= len(x)) || (incX < 0 && (1-m)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Cgeru", "blas: x index out of range: m=%d, incX=%d, len(x)=%d", m, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Cgeru", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasCgeru(C.cublasHandle_t(impl.h), C.int(m), C.int(n), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuComplex)(unsafe.Pointer(&y[0])), C.int(incY), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda)))
 }
@@ -2670,23 +3456,32 @@ func (impl *Standard) Cgerc(m, n int, alpha complex64, x []complex64, incX int,
 		return
 	}
 
+	if !impl.fits32("Cgerc", "m, n, incX, incY, lda", m, n, incX, incY, lda) {
+		return
+	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Cgerc", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Cgerc", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Cgerc", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Cgerc", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (m-1)*incX >= len(x)) || (incX < 0 && (1-m)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Cgerc", "blas: x index out of range: m=%d, incX=%d, len(x)=%d", m, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Cgerc", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasCgerc(C.cublasHandle_t(impl.h), C.int(m), C.int(n), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuComplex)(unsafe.Pointer(&y[0])), C.int(incY), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda)))
 }
@@ -2697,23 +3492,32 @@ func (impl *Standard) Zgeru(m, n int, alpha complex128, x []complex128, incX int
 		return
 	}
 
+	if !impl.fits32("Zgeru", "m, n, incX, incY, lda", m, n, incX, incY, lda) {
+		return
+	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Zgeru", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zgeru", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zgeru", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Zgeru", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (m-1)*incX >= len(x)) || (incX < 0 && (1-m)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zgeru", "blas: x index out of range: m=%d, incX=%d, len(x)=%d", m, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Zgeru", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasZgeru(C.cublasHandle_t(impl.h), C.int(m), C.int(n), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuDoubleComplex)(unsafe.Pointer(&y[0])), C.int(incY), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda)))
 }
@@ -2724,29 +3528,40 @@ func (impl *Standard) Zgerc(m, n int, alpha complex128, x []complex128, incX int
 		return
 	}
 
+	if !impl.fits32("Zgerc", "m, n, incX, incY, lda", m, n, incX, incY, lda) {
+		return
+	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Zgerc", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zgerc", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zgerc", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Zgerc", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (m-1)*incX >= len(x)) || (incX < 0 && (1-m)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zgerc", "blas: x index out of range: m=%d, incX=%d, len(x)=%d", m, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Zgerc", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasZgerc(C.cublasHandle_t(impl.h), C.int(m), C.int(n), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuDoubleComplex)(unsafe.Pointer(&y[0])), C.int(incY), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda)))
 }
 
 // Ssyr performs the rank-one update
-//  a += alpha * x * x^T
+//
+//	a += alpha * x * x^T
+//
 // where a is an n×n symmetric matrix, and x is a vector.
 func (impl *Standard) Ssyr(ul blas.Uplo, n int, alpha float32, x []float32, incX int, a []float32, lda int) {
 	// declared at cublasgen.h:1163:17 enum CUBLAS_STATUS { ... } cublasSsyr ...
@@ -2754,20 +3569,28 @@ func (impl *Standard) Ssyr(ul blas.Uplo, n int, alpha float32, x []float32, incX
 		return
 	}
 
+	if !impl.fits32("Ssyr", "n, incX, lda", n, incX, lda) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ssyr", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Ssyr", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Ssyr", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasSsyr(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.float)(&alpha), (*C.float)(&x[0]), C.int(incX), (*C.float)(&a[0]), C.int(lda)))
 }
 
 // Dsyr performs the rank-one update
-//  a += alpha * x * x^T
+//
+//	a += alpha * x * x^T
+//
 // where a is an n×n symmetric matrix, and x is a vector.
 func (impl *Standard) Dsyr(ul blas.Uplo, n int, alpha float64, x []float64, incX int, a []float64, lda int) {
 	// declared at cublasgen.h:1172:17 enum CUBLAS_STATUS { ... } cublasDsyr ...
@@ -2775,14 +3598,20 @@ func (impl *Standard) Dsyr(ul blas.Uplo, n int, alpha float64, x []float64, incX
 		return
 	}
 
+	if !impl.fits32("Dsyr", "n, incX, lda", n, incX, lda) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dsyr", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dsyr", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dsyr", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasDsyr(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.double)(&alpha), (*C.double)(&x[0]), C.int(incX), (*C.double)(&a[0]), C.int(lda)))
 }
@@ -2793,14 +3622,20 @@ func (impl *Standard) Csyr(ul blas.Uplo, n int, alpha complex64, x []complex64,
 		return
 	}
 
+	if !impl.fits32("Csyr", "n, incX, lda", n, incX, lda) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Csyr", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Csyr", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Csyr", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasCsyr(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda)))
 }
@@ -2811,14 +3646,20 @@ func (impl *Standard) Zsyr(ul blas.Uplo, n int, alpha complex128, x []complex128
 		return
 	}
 
+	if !impl.fits32("Zsyr", "n, incX, lda", n, incX, lda) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zsyr", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zsyr", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zsyr", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasZsyr(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda)))
 }
@@ -2829,14 +3670,20 @@ func (impl *Standard) Cher(ul blas.Uplo, n int, alpha float32, x []complex64, in
 		return
 	}
 
+	if !impl.fits32("Cher", "n, incX, lda", n, incX, lda) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Cher", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Cher", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Cher", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasCher(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.float)(&alpha), (*C.cuComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda)))
 }
@@ -2847,20 +3694,28 @@ func (impl *Standard) Zher(ul blas.Uplo, n int, alpha float64, x []complex128, i
 		return
 	}
 
+	if !impl.fits32("Zher", "n, incX, lda", n, incX, lda) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zher", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zher", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zher", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasZher(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.double)(&alpha), (*C.cuDoubleComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda)))
 }
 
 // Sspr computes the rank-one operation
-//  a += alpha * x * x^T
+//
+//	a += alpha * x * x^T
+//
 // where a is an n×n symmetric matrix in packed format, x is a vector, and
 // alpha is a scalar.
 func (impl *Standard) Sspr(ul blas.Uplo, n int, alpha float32, x []float32, incX int, aP []float32) {
@@ -2869,14 +3724,20 @@ func (impl *Standard) Sspr(ul blas.Uplo, n int, alpha float32, x []float32, incX
 		return
 	}
 
+	if !impl.fits32("Sspr", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Sspr", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Sspr", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Sspr", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -2885,7 +3746,9 @@ func (impl *Standard) Sspr(ul blas.Uplo, n int, alpha float32, x []float32, incX
 }
 
 // Dspr computes the rank-one operation
-//  a += alpha * x * x^T
+//
+//	a += alpha * x * x^T
+//
 // where a is an n×n symmetric matrix in packed format, x is a vector, and
 // alpha is a scalar.
 func (impl *Standard) Dspr(ul blas.Uplo, n int, alpha float64, x []float64, incX int, aP []float64) {
@@ -2894,14 +3757,20 @@ func (impl *Standard) Dspr(ul blas.Uplo, n int, alpha float64, x []float64, incX
 		return
 	}
 
+	if !impl.fits32("Dspr", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dspr", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dspr", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dspr", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -2915,14 +3784,20 @@ func (impl *Standard) Chpr(ul blas.Uplo, n int, alpha float32, x []complex64, in
 		return
 	}
 
+	if !impl.fits32("Chpr", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Chpr", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Chpr", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Chpr", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -2936,14 +3811,20 @@ func (impl *Standard) Zhpr(ul blas.Uplo, n int, alpha float64, x []complex128, i
 		return
 	}
 
+	if !impl.fits32("Zhpr", "n, incX", n, incX) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zhpr", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zhpr", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zhpr", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if n == 0 {
 		return
@@ -2952,7 +3833,9 @@ func (impl *Standard) Zhpr(ul blas.Uplo, n int, alpha float64, x []complex128, i
 }
 
 // Ssyr2 performs the symmetric rank-two update
-//  A += alpha * x * y^T + alpha * y * x^T
+//
+//	A += alpha * x * y^T + alpha * y * x^T
+//
 // where A is a symmetric n×n matrix, x and y are vectors, and alpha is a scalar.
 func (impl *Standard) Ssyr2(ul blas.Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int) {
 	// declared at cublasgen.h:1251:17 enum CUBLAS_STATUS { ... } cublasSsyr2 ...
@@ -2960,26 +3843,36 @@ func (impl *Standard) Ssyr2(ul blas.Uplo, n int, alpha float32, x []float32, inc
 		return
 	}
 
+	if !impl.fits32("Ssyr2", "n, incX, incY, lda", n, incX, incY, lda) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ssyr2", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Ssyr2", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Ssyr2", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Ssyr2", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Ssyr2", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasSsyr2(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.float)(&alpha), (*C.float)(&x[0]), C.int(incX), (*C.float)(&y[0]), C.int(incY), (*C.float)(&a[0]), C.int(lda)))
 }
 
 // Dsyr2 performs the symmetric rank-two update
-//  A += alpha * x * y^T + alpha * y * x^T
+//
+//	A += alpha * x * y^T + alpha * y * x^T
+//
 // where A is a symmetric n×n matrix, x and y are vectors, and alpha is a scalar.
 func (impl *Standard) Dsyr2(ul blas.Uplo, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) {
 	// declared at cublasgen.h:1262:17 enum CUBLAS_STATUS { ... } cublasDsyr2 ...
@@ -2987,20 +3880,28 @@ func (impl *Standard) Dsyr2(ul blas.Uplo, n int, alpha float64, x []float64, inc
 		return
 	}
 
+	if !impl.fits32("Dsyr2", "n, incX, incY, lda", n, incX, incY, lda) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dsyr2", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dsyr2", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Dsyr2", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dsyr2", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Dsyr2", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasDsyr2(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.double)(&alpha), (*C.double)(&x[0]), C.int(incX), (*C.double)(&y[0]), C.int(incY), (*C.double)(&a[0]), C.int(lda)))
 }
@@ -3011,20 +3912,28 @@ func (impl *Standard) Csyr2(ul blas.Uplo, n int, alpha complex64, x []complex64,
 		return
 	}
 
+	if !impl.fits32("Csyr2", "n, incX, incY, lda", n, incX, incY, lda) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Csyr2", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Csyr2", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Csyr2", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Csyr2", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Csyr2", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasCsyr2(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuComplex)(unsafe.Pointer(&y[0])), C.int(incY), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda)))
 }
@@ -3035,20 +3944,28 @@ func (impl *Standard) Zsyr2(ul blas.Uplo, n int, alpha complex128, x []complex12
 		return
 	}
 
+	if !impl.fits32("Zsyr2", "n, incX, incY, lda", n, incX, incY, lda) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zsyr2", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zsyr2", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Zsyr2", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zsyr2", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Zsyr2", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasZsyr2(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuDoubleComplex)(unsafe.Pointer(&y[0])), C.int(incY), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda)))
 }
@@ -3059,20 +3976,28 @@ func (impl *Standard) Cher2(ul blas.Uplo, n int, alpha complex64, x []complex64,
 		return
 	}
 
+	if !impl.fits32("Cher2", "n, incX, incY, lda", n, incX, incY, lda) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Cher2", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Cher2", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Cher2", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Cher2", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Cher2", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasCher2(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuComplex)(unsafe.Pointer(&y[0])), C.int(incY), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda)))
 }
@@ -3083,26 +4008,36 @@ func (impl *Standard) Zher2(ul blas.Uplo, n int, alpha complex128, x []complex12
 		return
 	}
 
+	if !impl.fits32("Zher2", "n, incX, incY, lda", n, incX, incY, lda) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zher2", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zher2", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Zher2", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zher2", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Zher2", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	impl.e = status(C.cublasZher2(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuDoubleComplex)(unsafe.Pointer(&y[0])), C.int(incY), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda)))
 }
 
 // Sspr2 performs the symmetric rank-2 update
-//  A += alpha * x * y^T + alpha * y * x^T,
+//
+//	A += alpha * x * y^T + alpha * y * x^T,
+//
 // where A is an n×n symmetric matrix in packed format, x and y are vectors,
 // and alpha is a scalar.
 func (impl *Standard) Sspr2(ul blas.Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, aP []float32) {
@@ -3111,20 +4046,28 @@ func (impl *Standard) Sspr2(ul blas.Uplo, n int, alpha float32, x []float32, inc
 		return
 	}
 
+	if !impl.fits32("Sspr2", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Sspr2", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Sspr2", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Sspr2", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Sspr2", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Sspr2", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -3133,7 +4076,9 @@ func (impl *Standard) Sspr2(ul blas.Uplo, n int, alpha float32, x []float32, inc
 }
 
 // Dspr2 performs the symmetric rank-2 update
-//  A += alpha * x * y^T + alpha * y * x^T,
+//
+//	A += alpha * x * y^T + alpha * y * x^T,
+//
 // where A is an n×n symmetric matrix in packed format, x and y are vectors,
 // and alpha is a scalar.
 func (impl *Standard) Dspr2(ul blas.Uplo, n int, alpha float64, x []float64, incX int, y []float64, incY int, aP []float64) {
@@ -3142,20 +4087,28 @@ func (impl *Standard) Dspr2(ul blas.Uplo, n int, alpha float64, x []float64, inc
 		return
 	}
 
+	if !impl.fits32("Dspr2", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dspr2", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Dspr2", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Dspr2", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Dspr2", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Dspr2", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -3169,20 +4122,28 @@ func (impl *Standard) Chpr2(ul blas.Uplo, n int, alpha complex64, x []complex64,
 		return
 	}
 
+	if !impl.fits32("Chpr2", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Chpr2", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Chpr2", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Chpr2", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Chpr2", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Chpr2", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -3196,20 +4157,28 @@ func (impl *Standard) Zhpr2(ul blas.Uplo, n int, alpha complex128, x []complex12
 		return
 	}
 
+	if !impl.fits32("Zhpr2", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zhpr2", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zhpr2", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Zhpr2", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zhpr2", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Zhpr2", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return
@@ -3218,7 +4187,9 @@ func (impl *Standard) Zhpr2(ul blas.Uplo, n int, alpha complex128, x []complex12
 }
 
 // Sgemm computes
-//  C = beta * C + alpha * A * B,
+//
+//	C = beta * C + alpha * A * B,
+//
 // where A, B, and C are dense matrices, and alpha and beta are scalars.
 // tA and tB specify whether A or B are transposed.
 func (impl *Standard) Sgemm(tA, tB blas.Transpose, m, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
@@ -3227,26 +4198,36 @@ func (impl *Standard) Sgemm(tA, tB blas.Transpose, m, n, k int, alpha float32, a
 		return
 	}
 
+	if !impl.fits32("Sgemm", "m, n, k, lda, ldb, ldc", m, n, k, lda, ldb, ldc) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Sgemm", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Sgemm", "blas: illegal transpose tB=%v", tB)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Sgemm", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Sgemm", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Sgemm", "blas: k=%d < 0", k)
+		return
 	}
 	impl.e = status(C.cublasSgemm(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), trans2cublasTrans(tB), C.int(m), C.int(n), C.int(k), (*C.float)(&alpha), (*C.float)(&a[0]), C.int(lda), (*C.float)(&b[0]), C.int(ldb), (*C.float)(&beta), (*C.float)(&c[0]), C.int(ldc)))
 }
 
 // Dgemm computes
-//  C = beta * C + alpha * A * B,
+//
+//	C = beta * C + alpha * A * B,
+//
 // where A, B, and C are dense matrices, and alpha and beta are scalars.
 // tA and tB specify whether A or B are transposed.
 func (impl *Standard) Dgemm(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
@@ -3255,20 +4236,28 @@ func (impl *Standard) Dgemm(tA, tB blas.Transpose, m, n, k int, alpha float64, a
 		return
 	}
 
+	if !impl.fits32("Dgemm", "m, n, k, lda, ldb, ldc", m, n, k, lda, ldb, ldc) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Dgemm", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Dgemm", "blas: illegal transpose tB=%v", tB)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Dgemm", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dgemm", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Dgemm", "blas: k=%d < 0", k)
+		return
 	}
 	impl.e = status(C.cublasDgemm(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), trans2cublasTrans(tB), C.int(m), C.int(n), C.int(k), (*C.double)(&alpha), (*C.double)(&a[0]), C.int(lda), (*C.double)(&b[0]), C.int(ldb), (*C.double)(&beta), (*C.double)(&c[0]), C.int(ldc)))
 }
@@ -3279,20 +4268,28 @@ func (impl *Standard) Cgemm(tA, tB blas.Transpose, m, n, k int, alpha complex64,
 		return
 	}
 
+	if !impl.fits32("Cgemm", "m, n, k, lda, ldb, ldc", m, n, k, lda, ldb, ldc) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Cgemm", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Cgemm", "blas: illegal transpose tB=%v", tB)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Cgemm", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Cgemm", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Cgemm", "blas: k=%d < 0", k)
+		return
 	}
 	impl.e = status(C.cublasCgemm(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), trans2cublasTrans(tB), C.int(m), C.int(n), C.int(k), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&b[0])), C.int(ldb), (*C.cuComplex)(unsafe.Pointer(&beta)), (*C.cuComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
@@ -3303,20 +4300,28 @@ func (impl *Standard) Cgemm3m(tA, tB blas.Transpose, m, n, k int, alpha complex6
 		return
 	}
 
+	if !impl.fits32("Cgemm3m", "m, n, k, lda, ldb, ldc", m, n, k, lda, ldb, ldc) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Cgemm3m", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Cgemm3m", "blas: illegal transpose tB=%v", tB)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Cgemm3m", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Cgemm3m", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Cgemm3m", "blas: k=%d < 0", k)
+		return
 	}
 	impl.e = status(C.cublasCgemm3m(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), trans2cublasTrans(tB), C.int(m), C.int(n), C.int(k), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&b[0])), C.int(ldb), (*C.cuComplex)(unsafe.Pointer(&beta)), (*C.cuComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
@@ -3327,20 +4332,28 @@ func (impl *Standard) Zgemm(tA, tB blas.Transpose, m, n, k int, alpha complex128
 		return
 	}
 
+	if !impl.fits32("Zgemm", "m, n, k, lda, ldb, ldc", m, n, k, lda, ldb, ldc) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Zgemm", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Zgemm", "blas: illegal transpose tB=%v", tB)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Zgemm", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zgemm", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Zgemm", "blas: k=%d < 0", k)
+		return
 	}
 	impl.e = status(C.cublasZgemm(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), trans2cublasTrans(tB), C.int(m), C.int(n), C.int(k), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&b[0])), C.int(ldb), (*C.cuDoubleComplex)(unsafe.Pointer(&beta)), (*C.cuDoubleComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
@@ -3351,26 +4364,36 @@ func (impl *Standard) Zgemm3m(tA, tB blas.Transpose, m, n, k int, alpha complex1
 		return
 	}
 
+	if !impl.fits32("Zgemm3m", "m, n, k, lda, ldb, ldc", m, n, k, lda, ldb, ldc) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Zgemm3m", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Zgemm3m", "blas: illegal transpose tB=%v", tB)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Zgemm3m", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zgemm3m", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Zgemm3m", "blas: k=%d < 0", k)
+		return
 	}
 	impl.e = status(C.cublasZgemm3m(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), trans2cublasTrans(tB), C.int(m), C.int(n), C.int(k), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&b[0])), C.int(ldb), (*C.cuDoubleComplex)(unsafe.Pointer(&beta)), (*C.cuDoubleComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
 
 // Ssyrk performs the symmetric rank-k operation
-//  C = alpha * A * A^T + beta*C
+//
+//	C = alpha * A * A^T + beta*C
+//
 // C is an n×n symmetric matrix. A is an n×k matrix if tA == blas.NoTrans, and
 // a k×n matrix otherwise. alpha and beta are scalars.
 func (impl *Standard) Ssyrk(ul blas.Uplo, t blas.Transpose, n, k int, alpha float32, a []float32, lda int, beta float32, c []float32, ldc int) {
@@ -3379,14 +4402,20 @@ func (impl *Standard) Ssyrk(ul blas.Uplo, t blas.Transpose, n, k int, alpha floa
 		return
 	}
 
+	if !impl.fits32("Ssyrk", "n, k, lda, ldc", n, k, lda, ldc) {
+		return
+	}
 	if t != blas.NoTrans && t != blas.Trans && t != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Ssyrk", "blas: illegal transpose t=%v", t)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ssyrk", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Ssyrk", "blas: k=%d < 0", k)
+		return
 	}
 	var row, col int
 	if t == blas.NoTrans {
@@ -3394,17 +4423,29 @@ func (impl *Standard) Ssyrk(ul blas.Uplo, t blas.Transpose, n, k int, alpha floa
 	} else {
 		row, col = k, n
 	}
-	if lda*(row-1)+col > len(a) || lda < max(1, col) {
-		panic("blas: index of a out of range")
+	if lda < max(1, col) {
+		impl.shapeError("Ssyrk", "blas: lda=%d < max(1, col=%d)", lda, col)
+		return
+	}
+	if lda*(row-1)+col > len(a) {
+		impl.shapeError("Ssyrk", "blas: index of a out of range: lda*(row-1)+col=%d > len(a)=%d", lda*(row-1)+col, len(a))
+		return
+	}
+	if ldc < max(1, n) {
+		impl.shapeError("Ssyrk", "blas: ldc=%d < max(1, n=%d)", ldc, n)
+		return
 	}
-	if ldc*(n-1)+n > len(c) || ldc < max(1, n) {
-		panic("blas: index of c out of range")
+	if ldc*(n-1)+n > len(c) {
+		impl.shapeError("Ssyrk", "blas: index of c out of range: ldc*(n-1)+n=%d > len(c)=%d", ldc*(n-1)+n, len(c))
+		return
 	}
 	impl.e = status(C.cublasSsyrk(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(t), C.int(n), C.int(k), (*C.float)(&alpha), (*C.float)(&a[0]), C.int(lda), (*C.float)(&beta), (*C.float)(&c[0]), C.int(ldc)))
 }
 
 // Dsyrk performs the symmetric rank-k operation
-//  C = alpha * A * A^T + beta*C
+//
+//	C = alpha * A * A^T + beta*C
+//
 // C is an n×n symmetric matrix. A is an n×k matrix if tA == blas.NoTrans, and
 // a k×n matrix otherwise. alpha and beta are scalars.
 func (impl *Standard) Dsyrk(ul blas.Uplo, t blas.Transpose, n, k int, alpha float64, a []float64, lda int, beta float64, c []float64, ldc int) {
@@ -3413,14 +4454,20 @@ func (impl *Standard) Dsyrk(ul blas.Uplo, t blas.Transpose, n, k int, alpha floa
 		return
 	}
 
+	if !impl.fits32("Dsyrk", "n, k, lda, ldc", n, k, lda, ldc) {
+		return
+	}
 	if t != blas.NoTrans && t != blas.Trans && t != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Dsyrk", "blas: illegal transpose t=%v", t)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dsyrk", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Dsyrk", "blas: k=%d < 0", k)
+		return
 	}
 	var row, col int
 	if t == blas.NoTrans {
@@ -3428,11 +4475,21 @@ func (impl *Standard) Dsyrk(ul blas.Uplo, t blas.Transpose, n, k int, alpha floa
 	} else {
 		row, col = k, n
 	}
-	if lda*(row-1)+col > len(a) || lda < max(1, col) {
-		panic("blas: index of a out of range")
+	if lda < max(1, col) {
+		impl.shapeError("Dsyrk", "blas: lda=%d < max(1, col=%d)", lda, col)
+		return
+	}
+	if lda*(row-1)+col > len(a) {
+		impl.shapeError("Dsyrk", "blas: index of a out of range: lda*(row-1)+col=%d > len(a)=%d", lda*(row-1)+col, len(a))
+		return
+	}
+	if ldc < max(1, n) {
+		impl.shapeError("Dsyrk", "blas: ldc=%d < max(1, n=%d)", ldc, n)
+		return
 	}
-	if ldc*(n-1)+n > len(c) || ldc < max(1, n) {
-		panic("blas: index of c out of range")
+	if ldc*(n-1)+n > len(c) {
+		impl.shapeError("Dsyrk", "blas: index of c out of range: ldc*(n-1)+n=%d > len(c)=%d", ldc*(n-1)+n, len(c))
+		return
 	}
 	impl.e = status(C.cublasDsyrk(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(t), C.int(n), C.int(k), (*C.double)(&alpha), (*C.double)(&a[0]), C.int(lda), (*C.double)(&beta), (*C.double)(&c[0]), C.int(ldc)))
 }
@@ -3443,14 +4500,20 @@ func (impl *Standard) Csyrk(ul blas.Uplo, t blas.Transpose, n, k int, alpha comp
 		return
 	}
 
+	if !impl.fits32("Csyrk", "n, k, lda, ldc", n, k, lda, ldc) {
+		return
+	}
 	if t != blas.NoTrans && t != blas.Trans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Csyrk", "blas: illegal transpose t=%v", t)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Csyrk", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Csyrk", "blas: k=%d < 0", k)
+		return
 	}
 	var row, col int
 	if t == blas.NoTrans {
@@ -3458,11 +4521,21 @@ func (impl *Standard) Csyrk(ul blas.Uplo, t blas.Transpose, n, k int, alpha comp
 	} else {
 		row, col = k, n
 	}
-	if lda*(row-1)+col > len(a) || lda < max(1, col) {
-		panic("blas: index of a out of range")
+	if lda < max(1, col) {
+		impl.shapeError("Csyrk", "blas: lda=%d < max(1, col=%d)", lda, col)
+		return
+	}
+	if lda*(row-1)+col > len(a) {
+		impl.shapeError("Csyrk", "blas: index of a out of range: lda*(row-1)+col=%d > len(a)=%d", lda*(row-1)+col, len(a))
+		return
+	}
+	if ldc < max(1, n) {
+		impl.shapeError("Csyrk", "blas: ldc=%d < max(1, n=%d)", ldc, n)
+		return
 	}
-	if ldc*(n-1)+n > len(c) || ldc < max(1, n) {
-		panic("blas: index of c out of range")
+	if ldc*(n-1)+n > len(c) {
+		impl.shapeError("Csyrk", "blas: index of c out of range: ldc*(n-1)+n=%d > len(c)=%d", ldc*(n-1)+n, len(c))
+		return
 	}
 	impl.e = status(C.cublasCsyrk(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(t), C.int(n), C.int(k), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&beta)), (*C.cuComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
@@ -3473,14 +4546,20 @@ func (impl *Standard) Zsyrk(ul blas.Uplo, t blas.Transpose, n, k int, alpha comp
 		return
 	}
 
+	if !impl.fits32("Zsyrk", "n, k, lda, ldc", n, k, lda, ldc) {
+		return
+	}
 	if t != blas.NoTrans && t != blas.Trans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Zsyrk", "blas: illegal transpose t=%v", t)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zsyrk", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Zsyrk", "blas: k=%d < 0", k)
+		return
 	}
 	var row, col int
 	if t == blas.NoTrans {
@@ -3488,11 +4567,21 @@ func (impl *Standard) Zsyrk(ul blas.Uplo, t blas.Transpose, n, k int, alpha comp
 	} else {
 		row, col = k, n
 	}
-	if lda*(row-1)+col > len(a) || lda < max(1, col) {
-		panic("blas: index of a out of range")
+	if lda < max(1, col) {
+		impl.shapeError("Zsyrk", "blas: lda=%d < max(1, col=%d)", lda, col)
+		return
+	}
+	if lda*(row-1)+col > len(a) {
+		impl.shapeError("Zsyrk", "blas: index of a out of range: lda*(row-1)+col=%d > len(a)=%d", lda*(row-1)+col, len(a))
+		return
+	}
+	if ldc < max(1, n) {
+		impl.shapeError("Zsyrk", "blas: ldc=%d < max(1, n=%d)", ldc, n)
+		return
 	}
-	if ldc*(n-1)+n > len(c) || ldc < max(1, n) {
-		panic("blas: index of c out of range")
+	if ldc*(n-1)+n > len(c) {
+		impl.shapeError("Zsyrk", "blas: index of c out of range: ldc*(n-1)+n=%d > len(c)=%d", ldc*(n-1)+n, len(c))
+		return
 	}
 	impl.e = status(C.cublasZsyrk(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(t), C.int(n), C.int(k), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&beta)), (*C.cuDoubleComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
@@ -3503,14 +4592,20 @@ func (impl *Standard) Cherk(ul blas.Uplo, t blas.Transpose, n, k int, alpha floa
 		return
 	}
 
+	if !impl.fits32("Cherk", "n, k, lda, ldc", n, k, lda, ldc) {
+		return
+	}
 	if t != blas.NoTrans && t != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Cherk", "blas: illegal transpose t=%v", t)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Cherk", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Cherk", "blas: k=%d < 0", k)
+		return
 	}
 	var row, col int
 	if t == blas.NoTrans {
@@ -3518,11 +4613,21 @@ func (impl *Standard) Cherk(ul blas.Uplo, t blas.Transpose, n, k int, alpha floa
 	} else {
 		row, col = k, n
 	}
-	if lda*(row-1)+col > len(a) || lda < max(1, col) {
-		panic("blas: index of a out of range")
+	if lda < max(1, col) {
+		impl.shapeError("Cherk", "blas: lda=%d < max(1, col=%d)", lda, col)
+		return
+	}
+	if lda*(row-1)+col > len(a) {
+		impl.shapeError("Cherk", "blas: index of a out of range: lda*(row-1)+col=%d > len(a)=%d", lda*(row-1)+col, len(a))
+		return
+	}
+	if ldc < max(1, n) {
+		impl.shapeError("Cherk", "blas: ldc=%d < max(1, n=%d)", ldc, n)
+		return
 	}
-	if ldc*(n-1)+n > len(c) || ldc < max(1, n) {
-		panic("blas: index of c out of range")
+	if ldc*(n-1)+n > len(c) {
+		impl.shapeError("Cherk", "blas: index of c out of range: ldc*(n-1)+n=%d > len(c)=%d", ldc*(n-1)+n, len(c))
+		return
 	}
 	impl.e = status(C.cublasCherk(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(t), C.int(n), C.int(k), (*C.float)(&alpha), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.float)(&beta), (*C.cuComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
@@ -3533,14 +4638,20 @@ func (impl *Standard) Zherk(ul blas.Uplo, t blas.Transpose, n, k int, alpha floa
 		return
 	}
 
+	if !impl.fits32("Zherk", "n, k, lda, ldc", n, k, lda, ldc) {
+		return
+	}
 	if t != blas.NoTrans && t != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Zherk", "blas: illegal transpose t=%v", t)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zherk", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Zherk", "blas: k=%d < 0", k)
+		return
 	}
 	var row, col int
 	if t == blas.NoTrans {
@@ -3548,17 +4659,29 @@ func (impl *Standard) Zherk(ul blas.Uplo, t blas.Transpose, n, k int, alpha floa
 	} else {
 		row, col = k, n
 	}
-	if lda*(row-1)+col > len(a) || lda < max(1, col) {
-		panic("blas: index of a out of range")
+	if lda < max(1, col) {
+		impl.shapeError("Zherk", "blas: lda=%d < max(1, col=%d)", lda, col)
+		return
+	}
+	if lda*(row-1)+col > len(a) {
+		impl.shapeError("Zherk", "blas: index of a out of range: lda*(row-1)+col=%d > len(a)=%d", lda*(row-1)+col, len(a))
+		return
+	}
+	if ldc < max(1, n) {
+		impl.shapeError("Zherk", "blas: ldc=%d < max(1, n=%d)", ldc, n)
+		return
 	}
-	if ldc*(n-1)+n > len(c) || ldc < max(1, n) {
-		panic("blas: index of c out of range")
+	if ldc*(n-1)+n > len(c) {
+		impl.shapeError("Zherk", "blas: index of c out of range: ldc*(n-1)+n=%d > len(c)=%d", ldc*(n-1)+n, len(c))
+		return
 	}
 	impl.e = status(C.cublasZherk(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(t), C.int(n), C.int(k), (*C.double)(&alpha), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.double)(&beta), (*C.cuDoubleComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
 
 // Ssyr2k performs the symmetric rank 2k operation
-//  C = alpha * A * B^T + alpha * B * A^T + beta * C
+//
+//	C = alpha * A * B^T + alpha * B * A^T + beta * C
+//
 // where C is an n×n symmetric matrix. A and B are n×k matrices if
 // tA == NoTrans and k×n otherwise. alpha and beta are scalars.
 func (impl *Standard) Ssyr2k(ul blas.Uplo, t blas.Transpose, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
@@ -3567,14 +4690,20 @@ func (impl *Standard) Ssyr2k(ul blas.Uplo, t blas.Transpose, n, k int, alpha flo
 		return
 	}
 
+	if !impl.fits32("Ssyr2k", "n, k, lda, ldb, ldc", n, k, lda, ldb, ldc) {
+		return
+	}
 	if t != blas.NoTrans && t != blas.Trans && t != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Ssyr2k", "blas: illegal transpose t=%v", t)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ssyr2k", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Ssyr2k", "blas: k=%d < 0", k)
+		return
 	}
 	var row, col int
 	if t == blas.NoTrans {
@@ -3582,20 +4711,37 @@ func (impl *Standard) Ssyr2k(ul blas.Uplo, t blas.Transpose, n, k int, alpha flo
 	} else {
 		row, col = k, n
 	}
-	if lda*(row-1)+col > len(a) || lda < max(1, col) {
-		panic("blas: index of a out of range")
+	if lda < max(1, col) {
+		impl.shapeError("Ssyr2k", "blas: lda=%d < max(1, col=%d)", lda, col)
+		return
+	}
+	if lda*(row-1)+col > len(a) {
+		impl.shapeError("Ssyr2k", "blas: index of a out of range: lda*(row-1)+col=%d > len(a)=%d", lda*(row-1)+col, len(a))
+		return
+	}
+	if ldb < max(1, col) {
+		impl.shapeError("Ssyr2k", "blas: ldb=%d < max(1, col=%d)", ldb, col)
+		return
+	}
+	if ldb*(row-1)+col > len(b) {
+		impl.shapeError("Ssyr2k", "blas: index of b out of range: ldb*(row-1)+col=%d > len(b)=%d", ldb*(row-1)+col, len(b))
+		return
 	}
-	if ldb*(row-1)+col > len(b) || ldb < max(1, col) {
-		panic("blas: index of b out of range")
+	if ldc < max(1, n) {
+		impl.shapeError("Ssyr2k", "blas: ldc=%d < max(1, n=%d)", ldc, n)
+		return
 	}
-	if ldc*(n-1)+n > len(c) || ldc < max(1, n) {
-		panic("blas: index of c out of range")
+	if ldc*(n-1)+n > len(c) {
+		impl.shapeError("Ssyr2k", "blas: index of c out of range: ldc*(n-1)+n=%d > len(c)=%d", ldc*(n-1)+n, len(c))
+		return
 	}
 	impl.e = status(C.cublasSsyr2k(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(t), C.int(n), C.int(k), (*C.float)(&alpha), (*C.float)(&a[0]), C.int(lda), (*C.float)(&b[0]), C.int(ldb), (*C.float)(&beta), (*C.float)(&c[0]), C.int(ldc)))
 }
 
 // Dsyr2k performs the symmetric rank 2k operation
-//  C = alpha * A * B^T + alpha * B * A^T + beta * C
+//
+//	C = alpha * A * B^T + alpha * B * A^T + beta * C
+//
 // where C is an n×n symmetric matrix. A and B are n×k matrices if
 // tA == NoTrans and k×n otherwise. alpha and beta are scalars.
 func (impl *Standard) Dsyr2k(ul blas.Uplo, t blas.Transpose, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
@@ -3604,14 +4750,20 @@ func (impl *Standard) Dsyr2k(ul blas.Uplo, t blas.Transpose, n, k int, alpha flo
 		return
 	}
 
+	if !impl.fits32("Dsyr2k", "n, k, lda, ldb, ldc", n, k, lda, ldb, ldc) {
+		return
+	}
 	if t != blas.NoTrans && t != blas.Trans && t != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Dsyr2k", "blas: illegal transpose t=%v", t)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dsyr2k", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Dsyr2k", "blas: k=%d < 0", k)
+		return
 	}
 	var row, col int
 	if t == blas.NoTrans {
@@ -3619,14 +4771,29 @@ func (impl *Standard) Dsyr2k(ul blas.Uplo, t blas.Transpose, n, k int, alpha flo
 	} else {
 		row, col = k, n
 	}
-	if lda*(row-1)+col > len(a) || lda < max(1, col) {
-		panic("blas: index of a out of range")
+	if lda < max(1, col) {
+		impl.shapeError("Dsyr2k", "blas: lda=%d < max(1, col=%d)", lda, col)
+		return
+	}
+	if lda*(row-1)+col > len(a) {
+		impl.shapeError("Dsyr2k", "blas: index of a out of range: lda*(row-1)+col=%d > len(a)=%d", lda*(row-1)+col, len(a))
+		return
+	}
+	if ldb < max(1, col) {
+		impl.shapeError("Dsyr2k", "blas: ldb=%d < max(1, col=%d)", ldb, col)
+		return
+	}
+	if ldb*(row-1)+col > len(b) {
+		impl.shapeError("Dsyr2k", "blas: index of b out of range: ldb*(row-1)+col=%d > len(b)=%d", ldb*(row-1)+col, len(b))
+		return
 	}
-	if ldb*(row-1)+col > len(b) || ldb < max(1, col) {
-		panic("blas: index of b out of range")
+	if ldc < max(1, n) {
+		impl.shapeError("Dsyr2k", "blas: ldc=%d < max(1, n=%d)", ldc, n)
+		return
 	}
-	if ldc*(n-1)+n > len(c) || ldc < max(1, n) {
-		panic("blas: index of c out of range")
+	if ldc*(n-1)+n > len(c) {
+		impl.shapeError("Dsyr2k", "blas: index of c out of range: ldc*(n-1)+n=%d > len(c)=%d", ldc*(n-1)+n, len(c))
+		return
 	}
 	impl.e = status(C.cublasDsyr2k(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(t), C.int(n), C.int(k), (*C.double)(&alpha), (*C.double)(&a[0]), C.int(lda), (*C.double)(&b[0]), C.int(ldb), (*C.double)(&beta), (*C.double)(&c[0]), C.int(ldc)))
 }
@@ -3637,14 +4804,20 @@ func (impl *Standard) Csyr2k(ul blas.Uplo, t blas.Transpose, n, k int, alpha com
 		return
 	}
 
+	if !impl.fits32("Csyr2k", "n, k, lda, ldb, ldc", n, k, lda, ldb, ldc) {
+		return
+	}
 	if t != blas.NoTrans && t != blas.Trans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Csyr2k", "blas: illegal transpose t=%v", t)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Csyr2k", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Csyr2k", "blas: k=%d < 0", k)
+		return
 	}
 	var row, col int
 	if t == blas.NoTrans {
@@ -3652,14 +4825,29 @@ func (impl *Standard) Csyr2k(ul blas.Uplo, t blas.Transpose, n, k int, alpha com
 	} else {
 		row, col = k, n
 	}
-	if lda*(row-1)+col > len(a) || lda < max(1, col) {
-		panic("blas: index of a out of range")
+	if lda < max(1, col) {
+		impl.shapeError("Csyr2k", "blas: lda=%d < max(1, col=%d)", lda, col)
+		return
+	}
+	if lda*(row-1)+col > len(a) {
+		impl.shapeError("Csyr2k", "blas: index of a out of range: lda*(row-1)+col=%d > len(a)=%d", lda*(row-1)+col, len(a))
+		return
+	}
+	if ldb < max(1, col) {
+		impl.shapeError("Csyr2k", "blas: ldb=%d < max(1, col=%d)", ldb, col)
+		return
+	}
+	if ldb*(row-1)+col > len(b) {
+		impl.shapeError("Csyr2k", "blas: index of b out of range: ldb*(row-1)+col=%d > len(b)=%d", ldb*(row-1)+col, len(b))
+		return
 	}
-	if ldb*(row-1)+col > len(b) || ldb < max(1, col) {
-		panic("blas: index of b out of range")
+	if ldc < max(1, n) {
+		impl.shapeError("Csyr2k", "blas: ldc=%d < max(1, n=%d)", ldc, n)
+		return
 	}
-	if ldc*(n-1)+n > len(c) || ldc < max(1, n) {
-		panic("blas: index of c out of range")
+	if ldc*(n-1)+n > len(c) {
+		impl.shapeError("Csyr2k", "blas: index of c out of range: ldc*(n-1)+n=%d > len(c)=%d", ldc*(n-1)+n, len(c))
+		return
 	}
 	impl.e = status(C.cublasCsyr2k(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(t), C.int(n), C.int(k), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&b[0])), C.int(ldb), (*C.cuComplex)(unsafe.Pointer(&beta)), (*C.cuComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
@@ -3670,14 +4858,20 @@ func (impl *Standard) Zsyr2k(ul blas.Uplo, t blas.Transpose, n, k int, alpha com
 		return
 	}
 
+	if !impl.fits32("Zsyr2k", "n, k, lda, ldb, ldc", n, k, lda, ldb, ldc) {
+		return
+	}
 	if t != blas.NoTrans && t != blas.Trans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Zsyr2k", "blas: illegal transpose t=%v", t)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zsyr2k", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Zsyr2k", "blas: k=%d < 0", k)
+		return
 	}
 	var row, col int
 	if t == blas.NoTrans {
@@ -3685,16 +4879,31 @@ func (impl *Standard) Zsyr2k(ul blas.Uplo, t blas.Transpose, n, k int, alpha com
 	} else {
 		row, col = k, n
 	}
-	if lda*(row-1)+col > len(a) || lda < max(1, col) {
-		panic("blas: index of a out of range")
+	if lda < max(1, col) {
+		impl.shapeError("Zsyr2k", "blas: lda=%d < max(1, col=%d)", lda, col)
+		return
 	}
-	if ldb*(row-1)+col > len(b) || ldb < max(1, col) {
-		panic("blas: index of b out of range")
+	if lda*(row-1)+col > len(a) {
+		impl.shapeError("Zsyr2k", "blas: index of a out of range: lda*(row-1)+col=%d > len(a)=%d", lda*(row-1)+col, len(a))
+		return
 	}
-	if ldc*(n-1)+n > len(c) || ldc < max(1, n) {
-		panic("blas: index of c out of range")
+	if ldb < max(1, col) {
+		impl.shapeError("Zsyr2k", "blas: ldb=%d < max(1, col=%d)", ldb, col)
+		return
 	}
-	impl.e = status(C.cublasZsyr2k(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(t), C.int(n), C.int(k), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&b[0])), C.int(ldb), (*C.cuDoubleComplex)(unsafe.Pointer(&beta)), (*C.cuDoubleComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
+	if ldb*(row-1)+col > len(b) {
+		impl.shapeError("Zsyr2k", "blas: index of b out of range: ldb*(row-1)+col=%d > len(b)=%d", ldb*(row-1)+col, len(b))
+		return
+	}
+	if ldc < max(1, n) {
+		impl.shapeError("Zsyr2k", "blas: ldc=%d < max(1, n=%d)", ldc, n)
+		return
+	}
+	if ldc*(n-1)+n > len(c) {
+		impl.shapeError("Zsyr2k", "blas: index of c out of range: ldc*(n-1)+n=%d > len(c)=%d", ldc*(n-1)+n, len(c))
+		return
+	}
+	impl.e = status(C.cublasZsyr2k(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(t), C.int(n), C.int(k), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&b[0])), C.int(ldb), (*C.cuDoubleComplex)(unsafe.Pointer(&beta)), (*C.cuDoubleComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
 
 func (impl *Standard) Cher2k(ul blas.Uplo, t blas.Transpose, n, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta float32, c []complex64, ldc int) {
@@ -3703,14 +4912,20 @@ func (impl *Standard) Cher2k(ul blas.Uplo, t blas.Transpose, n, k int, alpha com
 		return
 	}
 
+	if !impl.fits32("Cher2k", "n, k, lda, ldb, ldc", n, k, lda, ldb, ldc) {
+		return
+	}
 	if t != blas.NoTrans && t != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Cher2k", "blas: illegal transpose t=%v", t)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Cher2k", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Cher2k", "blas: k=%d < 0", k)
+		return
 	}
 	var row, col int
 	if t == blas.NoTrans {
@@ -3718,14 +4933,29 @@ func (impl *Standard) Cher2k(ul blas.Uplo, t blas.Transpose, n, k int, alpha com
 	} else {
 		row, col = k, n
 	}
-	if lda*(row-1)+col > len(a) || lda < max(1, col) {
-		panic("blas: index of a out of range")
+	if lda < max(1, col) {
+		impl.shapeError("Cher2k", "blas: lda=%d < max(1, col=%d)", lda, col)
+		return
+	}
+	if lda*(row-1)+col > len(a) {
+		impl.shapeError("Cher2k", "blas: index of a out of range: lda*(row-1)+col=%d > len(a)=%d", lda*(row-1)+col, len(a))
+		return
+	}
+	if ldb < max(1, col) {
+		impl.shapeError("Cher2k", "blas: ldb=%d < max(1, col=%d)", ldb, col)
+		return
+	}
+	if ldb*(row-1)+col > len(b) {
+		impl.shapeError("Cher2k", "blas: index of b out of range: ldb*(row-1)+col=%d > len(b)=%d", ldb*(row-1)+col, len(b))
+		return
 	}
-	if ldb*(row-1)+col > len(b) || ldb < max(1, col) {
-		panic("blas: index of b out of range")
+	if ldc < max(1, n) {
+		impl.shapeError("Cher2k", "blas: ldc=%d < max(1, n=%d)", ldc, n)
+		return
 	}
-	if ldc*(n-1)+n > len(c) || ldc < max(1, n) {
-		panic("blas: index of c out of range")
+	if ldc*(n-1)+n > len(c) {
+		impl.shapeError("Cher2k", "blas: index of c out of range: ldc*(n-1)+n=%d > len(c)=%d", ldc*(n-1)+n, len(c))
+		return
 	}
 	impl.e = status(C.cublasCher2k(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(t), C.int(n), C.int(k), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&b[0])), C.int(ldb), (*C.float)(&beta), (*C.cuComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
@@ -3736,14 +4966,20 @@ func (impl *Standard) Zher2k(ul blas.Uplo, t blas.Transpose, n, k int, alpha com
 		return
 	}
 
+	if !impl.fits32("Zher2k", "n, k, lda, ldb, ldc", n, k, lda, ldb, ldc) {
+		return
+	}
 	if t != blas.NoTrans && t != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Zher2k", "blas: illegal transpose t=%v", t)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zher2k", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Zher2k", "blas: k=%d < 0", k)
+		return
 	}
 	var row, col int
 	if t == blas.NoTrans {
@@ -3751,14 +4987,29 @@ func (impl *Standard) Zher2k(ul blas.Uplo, t blas.Transpose, n, k int, alpha com
 	} else {
 		row, col = k, n
 	}
-	if lda*(row-1)+col > len(a) || lda < max(1, col) {
-		panic("blas: index of a out of range")
+	if lda < max(1, col) {
+		impl.shapeError("Zher2k", "blas: lda=%d < max(1, col=%d)", lda, col)
+		return
+	}
+	if lda*(row-1)+col > len(a) {
+		impl.shapeError("Zher2k", "blas: index of a out of range: lda*(row-1)+col=%d > len(a)=%d", lda*(row-1)+col, len(a))
+		return
+	}
+	if ldb < max(1, col) {
+		impl.shapeError("Zher2k", "blas: ldb=%d < max(1, col=%d)", ldb, col)
+		return
 	}
-	if ldb*(row-1)+col > len(b) || ldb < max(1, col) {
-		panic("blas: index of b out of range")
+	if ldb*(row-1)+col > len(b) {
+		impl.shapeError("Zher2k", "blas: index of b out of range: ldb*(row-1)+col=%d > len(b)=%d", ldb*(row-1)+col, len(b))
+		return
 	}
-	if ldc*(n-1)+n > len(c) || ldc < max(1, n) {
-		panic("blas: index of c out of range")
+	if ldc < max(1, n) {
+		impl.shapeError("Zher2k", "blas: ldc=%d < max(1, n=%d)", ldc, n)
+		return
+	}
+	if ldc*(n-1)+n > len(c) {
+		impl.shapeError("Zher2k", "blas: index of c out of range: ldc*(n-1)+n=%d > len(c)=%d", ldc*(n-1)+n, len(c))
+		return
 	}
 	impl.e = status(C.cublasZher2k(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(t), C.int(n), C.int(k), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&b[0])), C.int(ldb), (*C.double)(&beta), (*C.cuDoubleComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
@@ -3769,14 +5020,20 @@ func (impl *Standard) Ssyrkx(ul blas.Uplo, t blas.Transpose, n, k int, alpha flo
 		return
 	}
 
+	if !impl.fits32("Ssyrkx", "n, k, lda, ldb, ldc", n, k, lda, ldb, ldc) {
+		return
+	}
 	if t != blas.NoTrans && t != blas.Trans && t != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Ssyrkx", "blas: illegal transpose t=%v", t)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ssyrkx", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Ssyrkx", "blas: k=%d < 0", k)
+		return
 	}
 	impl.e = status(C.cublasSsyrkx(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(t), C.int(n), C.int(k), (*C.float)(&alpha), (*C.float)(&a[0]), C.int(lda), (*C.float)(&b[0]), C.int(ldb), (*C.float)(&beta), (*C.float)(&c[0]), C.int(ldc)))
 }
@@ -3787,14 +5044,20 @@ func (impl *Standard) Dsyrkx(ul blas.Uplo, t blas.Transpose, n, k int, alpha flo
 		return
 	}
 
+	if !impl.fits32("Dsyrkx", "n, k, lda, ldb, ldc", n, k, lda, ldb, ldc) {
+		return
+	}
 	if t != blas.NoTrans && t != blas.Trans && t != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Dsyrkx", "blas: illegal transpose t=%v", t)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dsyrkx", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Dsyrkx", "blas: k=%d < 0", k)
+		return
 	}
 	impl.e = status(C.cublasDsyrkx(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(t), C.int(n), C.int(k), (*C.double)(&alpha), (*C.double)(&a[0]), C.int(lda), (*C.double)(&b[0]), C.int(ldb), (*C.double)(&beta), (*C.double)(&c[0]), C.int(ldc)))
 }
@@ -3805,14 +5068,20 @@ func (impl *Standard) Csyrkx(ul blas.Uplo, t blas.Transpose, n, k int, alpha com
 		return
 	}
 
+	if !impl.fits32("Csyrkx", "n, k, lda, ldb, ldc", n, k, lda, ldb, ldc) {
+		return
+	}
 	if t != blas.NoTrans && t != blas.Trans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Csyrkx", "blas: illegal transpose t=%v", t)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Csyrkx", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Csyrkx", "blas: k=%d < 0", k)
+		return
 	}
 	impl.e = status(C.cublasCsyrkx(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(t), C.int(n), C.int(k), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&b[0])), C.int(ldb), (*C.cuComplex)(unsafe.Pointer(&beta)), (*C.cuComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
@@ -3823,14 +5092,20 @@ func (impl *Standard) Zsyrkx(ul blas.Uplo, t blas.Transpose, n, k int, alpha com
 		return
 	}
 
+	if !impl.fits32("Zsyrkx", "n, k, lda, ldb, ldc", n, k, lda, ldb, ldc) {
+		return
+	}
 	if t != blas.NoTrans && t != blas.Trans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Zsyrkx", "blas: illegal transpose t=%v", t)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zsyrkx", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Zsyrkx", "blas: k=%d < 0", k)
+		return
 	}
 	impl.e = status(C.cublasZsyrkx(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(t), C.int(n), C.int(k), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&b[0])), C.int(ldb), (*C.cuDoubleComplex)(unsafe.Pointer(&beta)), (*C.cuDoubleComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
@@ -3841,14 +5116,20 @@ func (impl *Standard) Cherkx(ul blas.Uplo, t blas.Transpose, n, k int, alpha com
 		return
 	}
 
+	if !impl.fits32("Cherkx", "n, k, lda, ldb, ldc", n, k, lda, ldb, ldc) {
+		return
+	}
 	if t != blas.NoTrans && t != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Cherkx", "blas: illegal transpose t=%v", t)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Cherkx", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Cherkx", "blas: k=%d < 0", k)
+		return
 	}
 	impl.e = status(C.cublasCherkx(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(t), C.int(n), C.int(k), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&b[0])), C.int(ldb), (*C.float)(&beta), (*C.cuComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
@@ -3859,21 +5140,29 @@ func (impl *Standard) Zherkx(ul blas.Uplo, t blas.Transpose, n, k int, alpha com
 		return
 	}
 
+	if !impl.fits32("Zherkx", "n, k, lda, ldb, ldc", n, k, lda, ldb, ldc) {
+		return
+	}
 	if t != blas.NoTrans && t != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Zherkx", "blas: illegal transpose t=%v", t)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zherkx", "blas: n=%d < 0", n)
+		return
 	}
 	if k < 0 {
-		panic("blas: k < 0")
+		impl.shapeError("Zherkx", "blas: k=%d < 0", k)
+		return
 	}
 	impl.e = status(C.cublasZherkx(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), trans2cublasTrans(t), C.int(n), C.int(k), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&b[0])), C.int(ldb), (*C.double)(&beta), (*C.cuDoubleComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
 
 // Ssymm performs one of
-//  C = alpha * A * B + beta * C, if side == blas.Left,
-//  C = alpha * B * A + beta * C, if side == blas.Right,
+//
+//	C = alpha * A * B + beta * C, if side == blas.Left,
+//	C = alpha * B * A + beta * C, if side == blas.Right,
+//
 // where A is an n×n or m×m symmetric matrix, B and C are m×n matrices, and alpha
 // is a scalar.
 func (impl *Standard) Ssymm(s blas.Side, ul blas.Uplo, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
@@ -3882,11 +5171,16 @@ func (impl *Standard) Ssymm(s blas.Side, ul blas.Uplo, m, n int, alpha float32,
 		return
 	}
 
+	if !impl.fits32("Ssymm", "m, n, lda, ldb, ldc", m, n, lda, ldb, ldc) {
+		return
+	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Ssymm", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ssymm", "blas: n=%d < 0", n)
+		return
 	}
 	var k int
 	if s == blas.Left {
@@ -3894,21 +5188,38 @@ func (impl *Standard) Ssymm(s blas.Side, ul blas.Uplo, m, n int, alpha float32,
 	} else {
 		k = n
 	}
-	if lda*(k-1)+k > len(a) || lda < max(1, k) {
-		panic("blas: index of a out of range")
+	if lda < max(1, k) {
+		impl.shapeError("Ssymm", "blas: lda=%d < max(1, k=%d)", lda, k)
+		return
 	}
-	if ldb*(m-1)+n > len(b) || ldb < max(1, n) {
-		panic("blas: index of b out of range")
+	if lda*(k-1)+k > len(a) {
+		impl.shapeError("Ssymm", "blas: index of a out of range: lda*(k-1)+k=%d > len(a)=%d", lda*(k-1)+k, len(a))
+		return
+	}
+	if ldb < max(1, n) {
+		impl.shapeError("Ssymm", "blas: ldb=%d < max(1, n=%d)", ldb, n)
+		return
 	}
-	if ldc*(m-1)+n > len(c) || ldc < max(1, n) {
-		panic("blas: index of c out of range")
+	if ldb*(m-1)+n > len(b) {
+		impl.shapeError("Ssymm", "blas: index of b out of range: ldb*(m-1)+n=%d > len(b)=%d", ldb*(m-1)+n, len(b))
+		return
+	}
+	if ldc < max(1, n) {
+		impl.shapeError("Ssymm", "blas: ldc=%d < max(1, n=%d)", ldc, n)
+		return
+	}
+	if ldc*(m-1)+n > len(c) {
+		impl.shapeError("Ssymm", "blas: index of c out of range: ldc*(m-1)+n=%d > len(c)=%d", ldc*(m-1)+n, len(c))
+		return
 	}
 	impl.e = status(C.cublasSsymm(C.cublasHandle_t(impl.h), side2cublasSide(s), uplo2cublasUplo(ul), C.int(m), C.int(n), (*C.float)(&alpha), (*C.float)(&a[0]), C.int(lda), (*C.float)(&b[0]), C.int(ldb), (*C.float)(&beta), (*C.float)(&c[0]), C.int(ldc)))
 }
 
 // Dsymm performs one of
-//  C = alpha * A * B + beta * C, if side == blas.Left,
-//  C = alpha * B * A + beta * C, if side == blas.Right,
+//
+//	C = alpha * A * B + beta * C, if side == blas.Left,
+//	C = alpha * B * A + beta * C, if side == blas.Right,
+//
 // where A is an n×n or m×m symmetric matrix, B and C are m×n matrices, and alpha
 // is a scalar.
 func (impl *Standard) Dsymm(s blas.Side, ul blas.Uplo, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
@@ -3917,11 +5228,16 @@ func (impl *Standard) Dsymm(s blas.Side, ul blas.Uplo, m, n int, alpha float64,
 		return
 	}
 
+	if !impl.fits32("Dsymm", "m, n, lda, ldb, ldc", m, n, lda, ldb, ldc) {
+		return
+	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Dsymm", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dsymm", "blas: n=%d < 0", n)
+		return
 	}
 	var k int
 	if s == blas.Left {
@@ -3929,14 +5245,29 @@ func (impl *Standard) Dsymm(s blas.Side, ul blas.Uplo, m, n int, alpha float64,
 	} else {
 		k = n
 	}
-	if lda*(k-1)+k > len(a) || lda < max(1, k) {
-		panic("blas: index of a out of range")
+	if lda < max(1, k) {
+		impl.shapeError("Dsymm", "blas: lda=%d < max(1, k=%d)", lda, k)
+		return
 	}
-	if ldb*(m-1)+n > len(b) || ldb < max(1, n) {
-		panic("blas: index of b out of range")
+	if lda*(k-1)+k > len(a) {
+		impl.shapeError("Dsymm", "blas: index of a out of range: lda*(k-1)+k=%d > len(a)=%d", lda*(k-1)+k, len(a))
+		return
 	}
-	if ldc*(m-1)+n > len(c) || ldc < max(1, n) {
-		panic("blas: index of c out of range")
+	if ldb < max(1, n) {
+		impl.shapeError("Dsymm", "blas: ldb=%d < max(1, n=%d)", ldb, n)
+		return
+	}
+	if ldb*(m-1)+n > len(b) {
+		impl.shapeError("Dsymm", "blas: index of b out of range: ldb*(m-1)+n=%d > len(b)=%d", ldb*(m-1)+n, len(b))
+		return
+	}
+	if ldc < max(1, n) {
+		impl.shapeError("Dsymm", "blas: ldc=%d < max(1, n=%d)", ldc, n)
+		return
+	}
+	if ldc*(m-1)+n > len(c) {
+		impl.shapeError("Dsymm", "blas: index of c out of range: ldc*(m-1)+n=%d > len(c)=%d", ldc*(m-1)+n, len(c))
+		return
 	}
 	impl.e = status(C.cublasDsymm(C.cublasHandle_t(impl.h), side2cublasSide(s), uplo2cublasUplo(ul), C.int(m), C.int(n), (*C.double)(&alpha), (*C.double)(&a[0]), C.int(lda), (*C.double)(&b[0]), C.int(ldb), (*C.double)(&beta), (*C.double)(&c[0]), C.int(ldc)))
 }
@@ -3947,11 +5278,16 @@ func (impl *Standard) Csymm(s blas.Side, ul blas.Uplo, m, n int, alpha complex64
 		return
 	}
 
+	if !impl.fits32("Csymm", "m, n, lda, ldb, ldc", m, n, lda, ldb, ldc) {
+		return
+	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Csymm", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Csymm", "blas: n=%d < 0", n)
+		return
 	}
 	var k int
 	if s == blas.Left {
@@ -3959,14 +5295,29 @@ func (impl *Standard) Csymm(s blas.Side, ul blas.Uplo, m, n int, alpha complex64
 	} else {
 		k = n
 	}
-	if lda*(k-1)+k > len(a) || lda < max(1, k) {
-		panic("blas: index of a out of range")
+	if lda < max(1, k) {
+		impl.shapeError("Csymm", "blas: lda=%d < max(1, k=%d)", lda, k)
+		return
 	}
-	if ldb*(m-1)+n > len(b) || ldb < max(1, n) {
-		panic("blas: index of b out of range")
+	if lda*(k-1)+k > len(a) {
+		impl.shapeError("Csymm", "blas: index of a out of range: lda*(k-1)+k=%d > len(a)=%d", lda*(k-1)+k, len(a))
+		return
 	}
-	if ldc*(m-1)+n > len(c) || ldc < max(1, n) {
-		panic("blas: index of c out of range")
+	if ldb < max(1, n) {
+		impl.shapeError("Csymm", "blas: ldb=%d < max(1, n=%d)", ldb, n)
+		return
+	}
+	if ldb*(m-1)+n > len(b) {
+		impl.shapeError("Csymm", "blas: index of b out of range: ldb*(m-1)+n=%d > len(b)=%d", ldb*(m-1)+n, len(b))
+		return
+	}
+	if ldc < max(1, n) {
+		impl.shapeError("Csymm", "blas: ldc=%d < max(1, n=%d)", ldc, n)
+		return
+	}
+	if ldc*(m-1)+n > len(c) {
+		impl.shapeError("Csymm", "blas: index of c out of range: ldc*(m-1)+n=%d > len(c)=%d", ldc*(m-1)+n, len(c))
+		return
 	}
 	impl.e = status(C.cublasCsymm(C.cublasHandle_t(impl.h), side2cublasSide(s), uplo2cublasUplo(ul), C.int(m), C.int(n), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&b[0])), C.int(ldb), (*C.cuComplex)(unsafe.Pointer(&beta)), (*C.cuComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
@@ -3977,11 +5328,16 @@ func (impl *Standard) Zsymm(s blas.Side, ul blas.Uplo, m, n int, alpha complex12
 		return
 	}
 
+	if !impl.fits32("Zsymm", "m, n, lda, ldb, ldc", m, n, lda, ldb, ldc) {
+		return
+	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Zsymm", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zsymm", "blas: n=%d < 0", n)
+		return
 	}
 	var k int
 	if s == blas.Left {
@@ -3989,14 +5345,29 @@ func (impl *Standard) Zsymm(s blas.Side, ul blas.Uplo, m, n int, alpha complex12
 	} else {
 		k = n
 	}
-	if lda*(k-1)+k > len(a) || lda < max(1, k) {
-		panic("blas: index of a out of range")
+	if lda < max(1, k) {
+		impl.shapeError("Zsymm", "blas: lda=%d < max(1, k=%d)", lda, k)
+		return
+	}
+	if lda*(k-1)+k > len(a) {
+		impl.shapeError("Zsymm", "blas: index of a out of range: lda*(k-1)+k=%d > len(a)=%d", lda*(k-1)+k, len(a))
+		return
 	}
-	if ldb*(m-1)+n > len(b) || ldb < max(1, n) {
-		panic("blas: index of b out of range")
+	if ldb < max(1, n) {
+		impl.shapeError("Zsymm", "blas: ldb=%d < max(1, n=%d)", ldb, n)
+		return
 	}
-	if ldc*(m-1)+n > len(c) || ldc < max(1, n) {
-		panic("blas: index of c out of range")
+	if ldb*(m-1)+n > len(b) {
+		impl.shapeError("Zsymm", "blas: index of b out of range: ldb*(m-1)+n=%d > len(b)=%d", ldb*(m-1)+n, len(b))
+		return
+	}
+	if ldc < max(1, n) {
+		impl.shapeError("Zsymm", "blas: ldc=%d < max(1, n=%d)", ldc, n)
+		return
+	}
+	if ldc*(m-1)+n > len(c) {
+		impl.shapeError("Zsymm", "blas: index of c out of range: ldc*(m-1)+n=%d > len(c)=%d", ldc*(m-1)+n, len(c))
+		return
 	}
 	impl.e = status(C.cublasZsymm(C.cublasHandle_t(impl.h), side2cublasSide(s), uplo2cublasUplo(ul), C.int(m), C.int(n), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&b[0])), C.int(ldb), (*C.cuDoubleComplex)(unsafe.Pointer(&beta)), (*C.cuDoubleComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
@@ -4007,11 +5378,16 @@ func (impl *Standard) Chemm(s blas.Side, ul blas.Uplo, m, n int, alpha complex64
 		return
 	}
 
+	if !impl.fits32("Chemm", "m, n, lda, ldb, ldc", m, n, lda, ldb, ldc) {
+		return
+	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Chemm", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Chemm", "blas: n=%d < 0", n)
+		return
 	}
 	var k int
 	if s == blas.Left {
@@ -4019,14 +5395,29 @@ func (impl *Standard) Chemm(s blas.Side, ul blas.Uplo, m, n int, alpha complex64
 	} else {
 		k = n
 	}
-	if lda*(k-1)+k > len(a) || lda < max(1, k) {
-		panic("blas: index of a out of range")
+	if lda < max(1, k) {
+		impl.shapeError("Chemm", "blas: lda=%d < max(1, k=%d)", lda, k)
+		return
+	}
+	if lda*(k-1)+k > len(a) {
+		impl.shapeError("Chemm", "blas: index of a out of range: lda*(k-1)+k=%d > len(a)=%d", lda*(k-1)+k, len(a))
+		return
+	}
+	if ldb < max(1, n) {
+		impl.shapeError("Chemm", "blas: ldb=%d < max(1, n=%d)", ldb, n)
+		return
+	}
+	if ldb*(m-1)+n > len(b) {
+		impl.shapeError("Chemm", "blas: index of b out of range: ldb*(m-1)+n=%d > len(b)=%d", ldb*(m-1)+n, len(b))
+		return
 	}
-	if ldb*(m-1)+n > len(b) || ldb < max(1, n) {
-		panic("blas: index of b out of range")
+	if ldc < max(1, n) {
+		impl.shapeError("Chemm", "blas: ldc=%d < max(1, n=%d)", ldc, n)
+		return
 	}
-	if ldc*(m-1)+n > len(c) || ldc < max(1, n) {
-		panic("blas: index of c out of range")
+	if ldc*(m-1)+n > len(c) {
+		impl.shapeError("Chemm", "blas: index of c out of range: ldc*(m-1)+n=%d > len(c)=%d", ldc*(m-1)+n, len(c))
+		return
 	}
 	impl.e = status(C.cublasChemm(C.cublasHandle_t(impl.h), side2cublasSide(s), uplo2cublasUplo(ul), C.int(m), C.int(n), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&b[0])), C.int(ldb), (*C.cuComplex)(unsafe.Pointer(&beta)), (*C.cuComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
@@ -4037,11 +5428,16 @@ func (impl *Standard) Zhemm(s blas.Side, ul blas.Uplo, m, n int, alpha complex12
 		return
 	}
 
+	if !impl.fits32("Zhemm", "m, n, lda, ldb, ldc", m, n, lda, ldb, ldc) {
+		return
+	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Zhemm", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zhemm", "blas: n=%d < 0", n)
+		return
 	}
 	var k int
 	if s == blas.Left {
@@ -4049,23 +5445,40 @@ func (impl *Standard) Zhemm(s blas.Side, ul blas.Uplo, m, n int, alpha complex12
 	} else {
 		k = n
 	}
-	if lda*(k-1)+k > len(a) || lda < max(1, k) {
-		panic("blas: index of a out of range")
+	if lda < max(1, k) {
+		impl.shapeError("Zhemm", "blas: lda=%d < max(1, k=%d)", lda, k)
+		return
 	}
-	if ldb*(m-1)+n > len(b) || ldb < max(1, n) {
-		panic("blas: index of b out of range")
+	if lda*(k-1)+k > len(a) {
+		impl.shapeError("Zhemm", "blas: index of a out of range: lda*(k-1)+k=%d > len(a)=%d", lda*(k-1)+k, len(a))
+		return
+	}
+	if ldb < max(1, n) {
+		impl.shapeError("Zhemm", "blas: ldb=%d < max(1, n=%d)", ldb, n)
+		return
 	}
-	if ldc*(m-1)+n > len(c) || ldc < max(1, n) {
-		panic("blas: index of c out of range")
+	if ldb*(m-1)+n > len(b) {
+		impl.shapeError("Zhemm", "blas: index of b out of range: ldb*(m-1)+n=%d > len(b)=%d", ldb*(m-1)+n, len(b))
+		return
+	}
+	if ldc < max(1, n) {
+		impl.shapeError("Zhemm", "blas: ldc=%d < max(1, n=%d)", ldc, n)
+		return
+	}
+	if ldc*(m-1)+n > len(c) {
+		impl.shapeError("Zhemm", "blas: index of c out of range: ldc*(m-1)+n=%d > len(c)=%d", ldc*(m-1)+n, len(c))
+		return
 	}
 	impl.e = status(C.cublasZhemm(C.cublasHandle_t(impl.h), side2cublasSide(s), uplo2cublasUplo(ul), C.int(m), C.int(n), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&b[0])), C.int(ldb), (*C.cuDoubleComplex)(unsafe.Pointer(&beta)), (*C.cuDoubleComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
 
 // Strsm solves
-//  A * X = alpha * B,   if tA == blas.NoTrans side == blas.Left,
-//  A^T * X = alpha * B, if tA == blas.Trans or blas.ConjTrans, and side == blas.Left,
-//  X * A = alpha * B,   if tA == blas.NoTrans side == blas.Right,
-//  X * A^T = alpha * B, if tA == blas.Trans or blas.ConjTrans, and side == blas.Right,
+//
+//	A * X = alpha * B,   if tA == blas.NoTrans side == blas.Left,
+//	A^T * X = alpha * B, if tA == blas.Trans or blas.ConjTrans, and side == blas.Left,
+//	X * A = alpha * B,   if tA == blas.NoTrans side == blas.Right,
+//	X * A^T = alpha * B, if tA == blas.Trans or blas.ConjTrans, and side == blas.Right,
+//
 // where A is an n×n or m×m triangular matrix, X is an m×n matrix, and alpha is a
 // scalar.
 //
@@ -4079,14 +5492,20 @@ func (impl *Standard) Strsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		return
 	}
 
+	if !impl.fits32("Strsm", "m, n, lda, ldb", m, n, lda, ldb) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Strsm", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Strsm", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Strsm", "blas: n=%d < 0", n)
+		return
 	}
 	var k int
 	if s == blas.Left {
@@ -4094,20 +5513,32 @@ func (impl *Standard) Strsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 	} else {
 		k = n
 	}
-	if lda*(k-1)+k > len(a) || lda < max(1, k) {
-		panic("blas: index of a out of range")
+	if lda < max(1, k) {
+		impl.shapeError("Strsm", "blas: lda=%d < max(1, k=%d)", lda, k)
+		return
 	}
-	if ldb*(m-1)+n > len(b) || ldb < max(1, n) {
-		panic("blas: index of b out of range")
+	if lda*(k-1)+k > len(a) {
+		impl.shapeError("Strsm", "blas: index of a out of range: lda*(k-1)+k=%d > len(a)=%d", lda*(k-1)+k, len(a))
+		return
+	}
+	if ldb < max(1, n) {
+		impl.shapeError("Strsm", "blas: ldb=%d < max(1, n=%d)", ldb, n)
+		return
+	}
+	if ldb*(m-1)+n > len(b) {
+		impl.shapeError("Strsm", "blas: index of b out of range: ldb*(m-1)+n=%d > len(b)=%d", ldb*(m-1)+n, len(b))
+		return
 	}
 	impl.e = status(C.cublasStrsm(C.cublasHandle_t(impl.h), side2cublasSide(s), uplo2cublasUplo(ul), trans2cublasTrans(tA), diag2cublasDiag(d), C.int(m), C.int(n), (*C.float)(&alpha), (*C.float)(&a[0]), C.int(lda), (*C.float)(&b[0]), C.int(ldb)))
 }
 
 // Dtrsm solves
-//  A * X = alpha * B,   if tA == blas.NoTrans side == blas.Left,
-//  A^T * X = alpha * B, if tA == blas.Trans or blas.ConjTrans, and side == blas.Left,
-//  X * A = alpha * B,   if tA == blas.NoTrans side == blas.Right,
-//  X * A^T = alpha * B, if tA == blas.Trans or blas.ConjTrans, and side == blas.Right,
+//
+//	A * X = alpha * B,   if tA == blas.NoTrans side == blas.Left,
+//	A^T * X = alpha * B, if tA == blas.Trans or blas.ConjTrans, and side == blas.Left,
+//	X * A = alpha * B,   if tA == blas.NoTrans side == blas.Right,
+//	X * A^T = alpha * B, if tA == blas.Trans or blas.ConjTrans, and side == blas.Right,
+//
 // where A is an n×n or m×m triangular matrix, X is an m×n matrix, and alpha is a
 // scalar.
 //
@@ -4121,14 +5552,20 @@ func (impl *Standard) Dtrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		return
 	}
 
+	if !impl.fits32("Dtrsm", "m, n, lda, ldb", m, n, lda, ldb) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Dtrsm", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Dtrsm", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dtrsm", "blas: n=%d < 0", n)
+		return
 	}
 	var k int
 	if s == blas.Left {
@@ -4136,11 +5573,21 @@ func (impl *Standard) Dtrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 	} else {
 		k = n
 	}
-	if lda*(k-1)+k > len(a) || lda < max(1, k) {
-		panic("blas: index of a out of range")
+	if lda < max(1, k) {
+		impl.shapeError("Dtrsm", "blas: lda=%d < max(1, k=%d)", lda, k)
+		return
 	}
-	if ldb*(m-1)+n > len(b) || ldb < max(1, n) {
-		panic("blas: index of b out of range")
+	if lda*(k-1)+k > len(a) {
+		impl.shapeError("Dtrsm", "blas: index of a out of range: lda*(k-1)+k=%d > len(a)=%d", lda*(k-1)+k, len(a))
+		return
+	}
+	if ldb < max(1, n) {
+		impl.shapeError("Dtrsm", "blas: ldb=%d < max(1, n=%d)", ldb, n)
+		return
+	}
+	if ldb*(m-1)+n > len(b) {
+		impl.shapeError("Dtrsm", "blas: index of b out of range: ldb*(m-1)+n=%d > len(b)=%d", ldb*(m-1)+n, len(b))
+		return
 	}
 	impl.e = status(C.cublasDtrsm(C.cublasHandle_t(impl.h), side2cublasSide(s), uplo2cublasUplo(ul), trans2cublasTrans(tA), diag2cublasDiag(d), C.int(m), C.int(n), (*C.double)(&alpha), (*C.double)(&a[0]), C.int(lda), (*C.double)(&b[0]), C.int(ldb)))
 }
@@ -4151,14 +5598,20 @@ func (impl *Standard) Ctrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		return
 	}
 
+	if !impl.fits32("Ctrsm", "m, n, lda, ldb", m, n, lda, ldb) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Ctrsm", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Ctrsm", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ctrsm", "blas: n=%d < 0", n)
+		return
 	}
 	var k int
 	if s == blas.Left {
@@ -4166,11 +5619,21 @@ func (impl *Standard) Ctrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 	} else {
 		k = n
 	}
-	if lda*(k-1)+k > len(a) || lda < max(1, k) {
-		panic("blas: index of a out of range")
+	if lda < max(1, k) {
+		impl.shapeError("Ctrsm", "blas: lda=%d < max(1, k=%d)", lda, k)
+		return
+	}
+	if lda*(k-1)+k > len(a) {
+		impl.shapeError("Ctrsm", "blas: index of a out of range: lda*(k-1)+k=%d > len(a)=%d", lda*(k-1)+k, len(a))
+		return
+	}
+	if ldb < max(1, n) {
+		impl.shapeError("Ctrsm", "blas: ldb=%d < max(1, n=%d)", ldb, n)
+		return
 	}
-	if ldb*(m-1)+n > len(b) || ldb < max(1, n) {
-		panic("blas: index of b out of range")
+	if ldb*(m-1)+n > len(b) {
+		impl.shapeError("Ctrsm", "blas: index of b out of range: ldb*(m-1)+n=%d > len(b)=%d", ldb*(m-1)+n, len(b))
+		return
 	}
 	impl.e = status(C.cublasCtrsm(C.cublasHandle_t(impl.h), side2cublasSide(s), uplo2cublasUplo(ul), trans2cublasTrans(tA), diag2cublasDiag(d), C.int(m), C.int(n), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&b[0])), C.int(ldb)))
 }
@@ -4181,14 +5644,20 @@ func (impl *Standard) Ztrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		return
 	}
 
+	if !impl.fits32("Ztrsm", "m, n, lda, ldb", m, n, lda, ldb) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Ztrsm", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Ztrsm", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ztrsm", "blas: n=%d < 0", n)
+		return
 	}
 	var k int
 	if s == blas.Left {
@@ -4196,11 +5665,21 @@ func (impl *Standard) Ztrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 	} else {
 		k = n
 	}
-	if lda*(k-1)+k > len(a) || lda < max(1, k) {
-		panic("blas: index of a out of range")
+	if lda < max(1, k) {
+		impl.shapeError("Ztrsm", "blas: lda=%d < max(1, k=%d)", lda, k)
+		return
+	}
+	if lda*(k-1)+k > len(a) {
+		impl.shapeError("Ztrsm", "blas: index of a out of range: lda*(k-1)+k=%d > len(a)=%d", lda*(k-1)+k, len(a))
+		return
+	}
+	if ldb < max(1, n) {
+		impl.shapeError("Ztrsm", "blas: ldb=%d < max(1, n=%d)", ldb, n)
+		return
 	}
-	if ldb*(m-1)+n > len(b) || ldb < max(1, n) {
-		panic("blas: index of b out of range")
+	if ldb*(m-1)+n > len(b) {
+		impl.shapeError("Ztrsm", "blas: index of b out of range: ldb*(m-1)+n=%d > len(b)=%d", ldb*(m-1)+n, len(b))
+		return
 	}
 	impl.e = status(C.cublasZtrsm(C.cublasHandle_t(impl.h), side2cublasSide(s), uplo2cublasUplo(ul), trans2cublasTrans(tA), diag2cublasDiag(d), C.int(m), C.int(n), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&b[0])), C.int(ldb)))
 }
@@ -4211,17 +5690,24 @@ func (impl *Standard) Sgeam(tA, tB blas.Transpose, m, n int, alpha float32, a []
 		return
 	}
 
+	if !impl.fits32("Sgeam", "m, n, lda, ldb, ldc", m, n, lda, ldb, ldc) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Sgeam", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Sgeam", "blas: illegal transpose tB=%v", tB)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Sgeam", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Sgeam", "blas: n=%d < 0", n)
+		return
 	}
 	impl.e = status(C.cublasSgeam(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), trans2cublasTrans(tB), C.int(m), C.int(n), (*C.float)(&alpha), (*C.float)(&a[0]), C.int(lda), (*C.float)(&beta), (*C.float)(&b[0]), C.int(ldb), (*C.float)(&c[0]), C.int(ldc)))
 }
@@ -4232,17 +5718,24 @@ func (impl *Standard) Dgeam(tA, tB blas.Transpose, m, n int, alpha float64, a []
 		return
 	}
 
+	if !impl.fits32("Dgeam", "m, n, lda, ldb, ldc", m, n, lda, ldb, ldc) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Dgeam", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Dgeam", "blas: illegal transpose tB=%v", tB)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Dgeam", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dgeam", "blas: n=%d < 0", n)
+		return
 	}
 	impl.e = status(C.cublasDgeam(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), trans2cublasTrans(tB), C.int(m), C.int(n), (*C.double)(&alpha), (*C.double)(&a[0]), C.int(lda), (*C.double)(&beta), (*C.double)(&b[0]), C.int(ldb), (*C.double)(&c[0]), C.int(ldc)))
 }
@@ -4253,17 +5746,24 @@ func (impl *Standard) Cgeam(tA, tB blas.Transpose, m, n int, alpha complex64, a
 		return
 	}
 
+	if !impl.fits32("Cgeam", "m, n, lda, ldb, ldc", m, n, lda, ldb, ldc) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Cgeam", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Cgeam", "blas: illegal transpose tB=%v", tB)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Cgeam", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Cgeam", "blas: n=%d < 0", n)
+		return
 	}
 	impl.e = status(C.cublasCgeam(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), trans2cublasTrans(tB), C.int(m), C.int(n), (*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&beta)), (*C.cuComplex)(unsafe.Pointer(&b[0])), C.int(ldb), (*C.cuComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
@@ -4274,17 +5774,24 @@ func (impl *Standard) Zgeam(tA, tB blas.Transpose, m, n int, alpha complex128, a
 		return
 	}
 
+	if !impl.fits32("Zgeam", "m, n, lda, ldb, ldc", m, n, lda, ldb, ldc) {
+		return
+	}
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Zgeam", "blas: illegal transpose tA=%v", tA)
+		return
 	}
 	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
-		panic("blas: illegal transpose")
+		impl.shapeError("Zgeam", "blas: illegal transpose tB=%v", tB)
+		return
 	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Zgeam", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zgeam", "blas: n=%d < 0", n)
+		return
 	}
 	impl.e = status(C.cublasZgeam(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), trans2cublasTrans(tB), C.int(m), C.int(n), (*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&beta)), (*C.cuDoubleComplex)(unsafe.Pointer(&b[0])), C.int(ldb), (*C.cuDoubleComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
@@ -4295,17 +5802,24 @@ func (impl *Standard) Sdgmm(mode blas.Side, m, n int, a []float32, lda int, x []
 		return
 	}
 
+	if !impl.fits32("Sdgmm", "m, n, lda, incX, ldc", m, n, lda, incX, ldc) {
+		return
+	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Sdgmm", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Sdgmm", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Sdgmm", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (m-1)*incX >= len(x)) || (incX < 0 && (1-m)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Sdgmm", "blas: x index out of range: m=%d, incX=%d, len(x)=%d", m, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasSdgmm(C.cublasHandle_t(impl.h), side2cublasSide(mode), C.int(m), C.int(n), (*C.float)(&a[0]), C.int(lda), (*C.float)(&x[0]), C.int(incX), (*C.float)(&c[0]), C.int(ldc)))
 }
@@ -4316,17 +5830,24 @@ func (impl *Standard) Ddgmm(mode blas.Side, m, n int, a []float64, lda int, x []
 		return
 	}
 
+	if !impl.fits32("Ddgmm", "m, n, lda, incX, ldc", m, n, lda, incX, ldc) {
+		return
+	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Ddgmm", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ddgmm", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Ddgmm", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (m-1)*incX >= len(x)) || (incX < 0 && (1-m)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Ddgmm", "blas: x index out of range: m=%d, incX=%d, len(x)=%d", m, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasDdgmm(C.cublasHandle_t(impl.h), side2cublasSide(mode), C.int(m), C.int(n), (*C.double)(&a[0]), C.int(lda), (*C.double)(&x[0]), C.int(incX), (*C.double)(&c[0]), C.int(ldc)))
 }
@@ -4337,17 +5858,24 @@ func (impl *Standard) Cdgmm(mode blas.Side, m, n int, a []complex64, lda int, x
 		return
 	}
 
+	if !impl.fits32("Cdgmm", "m, n, lda, incX, ldc", m, n, lda, incX, ldc) {
+		return
+	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Cdgmm", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Cdgmm", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Cdgmm", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (m-1)*incX >= len(x)) || (incX < 0 && (1-m)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Cdgmm", "blas: x index out of range: m=%d, incX=%d, len(x)=%d", m, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasCdgmm(C.cublasHandle_t(impl.h), side2cublasSide(mode), C.int(m), C.int(n), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
@@ -4358,17 +5886,24 @@ func (impl *Standard) Zdgmm(mode blas.Side, m, n int, a []complex128, lda int, x
 		return
 	}
 
+	if !impl.fits32("Zdgmm", "m, n, lda, incX, ldc", m, n, lda, incX, ldc) {
+		return
+	}
 	if m < 0 {
-		panic("blas: m < 0")
+		impl.shapeError("Zdgmm", "blas: m=%d < 0", m)
+		return
 	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zdgmm", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zdgmm", "blas: zero x index increment")
+		return
 	}
 	if (incX > 0 && (m-1)*incX >= len(x)) || (incX < 0 && (1-m)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zdgmm", "blas: x index out of range: m=%d, incX=%d, len(x)=%d", m, incX, len(x))
+		return
 	}
 	impl.e = status(C.cublasZdgmm(C.cublasHandle_t(impl.h), side2cublasSide(mode), C.int(m), C.int(n), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&x[0])), C.int(incX), (*C.cuDoubleComplex)(unsafe.Pointer(&c[0])), C.int(ldc)))
 }
@@ -4379,8 +5914,12 @@ func (impl *Standard) Stpttr(ul blas.Uplo, n int, aP, a []float32, lda int) {
 		return
 	}
 
+	if !impl.fits32("Stpttr", "n, lda", n, lda) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Stpttr", "blas: n=%d < 0", n)
+		return
 	}
 	impl.e = status(C.cublasStpttr(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.float)(&aP[0]), (*C.float)(&a[0]), C.int(lda)))
 }
@@ -4391,8 +5930,12 @@ func (impl *Standard) Dtpttr(ul blas.Uplo, n int, aP, a []float64, lda int) {
 		return
 	}
 
+	if !impl.fits32("Dtpttr", "n, lda", n, lda) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dtpttr", "blas: n=%d < 0", n)
+		return
 	}
 	impl.e = status(C.cublasDtpttr(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.double)(&aP[0]), (*C.double)(&a[0]), C.int(lda)))
 }
@@ -4403,8 +5946,12 @@ func (impl *Standard) Ctpttr(ul blas.Uplo, n int, aP, a []complex64, lda int) {
 		return
 	}
 
+	if !impl.fits32("Ctpttr", "n, lda", n, lda) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ctpttr", "blas: n=%d < 0", n)
+		return
 	}
 	impl.e = status(C.cublasCtpttr(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.cuComplex)(unsafe.Pointer(&aP[0])), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda)))
 }
@@ -4415,8 +5962,12 @@ func (impl *Standard) Ztpttr(ul blas.Uplo, n int, aP, a []complex128, lda int) {
 		return
 	}
 
+	if !impl.fits32("Ztpttr", "n, lda", n, lda) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ztpttr", "blas: n=%d < 0", n)
+		return
 	}
 	impl.e = status(C.cublasZtpttr(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.cuDoubleComplex)(unsafe.Pointer(&aP[0])), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda)))
 }
@@ -4427,8 +5978,12 @@ func (impl *Standard) Strttp(ul blas.Uplo, n int, a []float32, lda int, aP []flo
 		return
 	}
 
+	if !impl.fits32("Strttp", "n, lda", n, lda) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Strttp", "blas: n=%d < 0", n)
+		return
 	}
 	impl.e = status(C.cublasStrttp(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.float)(&a[0]), C.int(lda), (*C.float)(&aP[0])))
 }
@@ -4439,8 +5994,12 @@ func (impl *Standard) Dtrttp(ul blas.Uplo, n int, a []float64, lda int, aP []flo
 		return
 	}
 
+	if !impl.fits32("Dtrttp", "n, lda", n, lda) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Dtrttp", "blas: n=%d < 0", n)
+		return
 	}
 	impl.e = status(C.cublasDtrttp(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.double)(&a[0]), C.int(lda), (*C.double)(&aP[0])))
 }
@@ -4451,8 +6010,12 @@ func (impl *Standard) Ctrttp(ul blas.Uplo, n int, a []complex64, lda int, aP []c
 		return
 	}
 
+	if !impl.fits32("Ctrttp", "n, lda", n, lda) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ctrttp", "blas: n=%d < 0", n)
+		return
 	}
 	impl.e = status(C.cublasCtrttp(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.cuComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuComplex)(unsafe.Pointer(&aP[0]))))
 }
@@ -4463,8 +6026,12 @@ func (impl *Standard) Ztrttp(ul blas.Uplo, n int, a []complex128, lda int, aP []
 		return
 	}
 
+	if !impl.fits32("Ztrttp", "n, lda", n, lda) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Ztrttp", "blas: n=%d < 0", n)
+		return
 	}
 	impl.e = status(C.cublasZtrttp(C.cublasHandle_t(impl.h), uplo2cublasUplo(ul), C.int(n), (*C.cuDoubleComplex)(unsafe.Pointer(&a[0])), C.int(lda), (*C.cuDoubleComplex)(unsafe.Pointer(&aP[0]))))
 }
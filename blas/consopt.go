@@ -17,3 +17,20 @@ func WithNativeData() ConsOpt {
 	}
 	return f
 }
+
+// WithContractor sets the Contractor used by Einsum for the expressions that cannot be lowered to GEMM.
+func WithContractor(c Contractor) ConsOpt {
+	f := func(impl *Standard) {
+		impl.contractor = c
+	}
+	return f
+}
+
+// WithShapePolicy sets what the implementation does when the arguments of a call are not valid, instead of following
+// the policy of the package (see SetShapePolicy).
+func WithShapePolicy(p ShapePolicy) ConsOpt {
+	f := func(impl *Standard) {
+		impl.shapes = p
+	}
+	return f
+}
@@ -0,0 +1,426 @@
+package cublas
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/blas"
+	"gorgonia.org/cu"
+)
+
+// EinsumSpec is a parsed einsum specification, such as "bij,bjk->bik".
+// Each subscript is a letter that labels a dimension of the operand.
+type EinsumSpec struct {
+	Inputs []string // subscripts of each operand
+	Output string   // subscripts of the result
+}
+
+// ParseEinsum parses an einsum specification.
+//
+// If the specification has no "->", the output is made of the subscripts that appear exactly once in the inputs,
+// in alphabetical order (as in numpy). Ellipses are not supported.
+func ParseEinsum(spec string) (*EinsumSpec, error) {
+	s := strings.Replace(spec, " ", "", -1)
+	if strings.Contains(s, "...") {
+		return nil, errors.Errorf("Einsum %q: ellipses are not supported", spec)
+	}
+
+	var retVal EinsumSpec
+	inputs := s
+	explicit := false
+	if i := strings.Index(s, "->"); i >= 0 {
+		inputs, retVal.Output = s[:i], s[i+2:]
+		explicit = true
+	}
+	retVal.Inputs = strings.Split(inputs, ",")
+
+	counts := make(map[byte]int)
+	for i, in := range retVal.Inputs {
+		if in == "" && inputs != "" {
+			return nil, errors.Errorf("Einsum %q: operand %d has no subscripts", spec, i)
+		}
+		for j := 0; j < len(in); j++ {
+			if !isSubscript(in[j]) {
+				return nil, errors.Errorf("Einsum %q: invalid subscript %q in operand %d", spec, in[j], i)
+			}
+			counts[in[j]]++
+		}
+	}
+
+	if !explicit {
+		var out []byte
+		for l, c := range counts {
+			if c == 1 {
+				out = append(out, l)
+			}
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+		retVal.Output = string(out)
+		return &retVal, nil
+	}
+
+	seen := make(map[byte]bool)
+	for j := 0; j < len(retVal.Output); j++ {
+		l := retVal.Output[j]
+		switch {
+		case !isSubscript(l):
+			return nil, errors.Errorf("Einsum %q: invalid subscript %q in the output", spec, l)
+		case seen[l]:
+			return nil, errors.Errorf("Einsum %q: subscript %q appears more than once in the output", spec, l)
+		case counts[l] == 0:
+			return nil, errors.Errorf("Einsum %q: output subscript %q does not appear in any operand", spec, l)
+		}
+		seen[l] = true
+	}
+	return &retVal, nil
+}
+
+func isSubscript(c byte) bool { return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+
+func (s *EinsumSpec) String() string { return strings.Join(s.Inputs, ",") + "->" + s.Output }
+
+// Sizes infers the size of each subscript from the shapes of the operands.
+func (s *EinsumSpec) Sizes(operands ...cu.DeviceTensor) (map[byte]int, error) {
+	if len(operands) != len(s.Inputs) {
+		return nil, errors.Errorf("Einsum %v expects %d operands. Got %d", s, len(s.Inputs), len(operands))
+	}
+	sizes := make(map[byte]int)
+	for i, op := range operands {
+		if err := op.Check(); err != nil {
+			return nil, errors.Wrapf(err, "Einsum %v: operand %d", s, i)
+		}
+		sub := s.Inputs[i]
+		if len(sub) != op.Dims() {
+			return nil, errors.Errorf("Einsum %v: operand %d has %d dimensions, but %d subscripts (%q)", s, i, op.Dims(), len(sub), sub)
+		}
+		if op.Dtype != operands[0].Dtype {
+			return nil, errors.Errorf("Einsum %v: operand %d is %v, but operand 0 is %v", s, i, op.Dtype, operands[0].Dtype)
+		}
+		for j := 0; j < len(sub); j++ {
+			l := sub[j]
+			if size, ok := sizes[l]; ok && size != op.Shape[j] {
+				return nil, errors.Errorf("Einsum %v: subscript %q has size %d in operand %d, but %d elsewhere", s, l, op.Shape[j], i, size)
+			}
+			sizes[l] = op.Shape[j]
+		}
+	}
+	return sizes, nil
+}
+
+// OutputShape returns the shape of the result, given the sizes of the subscripts.
+func (s *EinsumSpec) OutputShape(sizes map[byte]int) []int {
+	shape := make([]int, len(s.Output))
+	for i := range shape {
+		shape[i] = sizes[s.Output[i]]
+	}
+	return shape
+}
+
+// Contractor evaluates einsum expressions that cannot be lowered to GEMM. It is plugged in with WithContractor. The
+// cutensor package implements it with cuTENSOR.
+type Contractor interface {
+	Contract(spec *EinsumSpec, out cu.DeviceTensor, operands ...cu.DeviceTensor) error
+}
+
+// Einsum evaluates the einsum expression over the operands, and returns the result in a newly allocated,
+// contiguous tensor. The result must be freed by the caller.
+//
+// See EinsumInto for the supported expressions.
+func (impl *Standard) Einsum(spec string, operands ...cu.DeviceTensor) (retVal cu.DeviceTensor, err error) {
+	var s *EinsumSpec
+	if s, err = ParseEinsum(spec); err != nil {
+		return
+	}
+	var sizes map[byte]int
+	if sizes, err = s.Sizes(operands...); err != nil {
+		return
+	}
+	retVal = cu.NewDeviceTensor(0, operands[0].Dtype, s.OutputShape(sizes)...)
+	if retVal.Len() == 0 {
+		return retVal, nil
+	}
+	if retVal.Ptr, err = cu.MemAlloc(int64(retVal.Len()) * retVal.Dtype.Size()); err != nil {
+		return retVal, errors.Wrapf(err, "Einsum %v: unable to allocate the result", s)
+	}
+	if err = impl.einsum(s, sizes, retVal, operands); err != nil {
+		cu.MemFree(retVal.Ptr)
+		retVal.Ptr = 0
+	}
+	return
+}
+
+// EinsumInto evaluates the einsum expression over the operands, and writes the result into out.
+//
+// Contractions of two float32 or float64 operands are lowered to a single (strided batched) GEMM, provided that the
+// batch, free and contracted dimensions of each operand can be flattened into one strided dimension each.
+// The other expressions are handed to the Contractor set with WithContractor; without one, an error is returned.
+func (impl *Standard) EinsumInto(spec string, out cu.DeviceTensor, operands ...cu.DeviceTensor) error {
+	s, err := ParseEinsum(spec)
+	if err != nil {
+		return err
+	}
+	sizes, err := s.Sizes(operands...)
+	if err != nil {
+		return err
+	}
+	want := s.OutputShape(sizes)
+	if err = out.Check(); err != nil {
+		return errors.Wrapf(err, "Einsum %v: output", s)
+	}
+	if out.Dtype != operands[0].Dtype {
+		return errors.Errorf("Einsum %v: output is %v, but the operands are %v", s, out.Dtype, operands[0].Dtype)
+	}
+	if !equalShapes(want, out.Shape) {
+		return errors.Errorf("Einsum %v: output has shape %v. Expected %v", s, out.Shape, want)
+	}
+	return impl.einsum(s, sizes, out, operands)
+}
+
+func (impl *Standard) einsum(s *EinsumSpec, sizes map[byte]int, out cu.DeviceTensor, operands []cu.DeviceTensor) error {
+	p, err := planGEMM(s, sizes, out, operands)
+	if err != nil {
+		if impl.contractor == nil {
+			return errors.Wrap(err, "no Contractor is available (see WithContractor)")
+		}
+		return impl.contractor.Contract(s, out, operands...)
+	}
+	if p.m == 0 || p.n == 0 || p.batch == 0 {
+		return nil
+	}
+
+	impl.Lock()
+	defer impl.Unlock()
+	// an error left by an earlier call would stop the GEMM, and be taken for its own
+	if err = impl.Err(); err != nil {
+		return errors.Wrapf(err, "Einsum %v: an earlier call failed", s)
+	}
+	switch out.Dtype {
+	case cu.DtFloat32:
+		impl.SgemmStridedBatched(p.tA, p.tB, p.m, p.n, p.k, 1, p.a.ptr, p.a.ld, p.a.batchStride, p.b.ptr, p.b.ld, p.b.batchStride, 0, p.c.ptr, p.c.ld, p.c.batchStride, p.batch)
+	case cu.DtFloat64:
+		impl.DgemmStridedBatched(p.tA, p.tB, p.m, p.n, p.k, 1, p.a.ptr, p.a.ld, p.a.batchStride, p.b.ptr, p.b.ld, p.b.batchStride, 0, p.c.ptr, p.c.ld, p.c.batchStride, p.batch)
+	}
+	if err = impl.TakeErr(); err != nil {
+		return errors.Wrapf(err, "Einsum %v", s)
+	}
+	return nil
+}
+
+// matrix is a (batch of) strided matrices.
+type matrix struct {
+	ptr         cu.DevicePtr
+	rows, cols  int
+	rs, cs      int // row and column strides
+	batchStride int
+
+	ld int // leading dimension, once described as a column-major matrix
+}
+
+// t returns the transpose of the matrix.
+func (m matrix) t() matrix {
+	m.rows, m.cols = m.cols, m.rows
+	m.rs, m.cs = m.cs, m.rs
+	return m
+}
+
+// colMajor describes the matrix as a column-major matrix for cuBLAS. If trans is true, the matrix is stored as its transpose.
+func (m *matrix) colMajor() (trans bool, ok bool) {
+	switch {
+	case m.rows == 1 || m.rs == 1:
+		m.ld = m.cs
+		if m.cols == 1 {
+			m.ld = m.rows
+		}
+		return false, m.ld >= m.rows && m.ld >= 1
+	case m.cols == 1 || m.cs == 1:
+		m.ld = m.rs
+		return true, m.ld >= m.cols && m.ld >= 1
+	}
+	return false, false
+}
+
+// gemmPlan is an einsum lowered to C = A * B over a batch of column-major matrices.
+type gemmPlan struct {
+	tA, tB  blas.Transpose
+	m, n, k int
+	batch   int
+	a, b, c matrix
+}
+
+// planGEMM lowers a contraction of two operands to a strided batched GEMM.
+//
+// The subscripts are classified as batch (in both operands and the output), free (in one operand and the output),
+// or contracted (in both operands but not the output). Each class must be mergeable into a single strided dimension.
+func planGEMM(s *EinsumSpec, sizes map[byte]int, out cu.DeviceTensor, operands []cu.DeviceTensor) (p gemmPlan, err error) {
+	if len(operands) != 2 {
+		return p, errors.Errorf("Einsum %v: only contractions of two operands can be lowered to GEMM", s)
+	}
+	switch out.Dtype {
+	case cu.DtFloat32, cu.DtFloat64:
+	default:
+		return p, errors.Errorf("Einsum %v: %v cannot be lowered to GEMM", s, out.Dtype)
+	}
+
+	A, B := operands[0], operands[1]
+	sa, sb := s.Inputs[0], s.Inputs[1]
+	if hasRepeats(sa) || hasRepeats(sb) {
+		return p, errors.Errorf("Einsum %v: diagonals cannot be lowered to GEMM", s)
+	}
+
+	var batch, m, n, k []byte
+	for i := 0; i < len(s.Output); i++ {
+		l := s.Output[i]
+		inA, inB := strings.IndexByte(sa, l) >= 0, strings.IndexByte(sb, l) >= 0
+		switch {
+		case inA && inB:
+			batch = append(batch, l)
+		case inA:
+			m = append(m, l)
+		default:
+			n = append(n, l)
+		}
+	}
+	for i := 0; i < len(sa); i++ {
+		l := sa[i]
+		if strings.IndexByte(s.Output, l) >= 0 {
+			continue
+		}
+		if strings.IndexByte(sb, l) < 0 {
+			return p, errors.Errorf("Einsum %v: subscript %q is summed over a single operand, which cannot be lowered to GEMM", s, l)
+		}
+		k = append(k, l)
+	}
+	for i := 0; i < len(sb); i++ {
+		l := sb[i]
+		if strings.IndexByte(s.Output, l) < 0 && strings.IndexByte(sa, l) < 0 {
+			return p, errors.Errorf("Einsum %v: subscript %q is summed over a single operand, which cannot be lowered to GEMM", s, l)
+		}
+	}
+
+	strideA, strideB, strideC := strideMap(sa, A), strideMap(sb, B), strideMap(s.Output, out)
+	group := func(class, operand string, labels []byte, strides map[byte]int) (size, stride int, err error) {
+		var ok bool
+		if size, stride, ok = merge(labels, sizes, strides); !ok {
+			err = errors.Errorf("Einsum %v: the %v dimensions %q of %v cannot be flattened into a single strided dimension", s, class, string(labels), operand)
+		}
+		return
+	}
+
+	// batch
+	var bsize int
+	if bsize, p.a.batchStride, err = group("batch", "the first operand", batch, strideA); err != nil {
+		return
+	}
+	if _, p.b.batchStride, err = group("batch", "the second operand", batch, strideB); err != nil {
+		return
+	}
+	if _, p.c.batchStride, err = group("batch", "the output", batch, strideC); err != nil {
+		return
+	}
+	p.batch = bsize
+
+	// A is m×k, B is k×n and C is m×n
+	var mA, kA, kB, nB, mC, nC int
+	if p.m, mA, err = group("free", "the first operand", m, strideA); err != nil {
+		return
+	}
+	if p.k, kA, err = group("contracted", "the first operand", k, strideA); err != nil {
+		return
+	}
+	if _, kB, err = group("contracted", "the second operand", k, strideB); err != nil {
+		return
+	}
+	if p.n, nB, err = group("free", "the second operand", n, strideB); err != nil {
+		return
+	}
+	if _, mC, err = group("free", "the output", m, strideC); err != nil {
+		return
+	}
+	if _, nC, err = group("free", "the output", n, strideC); err != nil {
+		return
+	}
+
+	a := matrix{ptr: A.Ptr, rows: p.m, cols: p.k, rs: mA, cs: kA, batchStride: p.a.batchStride}
+	b := matrix{ptr: B.Ptr, rows: p.k, cols: p.n, rs: kB, cs: nB, batchStride: p.b.batchStride}
+	c := matrix{ptr: out.Ptr, rows: p.m, cols: p.n, rs: mC, cs: nC, batchStride: p.c.batchStride}
+
+	// if C is row-major, compute Cᵀ = Bᵀ * Aᵀ instead
+	trans, ok := c.colMajor()
+	if !ok {
+		return p, errors.Errorf("Einsum %v: the output cannot be described as a column-major matrix", s)
+	}
+	if trans {
+		c = c.t()
+		c.colMajor()
+		a, b = b.t(), a.t()
+		p.m, p.n = p.n, p.m
+	}
+
+	var tA, tB bool
+	if tA, ok = a.colMajor(); !ok {
+		return p, errors.Errorf("Einsum %v: the first operand cannot be described as a column-major matrix", s)
+	}
+	if tB, ok = b.colMajor(); !ok {
+		return p, errors.Errorf("Einsum %v: the second operand cannot be described as a column-major matrix", s)
+	}
+	p.tA, p.tB = toTranspose(tA), toTranspose(tB)
+	p.a, p.b, p.c = a, b, c
+	return p, nil
+}
+
+func toTranspose(trans bool) blas.Transpose {
+	if trans {
+		return blas.Trans
+	}
+	return blas.NoTrans
+}
+
+// merge flattens the dimensions labelled by labels (outermost first) into a single strided dimension.
+// Dimensions of size 1 are ignored. An empty group is a dimension of size 1.
+func merge(labels []byte, sizes, strides map[byte]int) (size, stride int, ok bool) {
+	size = 1
+	prev := -1
+	for _, l := range labels {
+		sz := sizes[l]
+		size *= sz
+		if sz == 1 {
+			continue
+		}
+		st := strides[l]
+		if prev >= 0 && prev != st*sz {
+			return 0, 0, false
+		}
+		prev, stride = st, st
+	}
+	return size, stride, true
+}
+
+func strideMap(sub string, t cu.DeviceTensor) map[byte]int {
+	retVal := make(map[byte]int, len(sub))
+	for i := 0; i < len(sub); i++ {
+		retVal[sub[i]] = t.Strides[i]
+	}
+	return retVal
+}
+
+func hasRepeats(sub string) bool {
+	for i := 0; i < len(sub); i++ {
+		if strings.IndexByte(sub[i+1:], sub[i]) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func equalShapes(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package cublas
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gorgonia.org/cu"
+)
+
+func TestParseEinsum(t *testing.T) {
+	good := []struct {
+		spec   string
+		inputs []string
+		output string
+	}{
+		{"ij,jk->ik", []string{"ij", "jk"}, "ik"},
+		{"bij, bjk -> bik", []string{"bij", "bjk"}, "bik"},
+		{"ij,jk", []string{"ij", "jk"}, "ik"},
+		{"ji", []string{"ji"}, "ij"},
+		{"ii->", []string{"ii"}, ""},
+	}
+	for _, c := range good {
+		s, err := ParseEinsum(c.spec)
+		if err != nil {
+			t.Errorf("%q: %v", c.spec, err)
+			continue
+		}
+		if !equalStrings(s.Inputs, c.inputs) || s.Output != c.output {
+			t.Errorf("%q: expected %v->%v. Got %v->%v", c.spec, c.inputs, c.output, s.Inputs, s.Output)
+		}
+	}
+
+	bad := []string{"ij,jk->iz", "ij,jk->ii", "i1,jk", "...ij,jk", "ij,,jk"}
+	for _, spec := range bad {
+		if _, err := ParseEinsum(spec); err == nil {
+			t.Errorf("%q: expected an error", spec)
+		}
+	}
+}
+
+func TestPlanGEMM(t *testing.T) {
+	f32 := func(shape ...int) cu.DeviceTensor { return cu.NewDeviceTensor(0x1000, cu.DtFloat32, shape...) }
+	plan := func(spec string, out cu.DeviceTensor, operands ...cu.DeviceTensor) (gemmPlan, error) {
+		s, err := ParseEinsum(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sizes, err := s.Sizes(operands...)
+		if err != nil {
+			return gemmPlan{}, err
+		}
+		return planGEMM(s, sizes, out, operands)
+	}
+
+	// row-major C = A*B is computed as Cᵀ = Bᵀ*Aᵀ in column-major, which requires no transposes
+	p, err := plan("ij,jk->ik", f32(2, 4), f32(2, 3), f32(3, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.tA != blas.NoTrans || p.tB != blas.NoTrans || p.m != 4 || p.n != 2 || p.k != 3 || p.batch != 1 {
+		t.Errorf("Unexpected plan %+v", p)
+	}
+	if p.a.ld != 4 || p.b.ld != 3 || p.c.ld != 4 {
+		t.Errorf("Unexpected leading dimensions %d %d %d", p.a.ld, p.b.ld, p.c.ld)
+	}
+
+	// batched, with the second operand transposed
+	p, err = plan("bij,bkj->bik", f32(5, 2, 4), f32(5, 2, 3), f32(5, 4, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.batch != 5 || p.m != 4 || p.n != 2 || p.k != 3 || p.tA != blas.Trans || p.tB != blas.NoTrans {
+		t.Errorf("Unexpected plan %+v", p)
+	}
+	if p.a.batchStride != 12 || p.b.batchStride != 6 || p.c.batchStride != 8 {
+		t.Errorf("Unexpected batch strides %d %d %d", p.a.batchStride, p.b.batchStride, p.c.batchStride)
+	}
+
+	// multiple contracted dimensions are merged
+	p, err = plan("ijk,jkl->il", f32(2, 5), f32(2, 3, 4), f32(3, 4, 5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.k != 12 {
+		t.Errorf("Expected the contracted dimensions to be merged into 12. Got %d", p.k)
+	}
+
+	unsupported := []struct {
+		spec     string
+		out      cu.DeviceTensor
+		operands []cu.DeviceTensor
+	}{
+		{"ij->i", f32(2), []cu.DeviceTensor{f32(2, 3)}},                           // single operand
+		{"ij,jk->i", f32(2), []cu.DeviceTensor{f32(2, 3), f32(3, 4)}},             // sum over one operand
+		{"ii,ij->j", f32(3), []cu.DeviceTensor{f32(3, 3), f32(3, 3)}},             // diagonal
+		{"ijk,kjl->il", f32(2, 5), []cu.DeviceTensor{f32(2, 3, 4), f32(4, 3, 5)}}, // contracted dimensions in different orders
+	}
+	for _, c := range unsupported {
+		if _, err = plan(c.spec, c.out, c.operands...); err == nil {
+			t.Errorf("%q: expected an error", c.spec)
+		}
+	}
+
+	if _, err = plan("ij,jk->ik", f32(2, 4), f32(2, 3), f32(4, 4)); err == nil {
+		t.Errorf("Expected an error for mismatched sizes")
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
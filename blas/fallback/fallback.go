@@ -0,0 +1,42 @@
+// Package fallback provides a CPU implementation of the cublas interface, backed by gonum's native BLAS.
+//
+// It is meant for running programs written against package cublas on machines without a GPU. It is slow.
+// Use cublas.NewAuto to select it automatically when no GPU is present.
+//
+// This package does not depend on CUDA.
+package fallback // import "gorgonia.org/cu/blas/fallback"
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/gonum"
+)
+
+// Interface is the BLAS interface that is satisfied by both *cublas.Standard and *Implementation.
+type Interface interface {
+	blas.Float32
+	blas.Float64
+	blas.Complex64
+	blas.Complex128
+
+	// Err returns the error of the last call, if any.
+	Err() error
+
+	// Close releases any resources held by the implementation.
+	Close() error
+}
+
+var _ Interface = &Implementation{}
+
+// Implementation is a CPU implementation of the BLAS interfaces. Like cublas.Standard, it assumes the data is in RowMajor.
+type Implementation struct {
+	gonum.Implementation
+}
+
+// New creates a new fallback implementation.
+func New() *Implementation { return &Implementation{} }
+
+// Err always returns nil. Invalid arguments cause a panic, as with the rest of gonum.
+func (impl *Implementation) Err() error { return nil }
+
+// Close is a no-op.
+func (impl *Implementation) Close() error { return nil }
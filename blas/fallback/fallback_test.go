@@ -0,0 +1,27 @@
+package fallback
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+func TestImplementation(t *testing.T) {
+	var impl Interface = New()
+	defer impl.Close()
+
+	a := []float64{1, 2, 3, 4, 5, 6} // (2, 3)
+	b := []float64{1, 2, 3, 4, 5, 6} // (3, 2)
+	c := make([]float64, 4)          // (2, 2)
+	impl.Dgemm(blas.NoTrans, blas.NoTrans, 2, 2, 3, 1, a, 3, b, 2, 0, c, 2)
+
+	correct := []float64{22, 28, 49, 64}
+	for i := range correct {
+		if c[i] != correct[i] {
+			t.Fatalf("Expected %v. Got %v", correct, c)
+		}
+	}
+	if err := impl.Err(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,163 @@
+package cublas
+
+// #include <cublas_v2.h>
+import "C"
+import (
+	"math"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/blas"
+	"gorgonia.org/cu"
+)
+
+// CublasComputeType is the precision GemmEx accumulates the products in. It also sets the type of alpha and beta:
+// half for the 16F types, float for the 32F types, double for the 64F types and int for the 32I types.
+//
+// The fast 32F types let cuBLAS use tensor cores on float32 data, by rounding the inputs to a narrower type (TF32 keeps
+// the exponent of a float32 and 10 bits of mantissa). The pedantic types forbid any reduced precision.
+type CublasComputeType int
+
+const (
+	Compute16F         CublasComputeType = C.CUBLAS_COMPUTE_16F
+	Compute16FPedantic CublasComputeType = C.CUBLAS_COMPUTE_16F_PEDANTIC
+	Compute32F         CublasComputeType = C.CUBLAS_COMPUTE_32F
+	Compute32FPedantic CublasComputeType = C.CUBLAS_COMPUTE_32F_PEDANTIC
+	Compute32FFast16F  CublasComputeType = C.CUBLAS_COMPUTE_32F_FAST_16F
+	Compute32FFast16BF CublasComputeType = C.CUBLAS_COMPUTE_32F_FAST_16BF
+	Compute32FFastTF32 CublasComputeType = C.CUBLAS_COMPUTE_32F_FAST_TF32
+	Compute64F         CublasComputeType = C.CUBLAS_COMPUTE_64F
+	Compute64FPedantic CublasComputeType = C.CUBLAS_COMPUTE_64F_PEDANTIC
+	Compute32I         CublasComputeType = C.CUBLAS_COMPUTE_32I
+	Compute32IPedantic CublasComputeType = C.CUBLAS_COMPUTE_32I_PEDANTIC
+)
+
+// GemmAlgo selects the algorithm of GemmEx. GemmDefault and GemmDefaultTensorOp let cuBLAS choose with its
+// heuristics; GemmAlgo(i) forces the algorithm CUBLAS_GEMM_ALGOi, and GemmAlgo(100+i) CUBLAS_GEMM_ALGOi_TENSOR_OP.
+// Since CUDA 11, the tensor op algorithms are the same as the others, and tensor cores are allowed by the compute type.
+type GemmAlgo int
+
+const (
+	GemmDefault         GemmAlgo = C.CUBLAS_GEMM_DEFAULT
+	GemmDefaultTensorOp GemmAlgo = C.CUBLAS_GEMM_DEFAULT_TENSOR_OP
+)
+
+func dataType(dt cu.Dtype) (C.cudaDataType, error) {
+	switch dt {
+	case cu.DtFloat32:
+		return C.CUDA_R_32F, nil
+	case cu.DtFloat64:
+		return C.CUDA_R_64F, nil
+	case cu.DtFloat16:
+		return C.CUDA_R_16F, nil
+	case cu.DtBFloat16:
+		return C.CUDA_R_16BF, nil
+	case cu.DtInt32:
+		return C.CUDA_R_32I, nil
+	case cu.DtInt8:
+		return C.CUDA_R_8I, nil
+	case cu.DtComplex64:
+		return C.CUDA_C_32F, nil
+	case cu.DtComplex128:
+		return C.CUDA_C_64F, nil
+	}
+	return 0, errors.Errorf("cuBLAS does not support %v", dt)
+}
+
+// scale writes v to buf, as the type of alpha and beta for the compute type.
+func (ct CublasComputeType) scale(v float64, buf unsafe.Pointer) error {
+	switch ct {
+	case Compute16F, Compute16FPedantic:
+		*(*uint16)(buf) = float32ToHalf(float32(v))
+	case Compute32F, Compute32FPedantic, Compute32FFast16F, Compute32FFast16BF, Compute32FFastTF32:
+		*(*float32)(buf) = float32(v)
+	case Compute64F, Compute64FPedantic:
+		*(*float64)(buf) = v
+	case Compute32I, Compute32IPedantic:
+		*(*int32)(buf) = int32(v)
+	default:
+		return errors.Errorf("Unknown compute type %d", int(ct))
+	}
+	return nil
+}
+
+// float32ToHalf returns the bits of the IEEE half nearest to f, rounding ties to even.
+func float32ToHalf(f float32) uint16 {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int(bits>>23&0xff) - 127 + 15
+	mant := bits & 0x7fffff
+	switch {
+	case bits&0x7fffffff > 0x7f800000: // NaN
+		return sign | 0x7e00
+	case exp >= 0x1f: // overflows to infinity
+		return sign | 0x7c00
+	case exp <= 0: // subnormal, or zero
+		if exp < -10 {
+			return sign
+		}
+		mant |= 0x800000
+		shift := uint(14 - exp)
+		h := mant >> shift
+		if rem, half := mant&(1<<shift-1), uint32(1)<<(shift-1); rem > half || rem == half && h&1 == 1 {
+			h++
+		}
+		return sign | uint16(h)
+	}
+	h := uint32(exp)<<10 | mant>>13
+	if rem := mant & 0x1fff; rem > 0x1000 || rem == 0x1000 && h&1 == 1 {
+		h++ // may carry into the exponent, up to infinity
+	}
+	return sign | uint16(h)
+}
+
+// GemmEx computes
+//
+//	C = beta * C + alpha * A * B
+//
+// where the matrices may each have their own type, and the products are accumulated with the given compute type.
+// This enables the mixed precision multiplications: float16 or bfloat16 inputs accumulated in float32, int8 inputs
+// accumulated in int32, or float32 inputs computed in TF32 on tensor cores. alpha and beta are converted to the scale
+// type of the compute type (see CublasComputeType).
+//
+// The matrices are column-major, and the pointers are device pointers. The supported combinations of types are listed in
+// the documentation of cublasGemmEx; int8 inputs for instance require lda, ldb and ldc to be multiples of 4.
+func (impl *Standard) GemmEx(tA, tB blas.Transpose, m, n, k int, alpha float64, a cu.DevicePtr, aType cu.Dtype, lda int, b cu.DevicePtr, bType cu.Dtype, ldb int, beta float64, c cu.DevicePtr, cType cu.Dtype, ldc int, compute CublasComputeType, algo GemmAlgo) {
+	if impl.e != nil {
+		return
+	}
+	if !impl.fits32("GemmEx", "m, n, k, lda, ldb, ldc", m, n, k, lda, ldb, ldc) {
+		return
+	}
+	if !impl.checkStridedBatched("GemmEx", tA, tB, m, n, k, 0) {
+		return
+	}
+	if !impl.checkMatrixMemory("GemmEx", "a", a, aType, tA, m, k, lda, 0, 1) ||
+		!impl.checkMatrixMemory("GemmEx", "b", b, bType, tB, k, n, ldb, 0, 1) ||
+		!impl.checkMatrixMemory("GemmEx", "c", c, cType, blas.NoTrans, m, n, ldc, 0, 1) {
+		return
+	}
+
+	var at, bt, ct C.cudaDataType
+	if at, impl.e = dataType(aType); impl.e != nil {
+		return
+	}
+	if bt, impl.e = dataType(bType); impl.e != nil {
+		return
+	}
+	if ct, impl.e = dataType(cType); impl.e != nil {
+		return
+	}
+	var alphaBuf, betaBuf [8]byte
+	if impl.e = compute.scale(alpha, unsafe.Pointer(&alphaBuf[0])); impl.e != nil {
+		return
+	}
+	if impl.e = compute.scale(beta, unsafe.Pointer(&betaBuf[0])); impl.e != nil {
+		return
+	}
+	impl.e = status(C.cublasGemmEx(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), trans2cublasTrans(tB), C.int(m), C.int(n), C.int(k),
+		unsafe.Pointer(&alphaBuf[0]), a.Pointer(), at, C.int(lda),
+		b.Pointer(), bt, C.int(ldb),
+		unsafe.Pointer(&betaBuf[0]), c.Pointer(), ct, C.int(ldc),
+		C.cublasComputeType_t(compute), C.cublasGemmAlgo_t(algo)))
+}
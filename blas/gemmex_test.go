@@ -0,0 +1,116 @@
+package cublas
+
+import (
+	"math"
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"gonum.org/v1/gonum/blas"
+	"gorgonia.org/cu"
+)
+
+func TestFloat32ToHalf(t *testing.T) {
+	for _, c := range []struct {
+		f    float32
+		bits uint16
+	}{
+		{0, 0x0000},
+		{float32(math.Copysign(0, -1)), 0x8000},
+		{1, 0x3c00},
+		{-2, 0xc000},
+		{0.5, 0x3800},
+		{65504, 0x7bff},
+		{65520, 0x7c00}, // rounds up to infinity
+		{1e10, 0x7c00},
+		{float32(math.Inf(-1)), 0xfc00},
+		{1 + 1.0/2048, 0x3c00}, // tie, rounds to even
+		{1 + 3.0/2048, 0x3c02}, // tie, rounds to even
+		{6.103515625e-05, 0x0400},
+		{5.960464477539063e-08, 0x0001}, // smallest subnormal
+		{1e-10, 0x0000},
+	} {
+		if got := float32ToHalf(c.f); got != c.bits {
+			t.Errorf("float32ToHalf(%v): expected %#04x. Got %#04x", c.f, c.bits, got)
+		}
+	}
+	if got := float32ToHalf(float32(math.NaN())); got&0x7c00 != 0x7c00 || got&0x3ff == 0 {
+		t.Errorf("Expected a NaN. Got %#04x", got)
+	}
+}
+
+func TestGemmEx(t *testing.T) {
+	if _, err := testSetup(); err != nil {
+		t.Skip(err)
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := cu.Device(0).MakeContext(cu.SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+	impl := New()
+	defer impl.Close()
+
+	upload := func(p unsafe.Pointer, size int64) cu.DevicePtr {
+		mem, err := cu.MemAlloc(size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = cu.MemcpyHtoD(mem, p, size); err != nil {
+			t.Fatal(err)
+		}
+		return mem
+	}
+
+	// int8 inputs accumulated in int32: column major [1 3; 2 4] * [1 -1; 1 1], with 4×4 storage as the leading
+	// dimensions of int8 matrices must be multiples of 4
+	a8, b8 := make([]int8, 16), make([]int8, 16)
+	copy(a8, []int8{1, 2, 0, 0, 3, 4})
+	copy(b8, []int8{1, 1, 0, 0, -1, 1})
+	c32 := make([]int32, 16)
+	A, B, C := upload(unsafe.Pointer(&a8[0]), 16), upload(unsafe.Pointer(&b8[0]), 16), upload(unsafe.Pointer(&c32[0]), 64)
+	for _, mem := range []cu.DevicePtr{A, B, C} {
+		defer cu.MemFree(mem)
+	}
+	impl.GemmEx(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, A, cu.DtInt8, 4, B, cu.DtInt8, 4, 0, C, cu.DtInt32, 4, Compute32I, GemmDefault)
+	if err = impl.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err = cu.MemcpyDtoH(unsafe.Pointer(&c32[0]), C, 64); err != nil {
+		t.Fatal(err)
+	}
+	if c32[0] != 4 || c32[1] != 6 || c32[4] != 2 || c32[5] != 2 {
+		t.Errorf("Expected [4 2; 6 2]. Got %v", c32)
+	}
+
+	// float16 inputs and output, scaled in half
+	h := func(fs ...float32) []uint16 {
+		retVal := make([]uint16, len(fs))
+		for i, f := range fs {
+			retVal[i] = float32ToHalf(f)
+		}
+		return retVal
+	}
+	ah, bh, ch := h(1, 2, 3, 4), h(1, 0, 0, 1), h(1, 1, 1, 1)
+	Ah, Bh, Ch := upload(unsafe.Pointer(&ah[0]), 8), upload(unsafe.Pointer(&bh[0]), 8), upload(unsafe.Pointer(&ch[0]), 8)
+	for _, mem := range []cu.DevicePtr{Ah, Bh, Ch} {
+		defer cu.MemFree(mem)
+	}
+	impl.GemmEx(blas.NoTrans, blas.NoTrans, 2, 2, 2, 2, Ah, cu.DtFloat16, 2, Bh, cu.DtFloat16, 2, 0.5, Ch, cu.DtFloat16, 2, Compute16F, GemmDefaultTensorOp)
+	if err = impl.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err = cu.MemcpyDtoH(unsafe.Pointer(&ch[0]), Ch, 8); err != nil {
+		t.Fatal(err)
+	}
+	if want := h(2.5, 4.5, 6.5, 8.5); ch[0] != want[0] || ch[1] != want[1] || ch[2] != want[2] || ch[3] != want[3] {
+		t.Errorf("Expected %#04x. Got %#04x", want, ch)
+	}
+
+	impl.GemmEx(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, A, cu.DtBool, 4, B, cu.DtInt8, 4, 0, C, cu.DtInt32, 4, Compute32I, GemmDefault)
+	if err = impl.Err(); err == nil {
+		t.Error("Expected an error for a bool matrix")
+	}
+}
@@ -38,8 +38,11 @@ type Standard struct {
 	m PointerMode
 	e error
 
+	shapes ShapePolicy
+
 	cu.Context
-	dataOnDev bool
+	dataOnDev  bool
+	contractor Contractor
 
 	sync.Mutex
 }
@@ -79,6 +82,13 @@ func (impl *Standard) Init(opts ...ConsOpt) error {
 
 func (impl *Standard) Err() error { return impl.e }
 
+// TakeErr returns the error of the last call, like Err, and clears it, so that the calls that follow are made.
+func (impl *Standard) TakeErr() error {
+	err := impl.e
+	impl.e = nil
+	return err
+}
+
 func (impl *Standard) Close() error {
 	impl.Lock()
 	defer impl.Unlock()
@@ -0,0 +1,31 @@
+package cublas
+
+// stubs are the methods of Standard that are required by the gonum BLAS interfaces, but have no cuBLAS equivalent.
+// Calling them panics.
+var stubs = []string{
+	"Ctrmm",
+	"Dsdot",
+	"Dtrmm",
+	"Sdsdot",
+	"Strmm",
+	"Ztrmm",
+}
+
+// Stubs returns the names of the methods of Standard that are not backed by cuBLAS.
+// These methods exist to satisfy the gonum BLAS interfaces (blas.Float32, blas.Float64, blas.Complex64 and blas.Complex128), and panic when called.
+// All other methods of those interfaces may be relied upon.
+func Stubs() []string {
+	retVal := make([]string, len(stubs))
+	copy(retVal, stubs)
+	return retVal
+}
+
+// IsStub returns true if the named method of Standard is not backed by cuBLAS.
+func IsStub(method string) bool {
+	for _, s := range stubs {
+		if s == method {
+			return true
+		}
+	}
+	return false
+}
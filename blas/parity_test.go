@@ -0,0 +1,69 @@
+package cublas
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/blas"
+)
+
+// TestParity checks that every method of the gonum BLAS interfaces is either backed by cuBLAS, or listed in Stubs().
+//
+// Each method is called with zero values on a Standard that is already in an errored state.
+// Methods backed by cuBLAS return early, while stubs panic.
+func TestParity(t *testing.T) {
+	ifaces := []reflect.Type{
+		reflect.TypeOf((*blas.Float32)(nil)).Elem(),
+		reflect.TypeOf((*blas.Float64)(nil)).Elem(),
+		reflect.TypeOf((*blas.Complex64)(nil)).Elem(),
+		reflect.TypeOf((*blas.Complex128)(nil)).Elem(),
+	}
+
+	impl := &Standard{e: errors.New("parity")}
+	v := reflect.ValueOf(impl)
+
+	var found []string
+	for _, iface := range ifaces {
+		for i := 0; i < iface.NumMethod(); i++ {
+			name := iface.Method(i).Name
+			m := v.MethodByName(name)
+			if !m.IsValid() {
+				t.Errorf("%v: Standard does not implement %v", iface, name)
+				continue
+			}
+			if isStubCall(m) {
+				found = append(found, name)
+			}
+		}
+	}
+
+	sort.Strings(found)
+	if !reflect.DeepEqual(found, Stubs()) {
+		t.Errorf("Stubs() is out of date. Expected %v. Got %v", found, Stubs())
+	}
+	for _, name := range found {
+		if !IsStub(name) {
+			t.Errorf("Expected IsStub(%q) to be true", name)
+		}
+	}
+}
+
+func isStubCall(m reflect.Value) (stub bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			stub = strings.HasPrefix(fmt.Sprint(r), "Unimplemented")
+		}
+	}()
+
+	typ := m.Type()
+	args := make([]reflect.Value, typ.NumIn())
+	for i := range args {
+		args[i] = reflect.Zero(typ.In(i))
+	}
+	m.Call(args)
+	return false
+}
@@ -0,0 +1,75 @@
+package cublas
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync/atomic"
+)
+
+// ShapeError is the error of a call whose arguments do not describe valid vectors or matrices, such as a negative
+// dimension or a slice too short for its dimensions. It is returned by Err under the ErrorOnBadShape policy.
+type ShapeError struct {
+	Func string // the method called, such as "Sgemv"
+	Msg  string // what is wrong, with the offending values, such as "blas: lda=3 < max(1, n=5)"
+}
+
+func (e *ShapeError) Error() string { return e.Func + ": " + e.Msg }
+
+// ShapePolicy is what an implementation does when the arguments of a call are not valid.
+type ShapePolicy byte
+
+const (
+	// DefaultShapePolicy follows the policy of the package (see SetShapePolicy). It is the policy of the implementations
+	// that do not set one with WithShapePolicy.
+	DefaultShapePolicy ShapePolicy = iota
+
+	// PanicOnBadShape panics, as the BLAS implementations of gonum do. It is the policy of the package by default.
+	PanicOnBadShape
+
+	// ErrorOnBadShape skips the call, and records a *ShapeError, which is returned by Err. Like the errors of cuBLAS, it
+	// makes the later calls no-ops until it is taken with TakeErr. Servers would rather fail a request than crash.
+	ErrorOnBadShape
+)
+
+// shapePolicy is the policy of the package. It is accessed atomically.
+var shapePolicy = uint32(PanicOnBadShape)
+
+// SetShapePolicy sets the policy of the implementations that do not have their own (see WithShapePolicy).
+// DefaultShapePolicy restores PanicOnBadShape.
+func SetShapePolicy(p ShapePolicy) {
+	if p == DefaultShapePolicy {
+		p = PanicOnBadShape
+	}
+	atomic.StoreUint32(&shapePolicy, uint32(p))
+}
+
+// shapeError reports that the arguments of the call to fn are not valid, as the policy of the implementation says. The
+// message is only formatted when a check fails, so that the checks cost nothing otherwise.
+func (impl *Standard) shapeError(fn, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	p := impl.shapes
+	if p == DefaultShapePolicy {
+		p = ShapePolicy(atomic.LoadUint32(&shapePolicy))
+	}
+	if p != ErrorOnBadShape {
+		panic(msg)
+	}
+	impl.e = &ShapeError{Func: fn, Msg: msg}
+}
+
+// fits32 reports whether the values, the int arguments of the call to fn, fit the 32-bit int that cuBLAS takes. If one
+// does not, the call is reported by shapeError rather than passed with a truncated value. names are the names of the
+// arguments, separated by commas.
+//
+// The bindings are generated from cublasgen.h, which predates the 64-bit interfaces of cuBLAS 12 (the cublas*_64
+// functions), so vectors and matrices of 2^31 elements or more must be split by the caller.
+func (impl *Standard) fits32(fn, names string, values ...int) bool {
+	for i, v := range values {
+		if v > math.MaxInt32 || v < math.MinInt32 {
+			impl.shapeError(fn, "blas: %s=%d overflows the 32-bit int of cuBLAS", strings.Split(names, ", ")[i], v)
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package cublas
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+func TestShapePolicy(t *testing.T) {
+	impl := &Standard{shapes: ErrorOnBadShape}
+	if got := impl.Sdot(-1, nil, 1, nil, 1); got != 0 {
+		t.Errorf("Expected 0. Got %v", got)
+	}
+	err, ok := impl.TakeErr().(*ShapeError)
+	if !ok {
+		t.Fatalf("Expected a *ShapeError. Got %v", impl.Err())
+	}
+	if err.Func != "Sdot" || err.Msg != "blas: n=-1 < 0" {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if impl.Err() != nil {
+		t.Errorf("Expected the error to be taken")
+	}
+	impl.SgemmStridedBatched('x', 'N', 1, 1, 1, 1, 0, 1, 1, 0, 1, 1, 0, 0, 1, 1, 1)
+	if err, ok := impl.TakeErr().(*ShapeError); !ok || err.Msg != "blas: illegal transpose tA=120" {
+		t.Errorf("Expected an illegal transpose. Got %v", err)
+	}
+
+	impl.Ssyrk(blas.Upper, blas.NoTrans, 5, 5, 1, make([]float32, 25), 3, 0, make([]float32, 25), 5)
+	if err, ok := impl.TakeErr().(*ShapeError); !ok || err.Msg != "blas: lda=3 < max(1, col=5)" {
+		t.Errorf("Expected the leading dimension to be reported with its values. Got %v", err)
+	}
+
+	impl = &Standard{}
+	defer SetShapePolicy(DefaultShapePolicy)
+	SetShapePolicy(ErrorOnBadShape)
+	impl.Sscal(-1, 1, nil, 1)
+	if _, ok := impl.Err().(*ShapeError); !ok {
+		t.Errorf("Expected the policy of the package to be followed. Got %v", impl.Err())
+	}
+
+	SetShapePolicy(DefaultShapePolicy)
+	impl = &Standard{}
+	defer func() {
+		if r := recover(); r != "blas: n=-1 < 0" {
+			t.Errorf("Expected a panic by default. Got %v", r)
+		}
+	}()
+	impl.Sscal(-1, 1, nil, 1)
+}
+
+func TestShapeOverflow(t *testing.T) {
+	impl := &Standard{shapes: ErrorOnBadShape}
+	impl.Sscal(1<<31, 1, nil, 1)
+	if err, ok := impl.TakeErr().(*ShapeError); !ok || err.Msg != "blas: n=2147483648 overflows the 32-bit int of cuBLAS" {
+		t.Errorf("Expected n to overflow. Got %v", err)
+	}
+	impl.Sgemv(blas.NoTrans, 2, 2, 1, make([]float32, 4), 2, nil, -1<<32, 0, nil, 1)
+	if err, ok := impl.TakeErr().(*ShapeError); !ok || err.Msg != "blas: incX=-4294967296 overflows the 32-bit int of cuBLAS" {
+		t.Errorf("Expected incX to overflow. Got %v", err)
+	}
+}
@@ -0,0 +1,240 @@
+package cublas
+
+// #include <cublas_v2.h>
+import "C"
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/blas"
+	"gorgonia.org/cu"
+)
+
+// SgemmStridedBatched computes
+//
+//	C[i] = beta * C[i] + alpha * A[i] * B[i]
+//
+// for batch matrices, where A[i] starts strideA elements after A[i-1] (and likewise for B and C).
+// The matrices are column-major, and the pointers are device pointers.
+func (impl *Standard) SgemmStridedBatched(tA, tB blas.Transpose, m, n, k int, alpha float32, a cu.DevicePtr, lda, strideA int, b cu.DevicePtr, ldb, strideB int, beta float32, c cu.DevicePtr, ldc, strideC int, batch int) {
+	if impl.e != nil {
+		return
+	}
+	if !impl.fits32("SgemmStridedBatched", "m, n, k, lda, ldb, ldc, batch", m, n, k, lda, ldb, ldc, batch) {
+		return
+	}
+	if !impl.checkStridedBatched("SgemmStridedBatched", tA, tB, m, n, k, batch) {
+		return
+	}
+	if !impl.checkGemmMemory("SgemmStridedBatched", cu.DtFloat32, tA, tB, m, n, k, a, lda, strideA, b, ldb, strideB, c, ldc, strideC, batch) {
+		return
+	}
+	impl.e = status(C.cublasSgemmStridedBatched(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), trans2cublasTrans(tB), C.int(m), C.int(n), C.int(k),
+		(*C.float)(&alpha), (*C.float)(a.Pointer()), C.int(lda), C.longlong(strideA),
+		(*C.float)(b.Pointer()), C.int(ldb), C.longlong(strideB),
+		(*C.float)(&beta), (*C.float)(c.Pointer()), C.int(ldc), C.longlong(strideC), C.int(batch)))
+}
+
+// DgemmStridedBatched is the float64 version of SgemmStridedBatched.
+func (impl *Standard) DgemmStridedBatched(tA, tB blas.Transpose, m, n, k int, alpha float64, a cu.DevicePtr, lda, strideA int, b cu.DevicePtr, ldb, strideB int, beta float64, c cu.DevicePtr, ldc, strideC int, batch int) {
+	if impl.e != nil {
+		return
+	}
+	if !impl.fits32("DgemmStridedBatched", "m, n, k, lda, ldb, ldc, batch", m, n, k, lda, ldb, ldc, batch) {
+		return
+	}
+	if !impl.checkStridedBatched("DgemmStridedBatched", tA, tB, m, n, k, batch) {
+		return
+	}
+	if !impl.checkGemmMemory("DgemmStridedBatched", cu.DtFloat64, tA, tB, m, n, k, a, lda, strideA, b, ldb, strideB, c, ldc, strideC, batch) {
+		return
+	}
+	impl.e = status(C.cublasDgemmStridedBatched(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), trans2cublasTrans(tB), C.int(m), C.int(n), C.int(k),
+		(*C.double)(&alpha), (*C.double)(a.Pointer()), C.int(lda), C.longlong(strideA),
+		(*C.double)(b.Pointer()), C.int(ldb), C.longlong(strideB),
+		(*C.double)(&beta), (*C.double)(c.Pointer()), C.int(ldc), C.longlong(strideC), C.int(batch)))
+}
+
+// CgemmStridedBatched is the complex64 version of SgemmStridedBatched.
+func (impl *Standard) CgemmStridedBatched(tA, tB blas.Transpose, m, n, k int, alpha complex64, a cu.DevicePtr, lda, strideA int, b cu.DevicePtr, ldb, strideB int, beta complex64, c cu.DevicePtr, ldc, strideC int, batch int) {
+	if impl.e != nil {
+		return
+	}
+	if !impl.fits32("CgemmStridedBatched", "m, n, k, lda, ldb, ldc, batch", m, n, k, lda, ldb, ldc, batch) {
+		return
+	}
+	if !impl.checkStridedBatched("CgemmStridedBatched", tA, tB, m, n, k, batch) {
+		return
+	}
+	if !impl.checkGemmMemory("CgemmStridedBatched", cu.DtComplex64, tA, tB, m, n, k, a, lda, strideA, b, ldb, strideB, c, ldc, strideC, batch) {
+		return
+	}
+	impl.e = status(C.cublasCgemmStridedBatched(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), trans2cublasTrans(tB), C.int(m), C.int(n), C.int(k),
+		(*C.cuComplex)(unsafe.Pointer(&alpha)), (*C.cuComplex)(a.Pointer()), C.int(lda), C.longlong(strideA),
+		(*C.cuComplex)(b.Pointer()), C.int(ldb), C.longlong(strideB),
+		(*C.cuComplex)(unsafe.Pointer(&beta)), (*C.cuComplex)(c.Pointer()), C.int(ldc), C.longlong(strideC), C.int(batch)))
+}
+
+// ZgemmStridedBatched is the complex128 version of SgemmStridedBatched.
+func (impl *Standard) ZgemmStridedBatched(tA, tB blas.Transpose, m, n, k int, alpha complex128, a cu.DevicePtr, lda, strideA int, b cu.DevicePtr, ldb, strideB int, beta complex128, c cu.DevicePtr, ldc, strideC int, batch int) {
+	if impl.e != nil {
+		return
+	}
+	if !impl.fits32("ZgemmStridedBatched", "m, n, k, lda, ldb, ldc, batch", m, n, k, lda, ldb, ldc, batch) {
+		return
+	}
+	if !impl.checkStridedBatched("ZgemmStridedBatched", tA, tB, m, n, k, batch) {
+		return
+	}
+	if !impl.checkGemmMemory("ZgemmStridedBatched", cu.DtComplex128, tA, tB, m, n, k, a, lda, strideA, b, ldb, strideB, c, ldc, strideC, batch) {
+		return
+	}
+	impl.e = status(C.cublasZgemmStridedBatched(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), trans2cublasTrans(tB), C.int(m), C.int(n), C.int(k),
+		(*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (*C.cuDoubleComplex)(a.Pointer()), C.int(lda), C.longlong(strideA),
+		(*C.cuDoubleComplex)(b.Pointer()), C.int(ldb), C.longlong(strideB),
+		(*C.cuDoubleComplex)(unsafe.Pointer(&beta)), (*C.cuDoubleComplex)(c.Pointer()), C.int(ldc), C.longlong(strideC), C.int(batch)))
+}
+
+// SgemmBatched computes
+//
+//	C[i] = beta * C[i] + alpha * A[i] * B[i]
+//
+// for batch matrices that need not be evenly spaced: a, b and c are device arrays of batch device pointers, one per
+// matrix. Such an array can be uploaded from a []cu.DevicePtr. Use SgemmStridedBatched when the matrices are evenly
+// spaced, as it saves the arrays.
+func (impl *Standard) SgemmBatched(tA, tB blas.Transpose, m, n, k int, alpha float32, a cu.DevicePtr, lda int, b cu.DevicePtr, ldb int, beta float32, c cu.DevicePtr, ldc int, batch int) {
+	if impl.e != nil {
+		return
+	}
+	if !impl.fits32("SgemmBatched", "m, n, k, lda, ldb, ldc, batch", m, n, k, lda, ldb, ldc, batch) {
+		return
+	}
+	if !impl.checkStridedBatched("SgemmBatched", tA, tB, m, n, k, batch) {
+		return
+	}
+	if !impl.checkPointerArrays("SgemmBatched", batch, a, b, c) {
+		return
+	}
+	impl.e = status(C.cublasSgemmBatched(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), trans2cublasTrans(tB), C.int(m), C.int(n), C.int(k),
+		(*C.float)(&alpha), (**C.float)(a.Pointer()), C.int(lda),
+		(**C.float)(b.Pointer()), C.int(ldb),
+		(*C.float)(&beta), (**C.float)(c.Pointer()), C.int(ldc), C.int(batch)))
+}
+
+// DgemmBatched is the float64 version of SgemmBatched.
+func (impl *Standard) DgemmBatched(tA, tB blas.Transpose, m, n, k int, alpha float64, a cu.DevicePtr, lda int, b cu.DevicePtr, ldb int, beta float64, c cu.DevicePtr, ldc int, batch int) {
+	if impl.e != nil {
+		return
+	}
+	if !impl.fits32("DgemmBatched", "m, n, k, lda, ldb, ldc, batch", m, n, k, lda, ldb, ldc, batch) {
+		return
+	}
+	if !impl.checkStridedBatched("DgemmBatched", tA, tB, m, n, k, batch) {
+		return
+	}
+	if !impl.checkPointerArrays("DgemmBatched", batch, a, b, c) {
+		return
+	}
+	impl.e = status(C.cublasDgemmBatched(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), trans2cublasTrans(tB), C.int(m), C.int(n), C.int(k),
+		(*C.double)(&alpha), (**C.double)(a.Pointer()), C.int(lda),
+		(**C.double)(b.Pointer()), C.int(ldb),
+		(*C.double)(&beta), (**C.double)(c.Pointer()), C.int(ldc), C.int(batch)))
+}
+
+// CgemmBatched is the complex64 version of SgemmBatched.
+func (impl *Standard) CgemmBatched(tA, tB blas.Transpose, m, n, k int, alpha complex64, a cu.DevicePtr, lda int, b cu.DevicePtr, ldb int, beta complex64, c cu.DevicePtr, ldc int, batch int) {
+	if impl.e != nil {
+		return
+	}
+	if !impl.fits32("CgemmBatched", "m, n, k, lda, ldb, ldc, batch", m, n, k, lda, ldb, ldc, batch) {
+		return
+	}
+	if !impl.checkStridedBatched("CgemmBatched", tA, tB, m, n, k, batch) {
+		return
+	}
+	if !impl.checkPointerArrays("CgemmBatched", batch, a, b, c) {
+		return
+	}
+	impl.e = status(C.cublasCgemmBatched(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), trans2cublasTrans(tB), C.int(m), C.int(n), C.int(k),
+		(*C.cuComplex)(unsafe.Pointer(&alpha)), (**C.cuComplex)(a.Pointer()), C.int(lda),
+		(**C.cuComplex)(b.Pointer()), C.int(ldb),
+		(*C.cuComplex)(unsafe.Pointer(&beta)), (**C.cuComplex)(c.Pointer()), C.int(ldc), C.int(batch)))
+}
+
+// ZgemmBatched is the complex128 version of SgemmBatched.
+func (impl *Standard) ZgemmBatched(tA, tB blas.Transpose, m, n, k int, alpha complex128, a cu.DevicePtr, lda int, b cu.DevicePtr, ldb int, beta complex128, c cu.DevicePtr, ldc int, batch int) {
+	if impl.e != nil {
+		return
+	}
+	if !impl.fits32("ZgemmBatched", "m, n, k, lda, ldb, ldc, batch", m, n, k, lda, ldb, ldc, batch) {
+		return
+	}
+	if !impl.checkStridedBatched("ZgemmBatched", tA, tB, m, n, k, batch) {
+		return
+	}
+	if !impl.checkPointerArrays("ZgemmBatched", batch, a, b, c) {
+		return
+	}
+	impl.e = status(C.cublasZgemmBatched(C.cublasHandle_t(impl.h), trans2cublasTrans(tA), trans2cublasTrans(tB), C.int(m), C.int(n), C.int(k),
+		(*C.cuDoubleComplex)(unsafe.Pointer(&alpha)), (**C.cuDoubleComplex)(a.Pointer()), C.int(lda),
+		(**C.cuDoubleComplex)(b.Pointer()), C.int(ldb),
+		(*C.cuDoubleComplex)(unsafe.Pointer(&beta)), (**C.cuDoubleComplex)(c.Pointer()), C.int(ldc), C.int(batch)))
+}
+
+// checkStridedBatched checks the arguments of the (batched) multiplications of the method fn, and reports whether they
+// are valid (see ShapePolicy).
+func (impl *Standard) checkStridedBatched(fn string, tA, tB blas.Transpose, m, n, k, batch int) bool {
+	switch {
+	case tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans:
+		impl.shapeError(fn, "blas: illegal transpose tA=%v", tA)
+	case tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans:
+		impl.shapeError(fn, "blas: illegal transpose tB=%v", tB)
+	case m < 0:
+		impl.shapeError(fn, "blas: m=%d < 0", m)
+	case n < 0:
+		impl.shapeError(fn, "blas: n=%d < 0", n)
+	case k < 0:
+		impl.shapeError(fn, "blas: k=%d < 0", k)
+	case batch < 0:
+		impl.shapeError(fn, "blas: batch=%d < 0", batch)
+	default:
+		return true
+	}
+	return false
+}
+
+// checkMatrixMemory checks the pointer of a strided batch of matrices against the matrices (see cu.CheckPtr): that it
+// is aligned to their elements, and that they are all in its allocation. The matrices are rows×cols, or cols×rows if
+// t transposes them.
+func (impl *Standard) checkMatrixMemory(fn, name string, ptr cu.DevicePtr, dt cu.Dtype, t blas.Transpose, rows, cols, ld, stride, batch int) bool {
+	if t != blas.NoTrans {
+		rows, cols = cols, rows
+	}
+	var size int64
+	if rows > 0 && cols > 0 && batch > 0 {
+		size = (int64(ld)*int64(cols-1) + int64(rows) + int64(stride)*int64(batch-1)) * dt.Size()
+	}
+	if err := cu.CheckPtr(ptr, size, dt.Size()); err != nil {
+		impl.e = errors.Wrapf(err, "%s: matrix %s", fn, name)
+		return false
+	}
+	return true
+}
+
+// checkGemmMemory checks the pointers of the matrices of a gemm of a strided batch (see checkMatrixMemory).
+func (impl *Standard) checkGemmMemory(fn string, dt cu.Dtype, tA, tB blas.Transpose, m, n, k int, a cu.DevicePtr, lda, strideA int, b cu.DevicePtr, ldb, strideB int, c cu.DevicePtr, ldc, strideC int, batch int) bool {
+	return impl.checkMatrixMemory(fn, "a", a, dt, tA, m, k, lda, strideA, batch) &&
+		impl.checkMatrixMemory(fn, "b", b, dt, tB, k, n, ldb, strideB, batch) &&
+		impl.checkMatrixMemory(fn, "c", c, dt, blas.NoTrans, m, n, ldc, strideC, batch)
+}
+
+// checkPointerArrays checks the device arrays of the pointers of the matrices of a batch (see cu.CheckPtr).
+func (impl *Standard) checkPointerArrays(fn string, batch int, arrays ...cu.DevicePtr) bool {
+	for _, p := range arrays {
+		if err := cu.CheckPtr(p, int64(batch)*8, 8); err != nil {
+			impl.e = errors.Wrapf(err, "%s: array of pointers", fn)
+			return false
+		}
+	}
+	return true
+}
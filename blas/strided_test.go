@@ -0,0 +1,88 @@
+package cublas
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"gonum.org/v1/gonum/blas"
+	"gorgonia.org/cu"
+)
+
+func TestSgemmBatched(t *testing.T) {
+	if _, err := testSetup(); err != nil {
+		t.Skip(err)
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := cu.Device(0).MakeContext(cu.SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+	impl := New()
+	defer impl.Close()
+
+	// 3 batches of 2×2 matrices: C[i] = A[i] * B[i]
+	const batch, size = 3, 4
+	a := []float32{1, 2, 3, 4, 0, 1, 1, 0, 2, 0, 0, 2}
+	b := []float32{1, 0, 0, 1, 1, 2, 3, 4, 1, 1, 1, 1}
+	upload := func(data []float32) cu.DevicePtr {
+		mem, err := cu.MemAlloc(int64(len(data) * 4))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = cu.MemcpyHtoD(mem, unsafe.Pointer(&data[0]), int64(len(data)*4)); err != nil {
+			t.Fatal(err)
+		}
+		return mem
+	}
+	A, B := upload(a), upload(b)
+	Cs, Cb := upload(make([]float32, batch*size)), upload(make([]float32, batch*size))
+	for _, mem := range []cu.DevicePtr{A, B, Cs, Cb} {
+		defer cu.MemFree(mem)
+	}
+
+	impl.SgemmStridedBatched(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, A, 2, size, B, 2, size, 0, Cs, 2, size, batch)
+
+	// the same matrices, through arrays of pointers
+	ptrs := func(base cu.DevicePtr) cu.DevicePtr {
+		arr := make([]cu.DevicePtr, batch)
+		for i := range arr {
+			arr[i] = base.Offset(int64(i * size * 4))
+		}
+		mem, err := cu.MemAlloc(int64(batch * 8))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = cu.MemcpyHtoD(mem, unsafe.Pointer(&arr[0]), int64(batch*8)); err != nil {
+			t.Fatal(err)
+		}
+		return mem
+	}
+	Ap, Bp, Cp := ptrs(A), ptrs(B), ptrs(Cb)
+	for _, mem := range []cu.DevicePtr{Ap, Bp, Cp} {
+		defer cu.MemFree(mem)
+	}
+	impl.SgemmBatched(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, Ap, 2, Bp, 2, 0, Cp, 2, batch)
+	if err = impl.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	strided, batched := make([]float32, batch*size), make([]float32, batch*size)
+	if err = cu.MemcpyDtoH(unsafe.Pointer(&strided[0]), Cs, int64(len(strided)*4)); err != nil {
+		t.Fatal(err)
+	}
+	if err = cu.MemcpyDtoH(unsafe.Pointer(&batched[0]), Cb, int64(len(batched)*4)); err != nil {
+		t.Fatal(err)
+	}
+	for i := range strided {
+		if strided[i] != batched[i] {
+			t.Fatalf("SgemmBatched computed %v, SgemmStridedBatched %v", batched, strided)
+		}
+	}
+	// column major: [1 3; 2 4] * identity
+	if strided[0] != 1 || strided[1] != 2 || strided[2] != 3 || strided[3] != 4 {
+		t.Errorf("Expected the first product to be A[0]. Got %v", strided[:size])
+	}
+}
@@ -0,0 +1,162 @@
+package cublas
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"gorgonia.org/cu"
+	"gorgonia.org/cu/blas/fallback"
+)
+
+// Dim is a named dimension of a BLAS call, e.g. the m, n and k of a GEMM.
+type Dim struct {
+	Name string
+	Size int
+}
+
+// TraceRecord is the record of a single traced BLAS call.
+type TraceRecord struct {
+	Method   string
+	Dims     []Dim
+	Duration time.Duration
+}
+
+func (r TraceRecord) String() string {
+	var buf bytes.Buffer
+	buf.WriteString(r.Method)
+	buf.WriteByte('(')
+	for i, d := range r.Dims {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(&buf, "%s=%d", d.Name, d.Size)
+	}
+	fmt.Fprintf(&buf, ") %v", r.Duration)
+	return buf.String()
+}
+
+// TraceSummary is the aggregate of all the traced calls of a method with the same dimensions.
+type TraceSummary struct {
+	Method string
+	Dims   []Dim
+	Calls  int
+	Total  time.Duration
+}
+
+// TracingImplementation wraps a BLAS implementation, and logs each call with its dimensions and the time it took.
+// To trace an implementation, simply wrap it:
+//
+//	impl := NewTracing(New(WithContext(ctx)), os.Stderr)
+//
+// When the traced implementation is a *Standard, calls are timed with CUDA events, and each call is synchronized.
+// This distorts the overall timing of a program, but gives an accurate account of which calls dominate.
+// Otherwise the wall clock time is used.
+//
+// The methods of TracingImplementation are generated by gentracing.
+type TracingImplementation struct {
+	fallback.Interface
+
+	w   io.Writer
+	gpu bool
+
+	sync.Mutex
+	summary map[string]*TraceSummary
+}
+
+// NewTracing creates a TracingImplementation. The records of each call are written to w, which may be nil.
+func NewTracing(impl fallback.Interface, w io.Writer) *TracingImplementation {
+	_, gpu := impl.(*Standard)
+	return &TracingImplementation{
+		Interface: impl,
+		w:         w,
+		gpu:       gpu,
+		summary:   make(map[string]*TraceSummary),
+	}
+}
+
+// Summary returns the aggregated records of all the calls traced so far, sorted by the total time taken, in descending order.
+func (impl *TracingImplementation) Summary() []TraceSummary {
+	impl.Lock()
+	retVal := make([]TraceSummary, 0, len(impl.summary))
+	for _, s := range impl.summary {
+		retVal = append(retVal, *s)
+	}
+	impl.Unlock()
+	sort.Slice(retVal, func(i, j int) bool { return retVal[i].Total > retVal[j].Total })
+	return retVal
+}
+
+// Reset clears the summary.
+func (impl *TracingImplementation) Reset() {
+	impl.Lock()
+	impl.summary = make(map[string]*TraceSummary)
+	impl.Unlock()
+}
+
+// traceStart is the state at the beginning of a traced call.
+type traceStart struct {
+	wall        time.Time
+	start, stop cu.Event
+	events      bool
+}
+
+func (impl *TracingImplementation) begin() (t traceStart) {
+	if impl.gpu {
+		t.events = t.makeEvents() == nil && t.start.Record(cu.NoStream) == nil
+	}
+	t.wall = time.Now()
+	return t
+}
+
+func (impl *TracingImplementation) end(t traceStart, method string, dims []Dim) {
+	d := time.Since(t.wall)
+	if t.events {
+		if ms, err := t.elapsed(); err == nil {
+			d = time.Duration(ms * float64(time.Millisecond))
+		}
+	}
+	if t.start != (cu.Event{}) {
+		cu.DestroyEvent(&t.start)
+	}
+	if t.stop != (cu.Event{}) {
+		cu.DestroyEvent(&t.stop)
+	}
+
+	rec := TraceRecord{Method: method, Dims: dims, Duration: d}
+	key := fmt.Sprint(method, dims)
+
+	impl.Lock()
+	defer impl.Unlock()
+	s, ok := impl.summary[key]
+	if !ok {
+		s = &TraceSummary{Method: method, Dims: dims}
+		impl.summary[key] = s
+	}
+	s.Calls++
+	s.Total += d
+	if impl.w != nil {
+		fmt.Fprintln(impl.w, rec)
+	}
+}
+
+func (t *traceStart) makeEvents() (err error) {
+	if t.start, err = cu.MakeEvent(cu.DefaultEvent); err != nil {
+		return err
+	}
+	t.stop, err = cu.MakeEvent(cu.DefaultEvent)
+	return err
+}
+
+func (t *traceStart) elapsed() (ms float64, err error) {
+	if err = t.stop.Record(cu.NoStream); err != nil {
+		return
+	}
+	if err = t.stop.Synchronize(); err != nil {
+		return
+	}
+	return t.start.Elapsed(t.stop)
+}
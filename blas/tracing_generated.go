@@ -0,0 +1,1023 @@
+// Code generated by gentracing. DO NOT EDIT.
+
+package cublas
+
+import "gonum.org/v1/gonum/blas"
+
+// Sdsdot traces a call to the Sdsdot method of the traced implementation.
+func (impl *TracingImplementation) Sdsdot(n int, alpha float32, x []float32, incX int, y []float32, incY int) float32 {
+	tr := impl.begin()
+	r0 := impl.Interface.Sdsdot(n, alpha, x, incX, y, incY)
+	impl.end(tr, "Sdsdot", []Dim{{"n", n}})
+	return r0
+}
+
+// Dsdot traces a call to the Dsdot method of the traced implementation.
+func (impl *TracingImplementation) Dsdot(n int, x []float32, incX int, y []float32, incY int) float64 {
+	tr := impl.begin()
+	r0 := impl.Interface.Dsdot(n, x, incX, y, incY)
+	impl.end(tr, "Dsdot", []Dim{{"n", n}})
+	return r0
+}
+
+// Sdot traces a call to the Sdot method of the traced implementation.
+func (impl *TracingImplementation) Sdot(n int, x []float32, incX int, y []float32, incY int) float32 {
+	tr := impl.begin()
+	r0 := impl.Interface.Sdot(n, x, incX, y, incY)
+	impl.end(tr, "Sdot", []Dim{{"n", n}})
+	return r0
+}
+
+// Snrm2 traces a call to the Snrm2 method of the traced implementation.
+func (impl *TracingImplementation) Snrm2(n int, x []float32, incX int) float32 {
+	tr := impl.begin()
+	r0 := impl.Interface.Snrm2(n, x, incX)
+	impl.end(tr, "Snrm2", []Dim{{"n", n}})
+	return r0
+}
+
+// Sasum traces a call to the Sasum method of the traced implementation.
+func (impl *TracingImplementation) Sasum(n int, x []float32, incX int) float32 {
+	tr := impl.begin()
+	r0 := impl.Interface.Sasum(n, x, incX)
+	impl.end(tr, "Sasum", []Dim{{"n", n}})
+	return r0
+}
+
+// Isamax traces a call to the Isamax method of the traced implementation.
+func (impl *TracingImplementation) Isamax(n int, x []float32, incX int) int {
+	tr := impl.begin()
+	r0 := impl.Interface.Isamax(n, x, incX)
+	impl.end(tr, "Isamax", []Dim{{"n", n}})
+	return r0
+}
+
+// Sswap traces a call to the Sswap method of the traced implementation.
+func (impl *TracingImplementation) Sswap(n int, x []float32, incX int, y []float32, incY int) {
+	tr := impl.begin()
+	impl.Interface.Sswap(n, x, incX, y, incY)
+	impl.end(tr, "Sswap", []Dim{{"n", n}})
+}
+
+// Scopy traces a call to the Scopy method of the traced implementation.
+func (impl *TracingImplementation) Scopy(n int, x []float32, incX int, y []float32, incY int) {
+	tr := impl.begin()
+	impl.Interface.Scopy(n, x, incX, y, incY)
+	impl.end(tr, "Scopy", []Dim{{"n", n}})
+}
+
+// Saxpy traces a call to the Saxpy method of the traced implementation.
+func (impl *TracingImplementation) Saxpy(n int, alpha float32, x []float32, incX int, y []float32, incY int) {
+	tr := impl.begin()
+	impl.Interface.Saxpy(n, alpha, x, incX, y, incY)
+	impl.end(tr, "Saxpy", []Dim{{"n", n}})
+}
+
+// Srotg traces a call to the Srotg method of the traced implementation.
+func (impl *TracingImplementation) Srotg(a float32, b float32) (float32, float32, float32, float32) {
+	tr := impl.begin()
+	r0, r1, r2, r3 := impl.Interface.Srotg(a, b)
+	impl.end(tr, "Srotg", nil)
+	return r0, r1, r2, r3
+}
+
+// Srotmg traces a call to the Srotmg method of the traced implementation.
+func (impl *TracingImplementation) Srotmg(d1 float32, d2 float32, b1 float32, b2 float32) (blas.SrotmParams, float32, float32, float32) {
+	tr := impl.begin()
+	r0, r1, r2, r3 := impl.Interface.Srotmg(d1, d2, b1, b2)
+	impl.end(tr, "Srotmg", nil)
+	return r0, r1, r2, r3
+}
+
+// Srot traces a call to the Srot method of the traced implementation.
+func (impl *TracingImplementation) Srot(n int, x []float32, incX int, y []float32, incY int, c float32, s float32) {
+	tr := impl.begin()
+	impl.Interface.Srot(n, x, incX, y, incY, c, s)
+	impl.end(tr, "Srot", []Dim{{"n", n}})
+}
+
+// Srotm traces a call to the Srotm method of the traced implementation.
+func (impl *TracingImplementation) Srotm(n int, x []float32, incX int, y []float32, incY int, p blas.SrotmParams) {
+	tr := impl.begin()
+	impl.Interface.Srotm(n, x, incX, y, incY, p)
+	impl.end(tr, "Srotm", []Dim{{"n", n}})
+}
+
+// Sscal traces a call to the Sscal method of the traced implementation.
+func (impl *TracingImplementation) Sscal(n int, alpha float32, x []float32, incX int) {
+	tr := impl.begin()
+	impl.Interface.Sscal(n, alpha, x, incX)
+	impl.end(tr, "Sscal", []Dim{{"n", n}})
+}
+
+// Sgemv traces a call to the Sgemv method of the traced implementation.
+func (impl *TracingImplementation) Sgemv(tA blas.Transpose, m int, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
+	tr := impl.begin()
+	impl.Interface.Sgemv(tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+	impl.end(tr, "Sgemv", []Dim{{"m", m}, {"n", n}})
+}
+
+// Sgbmv traces a call to the Sgbmv method of the traced implementation.
+func (impl *TracingImplementation) Sgbmv(tA blas.Transpose, m int, n int, kL int, kU int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
+	tr := impl.begin()
+	impl.Interface.Sgbmv(tA, m, n, kL, kU, alpha, a, lda, x, incX, beta, y, incY)
+	impl.end(tr, "Sgbmv", []Dim{{"m", m}, {"n", n}, {"kL", kL}, {"kU", kU}})
+}
+
+// Strmv traces a call to the Strmv method of the traced implementation.
+func (impl *TracingImplementation) Strmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float32, lda int, x []float32, incX int) {
+	tr := impl.begin()
+	impl.Interface.Strmv(ul, tA, d, n, a, lda, x, incX)
+	impl.end(tr, "Strmv", []Dim{{"n", n}})
+}
+
+// Stbmv traces a call to the Stbmv method of the traced implementation.
+func (impl *TracingImplementation) Stbmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []float32, lda int, x []float32, incX int) {
+	tr := impl.begin()
+	impl.Interface.Stbmv(ul, tA, d, n, k, a, lda, x, incX)
+	impl.end(tr, "Stbmv", []Dim{{"n", n}, {"k", k}})
+}
+
+// Stpmv traces a call to the Stpmv method of the traced implementation.
+func (impl *TracingImplementation) Stpmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float32, x []float32, incX int) {
+	tr := impl.begin()
+	impl.Interface.Stpmv(ul, tA, d, n, ap, x, incX)
+	impl.end(tr, "Stpmv", []Dim{{"n", n}})
+}
+
+// Strsv traces a call to the Strsv method of the traced implementation.
+func (impl *TracingImplementation) Strsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float32, lda int, x []float32, incX int) {
+	tr := impl.begin()
+	impl.Interface.Strsv(ul, tA, d, n, a, lda, x, incX)
+	impl.end(tr, "Strsv", []Dim{{"n", n}})
+}
+
+// Stbsv traces a call to the Stbsv method of the traced implementation.
+func (impl *TracingImplementation) Stbsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []float32, lda int, x []float32, incX int) {
+	tr := impl.begin()
+	impl.Interface.Stbsv(ul, tA, d, n, k, a, lda, x, incX)
+	impl.end(tr, "Stbsv", []Dim{{"n", n}, {"k", k}})
+}
+
+// Stpsv traces a call to the Stpsv method of the traced implementation.
+func (impl *TracingImplementation) Stpsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float32, x []float32, incX int) {
+	tr := impl.begin()
+	impl.Interface.Stpsv(ul, tA, d, n, ap, x, incX)
+	impl.end(tr, "Stpsv", []Dim{{"n", n}})
+}
+
+// Ssymv traces a call to the Ssymv method of the traced implementation.
+func (impl *TracingImplementation) Ssymv(ul blas.Uplo, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
+	tr := impl.begin()
+	impl.Interface.Ssymv(ul, n, alpha, a, lda, x, incX, beta, y, incY)
+	impl.end(tr, "Ssymv", []Dim{{"n", n}})
+}
+
+// Ssbmv traces a call to the Ssbmv method of the traced implementation.
+func (impl *TracingImplementation) Ssbmv(ul blas.Uplo, n int, k int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
+	tr := impl.begin()
+	impl.Interface.Ssbmv(ul, n, k, alpha, a, lda, x, incX, beta, y, incY)
+	impl.end(tr, "Ssbmv", []Dim{{"n", n}, {"k", k}})
+}
+
+// Sspmv traces a call to the Sspmv method of the traced implementation.
+func (impl *TracingImplementation) Sspmv(ul blas.Uplo, n int, alpha float32, ap []float32, x []float32, incX int, beta float32, y []float32, incY int) {
+	tr := impl.begin()
+	impl.Interface.Sspmv(ul, n, alpha, ap, x, incX, beta, y, incY)
+	impl.end(tr, "Sspmv", []Dim{{"n", n}})
+}
+
+// Sger traces a call to the Sger method of the traced implementation.
+func (impl *TracingImplementation) Sger(m int, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int) {
+	tr := impl.begin()
+	impl.Interface.Sger(m, n, alpha, x, incX, y, incY, a, lda)
+	impl.end(tr, "Sger", []Dim{{"m", m}, {"n", n}})
+}
+
+// Ssyr traces a call to the Ssyr method of the traced implementation.
+func (impl *TracingImplementation) Ssyr(ul blas.Uplo, n int, alpha float32, x []float32, incX int, a []float32, lda int) {
+	tr := impl.begin()
+	impl.Interface.Ssyr(ul, n, alpha, x, incX, a, lda)
+	impl.end(tr, "Ssyr", []Dim{{"n", n}})
+}
+
+// Sspr traces a call to the Sspr method of the traced implementation.
+func (impl *TracingImplementation) Sspr(ul blas.Uplo, n int, alpha float32, x []float32, incX int, ap []float32) {
+	tr := impl.begin()
+	impl.Interface.Sspr(ul, n, alpha, x, incX, ap)
+	impl.end(tr, "Sspr", []Dim{{"n", n}})
+}
+
+// Ssyr2 traces a call to the Ssyr2 method of the traced implementation.
+func (impl *TracingImplementation) Ssyr2(ul blas.Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int) {
+	tr := impl.begin()
+	impl.Interface.Ssyr2(ul, n, alpha, x, incX, y, incY, a, lda)
+	impl.end(tr, "Ssyr2", []Dim{{"n", n}})
+}
+
+// Sspr2 traces a call to the Sspr2 method of the traced implementation.
+func (impl *TracingImplementation) Sspr2(ul blas.Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32) {
+	tr := impl.begin()
+	impl.Interface.Sspr2(ul, n, alpha, x, incX, y, incY, a)
+	impl.end(tr, "Sspr2", []Dim{{"n", n}})
+}
+
+// Sgemm traces a call to the Sgemm method of the traced implementation.
+func (impl *TracingImplementation) Sgemm(tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Sgemm(tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	impl.end(tr, "Sgemm", []Dim{{"m", m}, {"n", n}, {"k", k}})
+}
+
+// Ssymm traces a call to the Ssymm method of the traced implementation.
+func (impl *TracingImplementation) Ssymm(s blas.Side, ul blas.Uplo, m int, n int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Ssymm(s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+	impl.end(tr, "Ssymm", []Dim{{"m", m}, {"n", n}})
+}
+
+// Ssyrk traces a call to the Ssyrk method of the traced implementation.
+func (impl *TracingImplementation) Ssyrk(ul blas.Uplo, t blas.Transpose, n int, k int, alpha float32, a []float32, lda int, beta float32, c []float32, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Ssyrk(ul, t, n, k, alpha, a, lda, beta, c, ldc)
+	impl.end(tr, "Ssyrk", []Dim{{"n", n}, {"k", k}})
+}
+
+// Ssyr2k traces a call to the Ssyr2k method of the traced implementation.
+func (impl *TracingImplementation) Ssyr2k(ul blas.Uplo, t blas.Transpose, n int, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Ssyr2k(ul, t, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	impl.end(tr, "Ssyr2k", []Dim{{"n", n}, {"k", k}})
+}
+
+// Strmm traces a call to the Strmm method of the traced implementation.
+func (impl *TracingImplementation) Strmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha float32, a []float32, lda int, b []float32, ldb int) {
+	tr := impl.begin()
+	impl.Interface.Strmm(s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+	impl.end(tr, "Strmm", []Dim{{"m", m}, {"n", n}})
+}
+
+// Strsm traces a call to the Strsm method of the traced implementation.
+func (impl *TracingImplementation) Strsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha float32, a []float32, lda int, b []float32, ldb int) {
+	tr := impl.begin()
+	impl.Interface.Strsm(s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+	impl.end(tr, "Strsm", []Dim{{"m", m}, {"n", n}})
+}
+
+// Ddot traces a call to the Ddot method of the traced implementation.
+func (impl *TracingImplementation) Ddot(n int, x []float64, incX int, y []float64, incY int) float64 {
+	tr := impl.begin()
+	r0 := impl.Interface.Ddot(n, x, incX, y, incY)
+	impl.end(tr, "Ddot", []Dim{{"n", n}})
+	return r0
+}
+
+// Dnrm2 traces a call to the Dnrm2 method of the traced implementation.
+func (impl *TracingImplementation) Dnrm2(n int, x []float64, incX int) float64 {
+	tr := impl.begin()
+	r0 := impl.Interface.Dnrm2(n, x, incX)
+	impl.end(tr, "Dnrm2", []Dim{{"n", n}})
+	return r0
+}
+
+// Dasum traces a call to the Dasum method of the traced implementation.
+func (impl *TracingImplementation) Dasum(n int, x []float64, incX int) float64 {
+	tr := impl.begin()
+	r0 := impl.Interface.Dasum(n, x, incX)
+	impl.end(tr, "Dasum", []Dim{{"n", n}})
+	return r0
+}
+
+// Idamax traces a call to the Idamax method of the traced implementation.
+func (impl *TracingImplementation) Idamax(n int, x []float64, incX int) int {
+	tr := impl.begin()
+	r0 := impl.Interface.Idamax(n, x, incX)
+	impl.end(tr, "Idamax", []Dim{{"n", n}})
+	return r0
+}
+
+// Dswap traces a call to the Dswap method of the traced implementation.
+func (impl *TracingImplementation) Dswap(n int, x []float64, incX int, y []float64, incY int) {
+	tr := impl.begin()
+	impl.Interface.Dswap(n, x, incX, y, incY)
+	impl.end(tr, "Dswap", []Dim{{"n", n}})
+}
+
+// Dcopy traces a call to the Dcopy method of the traced implementation.
+func (impl *TracingImplementation) Dcopy(n int, x []float64, incX int, y []float64, incY int) {
+	tr := impl.begin()
+	impl.Interface.Dcopy(n, x, incX, y, incY)
+	impl.end(tr, "Dcopy", []Dim{{"n", n}})
+}
+
+// Daxpy traces a call to the Daxpy method of the traced implementation.
+func (impl *TracingImplementation) Daxpy(n int, alpha float64, x []float64, incX int, y []float64, incY int) {
+	tr := impl.begin()
+	impl.Interface.Daxpy(n, alpha, x, incX, y, incY)
+	impl.end(tr, "Daxpy", []Dim{{"n", n}})
+}
+
+// Drotg traces a call to the Drotg method of the traced implementation.
+func (impl *TracingImplementation) Drotg(a float64, b float64) (float64, float64, float64, float64) {
+	tr := impl.begin()
+	r0, r1, r2, r3 := impl.Interface.Drotg(a, b)
+	impl.end(tr, "Drotg", nil)
+	return r0, r1, r2, r3
+}
+
+// Drotmg traces a call to the Drotmg method of the traced implementation.
+func (impl *TracingImplementation) Drotmg(d1 float64, d2 float64, b1 float64, b2 float64) (blas.DrotmParams, float64, float64, float64) {
+	tr := impl.begin()
+	r0, r1, r2, r3 := impl.Interface.Drotmg(d1, d2, b1, b2)
+	impl.end(tr, "Drotmg", nil)
+	return r0, r1, r2, r3
+}
+
+// Drot traces a call to the Drot method of the traced implementation.
+func (impl *TracingImplementation) Drot(n int, x []float64, incX int, y []float64, incY int, c float64, s float64) {
+	tr := impl.begin()
+	impl.Interface.Drot(n, x, incX, y, incY, c, s)
+	impl.end(tr, "Drot", []Dim{{"n", n}})
+}
+
+// Drotm traces a call to the Drotm method of the traced implementation.
+func (impl *TracingImplementation) Drotm(n int, x []float64, incX int, y []float64, incY int, p blas.DrotmParams) {
+	tr := impl.begin()
+	impl.Interface.Drotm(n, x, incX, y, incY, p)
+	impl.end(tr, "Drotm", []Dim{{"n", n}})
+}
+
+// Dscal traces a call to the Dscal method of the traced implementation.
+func (impl *TracingImplementation) Dscal(n int, alpha float64, x []float64, incX int) {
+	tr := impl.begin()
+	impl.Interface.Dscal(n, alpha, x, incX)
+	impl.end(tr, "Dscal", []Dim{{"n", n}})
+}
+
+// Dgemv traces a call to the Dgemv method of the traced implementation.
+func (impl *TracingImplementation) Dgemv(tA blas.Transpose, m int, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	tr := impl.begin()
+	impl.Interface.Dgemv(tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+	impl.end(tr, "Dgemv", []Dim{{"m", m}, {"n", n}})
+}
+
+// Dgbmv traces a call to the Dgbmv method of the traced implementation.
+func (impl *TracingImplementation) Dgbmv(tA blas.Transpose, m int, n int, kL int, kU int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	tr := impl.begin()
+	impl.Interface.Dgbmv(tA, m, n, kL, kU, alpha, a, lda, x, incX, beta, y, incY)
+	impl.end(tr, "Dgbmv", []Dim{{"m", m}, {"n", n}, {"kL", kL}, {"kU", kU}})
+}
+
+// Dtrmv traces a call to the Dtrmv method of the traced implementation.
+func (impl *TracingImplementation) Dtrmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float64, lda int, x []float64, incX int) {
+	tr := impl.begin()
+	impl.Interface.Dtrmv(ul, tA, d, n, a, lda, x, incX)
+	impl.end(tr, "Dtrmv", []Dim{{"n", n}})
+}
+
+// Dtbmv traces a call to the Dtbmv method of the traced implementation.
+func (impl *TracingImplementation) Dtbmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []float64, lda int, x []float64, incX int) {
+	tr := impl.begin()
+	impl.Interface.Dtbmv(ul, tA, d, n, k, a, lda, x, incX)
+	impl.end(tr, "Dtbmv", []Dim{{"n", n}, {"k", k}})
+}
+
+// Dtpmv traces a call to the Dtpmv method of the traced implementation.
+func (impl *TracingImplementation) Dtpmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float64, x []float64, incX int) {
+	tr := impl.begin()
+	impl.Interface.Dtpmv(ul, tA, d, n, ap, x, incX)
+	impl.end(tr, "Dtpmv", []Dim{{"n", n}})
+}
+
+// Dtrsv traces a call to the Dtrsv method of the traced implementation.
+func (impl *TracingImplementation) Dtrsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float64, lda int, x []float64, incX int) {
+	tr := impl.begin()
+	impl.Interface.Dtrsv(ul, tA, d, n, a, lda, x, incX)
+	impl.end(tr, "Dtrsv", []Dim{{"n", n}})
+}
+
+// Dtbsv traces a call to the Dtbsv method of the traced implementation.
+func (impl *TracingImplementation) Dtbsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []float64, lda int, x []float64, incX int) {
+	tr := impl.begin()
+	impl.Interface.Dtbsv(ul, tA, d, n, k, a, lda, x, incX)
+	impl.end(tr, "Dtbsv", []Dim{{"n", n}, {"k", k}})
+}
+
+// Dtpsv traces a call to the Dtpsv method of the traced implementation.
+func (impl *TracingImplementation) Dtpsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float64, x []float64, incX int) {
+	tr := impl.begin()
+	impl.Interface.Dtpsv(ul, tA, d, n, ap, x, incX)
+	impl.end(tr, "Dtpsv", []Dim{{"n", n}})
+}
+
+// Dsymv traces a call to the Dsymv method of the traced implementation.
+func (impl *TracingImplementation) Dsymv(ul blas.Uplo, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	tr := impl.begin()
+	impl.Interface.Dsymv(ul, n, alpha, a, lda, x, incX, beta, y, incY)
+	impl.end(tr, "Dsymv", []Dim{{"n", n}})
+}
+
+// Dsbmv traces a call to the Dsbmv method of the traced implementation.
+func (impl *TracingImplementation) Dsbmv(ul blas.Uplo, n int, k int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	tr := impl.begin()
+	impl.Interface.Dsbmv(ul, n, k, alpha, a, lda, x, incX, beta, y, incY)
+	impl.end(tr, "Dsbmv", []Dim{{"n", n}, {"k", k}})
+}
+
+// Dspmv traces a call to the Dspmv method of the traced implementation.
+func (impl *TracingImplementation) Dspmv(ul blas.Uplo, n int, alpha float64, ap []float64, x []float64, incX int, beta float64, y []float64, incY int) {
+	tr := impl.begin()
+	impl.Interface.Dspmv(ul, n, alpha, ap, x, incX, beta, y, incY)
+	impl.end(tr, "Dspmv", []Dim{{"n", n}})
+}
+
+// Dger traces a call to the Dger method of the traced implementation.
+func (impl *TracingImplementation) Dger(m int, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) {
+	tr := impl.begin()
+	impl.Interface.Dger(m, n, alpha, x, incX, y, incY, a, lda)
+	impl.end(tr, "Dger", []Dim{{"m", m}, {"n", n}})
+}
+
+// Dsyr traces a call to the Dsyr method of the traced implementation.
+func (impl *TracingImplementation) Dsyr(ul blas.Uplo, n int, alpha float64, x []float64, incX int, a []float64, lda int) {
+	tr := impl.begin()
+	impl.Interface.Dsyr(ul, n, alpha, x, incX, a, lda)
+	impl.end(tr, "Dsyr", []Dim{{"n", n}})
+}
+
+// Dspr traces a call to the Dspr method of the traced implementation.
+func (impl *TracingImplementation) Dspr(ul blas.Uplo, n int, alpha float64, x []float64, incX int, ap []float64) {
+	tr := impl.begin()
+	impl.Interface.Dspr(ul, n, alpha, x, incX, ap)
+	impl.end(tr, "Dspr", []Dim{{"n", n}})
+}
+
+// Dsyr2 traces a call to the Dsyr2 method of the traced implementation.
+func (impl *TracingImplementation) Dsyr2(ul blas.Uplo, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) {
+	tr := impl.begin()
+	impl.Interface.Dsyr2(ul, n, alpha, x, incX, y, incY, a, lda)
+	impl.end(tr, "Dsyr2", []Dim{{"n", n}})
+}
+
+// Dspr2 traces a call to the Dspr2 method of the traced implementation.
+func (impl *TracingImplementation) Dspr2(ul blas.Uplo, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64) {
+	tr := impl.begin()
+	impl.Interface.Dspr2(ul, n, alpha, x, incX, y, incY, a)
+	impl.end(tr, "Dspr2", []Dim{{"n", n}})
+}
+
+// Dgemm traces a call to the Dgemm method of the traced implementation.
+func (impl *TracingImplementation) Dgemm(tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Dgemm(tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	impl.end(tr, "Dgemm", []Dim{{"m", m}, {"n", n}, {"k", k}})
+}
+
+// Dsymm traces a call to the Dsymm method of the traced implementation.
+func (impl *TracingImplementation) Dsymm(s blas.Side, ul blas.Uplo, m int, n int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Dsymm(s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+	impl.end(tr, "Dsymm", []Dim{{"m", m}, {"n", n}})
+}
+
+// Dsyrk traces a call to the Dsyrk method of the traced implementation.
+func (impl *TracingImplementation) Dsyrk(ul blas.Uplo, t blas.Transpose, n int, k int, alpha float64, a []float64, lda int, beta float64, c []float64, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Dsyrk(ul, t, n, k, alpha, a, lda, beta, c, ldc)
+	impl.end(tr, "Dsyrk", []Dim{{"n", n}, {"k", k}})
+}
+
+// Dsyr2k traces a call to the Dsyr2k method of the traced implementation.
+func (impl *TracingImplementation) Dsyr2k(ul blas.Uplo, t blas.Transpose, n int, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Dsyr2k(ul, t, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	impl.end(tr, "Dsyr2k", []Dim{{"n", n}, {"k", k}})
+}
+
+// Dtrmm traces a call to the Dtrmm method of the traced implementation.
+func (impl *TracingImplementation) Dtrmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha float64, a []float64, lda int, b []float64, ldb int) {
+	tr := impl.begin()
+	impl.Interface.Dtrmm(s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+	impl.end(tr, "Dtrmm", []Dim{{"m", m}, {"n", n}})
+}
+
+// Dtrsm traces a call to the Dtrsm method of the traced implementation.
+func (impl *TracingImplementation) Dtrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha float64, a []float64, lda int, b []float64, ldb int) {
+	tr := impl.begin()
+	impl.Interface.Dtrsm(s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+	impl.end(tr, "Dtrsm", []Dim{{"m", m}, {"n", n}})
+}
+
+// Cdotu traces a call to the Cdotu method of the traced implementation.
+func (impl *TracingImplementation) Cdotu(n int, x []complex64, incX int, y []complex64, incY int) complex64 {
+	tr := impl.begin()
+	r0 := impl.Interface.Cdotu(n, x, incX, y, incY)
+	impl.end(tr, "Cdotu", []Dim{{"n", n}})
+	return r0
+}
+
+// Cdotc traces a call to the Cdotc method of the traced implementation.
+func (impl *TracingImplementation) Cdotc(n int, x []complex64, incX int, y []complex64, incY int) complex64 {
+	tr := impl.begin()
+	r0 := impl.Interface.Cdotc(n, x, incX, y, incY)
+	impl.end(tr, "Cdotc", []Dim{{"n", n}})
+	return r0
+}
+
+// Scnrm2 traces a call to the Scnrm2 method of the traced implementation.
+func (impl *TracingImplementation) Scnrm2(n int, x []complex64, incX int) float32 {
+	tr := impl.begin()
+	r0 := impl.Interface.Scnrm2(n, x, incX)
+	impl.end(tr, "Scnrm2", []Dim{{"n", n}})
+	return r0
+}
+
+// Scasum traces a call to the Scasum method of the traced implementation.
+func (impl *TracingImplementation) Scasum(n int, x []complex64, incX int) float32 {
+	tr := impl.begin()
+	r0 := impl.Interface.Scasum(n, x, incX)
+	impl.end(tr, "Scasum", []Dim{{"n", n}})
+	return r0
+}
+
+// Icamax traces a call to the Icamax method of the traced implementation.
+func (impl *TracingImplementation) Icamax(n int, x []complex64, incX int) int {
+	tr := impl.begin()
+	r0 := impl.Interface.Icamax(n, x, incX)
+	impl.end(tr, "Icamax", []Dim{{"n", n}})
+	return r0
+}
+
+// Cswap traces a call to the Cswap method of the traced implementation.
+func (impl *TracingImplementation) Cswap(n int, x []complex64, incX int, y []complex64, incY int) {
+	tr := impl.begin()
+	impl.Interface.Cswap(n, x, incX, y, incY)
+	impl.end(tr, "Cswap", []Dim{{"n", n}})
+}
+
+// Ccopy traces a call to the Ccopy method of the traced implementation.
+func (impl *TracingImplementation) Ccopy(n int, x []complex64, incX int, y []complex64, incY int) {
+	tr := impl.begin()
+	impl.Interface.Ccopy(n, x, incX, y, incY)
+	impl.end(tr, "Ccopy", []Dim{{"n", n}})
+}
+
+// Caxpy traces a call to the Caxpy method of the traced implementation.
+func (impl *TracingImplementation) Caxpy(n int, alpha complex64, x []complex64, incX int, y []complex64, incY int) {
+	tr := impl.begin()
+	impl.Interface.Caxpy(n, alpha, x, incX, y, incY)
+	impl.end(tr, "Caxpy", []Dim{{"n", n}})
+}
+
+// Cscal traces a call to the Cscal method of the traced implementation.
+func (impl *TracingImplementation) Cscal(n int, alpha complex64, x []complex64, incX int) {
+	tr := impl.begin()
+	impl.Interface.Cscal(n, alpha, x, incX)
+	impl.end(tr, "Cscal", []Dim{{"n", n}})
+}
+
+// Csscal traces a call to the Csscal method of the traced implementation.
+func (impl *TracingImplementation) Csscal(n int, alpha float32, x []complex64, incX int) {
+	tr := impl.begin()
+	impl.Interface.Csscal(n, alpha, x, incX)
+	impl.end(tr, "Csscal", []Dim{{"n", n}})
+}
+
+// Cgemv traces a call to the Cgemv method of the traced implementation.
+func (impl *TracingImplementation) Cgemv(tA blas.Transpose, m int, n int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	tr := impl.begin()
+	impl.Interface.Cgemv(tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+	impl.end(tr, "Cgemv", []Dim{{"m", m}, {"n", n}})
+}
+
+// Cgbmv traces a call to the Cgbmv method of the traced implementation.
+func (impl *TracingImplementation) Cgbmv(tA blas.Transpose, m int, n int, kL int, kU int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	tr := impl.begin()
+	impl.Interface.Cgbmv(tA, m, n, kL, kU, alpha, a, lda, x, incX, beta, y, incY)
+	impl.end(tr, "Cgbmv", []Dim{{"m", m}, {"n", n}, {"kL", kL}, {"kU", kU}})
+}
+
+// Ctrmv traces a call to the Ctrmv method of the traced implementation.
+func (impl *TracingImplementation) Ctrmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex64, lda int, x []complex64, incX int) {
+	tr := impl.begin()
+	impl.Interface.Ctrmv(ul, tA, d, n, a, lda, x, incX)
+	impl.end(tr, "Ctrmv", []Dim{{"n", n}})
+}
+
+// Ctbmv traces a call to the Ctbmv method of the traced implementation.
+func (impl *TracingImplementation) Ctbmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []complex64, lda int, x []complex64, incX int) {
+	tr := impl.begin()
+	impl.Interface.Ctbmv(ul, tA, d, n, k, a, lda, x, incX)
+	impl.end(tr, "Ctbmv", []Dim{{"n", n}, {"k", k}})
+}
+
+// Ctpmv traces a call to the Ctpmv method of the traced implementation.
+func (impl *TracingImplementation) Ctpmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []complex64, x []complex64, incX int) {
+	tr := impl.begin()
+	impl.Interface.Ctpmv(ul, tA, d, n, ap, x, incX)
+	impl.end(tr, "Ctpmv", []Dim{{"n", n}})
+}
+
+// Ctrsv traces a call to the Ctrsv method of the traced implementation.
+func (impl *TracingImplementation) Ctrsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex64, lda int, x []complex64, incX int) {
+	tr := impl.begin()
+	impl.Interface.Ctrsv(ul, tA, d, n, a, lda, x, incX)
+	impl.end(tr, "Ctrsv", []Dim{{"n", n}})
+}
+
+// Ctbsv traces a call to the Ctbsv method of the traced implementation.
+func (impl *TracingImplementation) Ctbsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []complex64, lda int, x []complex64, incX int) {
+	tr := impl.begin()
+	impl.Interface.Ctbsv(ul, tA, d, n, k, a, lda, x, incX)
+	impl.end(tr, "Ctbsv", []Dim{{"n", n}, {"k", k}})
+}
+
+// Ctpsv traces a call to the Ctpsv method of the traced implementation.
+func (impl *TracingImplementation) Ctpsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []complex64, x []complex64, incX int) {
+	tr := impl.begin()
+	impl.Interface.Ctpsv(ul, tA, d, n, ap, x, incX)
+	impl.end(tr, "Ctpsv", []Dim{{"n", n}})
+}
+
+// Chemv traces a call to the Chemv method of the traced implementation.
+func (impl *TracingImplementation) Chemv(ul blas.Uplo, n int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	tr := impl.begin()
+	impl.Interface.Chemv(ul, n, alpha, a, lda, x, incX, beta, y, incY)
+	impl.end(tr, "Chemv", []Dim{{"n", n}})
+}
+
+// Chbmv traces a call to the Chbmv method of the traced implementation.
+func (impl *TracingImplementation) Chbmv(ul blas.Uplo, n int, k int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	tr := impl.begin()
+	impl.Interface.Chbmv(ul, n, k, alpha, a, lda, x, incX, beta, y, incY)
+	impl.end(tr, "Chbmv", []Dim{{"n", n}, {"k", k}})
+}
+
+// Chpmv traces a call to the Chpmv method of the traced implementation.
+func (impl *TracingImplementation) Chpmv(ul blas.Uplo, n int, alpha complex64, ap []complex64, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	tr := impl.begin()
+	impl.Interface.Chpmv(ul, n, alpha, ap, x, incX, beta, y, incY)
+	impl.end(tr, "Chpmv", []Dim{{"n", n}})
+}
+
+// Cgeru traces a call to the Cgeru method of the traced implementation.
+func (impl *TracingImplementation) Cgeru(m int, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) {
+	tr := impl.begin()
+	impl.Interface.Cgeru(m, n, alpha, x, incX, y, incY, a, lda)
+	impl.end(tr, "Cgeru", []Dim{{"m", m}, {"n", n}})
+}
+
+// Cgerc traces a call to the Cgerc method of the traced implementation.
+func (impl *TracingImplementation) Cgerc(m int, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) {
+	tr := impl.begin()
+	impl.Interface.Cgerc(m, n, alpha, x, incX, y, incY, a, lda)
+	impl.end(tr, "Cgerc", []Dim{{"m", m}, {"n", n}})
+}
+
+// Cher traces a call to the Cher method of the traced implementation.
+func (impl *TracingImplementation) Cher(ul blas.Uplo, n int, alpha float32, x []complex64, incX int, a []complex64, lda int) {
+	tr := impl.begin()
+	impl.Interface.Cher(ul, n, alpha, x, incX, a, lda)
+	impl.end(tr, "Cher", []Dim{{"n", n}})
+}
+
+// Chpr traces a call to the Chpr method of the traced implementation.
+func (impl *TracingImplementation) Chpr(ul blas.Uplo, n int, alpha float32, x []complex64, incX int, a []complex64) {
+	tr := impl.begin()
+	impl.Interface.Chpr(ul, n, alpha, x, incX, a)
+	impl.end(tr, "Chpr", []Dim{{"n", n}})
+}
+
+// Cher2 traces a call to the Cher2 method of the traced implementation.
+func (impl *TracingImplementation) Cher2(ul blas.Uplo, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) {
+	tr := impl.begin()
+	impl.Interface.Cher2(ul, n, alpha, x, incX, y, incY, a, lda)
+	impl.end(tr, "Cher2", []Dim{{"n", n}})
+}
+
+// Chpr2 traces a call to the Chpr2 method of the traced implementation.
+func (impl *TracingImplementation) Chpr2(ul blas.Uplo, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, ap []complex64) {
+	tr := impl.begin()
+	impl.Interface.Chpr2(ul, n, alpha, x, incX, y, incY, ap)
+	impl.end(tr, "Chpr2", []Dim{{"n", n}})
+}
+
+// Cgemm traces a call to the Cgemm method of the traced implementation.
+func (impl *TracingImplementation) Cgemm(tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Cgemm(tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	impl.end(tr, "Cgemm", []Dim{{"m", m}, {"n", n}, {"k", k}})
+}
+
+// Csymm traces a call to the Csymm method of the traced implementation.
+func (impl *TracingImplementation) Csymm(s blas.Side, ul blas.Uplo, m int, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Csymm(s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+	impl.end(tr, "Csymm", []Dim{{"m", m}, {"n", n}})
+}
+
+// Csyrk traces a call to the Csyrk method of the traced implementation.
+func (impl *TracingImplementation) Csyrk(ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex64, a []complex64, lda int, beta complex64, c []complex64, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Csyrk(ul, t, n, k, alpha, a, lda, beta, c, ldc)
+	impl.end(tr, "Csyrk", []Dim{{"n", n}, {"k", k}})
+}
+
+// Csyr2k traces a call to the Csyr2k method of the traced implementation.
+func (impl *TracingImplementation) Csyr2k(ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Csyr2k(ul, t, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	impl.end(tr, "Csyr2k", []Dim{{"n", n}, {"k", k}})
+}
+
+// Ctrmm traces a call to the Ctrmm method of the traced implementation.
+func (impl *TracingImplementation) Ctrmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int) {
+	tr := impl.begin()
+	impl.Interface.Ctrmm(s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+	impl.end(tr, "Ctrmm", []Dim{{"m", m}, {"n", n}})
+}
+
+// Ctrsm traces a call to the Ctrsm method of the traced implementation.
+func (impl *TracingImplementation) Ctrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int) {
+	tr := impl.begin()
+	impl.Interface.Ctrsm(s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+	impl.end(tr, "Ctrsm", []Dim{{"m", m}, {"n", n}})
+}
+
+// Chemm traces a call to the Chemm method of the traced implementation.
+func (impl *TracingImplementation) Chemm(s blas.Side, ul blas.Uplo, m int, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Chemm(s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+	impl.end(tr, "Chemm", []Dim{{"m", m}, {"n", n}})
+}
+
+// Cherk traces a call to the Cherk method of the traced implementation.
+func (impl *TracingImplementation) Cherk(ul blas.Uplo, t blas.Transpose, n int, k int, alpha float32, a []complex64, lda int, beta float32, c []complex64, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Cherk(ul, t, n, k, alpha, a, lda, beta, c, ldc)
+	impl.end(tr, "Cherk", []Dim{{"n", n}, {"k", k}})
+}
+
+// Cher2k traces a call to the Cher2k method of the traced implementation.
+func (impl *TracingImplementation) Cher2k(ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta float32, c []complex64, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Cher2k(ul, t, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	impl.end(tr, "Cher2k", []Dim{{"n", n}, {"k", k}})
+}
+
+// Zdotu traces a call to the Zdotu method of the traced implementation.
+func (impl *TracingImplementation) Zdotu(n int, x []complex128, incX int, y []complex128, incY int) complex128 {
+	tr := impl.begin()
+	r0 := impl.Interface.Zdotu(n, x, incX, y, incY)
+	impl.end(tr, "Zdotu", []Dim{{"n", n}})
+	return r0
+}
+
+// Zdotc traces a call to the Zdotc method of the traced implementation.
+func (impl *TracingImplementation) Zdotc(n int, x []complex128, incX int, y []complex128, incY int) complex128 {
+	tr := impl.begin()
+	r0 := impl.Interface.Zdotc(n, x, incX, y, incY)
+	impl.end(tr, "Zdotc", []Dim{{"n", n}})
+	return r0
+}
+
+// Dznrm2 traces a call to the Dznrm2 method of the traced implementation.
+func (impl *TracingImplementation) Dznrm2(n int, x []complex128, incX int) float64 {
+	tr := impl.begin()
+	r0 := impl.Interface.Dznrm2(n, x, incX)
+	impl.end(tr, "Dznrm2", []Dim{{"n", n}})
+	return r0
+}
+
+// Dzasum traces a call to the Dzasum method of the traced implementation.
+func (impl *TracingImplementation) Dzasum(n int, x []complex128, incX int) float64 {
+	tr := impl.begin()
+	r0 := impl.Interface.Dzasum(n, x, incX)
+	impl.end(tr, "Dzasum", []Dim{{"n", n}})
+	return r0
+}
+
+// Izamax traces a call to the Izamax method of the traced implementation.
+func (impl *TracingImplementation) Izamax(n int, x []complex128, incX int) int {
+	tr := impl.begin()
+	r0 := impl.Interface.Izamax(n, x, incX)
+	impl.end(tr, "Izamax", []Dim{{"n", n}})
+	return r0
+}
+
+// Zswap traces a call to the Zswap method of the traced implementation.
+func (impl *TracingImplementation) Zswap(n int, x []complex128, incX int, y []complex128, incY int) {
+	tr := impl.begin()
+	impl.Interface.Zswap(n, x, incX, y, incY)
+	impl.end(tr, "Zswap", []Dim{{"n", n}})
+}
+
+// Zcopy traces a call to the Zcopy method of the traced implementation.
+func (impl *TracingImplementation) Zcopy(n int, x []complex128, incX int, y []complex128, incY int) {
+	tr := impl.begin()
+	impl.Interface.Zcopy(n, x, incX, y, incY)
+	impl.end(tr, "Zcopy", []Dim{{"n", n}})
+}
+
+// Zaxpy traces a call to the Zaxpy method of the traced implementation.
+func (impl *TracingImplementation) Zaxpy(n int, alpha complex128, x []complex128, incX int, y []complex128, incY int) {
+	tr := impl.begin()
+	impl.Interface.Zaxpy(n, alpha, x, incX, y, incY)
+	impl.end(tr, "Zaxpy", []Dim{{"n", n}})
+}
+
+// Zscal traces a call to the Zscal method of the traced implementation.
+func (impl *TracingImplementation) Zscal(n int, alpha complex128, x []complex128, incX int) {
+	tr := impl.begin()
+	impl.Interface.Zscal(n, alpha, x, incX)
+	impl.end(tr, "Zscal", []Dim{{"n", n}})
+}
+
+// Zdscal traces a call to the Zdscal method of the traced implementation.
+func (impl *TracingImplementation) Zdscal(n int, alpha float64, x []complex128, incX int) {
+	tr := impl.begin()
+	impl.Interface.Zdscal(n, alpha, x, incX)
+	impl.end(tr, "Zdscal", []Dim{{"n", n}})
+}
+
+// Zgemv traces a call to the Zgemv method of the traced implementation.
+func (impl *TracingImplementation) Zgemv(tA blas.Transpose, m int, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	tr := impl.begin()
+	impl.Interface.Zgemv(tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+	impl.end(tr, "Zgemv", []Dim{{"m", m}, {"n", n}})
+}
+
+// Zgbmv traces a call to the Zgbmv method of the traced implementation.
+func (impl *TracingImplementation) Zgbmv(tA blas.Transpose, m int, n int, kL int, kU int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	tr := impl.begin()
+	impl.Interface.Zgbmv(tA, m, n, kL, kU, alpha, a, lda, x, incX, beta, y, incY)
+	impl.end(tr, "Zgbmv", []Dim{{"m", m}, {"n", n}, {"kL", kL}, {"kU", kU}})
+}
+
+// Ztrmv traces a call to the Ztrmv method of the traced implementation.
+func (impl *TracingImplementation) Ztrmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex128, lda int, x []complex128, incX int) {
+	tr := impl.begin()
+	impl.Interface.Ztrmv(ul, tA, d, n, a, lda, x, incX)
+	impl.end(tr, "Ztrmv", []Dim{{"n", n}})
+}
+
+// Ztbmv traces a call to the Ztbmv method of the traced implementation.
+func (impl *TracingImplementation) Ztbmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []complex128, lda int, x []complex128, incX int) {
+	tr := impl.begin()
+	impl.Interface.Ztbmv(ul, tA, d, n, k, a, lda, x, incX)
+	impl.end(tr, "Ztbmv", []Dim{{"n", n}, {"k", k}})
+}
+
+// Ztpmv traces a call to the Ztpmv method of the traced implementation.
+func (impl *TracingImplementation) Ztpmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []complex128, x []complex128, incX int) {
+	tr := impl.begin()
+	impl.Interface.Ztpmv(ul, tA, d, n, ap, x, incX)
+	impl.end(tr, "Ztpmv", []Dim{{"n", n}})
+}
+
+// Ztrsv traces a call to the Ztrsv method of the traced implementation.
+func (impl *TracingImplementation) Ztrsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex128, lda int, x []complex128, incX int) {
+	tr := impl.begin()
+	impl.Interface.Ztrsv(ul, tA, d, n, a, lda, x, incX)
+	impl.end(tr, "Ztrsv", []Dim{{"n", n}})
+}
+
+// Ztbsv traces a call to the Ztbsv method of the traced implementation.
+func (impl *TracingImplementation) Ztbsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []complex128, lda int, x []complex128, incX int) {
+	tr := impl.begin()
+	impl.Interface.Ztbsv(ul, tA, d, n, k, a, lda, x, incX)
+	impl.end(tr, "Ztbsv", []Dim{{"n", n}, {"k", k}})
+}
+
+// Ztpsv traces a call to the Ztpsv method of the traced implementation.
+func (impl *TracingImplementation) Ztpsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []complex128, x []complex128, incX int) {
+	tr := impl.begin()
+	impl.Interface.Ztpsv(ul, tA, d, n, ap, x, incX)
+	impl.end(tr, "Ztpsv", []Dim{{"n", n}})
+}
+
+// Zhemv traces a call to the Zhemv method of the traced implementation.
+func (impl *TracingImplementation) Zhemv(ul blas.Uplo, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	tr := impl.begin()
+	impl.Interface.Zhemv(ul, n, alpha, a, lda, x, incX, beta, y, incY)
+	impl.end(tr, "Zhemv", []Dim{{"n", n}})
+}
+
+// Zhbmv traces a call to the Zhbmv method of the traced implementation.
+func (impl *TracingImplementation) Zhbmv(ul blas.Uplo, n int, k int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	tr := impl.begin()
+	impl.Interface.Zhbmv(ul, n, k, alpha, a, lda, x, incX, beta, y, incY)
+	impl.end(tr, "Zhbmv", []Dim{{"n", n}, {"k", k}})
+}
+
+// Zhpmv traces a call to the Zhpmv method of the traced implementation.
+func (impl *TracingImplementation) Zhpmv(ul blas.Uplo, n int, alpha complex128, ap []complex128, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	tr := impl.begin()
+	impl.Interface.Zhpmv(ul, n, alpha, ap, x, incX, beta, y, incY)
+	impl.end(tr, "Zhpmv", []Dim{{"n", n}})
+}
+
+// Zgeru traces a call to the Zgeru method of the traced implementation.
+func (impl *TracingImplementation) Zgeru(m int, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
+	tr := impl.begin()
+	impl.Interface.Zgeru(m, n, alpha, x, incX, y, incY, a, lda)
+	impl.end(tr, "Zgeru", []Dim{{"m", m}, {"n", n}})
+}
+
+// Zgerc traces a call to the Zgerc method of the traced implementation.
+func (impl *TracingImplementation) Zgerc(m int, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
+	tr := impl.begin()
+	impl.Interface.Zgerc(m, n, alpha, x, incX, y, incY, a, lda)
+	impl.end(tr, "Zgerc", []Dim{{"m", m}, {"n", n}})
+}
+
+// Zher traces a call to the Zher method of the traced implementation.
+func (impl *TracingImplementation) Zher(ul blas.Uplo, n int, alpha float64, x []complex128, incX int, a []complex128, lda int) {
+	tr := impl.begin()
+	impl.Interface.Zher(ul, n, alpha, x, incX, a, lda)
+	impl.end(tr, "Zher", []Dim{{"n", n}})
+}
+
+// Zhpr traces a call to the Zhpr method of the traced implementation.
+func (impl *TracingImplementation) Zhpr(ul blas.Uplo, n int, alpha float64, x []complex128, incX int, a []complex128) {
+	tr := impl.begin()
+	impl.Interface.Zhpr(ul, n, alpha, x, incX, a)
+	impl.end(tr, "Zhpr", []Dim{{"n", n}})
+}
+
+// Zher2 traces a call to the Zher2 method of the traced implementation.
+func (impl *TracingImplementation) Zher2(ul blas.Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
+	tr := impl.begin()
+	impl.Interface.Zher2(ul, n, alpha, x, incX, y, incY, a, lda)
+	impl.end(tr, "Zher2", []Dim{{"n", n}})
+}
+
+// Zhpr2 traces a call to the Zhpr2 method of the traced implementation.
+func (impl *TracingImplementation) Zhpr2(ul blas.Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, ap []complex128) {
+	tr := impl.begin()
+	impl.Interface.Zhpr2(ul, n, alpha, x, incX, y, incY, ap)
+	impl.end(tr, "Zhpr2", []Dim{{"n", n}})
+}
+
+// Zgemm traces a call to the Zgemm method of the traced implementation.
+func (impl *TracingImplementation) Zgemm(tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Zgemm(tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	impl.end(tr, "Zgemm", []Dim{{"m", m}, {"n", n}, {"k", k}})
+}
+
+// Zsymm traces a call to the Zsymm method of the traced implementation.
+func (impl *TracingImplementation) Zsymm(s blas.Side, ul blas.Uplo, m int, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Zsymm(s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+	impl.end(tr, "Zsymm", []Dim{{"m", m}, {"n", n}})
+}
+
+// Zsyrk traces a call to the Zsyrk method of the traced implementation.
+func (impl *TracingImplementation) Zsyrk(ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex128, a []complex128, lda int, beta complex128, c []complex128, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Zsyrk(ul, t, n, k, alpha, a, lda, beta, c, ldc)
+	impl.end(tr, "Zsyrk", []Dim{{"n", n}, {"k", k}})
+}
+
+// Zsyr2k traces a call to the Zsyr2k method of the traced implementation.
+func (impl *TracingImplementation) Zsyr2k(ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Zsyr2k(ul, t, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	impl.end(tr, "Zsyr2k", []Dim{{"n", n}, {"k", k}})
+}
+
+// Ztrmm traces a call to the Ztrmm method of the traced implementation.
+func (impl *TracingImplementation) Ztrmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) {
+	tr := impl.begin()
+	impl.Interface.Ztrmm(s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+	impl.end(tr, "Ztrmm", []Dim{{"m", m}, {"n", n}})
+}
+
+// Ztrsm traces a call to the Ztrsm method of the traced implementation.
+func (impl *TracingImplementation) Ztrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) {
+	tr := impl.begin()
+	impl.Interface.Ztrsm(s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+	impl.end(tr, "Ztrsm", []Dim{{"m", m}, {"n", n}})
+}
+
+// Zhemm traces a call to the Zhemm method of the traced implementation.
+func (impl *TracingImplementation) Zhemm(s blas.Side, ul blas.Uplo, m int, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Zhemm(s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+	impl.end(tr, "Zhemm", []Dim{{"m", m}, {"n", n}})
+}
+
+// Zherk traces a call to the Zherk method of the traced implementation.
+func (impl *TracingImplementation) Zherk(ul blas.Uplo, t blas.Transpose, n int, k int, alpha float64, a []complex128, lda int, beta float64, c []complex128, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Zherk(ul, t, n, k, alpha, a, lda, beta, c, ldc)
+	impl.end(tr, "Zherk", []Dim{{"n", n}, {"k", k}})
+}
+
+// Zher2k traces a call to the Zher2k method of the traced implementation.
+func (impl *TracingImplementation) Zher2k(ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta float64, c []complex128, ldc int) {
+	tr := impl.begin()
+	impl.Interface.Zher2k(ul, t, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	impl.end(tr, "Zher2k", []Dim{{"n", n}, {"k", k}})
+}
@@ -0,0 +1,41 @@
+package cublas
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gorgonia.org/cu/blas/fallback"
+)
+
+func TestTracingImplementation(t *testing.T) {
+	var buf bytes.Buffer
+	impl := NewTracing(fallback.New(), &buf)
+
+	a := []float64{1, 2, 3, 4, 5, 6}
+	b := []float64{1, 2, 3, 4, 5, 6}
+	c := make([]float64, 4)
+	impl.Dgemm(blas.NoTrans, blas.NoTrans, 2, 2, 3, 1, a, 3, b, 2, 0, c, 2)
+	impl.Dgemm(blas.NoTrans, blas.NoTrans, 2, 2, 3, 1, a, 3, b, 2, 0, c, 2)
+	if c[0] != 22 {
+		t.Errorf("Expected the call to be forwarded. Got %v", c)
+	}
+	if d := impl.Ddot(3, a, 1, b, 1); d != 14 {
+		t.Errorf("Expected Ddot to return 14. Got %v", d)
+	}
+
+	if !strings.HasPrefix(buf.String(), "Dgemm(m=2, n=2, k=3) ") {
+		t.Errorf("Unexpected trace %q", buf.String())
+	}
+
+	summary := impl.Summary()
+	if len(summary) != 2 {
+		t.Fatalf("Expected 2 entries in the summary. Got %v", summary)
+	}
+	for _, s := range summary {
+		if s.Method == "Dgemm" && s.Calls != 2 {
+			t.Errorf("Expected 2 calls of Dgemm. Got %d", s.Calls)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package cublas
+
+// #include <cublas_v2.h>
+import "C"
+import (
+	"fmt"
+
+	"gorgonia.org/cu"
+)
+
+func init() { cu.RegisterLibrary("cublas", Version) }
+
+// Version returns the version of the cuBLAS library, as major.minor.patch.
+func Version() (string, error) {
+	var v [3]C.int
+	for i, prop := range []C.libraryPropertyType{C.MAJOR_VERSION, C.MINOR_VERSION, C.PATCH_LEVEL} {
+		if err := status(C.cublasGetProperty(prop, &v[i])); err != nil {
+			return "", err
+		}
+	}
+	return fmt.Sprintf("%d.%d.%d", v[0], v[1], v[2]), nil
+}
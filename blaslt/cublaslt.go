@@ -0,0 +1,128 @@
+// Package cublaslt provides bindings to cuBLASLt, the lightweight matrix multiplication API of cuBLAS.
+//
+// Unlike the cublas package, cuBLASLt describes every operand with a MatrixLayout, and the multiplication itself with a
+// MatmulDesc. This lets it pick kernels using tensor cores for mixed precision, and fuse an epilogue - a bias and an
+// activation - into the multiplication:
+//
+//	desc, _ := NewMatmulDesc(Compute32F, cu.DtFloat32)
+//	desc.SetEpilogue(EpilogueReLUBias)
+//	desc.SetBias(bias)
+//	a, _ := NewMatrixLayout(cu.DtFloat32, m, k, m)
+//	...
+//	h.Matmul(desc, 1, Operand{A, a}, Operand{B, b}, 0, Operand{C, c}, Operand{C, c}, nil, 0, 0, stream)
+//
+// Matrices are in column major order unless their layout says otherwise. The status codes are those of cuBLAS, so the
+// errors returned can be compared to the ones of the cublas package.
+package cublaslt
+
+// #include <cublasLt.h>
+import "C"
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+	cublas "gorgonia.org/cu/blas"
+)
+
+func result(x C.cublasStatus_t) error {
+	if x == C.CUBLAS_STATUS_SUCCESS {
+		return nil
+	}
+	return cublas.Status(x)
+}
+
+// Version returns the version of cuBLASLt.
+func Version() int { return int(C.cublasLtGetVersion()) }
+
+// LtHandle is a handle to the cuBLASLt library. It is bound to the context that was current when it was created, and
+// is safe for concurrent use.
+type LtHandle struct {
+	h C.cublasLtHandle_t
+}
+
+// NewLtHandle creates a handle.
+func NewLtHandle() (*LtHandle, error) {
+	var h C.cublasLtHandle_t
+	if err := result(C.cublasLtCreate(&h)); err != nil {
+		return nil, errors.Wrap(err, "Unable to create a cuBLASLt handle")
+	}
+	return &LtHandle{h: h}, nil
+}
+
+// Close destroys the handle.
+func (h *LtHandle) Close() error {
+	if h.h == nil {
+		return nil
+	}
+	err := result(C.cublasLtDestroy(h.h))
+	h.h = nil
+	return err
+}
+
+// Operand is a matrix in device memory, with its layout.
+type Operand struct {
+	Ptr    cu.DevicePtr
+	Layout *MatrixLayout
+}
+
+// Algo is an algorithm for a multiplication, as returned by Heuristics.
+type Algo struct {
+	algo          C.cublasLtMatmulAlgo_t
+	WorkspaceSize int64   // bytes of workspace the algorithm needs
+	Waves         float32 // ratio of the blocks launched to the capacity of the device. Close to (but not over) a whole number is best
+}
+
+// Heuristics returns up to n algorithms for the multiplication, best first, with the workspace allowed by the preference.
+// A nil preference allows no workspace.
+func (h *LtHandle) Heuristics(desc *MatmulDesc, a, b, c, d *MatrixLayout, pref *Preference, n int) ([]Algo, error) {
+	if n <= 0 {
+		return nil, errors.Errorf("Cannot request %d algorithms", n)
+	}
+	if pref == nil {
+		var err error
+		if pref, err = NewPreference(0); err != nil {
+			return nil, err
+		}
+		defer pref.Close()
+	}
+	results := make([]C.cublasLtMatmulHeuristicResult_t, n)
+	var found C.int
+	if err := result(C.cublasLtMatmulAlgoGetHeuristic(h.h, desc.d, a.l, b.l, c.l, d.l, pref.p, C.int(n), &results[0], &found)); err != nil {
+		return nil, errors.Wrap(err, "Unable to get the algorithms of the multiplication")
+	}
+	algos := make([]Algo, 0, int(found))
+	for _, r := range results[:int(found)] {
+		if r.state != C.CUBLAS_STATUS_SUCCESS {
+			continue
+		}
+		algos = append(algos, Algo{algo: r.algo, WorkspaceSize: int64(r.workspaceSize), Waves: float32(r.wavesCount)})
+	}
+	return algos, nil
+}
+
+// Matmul computes d = alpha * op(a) op(b) + beta * c, followed by the epilogue of the descriptor. op is the transposition
+// set on the descriptor. c and d may be the same matrix, in which case their layouts must be identical.
+//
+// alpha and beta are converted to the scale type of the descriptor. If algo is nil, cuBLASLt chooses the algorithm
+// itself. The multiplication is asynchronous, on the stream.
+func (h *LtHandle) Matmul(desc *MatmulDesc, alpha float64, a, b Operand, beta float64, c, d Operand, algo *Algo, workspace cu.DevicePtr, workspaceSize int64, stream cu.Stream) error {
+	var alphaBuf, betaBuf [8]byte
+	if err := desc.scale(alpha, unsafe.Pointer(&alphaBuf[0])); err != nil {
+		return err
+	}
+	if err := desc.scale(beta, unsafe.Pointer(&betaBuf[0])); err != nil {
+		return err
+	}
+	var calgo *C.cublasLtMatmulAlgo_t
+	if algo != nil {
+		calgo = &algo.algo
+	}
+	err := result(C.cublasLtMatmul(h.h, desc.d,
+		unsafe.Pointer(&alphaBuf[0]), a.Ptr.Pointer(), a.Layout.l,
+		b.Ptr.Pointer(), b.Layout.l,
+		unsafe.Pointer(&betaBuf[0]), c.Ptr.Pointer(), c.Layout.l,
+		d.Ptr.Pointer(), d.Layout.l,
+		calgo, workspace.Pointer(), C.size_t(workspaceSize), C.cudaStream_t(stream.Pointer())))
+	return errors.Wrap(err, "cublasLtMatmul")
+}
@@ -0,0 +1,80 @@
+package cublaslt
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"gorgonia.org/cu"
+)
+
+func TestMatmul_ReLUBias(t *testing.T) {
+	if devices, _ := cu.NumDevices(); devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := cu.Device(0).MakeContext(cu.SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+
+	h, err := NewLtHandle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	// column major: A = [1 2; 3 4], B = identity, bias = [-2, 1]
+	// D = max(A B + bias, 0) = [0 0; 4 5]
+	a := []float32{1, 3, 2, 4}
+	b := []float32{1, 0, 0, 1}
+	bias := []float32{-2, 1}
+	want := []float32{0, 4, 0, 5}
+
+	upload := func(data []float32) cu.DevicePtr {
+		mem, err := cu.MemAlloc(int64(len(data) * 4))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = cu.MemcpyHtoD(mem, unsafe.Pointer(&data[0]), int64(len(data)*4)); err != nil {
+			t.Fatal(err)
+		}
+		return mem
+	}
+	A, B, Bias, D := upload(a), upload(b), upload(bias), upload(make([]float32, 4))
+	for _, mem := range []cu.DevicePtr{A, B, Bias, D} {
+		defer cu.MemFree(mem)
+	}
+
+	desc, err := NewMatmulDesc(Compute32F, cu.DtFloat32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer desc.Close()
+	if err = desc.SetEpilogue(EpilogueReLUBias); err != nil {
+		t.Fatal(err)
+	}
+	if err = desc.SetBias(Bias); err != nil {
+		t.Fatal(err)
+	}
+	layout, err := NewMatrixLayout(cu.DtFloat32, 2, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer layout.Close()
+
+	if err = h.Matmul(desc, 1, Operand{A, layout}, Operand{B, layout}, 0, Operand{D, layout}, Operand{D, layout}, nil, 0, 0, cu.Stream{}); err != nil {
+		t.Fatal(err)
+	}
+	got := make([]float32, 4)
+	if err = cu.MemcpyDtoH(unsafe.Pointer(&got[0]), D, 16); err != nil {
+		t.Fatal(err)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected %v. Got %v", want, got)
+		}
+	}
+}
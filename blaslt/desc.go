@@ -0,0 +1,224 @@
+package cublaslt
+
+// #include <cublasLt.h>
+import "C"
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// ComputeType is the precision the products are accumulated in. The fast types let cuBLASLt use tensor cores on
+// float32 data, by rounding the inputs to a narrower type.
+type ComputeType int
+
+const (
+	Compute16F         ComputeType = C.CUBLAS_COMPUTE_16F
+	Compute32F         ComputeType = C.CUBLAS_COMPUTE_32F
+	Compute32FFast16F  ComputeType = C.CUBLAS_COMPUTE_32F_FAST_16F
+	Compute32FFast16BF ComputeType = C.CUBLAS_COMPUTE_32F_FAST_16BF
+	Compute32FFastTF32 ComputeType = C.CUBLAS_COMPUTE_32F_FAST_TF32
+	Compute64F         ComputeType = C.CUBLAS_COMPUTE_64F
+	Compute32I         ComputeType = C.CUBLAS_COMPUTE_32I
+)
+
+// Epilogue is an operation fused after the multiplication.
+type Epilogue int
+
+const (
+	EpilogueDefault  Epilogue = C.CUBLASLT_EPILOGUE_DEFAULT   // no epilogue
+	EpilogueReLU     Epilogue = C.CUBLASLT_EPILOGUE_RELU      // max(d, 0)
+	EpilogueBias     Epilogue = C.CUBLASLT_EPILOGUE_BIAS      // d + bias, broadcast over the columns
+	EpilogueReLUBias Epilogue = C.CUBLASLT_EPILOGUE_RELU_BIAS // max(d + bias, 0)
+	EpilogueGELU     Epilogue = C.CUBLASLT_EPILOGUE_GELU
+	EpilogueGELUBias Epilogue = C.CUBLASLT_EPILOGUE_GELU_BIAS
+)
+
+// Order is the order of the elements of a matrix.
+type Order int
+
+const (
+	ColMajor Order = C.CUBLASLT_ORDER_COL
+	RowMajor Order = C.CUBLASLT_ORDER_ROW
+)
+
+func dataType(dt cu.Dtype) (C.cudaDataType, error) {
+	switch dt {
+	case cu.DtFloat32:
+		return C.CUDA_R_32F, nil
+	case cu.DtFloat64:
+		return C.CUDA_R_64F, nil
+	case cu.DtFloat16:
+		return C.CUDA_R_16F, nil
+	case cu.DtBFloat16:
+		return C.CUDA_R_16BF, nil
+	case cu.DtInt32:
+		return C.CUDA_R_32I, nil
+	case cu.DtInt8:
+		return C.CUDA_R_8I, nil
+	case cu.DtUint8:
+		return C.CUDA_R_8U, nil
+	case cu.DtComplex64:
+		return C.CUDA_C_32F, nil
+	case cu.DtComplex128:
+		return C.CUDA_C_64F, nil
+	}
+	return 0, errors.Errorf("cuBLASLt does not support %v", dt)
+}
+
+// MatmulDesc describes a multiplication: the precision of the computation, the transposition of the inputs, and the
+// epilogue.
+type MatmulDesc struct {
+	d         C.cublasLtMatmulDesc_t
+	scaleType cu.Dtype
+}
+
+// NewMatmulDesc creates the descriptor of a multiplication computed with the given precision. scaleType is the type of
+// alpha and beta: float32 for Compute32F and the fast types, float64 for Compute64F, int32 or float32 for Compute32I...
+func NewMatmulDesc(compute ComputeType, scaleType cu.Dtype) (*MatmulDesc, error) {
+	st, err := dataType(scaleType)
+	if err != nil {
+		return nil, err
+	}
+	var d C.cublasLtMatmulDesc_t
+	if err = result(C.cublasLtMatmulDescCreate(&d, C.cublasComputeType_t(compute), st)); err != nil {
+		return nil, errors.Wrap(err, "Unable to create a MatmulDesc")
+	}
+	return &MatmulDesc{d: d, scaleType: scaleType}, nil
+}
+
+func (desc *MatmulDesc) set(attr C.cublasLtMatmulDescAttributes_t, buf unsafe.Pointer, size uintptr) error {
+	return result(C.cublasLtMatmulDescSetAttribute(desc.d, attr, buf, C.size_t(size)))
+}
+
+// SetTranspose sets whether the first and second inputs are transposed.
+func (desc *MatmulDesc) SetTranspose(transA, transB bool) error {
+	op := func(t bool) C.cublasOperation_t {
+		if t {
+			return C.CUBLAS_OP_T
+		}
+		return C.CUBLAS_OP_N
+	}
+	a, b := op(transA), op(transB)
+	if err := desc.set(C.CUBLASLT_MATMUL_DESC_TRANSA, unsafe.Pointer(&a), unsafe.Sizeof(a)); err != nil {
+		return err
+	}
+	return desc.set(C.CUBLASLT_MATMUL_DESC_TRANSB, unsafe.Pointer(&b), unsafe.Sizeof(b))
+}
+
+// SetEpilogue sets the operation fused after the multiplication. The epilogues with a bias need SetBias.
+func (desc *MatmulDesc) SetEpilogue(e Epilogue) error {
+	ce := C.cublasLtEpilogue_t(e)
+	return desc.set(C.CUBLASLT_MATMUL_DESC_EPILOGUE, unsafe.Pointer(&ce), unsafe.Sizeof(ce))
+}
+
+// SetBias sets the bias added by the epilogue: a vector with one element per row of the result.
+func (desc *MatmulDesc) SetBias(bias cu.DevicePtr) error {
+	p := bias.Uintptr()
+	return desc.set(C.CUBLASLT_MATMUL_DESC_BIAS_POINTER, unsafe.Pointer(&p), unsafe.Sizeof(p))
+}
+
+// scale writes v, converted to the scale type, to buf.
+func (desc *MatmulDesc) scale(v float64, buf unsafe.Pointer) error {
+	switch desc.scaleType {
+	case cu.DtFloat32:
+		*(*float32)(buf) = float32(v)
+	case cu.DtFloat64:
+		*(*float64)(buf) = v
+	case cu.DtInt32:
+		*(*int32)(buf) = int32(v)
+	default:
+		return errors.Errorf("Unsupported scale type %v", desc.scaleType)
+	}
+	return nil
+}
+
+// Close destroys the descriptor.
+func (desc *MatmulDesc) Close() error {
+	if desc.d == nil {
+		return nil
+	}
+	err := result(C.cublasLtMatmulDescDestroy(desc.d))
+	desc.d = nil
+	return err
+}
+
+// MatrixLayout describes the memory layout of a matrix, or of a batch of matrices.
+type MatrixLayout struct {
+	l C.cublasLtMatrixLayout_t
+}
+
+// NewMatrixLayout creates the layout of a rows×cols matrix of the given type, with a leading dimension of ld elements.
+func NewMatrixLayout(dt cu.Dtype, rows, cols, ld int) (*MatrixLayout, error) {
+	t, err := dataType(dt)
+	if err != nil {
+		return nil, err
+	}
+	if rows < 0 || cols < 0 || ld < 0 {
+		return nil, errors.Errorf("Invalid layout: %d×%d matrix with a leading dimension of %d", rows, cols, ld)
+	}
+	var l C.cublasLtMatrixLayout_t
+	if err = result(C.cublasLtMatrixLayoutCreate(&l, t, C.uint64_t(rows), C.uint64_t(cols), C.int64_t(ld))); err != nil {
+		return nil, errors.Wrap(err, "Unable to create a MatrixLayout")
+	}
+	return &MatrixLayout{l: l}, nil
+}
+
+func (layout *MatrixLayout) set(attr C.cublasLtMatrixLayoutAttribute_t, buf unsafe.Pointer, size uintptr) error {
+	return result(C.cublasLtMatrixLayoutSetAttribute(layout.l, attr, buf, C.size_t(size)))
+}
+
+// SetOrder sets the order of the elements. The default is ColMajor.
+func (layout *MatrixLayout) SetOrder(o Order) error {
+	co := C.cublasLtOrder_t(o)
+	return layout.set(C.CUBLASLT_MATRIX_LAYOUT_ORDER, unsafe.Pointer(&co), unsafe.Sizeof(co))
+}
+
+// SetBatch makes the layout that of count matrices, stride elements apart.
+func (layout *MatrixLayout) SetBatch(count int, stride int64) error {
+	n, s := C.int32_t(count), C.int64_t(stride)
+	if err := layout.set(C.CUBLASLT_MATRIX_LAYOUT_BATCH_COUNT, unsafe.Pointer(&n), unsafe.Sizeof(n)); err != nil {
+		return err
+	}
+	return layout.set(C.CUBLASLT_MATRIX_LAYOUT_STRIDED_BATCH_OFFSET, unsafe.Pointer(&s), unsafe.Sizeof(s))
+}
+
+// Close destroys the layout.
+func (layout *MatrixLayout) Close() error {
+	if layout.l == nil {
+		return nil
+	}
+	err := result(C.cublasLtMatrixLayoutDestroy(layout.l))
+	layout.l = nil
+	return err
+}
+
+// Preference restricts the algorithms returned by Heuristics.
+type Preference struct {
+	p C.cublasLtMatmulPreference_t
+}
+
+// NewPreference creates a preference for the algorithms needing at most maxWorkspace bytes of workspace.
+func NewPreference(maxWorkspace int64) (*Preference, error) {
+	var p C.cublasLtMatmulPreference_t
+	if err := result(C.cublasLtMatmulPreferenceCreate(&p)); err != nil {
+		return nil, errors.Wrap(err, "Unable to create a Preference")
+	}
+	ws := C.size_t(maxWorkspace)
+	if err := result(C.cublasLtMatmulPreferenceSetAttribute(p, C.CUBLASLT_MATMUL_PREF_MAX_WORKSPACE_BYTES, unsafe.Pointer(&ws), C.size_t(unsafe.Sizeof(ws)))); err != nil {
+		C.cublasLtMatmulPreferenceDestroy(p)
+		return nil, errors.Wrap(err, "Unable to set the workspace of a Preference")
+	}
+	return &Preference{p: p}, nil
+}
+
+// Close destroys the preference.
+func (pref *Preference) Close() error {
+	if pref.p == nil {
+		return nil
+	}
+	err := result(C.cublasLtMatmulPreferenceDestroy(pref.p))
+	pref.p = nil
+	return err
+}
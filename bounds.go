@@ -0,0 +1,114 @@
+package cu
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// BoundsError is the value of the panic of an offset out of its allocation. See DevicePtr.Offset.
+type BoundsError struct {
+	Ptr    DevicePtr // pointer offset
+	Offset int64
+	Base   DevicePtr // allocation the pointer points into
+	Size   int64
+}
+
+func (err BoundsError) Error() string {
+	return fmt.Sprintf("Offset %d from %v is out of the allocation of %d bytes at %v", err.Offset, err.Ptr, err.Size, err.Base)
+}
+
+// AlignmentError is the error of a pointer that is not aligned to the elements it points to. See CheckPtr.
+type AlignmentError struct {
+	Ptr   DevicePtr
+	Align int64 // the alignment required, in bytes
+}
+
+func (err AlignmentError) Error() string {
+	return fmt.Sprintf("%v is not aligned to %d bytes", err.Ptr, err.Align)
+}
+
+type allocation struct {
+	base DevicePtr
+	size int64
+}
+
+// allocations records the allocations made with MemAlloc while offsets or pointers are being checked, sorted by base.
+var allocations struct {
+	sync.RWMutex
+	list []allocation
+}
+
+func trackAlloc(ptr DevicePtr, size int64) {
+	if !debugging(DebugBounds | DebugAlign) {
+		return
+	}
+	allocations.Lock()
+	i := sort.Search(len(allocations.list), func(i int) bool { return allocations.list[i].base >= ptr })
+	// an allocation at the same address was freed without being untracked, by a path that does not know of the
+	// tracking: the new allocation replaces it
+	if i == len(allocations.list) || allocations.list[i].base != ptr {
+		allocations.list = append(allocations.list, allocation{})
+		copy(allocations.list[i+1:], allocations.list[i:])
+	}
+	allocations.list[i] = allocation{ptr, size}
+	allocations.Unlock()
+}
+
+// untrackAlloc forgets the allocation, even if offsets are no longer being checked.
+func untrackAlloc(ptr DevicePtr) {
+	allocations.Lock()
+	l := allocations.list
+	if i := sort.Search(len(l), func(i int) bool { return l[i].base >= ptr }); i < len(l) && l[i].base == ptr {
+		allocations.list = append(l[:i], l[i+1:]...)
+	}
+	allocations.Unlock()
+}
+
+// allocationOf returns the recorded allocation containing ptr.
+func allocationOf(ptr DevicePtr) (allocation, bool) {
+	allocations.RLock()
+	defer allocations.RUnlock()
+	l := allocations.list
+	i := sort.Search(len(l), func(i int) bool { return l[i].base > ptr }) - 1
+	if i < 0 || ptr >= l[i].base+DevicePtr(l[i].size) {
+		return allocation{}, false
+	}
+	return l[i], true
+}
+
+// Offset returns the pointer n bytes after d (before it if n is negative).
+//
+// When offsets are being checked (see DebugBounds), Offset panics with a BoundsError if d points into an allocation
+// made with MemAlloc, and the result is out of it. The end of the allocation is in bounds. Only the allocations made
+// while offsets are being checked are known: offsets from other pointers are not checked.
+func (d DevicePtr) Offset(n int64) DevicePtr {
+	if debugging(DebugBounds) {
+		if a, ok := allocationOf(d); ok {
+			if off := int64(d-a.base) + n; off < 0 || off > a.size {
+				panic(BoundsError{Ptr: d, Offset: n, Base: a.base, Size: a.size})
+			}
+		}
+	}
+	return d + DevicePtr(n)
+}
+
+// CheckPtr checks that ptr is aligned to align bytes, and that the size bytes from ptr are in the allocation ptr points
+// into, when pointers are being checked (see DebugAlign). It returns nil otherwise. It is called by the packages that
+// take device pointers, such as the BLAS and the kernels, so that misaligned accesses, which are slow or fault, and
+// accesses out of the allocation, which fault or corrupt other memory, are reported by the call that makes them.
+//
+// The error is an AlignmentError or a BoundsError. Only the allocations made with MemAlloc while offsets or pointers
+// are being checked are known: the lengths of other pointers are not checked.
+func CheckPtr(ptr DevicePtr, size, align int64) error {
+	if !debugging(DebugAlign) {
+		return nil
+	}
+	if align > 1 && int64(ptr)%align != 0 {
+		return AlignmentError{Ptr: ptr, Align: align}
+	}
+	if a, ok := allocationOf(ptr); ok && int64(ptr-a.base)+size > a.size {
+		return BoundsError{Ptr: ptr, Offset: size, Base: a.base, Size: a.size}
+	}
+	return nil
+}
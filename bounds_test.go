@@ -0,0 +1,108 @@
+package cu
+
+import "testing"
+
+func TestDevicePtr_Offset(t *testing.T) {
+	old := CurrentFeatures()
+	defer SetFeatures(old)
+	f := old
+	f.Debug |= DebugBounds
+	SetFeatures(f)
+
+	// fake allocations: only the records of the tracker are used
+	const base, size = DevicePtr(0x10000), 256
+	trackAlloc(base, size)
+	trackAlloc(base+0x1000, size)
+	defer untrackAlloc(base)
+	defer untrackAlloc(base + 0x1000)
+
+	mustPanic := func(d DevicePtr, n int64) {
+		defer func() {
+			r := recover()
+			if _, ok := r.(BoundsError); !ok {
+				t.Errorf("Expected %v.Offset(%d) to panic with a BoundsError. Got %v", d, n, r)
+			}
+		}()
+		d.Offset(n)
+	}
+
+	if got := base.Offset(16); got != base+16 {
+		t.Errorf("Expected %v. Got %v", base+16, got)
+	}
+	if got := base.Offset(16).Offset(-16); got != base {
+		t.Errorf("Expected %v. Got %v", base, got)
+	}
+	base.Offset(size) // the end is in bounds
+	mustPanic(base, size+1)
+	mustPanic(base, -1)
+	mustPanic(base.Offset(128), 129)
+	mustPanic(base+0x1000+255, 2)
+
+	// pointers out of the recorded allocations are not checked
+	if got := DevicePtr(0x20000).Offset(1 << 20); got != 0x20000+1<<20 {
+		t.Errorf("Expected %v. Got %v", DevicePtr(0x20000+1<<20), got)
+	}
+
+	untrackAlloc(base)
+	base.Offset(size + 1)
+
+	SetFeatures(old)
+	(base + 0x1000).Offset(size + 1)
+}
+
+func TestCheckPtr(t *testing.T) {
+	old := CurrentFeatures()
+	defer SetFeatures(old)
+	f := old
+	f.Debug |= DebugAlign
+	SetFeatures(f)
+
+	const base, size = DevicePtr(0x10000), 256
+	trackAlloc(base, size)
+	defer untrackAlloc(base)
+
+	if err := CheckPtr(base+4, size-4, 4); err != nil {
+		t.Errorf("Expected no error. Got %v", err)
+	}
+	if err, ok := CheckPtr(base+2, 4, 4).(AlignmentError); !ok || err.Align != 4 {
+		t.Errorf("Expected an AlignmentError. Got %v", err)
+	}
+	if _, ok := CheckPtr(base+8, size, 8).(BoundsError); !ok {
+		t.Errorf("Expected a BoundsError")
+	}
+	tensor := NewDeviceTensor(base, DtFloat32, 8, 8)
+	if err := tensor.CheckMemory(); err != nil {
+		t.Errorf("Expected the tensor to fit. Got %v", err)
+	}
+	tensor = NewDeviceTensor(base, DtFloat32, 8, 9)
+	if err := tensor.CheckMemory(); err == nil {
+		t.Errorf("Expected the tensor to be out of its allocation")
+	}
+
+	SetFeatures(old)
+	if err := CheckPtr(base+2, 1<<20, 4); err != nil {
+		t.Errorf("Expected no checks when pointers are not being checked. Got %v", err)
+	}
+}
+
+func TestTrackAllocReplaces(t *testing.T) {
+	old := CurrentFeatures()
+	defer SetFeatures(old)
+	f := old
+	f.Debug |= DebugAlign
+	SetFeatures(f)
+
+	// the address of an allocation freed without being untracked is reused by a smaller one
+	const base = DevicePtr(0x10000)
+	trackAlloc(base, 4096)
+	trackAlloc(base, 256)
+	defer untrackAlloc(base)
+
+	if _, ok := CheckPtr(base, 512, 4).(BoundsError); !ok {
+		t.Errorf("Expected the new allocation to replace the old one")
+	}
+	untrackAlloc(base)
+	if a, ok := allocationOf(base); ok {
+		t.Errorf("Expected no allocation to be left at %v. Got %+v", base, a)
+	}
+}
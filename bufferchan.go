@@ -0,0 +1,111 @@
+package cu
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+// BufferChan hands device buffers over from producing goroutines to consuming ones.
+//
+// A producer usually enqueues the work writing a buffer on a stream, and sends the buffer without waiting for it: the
+// buffer travels with an event recorded on that stream. The consumer makes its own stream wait for the event before
+// using the buffer, so the handoff never blocks a host thread on the device:
+//
+//	// producer
+//	fn.Launch(..., stream, params)
+//	ch.Send(buf, stream)
+//
+//	// consumer
+//	buf, ok, err := ch.Recv(otherStream)
+//	... use buf on otherStream ...
+//	buf.Release()
+//
+// Buffers change owner when they are sent: Send releases the reference of the sender, and the receiver gets a new one.
+// A sender that keeps using its reference uses a released reference, which is caught by RcBuffer.
+//
+// The events come from an EventPool. Send and Recv must be called from threads where the context of the pool is
+// current.
+type BufferChan struct {
+	events *EventPool
+	ch     chan handoff
+
+	mu     sync.RWMutex // held for reading by Send, for writing by Close
+	closed bool
+}
+
+type handoff struct {
+	buf   *RcBuffer
+	ready *ScopedEvent
+}
+
+// NewBufferChan creates a BufferChan that buffers up to capacity buffers, recording the readiness of buffers with
+// events from the pool.
+func NewBufferChan(events *EventPool, capacity int) *BufferChan {
+	return &BufferChan{events: events, ch: make(chan handoff, capacity)}
+}
+
+// Send hands the buffer over, once the work already enqueued on the stream is done. It blocks while the channel is
+// full. The reference b is released: the sender must not use it anymore. Sending a released reference, or sending on
+// a closed channel, returns an error and leaves b as it was.
+func (c *BufferChan) Send(b *RcBuffer, stream Stream) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return errors.Errorf("Cannot send %v: the BufferChan is closed", b)
+	}
+	if atomic.LoadUint32(&b.released) != 0 {
+		return errors.Errorf("Cannot send %v: the reference has been released", b)
+	}
+	ready, err := c.events.RecordScoped(stream)
+	if err != nil {
+		return errors.Wrap(err, "Unable to record the readiness of the buffer")
+	}
+	// the reference of the sender moves to the receiver, count included. It may still be released concurrently.
+	if !atomic.CompareAndSwapUint32(&b.released, 0, 1) {
+		ready.discard()
+		return errors.Errorf("Cannot send %v: the reference has been released", b)
+	}
+	c.ch <- handoff{buf: &RcBuffer{ptr: b.ptr, size: b.size, ref: b.ref}, ready: ready}
+	return nil
+}
+
+// Recv takes the next buffer, and makes the stream wait until it is ready. It blocks until a buffer is sent, and
+// returns false once the channel is closed and empty. The receiver owns the reference returned, and must release it,
+// even if an error is returned.
+func (c *BufferChan) Recv(stream Stream) (*RcBuffer, bool, error) {
+	h, ok := <-c.ch
+	if !ok {
+		return nil, false, nil
+	}
+	if err := h.ready.WaitOn(stream); err != nil {
+		return h.buf, true, errors.Wrap(err, "Unable to wait for the readiness of the buffer")
+	}
+	return h.buf, true, nil
+}
+
+// RecvHost is like Recv, but blocks the calling thread until the buffer is ready, for buffers consumed by the host,
+// or by calls that do not take a stream.
+func (c *BufferChan) RecvHost() (*RcBuffer, bool, error) {
+	h, ok := <-c.ch
+	if !ok {
+		return nil, false, nil
+	}
+	if err := h.ready.Wait(); err != nil {
+		return h.buf, true, errors.Wrap(err, "Unable to wait for the readiness of the buffer")
+	}
+	return h.buf, true, nil
+}
+
+// Close closes the channel: buffers already sent can still be received, and later Sends return an error. Close waits
+// for the Sends blocked on a full channel. The buffers that are never received are not released - drain the channel to
+// release them.
+func (c *BufferChan) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.closed {
+		c.closed = true
+		close(c.ch)
+	}
+}
@@ -0,0 +1,103 @@
+package cu
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+)
+
+func TestBufferChan(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	_, ctx, err := testSetup()
+	if err != nil {
+		if err.Error() == "NoDevice" {
+			t.Skip("NoDevice")
+		}
+		t.Fatal(err)
+	}
+	defer testTeardown(ctx, Module{})
+
+	events := NewEventPool(DisableTiming)
+	defer events.Destroy()
+	producer, err := MakeStream(NonBlocking)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer producer.Destroy()
+
+	const n, size = 4, 1024
+	ch := NewBufferChan(events, n)
+	for i := 0; i < n; i++ {
+		b, err := NewRcBuffer(size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = MemsetD32Async(b.Ptr(), uint(i), size/4, producer); err != nil {
+			t.Fatal(err)
+		}
+		if err = ch.Send(b, producer); err != nil {
+			t.Fatal(err)
+		}
+		if err = b.Release(); err == nil {
+			t.Error("Expected the reference of the sender to be released by Send")
+		}
+	}
+	ch.Close()
+
+	host := make([]uint32, size/4)
+	for i := 0; ; i++ {
+		b, ok, err := ch.RecvHost()
+		if !ok {
+			if i != n {
+				t.Errorf("Received %d buffers, expected %d", i, n)
+			}
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = MemcpyDtoH(unsafe.Pointer(&host[0]), b.Ptr(), size); err != nil {
+			t.Fatal(err)
+		}
+		if host[0] != uint32(i) || host[len(host)-1] != uint32(i) {
+			t.Errorf("Buffer %d holds %d", i, host[0])
+		}
+		if err = b.Release(); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestBufferChanRefusals(t *testing.T) {
+	freed := 0
+	newBuffer := func() *RcBuffer {
+		return &RcBuffer{ptr: 1, size: 1, ref: &memRef{base: 1, free: func(DevicePtr) error { freed++; return nil }, refs: 1}}
+	}
+
+	// neither refusal records an event, so no device is needed
+	ch := NewBufferChan(NewEventPool(DisableTiming), 1)
+	released := newBuffer()
+	if err := released.Release(); err != nil {
+		t.Fatal(err)
+	}
+	if err := ch.Send(released, Stream{}); err == nil {
+		t.Error("Expected an error sending a released reference")
+	}
+
+	ch.Close()
+	ch.Close()
+	b := newBuffer()
+	if err := ch.Send(b, Stream{}); err == nil {
+		t.Error("Expected an error sending on a closed BufferChan")
+	}
+	if err := b.Release(); err != nil {
+		t.Errorf("Expected a refused Send to leave the reference to the sender: %v", err)
+	}
+	if freed != 2 {
+		t.Errorf("Expected both buffers to be freed once. Got %d frees", freed)
+	}
+	if _, ok, _ := ch.RecvHost(); ok {
+		t.Error("Expected the BufferChan to be empty")
+	}
+}
@@ -0,0 +1,160 @@
+// cucoverage reports how much of the cuBLAS API is covered by package cublas.
+//
+// It parses the installed cuBLAS header, and compares the routines declared against the cuBLAS calls made in package cublas,
+// and the routines that gencublas has been told to skip. Routines are reported as:
+//
+//	missing - declared in the header, but neither bound nor skipped
+//	skipped - declared in the header, and deliberately skipped by gencublas
+//	extra   - bound in package cublas, but not declared in the header
+//
+// Run it from the root of the repository:
+//
+//	go run ./cmd/cucoverage -header /usr/local/cuda/include/cublas_api.h
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	header   = flag.String("header", "/usr/local/cuda/include/cublas_api.h", "the cuBLAS header to compare against")
+	pkg      = flag.String("pkg", "blas", "the directory of package cublas")
+	mappings = flag.String("mappings", "cmd/gencublas/mappings.go", "the gencublas file containing the skip map")
+	ilp64    = flag.Bool("ilp64", false, "include the 64-bit integer (_64) variants of the routines")
+	verbose  = flag.Bool("v", false, "list the covered routines as well")
+	strict   = flag.Bool("strict", false, "exit with a non-zero status if any routines are missing")
+)
+
+func main() {
+	flag.Parse()
+	log.SetFlags(0)
+
+	src, err := ioutil.ReadFile(*header)
+	if err != nil {
+		log.Fatal(err)
+	}
+	declared := headerRoutines(string(src), *ilp64)
+
+	bound, err := boundRoutines(*pkg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	skipped, err := skipMap(*mappings)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	r := compare(declared, bound, skipped)
+	r.write(os.Stdout, *verbose)
+	if *strict && len(r.missing) > 0 {
+		os.Exit(1)
+	}
+}
+
+// declRe matches the name of a declared routine. The CUBLASAPI and CUBLASWINAPI macros may or may not have been expanded.
+var declRe = regexp.MustCompile(`(?m)^\s*(?:CUBLASAPI\s+)?cublasStatus_t\s+(?:CUBLASWINAPI\s+)?(cublas\w+)\s*\(`)
+
+// headerRoutines returns the set of routines declared in the header.
+// The _v2 suffixes are elided, as package cublas binds to the unsuffixed names (which are #defined to the _v2 routines).
+func headerRoutines(src string, ilp64 bool) map[string]bool {
+	retVal := make(map[string]bool)
+	for _, m := range declRe.FindAllStringSubmatch(src, -1) {
+		name := m[1]
+		if strings.HasSuffix(name, "_64") {
+			if !ilp64 {
+				continue
+			}
+		}
+		name = strings.Replace(name, "_v2", "", 1)
+		retVal[name] = true
+	}
+	return retVal
+}
+
+type report struct {
+	covered []string
+	missing []string
+	skipped []string
+	extra   []string
+}
+
+func compare(declared, bound, skipped map[string]bool) (r report) {
+	for name := range declared {
+		switch {
+		case bound[name]:
+			r.covered = append(r.covered, name)
+		case skipped[name]:
+			r.skipped = append(r.skipped, name)
+		default:
+			r.missing = append(r.missing, name)
+		}
+	}
+	for name := range bound {
+		if !declared[name] {
+			r.extra = append(r.extra, name)
+		}
+	}
+	sort.Strings(r.covered)
+	sort.Strings(r.missing)
+	sort.Strings(r.skipped)
+	sort.Strings(r.extra)
+	return r
+}
+
+func (r report) write(w io.Writer, verbose bool) {
+	total := len(r.covered) + len(r.missing) + len(r.skipped)
+	var pct float64
+	if total > 0 {
+		pct = 100 * float64(len(r.covered)) / float64(total)
+	}
+	fmt.Fprintf(w, "Coverage: %d/%d routines (%.1f%%). %d missing, %d skipped, %d extra\n",
+		len(r.covered), total, pct, len(r.missing), len(r.skipped), len(r.extra))
+
+	section := func(title string, names []string) {
+		if len(names) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "\n%s (%d):\n", title, len(names))
+		for _, name := range names {
+			fmt.Fprintf(w, "\t%s\n", name)
+		}
+	}
+	section("Missing", r.missing)
+	section("Skipped", r.skipped)
+	section("Extra", r.extra)
+	if verbose {
+		section("Covered", r.covered)
+	}
+}
+
+// boundRoutines returns the set of cuBLAS routines called from the non-test Go files in dir.
+func boundRoutines(dir string) (map[string]bool, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	retVal := make(map[string]bool)
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		names, err := cgoCalls(file, "cublas")
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			retVal[name] = true
+		}
+	}
+	return retVal, nil
+}
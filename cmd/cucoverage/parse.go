@@ -0,0 +1,78 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// cgoCalls returns the names of the C functions with the given prefix that are called in the file.
+// Conversions to C types (e.g. C.cublasHandle_t(x)) are ignored.
+func cgoCalls(filename, prefix string) ([]string, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, nil, 0)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to parse %v", filename)
+	}
+
+	var retVal []string
+	ast.Inspect(f, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if x, ok := sel.X.(*ast.Ident); ok && x.Name == "C" && strings.HasPrefix(sel.Sel.Name, prefix) && !strings.HasSuffix(sel.Sel.Name, "_t") {
+			retVal = append(retVal, sel.Sel.Name)
+		}
+		return true
+	})
+	return retVal, nil
+}
+
+// skipMap reads the keys of the `skip` map declared in the given file.
+func skipMap(filename string) (map[string]bool, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, nil, 0)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to parse %v", filename)
+	}
+
+	obj := f.Scope.Lookup("skip")
+	if obj == nil {
+		return nil, errors.Errorf("No skip map found in %v", filename)
+	}
+	spec, ok := obj.Decl.(*ast.ValueSpec)
+	if !ok || len(spec.Values) != 1 {
+		return nil, errors.Errorf("Expected skip in %v to be a single var declaration", filename)
+	}
+	lit, ok := spec.Values[0].(*ast.CompositeLit)
+	if !ok {
+		return nil, errors.Errorf("Expected skip in %v to be a composite literal", filename)
+	}
+
+	retVal := make(map[string]bool)
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.BasicLit)
+		if !ok || key.Kind != token.STRING {
+			continue
+		}
+		name, err := strconv.Unquote(key.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Bad key in skip map: %v", key.Value)
+		}
+		retVal[name] = true
+	}
+	return retVal, nil
+}
@@ -96,6 +96,7 @@ func main() {
 		}
 
 		`)
+		checkInt32(&buf, d)
 		parameterChecks(&buf, d, parameterCheckRules)
 		buf.WriteByte('\t')
 		cgoCall(&buf, d)
@@ -240,6 +241,52 @@ func parameterChecks(buf *bytes.Buffer, d *bg.CSignature, rules []func(*bytes.Bu
 	}
 }
 
+// checkInt32 writes the check that the int parameters of the function fit the C int of cuBLAS, so that dimensions and
+// increments of 2^31 or more are reported instead of silently truncated.
+func checkInt32(buf *bytes.Buffer, d *bg.CSignature) {
+	var names []string
+	for _, p := range d.Parameters() {
+		if p.Name() == "handle" || p.Name() == "result" {
+			continue
+		}
+		if p.Kind() == cc.Int || p.Type().String() == "const int*" {
+			names = append(names, shorten(LowerCaseFirst(p.Name())))
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	goName := UpperCaseFirst(strings.TrimPrefix(d.Name, prefix))
+	list := strings.Join(names, ", ")
+	fmt.Fprintf(buf, "\tif !impl.fits32(%q, %q, %s) {\n\t\treturn\n\t}\n", goName, list, list)
+}
+
+// check writes a parameter check of the function. When cond holds, the call is reported by shapeError, which panics
+// or records a ShapeError depending on the shape policy of the implementation, with the message formatted from args.
+func check(buf *bytes.Buffer, d *bg.CSignature, cond, format string, args ...string) {
+	goName := UpperCaseFirst(strings.TrimPrefix(d.Name, prefix))
+	fmt.Fprintf(buf, "\tif %s {\n\t\timpl.shapeError(%q, %q", cond, goName, format)
+	for _, arg := range args {
+		fmt.Fprintf(buf, ", %s", arg)
+	}
+	buf.WriteString(")\n\t\treturn\n\t}\n")
+}
+
+// checkVector writes the check that the vector is long enough for its n elements.
+func checkVector(buf *bytes.Buffer, d *bg.CSignature, x, n string) {
+	inc := "inc" + strings.ToUpper(x)
+	cond := fmt.Sprintf("(%[2]s > 0 && (%[3]s-1)*%[2]s >= len(%[1]s)) || (%[2]s < 0 && (1-%[3]s)*%[2]s >= len(%[1]s))", x, inc, n)
+	check(buf, d, cond, fmt.Sprintf("blas: %[1]s index out of range: %[3]s=%%d, %[2]s=%%d, len(%[1]s)=%%d", x, inc, n), n, inc, "len("+x+")")
+}
+
+// checkMatrix writes the checks of the leading dimension of the matrix, and of its length.
+func checkMatrix(buf *bytes.Buffer, d *bg.CSignature, a, rows, cols string) {
+	ld := "ld" + a
+	check(buf, d, fmt.Sprintf("%s < max(1, %s)", ld, cols), fmt.Sprintf("blas: %s=%%d < max(1, %s=%%d)", ld, cols), ld, cols)
+	size := fmt.Sprintf("%s*(%s-1)+%s", ld, rows, cols)
+	check(buf, d, fmt.Sprintf("%s > len(%s)", size, a), fmt.Sprintf("blas: index of %s out of range: %s=%%d > len(%s)=%%d", a, size, a), size, "len("+a+")")
+}
+
 func cgoCall(buf *bytes.Buffer, d *bg.CSignature) {
 	// if there is a "result" param, lift it out of the call
 	var hasRet bool
@@ -347,33 +394,25 @@ func amaxShape(buf *bytes.Buffer, d *bg.CSignature, p bg.Parameter) bool {
 	fmt.Fprint(buf, `	if n == 0 || incX < 0 {
 		return -1
 	}
-	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
-	}
 `)
+	check(buf, d, "incX > 0 && (n-1)*incX >= len(x)", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", "n", "incX", "len(x)")
 	return true
 }
 
-func apShape(buf *bytes.Buffer, _ *bg.CSignature, p bg.Parameter) bool {
+func apShape(buf *bytes.Buffer, d *bg.CSignature, p bg.Parameter) bool {
 	n := LowerCaseFirst(p.Name())
 	if n != "ap" {
 		return false
 	}
-	fmt.Fprint(buf, `	if n*(n+1)/2 > len(ap) {
-		panic("blas: index of ap out of range")
-	}
-`)
+	check(buf, d, "n*(n+1)/2 > len(ap)", "blas: index of ap out of range: n*(n+1)/2=%d > len(ap)=%d", "n*(n+1)/2", "len(ap)")
 	return true
 }
 
-func diag(buf *bytes.Buffer, _ *bg.CSignature, p bg.Parameter) bool {
+func diag(buf *bytes.Buffer, d *bg.CSignature, p bg.Parameter) bool {
 	if p.Name() != "Diag" {
 		return false
 	}
-	fmt.Fprint(buf, `	if d != blas.NonUnit && d != blas.Unit {
-		panic("blas: illegal diagonal")
-	}
-`)
+	check(buf, d, "d != blas.NonUnit && d != blas.Unit", "blas: illegal diagonal d=%v", "d")
 	return true
 }
 
@@ -489,10 +528,8 @@ func nrmSumShape(buf *bytes.Buffer, d *bg.CSignature, p bg.Parameter) bool {
 	fmt.Fprint(buf, `	if incX < 0 {
 		return 0
 	}
-	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
-	}
 `)
+	check(buf, d, "incX > 0 && (n-1)*incX >= len(x)", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", "n", "incX", "len(x)")
 	return true
 }
 
@@ -525,17 +562,11 @@ func rkShape(buf *bytes.Buffer, d *bg.CSignature, p bg.Parameter) bool {
 	}
 	for _, label := range []string{"a", "b"} {
 		if has[label] {
-			fmt.Fprintf(buf, `	if ld%[1]s*(row-1)+col > len(%[1]s) || ld%[1]s < max(1, col) {
-		panic("blas: index of %[1]s out of range")
-	}
-`, label)
+			checkMatrix(buf, d, label, "row", "col")
 		}
 	}
 	if has["c"] {
-		fmt.Fprint(buf, `	if ldc*(n-1)+n > len(c) || ldc < max(1, n) {
-		panic("blas: index of c out of range")
-	}
-`)
+		checkMatrix(buf, d, "c", "n", "n")
 	}
 
 	return true
@@ -555,33 +586,25 @@ func scalShape(buf *bytes.Buffer, d *bg.CSignature, p bg.Parameter) bool {
 	fmt.Fprint(buf, `	if incX < 0 {
 		return
 	}
-	if incX > 0 && (n-1)*incX >= len(x) {
-		panic("blas: x index out of range")
-	}
 `)
+	check(buf, d, "incX > 0 && (n-1)*incX >= len(x)", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", "n", "incX", "len(x)")
 	return true
 }
 
-func shape(buf *bytes.Buffer, _ *bg.CSignature, p bg.Parameter) bool {
+func shape(buf *bytes.Buffer, d *bg.CSignature, p bg.Parameter) bool {
 	switch n := LowerCaseFirst(p.Name()); n {
 	case "m", "n", "k", "kL", "kU":
-		fmt.Fprintf(buf, `	if %[1]s < 0 {
-		panic("blas: %[1]s < 0")
-	}
-`, n)
+		check(buf, d, n+" < 0", "blas: "+n+"=%d < 0", n)
 		return false
 	}
 	return false
 }
 
-func side(buf *bytes.Buffer, _ *bg.CSignature, p bg.Parameter) bool {
+func side(buf *bytes.Buffer, d *bg.CSignature, p bg.Parameter) bool {
 	if p.Name() != "Side" {
 		return false
 	}
-	fmt.Fprint(buf, `	if s != blas.Left && s != blas.Right {
-		panic("blas: illegal side")
-	}
-`)
+	check(buf, d, "s != blas.Left && s != blas.Right", "blas: illegal side s=%v", "s")
 	return true
 }
 
@@ -614,21 +637,14 @@ func sidedShape(buf *bytes.Buffer, d *bg.CSignature, p bg.Parameter) bool {
 	} else {
 		k = n
 	}
-	if lda*(k-1)+k > len(a) || lda < max(1, k) {
-		panic("blas: index of a out of range")
-	}
-	if ldb*(m-1)+n > len(b) || ldb < max(1, n) {
-		panic("blas: index of b out of range")
-	}
 `)
+		checkMatrix(buf, d, "a", "k", "k")
+		checkMatrix(buf, d, "b", "m", "n")
 	} else {
 		return true
 	}
 	if hasC {
-		fmt.Fprint(buf, `	if ldc*(m-1)+n > len(c) || ldc < max(1, n) {
-		panic("blas: index of c out of range")
-	}
-`)
+		checkMatrix(buf, d, "c", "m", "n")
 	}
 
 	return true
@@ -639,33 +655,21 @@ func trans(buf *bytes.Buffer, d *bg.CSignature, p bg.Parameter) bool {
 	case "t", "tA", "tB":
 		switch {
 		case strings.HasPrefix(d.Name, "cublasCh"), strings.HasPrefix(d.Name, "cublasZh"):
-			fmt.Fprintf(buf, `	if %[1]s != blas.NoTrans && %[1]s != blas.ConjTrans {
-		panic("blas: illegal transpose")
-	}
-`, n)
+			check(buf, d, fmt.Sprintf("%[1]s != blas.NoTrans && %[1]s != blas.ConjTrans", n), "blas: illegal transpose "+n+"=%v", n)
 		case strings.HasPrefix(d.Name, "cublasCs"), strings.HasPrefix(d.Name, "cublasZs"):
-			fmt.Fprintf(buf, `	if %[1]s != blas.NoTrans && %[1]s != blas.Trans {
-		panic("blas: illegal transpose")
-	}
-`, n)
+			check(buf, d, fmt.Sprintf("%[1]s != blas.NoTrans && %[1]s != blas.Trans", n), "blas: illegal transpose "+n+"=%v", n)
 		default:
-			fmt.Fprintf(buf, `	if %[1]s != blas.NoTrans && %[1]s != blas.Trans && %[1]s != blas.ConjTrans {
-		panic("blas: illegal transpose")
-	}
-`, n)
+			check(buf, d, fmt.Sprintf("%[1]s != blas.NoTrans && %[1]s != blas.Trans && %[1]s != blas.ConjTrans", n), "blas: illegal transpose "+n+"=%v", n)
 		}
 	}
 	return false
 }
 
-func uplo(buf *bytes.Buffer, _ *bg.CSignature, p bg.Parameter) bool {
+func uplo(buf *bytes.Buffer, d *bg.CSignature, p bg.Parameter) bool {
 	if p.Name() != "Uplo" {
 		return false
 	}
-	fmt.Fprint(buf, `	if ul != blas.Upper && ul != blas.Lower {
-		panic("blas: illegal triangle")
-	}
-`)
+	check(buf, d, "ul != blas.Upper && ul != blas.Lower", "blas: illegal triangle ul=%v", "ul")
 	return true
 }
 
@@ -708,32 +712,20 @@ func vectorShape(buf *bytes.Buffer, d *bg.CSignature, p bg.Parameter) bool {
 		label = "n"
 	}
 	if hasIncX {
-		fmt.Fprintf(buf, `	if (incX > 0 && (%[1]s-1)*incX >= len(x)) || (incX < 0 && (1-%[1]s)*incX >= len(x)) {
-		panic("blas: x index out of range")
-	}
-`, label)
+		checkVector(buf, d, "x", label)
 	}
 	if hasIncY {
-		fmt.Fprint(buf, `	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
-	}
-`)
+		checkVector(buf, d, "y", "n")
 	}
 	return true
 }
 
-func zeroInc(buf *bytes.Buffer, _ *bg.CSignature, p bg.Parameter) bool {
+func zeroInc(buf *bytes.Buffer, d *bg.CSignature, p bg.Parameter) bool {
 	switch n := LowerCaseFirst(p.Name()); n {
 	case "incX":
-		fmt.Fprintf(buf, `	if incX == 0 {
-		panic("blas: zero x index increment")
-	}
-`)
+		check(buf, d, "incX == 0", "blas: zero x index increment")
 	case "incY":
-		fmt.Fprintf(buf, `	if incY == 0 {
-		panic("blas: zero y index increment")
-	}
-`)
+		check(buf, d, "incY == 0", "blas: zero y index increment")
 		return true
 	}
 	return false
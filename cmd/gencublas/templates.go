@@ -21,7 +21,7 @@ import "C"
 import (
 	"unsafe"
 
-	"github.com/gonum/blas"
+	"gonum.org/v1/gonum/blas"
 )
 
 
@@ -55,23 +55,32 @@ func (impl *Standard) Srotm(n int, x []float32, incX int, y []float32, incY int,
 			return
 	}
 
+	if !impl.fits32("Srotm", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Srotm", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Srotm", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Srotm", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Srotm", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Srotm", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if p.Flag < blas.Identity || p.Flag > blas.Diagonal {
-		panic("blas: illegal blas.Flag value")
+		impl.shapeError("Srotm", "blas: illegal blas.Flag value %v", p.Flag)
+		return
 	}
 	if n == 0 {
 		return
@@ -104,23 +113,33 @@ func (impl *Standard) Drotm(n int, x []float64, incX int, y []float64, incY int,
 	if impl.e != nil {
 			return
 	}
+
+	if !impl.fits32("Drotm", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Drotm", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Drotm", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Drotm", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Drotm", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Drotm", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if p.Flag < blas.Identity || p.Flag > blas.Diagonal {
-		panic("blas: illegal blas.Flag value")
+		impl.shapeError("Drotm", "blas: illegal blas.Flag value %v", p.Flag)
+		return
 	}
 	if n == 0 {
 		return
@@ -136,20 +155,29 @@ func (impl *Standard) Cdotu(n int, x []complex64, incX int, y []complex64, incY
 	if impl.e != nil {
 			return
 	}
+
+	if !impl.fits32("Cdotu", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Cdotu", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Cdotu", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Cdotu", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Cdotu", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Cdotu", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return 0
@@ -162,20 +190,28 @@ func (impl *Standard) Cdotc(n int, x []complex64, incX int, y []complex64, incY
 			return
 	}
 
+	if !impl.fits32("Cdotc", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Cdotc", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Cdotc", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Cdotc", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Cdotc", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Cdotc", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return 0
@@ -188,20 +224,28 @@ func (impl *Standard) Zdotu(n int, x []complex128, incX int, y []complex128, inc
 			return
 	}
 
+	if !impl.fits32("Zdotu", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zdotu", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zdotu", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Zdotu", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zdotu", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Zdotu", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return 0
@@ -213,20 +257,29 @@ func (impl *Standard) Zdotc(n int, x []complex128, incX int, y []complex128, inc
 	if impl.e != nil {
 			return
 	}
+
+	if !impl.fits32("Zdotc", "n, incX, incY", n, incX, incY) {
+		return
+	}
 	if n < 0 {
-		panic("blas: n < 0")
+		impl.shapeError("Zdotc", "blas: n=%d < 0", n)
+		return
 	}
 	if incX == 0 {
-		panic("blas: zero x index increment")
+		impl.shapeError("Zdotc", "blas: zero x index increment")
+		return
 	}
 	if incY == 0 {
-		panic("blas: zero y index increment")
+		impl.shapeError("Zdotc", "blas: zero y index increment")
+		return
 	}
 	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
-		panic("blas: x index out of range")
+		impl.shapeError("Zdotc", "blas: x index out of range: n=%d, incX=%d, len(x)=%d", n, incX, len(x))
+		return
 	}
 	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
-		panic("blas: y index out of range")
+		impl.shapeError("Zdotc", "blas: y index out of range: n=%d, incY=%d, len(y)=%d", n, incY, len(y))
+		return
 	}
 	if n == 0 {
 		return 0
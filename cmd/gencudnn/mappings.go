@@ -54,27 +54,27 @@ var ignored = map[string]struct{}{
 	"cudnnGetConvolutionNdForwardOutputDim":       {},
 	"cudnnDestroyConvolutionDescriptor":           {},
 	"cudnnGetConvolutionForwardAlgorithmMaxCount": {},
-	// "cudnnFindConvolutionForwardAlgorithm":               {},
-	// "cudnnFindConvolutionForwardAlgorithmEx":             {},
-	"cudnnGetConvolutionForwardAlgorithm":     {},
-	"cudnnGetConvolutionForwardAlgorithm_v7":  {},
-	"cudnnGetConvolutionForwardWorkspaceSize": {},
+	"cudnnFindConvolutionForwardAlgorithm":        {}, // algorithms.go
+	"cudnnFindConvolutionForwardAlgorithmEx":      {}, // algorithms.go
+	"cudnnGetConvolutionForwardAlgorithm":         {},
+	"cudnnGetConvolutionForwardAlgorithm_v7":      {}, // algorithms.go
+	"cudnnGetConvolutionForwardWorkspaceSize":     {},
 	// "cudnnConvolutionForward":                            {},
 	// "cudnnConvolutionBiasActivationForward":              {},
 	// "cudnnConvolutionBackwardBias":                       {},
 	"cudnnGetConvolutionBackwardFilterAlgorithmMaxCount": {},
-	// "cudnnFindConvolutionBackwardFilterAlgorithm":        {},
-	// "cudnnFindConvolutionBackwardFilterAlgorithmEx":      {},
-	"cudnnGetConvolutionBackwardFilterAlgorithm":     {},
-	"cudnnGetConvolutionBackwardFilterAlgorithm_v7":  {},
-	"cudnnGetConvolutionBackwardFilterWorkspaceSize": {},
+	"cudnnFindConvolutionBackwardFilterAlgorithm":        {}, // algorithms.go
+	"cudnnFindConvolutionBackwardFilterAlgorithmEx":      {}, // algorithms.go
+	"cudnnGetConvolutionBackwardFilterAlgorithm":         {},
+	"cudnnGetConvolutionBackwardFilterAlgorithm_v7":      {}, // algorithms.go
+	"cudnnGetConvolutionBackwardFilterWorkspaceSize":     {},
 	// "cudnnConvolutionBackwardFilter":                     {},
 	"cudnnGetConvolutionBackwardDataAlgorithmMaxCount": {},
-	// "cudnnFindConvolutionBackwardDataAlgorithm":          {},
-	// "cudnnFindConvolutionBackwardDataAlgorithmEx":        {},
-	"cudnnGetConvolutionBackwardDataAlgorithm":     {},
-	"cudnnGetConvolutionBackwardDataAlgorithm_v7":  {},
-	"cudnnGetConvolutionBackwardDataWorkspaceSize": {},
+	"cudnnFindConvolutionBackwardDataAlgorithm":        {}, // algorithms.go
+	"cudnnFindConvolutionBackwardDataAlgorithmEx":      {}, // algorithms.go
+	"cudnnGetConvolutionBackwardDataAlgorithm":         {},
+	"cudnnGetConvolutionBackwardDataAlgorithm_v7":      {}, // algorithms.go
+	"cudnnGetConvolutionBackwardDataWorkspaceSize":     {},
 	// "cudnnConvolutionBackwardData":                       {},
 	// "cudnnIm2Col":                                        {},
 	// "cudnnSoftmaxForward":                                {},
@@ -134,8 +134,8 @@ var ignored = map[string]struct{}{
 	// "cudnnGetRNNWorkspaceSize":                           {},
 	// "cudnnGetRNNTrainingReserveSize":                     {},
 	// "cudnnGetRNNParamsSize":                              {},
-	// "cudnnGetRNNLinLayerMatrixParams":                    {},
-	// "cudnnGetRNNLinLayerBiasParams":                      {},
+	"cudnnGetRNNLinLayerMatrixParams": {}, // rnnlayer.go
+	"cudnnGetRNNLinLayerBiasParams":   {}, // rnnlayer.go
 	// "cudnnRNNForwardInference":                           {},
 	// "cudnnRNNForwardTraining":                            {},
 	// "cudnnRNNBackwardData":                               {},
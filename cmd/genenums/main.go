@@ -0,0 +1,227 @@
+// genenums generates the enum bindings of package cu from cuda.h.
+//
+// The constants that are bound by hand (with their documentation) are kept as they are. For every enum listed in
+// mappings.go, the enumerators that are not bound yet are bound in generated_enums.go, so that the binding stays
+// complete as new versions of the toolkit add values. The Stringers and the validity checks of all the enum types are
+// generated in generated_enums_strings.go.
+//
+// Usage:
+//
+//	go run ./cmd/genenums -header cmd/genlib/cuda.h -pkg .
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/cznic/cc"
+	"github.com/gorgonia/bindgen"
+)
+
+const pkghdr = `package cu
+
+/* Generated by genenums. DO NOT EDIT */
+`
+
+var (
+	header = flag.String("header", "cuda.h", "the preprocessed cuda.h to parse (see cmd/genlib)")
+	pkgloc = flag.String("pkg", path.Join(os.Getenv("GOPATH"), "src/gorgonia.org/cu"), "the location of package cu")
+)
+
+type errNoPackage string
+
+func (e errNoPackage) Error() string { return fmt.Sprintf("package cu not found in %q", string(e)) }
+
+func handleErr(err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func sortStrings(a []string) { sort.Strings(a) }
+
+// yes I know goimports can be imported, but I'm lazy
+func goimports(filename string) error {
+	cmd := exec.Command("goimports", "-w", filename)
+	return cmd.Run()
+}
+
+func main() {
+	flag.Parse()
+
+	types := make(map[string]struct{})
+	for _, et := range enumTypes {
+		types[et.Go] = struct{}{}
+	}
+	bound, declared, err := boundConstants(*pkgloc, types)
+	handleErr(err)
+
+	enums, err := parseEnums(*header)
+	handleErr(err)
+
+	generated := make(map[string][]constant)
+	for _, et := range enumTypes {
+		var consts []constant
+		byC := make(map[string]int)
+		for _, c := range bound[et.Go] {
+			if _, ok := ignoredEnumerators[c.C]; ok {
+				continue
+			}
+			byC[c.C] = len(consts)
+			consts = append(consts, c)
+		}
+		for _, e := range enums[et.C] {
+			if _, ok := ignoredEnumerators[e.C]; ok {
+				continue
+			}
+			if i, ok := byC[e.C]; ok {
+				consts[i].Value, consts[i].Known = e.Value, true
+				continue
+			}
+			e.Name = goName(et, e.C, enums[et.C])
+			if _, ok := declared[e.Name]; ok {
+				log.Fatalf("The name %v generated for %v is already declared in package cu. Add a name for it in mappings.go", e.Name, e.C)
+			}
+			declared[e.Name] = struct{}{}
+			generated[et.Go] = append(generated[et.Go], e)
+		}
+		bound[et.Go] = consts
+	}
+
+	generateEnums(path.Join(*pkgloc, "generated_enums.go"), generated)
+	generateEnumStrings(path.Join(*pkgloc, "generated_enums_strings.go"), bound, generated)
+}
+
+// parseEnums parses the header, and returns the enumerators of the enums, keyed by the name of the enum.
+func parseEnums(hdr string) (map[string][]constant, error) {
+	t, err := bindgen.Parse(bindgen.Model(), hdr)
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[string]struct{})
+	for _, et := range enumTypes {
+		if et.C != "" {
+			wanted[et.C] = struct{}{}
+		}
+	}
+	filter := func(decl *cc.Declarator) bool {
+		if decl.Type.Kind() != cc.Enum {
+			return false
+		}
+		_, ok := wanted[bindgen.NameOf(decl)]
+		return ok
+	}
+	decls, err := bindgen.Get(t, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	retVal := make(map[string][]constant)
+	for _, d := range decls {
+		e := d.(*bindgen.Enum)
+		for _, a := range e.Type.EnumeratorList() {
+			c := constant{C: string(a.DefTok.S()), Known: true}
+			switch v := a.Value.(type) {
+			case int32:
+				c.Value = int64(v)
+			case int64:
+				c.Value = v
+			}
+			retVal[e.Name] = append(retVal[e.Name], c)
+		}
+	}
+	return retVal, nil
+}
+
+// goName is the name of the Go constant generated for an enumerator: the enumerator without the prefix it shares with
+// the other enumerators of its enum, in camel case.
+func goName(et enumType, cname string, enumerators []constant) string {
+	if n, ok := names[cname]; ok {
+		return n
+	}
+	var cnames []string
+	for _, e := range enumerators {
+		cnames = append(cnames, e.C)
+	}
+	lcp := bindgen.LongestCommonPrefix(cnames...)
+	if i := strings.LastIndex(lcp, "_"); i >= 0 {
+		lcp = lcp[:i+1]
+	}
+	return et.Prefix + bindgen.Snake2Camel(strings.ToLower(strings.TrimPrefix(cname, lcp)), true)
+}
+
+func create(fullpath string) io.WriteCloser {
+	f, err := os.OpenFile(fullpath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	handleErr(err)
+	fmt.Fprint(f, pkghdr)
+	return f
+}
+
+func generateEnums(fullpath string, generated map[string][]constant) {
+	buf := create(fullpath)
+	fmt.Fprintln(buf, "\n// #include <cuda.h>\nimport \"C\"")
+	for _, et := range enumTypes {
+		if len(generated[et.Go]) == 0 {
+			continue
+		}
+		fmt.Fprintf(buf, "\nconst (\n")
+		for _, c := range generated[et.Go] {
+			fmt.Fprintf(buf, "\t%v %v = C.%v\n", c.Name, et.Go, c.C)
+		}
+		fmt.Fprintf(buf, ")\n")
+	}
+	buf.Close()
+	if err := goimports(fullpath); err != nil {
+		log.Printf("Failed to Goimports %q: %v", fullpath, err)
+	}
+}
+
+func generateEnumStrings(fullpath string, bound, generated map[string][]constant) {
+	buf := create(fullpath)
+	fmt.Fprintln(buf, "\nimport \"fmt\"")
+	for _, et := range enumTypes {
+		consts := append(bound[et.Go], generated[et.Go]...)
+
+		// aliases (constants of the same value) are named after the first constant
+		seen := make(map[int64]struct{})
+		var uniq []constant
+		for _, c := range consts {
+			if c.Known {
+				if _, ok := seen[c.Value]; ok {
+					continue
+				}
+				seen[c.Value] = struct{}{}
+			}
+			uniq = append(uniq, c)
+		}
+
+		if et.Flags {
+			fmt.Fprintf(buf, "\nvar _%vNames = []flagName{\n", et.Go)
+			for _, c := range uniq {
+				fmt.Fprintf(buf, "{uint64(%v), %q},\n", c.Name, c.Name)
+			}
+			fmt.Fprintf(buf, "}\n\nfunc (e %v) String() string { return flagString(uint64(e), _%vNames, %q) }\n", et.Go, et.Go, et.Go)
+			fmt.Fprintf(buf, "\n// IsValid returns true if all the flags set in e are known flags.\n")
+			fmt.Fprintf(buf, "func (e %v) IsValid() bool { return flagsValid(uint64(e), _%vNames) }\n", et.Go, et.Go)
+			continue
+		}
+		fmt.Fprintf(buf, "\nvar _%vNames = map[%v]string{\n", et.Go, et.Go)
+		for _, c := range uniq {
+			fmt.Fprintf(buf, "%v: %q,\n", c.Name, c.Name)
+		}
+		fmt.Fprintf(buf, "}\n\nfunc (e %v) String() string {\n\tif s, ok := _%vNames[e]; ok {\n\t\treturn s\n\t}\n\treturn fmt.Sprintf(\"%v(%%d)\", e)\n}\n", et.Go, et.Go, et.Go)
+		fmt.Fprintf(buf, "\n// IsValid returns true if e is a known %v.\n", et.Go)
+		fmt.Fprintf(buf, "func (e %v) IsValid() bool { _, ok := _%vNames[e]; return ok }\n", et.Go, et.Go)
+	}
+	buf.Close()
+	if err := goimports(fullpath); err != nil {
+		log.Printf("Failed to Goimports %q: %v", fullpath, err)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+// enumType describes how a C enum is bound to a Go type.
+type enumType struct {
+	C      string // the name of the C enum. Empty for sets of constants that are #defined
+	Go     string // the name of the Go type
+	Prefix string // prefix of the names of generated constants
+	Flags  bool   // the values are bit flags that may be combined
+}
+
+// enumTypes lists the enum types of package cu, in the order they are generated in.
+var enumTypes = []enumType{
+	{C: "CUarray_format", Go: "Format"},
+	{C: "CUfunc_cache", Go: "FuncCacheConfig", Prefix: "Prefer"},
+	{C: "CUctx_flags", Go: "ContextFlags", Flags: true},
+	{C: "CUlimit", Go: "Limit"},
+	{C: "CUsharedconfig", Go: "SharedConfig"},
+	{C: "CUmemAttach_flags", Go: "MemAttachFlags", Prefix: "Attach", Flags: true},
+	{C: "CUstream_flags", Go: "StreamFlags"},
+	{C: "CUmem_advise", Go: "MemAdvice"},
+	{C: "CUmemorytype", Go: "MemoryType"},
+	{C: "CUoccupancy_flags", Go: "OccupancyFlags"},
+	{C: "CUevent_flags", Go: "EventFlags", Flags: true},
+	{C: "CUaddress_mode", Go: "AddressMode"},
+	{C: "CUfilter_mode", Go: "FilterMode"},
+	{Go: "TexRefFlags", Flags: true},
+	{C: "CUgraphNodeType", Go: "GraphNodeType"},
+	{C: "CUdevice_attribute", Go: "DeviceAttribute"},
+	{C: "CUfunction_attribute", Go: "FunctionAttribute", Prefix: "Fn"},
+	{C: "CUpointer_attribute", Go: "PointerAttribute"},
+	{C: "CUdevice_P2PAttribute", Go: "P2PAttribute", Prefix: "P2P"},
+	{C: "CUjit_option", Go: "JITOptionType", Prefix: "JITOpt"},
+	{C: "CUjit_target", Go: "JITTargetOption", Prefix: "JITTarget"},
+	{C: "CUjit_fallback", Go: "JITFallbackOption", Prefix: "JIT"},
+	{C: "CUjit_cacheMode", Go: "JITCacheModeOption", Prefix: "JITCache"},
+	{C: "CUjitInputType", Go: "JITInputType", Prefix: "JITInput"},
+}
+
+// ignoredEnumerators are the enumerators that are not values: masks and counts.
+var ignoredEnumerators = map[string]struct{}{
+	"CU_CTX_SCHED_MASK":        {},
+	"CU_CTX_FLAGS_MASK":        {},
+	"CU_CTX_BLOCKING_SYNC":     {}, // deprecated alias of CU_CTX_SCHED_BLOCKING_SYNC
+	"CU_LIMIT_MAX":             {},
+	"CU_FUNC_ATTRIBUTE_MAX":    {},
+	"CU_DEVICE_ATTRIBUTE_MAX":  {},
+	"CU_JIT_NUM_OPTIONS":       {},
+	"CU_JIT_NUM_INPUT_TYPES":   {},
+	"CU_GRAPH_NODE_TYPE_COUNT": {},
+
+	// targets that are no longer supported
+	"CU_TARGET_COMPUTE_10": {},
+	"CU_TARGET_COMPUTE_11": {},
+	"CU_TARGET_COMPUTE_12": {},
+	"CU_TARGET_COMPUTE_13": {},
+}
+
+// names overrides the names given to generated constants, for the enumerators whose default names would clash with
+// other names of the package.
+var names = map[string]string{}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+)
+
+// constant is a constant of package cu that is bound to a C enumerator.
+type constant struct {
+	Name  string
+	C     string
+	Value int64
+	Known bool // Value is known (the enumerator was found in the header)
+}
+
+// boundConstants parses the package in dir, and returns the constants of the given types that are bound to a C
+// enumerator, by type, in order of declaration. The generated files are skipped. All the names that are declared at
+// the top level of the package are returned as well.
+func boundConstants(dir string, types map[string]struct{}) (consts map[string][]constant, declared map[string]struct{}, err error) {
+	filter := func(fi os.FileInfo) bool {
+		n := fi.Name()
+		return !strings.HasSuffix(n, "_test.go") && !strings.HasPrefix(n, "generated_enums")
+	}
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, filter, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	pkg, ok := pkgs["cu"]
+	if !ok {
+		return nil, nil, errNoPackage(dir)
+	}
+
+	consts = make(map[string][]constant)
+	declared = make(map[string]struct{})
+	// files are sorted by name for a stable output
+	var files []string
+	for name := range pkg.Files {
+		files = append(files, name)
+	}
+	sortStrings(files)
+	for _, name := range files {
+		for _, decl := range pkg.Files[name].Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv == nil {
+					declared[d.Name.Name] = struct{}{}
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						declared[s.Name.Name] = struct{}{}
+					case *ast.ValueSpec:
+						for _, n := range s.Names {
+							declared[n.Name] = struct{}{}
+						}
+						if d.Tok != token.CONST || len(s.Names) != 1 || len(s.Values) != 1 {
+							continue
+						}
+						typ, ok := s.Type.(*ast.Ident)
+						if !ok {
+							continue
+						}
+						if _, ok := types[typ.Name]; !ok {
+							continue
+						}
+						sel, ok := s.Values[0].(*ast.SelectorExpr)
+						if !ok {
+							continue
+						}
+						if x, ok := sel.X.(*ast.Ident); !ok || x.Name != "C" {
+							continue
+						}
+						consts[typ.Name] = append(consts[typ.Name], constant{Name: s.Names[0].Name, C: sel.Sel.Name})
+					}
+				}
+			}
+		}
+	}
+	return consts, declared, nil
+}
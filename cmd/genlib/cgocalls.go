@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"io"
+	"strings"
 )
 
 func filterCSigs(sigs []*CSignature) (retVal []*CSignature) {
@@ -15,7 +16,61 @@ func filterCSigs(sigs []*CSignature) (retVal []*CSignature) {
 	return
 }
 
-func cgoCall(buf io.Writer, sig *CSignature) {
+// copyKinds are the transfer kinds of the linear copies, whose bytes are counted (see CountTraffic).
+var copyKinds = map[string]string{
+	"Memcpy":          "Unified",
+	"MemcpyAsync":     "Unified",
+	"MemcpyPeer":      "PtoP",
+	"MemcpyPeerAsync": "PtoP",
+	"MemcpyHtoD":      "HtoD",
+	"MemcpyHtoDAsync": "HtoD",
+	"MemcpyDtoHAsync": "DtoH",
+	"MemcpyDtoD":      "DtoD",
+	"MemcpyDtoDAsync": "DtoD",
+}
+
+// cgoCall writes the cgo call of the function. The calls of asynchronous functions are followed by the check of
+// force-synchronous mode, and the bytes of copies are counted.
+func cgoCall(buf io.Writer, sig *GoSignature) {
+	stream := asyncStream(sig)
+	kind, isCopy := copyKinds[sig.Name]
+	if isCopy {
+		countedOn := stream
+		if countedOn == "" {
+			countedOn = "NoStream"
+		}
+		fmt.Fprintf(buf, "counted(%s, %s, ByteCount, ", kind, countedOn)
+	}
+	if stream != "" {
+		fmt.Fprintf(buf, "checkSync(%q, %s, ", sig.Name, stream)
+	}
+	cgoCallExpr(buf, sig.CSig)
+	if stream != "" {
+		buf.Write([]byte(")"))
+	}
+	if isCopy {
+		buf.Write([]byte(")"))
+	}
+	buf.Write([]byte("\n"))
+}
+
+// asyncStream returns the name of the stream parameter of an asynchronous function, or "" if it is not one.
+func asyncStream(sig *GoSignature) string {
+	if !strings.HasSuffix(sig.Name, "Async") {
+		return ""
+	}
+	if sig.Receiver != nil && sig.Receiver.Type == "Stream" {
+		return sig.Receiver.Name
+	}
+	for _, param := range sig.Params {
+		if param.Type == "Stream" {
+			return param.Name
+		}
+	}
+	return ""
+}
+
+func cgoCallExpr(buf io.Writer, sig *CSignature) {
 	fmt.Fprintf(buf, "result(C.%s(", sig.Name)
 	for i, param := range sig.Params {
 		if param.Type == "void" && !param.IsPtr {
@@ -31,7 +86,7 @@ func cgoCall(buf io.Writer, sig *CSignature) {
 			buf.Write([]byte(", "))
 		}
 	}
-	fmt.Fprintf(buf, "))\n")
+	fmt.Fprintf(buf, "))")
 }
 
 func go2CParam(buf io.Writer, goParam, cParam *Param) {
@@ -16,6 +16,9 @@ func generateAPI(buf io.Writer, sigs []*GoSignature) {
 
 func generateContextAPI(buf io.Writer, sigs []*GoSignature) {
 	for _, sig := range sigs {
+		if _, ok := ignoredContextMethods[sig.CSig.Name]; ok {
+			continue
+		}
 		writeContextSig(buf, sig)
 		fmt.Fprintf(buf, "{\n")
 		writeContextMethodBody(buf, sig)
@@ -47,7 +50,7 @@ func writeFunctionBody(buf io.Writer, sig *GoSignature) {
 		buf.Write([]byte("err = "))
 	}
 
-	cgoCall(buf, sig.CSig)
+	cgoCall(buf, sig)
 
 	if len(sig.RetVals) > 0 {
 		for _, ret := range sig.RetVals {
@@ -85,11 +88,11 @@ func writeContextMethodBody(buf io.Writer, sig *GoSignature) {
 	}
 
 	fmt.Fprintf(buf, "f := func() error { return ")
-	cgoCall(buf, sig.CSig)
+	cgoCall(buf, sig)
 	fmt.Fprintf(buf, "}\n")
 
 	if len(sig.RetVals) == 0 {
-		buf.Write([]byte("ctx.err = ctx.Do(f) "))
+		buf.Write([]byte("ctx.setErr(ctx.Do(f)) "))
 	} else {
 		fmt.Fprintf(buf, "if err = ctx.Do(f); err != nil {\n err = errors.Wrap(err, \"%s\")\n }\n", sig.Name)
 
@@ -11,9 +11,15 @@ var ignoredFunctions = map[string]struct{}{
 	"cuDeviceGetName":    empty, // wat?
 
 	// context stuff
-	"cuCtxCreate":              empty,
-	"cuCtxDestroy":             empty,
-	"cuDevicePrimaryCtxRetain": empty,
+	"cuCtxCreate":               empty,
+	"cuCtxDestroy":              empty,
+	"cuDevicePrimaryCtxRetain":  empty,
+	"cuDevicePrimaryCtxRelease": empty, // cucontext.go
+	"cuDevicePrimaryCtxReset":   empty, // cucontext.go
+	"cuCtxPushCurrent":          empty, // currentctx.go
+	"cuCtxPopCurrent":           empty, // currentctx.go
+	"cuCtxSetCurrent":           empty, // currentctx.go
+	"cuCtxGetCurrent":           empty, // currentctx.go
 
 	// pointer/memory/unified addressing stuff
 	"cuPointerGetAttribute":   empty,
@@ -23,9 +29,15 @@ var ignoredFunctions = map[string]struct{}{
 	"cuMemRangeGetAttributes": empty,
 	"cuPointerSetAttribute":   empty,
 	"cuPointerGetAttributes":  empty,
-	"cuMemHostRegister":       empty,
-	"cuMemHostUnregister":     empty,
+	"cuMemHostRegister":       empty, // hostregister.go
+	"cuMemHostUnregister":     empty, // hostregister.go
 	"cuMemGetAddressRange":    empty,
+	"cuMemAlloc":              empty, // memory.go
+	"cuMemFree":               empty, // memory.go
+	"cuMemcpyDtoH":            empty, // uninit.go
+	"cuMemAllocAsync":         empty, // memorypool.go
+	"cuMemAllocFromPoolAsync": empty, // memorypool.go
+	"cuMemFreeAsync":          empty, // memorypool.go
 
 	// dealing with voids and strings...
 	"cuLaunchKernel":      empty,
@@ -33,6 +45,7 @@ var ignoredFunctions = map[string]struct{}{
 	"cuModuleLoadData":    empty, // dealing with strings
 	"cuModuleGetFunction": empty, // dealing with strings
 	"cuModuleGetGlobal":   empty, // dealing with strings
+	"cuModuleUnload":      empty, // module.go, tracks the loaded modules for Report
 
 	// event stuff
 	"cuEventCreate":  empty,
@@ -43,6 +56,18 @@ var ignoredFunctions = map[string]struct{}{
 	"cuStreamCreateWithPriority": empty,
 	"cuStreamDestroy":            empty,
 
+	// graph stuff
+	"cuStreamBeginCapture":              empty, // graph.go
+	"cuStreamEndCapture":                empty, // graph.go
+	"cuStreamIsCapturing":               empty, // graph.go
+	"cuThreadExchangeStreamCaptureMode": empty, // graph.go
+	"cuGraphDestroy":                    empty, // graph.go
+	"cuGraphGetNodes":                   empty, // graph.go
+	"cuGraphNodeGetType":                empty, // graph.go
+	"cuGraphInstantiate":                empty, // graph.go
+	"cuGraphLaunch":                     empty, // graph.go
+	"cuGraphExecDestroy":                empty, // graph.go
+
 	// arrays
 	"cuArrayCreate":   empty,
 	"cuArray3DCreate": empty,
@@ -50,12 +75,14 @@ var ignoredFunctions = map[string]struct{}{
 	// occupany stuff
 	"cuOccupancyMaxActiveBlocksPerMultiprocessor":          empty,
 	"cuOccupancyMaxActiveBlocksPerMultiprocessorWithFlags": empty,
+	"cuOccupancyMaxPotentialBlockSize":                     empty, // occupancy.go
+	"cuOccupancyMaxPotentialBlockSizeWithFlags":            empty, // occupancy.go
 
 	/* SUPPORT PLANNED BUT NOT YET DONE */
 	// memory stuff
 	"cuMemAllocHost":            empty, // use C.malloc
 	"cuMemHostAlloc":            empty, // use C.malloc, be sure to allocate page-aligned ones
-	"cuMemHostGetDevicePointer": empty,
+	"cuMemHostGetDevicePointer": empty, // hostregister.go
 	"cuMemHostGetFlags":         empty,
 
 	// module/JIT stuff
@@ -94,9 +121,7 @@ var ignoredFunctions = map[string]struct{}{
 	"cuTexRefGetMipmappedArray":   empty,
 
 	// Function stuff
-	"cuOccupancyMaxPotentialBlockSize":          empty,
-	"cuOccupancyMaxPotentialBlockSizeWithFlags": empty,
-	"cuStreamAddCallback":                       empty, // really only valid for C API calls in C programs
+	"cuStreamAddCallback": empty, // really only valid for C API calls in C programs
 
 	// Graphics Interop
 	"cuGraphicsUnregisterResource":              empty,
@@ -298,6 +323,17 @@ var fnNameMap = map[string]string{
 	"cuCtxDisablePeerAccess":  "CUContext DisablePeerAccess",
 }
 
+// context methods that are manually written (in hotpath.go), because they are on the hot path and must not allocate
+var ignoredContextMethods = map[string]struct{}{
+	"cuMemcpy":          empty,
+	"cuMemcpyHtoD":      empty,
+	"cuMemcpyDtoH":      empty,
+	"cuMemcpyDtoD":      empty,
+	"cuMemcpyHtoDAsync": empty,
+	"cuMemcpyDtoHAsync": empty,
+	"cuMemcpyDtoDAsync": empty,
+}
+
 // list of functions that returns stuff but do not have "Get" in the name
 var returns = []string{
 	"cuDeviceCanAccessPeer",
@@ -0,0 +1,200 @@
+// gentracing generates the TracingImplementation in package cublas.
+//
+// It parses the BLAS interfaces declared by gonum (gonum.org/v1/gonum/blas), and writes a method for each of them
+// that times the call and forwards it to the traced implementation.
+//
+// Run it from the root of the repository:
+//
+//	go run ./cmd/gentracing
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	gonumDir = flag.String("gonum", "", "the directory of the gonum module. If empty, it is found with `go list`")
+	target   = flag.String("o", "blas/tracing_generated.go", "the file to write")
+)
+
+// interfaces are the interfaces to generate the methods for, in order.
+var interfaces = []string{"Float32", "Float64", "Complex64", "Complex128"}
+
+const header = `// Code generated by gentracing. DO NOT EDIT.
+
+package cublas
+
+import "gonum.org/v1/gonum/blas"
+`
+
+func main() {
+	flag.Parse()
+	log.SetFlags(0)
+
+	dir := *gonumDir
+	if dir == "" {
+		out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", "gonum.org/v1/gonum").Output()
+		if err != nil {
+			log.Fatalf("Unable to find gonum: %v", err)
+		}
+		dir = strings.TrimSpace(string(out))
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filepath.Join(dir, "blas", "blas.go"), nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ifaces := make(map[string]*ast.InterfaceType)
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if it, ok := ts.Type.(*ast.InterfaceType); ok {
+				ifaces[ts.Name.Name] = it
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	for _, name := range interfaces {
+		for _, m := range methods(ifaces, name) {
+			writeMethod(&buf, m)
+		}
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("Unable to format generated code: %v\n%s", err, buf.Bytes())
+	}
+	if err = ioutil.WriteFile(*target, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type param struct {
+	name, typ string
+}
+
+type method struct {
+	name    string
+	params  []param
+	results []string
+}
+
+// methods returns the methods of the named interface, with the embedded interfaces expanded.
+func methods(ifaces map[string]*ast.InterfaceType, name string) []method {
+	it, ok := ifaces[name]
+	if !ok {
+		log.Fatalf("Interface %v not found", name)
+	}
+	var retVal []method
+	for _, field := range it.Methods.List {
+		ft, ok := field.Type.(*ast.FuncType)
+		if !ok {
+			// embedded interface
+			retVal = append(retVal, methods(ifaces, field.Type.(*ast.Ident).Name)...)
+			continue
+		}
+		m := method{name: field.Names[0].Name}
+		for i, p := range ft.Params.List {
+			typ := typeString(p.Type)
+			if len(p.Names) == 0 {
+				m.params = append(m.params, param{fmt.Sprintf("p%d", i), typ})
+			}
+			for _, n := range p.Names {
+				m.params = append(m.params, param{n.Name, typ})
+			}
+		}
+		if ft.Results != nil {
+			for _, r := range ft.Results.List {
+				n := len(r.Names)
+				if n == 0 {
+					n = 1
+				}
+				for i := 0; i < n; i++ {
+					m.results = append(m.results, typeString(r.Type))
+				}
+			}
+		}
+		retVal = append(retVal, m)
+	}
+	return retVal
+}
+
+// typeString returns the type as it is written in package cublas.
+func typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(t.Name) {
+			return "blas." + t.Name
+		}
+		return t.Name
+	case *ast.ArrayType:
+		return "[]" + typeString(t.Elt)
+	}
+	log.Fatalf("Unhandled type %T", expr)
+	panic("unreachable")
+}
+
+// isDim returns true if the parameter describes the shape of the operands.
+// Increments and leading dimensions are not considered to be part of the shape.
+func isDim(p param) bool {
+	return p.typ == "int" && !strings.HasPrefix(p.name, "inc") && !strings.HasPrefix(p.name, "ld")
+}
+
+func writeMethod(buf *bytes.Buffer, m method) {
+	var params, args, dims []string
+	for _, p := range m.params {
+		params = append(params, p.name+" "+p.typ)
+		args = append(args, p.name)
+		if isDim(p) {
+			dims = append(dims, fmt.Sprintf("{%q, %s}", p.name, p.name))
+		}
+	}
+	var rets []string
+	for i := range m.results {
+		rets = append(rets, fmt.Sprintf("r%d", i))
+	}
+
+	fmt.Fprintf(buf, "\n// %s traces a call to the %s method of the traced implementation.\n", m.name, m.name)
+	fmt.Fprintf(buf, "func (impl *TracingImplementation) %s(%s)", m.name, strings.Join(params, ", "))
+	switch len(m.results) {
+	case 0:
+		buf.WriteString(" {\n")
+	case 1:
+		fmt.Fprintf(buf, " %s {\n", m.results[0])
+	default:
+		fmt.Fprintf(buf, " (%s) {\n", strings.Join(m.results, ", "))
+	}
+
+	buf.WriteString("\ttr := impl.begin()\n\t")
+	if len(rets) > 0 {
+		fmt.Fprintf(buf, "%s := ", strings.Join(rets, ", "))
+	}
+	fmt.Fprintf(buf, "impl.Interface.%s(%s)\n", m.name, strings.Join(args, ", "))
+	dimList := "nil"
+	if len(dims) > 0 {
+		dimList = fmt.Sprintf("[]Dim{%s}", strings.Join(dims, ", "))
+	}
+	fmt.Fprintf(buf, "\timpl.end(tr, %q, %s)\n", m.name, dimList)
+	if len(rets) > 0 {
+		fmt.Fprintf(buf, "\treturn %s\n", strings.Join(rets, ", "))
+	}
+	buf.WriteString("}\n")
+}
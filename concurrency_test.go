@@ -0,0 +1,258 @@
+package cu
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"unsafe"
+)
+
+// The tests in this file exercise the types documented as safe for concurrent use from many goroutines at once.
+// They are meant to be run with the race detector:
+//
+//	go test -race -run Concurrent
+
+const stressGoroutines = 16
+
+func stressIterations() int {
+	if testing.Short() {
+		return 100
+	}
+	return 1000
+}
+
+// stress runs fn from many goroutines, and waits for them to be done.
+func stress(fn func(g int)) {
+	var wg sync.WaitGroup
+	for g := 0; g < stressGoroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			fn(g)
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestMemPool_Concurrent(t *testing.T) {
+	var allocated, freed int
+	p := newFakePool(&allocated, &freed) // the counters are only updated with the lock of the pool held
+
+	// owners records the goroutine holding each block, to catch blocks handed out twice
+	var owners sync.Map
+	n := stressIterations()
+	stress(func(g int) {
+		var held []DevicePtr
+		for i := 0; i < n; i++ {
+			if len(held) > 0 && (i%3 == 0 || len(held) > 8) {
+				ptr := held[len(held)-1]
+				held = held[:len(held)-1]
+				owners.Delete(ptr)
+				if err := p.Free(ptr); err != nil {
+					t.Error(err)
+					return
+				}
+				continue
+			}
+			ptr, err := p.Alloc(int64(1 + (g*n+i)%2048))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if owner, loaded := owners.LoadOrStore(ptr, g); loaded {
+				t.Errorf("%v was handed to goroutine %d while held by goroutine %v", ptr, g, owner)
+				return
+			}
+			held = append(held, ptr)
+		}
+		for _, ptr := range held {
+			owners.Delete(ptr)
+			if err := p.Free(ptr); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+
+	s := p.Stats()
+	if s.InUse != 0 || s.Allocs != s.Frees || s.Hits+s.Misses != s.Allocs || s.Misses != allocated {
+		t.Errorf("Inconsistent statistics after the stress: %+v, %d blocks allocated", s, allocated)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if allocated != freed {
+		t.Errorf("%d blocks allocated, %d freed", allocated, freed)
+	}
+}
+
+func TestCtx_Concurrent(t *testing.T) {
+	_, cuctx, err := testSetup()
+	if err != nil {
+		if err.Error() == "NoDevice" {
+			t.Skip("NoDevice")
+		}
+		t.Fatal(err)
+	}
+	defer testTeardown(cuctx, Module{})
+	ctx := NewContext(Device(0), SchedAuto)
+	defer ctx.Close()
+
+	n := stressIterations() / 10
+	stress(func(g int) {
+		src := make([]int32, 256)
+		dst := make([]int32, 256)
+		size := int64(len(src) * 4)
+		for i := 0; i < n; i++ {
+			for j := range src {
+				src[j] = int32(g*n + i + j)
+			}
+			mem, err := ctx.MemAlloc(size)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			ctx.MemcpyHtoD(mem, unsafe.Pointer(&src[0]), size)
+			ctx.MemcpyDtoH(unsafe.Pointer(&dst[0]), mem, size)
+			ctx.MemFree(mem)
+			_ = ctx.Error() // read concurrently with the writes of the other goroutines
+			for j := range dst {
+				if dst[j] != src[j] {
+					t.Errorf("goroutine %d, iteration %d: read %d at %d, expected %d", g, i, dst[j], j, src[j])
+					return
+				}
+			}
+		}
+	})
+}
+
+func TestCtx_CloseConcurrent(t *testing.T) {
+	_, cuctx, err := testSetup()
+	if err != nil {
+		if err.Error() == "NoDevice" {
+			t.Skip("NoDevice")
+		}
+		t.Fatal(err)
+	}
+	defer testTeardown(cuctx, Module{})
+	ctx := NewContext(Device(0), SchedAuto)
+
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for g := 0; g < stressGoroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			for i := 0; i < stressIterations(); i++ {
+				if err := ctx.Do(func() error { return nil }); err != nil {
+					return // closed
+				}
+			}
+		}()
+	}
+	close(start)
+	if err := ctx.Close(); err != nil {
+		t.Error(err)
+	}
+	wg.Wait()
+	if err := ctx.Do(func() error { return nil }); err == nil {
+		t.Error("Expected Do to fail once the context is closed")
+	}
+}
+
+func TestBatchedContext_Concurrent(t *testing.T) {
+	dev, cuctx, err := testSetup()
+	if err != nil {
+		if err.Error() == "NoDevice" {
+			t.Skip("NoDevice")
+		}
+		t.Fatal(err)
+	}
+	defer testTeardown(cuctx, Module{})
+	bctx := NewBatchedContext(newContext(cuctx), dev)
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	var owners sync.Map
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		n := stressIterations() / 10
+		stress(func(g int) {
+			for i := 0; i < n; i++ {
+				mem, err := bctx.MemAlloc(1024)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if owner, loaded := owners.LoadOrStore(mem, g); loaded {
+					t.Errorf("%v was returned to goroutine %d and goroutine %v", mem, g, owner)
+					return
+				}
+				_ = bctx.Errors()
+				owners.Delete(mem)
+				bctx.MemFree(mem)
+			}
+		})
+		bctx.workAvailable <- struct{}{}
+	}()
+
+	for {
+		select {
+		case <-bctx.WorkAvailable():
+			bctx.DoWork()
+		case <-done:
+			bctx.DoWork()
+			bctx.Cleanup()
+			if err := bctx.Errors(); err != nil {
+				t.Error(err)
+			}
+			return
+		}
+	}
+}
+
+func TestBatchedContext_ConcurrentBlocking(t *testing.T) {
+	bctx := NewBatchedContext(nil, 0)
+
+	// the worker stands in for DoWork: it returns the size of each allocation as its pointer, so that each caller can
+	// check that it received the return value of its own call
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-bctx.workAvailable:
+			case <-done:
+				return
+			}
+			runtime.Gosched() // let other calls be queued behind the first
+			for drained := false; !drained; {
+				select {
+				case c := <-bctx.work:
+					if c.blocking {
+						bctx.retVal <- DevicePtr(c.fnargs.size)
+					}
+				default:
+					drained = true
+				}
+			}
+		}
+	}()
+
+	stress(func(g int) {
+		for i := 0; i < stressIterations(); i++ {
+			size := int64(g*stressIterations() + i + 1)
+			ptr, err := bctx.MemAlloc(size)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if ptr != DevicePtr(size) {
+				t.Errorf("Goroutine %d expected the pointer of its allocation of %d bytes. Got that of %d bytes", g, size, uintptr(ptr))
+				return
+			}
+		}
+	})
+}
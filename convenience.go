@@ -48,3 +48,13 @@ func (d Device) ComputeCapability() (major, minor int, err error) {
 	minor = attrs[1]
 	return
 }
+
+// CopyEngines returns the number of asynchronous copy engines of the device.
+// A device with two or more copy engines can perform host to device and device to host copies concurrently.
+func (d Device) CopyEngines() (int, error) {
+	n, err := d.Attribute(AsyncEngineCount)
+	if err != nil {
+		return 0, errors.Wrapf(err, "Failed to get CopyEngines")
+	}
+	return n, nil
+}
@@ -0,0 +1,90 @@
+package cu
+
+import "github.com/pkg/errors"
+
+// CopyStreams are streams dedicated to each transfer direction.
+//
+// Issuing uploads and downloads on the same stream serializes them, even when the device has a separate copy engine for each direction.
+// CopyStreams assigns a stream per copy engine direction so that they may overlap. On a device with a single copy engine,
+// HtoD and DtoH share a stream, as the hardware would serialize them anyway.
+type CopyStreams struct {
+	HtoD Stream
+	DtoH Stream
+	DtoD Stream
+
+	engines int
+}
+
+// MakeCopyStreams creates the streams dedicated to each transfer direction for the given device.
+// The streams are created in the current context, which is expected to be a context on d.
+func MakeCopyStreams(d Device) (cs CopyStreams, err error) {
+	if cs.engines, err = d.CopyEngines(); err != nil {
+		return cs, errors.Wrap(err, "MakeCopyStreams")
+	}
+	if cs.HtoD, err = MakeStream(NonBlocking); err != nil {
+		return cs, errors.Wrap(err, "MakeCopyStreams")
+	}
+	if cs.engines < 2 {
+		cs.DtoH = cs.HtoD
+	} else if cs.DtoH, err = MakeStream(NonBlocking); err != nil {
+		cs.Destroy()
+		return cs, errors.Wrap(err, "MakeCopyStreams")
+	}
+	if cs.DtoD, err = MakeStream(NonBlocking); err != nil {
+		cs.Destroy()
+		return cs, errors.Wrap(err, "MakeCopyStreams")
+	}
+	return cs, nil
+}
+
+// CopyEngines returns the number of copy engines of the device the streams were made for.
+func (cs *CopyStreams) CopyEngines() int { return cs.engines }
+
+// For returns the stream dedicated to the given transfer direction.
+func (cs *CopyStreams) For(kind TransferKind) Stream {
+	switch kind {
+	case HtoD:
+		return cs.HtoD
+	case DtoH:
+		return cs.DtoH
+	default:
+		return cs.DtoD
+	}
+}
+
+// Synchronize waits for all the copies issued on the streams to complete.
+func (cs *CopyStreams) Synchronize() error {
+	for _, s := range cs.streams() {
+		if err := s.Synchronize(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Destroy destroys the streams.
+func (cs *CopyStreams) Destroy() error {
+	var err error
+	for _, s := range cs.streams() {
+		if e := s.Destroy(); e != nil && err == nil {
+			err = e
+		}
+	}
+	*cs = CopyStreams{}
+	return err
+}
+
+// streams returns the distinct streams that have been created.
+func (cs *CopyStreams) streams() []*Stream {
+	var retVal []*Stream
+	if cs.HtoD != NoStream {
+		retVal = append(retVal, &cs.HtoD)
+	}
+	if cs.DtoH != NoStream && cs.DtoH != cs.HtoD {
+		retVal = append(retVal, &cs.DtoH)
+	}
+	if cs.DtoD != NoStream {
+		retVal = append(retVal, &cs.DtoD)
+	}
+	return retVal
+}
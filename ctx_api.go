@@ -22,7 +22,7 @@ func (ctx *Ctx) Synchronize() {
 	f := func() error {
 		return result(C.cuCtxSynchronize())
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) SetLimit(limit Limit, value int64) {
@@ -31,7 +31,7 @@ func (ctx *Ctx) SetLimit(limit Limit, value int64) {
 	f := func() error {
 		return result(C.cuCtxSetLimit(Climit, Cvalue))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) Limits(limit Limit) (pvalue int64, err error) {
@@ -64,7 +64,7 @@ func (ctx *Ctx) SetCurrentCacheConfig(config FuncCacheConfig) {
 	f := func() error {
 		return result(C.cuCtxSetCacheConfig(Cconfig))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) SharedMemConfig() (pConfig SharedConfig, err error) {
@@ -84,7 +84,7 @@ func (ctx *Ctx) SetSharedMemConfig(config SharedConfig) {
 	f := func() error {
 		return result(C.cuCtxSetSharedMemConfig(Cconfig))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) StreamPriorityRange() (leastPriority int, greatestPriority int, err error) {
@@ -101,14 +101,6 @@ func (ctx *Ctx) StreamPriorityRange() (leastPriority int, greatestPriority int,
 	return
 }
 
-func (ctx *Ctx) Unload(hmod Module) {
-	Chmod := hmod.c()
-	f := func() error {
-		return result(C.cuModuleUnload(Chmod))
-	}
-	ctx.err = ctx.Do(f)
-}
-
 func (ctx *Ctx) MemInfo() (free int64, total int64, err error) {
 	var Cfree C.size_t
 	var Ctotal C.size_t
@@ -123,19 +115,6 @@ func (ctx *Ctx) MemInfo() (free int64, total int64, err error) {
 	return
 }
 
-func (ctx *Ctx) MemAlloc(bytesize int64) (dptr DevicePtr, err error) {
-	Cbytesize := C.size_t(bytesize)
-	var Cdptr C.CUdeviceptr
-	f := func() error {
-		return result(C.cuMemAlloc(&Cdptr, Cbytesize))
-	}
-	if err = ctx.Do(f); err != nil {
-		err = errors.Wrap(err, "MemAlloc")
-	}
-	dptr = DevicePtr(Cdptr)
-	return
-}
-
 func (ctx *Ctx) MemAllocPitch(WidthInBytes int64, Height int64, ElementSizeBytes uint) (dptr DevicePtr, pPitch int64, err error) {
 	CWidthInBytes := C.size_t(WidthInBytes)
 	CHeight := C.size_t(Height)
@@ -153,20 +132,12 @@ func (ctx *Ctx) MemAllocPitch(WidthInBytes int64, Height int64, ElementSizeBytes
 	return
 }
 
-func (ctx *Ctx) MemFree(dptr DevicePtr) {
-	Cdptr := C.CUdeviceptr(dptr)
-	f := func() error {
-		return result(C.cuMemFree(Cdptr))
-	}
-	ctx.err = ctx.Do(f)
-}
-
 func (ctx *Ctx) MemFreeHost(p unsafe.Pointer) {
 	Cp := p
 	f := func() error {
 		return result(C.cuMemFreeHost(Cp))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemAllocManaged(bytesize int64, flags MemAttachFlags) (dptr DevicePtr, err error) {
@@ -183,16 +154,6 @@ func (ctx *Ctx) MemAllocManaged(bytesize int64, flags MemAttachFlags) (dptr Devi
 	return
 }
 
-func (ctx *Ctx) Memcpy(dst DevicePtr, src DevicePtr, ByteCount int64) {
-	Cdst := C.CUdeviceptr(dst)
-	Csrc := C.CUdeviceptr(src)
-	CByteCount := C.size_t(ByteCount)
-	f := func() error {
-		return result(C.cuMemcpy(Cdst, Csrc, CByteCount))
-	}
-	ctx.err = ctx.Do(f)
-}
-
 func (ctx *Ctx) MemcpyPeer(dstDevice DevicePtr, dstContext CUContext, srcDevice DevicePtr, srcContext CUContext, ByteCount int64) {
 	CdstDevice := C.CUdeviceptr(dstDevice)
 	CdstContext := dstContext.c()
@@ -200,39 +161,9 @@ func (ctx *Ctx) MemcpyPeer(dstDevice DevicePtr, dstContext CUContext, srcDevice
 	CsrcContext := srcContext.c()
 	CByteCount := C.size_t(ByteCount)
 	f := func() error {
-		return result(C.cuMemcpyPeer(CdstDevice, CdstContext, CsrcDevice, CsrcContext, CByteCount))
-	}
-	ctx.err = ctx.Do(f)
-}
-
-func (ctx *Ctx) MemcpyHtoD(dstDevice DevicePtr, srcHost unsafe.Pointer, ByteCount int64) {
-	CdstDevice := C.CUdeviceptr(dstDevice)
-	CsrcHost := srcHost
-	CByteCount := C.size_t(ByteCount)
-	f := func() error {
-		return result(C.cuMemcpyHtoD(CdstDevice, CsrcHost, CByteCount))
+		return counted(PtoP, NoStream, ByteCount, result(C.cuMemcpyPeer(CdstDevice, CdstContext, CsrcDevice, CsrcContext, CByteCount)))
 	}
-	ctx.err = ctx.Do(f)
-}
-
-func (ctx *Ctx) MemcpyDtoH(dstHost unsafe.Pointer, srcDevice DevicePtr, ByteCount int64) {
-	CdstHost := dstHost
-	CsrcDevice := C.CUdeviceptr(srcDevice)
-	CByteCount := C.size_t(ByteCount)
-	f := func() error {
-		return result(C.cuMemcpyDtoH(CdstHost, CsrcDevice, CByteCount))
-	}
-	ctx.err = ctx.Do(f)
-}
-
-func (ctx *Ctx) MemcpyDtoD(dstDevice DevicePtr, srcDevice DevicePtr, ByteCount int64) {
-	CdstDevice := C.CUdeviceptr(dstDevice)
-	CsrcDevice := C.CUdeviceptr(srcDevice)
-	CByteCount := C.size_t(ByteCount)
-	f := func() error {
-		return result(C.cuMemcpyDtoD(CdstDevice, CsrcDevice, CByteCount))
-	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemcpyDtoA(dstArray Array, dstOffset int64, srcDevice DevicePtr, ByteCount int64) {
@@ -243,7 +174,7 @@ func (ctx *Ctx) MemcpyDtoA(dstArray Array, dstOffset int64, srcDevice DevicePtr,
 	f := func() error {
 		return result(C.cuMemcpyDtoA(CdstArray, CdstOffset, CsrcDevice, CByteCount))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemcpyAtoD(dstDevice DevicePtr, srcArray Array, srcOffset int64, ByteCount int64) {
@@ -254,7 +185,7 @@ func (ctx *Ctx) MemcpyAtoD(dstDevice DevicePtr, srcArray Array, srcOffset int64,
 	f := func() error {
 		return result(C.cuMemcpyAtoD(CdstDevice, CsrcArray, CsrcOffset, CByteCount))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemcpyHtoA(dstArray Array, dstOffset int64, srcHost unsafe.Pointer, ByteCount int64) {
@@ -265,7 +196,7 @@ func (ctx *Ctx) MemcpyHtoA(dstArray Array, dstOffset int64, srcHost unsafe.Point
 	f := func() error {
 		return result(C.cuMemcpyHtoA(CdstArray, CdstOffset, CsrcHost, CByteCount))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemcpyAtoH(dstHost unsafe.Pointer, srcArray Array, srcOffset int64, ByteCount int64) {
@@ -276,7 +207,7 @@ func (ctx *Ctx) MemcpyAtoH(dstHost unsafe.Pointer, srcArray Array, srcOffset int
 	f := func() error {
 		return result(C.cuMemcpyAtoH(CdstHost, CsrcArray, CsrcOffset, CByteCount))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemcpyAtoA(dstArray Array, dstOffset int64, srcArray Array, srcOffset int64, ByteCount int64) {
@@ -288,7 +219,7 @@ func (ctx *Ctx) MemcpyAtoA(dstArray Array, dstOffset int64, srcArray Array, srcO
 	f := func() error {
 		return result(C.cuMemcpyAtoA(CdstArray, CdstOffset, CsrcArray, CsrcOffset, CByteCount))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) Memcpy2D(pCopy Memcpy2dParam) {
@@ -296,7 +227,7 @@ func (ctx *Ctx) Memcpy2D(pCopy Memcpy2dParam) {
 	f := func() error {
 		return result(C.cuMemcpy2D(CpCopy))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) Memcpy2DUnaligned(pCopy Memcpy2dParam) {
@@ -304,7 +235,7 @@ func (ctx *Ctx) Memcpy2DUnaligned(pCopy Memcpy2dParam) {
 	f := func() error {
 		return result(C.cuMemcpy2DUnaligned(CpCopy))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) Memcpy3D(pCopy Memcpy3dParam) {
@@ -312,7 +243,7 @@ func (ctx *Ctx) Memcpy3D(pCopy Memcpy3dParam) {
 	f := func() error {
 		return result(C.cuMemcpy3D(CpCopy))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) Memcpy3DPeer(pCopy Memcpy3dPeerParam) {
@@ -320,7 +251,7 @@ func (ctx *Ctx) Memcpy3DPeer(pCopy Memcpy3dPeerParam) {
 	f := func() error {
 		return result(C.cuMemcpy3DPeer(CpCopy))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemcpyAsync(dst DevicePtr, src DevicePtr, ByteCount int64, hStream Stream) {
@@ -329,9 +260,9 @@ func (ctx *Ctx) MemcpyAsync(dst DevicePtr, src DevicePtr, ByteCount int64, hStre
 	CByteCount := C.size_t(ByteCount)
 	ChStream := hStream.c()
 	f := func() error {
-		return result(C.cuMemcpyAsync(Cdst, Csrc, CByteCount, ChStream))
+		return counted(Unified, hStream, ByteCount, checkSync("MemcpyAsync", hStream, result(C.cuMemcpyAsync(Cdst, Csrc, CByteCount, ChStream))))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemcpyPeerAsync(dstDevice DevicePtr, dstContext CUContext, srcDevice DevicePtr, srcContext CUContext, ByteCount int64, hStream Stream) {
@@ -342,42 +273,9 @@ func (ctx *Ctx) MemcpyPeerAsync(dstDevice DevicePtr, dstContext CUContext, srcDe
 	CByteCount := C.size_t(ByteCount)
 	ChStream := hStream.c()
 	f := func() error {
-		return result(C.cuMemcpyPeerAsync(CdstDevice, CdstContext, CsrcDevice, CsrcContext, CByteCount, ChStream))
-	}
-	ctx.err = ctx.Do(f)
-}
-
-func (ctx *Ctx) MemcpyHtoDAsync(dstDevice DevicePtr, srcHost unsafe.Pointer, ByteCount int64, hStream Stream) {
-	CdstDevice := C.CUdeviceptr(dstDevice)
-	CsrcHost := srcHost
-	CByteCount := C.size_t(ByteCount)
-	ChStream := hStream.c()
-	f := func() error {
-		return result(C.cuMemcpyHtoDAsync(CdstDevice, CsrcHost, CByteCount, ChStream))
-	}
-	ctx.err = ctx.Do(f)
-}
-
-func (ctx *Ctx) MemcpyDtoHAsync(dstHost unsafe.Pointer, srcDevice DevicePtr, ByteCount int64, hStream Stream) {
-	CdstHost := dstHost
-	CsrcDevice := C.CUdeviceptr(srcDevice)
-	CByteCount := C.size_t(ByteCount)
-	ChStream := hStream.c()
-	f := func() error {
-		return result(C.cuMemcpyDtoHAsync(CdstHost, CsrcDevice, CByteCount, ChStream))
-	}
-	ctx.err = ctx.Do(f)
-}
-
-func (ctx *Ctx) MemcpyDtoDAsync(dstDevice DevicePtr, srcDevice DevicePtr, ByteCount int64, hStream Stream) {
-	CdstDevice := C.CUdeviceptr(dstDevice)
-	CsrcDevice := C.CUdeviceptr(srcDevice)
-	CByteCount := C.size_t(ByteCount)
-	ChStream := hStream.c()
-	f := func() error {
-		return result(C.cuMemcpyDtoDAsync(CdstDevice, CsrcDevice, CByteCount, ChStream))
+		return counted(PtoP, hStream, ByteCount, checkSync("MemcpyPeerAsync", hStream, result(C.cuMemcpyPeerAsync(CdstDevice, CdstContext, CsrcDevice, CsrcContext, CByteCount, ChStream))))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemcpyHtoAAsync(dstArray Array, dstOffset int64, srcHost unsafe.Pointer, ByteCount int64, hStream Stream) {
@@ -387,9 +285,9 @@ func (ctx *Ctx) MemcpyHtoAAsync(dstArray Array, dstOffset int64, srcHost unsafe.
 	CByteCount := C.size_t(ByteCount)
 	ChStream := hStream.c()
 	f := func() error {
-		return result(C.cuMemcpyHtoAAsync(CdstArray, CdstOffset, CsrcHost, CByteCount, ChStream))
+		return checkSync("MemcpyHtoAAsync", hStream, result(C.cuMemcpyHtoAAsync(CdstArray, CdstOffset, CsrcHost, CByteCount, ChStream)))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemcpyAtoHAsync(dstHost unsafe.Pointer, srcArray Array, srcOffset int64, ByteCount int64, hStream Stream) {
@@ -399,36 +297,36 @@ func (ctx *Ctx) MemcpyAtoHAsync(dstHost unsafe.Pointer, srcArray Array, srcOffse
 	CByteCount := C.size_t(ByteCount)
 	ChStream := hStream.c()
 	f := func() error {
-		return result(C.cuMemcpyAtoHAsync(CdstHost, CsrcArray, CsrcOffset, CByteCount, ChStream))
+		return checkSync("MemcpyAtoHAsync", hStream, result(C.cuMemcpyAtoHAsync(CdstHost, CsrcArray, CsrcOffset, CByteCount, ChStream)))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) Memcpy2DAsync(pCopy Memcpy2dParam, hStream Stream) {
 	CpCopy := pCopy.c()
 	ChStream := hStream.c()
 	f := func() error {
-		return result(C.cuMemcpy2DAsync(CpCopy, ChStream))
+		return checkSync("Memcpy2DAsync", hStream, result(C.cuMemcpy2DAsync(CpCopy, ChStream)))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) Memcpy3DAsync(pCopy Memcpy3dParam, hStream Stream) {
 	CpCopy := pCopy.c()
 	ChStream := hStream.c()
 	f := func() error {
-		return result(C.cuMemcpy3DAsync(CpCopy, ChStream))
+		return checkSync("Memcpy3DAsync", hStream, result(C.cuMemcpy3DAsync(CpCopy, ChStream)))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) Memcpy3DPeerAsync(pCopy Memcpy3dPeerParam, hStream Stream) {
 	CpCopy := pCopy.c()
 	ChStream := hStream.c()
 	f := func() error {
-		return result(C.cuMemcpy3DPeerAsync(CpCopy, ChStream))
+		return checkSync("Memcpy3DPeerAsync", hStream, result(C.cuMemcpy3DPeerAsync(CpCopy, ChStream)))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemsetD8(dstDevice DevicePtr, uc byte, N int64) {
@@ -438,7 +336,7 @@ func (ctx *Ctx) MemsetD8(dstDevice DevicePtr, uc byte, N int64) {
 	f := func() error {
 		return result(C.cuMemsetD8(CdstDevice, Cuc, CN))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemsetD16(dstDevice DevicePtr, us uint16, N int64) {
@@ -448,7 +346,7 @@ func (ctx *Ctx) MemsetD16(dstDevice DevicePtr, us uint16, N int64) {
 	f := func() error {
 		return result(C.cuMemsetD16(CdstDevice, Cus, CN))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemsetD32(dstDevice DevicePtr, ui uint, N int64) {
@@ -458,7 +356,7 @@ func (ctx *Ctx) MemsetD32(dstDevice DevicePtr, ui uint, N int64) {
 	f := func() error {
 		return result(C.cuMemsetD32(CdstDevice, Cui, CN))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemsetD2D8(dstDevice DevicePtr, dstPitch int64, uc byte, Width int64, Height int64) {
@@ -470,7 +368,7 @@ func (ctx *Ctx) MemsetD2D8(dstDevice DevicePtr, dstPitch int64, uc byte, Width i
 	f := func() error {
 		return result(C.cuMemsetD2D8(CdstDevice, CdstPitch, Cuc, CWidth, CHeight))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemsetD2D16(dstDevice DevicePtr, dstPitch int64, us uint16, Width int64, Height int64) {
@@ -482,7 +380,7 @@ func (ctx *Ctx) MemsetD2D16(dstDevice DevicePtr, dstPitch int64, us uint16, Widt
 	f := func() error {
 		return result(C.cuMemsetD2D16(CdstDevice, CdstPitch, Cus, CWidth, CHeight))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemsetD2D32(dstDevice DevicePtr, dstPitch int64, ui uint, Width int64, Height int64) {
@@ -494,7 +392,7 @@ func (ctx *Ctx) MemsetD2D32(dstDevice DevicePtr, dstPitch int64, ui uint, Width
 	f := func() error {
 		return result(C.cuMemsetD2D32(CdstDevice, CdstPitch, Cui, CWidth, CHeight))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemsetD8Async(dstDevice DevicePtr, uc byte, N int64, hStream Stream) {
@@ -503,9 +401,9 @@ func (ctx *Ctx) MemsetD8Async(dstDevice DevicePtr, uc byte, N int64, hStream Str
 	CN := C.size_t(N)
 	ChStream := hStream.c()
 	f := func() error {
-		return result(C.cuMemsetD8Async(CdstDevice, Cuc, CN, ChStream))
+		return checkSync("MemsetD8Async", hStream, result(C.cuMemsetD8Async(CdstDevice, Cuc, CN, ChStream)))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemsetD16Async(dstDevice DevicePtr, us uint16, N int64, hStream Stream) {
@@ -514,9 +412,9 @@ func (ctx *Ctx) MemsetD16Async(dstDevice DevicePtr, us uint16, N int64, hStream
 	CN := C.size_t(N)
 	ChStream := hStream.c()
 	f := func() error {
-		return result(C.cuMemsetD16Async(CdstDevice, Cus, CN, ChStream))
+		return checkSync("MemsetD16Async", hStream, result(C.cuMemsetD16Async(CdstDevice, Cus, CN, ChStream)))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemsetD32Async(dstDevice DevicePtr, ui uint, N int64, hStream Stream) {
@@ -525,9 +423,9 @@ func (ctx *Ctx) MemsetD32Async(dstDevice DevicePtr, ui uint, N int64, hStream St
 	CN := C.size_t(N)
 	ChStream := hStream.c()
 	f := func() error {
-		return result(C.cuMemsetD32Async(CdstDevice, Cui, CN, ChStream))
+		return checkSync("MemsetD32Async", hStream, result(C.cuMemsetD32Async(CdstDevice, Cui, CN, ChStream)))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemsetD2D8Async(dstDevice DevicePtr, dstPitch int64, uc byte, Width int64, Height int64, hStream Stream) {
@@ -538,9 +436,9 @@ func (ctx *Ctx) MemsetD2D8Async(dstDevice DevicePtr, dstPitch int64, uc byte, Wi
 	CHeight := C.size_t(Height)
 	ChStream := hStream.c()
 	f := func() error {
-		return result(C.cuMemsetD2D8Async(CdstDevice, CdstPitch, Cuc, CWidth, CHeight, ChStream))
+		return checkSync("MemsetD2D8Async", hStream, result(C.cuMemsetD2D8Async(CdstDevice, CdstPitch, Cuc, CWidth, CHeight, ChStream)))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemsetD2D16Async(dstDevice DevicePtr, dstPitch int64, us uint16, Width int64, Height int64, hStream Stream) {
@@ -551,9 +449,9 @@ func (ctx *Ctx) MemsetD2D16Async(dstDevice DevicePtr, dstPitch int64, us uint16,
 	CHeight := C.size_t(Height)
 	ChStream := hStream.c()
 	f := func() error {
-		return result(C.cuMemsetD2D16Async(CdstDevice, CdstPitch, Cus, CWidth, CHeight, ChStream))
+		return checkSync("MemsetD2D16Async", hStream, result(C.cuMemsetD2D16Async(CdstDevice, CdstPitch, Cus, CWidth, CHeight, ChStream)))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) MemsetD2D32Async(dstDevice DevicePtr, dstPitch int64, ui uint, Width int64, Height int64, hStream Stream) {
@@ -564,9 +462,9 @@ func (ctx *Ctx) MemsetD2D32Async(dstDevice DevicePtr, dstPitch int64, ui uint, W
 	CHeight := C.size_t(Height)
 	ChStream := hStream.c()
 	f := func() error {
-		return result(C.cuMemsetD2D32Async(CdstDevice, CdstPitch, Cui, CWidth, CHeight, ChStream))
+		return checkSync("MemsetD2D32Async", hStream, result(C.cuMemsetD2D32Async(CdstDevice, CdstPitch, Cui, CWidth, CHeight, ChStream)))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) Descriptor(hArray Array) (pArrayDescriptor ArrayDesc, err error) {
@@ -587,7 +485,7 @@ func (ctx *Ctx) DestroyArray(hArray Array) {
 	f := func() error {
 		return result(C.cuArrayDestroy(ChArray))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) Descriptor3(hArray Array) (pArrayDescriptor Array3Desc, err error) {
@@ -636,7 +534,7 @@ func (ctx *Ctx) Wait(hStream Stream, hEvent Event, Flags uint) {
 	f := func() error {
 		return result(C.cuStreamWaitEvent(ChStream, ChEvent, CFlags))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) AttachMemAsync(hStream Stream, dptr DevicePtr, length int64, flags uint) {
@@ -645,9 +543,9 @@ func (ctx *Ctx) AttachMemAsync(hStream Stream, dptr DevicePtr, length int64, fla
 	Clength := C.size_t(length)
 	Cflags := C.uint(flags)
 	f := func() error {
-		return result(C.cuStreamAttachMemAsync(ChStream, Cdptr, Clength, Cflags))
+		return checkSync("AttachMemAsync", hStream, result(C.cuStreamAttachMemAsync(ChStream, Cdptr, Clength, Cflags)))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) QueryStream(hStream Stream) {
@@ -655,7 +553,7 @@ func (ctx *Ctx) QueryStream(hStream Stream) {
 	f := func() error {
 		return result(C.cuStreamQuery(ChStream))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) SynchronizeStream(hStream Stream) {
@@ -663,7 +561,7 @@ func (ctx *Ctx) SynchronizeStream(hStream Stream) {
 	f := func() error {
 		return result(C.cuStreamSynchronize(ChStream))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) Record(hEvent Event, hStream Stream) {
@@ -672,7 +570,7 @@ func (ctx *Ctx) Record(hEvent Event, hStream Stream) {
 	f := func() error {
 		return result(C.cuEventRecord(ChEvent, ChStream))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) QueryEvent(hEvent Event) {
@@ -680,7 +578,7 @@ func (ctx *Ctx) QueryEvent(hEvent Event) {
 	f := func() error {
 		return result(C.cuEventQuery(ChEvent))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) SynchronizeEvent(hEvent Event) {
@@ -688,7 +586,7 @@ func (ctx *Ctx) SynchronizeEvent(hEvent Event) {
 	f := func() error {
 		return result(C.cuEventSynchronize(ChEvent))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) Elapsed(hStart Event, hEnd Event) (pMilliseconds float64, err error) {
@@ -713,7 +611,7 @@ func (ctx *Ctx) WaitOnValue32(stream Stream, addr DevicePtr, value uint32, flags
 	f := func() error {
 		return result(C.cuStreamWaitValue32(Cstream, Caddr, Cvalue, Cflags))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) WriteValue32(stream Stream, addr DevicePtr, value uint32, flags uint) {
@@ -724,7 +622,7 @@ func (ctx *Ctx) WriteValue32(stream Stream, addr DevicePtr, value uint32, flags
 	f := func() error {
 		return result(C.cuStreamWriteValue32(Cstream, Caddr, Cvalue, Cflags))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) FunctionAttribute(fn Function, attrib FunctionAttribute) (pi int, err error) {
@@ -747,7 +645,7 @@ func (ctx *Ctx) SetCacheConfig(fn Function, config FuncCacheConfig) {
 	f := func() error {
 		return result(C.cuFuncSetCacheConfig(Cfn, Cconfig))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) SetFunctionSharedMemConfig(fn Function, config SharedConfig) {
@@ -756,7 +654,7 @@ func (ctx *Ctx) SetFunctionSharedMemConfig(fn Function, config SharedConfig) {
 	f := func() error {
 		return result(C.cuFuncSetSharedMemConfig(Cfn, Cconfig))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) TexRefSetArray(hTexRef TexRef, hArray Array, Flags uint) {
@@ -766,7 +664,7 @@ func (ctx *Ctx) TexRefSetArray(hTexRef TexRef, hArray Array, Flags uint) {
 	f := func() error {
 		return result(C.cuTexRefSetArray(ChTexRef, ChArray, CFlags))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) SetAddress(hTexRef TexRef, dptr DevicePtr, bytes int64) (ByteOffset int64, err error) {
@@ -792,7 +690,7 @@ func (ctx *Ctx) SetAddress2D(hTexRef TexRef, desc ArrayDesc, dptr DevicePtr, Pit
 	f := func() error {
 		return result(C.cuTexRefSetAddress2D(ChTexRef, Cdesc, Cdptr, CPitch))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) SetFormat(hTexRef TexRef, fmt Format, NumPackedComponents int) {
@@ -802,7 +700,7 @@ func (ctx *Ctx) SetFormat(hTexRef TexRef, fmt Format, NumPackedComponents int) {
 	f := func() error {
 		return result(C.cuTexRefSetFormat(ChTexRef, Cfmt, CNumPackedComponents))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) SetAddressMode(hTexRef TexRef, dim int, am AddressMode) {
@@ -812,7 +710,7 @@ func (ctx *Ctx) SetAddressMode(hTexRef TexRef, dim int, am AddressMode) {
 	f := func() error {
 		return result(C.cuTexRefSetAddressMode(ChTexRef, Cdim, Cam))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) SetFilterMode(hTexRef TexRef, fm FilterMode) {
@@ -821,7 +719,7 @@ func (ctx *Ctx) SetFilterMode(hTexRef TexRef, fm FilterMode) {
 	f := func() error {
 		return result(C.cuTexRefSetFilterMode(ChTexRef, Cfm))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) SetMipmapFilterMode(hTexRef TexRef, fm FilterMode) {
@@ -830,7 +728,7 @@ func (ctx *Ctx) SetMipmapFilterMode(hTexRef TexRef, fm FilterMode) {
 	f := func() error {
 		return result(C.cuTexRefSetMipmapFilterMode(ChTexRef, Cfm))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) SetMipmapLevelBias(hTexRef TexRef, bias float64) {
@@ -839,7 +737,7 @@ func (ctx *Ctx) SetMipmapLevelBias(hTexRef TexRef, bias float64) {
 	f := func() error {
 		return result(C.cuTexRefSetMipmapLevelBias(ChTexRef, Cbias))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) SetMipmapLevelClamp(hTexRef TexRef, minMipmapLevelClamp float64, maxMipmapLevelClamp float64) {
@@ -849,7 +747,7 @@ func (ctx *Ctx) SetMipmapLevelClamp(hTexRef TexRef, minMipmapLevelClamp float64,
 	f := func() error {
 		return result(C.cuTexRefSetMipmapLevelClamp(ChTexRef, CminMipmapLevelClamp, CmaxMipmapLevelClamp))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) SetMaxAnisotropy(hTexRef TexRef, maxAniso uint) {
@@ -858,7 +756,7 @@ func (ctx *Ctx) SetMaxAnisotropy(hTexRef TexRef, maxAniso uint) {
 	f := func() error {
 		return result(C.cuTexRefSetMaxAnisotropy(ChTexRef, CmaxAniso))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) SetBorderColor(hTexRef TexRef, pBorderColor [3]float32) {
@@ -867,7 +765,7 @@ func (ctx *Ctx) SetBorderColor(hTexRef TexRef, pBorderColor [3]float32) {
 	f := func() error {
 		return result(C.cuTexRefSetBorderColor(ChTexRef, CpBorderColor))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) SetTexRefFlags(hTexRef TexRef, Flags TexRefFlags) {
@@ -876,7 +774,7 @@ func (ctx *Ctx) SetTexRefFlags(hTexRef TexRef, Flags TexRefFlags) {
 	f := func() error {
 		return result(C.cuTexRefSetFlags(ChTexRef, CFlags))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) Address(hTexRef TexRef) (pdptr DevicePtr, err error) {
@@ -992,7 +890,7 @@ func (ctx *Ctx) SurfRefSetArray(hSurfRef SurfRef, hArray Array, Flags uint) {
 	f := func() error {
 		return result(C.cuSurfRefSetArray(ChSurfRef, ChArray, CFlags))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) GetArray(hSurfRef SurfRef) (phArray Array, err error) {
@@ -1028,7 +926,7 @@ func (ctx *Ctx) EnablePeerAccess(peerContext CUContext, Flags uint) {
 	f := func() error {
 		return result(C.cuCtxEnablePeerAccess(CpeerContext, CFlags))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
 
 func (ctx *Ctx) DisablePeerAccess(peerContext CUContext) {
@@ -1036,5 +934,5 @@ func (ctx *Ctx) DisablePeerAccess(peerContext CUContext) {
 	f := func() error {
 		return result(C.cuCtxDisablePeerAccess(CpeerContext))
 	}
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
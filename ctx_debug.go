@@ -3,10 +3,15 @@
 package cu
 
 // #include <cuda.h>
+// #include "currentctx.h"
 import "C"
 import (
+	"log"
 	"runtime"
+	"sync"
 	"unsafe"
+
+	"github.com/pkg/errors"
 )
 
 // Ctx is a standalone CUDA Context that is threadlocked.
@@ -14,26 +19,35 @@ type Ctx struct {
 	CUContext
 	work    chan (func() error)
 	errChan chan error
-	err     error
+	mu      sync.RWMutex // held for reading by Do, and for writing by Close
+
+	errMu sync.Mutex
+	err   error
 
 	device Device
 	flags  ContextFlags
 	locked bool
+	loop   *ctxLoop
+	async  *asyncErrors
+	logger *log.Logger
 }
 
-// NewContext creates a new context, and runs a listener locked to an OSThread. All work is piped through that goroutine
-func NewContext(d Device, flags ContextFlags) *Ctx {
+// NewContext creates a new context, and runs a listener locked to an OSThread. All work is piped through that goroutine.
+// It uses the WithLogger option.
+func NewContext(d Device, flags ContextFlags, opts ...Option) *Ctx {
 	var cctx C.CUcontext
-	err := result(C.cuCtxCreate(&cctx, C.uint(flags), C.CUdevice(d)))
+	err := result(C.cuCtxCreateCached(&cctx, C.uint(flags), C.CUdevice(d)))
 	if err != nil {
 		panic(err)
 	}
 	ctx := newContext(makeContext(cctx))
 	ctx.device = d
 	ctx.flags = flags
+	ctx.logger = makeOptions(opts).logger
 
 	errChan := make(chan error)
-	go ctx.Run(errChan)
+	run := ctx.runner(errChan)
+	lockedThreads.run(func() { run() })
 	if err := <-errChan; err != nil {
 		panic(err)
 	}
@@ -41,21 +55,23 @@ func NewContext(d Device, flags ContextFlags) *Ctx {
 	return ctx
 }
 
-// NewManuallyManagedContext creates a new context, but the Run() method which locks a goroutine to an OS thread, has to be manually run
-func NewManuallyManagedContext(d Device, flags ContextFlags) *Ctx {
+// NewManuallyManagedContext creates a new context, but the Run() method which locks a goroutine to an OS thread, has to be manually run.
+// It uses the WithLogger option.
+func NewManuallyManagedContext(d Device, flags ContextFlags, opts ...Option) *Ctx {
 	var cctx C.CUcontext
-	err := result(C.cuCtxCreate(&cctx, C.uint(flags), C.CUdevice(d)))
+	err := result(C.cuCtxCreateCached(&cctx, C.uint(flags), C.CUdevice(d)))
 	if err != nil {
 		panic(err)
 	}
 	ctx := newContext(makeContext(cctx))
 	ctx.device = d
 	ctx.flags = flags
+	ctx.logger = makeOptions(opts).logger
 
 	return ctx
 }
 
-// CtxFromCUContext is another way of buildinga *Ctx.
+// CtxFromCUContext is another way of buildinga *Ctx. It uses the WithLogger option.
 //
 // Typical example:
 //	cuctx, err := dev.MakeContext(SchedAuto)
@@ -63,10 +79,11 @@ func NewManuallyManagedContext(d Device, flags ContextFlags) *Ctx {
 //		..error handling..
 //	}
 // 	ctx := CtxFroMCUContext(d, cuctx)
-func CtxFromCUContext(d Device, cuctx CUContext, flags ContextFlags) *Ctx {
+func CtxFromCUContext(d Device, cuctx CUContext, flags ContextFlags, opts ...Option) *Ctx {
 	ctx := newContext(cuctx)
 	ctx.device = d
 	ctx.flags = flags
+	ctx.logger = makeOptions(opts).logger
 	return ctx
 }
 
@@ -75,6 +92,8 @@ func newContext(c CUContext) *Ctx {
 		CUContext: c,
 		work:      make(chan func() error),
 		errChan:   make(chan error),
+		loop:      newCtxLoop(),
+		async:     newAsyncErrors(),
 	}
 	logf("Created %p", ctx)
 	runtime.SetFinalizer(ctx, finalizeCtx)
@@ -85,20 +104,26 @@ func newContext(c CUContext) *Ctx {
 func (ctx *Ctx) Close() error {
 	logf("Closing Ctx %v | ", ctx)
 	logCaller("Ctx.Close")
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
 	var empty C.CUcontext
 	if ctx.CUContext.ctx == empty {
 		return nil
 	}
 
-	if ctx.errChan != nil {
-		close(ctx.errChan)
-	}
-
 	if ctx.work != nil {
 		close(ctx.work)
 	}
 
-	err := result(C.cuCtxDestroy(C.CUcontext(unsafe.Pointer(ctx.CUContext.ctx))))
+	// wait for the thread running the context to be released
+	ctx.loop.wait()
+	ctx.async.close()
+
+	if ctx.errChan != nil {
+		close(ctx.errChan)
+	}
+
+	err := result(C.cuCtxDestroyCached(C.CUcontext(unsafe.Pointer(ctx.CUContext.ctx))))
 	ctx.CUContext.ctx = empty
 	ctx.errChan = nil
 	ctx.work = nil
@@ -106,16 +131,25 @@ func (ctx *Ctx) Close() error {
 }
 
 func (ctx *Ctx) Do(fn func() error) error {
+	ctx.mu.RLock()
+	defer ctx.mu.RUnlock()
+	if ctx.work == nil {
+		return errors.New("Context is closed")
+	}
 	ctx.work <- fn
 	err := <-ctx.errChan
-	return err
+	return ctx.checkAsync(err)
 }
 
 // CUDAContext returns the CUDA Context
 func (ctx *Ctx) CUDAContext() CUContext { return ctx.CUContext }
 
 // Error returns the errors that may have occured during the calls.
-func (ctx *Ctx) Error() error { return ctx.err }
+func (ctx *Ctx) Error() error {
+	ctx.errMu.Lock()
+	defer ctx.errMu.Unlock()
+	return ctx.err
+}
 
 // Work returns the channel where work will be passed in. In most cases you don't need this. Use Run instead.
 func (ctx *Ctx) Work() <-chan func() error { return ctx.work }
@@ -153,27 +187,42 @@ func (ctx *Ctx) ErrChan() chan error { return ctx.errChan }
 	}
 */
 func (ctx *Ctx) Run(errChan chan error) error {
-	runtime.LockOSThread()
+	return ctx.runner(errChan)()
+}
 
-	// set current, which locks the context to the OS thread
-	if err := SetCurrentContext(ctx.CUContext); err != nil {
-		if errChan != nil {
-			errChan <- err
-		} else {
+// runner returns the function that runs the context. The function does not refer to the *Ctx, so an abandoned *Ctx
+// can still be finalized while it runs.
+func (ctx *Ctx) runner(errChan chan error) func() error {
+	cuctx, work, results, loop := ctx.CUContext, ctx.work, ctx.errChan, ctx.loop
+	return func() error {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		// set current, which locks the context to the OS thread
+		if err := SetCurrentContext(cuctx); err != nil {
+			if errChan != nil {
+				errChan <- err
+				return nil
+			}
 			return err
 		}
-		return nil
-	}
-	close(errChan)
+		close(loop.started)
+		if errChan != nil {
+			close(errChan)
+		}
 
-	// wait for Do()s
-	for w := range ctx.work {
-		current, _ := CurrentContext()
-		logf("Current Context %v", current)
-		ctx.errChan <- w()
+		// wait for Do()s
+		for w := range work {
+			current, _ := CurrentContext()
+			logf("Current Context %v", current)
+			results <- w()
+		}
+
+		// release the thread before the context is destroyed
+		SetCurrentContext(CUContext{})
+		close(loop.done)
+		return nil
 	}
-	runtime.UnlockOSThread()
-	return nil
 }
 
 func finalizeCtx(ctx *Ctx) {
@@ -1,4 +1,22 @@
 // Package cu provides an idiomatic interface to the CUDA Driver API.
+//
+// # Concurrency
+//
+// The CUDA driver is thread safe, but the current context is a property of the OS thread. The types of this package
+// fall in three groups:
+//
+// Handles - Device, CUContext, Stream, Event, Module, Function, DevicePtr and DeviceTensor - are values that may be
+// copied and used from any goroutine. Calls on them are made on the thread of the calling goroutine, so the goroutine
+// must be locked to a thread where the right context is current (see runtime.LockOSThread and SetCurrentContext).
+// Destroying a handle while another goroutine uses it is a bug, as it is in C.
+//
+// Ctx, MemPool, Arena, EventPool, ScopedEvent and Preinit are internally synchronized, and safe for concurrent use.
+// The calls made through a Ctx are run one at a time on the thread of the context. Close may be called while other
+// goroutines use the Ctx: their calls fail once it is closed. The sticky error returned by Ctx.Error is that of the
+// last call, whichever goroutine made it.
+//
+// The calls of a BatchedContext may be made from many goroutines, but its queue must be processed (DoWork, Run, Cleanup)
+// by a single goroutine. Blocking calls, such as MemAlloc, are run one at a time.
 package cu // import "gorgonia.org/cu"
 
 // This file implements CUDA driver context management
@@ -6,22 +24,28 @@ package cu // import "gorgonia.org/cu"
 //#include <cuda.h>
 import "C"
 import (
-	"fmt"
-	"os"
+	"github.com/pkg/errors"
 )
 
 const initHtml = "https://docs.nvidia.com/cuda/cuda-driver-api/group__CUDA__INITIALIZE.html"
 
+// initErr is the error returned by cuInit, if any.
+var initErr error
+
 func init() {
 	// Given that the flags must be 0, the CUDA driver is initialized at the package level
 	// http://docs.nvidia.com/cuda/cuda-driver-api/group__CUDA__INITIALIZE.html
+	//
+	// A failure to initialize is not fatal: programs may choose to fall back to the CPU when there is no GPU present.
 	if err := result(C.cuInit(C.uint(0))); err != nil {
-		fmt.Printf("Error in initialization, please refer to %q for details on: %+v\n", initHtml, err)
-		os.Exit(1)
+		initErr = errors.Wrapf(err, "Error in initialization, please refer to %q for details", initHtml)
 	}
-
 }
 
+// InitErr returns the error that occurred when initializing the CUDA driver, if any.
+// When it is not nil, all calls to the driver will fail.
+func InitErr() error { return initErr }
+
 // Version returns the version of the CUDA driver
 func Version() int {
 	var v C.int
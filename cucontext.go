@@ -1,6 +1,7 @@
 package cu
 
 // #include <cuda.h>
+// #include "currentctx.h"
 import "C"
 import (
 	"fmt"
@@ -20,7 +21,7 @@ func (ctx CUContext) c() C.CUcontext { return ctx.ctx }
 
 func (d Device) MakeContext(flags ContextFlags) (CUContext, error) {
 	var ctx CUContext
-	err := result(C.cuCtxCreate(&ctx.ctx, C.uint(flags), C.CUdevice(d)))
+	err := result(C.cuCtxCreateCached(&ctx.ctx, C.uint(flags), C.CUdevice(d)))
 	return ctx, err
 }
 
@@ -66,7 +67,7 @@ func (ctx CUContext) Unlock() error {
 //
 // Wrapper over cuCtxDestroy: http://docs.nvidia.com/cuda/cuda-driver-api/group__CUDA__CTX.html#group__CUDA__CTX_1g27a365aebb0eb548166309f58a1e8b8e
 func (ctx *CUContext) Destroy() error {
-	err := result(C.cuCtxDestroy(ctx.ctx))
+	err := result(C.cuCtxDestroyCached(ctx.ctx))
 	*ctx = CUContext{}
 	return err
 }
@@ -86,3 +87,19 @@ func (d Device) RetainPrimaryCtx() (primaryContext CUContext, err error) {
 	}
 	return primaryContext, nil
 }
+
+// ReleasePrimaryCtx releases the primary context on the GPU, decreasing its usage count. The primary context is
+// destroyed once it is no longer used.
+//
+// Wrapper over cuDevicePrimaryCtxRelease: http://docs.nvidia.com/cuda/cuda-driver-api/group__CUDA__PRIMARY__CTX.html
+func (d Device) ReleasePrimaryCtx() error {
+	return result(C.cuDevicePrimaryCtxReleaseCached(C.CUdevice(d)))
+}
+
+// ResetPrimaryCtx destroys the primary context on the GPU, and resets its state, regardless of its usage count.
+// The context must no longer be used.
+//
+// Wrapper over cuDevicePrimaryCtxReset: http://docs.nvidia.com/cuda/cuda-driver-api/group__CUDA__PRIMARY__CTX.html
+func (d Device) ResetPrimaryCtx() error {
+	return result(C.cuDevicePrimaryCtxResetCached(C.CUdevice(d)))
+}
@@ -0,0 +1,132 @@
+// Package curand provides bindings to the host API of cuRAND, which generates random numbers in device memory.
+//
+// The numbers are generated where they are used, rather than on the host and copied: random initializations of
+// weights are drawn with Uniform or Normal, and dropout masks are drawn with Uniform, then compared to the rate.
+package curand
+
+//#include <curand.h>
+import "C"
+import (
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// RNGType is the type of a random number generator.
+type RNGType int
+
+const (
+	PseudoDefault     RNGType = C.CURAND_RNG_PSEUDO_DEFAULT
+	PseudoXORWOW      RNGType = C.CURAND_RNG_PSEUDO_XORWOW
+	PseudoMRG32K3A    RNGType = C.CURAND_RNG_PSEUDO_MRG32K3A
+	PseudoMTGP32      RNGType = C.CURAND_RNG_PSEUDO_MTGP32
+	PseudoMT19937     RNGType = C.CURAND_RNG_PSEUDO_MT19937
+	PseudoPhilox43210 RNGType = C.CURAND_RNG_PSEUDO_PHILOX4_32_10
+	QuasiDefault      RNGType = C.CURAND_RNG_QUASI_DEFAULT
+	QuasiSobol32      RNGType = C.CURAND_RNG_QUASI_SOBOL32
+	QuasiSobol64      RNGType = C.CURAND_RNG_QUASI_SOBOL64
+)
+
+// Version returns the version of cuRAND.
+func Version() (int, error) {
+	var v C.int
+	err := result(C.curandGetVersion(&v))
+	return int(v), err
+}
+
+// Generator generates random numbers in device memory. The numbers are generated asynchronously, on the stream of the
+// generator.
+//
+// A Generator is bound to the context that was current when it was created.
+type Generator struct {
+	g C.curandGenerator_t
+}
+
+// NewGenerator creates a Generator of the given type.
+func NewGenerator(t RNGType) (*Generator, error) {
+	var g C.curandGenerator_t
+	if err := result(C.curandCreateGenerator(&g, C.curandRngType_t(t))); err != nil {
+		return nil, err
+	}
+	return &Generator{g: g}, nil
+}
+
+// SetSeed sets the seed of a pseudo random number generator.
+func (g *Generator) SetSeed(seed uint64) error {
+	return result(C.curandSetPseudoRandomGeneratorSeed(g.g, C.ulonglong(seed)))
+}
+
+// SetOffset sets the position in the sequence of random numbers.
+func (g *Generator) SetOffset(offset uint64) error {
+	return result(C.curandSetGeneratorOffset(g.g, C.ulonglong(offset)))
+}
+
+// SetQuasiDimensions sets the number of dimensions of a quasi random generator. The numbers are generated dimension
+// after dimension, so the number of numbers generated at once must be a multiple of the number of dimensions.
+func (g *Generator) SetQuasiDimensions(dims int) error {
+	return result(C.curandSetQuasiRandomGeneratorDimensions(g.g, C.uint(dims)))
+}
+
+// GenerateSeeds sets up the state of the generator, which is otherwise done by the first generation. It allows the
+// cost of setting up, which is large for some generators, to be paid ahead of time.
+func (g *Generator) GenerateSeeds() error {
+	return result(C.curandGenerateSeeds(g.g))
+}
+
+// SetStream sets the stream on which the numbers are generated.
+func (g *Generator) SetStream(stream cu.Stream) error {
+	return result(C.curandSetStream(g.g, C.cudaStream_t(stream.Pointer())))
+}
+
+// Uniform fills mem with n float32 uniformly distributed in (0, 1].
+func (g *Generator) Uniform(mem cu.DevicePtr, n int) error {
+	return result(C.curandGenerateUniform(g.g, (*C.float)(mem.Pointer()), C.size_t(n)))
+}
+
+// UniformFloat64 fills mem with n float64 uniformly distributed in (0, 1].
+func (g *Generator) UniformFloat64(mem cu.DevicePtr, n int) error {
+	return result(C.curandGenerateUniformDouble(g.g, (*C.double)(mem.Pointer()), C.size_t(n)))
+}
+
+// Normal fills mem with n float32 normally distributed. For pseudo random generators, n must be even.
+func (g *Generator) Normal(mem cu.DevicePtr, n int, mean, stddev float32) error {
+	return result(C.curandGenerateNormal(g.g, (*C.float)(mem.Pointer()), C.size_t(n), C.float(mean), C.float(stddev)))
+}
+
+// NormalFloat64 fills mem with n float64 normally distributed. For pseudo random generators, n must be even.
+func (g *Generator) NormalFloat64(mem cu.DevicePtr, n int, mean, stddev float64) error {
+	return result(C.curandGenerateNormalDouble(g.g, (*C.double)(mem.Pointer()), C.size_t(n), C.double(mean), C.double(stddev)))
+}
+
+// Generate fills mem with n uint32 of random bits. Quasi random generators of 64 bits are not supported.
+func (g *Generator) Generate(mem cu.DevicePtr, n int) error {
+	return result(C.curandGenerate(g.g, (*C.uint)(mem.Pointer()), C.size_t(n)))
+}
+
+// LogNormal fills mem with n float32 log-normally distributed: their logarithms are normally distributed, of the given
+// mean and standard deviation. For pseudo random generators, n must be even.
+func (g *Generator) LogNormal(mem cu.DevicePtr, n int, mean, stddev float32) error {
+	return result(C.curandGenerateLogNormal(g.g, (*C.float)(mem.Pointer()), C.size_t(n), C.float(mean), C.float(stddev)))
+}
+
+// LogNormalFloat64 fills mem with n float64 log-normally distributed. For pseudo random generators, n must be even.
+func (g *Generator) LogNormalFloat64(mem cu.DevicePtr, n int, mean, stddev float64) error {
+	return result(C.curandGenerateLogNormalDouble(g.g, (*C.double)(mem.Pointer()), C.size_t(n), C.double(mean), C.double(stddev)))
+}
+
+// Poisson fills mem with n uint32 Poisson distributed, of the given mean.
+func (g *Generator) Poisson(mem cu.DevicePtr, n int, lambda float64) error {
+	if lambda <= 0 {
+		return errors.Errorf("Expected a positive mean. Got %v", lambda)
+	}
+	return result(C.curandGeneratePoisson(g.g, (*C.uint)(mem.Pointer()), C.size_t(n), C.double(lambda)))
+}
+
+// Close destroys the generator.
+func (g *Generator) Close() error {
+	if g.g == nil {
+		return nil
+	}
+	err := result(C.curandDestroyGenerator(g.g))
+	g.g = nil
+	return err
+}
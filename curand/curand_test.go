@@ -0,0 +1,118 @@
+package curand
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"gorgonia.org/cu"
+)
+
+func TestUniform(t *testing.T) {
+	if devices, _ := cu.NumDevices(); devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := cu.Device(0).MakeContext(cu.SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+
+	const n = 1024
+	mem, err := cu.MemAlloc(n * 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cu.MemFree(mem)
+
+	g, err := NewGenerator(PseudoPhilox43210)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+	if err = g.SetSeed(42); err != nil {
+		t.Fatal(err)
+	}
+	if err = g.Uniform(mem, n); err != nil {
+		t.Fatal(err)
+	}
+	out := make([]float32, n)
+	if err = cu.MemcpyDtoH(unsafe.Pointer(&out[0]), mem, n*4); err != nil {
+		t.Fatal(err)
+	}
+	var sum float32
+	for _, v := range out {
+		if v <= 0 || v > 1 {
+			t.Fatalf("Expected numbers in (0, 1]. Got %v", v)
+		}
+		sum += v
+	}
+	if mean := sum / n; mean < 0.4 || mean > 0.6 {
+		t.Errorf("Expected a mean close to 0.5. Got %v", mean)
+	}
+}
+
+func TestPoisson_Lambda(t *testing.T) {
+	g := new(Generator)
+	if err := g.Poisson(0, 2, 0); err == nil {
+		t.Error("Expected an error for a mean of 0")
+	}
+}
+
+func TestDistributions(t *testing.T) {
+	if devices, _ := cu.NumDevices(); devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := cu.Device(0).MakeContext(cu.SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+
+	const n = 4096
+	mem, err := cu.MemAlloc(n * 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cu.MemFree(mem)
+	g, err := NewGenerator(PseudoXORWOW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+	if err = g.SetSeed(7); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = g.LogNormalFloat64(mem, n, 0, 0.5); err != nil {
+		t.Fatal(err)
+	}
+	floats := make([]float64, n)
+	if err = cu.MemcpyDtoH(unsafe.Pointer(&floats[0]), mem, n*8); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range floats {
+		if v <= 0 {
+			t.Fatalf("Expected log-normal numbers to be positive. Got %v", v)
+		}
+	}
+
+	if err = g.Poisson(mem, n, 3); err != nil {
+		t.Fatal(err)
+	}
+	counts := make([]uint32, n)
+	if err = cu.MemcpyDtoH(unsafe.Pointer(&counts[0]), mem, n*4); err != nil {
+		t.Fatal(err)
+	}
+	var sum float64
+	for _, v := range counts {
+		sum += float64(v)
+	}
+	if mean := sum / n; mean < 2.8 || mean > 3.2 {
+		t.Errorf("Expected a mean close to 3. Got %v", mean)
+	}
+}
@@ -0,0 +1,52 @@
+package curand
+
+//#include <curand.h>
+import "C"
+
+type curandStatus int
+
+func (err curandStatus) Error() string  { return err.String() }
+func (err curandStatus) String() string { return resString[err] }
+
+func result(x C.curandStatus_t) error {
+	err := curandStatus(x)
+	if err == Success {
+		return nil
+	}
+	if _, ok := resString[err]; !ok {
+		return InternalError
+	}
+	return err
+}
+
+const (
+	Success                 curandStatus = C.CURAND_STATUS_SUCCESS
+	VersionMismatch         curandStatus = C.CURAND_STATUS_VERSION_MISMATCH
+	NotInitialized          curandStatus = C.CURAND_STATUS_NOT_INITIALIZED
+	AllocationFailed        curandStatus = C.CURAND_STATUS_ALLOCATION_FAILED
+	TypeError               curandStatus = C.CURAND_STATUS_TYPE_ERROR
+	OutOfRange              curandStatus = C.CURAND_STATUS_OUT_OF_RANGE
+	LengthNotMultiple       curandStatus = C.CURAND_STATUS_LENGTH_NOT_MULTIPLE
+	DoublePrecisionRequired curandStatus = C.CURAND_STATUS_DOUBLE_PRECISION_REQUIRED
+	LaunchFailure           curandStatus = C.CURAND_STATUS_LAUNCH_FAILURE
+	PreexistingFailure      curandStatus = C.CURAND_STATUS_PREEXISTING_FAILURE
+	InitializationFailed    curandStatus = C.CURAND_STATUS_INITIALIZATION_FAILED
+	ArchMismatch            curandStatus = C.CURAND_STATUS_ARCH_MISMATCH
+	InternalError           curandStatus = C.CURAND_STATUS_INTERNAL_ERROR
+)
+
+var resString = map[curandStatus]string{
+	Success:                 "Success",
+	VersionMismatch:         "VersionMismatch",
+	NotInitialized:          "NotInitialized",
+	AllocationFailed:        "AllocationFailed",
+	TypeError:               "TypeError",
+	OutOfRange:              "OutOfRange",
+	LengthNotMultiple:       "LengthNotMultiple",
+	DoublePrecisionRequired: "DoublePrecisionRequired",
+	LaunchFailure:           "LaunchFailure",
+	PreexistingFailure:      "PreexistingFailure",
+	InitializationFailed:    "InitializationFailed",
+	ArchMismatch:            "ArchMismatch",
+	InternalError:           "InternalError",
+}
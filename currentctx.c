@@ -0,0 +1,99 @@
+#include <cuda.h>
+#include "currentctx.h"
+
+// currentCtx is the context current on the thread, as last set or read by this package. It is only valid if
+// currentKnown is set. Each function below changes the context current on the thread and updates the cache in the
+// same call, so that the goroutine making the call cannot be moved to another thread in between.
+static __thread CUcontext currentCtx;
+static __thread int currentKnown;
+
+// destroyGen counts the contexts destroyed, on any thread. A context may be destroyed on another thread than those it
+// is cached as current on, and the driver may give its handle to the next context created, so the cache of a thread is
+// only valid if no context was destroyed since it was filled (currentGen).
+static unsigned long destroyGen;
+static __thread unsigned long currentGen;
+
+static int known(void) {
+	return currentKnown && currentGen == __atomic_load_n(&destroyGen, __ATOMIC_ACQUIRE);
+}
+
+static void remember(CUcontext ctx, CUresult ret) {
+	currentCtx = ctx;
+	currentKnown = ret == CUDA_SUCCESS;
+	currentGen = __atomic_load_n(&destroyGen, __ATOMIC_ACQUIRE);
+}
+
+CUresult cuCtxSetCurrentCached(CUcontext ctx) {
+	CUresult ret;
+	if (known() && currentCtx == ctx) {
+		return CUDA_SUCCESS;
+	}
+	ret = cuCtxSetCurrent(ctx);
+	remember(ctx, ret);
+	return ret;
+}
+
+CUresult cuCtxGetCurrentCached(CUcontext* ctx) {
+	CUresult ret;
+	if (known()) {
+		*ctx = currentCtx;
+		return CUDA_SUCCESS;
+	}
+	ret = cuCtxGetCurrent(ctx);
+	if (ret == CUDA_SUCCESS) {
+		remember(*ctx, ret);
+	}
+	return ret;
+}
+
+CUresult cuCtxPushCurrentCached(CUcontext ctx) {
+	CUresult ret;
+	ret = cuCtxPushCurrent(ctx);
+	remember(ctx, ret);
+	return ret;
+}
+
+CUresult cuCtxPopCurrentCached(CUcontext* ctx) {
+	// the context below the popped one is not known
+	currentKnown = 0;
+	return cuCtxPopCurrent(ctx);
+}
+
+CUresult cuCtxCreateCached(CUcontext* ctx, unsigned int flags, CUdevice dev) {
+	CUresult ret;
+	// the new context is pushed on the thread
+	ret = cuCtxCreate(ctx, flags, dev);
+	remember(*ctx, ret);
+	return ret;
+}
+
+CUresult cuCtxDestroyCached(CUcontext ctx) {
+	CUresult ret;
+	// a destroyed context is popped if it is current, and it may be cached as current on other threads
+	currentKnown = 0;
+	ret = cuCtxDestroy(ctx);
+	__atomic_add_fetch(&destroyGen, 1, __ATOMIC_RELEASE);
+	return ret;
+}
+
+CUresult cuDevicePrimaryCtxReleaseCached(CUdevice dev) {
+	CUresult ret;
+	// releasing the last reference destroys the primary context, like cuCtxDestroy
+	currentKnown = 0;
+	ret = cuDevicePrimaryCtxRelease(dev);
+	__atomic_add_fetch(&destroyGen, 1, __ATOMIC_RELEASE);
+	return ret;
+}
+
+CUresult cuDevicePrimaryCtxResetCached(CUdevice dev) {
+	CUresult ret;
+	// the primary context is destroyed, whatever its references
+	currentKnown = 0;
+	ret = cuDevicePrimaryCtxReset(dev);
+	__atomic_add_fetch(&destroyGen, 1, __ATOMIC_RELEASE);
+	return ret;
+}
+
+void cuCtxForgetCurrent(void) {
+	currentKnown = 0;
+}
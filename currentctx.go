@@ -0,0 +1,46 @@
+package cu
+
+// #include <cuda.h>
+// #include "currentctx.h"
+import "C"
+
+/*
+The context current on each OS thread is cached, so that setting the context that is already current, as is done
+before every batch of calls and every Do, does not go through the driver.
+
+The cache is kept up to date by the functions of this package that change the current context. Code that changes
+the current context of a thread by other means (the CUDA runtime API, or other cgo libraries) must call
+ForgetCurrentContext on that thread afterwards.
+*/
+
+// PushCurrentCtx pushes the context onto the stack of contexts of the calling thread, making it current.
+func PushCurrentCtx(ctx CUContext) (err error) {
+	return result(C.cuCtxPushCurrentCached(ctx.c()))
+}
+
+// PopCurrentCtx pops the current context from the stack of contexts of the calling thread, and returns it.
+func PopCurrentCtx() (pctx CUContext, err error) {
+	var Cpctx C.CUcontext
+	err = result(C.cuCtxPopCurrentCached(&Cpctx))
+	pctx = makeContext(Cpctx)
+	return
+}
+
+// SetCurrentContext binds the context to the calling thread. It does not call the driver if the context is current
+// already.
+func SetCurrentContext(ctx CUContext) (err error) {
+	return result(C.cuCtxSetCurrentCached(ctx.c()))
+}
+
+// CurrentContext returns the context bound to the calling thread.
+func CurrentContext() (pctx CUContext, err error) {
+	var Cpctx C.CUcontext
+	err = result(C.cuCtxGetCurrentCached(&Cpctx))
+	pctx = makeContext(Cpctx)
+	return
+}
+
+// ForgetCurrentContext drops the cached current context of the calling thread, so that the next calls to
+// SetCurrentContext and CurrentContext go through the driver. It must be called after the current context of the
+// thread has been changed by code outside of this package.
+func ForgetCurrentContext() { C.cuCtxForgetCurrent() }
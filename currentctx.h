@@ -0,0 +1,11 @@
+#include <cuda.h>
+
+extern CUresult cuCtxSetCurrentCached(CUcontext ctx);
+extern CUresult cuCtxGetCurrentCached(CUcontext* ctx);
+extern CUresult cuCtxPushCurrentCached(CUcontext ctx);
+extern CUresult cuCtxPopCurrentCached(CUcontext* ctx);
+extern CUresult cuCtxCreateCached(CUcontext* ctx, unsigned int flags, CUdevice dev);
+extern CUresult cuCtxDestroyCached(CUcontext ctx);
+extern CUresult cuDevicePrimaryCtxReleaseCached(CUdevice dev);
+extern CUresult cuDevicePrimaryCtxResetCached(CUdevice dev);
+extern void cuCtxForgetCurrent(void);
@@ -0,0 +1,208 @@
+package cu
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestCurrentContextCache(t *testing.T) {
+	devices, _ := NumDevices()
+	if devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	ctx, err := Device(0).MakeContext(SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+	other, err := Device(0).MakeContext(SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Destroy()
+
+	check := func(expected CUContext) {
+		t.Helper()
+		current, err := CurrentContext()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if current != expected {
+			t.Errorf("Expected %v to be current. Got %v", expected, current)
+		}
+		// the cache must agree with the driver
+		ForgetCurrentContext()
+		if current, _ = CurrentContext(); current != expected {
+			t.Errorf("Expected the driver to have %v current. Got %v", expected, current)
+		}
+	}
+
+	check(other)
+	if err = SetCurrentContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	check(ctx)
+	if err = PushCurrentCtx(other); err != nil {
+		t.Fatal(err)
+	}
+	check(other)
+	if _, err = PopCurrentCtx(); err != nil {
+		t.Fatal(err)
+	}
+	check(ctx)
+}
+
+func TestCurrentContextDestroyedElsewhere(t *testing.T) {
+	devices, _ := NumDevices()
+	if devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	ctx, err := Device(0).MakeContext(SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ctx is cached as current on this thread. It is destroyed on another, which makes a new context, that the driver
+	// may give the same handle.
+	made := make(chan CUContext)
+	errs := make(chan error, 1)
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		if err := ctx.Destroy(); err != nil {
+			errs <- err
+			close(made)
+			return
+		}
+		other, err := Device(0).MakeContext(SchedAuto)
+		if err != nil {
+			errs <- err
+			close(made)
+			return
+		}
+		made <- other
+	}()
+	other, ok := <-made
+	if !ok {
+		t.Fatal(<-errs)
+	}
+	defer other.Destroy()
+
+	if err = SetCurrentContext(other); err != nil {
+		t.Fatal(err)
+	}
+	ForgetCurrentContext()
+	if current, _ := CurrentContext(); current != other {
+		t.Errorf("Expected the driver to have %v current. Got %v", other, current)
+	}
+}
+
+func TestCurrentContextPrimaryReleasedElsewhere(t *testing.T) {
+	devices, _ := NumDevices()
+	if devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	primary, err := Device(0).RetainPrimaryCtx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = SetCurrentContext(primary); err != nil {
+		t.Fatal(err)
+	}
+
+	// the primary context, cached as current on this thread, is destroyed by its release on another thread, which
+	// makes a new context, that the driver may give the same handle
+	made := make(chan CUContext)
+	errs := make(chan error, 1)
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		if err := Device(0).ReleasePrimaryCtx(); err != nil {
+			errs <- err
+			close(made)
+			return
+		}
+		other, err := Device(0).MakeContext(SchedAuto)
+		if err != nil {
+			errs <- err
+			close(made)
+			return
+		}
+		made <- other
+	}()
+	other, ok := <-made
+	if !ok {
+		t.Fatal(<-errs)
+	}
+	defer other.Destroy()
+
+	if err = SetCurrentContext(other); err != nil {
+		t.Fatal(err)
+	}
+	ForgetCurrentContext()
+	if current, _ := CurrentContext(); current != other {
+		t.Errorf("Expected the driver to have %v current. Got %v", other, current)
+	}
+}
+
+func benchmarkSetCurrentContext(b *testing.B, forget bool) {
+	devices, _ := NumDevices()
+	if devices == 0 {
+		b.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	ctx, err := Device(0).MakeContext(SchedAuto)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer ctx.Destroy()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if forget {
+			ForgetCurrentContext()
+		}
+		if err = SetCurrentContext(ctx); err != nil {
+			b.Fatal(err)
+		}
+		if _, err = CurrentContext(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkCurrentContextCached measures the overhead of setting and querying the context current on a thread, as is
+// done around every operation.
+func BenchmarkCurrentContextCached(b *testing.B) { benchmarkSetCurrentContext(b, false) }
+
+// BenchmarkCurrentContextUncached measures the same calls when each goes through the driver.
+func BenchmarkCurrentContextUncached(b *testing.B) { benchmarkSetCurrentContext(b, true) }
+
+// BenchmarkCtxDo measures the overhead of an empty call through a Ctx.
+func BenchmarkCtxDo(b *testing.B) {
+	devices, _ := NumDevices()
+	if devices == 0 {
+		b.Skip("NoDevice")
+	}
+	ctx := NewContext(Device(0), SchedAuto)
+	defer ctx.Close()
+
+	noop := func() error { return nil }
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := ctx.Do(noop); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package cutensor
+
+//#cgo LDFLAGS:-lcutensor
+//
+////default location:
+//#cgo linux,windows LDFLAGS:-L/usr/local/cuda/lib64 -L/usr/local/cuda/lib
+//#cgo linux,windows CFLAGS: -I/usr/local/cuda/include/
+//
+////cuTENSOR (2.0 or later) extracted from its archive, for CUDA 12:
+//#cgo linux LDFLAGS:-L/usr/local/libcutensor/lib/12
+//#cgo linux CFLAGS: -I/usr/local/libcutensor/include
+//
+////cuTENSOR installed from the CUDA repository on Debian and Ubuntu:
+//#cgo linux LDFLAGS:-L/usr/lib/x86_64-linux-gnu/libcutensor/12
+//#cgo linux CFLAGS: -I/usr/include/libcutensor/12
+//
+////Ubuntu 15.04:
+//#cgo linux LDFLAGS:-L/usr/lib/x86_64-linux-gnu/
+//#cgo linux CFLAGS: -I/usr/include
+//
+////arch linux:
+//#cgo linux LDFLAGS:-L/opt/cuda/lib64 -L/opt/cuda/lib
+//#cgo linux CFLAGS: -I/opt/cuda/include
+//
+////Darwin:
+//#cgo darwin LDFLAGS:-L/usr/local/cuda/lib
+//#cgo darwin CFLAGS: -I/usr/local/cuda/include/
+//
+////WINDOWS:
+//#cgo windows LDFLAGS:-LC:/cuda/v5.0/lib/x64 -LC:/cuda/v5.5/lib/x64 -LC:/cuda/v6.0/lib/x64 -LC:/cuda/v6.5/lib/x64 -LC:/cuda/v7.0/lib/x64 -LC:/cuda/v8.0/lib/x64 -LC:/cuda/v9.0/x64
+//#cgo windows CFLAGS: -IC:/cuda/v5.0/include -IC:/cuda/v5.5/include -IC:/cuda/v6.0/include -IC:/cuda/v6.5/include -IC:/cuda/v7.0/include -IC:/cuda/v8.0/include -IC:/cuda/v9.0/include
+import "C"
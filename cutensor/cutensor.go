@@ -0,0 +1,331 @@
+// Package cutensor provides bindings to cuTENSOR (2.0 or later), for the tensor contractions and reductions that the
+// cublas package cannot lower to GEMM.
+//
+// A Contractor evaluates einsum expressions, and plugs into the Einsum of the cublas package:
+//
+//	c, _ := cutensor.NewContractor(stream)
+//	defer c.Close()
+//	impl := cublas.New(cublas.WithContractor(c))
+//	out, err := impl.Einsum("ii->i", a) // the diagonal of a
+//
+// Every kind of expression is supported: contractions of two operands with arbitrary strides, reductions and
+// permutations of one operand, diagonals, and chains of three or more operands (contracted pairwise, from left to
+// right).
+package cutensor
+
+// #include <cutensor.h>
+import "C"
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+	cublas "gorgonia.org/cu/blas"
+)
+
+// Version returns the version of cuTENSOR.
+func Version() int { return int(C.cutensorGetVersion()) }
+
+// Contractor evaluates einsum expressions with cuTENSOR, on a stream. It implements cublas.Contractor.
+//
+// A Contractor keeps a workspace in device memory, which grows to the largest one asked for, and relies on the plan
+// cache of its cuTENSOR handle for the expressions it has seen before. It is bound to the context that was current when
+// it was created. It may be used by several goroutines, but the calls are serialized.
+type Contractor struct {
+	stream cu.Stream
+
+	mu           sync.Mutex
+	h            C.cutensorHandle_t
+	workspace    cu.DevicePtr
+	workspaceLen int64
+}
+
+var _ cublas.Contractor = (*Contractor)(nil)
+
+// NewContractor creates a Contractor that works on the given stream.
+func NewContractor(stream cu.Stream) (*Contractor, error) {
+	var h C.cutensorHandle_t
+	if err := result(C.cutensorCreate(&h)); err != nil {
+		return nil, errors.Wrap(err, "Unable to create a cuTENSOR handle")
+	}
+	return &Contractor{stream: stream, h: h}, nil
+}
+
+// Close waits for the stream, frees the workspace and destroys the handle.
+func (c *Contractor) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.h == nil {
+		return nil
+	}
+	if err := c.stream.Synchronize(); err != nil {
+		return err
+	}
+	if c.workspace != 0 {
+		cu.MemFree(c.workspace)
+		c.workspace, c.workspaceLen = 0, 0
+	}
+	err := result(C.cutensorDestroy(c.h))
+	c.h = nil
+	return err
+}
+
+// Contract evaluates the expression over the operands, and writes the result into out. It is asynchronous, unless
+// the expression needs intermediate results (a reduction before a contraction, or three or more operands): those are
+// freed once the stream is done with them.
+func (c *Contractor) Contract(spec *cublas.EinsumSpec, out cu.DeviceTensor, operands ...cu.DeviceTensor) (err error) {
+	sizes, err := spec.Sizes(operands...)
+	if err != nil {
+		return err
+	}
+	if err = out.Check(); err != nil {
+		return errors.Wrapf(err, "Einsum %v: output", spec)
+	}
+	if out.Dtype != operands[0].Dtype {
+		return errors.Errorf("Einsum %v: output is %v, but the operands are %v", spec, out.Dtype, operands[0].Dtype)
+	}
+	want := spec.OutputShape(sizes)
+	if len(want) != out.Dims() {
+		return errors.Errorf("Einsum %v: output has shape %v. Expected %v", spec, out.Shape, want)
+	}
+	for i := range want {
+		if want[i] != out.Shape[i] {
+			return errors.Errorf("Einsum %v: output has shape %v. Expected %v", spec, out.Shape, want)
+		}
+	}
+	ty, err := typeOf(out.Dtype)
+	if err != nil {
+		return errors.Wrapf(err, "Einsum %v", spec)
+	}
+	if out.Len() == 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.h == nil {
+		return errors.New("The Contractor is closed")
+	}
+
+	tensors := make([]tensor, len(operands))
+	inputs := make([]string, len(operands))
+	for i, op := range operands {
+		tensors[i] = newTensor(spec.Inputs[i], op)
+		inputs[i] = tensors[i].modes
+	}
+	var intermediates []cu.DevicePtr
+	defer func() {
+		if len(intermediates) == 0 {
+			return
+		}
+		if serr := c.stream.Synchronize(); serr != nil && err == nil {
+			err = serr
+		}
+		for _, mem := range intermediates {
+			cu.MemFree(mem)
+		}
+	}()
+
+	steps := plan(inputs, spec.Output)
+	for i, s := range steps {
+		dst := newTensor(spec.Output, out)
+		if i < len(steps)-1 {
+			shape := make([]int, len(s.out))
+			for j := range shape {
+				shape[j] = sizes[s.out[j]]
+			}
+			t := cu.NewDeviceTensor(0, out.Dtype, shape...)
+			n := t.Len()
+			if n == 0 {
+				n = 1 // cu.MemAlloc does not allocate nothing
+			}
+			if t.Ptr, err = cu.MemAlloc(int64(n) * out.Dtype.Size()); err != nil {
+				return errors.Wrapf(err, "Einsum %v: unable to allocate an intermediate result", spec)
+			}
+			intermediates = append(intermediates, t.Ptr)
+			dst = newTensor(s.out, t)
+		}
+		if s.b < 0 {
+			err = c.reduce(ty, tensors[s.a], dst)
+		} else {
+			err = c.contract(ty, tensors[s.a], tensors[s.b], dst)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "Einsum %v", spec)
+		}
+		tensors = append(tensors, dst)
+	}
+	return nil
+}
+
+// contract computes d = a b, contracting the modes that d does not have.
+func (c *Contractor) contract(ty dtype, a, b, d tensor) error {
+	descs, err := c.describe(ty, a, b, d)
+	if err != nil {
+		return err
+	}
+	defer destroy(descs)
+	var op C.cutensorOperationDescriptor_t
+	if err = result(C.cutensorCreateContraction(c.h, &op,
+		descs[0], modes(a), C.CUTENSOR_OP_IDENTITY,
+		descs[1], modes(b), C.CUTENSOR_OP_IDENTITY,
+		descs[2], modes(d), C.CUTENSOR_OP_IDENTITY,
+		descs[2], modes(d), ty.compute)); err != nil {
+		return errors.Wrap(err, "Unable to describe the contraction")
+	}
+	defer C.cutensorDestroyOperationDescriptor(op)
+	return c.execute(op, func(plan C.cutensorPlan_t, workspace unsafe.Pointer, size C.uint64_t) C.cutensorStatus_t {
+		return C.cutensorContract(c.h, plan, ty.one, a.ptr.Pointer(), b.ptr.Pointer(), ty.zero, d.ptr.Pointer(), d.ptr.Pointer(),
+			workspace, size, C.cudaStream_t(c.stream.Pointer()))
+	})
+}
+
+// reduce computes d as the sum of a over the modes that d does not have. If there are none, it permutes a.
+func (c *Contractor) reduce(ty dtype, a, d tensor) error {
+	descs, err := c.describe(ty, a, d)
+	if err != nil {
+		return err
+	}
+	defer destroy(descs)
+	var op C.cutensorOperationDescriptor_t
+	if err = result(C.cutensorCreateReduction(c.h, &op,
+		descs[0], modes(a), C.CUTENSOR_OP_IDENTITY,
+		descs[1], modes(d), C.CUTENSOR_OP_IDENTITY,
+		descs[1], modes(d), C.CUTENSOR_OP_ADD, ty.compute)); err != nil {
+		return errors.Wrap(err, "Unable to describe the reduction")
+	}
+	defer C.cutensorDestroyOperationDescriptor(op)
+	return c.execute(op, func(plan C.cutensorPlan_t, workspace unsafe.Pointer, size C.uint64_t) C.cutensorStatus_t {
+		return C.cutensorReduce(c.h, plan, ty.one, a.ptr.Pointer(), ty.zero, d.ptr.Pointer(), d.ptr.Pointer(),
+			workspace, size, C.cudaStream_t(c.stream.Pointer()))
+	})
+}
+
+// execute plans the operation with the workspace it asks for, and runs it.
+func (c *Contractor) execute(op C.cutensorOperationDescriptor_t, run func(C.cutensorPlan_t, unsafe.Pointer, C.uint64_t) C.cutensorStatus_t) error {
+	var pref C.cutensorPlanPreference_t
+	if err := result(C.cutensorCreatePlanPreference(c.h, &pref, C.CUTENSOR_ALGO_DEFAULT, C.CUTENSOR_JIT_MODE_NONE)); err != nil {
+		return errors.Wrap(err, "Unable to create a plan preference")
+	}
+	defer C.cutensorDestroyPlanPreference(pref)
+	var size C.uint64_t
+	if err := result(C.cutensorEstimateWorkspaceSize(c.h, op, pref, C.CUTENSOR_WORKSPACE_DEFAULT, &size)); err != nil {
+		return errors.Wrap(err, "Unable to estimate the size of the workspace")
+	}
+	if err := c.reserve(int64(size)); err != nil {
+		return err
+	}
+	var plan C.cutensorPlan_t
+	if err := result(C.cutensorCreatePlan(c.h, &plan, op, pref, C.uint64_t(c.workspaceLen))); err != nil {
+		return errors.Wrap(err, "Unable to plan the operation")
+	}
+	defer C.cutensorDestroyPlan(plan)
+	return result(run(plan, c.workspace.Pointer(), C.uint64_t(c.workspaceLen)))
+}
+
+// reserve grows the workspace to at least size bytes. The previous workspace is freed once the stream is done with it.
+func (c *Contractor) reserve(size int64) error {
+	if size <= c.workspaceLen {
+		return nil
+	}
+	if c.workspace != 0 {
+		if err := c.stream.Synchronize(); err != nil {
+			return err
+		}
+		if err := cu.MemFree(c.workspace); err != nil {
+			return err
+		}
+		c.workspace, c.workspaceLen = 0, 0
+	}
+	mem, err := cu.MemAlloc(size)
+	if err != nil {
+		return errors.Wrapf(err, "Unable to allocate a workspace of %d bytes", size)
+	}
+	c.workspace, c.workspaceLen = mem, size
+	return nil
+}
+
+// describe creates the descriptors of the tensors. On failure, the descriptors already created are destroyed.
+func (c *Contractor) describe(ty dtype, tensors ...tensor) ([]C.cutensorTensorDescriptor_t, error) {
+	descs := make([]C.cutensorTensorDescriptor_t, 0, len(tensors))
+	for _, t := range tensors {
+		var desc C.cutensorTensorDescriptor_t
+		var extents, strides *C.int64_t
+		if len(t.modes) > 0 {
+			extents, strides = (*C.int64_t)(&t.extents[0]), (*C.int64_t)(&t.strides[0])
+		}
+		if err := result(C.cutensorCreateTensorDescriptor(c.h, &desc, C.uint32_t(len(t.modes)), extents, strides, ty.data, C.uint32_t(alignment(t.ptr)))); err != nil {
+			destroy(descs)
+			return nil, errors.Wrap(err, "Unable to describe a tensor")
+		}
+		descs = append(descs, desc)
+	}
+	return descs, nil
+}
+
+func destroy(descs []C.cutensorTensorDescriptor_t) {
+	for _, desc := range descs {
+		C.cutensorDestroyTensorDescriptor(desc)
+	}
+}
+
+// modes returns the modes of the tensor, as cuTENSOR takes them: one integer per subscript.
+func modes(t tensor) *C.int32_t {
+	if len(t.modes) == 0 {
+		return nil
+	}
+	retVal := make([]int32, len(t.modes))
+	for i := range retVal {
+		retVal[i] = int32(t.modes[i])
+	}
+	return (*C.int32_t)(&retVal[0])
+}
+
+// alignment returns the alignment of ptr in bytes, up to the 256 bytes cuTENSOR cares about.
+func alignment(ptr cu.DevicePtr) int {
+	a := 256
+	for a > 1 && uintptr(ptr)%uintptr(a) != 0 {
+		a >>= 1
+	}
+	return a
+}
+
+// The scalars 1 and 0, as float, double, cuComplex or cuDoubleComplex.
+var (
+	one32, zero32 = [2]float32{1, 0}, [2]float32{0, 0}
+	one64, zero64 = [2]float64{1, 0}, [2]float64{0, 0}
+)
+
+// dtype is how cuTENSOR computes on the elements of a Dtype.
+type dtype struct {
+	data      C.cutensorDataType_t
+	compute   C.cutensorComputeDescriptor_t
+	one, zero unsafe.Pointer // the scalars 1 and 0, in the type the compute descriptor takes them
+}
+
+func typeOf(dt cu.Dtype) (dtype, error) {
+	single := dtype{compute: C.CUTENSOR_COMPUTE_DESC_32F, one: unsafe.Pointer(&one32[0]), zero: unsafe.Pointer(&zero32[0])}
+	double := dtype{compute: C.CUTENSOR_COMPUTE_DESC_64F, one: unsafe.Pointer(&one64[0]), zero: unsafe.Pointer(&zero64[0])}
+	switch dt {
+	case cu.DtFloat32:
+		single.data = C.CUTENSOR_R_32F
+		return single, nil
+	case cu.DtFloat16:
+		single.data = C.CUTENSOR_R_16F
+		return single, nil
+	case cu.DtBFloat16:
+		single.data = C.CUTENSOR_R_16BF
+		return single, nil
+	case cu.DtComplex64:
+		single.data = C.CUTENSOR_C_32F
+		return single, nil
+	case cu.DtFloat64:
+		double.data = C.CUTENSOR_R_64F
+		return double, nil
+	case cu.DtComplex128:
+		double.data = C.CUTENSOR_C_64F
+		return double, nil
+	}
+	return dtype{}, errors.Errorf("cuTENSOR does not support %v", dt)
+}
@@ -0,0 +1,115 @@
+package cutensor
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"gorgonia.org/cu"
+	cublas "gorgonia.org/cu/blas"
+)
+
+func TestNewTensor(t *testing.T) {
+	// the diagonal of a 3x3 row-major matrix has a stride of 3+1
+	got := newTensor("ii", cu.NewDeviceTensor(0, cu.DtFloat32, 3, 3))
+	if got.modes != "i" || !reflect.DeepEqual(got.extents, []int64{3}) || !reflect.DeepEqual(got.strides, []int64{4}) {
+		t.Errorf("Diagonal: got %+v", got)
+	}
+	got = newTensor("iji", cu.NewDeviceTensor(0, cu.DtFloat32, 2, 5, 2))
+	if got.modes != "ij" || !reflect.DeepEqual(got.extents, []int64{2, 5}) || !reflect.DeepEqual(got.strides, []int64{11, 2}) {
+		t.Errorf("Diagonal around another mode: got %+v", got)
+	}
+	if got = newTensor("", cu.NewDeviceTensor(0, cu.DtFloat32)); got.modes != "" || len(got.extents) != 0 {
+		t.Errorf("Scalar: got %+v", got)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	cases := []struct {
+		inputs []string
+		output string
+		want   []step
+	}{
+		{[]string{"ij"}, "j", []step{{0, -1, "j"}}},
+		{[]string{"ij"}, "ji", []step{{0, -1, "ji"}}},
+		{[]string{"ij", "jk"}, "ik", []step{{0, 1, "ik"}}},
+		{[]string{"bij", "bjk"}, "bik", []step{{0, 1, "bik"}}},
+
+		// i is only in the first operand, and l only in the second: both are reduced away first
+		{[]string{"ij", "jl"}, "", []step{{0, -1, "j"}, {1, -1, "j"}, {2, 3, ""}}},
+
+		// the chain keeps what the later operands and the output need
+		{[]string{"ij", "jk", "kl"}, "il", []step{{0, 1, "ik"}, {3, 2, "il"}}},
+		{[]string{"ij", "jk", "ki"}, "", []step{{0, 1, "ik"}, {3, 2, ""}}},
+		{[]string{"i", "i", ""}, "", []step{{0, 1, ""}, {3, 2, ""}}},
+	}
+	for _, c := range cases {
+		if got := plan(c.inputs, c.output); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("plan(%q, %q): expected %v. Got %v", c.inputs, c.output, c.want, got)
+		}
+	}
+}
+
+func TestContract(t *testing.T) {
+	if devices, _ := cu.NumDevices(); devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := cu.Device(0).MakeContext(cu.SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+
+	c, err := NewContractor(cu.Stream{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	impl := cublas.New(cublas.WithContractor(c))
+
+	upload := func(data []float32, shape ...int) cu.DeviceTensor {
+		mem, err := cu.MemAlloc(int64(len(data) * 4))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = cu.MemcpyHtoD(mem, unsafe.Pointer(&data[0]), int64(len(data)*4)); err != nil {
+			t.Fatal(err)
+		}
+		return cu.NewDeviceTensor(mem, cu.DtFloat32, shape...)
+	}
+	// A = [1 2; 3 4], B = [0 1; 1 0]
+	A := upload([]float32{1, 2, 3, 4}, 2, 2)
+	B := upload([]float32{0, 1, 1, 0}, 2, 2)
+	defer cu.MemFree(A.Ptr)
+	defer cu.MemFree(B.Ptr)
+
+	cases := []struct {
+		spec     string
+		operands []cu.DeviceTensor
+		want     []float32
+	}{
+		{"ii->i", []cu.DeviceTensor{A}, []float32{1, 4}},
+		{"ij->j", []cu.DeviceTensor{A}, []float32{4, 6}},
+		{"ij->ji", []cu.DeviceTensor{A}, []float32{1, 3, 2, 4}},
+		{"ij,jk,kl->il", []cu.DeviceTensor{A, B, A}, []float32{5, 8, 13, 20}},
+		{"ij,kl->", []cu.DeviceTensor{A, B}, []float32{20}},
+	}
+	for _, tc := range cases {
+		out, err := impl.Einsum(tc.spec, tc.operands...)
+		if err != nil {
+			t.Errorf("%v: %v", tc.spec, err)
+			continue
+		}
+		got := make([]float32, out.Len())
+		if err = cu.MemcpyDtoH(unsafe.Pointer(&got[0]), out.Ptr, int64(len(got)*4)); err != nil {
+			t.Fatal(err)
+		}
+		cu.MemFree(out.Ptr)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: expected %v. Got %v", tc.spec, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package cutensor
+
+import (
+	"strings"
+
+	"gorgonia.org/cu"
+)
+
+// tensor is an operand as cuTENSOR sees it: a mode per distinct subscript. The dimensions of a subscript repeated in
+// an operand (a diagonal) are merged into one, whose stride is the sum of theirs.
+type tensor struct {
+	ptr     cu.DevicePtr
+	modes   string
+	extents []int64
+	strides []int64
+}
+
+func newTensor(sub string, t cu.DeviceTensor) tensor {
+	retVal := tensor{ptr: t.Ptr}
+	for i := 0; i < len(sub); i++ {
+		if j := strings.IndexByte(retVal.modes, sub[i]); j >= 0 {
+			retVal.strides[j] += int64(t.Strides[i])
+			continue
+		}
+		retVal.modes += sub[i : i+1]
+		retVal.extents = append(retVal.extents, int64(t.Shape[i]))
+		retVal.strides = append(retVal.strides, int64(t.Strides[i]))
+	}
+	return retVal
+}
+
+// step is a call to cuTENSOR: the contraction of the operands a and b, or the reduction of a if b is negative. The
+// operands are numbered as the inputs of the expression, followed by the results of the steps before. The last step
+// writes the output.
+type step struct {
+	a, b int
+	out  string // the subscripts of the result
+}
+
+// plan returns the steps that evaluate the expression over operands with the given (distinct) subscripts.
+//
+// The operands are contracted pairwise, from left to right. cuTENSOR requires every mode of a contraction to appear in
+// at least two of its tensors, so the subscripts that only one side of a contraction has, and that neither the output
+// nor a later operand needs, are reduced away first.
+func plan(inputs []string, output string) []step {
+	if len(inputs) == 1 {
+		return []step{{a: 0, b: -1, out: output}}
+	}
+	subs := append([]string(nil), inputs...)
+	var steps []step
+	push := func(a, b int, out string) int {
+		steps = append(steps, step{a: a, b: b, out: out})
+		subs = append(subs, out)
+		return len(subs) - 1
+	}
+	// needed returns the subscripts of s that the output, other, or an operand after the i-th needs
+	needed := func(s string, i int, other string) string {
+		var retVal []byte
+		for j := 0; j < len(s); j++ {
+			l := s[j]
+			if strings.IndexByte(output, l) >= 0 || strings.IndexByte(other, l) >= 0 || anyHas(inputs[i+1:], l) {
+				retVal = append(retVal, l)
+			}
+		}
+		return string(retVal)
+	}
+
+	a := 0
+	for i := 1; i < len(inputs); i++ {
+		if keep := needed(subs[a], i, subs[i]); len(keep) < len(subs[a]) {
+			a = push(a, -1, keep)
+		}
+		b := i
+		if keep := needed(subs[i], i, subs[a]); len(keep) < len(subs[i]) {
+			b = push(i, -1, keep)
+		}
+		out := output
+		if i < len(inputs)-1 {
+			out = needed(subs[a], i, "")
+			for _, l := range []byte(needed(subs[b], i, "")) {
+				if strings.IndexByte(out, l) < 0 {
+					out += string(l)
+				}
+			}
+		}
+		a = push(a, b, out)
+	}
+	return steps
+}
+
+func anyHas(subs []string, l byte) bool {
+	for _, s := range subs {
+		if strings.IndexByte(s, l) >= 0 {
+			return true
+		}
+	}
+	return false
+}
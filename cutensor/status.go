@@ -0,0 +1,57 @@
+package cutensor
+
+// #include <cutensor.h>
+import "C"
+
+// Status is the status returned by cuTENSOR.
+type Status int
+
+func (err Status) Error() string  { return err.String() }
+func (err Status) String() string { return resString[err] }
+
+func result(x C.cutensorStatus_t) error {
+	err := Status(x)
+	if err == Success {
+		return nil
+	}
+	if _, ok := resString[err]; !ok {
+		return InternalError
+	}
+	return err
+}
+
+const (
+	Success               Status = C.CUTENSOR_STATUS_SUCCESS
+	NotInitialized        Status = C.CUTENSOR_STATUS_NOT_INITIALIZED
+	AllocFailed           Status = C.CUTENSOR_STATUS_ALLOC_FAILED
+	InvalidValue          Status = C.CUTENSOR_STATUS_INVALID_VALUE
+	ArchMismatch          Status = C.CUTENSOR_STATUS_ARCH_MISMATCH
+	MappingError          Status = C.CUTENSOR_STATUS_MAPPING_ERROR
+	ExecutionFailed       Status = C.CUTENSOR_STATUS_EXECUTION_FAILED
+	InternalError         Status = C.CUTENSOR_STATUS_INTERNAL_ERROR
+	NotSupported          Status = C.CUTENSOR_STATUS_NOT_SUPPORTED
+	LicenseError          Status = C.CUTENSOR_STATUS_LICENSE_ERROR
+	CublasError           Status = C.CUTENSOR_STATUS_CUBLAS_ERROR
+	CudaError             Status = C.CUTENSOR_STATUS_CUDA_ERROR
+	InsufficientWorkspace Status = C.CUTENSOR_STATUS_INSUFFICIENT_WORKSPACE
+	InsufficientDriver    Status = C.CUTENSOR_STATUS_INSUFFICIENT_DRIVER
+	IOError               Status = C.CUTENSOR_STATUS_IO_ERROR
+)
+
+var resString = map[Status]string{
+	Success:               "Success",
+	NotInitialized:        "NotInitialized",
+	AllocFailed:           "AllocFailed",
+	InvalidValue:          "InvalidValue",
+	ArchMismatch:          "ArchMismatch",
+	MappingError:          "MappingError",
+	ExecutionFailed:       "ExecutionFailed",
+	InternalError:         "InternalError",
+	NotSupported:          "NotSupported",
+	LicenseError:          "LicenseError",
+	CublasError:           "CublasError",
+	CudaError:             "CudaError",
+	InsufficientWorkspace: "InsufficientWorkspace",
+	InsufficientDriver:    "InsufficientDriver",
+	IOError:               "IOError",
+}
@@ -0,0 +1,100 @@
+package cu
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// LaunchConfig is the configuration of a kernel launch. Unlike the handles of this package, it is a plain value that
+// can be encoded with encoding/json or encoding/gob, so that a tuned configuration can be stored and reused on another
+// machine with the same DeviceModel.
+type LaunchConfig struct {
+	Grid      [3]int `json:"grid"`
+	Block     [3]int `json:"block"`
+	SharedMem int    `json:"sharedMem,omitempty"` // bytes of dynamic shared memory
+
+	// Cluster are the dimensions of the thread block clusters, in blocks. The blocks of a cluster run at the same time on
+	// a GPU processing cluster, and access the shared memory of each other (distributed shared memory). The grid must be
+	// a multiple of the clusters. Clusters need a device of compute capability 9.0; the zero value launches no clusters.
+	// Clusters of more than 8 blocks are not portable, and need the NonPortableClusterSizeAllowed attribute of the
+	// function to be set (see SetAttribute).
+	Cluster [3]int `json:"cluster,omitempty"`
+}
+
+// Validate checks that the dimensions of the configuration are positive, and that the grid is a multiple of the
+// clusters.
+func (cfg LaunchConfig) Validate() error {
+	for i := 0; i < 3; i++ {
+		if cfg.Grid[i] < 1 || cfg.Block[i] < 1 {
+			return errors.Errorf("Invalid launch configuration: grid %v, block %v", cfg.Grid, cfg.Block)
+		}
+	}
+	if cfg.SharedMem < 0 {
+		return errors.Errorf("Invalid launch configuration: %d bytes of shared memory", cfg.SharedMem)
+	}
+	if cfg.clustered() {
+		for i := 0; i < 3; i++ {
+			if cfg.Cluster[i] < 1 || cfg.Grid[i]%cfg.Cluster[i] != 0 {
+				return errors.Errorf("Invalid launch configuration: grid %v in clusters %v", cfg.Grid, cfg.Cluster)
+			}
+		}
+	}
+	return nil
+}
+
+// clustered returns true if the blocks are launched in clusters.
+func (cfg LaunchConfig) clustered() bool { return cfg.Cluster != [3]int{} }
+
+// LaunchWith launches a CUDA function with the given configuration. Launches in clusters are made with
+// cuLaunchKernelEx, the others as by Launch.
+func (fn Function) LaunchWith(cfg LaunchConfig, stream Stream, kernelParams []unsafe.Pointer) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if cfg.clustered() {
+		if timingKernels() {
+			return timeLaunch(fn, stream, func() error { return fn.launchEx(cfg, nil, stream, kernelParams) })
+		}
+		return fn.launchEx(cfg, nil, stream, kernelParams)
+	}
+	return fn.Launch(cfg.Grid[0], cfg.Grid[1], cfg.Grid[2], cfg.Block[0], cfg.Block[1], cfg.Block[2], cfg.SharedMem, stream, kernelParams)
+}
+
+// DeviceModel identifies a model of GPU. Tuned configurations are recorded along with the model they were tuned on,
+// and should only be reused on devices of the same model.
+type DeviceModel struct {
+	Name  string `json:"name"`
+	Major int    `json:"major"` // compute capability
+	Minor int    `json:"minor"`
+}
+
+// ModelOf returns the model of the device.
+func ModelOf(d Device) (DeviceModel, error) {
+	name, err := d.Name()
+	if err != nil {
+		return DeviceModel{}, errors.Wrapf(err, "Unable to get the name of %v", d)
+	}
+	major, minor, err := d.ComputeCapability()
+	if err != nil {
+		return DeviceModel{}, err
+	}
+	return DeviceModel{Name: name, Major: major, Minor: minor}, nil
+}
+
+// Check returns an error if the device is not of the model.
+func (m DeviceModel) Check(d Device) error {
+	got, err := ModelOf(d)
+	if err != nil {
+		return err
+	}
+	if got != m {
+		return errors.Errorf("%v is a %v, expected a %v", d, got, m)
+	}
+	return nil
+}
+
+func (m DeviceModel) String() string {
+	return fmt.Sprintf("%s (sm_%d%d)", m.Name, m.Major, m.Minor)
+}
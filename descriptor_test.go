@@ -0,0 +1,55 @@
+package cu
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescriptorEncoding(t *testing.T) {
+	assert := assert.New(t)
+	type tuned struct {
+		Model  DeviceModel
+		Dtype  Dtype
+		Launch LaunchConfig
+	}
+	want := tuned{
+		Model:  DeviceModel{Name: "NVIDIA A100-SXM4-40GB", Major: 8, Minor: 0},
+		Dtype:  DtBFloat16,
+		Launch: LaunchConfig{Grid: [3]int{128, 1, 1}, Block: [3]int{256, 1, 1}, SharedMem: 4096, Cluster: [3]int{2, 1, 1}},
+	}
+
+	data, err := json.Marshal(want)
+	assert.Nil(err)
+	assert.Contains(string(data), `"Dtype":"bfloat16"`)
+	var got tuned
+	assert.Nil(json.Unmarshal(data, &got))
+	assert.Equal(want, got)
+
+	var buf bytes.Buffer
+	assert.Nil(gob.NewEncoder(&buf).Encode(want))
+	assert.Contains(buf.String(), "bfloat16")
+	got = tuned{}
+	assert.Nil(gob.NewDecoder(&buf).Decode(&got))
+	assert.Equal(want, got)
+
+	var dt Dtype
+	assert.NotNil(json.Unmarshal([]byte(`"float128"`), &dt))
+	_, err = json.Marshal(DtInvalid)
+	assert.NotNil(err)
+}
+
+func TestLaunchConfig_Validate(t *testing.T) {
+	assert := assert.New(t)
+	assert.Nil(LaunchConfig{Grid: [3]int{1, 1, 1}, Block: [3]int{32, 1, 1}}.Validate())
+	assert.NotNil(LaunchConfig{Grid: [3]int{1, 0, 1}, Block: [3]int{32, 1, 1}}.Validate())
+	assert.NotNil(LaunchConfig{Grid: [3]int{1, 1, 1}, Block: [3]int{32, 1, 1}, SharedMem: -1}.Validate())
+
+	// clusters
+	assert.Nil(LaunchConfig{Grid: [3]int{8, 2, 1}, Block: [3]int{128, 1, 1}, Cluster: [3]int{4, 2, 1}}.Validate())
+	assert.NotNil(LaunchConfig{Grid: [3]int{8, 3, 1}, Block: [3]int{128, 1, 1}, Cluster: [3]int{4, 2, 1}}.Validate())
+	assert.NotNil(LaunchConfig{Grid: [3]int{8, 2, 1}, Block: [3]int{128, 1, 1}, Cluster: [3]int{4, 0, 1}}.Validate())
+}
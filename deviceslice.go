@@ -0,0 +1,103 @@
+package cu
+
+import (
+	"fmt"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// memRef counts the references to an allocation. The allocation is freed when the last reference is released.
+type memRef struct {
+	base DevicePtr
+	free func(DevicePtr) error
+	refs int32 // atomic
+}
+
+func (r *memRef) retain() { atomic.AddInt32(&r.refs, 1) }
+
+func (r *memRef) release() error {
+	if atomic.AddInt32(&r.refs, -1) == 0 {
+		return r.free(r.base)
+	}
+	return nil
+}
+
+// DeviceSlice is a range of device memory that owns a reference to the allocation it is in.
+//
+// Views of a DeviceSlice made with SubSlice hold references of their own: freeing the parent while views are
+// outstanding does not invalidate them. The allocation is freed when the parent and all its views have been freed.
+type DeviceSlice struct {
+	ptr   DevicePtr
+	size  int64
+	ref   *memRef
+	freed uint32 // atomic
+}
+
+// MakeDeviceSlice allocates a DeviceSlice of size bytes with MemAlloc.
+func MakeDeviceSlice(size int64) (*DeviceSlice, error) {
+	ptr, err := MemAlloc(size)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to allocate a DeviceSlice of %d bytes", size)
+	}
+	return newDeviceSlice(ptr, size, MemFree), nil
+}
+
+// AllocSlice allocates a DeviceSlice of size bytes from the pool. The memory is returned to the pool once the slice
+// and its views are freed.
+func (p *MemPool) AllocSlice(size int64) (*DeviceSlice, error) {
+	ptr, err := p.Alloc(size)
+	if err != nil {
+		return nil, err
+	}
+	return newDeviceSlice(ptr, size, p.Free), nil
+}
+
+func newDeviceSlice(ptr DevicePtr, size int64, free func(DevicePtr) error) *DeviceSlice {
+	return &DeviceSlice{ptr: ptr, size: size, ref: &memRef{base: ptr, free: free, refs: 1}}
+}
+
+// Ptr returns the start of the slice.
+func (s *DeviceSlice) Ptr() DevicePtr { return s.ptr }
+
+// Len returns the size of the slice, in bytes.
+func (s *DeviceSlice) Len() int64 { return s.size }
+
+// SubSlice returns a view of the bytes [lo, hi) of the slice. The view keeps the allocation alive until it is freed.
+func (s *DeviceSlice) SubSlice(lo, hi int64) (*DeviceSlice, error) {
+	if atomic.LoadUint32(&s.freed) != 0 {
+		return nil, errors.Errorf("Cannot slice %v: it has been freed", s)
+	}
+	if lo < 0 || hi > s.size || lo > hi {
+		return nil, errors.Errorf("Invalid slice [%d:%d] of %v", lo, hi, s)
+	}
+	s.ref.retain()
+	return &DeviceSlice{ptr: s.ptr.Offset(lo), size: hi - lo, ref: s.ref}, nil
+}
+
+// Free releases the reference of the slice to its allocation. The memory is freed once the allocation has no other
+// references. A slice must be freed once only.
+func (s *DeviceSlice) Free() error {
+	if !atomic.CompareAndSwapUint32(&s.freed, 0, 1) {
+		return errors.Errorf("%v has already been freed", s)
+	}
+	return s.ref.release()
+}
+
+// Uintptr returns the start of the slice in form of a uintptr.
+func (s *DeviceSlice) Uintptr() uintptr { return uintptr(s.ptr) }
+
+// Pointer returns the start of the slice in form of an unsafe.Pointer.
+// The pointer is on the device, and must not be dereferenced by the host.
+func (s *DeviceSlice) Pointer() unsafe.Pointer { return s.ptr.Pointer() }
+
+// IsNativelyAccessible returns false.
+func (s *DeviceSlice) IsNativelyAccessible() bool { return false }
+
+// IsCUDAMemory returns true.
+func (s *DeviceSlice) IsCUDAMemory() bool { return true }
+
+func (s *DeviceSlice) String() string {
+	return fmt.Sprintf("DeviceSlice{%v %d bytes}", s.ptr, s.size)
+}
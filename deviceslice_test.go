@@ -0,0 +1,52 @@
+package cu
+
+import "testing"
+
+func TestDeviceSlice_SubSlice(t *testing.T) {
+	var allocated, freed int
+	p := newFakePool(&allocated, &freed)
+	defer p.Close()
+
+	s, err := p.AllocSlice(1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := s.SubSlice(256, 512)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Ptr() != s.Ptr()+256 || v.Len() != 256 {
+		t.Errorf("Expected a view of 256 bytes at %v. Got %v", s.Ptr()+256, v)
+	}
+	w, err := v.SubSlice(0, 128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.SubSlice(512, 1025); err == nil {
+		t.Error("Expected an error slicing out of the slice")
+	}
+
+	// freeing the parent leaves the views valid
+	if err = s.Free(); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Free(); err == nil {
+		t.Error("Expected an error freeing the slice twice")
+	}
+	if _, err = s.SubSlice(0, 1); err == nil {
+		t.Error("Expected an error slicing a freed slice")
+	}
+	if s := p.Stats(); s.InUse == 0 {
+		t.Error("The memory was returned to the pool while views were outstanding")
+	}
+
+	if err = v.Free(); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Free(); err != nil {
+		t.Fatal(err)
+	}
+	if s := p.Stats(); s.InUse != 0 || s.Frees != 1 {
+		t.Errorf("Expected the memory to be returned to the pool once, when the last view was freed. Got %+v", s)
+	}
+}
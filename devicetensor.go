@@ -0,0 +1,254 @@
+package cu
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// DeviceTensor describes a strided, n-dimensional array of elements in device memory.
+// It does not own the memory it points to.
+//
+// Strides are in elements, not bytes. A tensor with no dimensions is a scalar.
+//
+// Slice, Reshape and Transpose return views of the tensor: they only change the pointer, shape and strides,
+// and never copy the elements.
+type DeviceTensor struct {
+	Ptr     DevicePtr
+	Dtype   Dtype
+	Shape   []int
+	Strides []int
+}
+
+// NewDeviceTensor creates a DeviceTensor of the given shape over ptr, with contiguous row-major strides.
+func NewDeviceTensor(ptr DevicePtr, dt Dtype, shape ...int) DeviceTensor {
+	return DeviceTensor{
+		Ptr:     ptr,
+		Dtype:   dt,
+		Shape:   shape,
+		Strides: RowMajorStrides(shape...),
+	}
+}
+
+// RowMajorStrides returns the strides of a contiguous row-major tensor of the given shape.
+func RowMajorStrides(shape ...int) []int {
+	strides := make([]int, len(shape))
+	acc := 1
+	for i := len(shape) - 1; i >= 0; i-- {
+		strides[i] = acc
+		acc *= shape[i]
+	}
+	return strides
+}
+
+// Dims returns the number of dimensions of the tensor.
+func (t DeviceTensor) Dims() int { return len(t.Shape) }
+
+// Len returns the number of elements in the tensor.
+func (t DeviceTensor) Len() int {
+	n := 1
+	for _, s := range t.Shape {
+		n *= s
+	}
+	return n
+}
+
+// IsContiguous returns true if the elements of the tensor are laid out contiguously in row-major order.
+func (t DeviceTensor) IsContiguous() bool {
+	acc := 1
+	for i := len(t.Shape) - 1; i >= 0; i-- {
+		if t.Shape[i] != 1 && t.Strides[i] != acc {
+			return false
+		}
+		acc *= t.Shape[i]
+	}
+	return true
+}
+
+// Check checks that the tensor is well formed.
+func (t DeviceTensor) Check() error {
+	if t.Dtype.Size() == 0 {
+		return errors.Errorf("Invalid Dtype %v", t.Dtype)
+	}
+	if len(t.Strides) != len(t.Shape) {
+		return errors.Errorf("Tensor has %d dimensions but %d strides", len(t.Shape), len(t.Strides))
+	}
+	for i, s := range t.Shape {
+		if s < 0 {
+			return errors.Errorf("Invalid size %d of dimension %d", s, i)
+		}
+		if t.Strides[i] < 0 {
+			return errors.Errorf("Invalid stride %d of dimension %d", t.Strides[i], i)
+		}
+	}
+	return nil
+}
+
+// CheckMemory checks the pointer of the tensor against its elements (see CheckPtr): that it is aligned to them, and
+// that they are all in its allocation.
+func (t DeviceTensor) CheckMemory() error {
+	size := t.Dtype.Size()
+	if t.Len() == 0 {
+		return CheckPtr(t.Ptr, 0, size)
+	}
+	extent := int64(1) // in elements
+	for i, s := range t.Shape {
+		extent += int64(s-1) * int64(t.Strides[i])
+	}
+	return CheckPtr(t.Ptr, extent*size, size)
+}
+
+// Uintptr returns the address of the first element of the tensor.
+func (t DeviceTensor) Uintptr() uintptr { return uintptr(t.Ptr) }
+
+// Pointer returns the address of the first element of the tensor in form of an unsafe.Pointer.
+// The pointer is on the device, and must not be dereferenced by the host.
+func (t DeviceTensor) Pointer() unsafe.Pointer { return t.Ptr.Pointer() }
+
+// IsNativelyAccessible returns false.
+func (t DeviceTensor) IsNativelyAccessible() bool { return false }
+
+// IsCUDAMemory returns true.
+func (t DeviceTensor) IsCUDAMemory() bool { return true }
+
+// Slice returns a view of the elements [start, end) of the given dimension.
+func (t DeviceTensor) Slice(dim, start, end int) (DeviceTensor, error) {
+	if dim < 0 || dim >= len(t.Shape) {
+		return t, errors.Errorf("Cannot slice dimension %d of a %d dimensional tensor", dim, len(t.Shape))
+	}
+	if start < 0 || end > t.Shape[dim] || start > end {
+		return t, errors.Errorf("Invalid slice [%d:%d] of dimension %d of size %d", start, end, dim, t.Shape[dim])
+	}
+	retVal := t.clone()
+	retVal.Ptr = t.Ptr.Offset(int64(start*t.Strides[dim]) * t.Dtype.Size())
+	retVal.Shape[dim] = end - start
+	return retVal, nil
+}
+
+// Reshape returns a view of the tensor with the given shape. One of the sizes may be -1, in which case it is inferred.
+//
+// Only contiguous tensors can be reshaped without copying; an error is returned for other tensors.
+func (t DeviceTensor) Reshape(shape ...int) (DeviceTensor, error) {
+	shape = append([]int(nil), shape...)
+	infer := -1
+	n := 1
+	for i, s := range shape {
+		switch {
+		case s == -1 && infer < 0:
+			infer = i
+		case s < 0:
+			return t, errors.Errorf("Invalid shape %v", shape)
+		default:
+			n *= s
+		}
+	}
+	if infer >= 0 {
+		if n == 0 || t.Len()%n != 0 {
+			return t, errors.Errorf("Cannot reshape %v into %v", t.Shape, shape)
+		}
+		shape[infer] = t.Len() / n
+		n *= shape[infer]
+	}
+	if n != t.Len() {
+		return t, errors.Errorf("Cannot reshape %v (%d elements) into %v (%d elements)", t.Shape, t.Len(), shape, n)
+	}
+	if !t.IsContiguous() {
+		return t, errors.Errorf("Cannot reshape a non-contiguous tensor (shape %v, strides %v) without copying", t.Shape, t.Strides)
+	}
+	return DeviceTensor{
+		Ptr:     t.Ptr,
+		Dtype:   t.Dtype,
+		Shape:   shape,
+		Strides: RowMajorStrides(shape...),
+	}, nil
+}
+
+// Transpose returns a view of the tensor with its dimensions permuted: dimension i of the result is dimension axes[i]
+// of the tensor. If no axes are given, the dimensions are reversed.
+func (t DeviceTensor) Transpose(axes ...int) (DeviceTensor, error) {
+	if len(axes) == 0 {
+		axes = make([]int, len(t.Shape))
+		for i := range axes {
+			axes[i] = len(axes) - 1 - i
+		}
+	}
+	if len(axes) != len(t.Shape) {
+		return t, errors.Errorf("Expected %d axes to transpose a %d dimensional tensor. Got %v", len(t.Shape), len(t.Shape), axes)
+	}
+	seen := make([]bool, len(axes))
+	retVal := t.clone()
+	for i, a := range axes {
+		if a < 0 || a >= len(axes) || seen[a] {
+			return t, errors.Errorf("Invalid permutation %v", axes)
+		}
+		seen[a] = true
+		retVal.Shape[i] = t.Shape[a]
+		retVal.Strides[i] = t.Strides[a]
+	}
+	return retVal, nil
+}
+
+// BroadcastTo returns a view of the tensor broadcast to the given shape, following NumPy's rules: the dimensions are
+// aligned from the right, and dimensions of size 1 (or missing leading dimensions) are repeated with a stride of 0.
+func (t DeviceTensor) BroadcastTo(shape ...int) (DeviceTensor, error) {
+	if len(shape) < len(t.Shape) {
+		return t, errors.Errorf("Cannot broadcast %v to %v", t.Shape, shape)
+	}
+	retVal := DeviceTensor{
+		Ptr:     t.Ptr,
+		Dtype:   t.Dtype,
+		Shape:   append([]int(nil), shape...),
+		Strides: make([]int, len(shape)),
+	}
+	offset := len(shape) - len(t.Shape)
+	for i, s := range t.Shape {
+		switch {
+		case s == shape[offset+i]:
+			retVal.Strides[offset+i] = t.Strides[i]
+		case s == 1:
+			// stride stays 0
+		default:
+			return t, errors.Errorf("Cannot broadcast %v to %v: dimension %d has size %d, expected 1 or %d", t.Shape, shape, i, s, shape[offset+i])
+		}
+	}
+	return retVal, nil
+}
+
+// BroadcastShapes returns the shape that the given shapes broadcast to, following NumPy's rules.
+func BroadcastShapes(shapes ...[]int) ([]int, error) {
+	var dims int
+	for _, s := range shapes {
+		if len(s) > dims {
+			dims = len(s)
+		}
+	}
+	retVal := make([]int, dims)
+	for i := range retVal {
+		retVal[i] = 1
+	}
+	for _, s := range shapes {
+		offset := dims - len(s)
+		for i, size := range s {
+			switch r := retVal[offset+i]; {
+			case r == size || size == 1:
+			case r == 1:
+				retVal[offset+i] = size
+			default:
+				return nil, errors.Errorf("Shapes %v cannot be broadcast together", shapes)
+			}
+		}
+	}
+	return retVal, nil
+}
+
+// clone returns a copy of the tensor that does not share its shape and strides.
+func (t DeviceTensor) clone() DeviceTensor {
+	t.Shape = append([]int(nil), t.Shape...)
+	t.Strides = append([]int(nil), t.Strides...)
+	return t
+}
+
+func (t DeviceTensor) String() string {
+	return fmt.Sprintf("DeviceTensor{0x%x %v %v strides %v}", uintptr(t.Ptr), t.Dtype, t.Shape, t.Strides)
+}
@@ -0,0 +1,66 @@
+package cu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceTensor_Views(t *testing.T) {
+	assert := assert.New(t)
+	a := NewDeviceTensor(0x1000, DtFloat32, 2, 3, 4)
+	assert.Equal([]int{12, 4, 1}, a.Strides)
+	assert.Equal(24, a.Len())
+	assert.True(a.IsContiguous())
+
+	// slicing offsets the pointer, and keeps the strides
+	s, err := a.Slice(1, 1, 3)
+	assert.Nil(err)
+	assert.Equal(DevicePtr(0x1000+4*4), s.Ptr)
+	assert.Equal([]int{2, 2, 4}, s.Shape)
+	assert.Equal([]int{12, 4, 1}, s.Strides)
+	assert.False(s.IsContiguous())
+	assert.Equal([]int{2, 3, 4}, a.Shape, "the original tensor is not modified")
+
+	_, err = a.Slice(1, 2, 4)
+	assert.NotNil(err)
+
+	// transposing permutes the strides
+	tr, err := a.Transpose()
+	assert.Nil(err)
+	assert.Equal([]int{4, 3, 2}, tr.Shape)
+	assert.Equal([]int{1, 4, 12}, tr.Strides)
+	tr, err = a.Transpose(0, 2, 1)
+	assert.Nil(err)
+	assert.Equal([]int{2, 4, 3}, tr.Shape)
+	assert.Equal([]int{12, 1, 4}, tr.Strides)
+	_, err = a.Transpose(0, 0, 1)
+	assert.NotNil(err)
+
+	// reshaping
+	r, err := a.Reshape(6, -1)
+	assert.Nil(err)
+	assert.Equal([]int{6, 4}, r.Shape)
+	assert.Equal([]int{4, 1}, r.Strides)
+	_, err = a.Reshape(5, -1)
+	assert.NotNil(err)
+	_, err = s.Reshape(16)
+	assert.NotNil(err, "non-contiguous tensors cannot be reshaped without copying")
+}
+
+func TestBroadcast(t *testing.T) {
+	assert := assert.New(t)
+	shape, err := BroadcastShapes([]int{3, 1, 5}, []int{4, 1}, nil)
+	assert.Nil(err)
+	assert.Equal([]int{3, 4, 5}, shape)
+	_, err = BroadcastShapes([]int{3, 2}, []int{4, 2})
+	assert.NotNil(err)
+
+	a := NewDeviceTensor(0x1000, DtFloat32, 4, 1)
+	b, err := a.BroadcastTo(3, 4, 5)
+	assert.Nil(err)
+	assert.Equal([]int{3, 4, 5}, b.Shape)
+	assert.Equal([]int{0, 1, 0}, b.Strides)
+	_, err = a.BroadcastTo(3, 5)
+	assert.NotNil(err)
+}
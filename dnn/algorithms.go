@@ -0,0 +1,246 @@
+package cudnn
+
+// #include <cudnn.h>
+import "C"
+import "github.com/pkg/errors"
+
+/* Finding the algorithms of the convolutions */
+
+// FindConvolutionForwardAlgorithm runs the algorithms of ConvolutionForward, and returns their performance, fastest
+// first. At most requestedAlgoCount algorithms are returned, or all of them if requestedAlgoCount is 0. cuDNN
+// allocates the memory it runs them on: use FindConvolutionForwardAlgorithmEx when the memory is tight.
+func (co *Context) FindConvolutionForwardAlgorithm(xDesc *TensorDescriptor, wDesc *Filter, convDesc *Convolution, yDesc *TensorDescriptor, requestedAlgoCount int) ([]ConvolutionFwdPerf, error) {
+	n, err := co.fwdAlgoCount(requestedAlgoCount)
+	if err != nil {
+		return nil, err
+	}
+	perfs := make([]C.cudnnConvolutionFwdAlgoPerf_t, n)
+	var returned C.int
+	if err = result(C.cudnnFindConvolutionForwardAlgorithm(co.internal, xDesc.internal, wDesc.internal, convDesc.internal, yDesc.internal, C.int(n), &returned, &perfs[0])); err != nil {
+		return nil, err
+	}
+	return fwdPerfs(perfs[:returned]), nil
+}
+
+// FindConvolutionForwardAlgorithmEx is FindConvolutionForwardAlgorithm, running the algorithms on the given tensors and
+// workspace. The algorithms that need a larger workspace are reported as failed. The content of y is overwritten.
+func (co *Context) FindConvolutionForwardAlgorithmEx(xDesc *TensorDescriptor, x Memory, wDesc *Filter, w Memory, convDesc *Convolution, yDesc *TensorDescriptor, y Memory, requestedAlgoCount int, workSpace Memory, workSpaceSizeInBytes uintptr) ([]ConvolutionFwdPerf, error) {
+	n, err := co.fwdAlgoCount(requestedAlgoCount)
+	if err != nil {
+		return nil, err
+	}
+	perfs := make([]C.cudnnConvolutionFwdAlgoPerf_t, n)
+	var returned C.int
+	if err = result(C.cudnnFindConvolutionForwardAlgorithmEx(co.internal, xDesc.internal, x.Pointer(), wDesc.internal, w.Pointer(), convDesc.internal, yDesc.internal, y.Pointer(), C.int(n), &returned, &perfs[0], workSpace.Pointer(), C.size_t(workSpaceSizeInBytes))); err != nil {
+		return nil, err
+	}
+	return fwdPerfs(perfs[:returned]), nil
+}
+
+// GetConvolutionForwardAlgorithm_v7 returns the algorithms of ConvolutionForward ranked by the heuristics of cuDNN,
+// best first, without running them. The times of the results are not measured.
+func (co *Context) GetConvolutionForwardAlgorithm_v7(xDesc *TensorDescriptor, wDesc *Filter, convDesc *Convolution, yDesc *TensorDescriptor, requestedAlgoCount int) ([]ConvolutionFwdPerf, error) {
+	n, err := co.fwdAlgoCount(requestedAlgoCount)
+	if err != nil {
+		return nil, err
+	}
+	perfs := make([]C.cudnnConvolutionFwdAlgoPerf_t, n)
+	var returned C.int
+	if err = result(C.cudnnGetConvolutionForwardAlgorithm_v7(co.internal, xDesc.internal, wDesc.internal, convDesc.internal, yDesc.internal, C.int(n), &returned, &perfs[0])); err != nil {
+		return nil, err
+	}
+	return fwdPerfs(perfs[:returned]), nil
+}
+
+// FindConvolutionBackwardFilterAlgorithm runs the algorithms of ConvolutionBackwardFilter, and returns their
+// performance, fastest first (see FindConvolutionForwardAlgorithm).
+func (co *Context) FindConvolutionBackwardFilterAlgorithm(xDesc *TensorDescriptor, dyDesc *TensorDescriptor, convDesc *Convolution, dwDesc *Filter, requestedAlgoCount int) ([]ConvolutionBwdPerf, error) {
+	n, err := co.bwdFilterAlgoCount(requestedAlgoCount)
+	if err != nil {
+		return nil, err
+	}
+	perfs := make([]C.cudnnConvolutionBwdFilterAlgoPerf_t, n)
+	var returned C.int
+	if err = result(C.cudnnFindConvolutionBackwardFilterAlgorithm(co.internal, xDesc.internal, dyDesc.internal, convDesc.internal, dwDesc.internal, C.int(n), &returned, &perfs[0])); err != nil {
+		return nil, err
+	}
+	return bwdFilterPerfs(perfs[:returned]), nil
+}
+
+// FindConvolutionBackwardFilterAlgorithmEx is FindConvolutionBackwardFilterAlgorithm, running the algorithms on the
+// given tensors and workspace. The content of dw is overwritten.
+func (co *Context) FindConvolutionBackwardFilterAlgorithmEx(xDesc *TensorDescriptor, x Memory, dyDesc *TensorDescriptor, dy Memory, convDesc *Convolution, dwDesc *Filter, dw Memory, requestedAlgoCount int, workSpace Memory, workSpaceSizeInBytes uintptr) ([]ConvolutionBwdPerf, error) {
+	n, err := co.bwdFilterAlgoCount(requestedAlgoCount)
+	if err != nil {
+		return nil, err
+	}
+	perfs := make([]C.cudnnConvolutionBwdFilterAlgoPerf_t, n)
+	var returned C.int
+	if err = result(C.cudnnFindConvolutionBackwardFilterAlgorithmEx(co.internal, xDesc.internal, x.Pointer(), dyDesc.internal, dy.Pointer(), convDesc.internal, dwDesc.internal, dw.Pointer(), C.int(n), &returned, &perfs[0], workSpace.Pointer(), C.size_t(workSpaceSizeInBytes))); err != nil {
+		return nil, err
+	}
+	return bwdFilterPerfs(perfs[:returned]), nil
+}
+
+// GetConvolutionBackwardFilterAlgorithm_v7 returns the algorithms of ConvolutionBackwardFilter ranked by the heuristics
+// of cuDNN, best first, without running them.
+func (co *Context) GetConvolutionBackwardFilterAlgorithm_v7(xDesc *TensorDescriptor, dyDesc *TensorDescriptor, convDesc *Convolution, dwDesc *Filter, requestedAlgoCount int) ([]ConvolutionBwdPerf, error) {
+	n, err := co.bwdFilterAlgoCount(requestedAlgoCount)
+	if err != nil {
+		return nil, err
+	}
+	perfs := make([]C.cudnnConvolutionBwdFilterAlgoPerf_t, n)
+	var returned C.int
+	if err = result(C.cudnnGetConvolutionBackwardFilterAlgorithm_v7(co.internal, xDesc.internal, dyDesc.internal, convDesc.internal, dwDesc.internal, C.int(n), &returned, &perfs[0])); err != nil {
+		return nil, err
+	}
+	return bwdFilterPerfs(perfs[:returned]), nil
+}
+
+// FindConvolutionBackwardDataAlgorithm runs the algorithms of ConvolutionBackwardData, and returns their performance,
+// fastest first (see FindConvolutionForwardAlgorithm).
+func (co *Context) FindConvolutionBackwardDataAlgorithm(wDesc *Filter, dyDesc *TensorDescriptor, convDesc *Convolution, dxDesc *TensorDescriptor, requestedAlgoCount int) ([]ConvolutionBwdDataPerf, error) {
+	n, err := co.bwdDataAlgoCount(requestedAlgoCount)
+	if err != nil {
+		return nil, err
+	}
+	perfs := make([]C.cudnnConvolutionBwdDataAlgoPerf_t, n)
+	var returned C.int
+	if err = result(C.cudnnFindConvolutionBackwardDataAlgorithm(co.internal, wDesc.internal, dyDesc.internal, convDesc.internal, dxDesc.internal, C.int(n), &returned, &perfs[0])); err != nil {
+		return nil, err
+	}
+	return bwdDataPerfs(perfs[:returned]), nil
+}
+
+// FindConvolutionBackwardDataAlgorithmEx is FindConvolutionBackwardDataAlgorithm, running the algorithms on the given
+// tensors and workspace. The content of dx is overwritten.
+func (co *Context) FindConvolutionBackwardDataAlgorithmEx(wDesc *Filter, w Memory, dyDesc *TensorDescriptor, dy Memory, convDesc *Convolution, dxDesc *TensorDescriptor, dx Memory, requestedAlgoCount int, workSpace Memory, workSpaceSizeInBytes uintptr) ([]ConvolutionBwdDataPerf, error) {
+	n, err := co.bwdDataAlgoCount(requestedAlgoCount)
+	if err != nil {
+		return nil, err
+	}
+	perfs := make([]C.cudnnConvolutionBwdDataAlgoPerf_t, n)
+	var returned C.int
+	if err = result(C.cudnnFindConvolutionBackwardDataAlgorithmEx(co.internal, wDesc.internal, w.Pointer(), dyDesc.internal, dy.Pointer(), convDesc.internal, dxDesc.internal, dx.Pointer(), C.int(n), &returned, &perfs[0], workSpace.Pointer(), C.size_t(workSpaceSizeInBytes))); err != nil {
+		return nil, err
+	}
+	return bwdDataPerfs(perfs[:returned]), nil
+}
+
+// GetConvolutionBackwardDataAlgorithm_v7 returns the algorithms of ConvolutionBackwardData ranked by the heuristics of
+// cuDNN, best first, without running them.
+func (co *Context) GetConvolutionBackwardDataAlgorithm_v7(wDesc *Filter, dyDesc *TensorDescriptor, convDesc *Convolution, dxDesc *TensorDescriptor, requestedAlgoCount int) ([]ConvolutionBwdDataPerf, error) {
+	n, err := co.bwdDataAlgoCount(requestedAlgoCount)
+	if err != nil {
+		return nil, err
+	}
+	perfs := make([]C.cudnnConvolutionBwdDataAlgoPerf_t, n)
+	var returned C.int
+	if err = result(C.cudnnGetConvolutionBackwardDataAlgorithm_v7(co.internal, wDesc.internal, dyDesc.internal, convDesc.internal, dxDesc.internal, C.int(n), &returned, &perfs[0])); err != nil {
+		return nil, err
+	}
+	return bwdDataPerfs(perfs[:returned]), nil
+}
+
+// FindAlgorithms sets the algorithms of the layer to the fastest ones whose workspace is at most workspaceLimit bytes,
+// running them with the Find functions. The algorithms of the backward passes are only set if Backward is true.
+//
+// Use it before creating the ConvolutionPlanner of the layers, so that the planned workspace fits the algorithms.
+func (l *ConvolutionLayer) FindAlgorithms(ctx *Context, workspaceLimit uintptr) error {
+	fwd, err := ctx.FindConvolutionForwardAlgorithm(l.X, l.W, l.Conv, l.Y, 0)
+	if err != nil {
+		return errors.Wrap(err, "Unable to find the forward algorithm")
+	}
+	found := false
+	for _, p := range fwd {
+		if p.Err == nil && p.Memory <= workspaceLimit {
+			l.Fwd, found = p.Algo, true
+			break
+		}
+	}
+	if !found {
+		return errors.Errorf("No forward algorithm fits in a workspace of %d bytes", workspaceLimit)
+	}
+	if !l.Backward {
+		return nil
+	}
+
+	// the gradients share the descriptors of X and Y
+	bwdData, err := ctx.FindConvolutionBackwardDataAlgorithm(l.W, l.Y, l.Conv, l.X, 0)
+	if err != nil {
+		return errors.Wrap(err, "Unable to find the backward data algorithm")
+	}
+	found = false
+	for _, p := range bwdData {
+		if p.Err == nil && p.Memory <= workspaceLimit {
+			l.BwdData, found = p.Algo, true
+			break
+		}
+	}
+	if !found {
+		return errors.Errorf("No backward data algorithm fits in a workspace of %d bytes", workspaceLimit)
+	}
+
+	bwdFilter, err := ctx.FindConvolutionBackwardFilterAlgorithm(l.X, l.Y, l.Conv, l.W, 0)
+	if err != nil {
+		return errors.Wrap(err, "Unable to find the backward filter algorithm")
+	}
+	for _, p := range bwdFilter {
+		if p.Err == nil && p.Memory <= workspaceLimit {
+			l.BwdFilter = p.Algo
+			return nil
+		}
+	}
+	return errors.Errorf("No backward filter algorithm fits in a workspace of %d bytes", workspaceLimit)
+}
+
+func (co *Context) fwdAlgoCount(requested int) (int, error) {
+	if requested > 0 {
+		return requested, nil
+	}
+	var n C.int
+	err := result(C.cudnnGetConvolutionForwardAlgorithmMaxCount(co.internal, &n))
+	return int(n), err
+}
+
+func (co *Context) bwdFilterAlgoCount(requested int) (int, error) {
+	if requested > 0 {
+		return requested, nil
+	}
+	var n C.int
+	err := result(C.cudnnGetConvolutionBackwardFilterAlgorithmMaxCount(co.internal, &n))
+	return int(n), err
+}
+
+func (co *Context) bwdDataAlgoCount(requested int) (int, error) {
+	if requested > 0 {
+		return requested, nil
+	}
+	var n C.int
+	err := result(C.cudnnGetConvolutionBackwardDataAlgorithmMaxCount(co.internal, &n))
+	return int(n), err
+}
+
+func fwdPerfs(ps []C.cudnnConvolutionFwdAlgoPerf_t) []ConvolutionFwdPerf {
+	retVal := make([]ConvolutionFwdPerf, len(ps))
+	for i, p := range ps {
+		retVal[i] = convolutionFwdPerfFromC(p)
+	}
+	return retVal
+}
+
+func bwdFilterPerfs(ps []C.cudnnConvolutionBwdFilterAlgoPerf_t) []ConvolutionBwdPerf {
+	retVal := make([]ConvolutionBwdPerf, len(ps))
+	for i, p := range ps {
+		retVal[i] = convolutionBwdPerfFromC(p)
+	}
+	return retVal
+}
+
+func bwdDataPerfs(ps []C.cudnnConvolutionBwdDataAlgoPerf_t) []ConvolutionBwdDataPerf {
+	retVal := make([]ConvolutionBwdDataPerf, len(ps))
+	for i, p := range ps {
+		retVal[i] = convolutionBwdDataPerfFromC(p)
+	}
+	return retVal
+}
@@ -142,6 +142,8 @@ type Convolution struct {
 	padding      []int
 	filterStride []int
 	dilation     []int
+	mode         ConvolutionMode
+	dataType     DataType
 
 	// cache of outputShape
 	dims        int
@@ -158,6 +160,14 @@ func NewConvolution(mathType MathType, groupCount int, padding, filterStride, di
 	if len(padding) < 2 {
 		return nil, errors.Errorf("Convolution expects 4 dimensional inputs")
 	}
+	if groupCount < 1 {
+		return nil, errors.Errorf("Expected at least one group. Got %d", groupCount)
+	}
+	for i := range dilation {
+		if dilation[i] < 1 || filterStride[i] < 1 || padding[i] < 0 {
+			return nil, errors.Errorf("Invalid inputs: padding %v, filterStride %v, dilation %v", padding, filterStride, dilation)
+		}
+	}
 
 	var internal C.cudnnConvolutionDescriptor_t
 	padA, padAManaged := ints2CIntPtr(padding)
@@ -179,16 +189,27 @@ func NewConvolution(mathType MathType, groupCount int, padding, filterStride, di
 		padding:      padding,
 		filterStride: filterStride,
 		dilation:     dilation,
+		mode:         convolutionMode,
+		dataType:     datatype,
 	}
 	runtime.SetFinalizer(retVal, destroyConvolution)
 	return retVal, nil
 }
 
-func (c *Convolution) MathType() MathType  { return c.mathType }
-func (c *Convolution) GroupCount() int     { return c.groupCount }
-func (c *Convolution) Padding() []int      { return cloneShape(c.padding) }
-func (c *Convolution) FilterStride() []int { return cloneShape(c.filterStride) }
-func (c *Convolution) Dilation() []int     { return cloneShape(c.dilation) }
+func (c *Convolution) MathType() MathType    { return c.mathType }
+func (c *Convolution) GroupCount() int       { return c.groupCount }
+func (c *Convolution) Padding() []int        { return cloneShape(c.padding) }
+func (c *Convolution) FilterStride() []int   { return cloneShape(c.filterStride) }
+func (c *Convolution) Dilation() []int       { return cloneShape(c.dilation) }
+func (c *Convolution) Mode() ConvolutionMode { return c.mode }
+func (c *Convolution) DataType() DataType    { return c.dataType }
+
+// InferOutputShape infers the shape of the output of the convolution, given the shapes of the input (N, C, spatial...)
+// and the filter (K, C/groups, kernel...). Unlike ForwardOutputShape, it does not require descriptors, and it reports
+// mismatched groups and channels in its error.
+func (c *Convolution) InferOutputShape(input, filter []int) ([]int, error) {
+	return ConvolutionOutputShape(input, filter, c.padding, c.filterStride, c.dilation, c.groupCount)
+}
 
 func (c *Convolution) ForwardOutputShape(input *TensorDescriptor, filter *Filter, dims int) (retVal []int, err error) {
 	if c.dims == dims && shapeEq(c.inputTensor, input.shape) && shapeEq(c.inputFilter, filter.shape) {
@@ -226,50 +247,66 @@ func (c *Convolution) CalcForwardOutputShape(input *TensorDescriptor, filter *Fi
 
 func destroyConvolution(obj *Convolution) { C.cudnnDestroyConvolutionDescriptor(obj.internal) }
 
-// TODO
+// ConvolutionFwdPerf is the performance of an algorithm of ConvolutionForward, as measured by
+// FindConvolutionForwardAlgorithm or estimated by GetConvolutionForwardAlgorithm_v7.
 type ConvolutionFwdPerf struct {
-	internal    C.cudnnConvolutionFwdAlgo_t
 	Algo        ConvolutionFwdAlgo
-	Time        float64
-	Memory      uintptr // size
+	Time        float64 // in milliseconds
+	Memory      uintptr // the size of the workspace
 	Determinism Determinism
 	MathType    MathType
-	Err         error
+	Err         error // the reason the algorithm cannot be used, if any
 }
 
-func convolutionFwdPerfFromC(p C.cudnnConvolutionFwdAlgo_t) *ConvolutionFwdPerf {
-	retVal := &ConvolutionFwdPerf{}
-	return retVal
+func convolutionFwdPerfFromC(p C.cudnnConvolutionFwdAlgoPerf_t) ConvolutionFwdPerf {
+	return ConvolutionFwdPerf{
+		Algo:        ConvolutionFwdAlgo(p.algo),
+		Time:        float64(p.time),
+		Memory:      uintptr(p.memory),
+		Determinism: Determinism(p.determinism),
+		MathType:    MathType(p.mathType),
+		Err:         result(p.status),
+	}
 }
 
+// ConvolutionBwdPerf is the performance of an algorithm of ConvolutionBackwardFilter.
 type ConvolutionBwdPerf struct {
-	internal *C.cudnnConvolutionBwdFilterAlgoPerf_t
-	Err      error
-
 	Algo        ConvolutionBwdFilterAlgo
-	Time        float64
-	Memory      uintptr // size
+	Time        float64 // in milliseconds
+	Memory      uintptr // the size of the workspace
 	Determinism Determinism
 	MathType    MathType
+	Err         error // the reason the algorithm cannot be used, if any
 }
 
-func convolutionBwdPerfFromC(p C.cudnnConvolutionBwdFilterAlgoPerf_t) *ConvolutionBwdPerf {
-	retVal := &ConvolutionBwdPerf{}
-	return retVal
+func convolutionBwdPerfFromC(p C.cudnnConvolutionBwdFilterAlgoPerf_t) ConvolutionBwdPerf {
+	return ConvolutionBwdPerf{
+		Algo:        ConvolutionBwdFilterAlgo(p.algo),
+		Time:        float64(p.time),
+		Memory:      uintptr(p.memory),
+		Determinism: Determinism(p.determinism),
+		MathType:    MathType(p.mathType),
+		Err:         result(p.status),
+	}
 }
 
+// ConvolutionBwdDataPerf is the performance of an algorithm of ConvolutionBackwardData.
 type ConvolutionBwdDataPerf struct {
-	internal *C.cudnnConvolutionBwdDataAlgoPerf_t
-	Algo     ConvolutionBwdDataAlgo
-	Err      error
-
-	Time        float64
-	Memory      uintptr // size
+	Algo        ConvolutionBwdDataAlgo
+	Time        float64 // in milliseconds
+	Memory      uintptr // the size of the workspace
 	Determinism Determinism
 	MathType    MathType
+	Err         error // the reason the algorithm cannot be used, if any
 }
 
-func ConvolutionBwdDataPerfFromC(p C.cudnnConvolutionBwdDataAlgoPerf_t) *ConvolutionBwdDataPerf {
-	retVal := &ConvolutionBwdDataPerf{}
-	return retVal
+func convolutionBwdDataPerfFromC(p C.cudnnConvolutionBwdDataAlgoPerf_t) ConvolutionBwdDataPerf {
+	return ConvolutionBwdDataPerf{
+		Algo:        ConvolutionBwdDataAlgo(p.algo),
+		Time:        float64(p.time),
+		Memory:      uintptr(p.memory),
+		Determinism: Determinism(p.determinism),
+		MathType:    MathType(p.mathType),
+		Err:         result(p.status),
+	}
 }
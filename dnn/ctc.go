@@ -0,0 +1,163 @@
+package cudnn
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// maxCTCLabelLength is the maximum length of the labels of a sequence supported by the deterministic CTC loss algorithm.
+const maxCTCLabelLength = 256
+
+// CTCLabels are the labels of a minibatch of sequences, in the layout expected by CTCLoss.
+//
+// Label 0 is reserved for the blank symbol.
+type CTCLabels struct {
+	Labels       []int // the labels of all the sequences, concatenated
+	LabelLengths []int // the number of labels of each sequence
+	InputLengths []int // the number of time steps of each sequence
+}
+
+// NewCTCLabels creates the CTCLabels of a minibatch, given the labels and the number of time steps of each sequence.
+func NewCTCLabels(labels [][]int, inputLengths []int) (CTCLabels, error) {
+	if len(labels) != len(inputLengths) {
+		return CTCLabels{}, errors.Errorf("Expected as many input lengths as label sequences (%d). Got %d instead", len(labels), len(inputLengths))
+	}
+	l := CTCLabels{
+		LabelLengths: make([]int, len(labels)),
+		InputLengths: cloneShape(inputLengths),
+	}
+	for i, seq := range labels {
+		l.Labels = append(l.Labels, seq...)
+		l.LabelLengths[i] = len(seq)
+	}
+	return l, nil
+}
+
+// BatchSize returns the number of sequences.
+func (l CTCLabels) BatchSize() int { return len(l.LabelLengths) }
+
+// Check checks that the labels are consistent with the probabilities, which are expected to be of shape (T, N, A):
+// T time steps, a minibatch of N sequences, and an alphabet of A symbols (including the blank).
+func (l CTCLabels) Check(probsDesc *TensorDescriptor, algo CTCLossAlgo) error {
+	shape := probsDesc.Shape()
+	if len(shape) != 3 {
+		return errors.Errorf("Expected the probabilities to be of shape (T, N, A). Got %v instead", shape)
+	}
+	t, n, a := shape[0], shape[1], shape[2]
+	if len(l.LabelLengths) != n || len(l.InputLengths) != n {
+		return errors.Errorf("Expected %d label lengths and input lengths. Got %d and %d instead", n, len(l.LabelLengths), len(l.InputLengths))
+	}
+
+	var total int
+	for i, ll := range l.LabelLengths {
+		il := l.InputLengths[i]
+		switch {
+		case ll < 0:
+			return errors.Errorf("Sequence %d has a negative number of labels (%d)", i, ll)
+		case il <= 0 || il > t:
+			return errors.Errorf("Sequence %d has %d time steps. Expected between 1 and %d", i, il, t)
+		case ll > il:
+			return errors.Errorf("Sequence %d has more labels (%d) than time steps (%d)", i, ll, il)
+		case algo == DeterministicCTCLoss && ll >= maxCTCLabelLength:
+			return errors.Errorf("Sequence %d has %d labels. DeterministicCTCLoss supports at most %d", i, ll, maxCTCLabelLength-1)
+		}
+		total += ll
+	}
+	if total != len(l.Labels) {
+		return errors.Errorf("The label lengths add up to %d. There are %d labels", total, len(l.Labels))
+	}
+	for i, label := range l.Labels {
+		if label <= 0 || label >= a {
+			return errors.Errorf("Label %d (%d) is out of range. Expected between 1 and %d (0 is the blank)", i, label, a-1)
+		}
+	}
+	return nil
+}
+
+// CTC computes the CTC loss. It manages the workspace required by CTCLoss: the workspace is allocated with the given
+// Allocator, and grown as required by the minibatches it is called with.
+type CTC struct {
+	ctx   *Context
+	desc  *CTCLoss
+	algo  CTCLossAlgo
+	alloc Allocator
+
+	sync.Mutex
+	workspace Memory
+	size      uintptr
+}
+
+// NewCTC creates a CTC that computes the loss with the given precision and algorithm.
+func NewCTC(ctx *Context, compType DataType, algo CTCLossAlgo, alloc Allocator) (*CTC, error) {
+	desc, err := NewCTCLoss(compType)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to create a CTC loss descriptor")
+	}
+	return &CTC{ctx: ctx, desc: desc, algo: algo, alloc: alloc}, nil
+}
+
+// Descriptor returns the CTC loss descriptor.
+func (c *CTC) Descriptor() *CTCLoss { return c.desc }
+
+// Algo returns the algorithm used.
+func (c *CTC) Algo() CTCLossAlgo { return c.algo }
+
+// Loss computes the cost of each sequence of the minibatch, and the gradients of the costs with regards to the probabilities.
+// probs are of shape (T, N, A) (see CTCLabels.Check), and costs are of shape (N).
+//
+// The workspace is shared by all the calls to Loss: calls are expected to be serialized on the same stream.
+func (c *CTC) Loss(probsDesc *TensorDescriptor, probs Memory, labels CTCLabels, costs Memory, gradientsDesc *TensorDescriptor, gradients Memory) error {
+	if err := labels.Check(probsDesc, c.algo); err != nil {
+		return err
+	}
+	size, err := c.ctx.GetCTCLossWorkspaceSize(probsDesc, gradientsDesc, labels.Labels, labels.LabelLengths, labels.InputLengths, c.algo, c.desc)
+	if err != nil {
+		return errors.Wrap(err, "Unable to get the workspace size of CTCLoss")
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	ws, err := c.reserve(size)
+	if err != nil {
+		return err
+	}
+	return c.ctx.CTCLoss(probsDesc, probs, labels.Labels, labels.LabelLengths, labels.InputLengths, costs, gradientsDesc, gradients, c.algo, c.desc, ws, c.size)
+}
+
+// Close frees the workspace.
+func (c *CTC) Close() error {
+	c.Lock()
+	defer c.Unlock()
+	if c.workspace == nil {
+		return nil
+	}
+	err := c.alloc.Free(c.workspace)
+	c.workspace, c.size = nil, 0
+	return err
+}
+
+// reserve returns a workspace of at least size bytes. The lock is expected to be held.
+func (c *CTC) reserve(size uintptr) (Memory, error) {
+	if size == 0 {
+		if c.workspace == nil {
+			return noWorkspace{}, nil
+		}
+		return c.workspace, nil
+	}
+	if size <= c.size {
+		return c.workspace, nil
+	}
+	if c.workspace != nil {
+		if err := c.alloc.Free(c.workspace); err != nil {
+			return nil, errors.Wrap(err, "Unable to free the previous workspace")
+		}
+		c.workspace, c.size = nil, 0
+	}
+	ws, err := c.alloc.Alloc(size)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to allocate a workspace of %d bytes", size)
+	}
+	c.workspace, c.size = ws, size
+	return ws, nil
+}
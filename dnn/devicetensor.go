@@ -0,0 +1,62 @@
+package cudnn
+
+import (
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// DataTypeOf returns the cuDNN data type of the given element type.
+func DataTypeOf(dt cu.Dtype) (DataType, error) {
+	switch dt {
+	case cu.DtFloat32:
+		return Float, nil
+	case cu.DtFloat64:
+		return Double, nil
+	case cu.DtFloat16:
+		return Half, nil
+	case cu.DtInt32:
+		return Int32, nil
+	}
+	return 0, errors.Errorf("%v has no cuDNN equivalent", dt)
+}
+
+// dataTypeSize returns the size in bytes of an element of the given data type.
+func dataTypeSize(dt DataType) uintptr {
+	switch dt {
+	case Double:
+		return 8
+	case Float, Int32, Int8x4:
+		return 4
+	case Half:
+		return 2
+	}
+	return 1
+}
+
+// DescribeTensor creates a TensorDescriptor with the shape and strides of the given tensor.
+// Because cu.DeviceTensor satisfies Memory, the tensor itself can then be passed as the memory of the descriptor.
+//
+// cuDNN requires at least 4 dimensions for most operations: tensors with fewer dimensions are padded with trailing
+// dimensions of size 1.
+//
+// When pointers are being checked (see cu.DebugAlign), the pointer of the tensor is checked against its elements.
+func DescribeTensor(t cu.DeviceTensor) (*TensorDescriptor, error) {
+	if err := t.Check(); err != nil {
+		return nil, err
+	}
+	if err := t.CheckMemory(); err != nil {
+		return nil, err
+	}
+	dt, err := DataTypeOf(t.Dtype)
+	if err != nil {
+		return nil, err
+	}
+	shape, strides := cloneShape(t.Shape), cloneShape(t.Strides)
+	for len(shape) < 4 {
+		shape = append(shape, 1)
+		strides = append(strides, 1)
+	}
+	return NewTensorDescriptor(NCHW, dt, shape, strides)
+}
+
+var _ Memory = cu.DeviceTensor{}
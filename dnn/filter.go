@@ -39,6 +39,7 @@ func NewFilter(dataType DataType, format TensorFormat, shape []int) (retVal *Fil
 	}
 	return &Filter{
 		internal: internal,
+		dataType: dataType,
 		format:   format,
 		shape:    shape,
 	}, nil
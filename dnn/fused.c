@@ -0,0 +1,304 @@
+#include <stdbool.h>
+#include <stdlib.h>
+#include <cudnn.h>
+#include "fused.h"
+
+#if CUDNN_MAJOR >= 8
+
+// the unique IDs of the tensors of the graph. The virtual tensors are the results of the operations before the last.
+enum {
+	uidX = 1,
+	uidW,
+	uidB,
+	uidY,
+	uidZ,
+	uidConv,
+	uidResidual,
+	uidBias,
+};
+
+#define CHECK(x) do { status = (x); if (status != CUDNN_STATUS_SUCCESS) goto done; } while (0)
+
+// descriptors are the descriptors created while building a plan, destroyed once the plan is finalized.
+typedef struct {
+	cudnnBackendDescriptor_t d[32];
+	int n;
+} descriptors;
+
+static cudnnStatus_t create(descriptors *ds, cudnnBackendDescriptorType_t type, cudnnBackendDescriptor_t *retVal) {
+	cudnnStatus_t status = cudnnBackendCreateDescriptor(type, retVal);
+	if (status == CUDNN_STATUS_SUCCESS) {
+		ds->d[ds->n++] = *retVal;
+	}
+	return status;
+}
+
+static cudnnStatus_t newTensor(descriptors *ds, cudnnBackendDescriptor_t *retVal, int64_t uid, cudnnDataType_t dataType,
+	const int nbDims, const int64_t *dims, const int64_t *strides, bool isVirtual) {
+
+	cudnnStatus_t status;
+	int64_t alignment = 16;
+	CHECK(create(ds, CUDNN_BACKEND_TENSOR_DESCRIPTOR, retVal));
+	CHECK(cudnnBackendSetAttribute(*retVal, CUDNN_ATTR_TENSOR_DATA_TYPE, CUDNN_TYPE_DATA_TYPE, 1, &dataType));
+	CHECK(cudnnBackendSetAttribute(*retVal, CUDNN_ATTR_TENSOR_DIMENSIONS, CUDNN_TYPE_INT64, nbDims, dims));
+	CHECK(cudnnBackendSetAttribute(*retVal, CUDNN_ATTR_TENSOR_STRIDES, CUDNN_TYPE_INT64, nbDims, strides));
+	CHECK(cudnnBackendSetAttribute(*retVal, CUDNN_ATTR_TENSOR_UNIQUE_ID, CUDNN_TYPE_INT64, 1, &uid));
+	CHECK(cudnnBackendSetAttribute(*retVal, CUDNN_ATTR_TENSOR_BYTE_ALIGNMENT, CUDNN_TYPE_INT64, 1, &alignment));
+	CHECK(cudnnBackendSetAttribute(*retVal, CUDNN_ATTR_TENSOR_IS_VIRTUAL, CUDNN_TYPE_BOOLEAN, 1, &isVirtual));
+	CHECK(cudnnBackendFinalize(*retVal));
+done:
+	return status;
+}
+
+// setScalar sets a scalar attribute of an operation, as a double if the computation is in double, as a float otherwise.
+static cudnnStatus_t setScalar(cudnnBackendDescriptor_t desc, cudnnBackendAttributeName_t name, cudnnDataType_t computeType, double v) {
+	if (computeType == CUDNN_DATA_DOUBLE) {
+		return cudnnBackendSetAttribute(desc, name, CUDNN_TYPE_DOUBLE, 1, &v);
+	}
+	float f = (float)v;
+	return cudnnBackendSetAttribute(desc, name, CUDNN_TYPE_FLOAT, 1, &f);
+}
+
+// newPointwise creates the operation y = mode(x) or, if b is not NULL, y = mode(x, alpha2 * b).
+static cudnnStatus_t newPointwise(descriptors *ds, cudnnBackendDescriptor_t *retVal, cudnnPointwiseMode_t mode,
+	cudnnDataType_t computeType, double coef, cudnnBackendDescriptor_t x, cudnnBackendDescriptor_t b, double alpha2,
+	cudnnBackendDescriptor_t y) {
+
+	cudnnStatus_t status;
+	cudnnBackendDescriptor_t pw;
+	CHECK(create(ds, CUDNN_BACKEND_POINTWISE_DESCRIPTOR, &pw));
+	CHECK(cudnnBackendSetAttribute(pw, CUDNN_ATTR_POINTWISE_MODE, CUDNN_TYPE_POINTWISE_MODE, 1, &mode));
+	CHECK(cudnnBackendSetAttribute(pw, CUDNN_ATTR_POINTWISE_MATH_PREC, CUDNN_TYPE_DATA_TYPE, 1, &computeType));
+	switch (mode) {
+	case CUDNN_POINTWISE_RELU_FWD:
+		if (coef > 0) {
+			CHECK(cudnnBackendSetAttribute(pw, CUDNN_ATTR_POINTWISE_RELU_UPPER_CLIP, CUDNN_TYPE_DOUBLE, 1, &coef));
+		}
+		break;
+	case CUDNN_POINTWISE_ELU_FWD:
+		CHECK(cudnnBackendSetAttribute(pw, CUDNN_ATTR_POINTWISE_ELU_ALPHA, CUDNN_TYPE_DOUBLE, 1, &coef));
+		break;
+	default:
+		break;
+	}
+	CHECK(cudnnBackendFinalize(pw));
+
+	CHECK(create(ds, CUDNN_BACKEND_OPERATION_POINTWISE_DESCRIPTOR, retVal));
+	CHECK(cudnnBackendSetAttribute(*retVal, CUDNN_ATTR_OPERATION_POINTWISE_PW_DESCRIPTOR, CUDNN_TYPE_BACKEND_DESCRIPTOR, 1, &pw));
+	CHECK(cudnnBackendSetAttribute(*retVal, CUDNN_ATTR_OPERATION_POINTWISE_XDESC, CUDNN_TYPE_BACKEND_DESCRIPTOR, 1, &x));
+	if (b != NULL) {
+		CHECK(cudnnBackendSetAttribute(*retVal, CUDNN_ATTR_OPERATION_POINTWISE_BDESC, CUDNN_TYPE_BACKEND_DESCRIPTOR, 1, &b));
+		CHECK(setScalar(*retVal, CUDNN_ATTR_OPERATION_POINTWISE_ALPHA2, computeType, alpha2));
+	}
+	CHECK(cudnnBackendSetAttribute(*retVal, CUDNN_ATTR_OPERATION_POINTWISE_YDESC, CUDNN_TYPE_BACKEND_DESCRIPTOR, 1, &y));
+	CHECK(cudnnBackendFinalize(*retVal));
+done:
+	return status;
+}
+
+// newPlan finalizes an execution plan of the graph with the first engine configuration of the heuristics that can be
+// finalized.
+static cudnnStatus_t newPlan(descriptors *ds, cudnnHandle_t handle, cudnnBackendDescriptor_t graph, cudnnBackendDescriptor_t *retVal) {
+	cudnnStatus_t status;
+	cudnnBackendDescriptor_t heur;
+	cudnnBackendHeurMode_t mode = CUDNN_HEUR_MODE_INSTANT;
+	int64_t count = 0, created = 0;
+	cudnnBackendDescriptor_t *cfgs = NULL;
+	int64_t i;
+
+	CHECK(create(ds, CUDNN_BACKEND_ENGINEHEUR_DESCRIPTOR, &heur));
+	CHECK(cudnnBackendSetAttribute(heur, CUDNN_ATTR_ENGINEHEUR_OPERATION_GRAPH, CUDNN_TYPE_BACKEND_DESCRIPTOR, 1, &graph));
+	CHECK(cudnnBackendSetAttribute(heur, CUDNN_ATTR_ENGINEHEUR_MODE, CUDNN_TYPE_HEUR_MODE, 1, &mode));
+	CHECK(cudnnBackendFinalize(heur));
+	CHECK(cudnnBackendGetAttribute(heur, CUDNN_ATTR_ENGINEHEUR_RESULTS, CUDNN_TYPE_BACKEND_DESCRIPTOR, 0, &count, NULL));
+	if (count == 0) {
+		status = CUDNN_STATUS_NOT_SUPPORTED;
+		goto done;
+	}
+
+	cfgs = calloc(count, sizeof(cudnnBackendDescriptor_t));
+	if (cfgs == NULL) {
+		status = CUDNN_STATUS_ALLOC_FAILED;
+		goto done;
+	}
+	for (; created < count; created++) {
+		CHECK(cudnnBackendCreateDescriptor(CUDNN_BACKEND_ENGINECFG_DESCRIPTOR, &cfgs[created]));
+	}
+	CHECK(cudnnBackendGetAttribute(heur, CUDNN_ATTR_ENGINEHEUR_RESULTS, CUDNN_TYPE_BACKEND_DESCRIPTOR, count, &count, cfgs));
+
+	status = CUDNN_STATUS_NOT_SUPPORTED;
+	for (i = 0; i < count && status != CUDNN_STATUS_SUCCESS; i++) {
+		cudnnBackendDescriptor_t plan;
+		if ((status = cudnnBackendCreateDescriptor(CUDNN_BACKEND_EXECUTION_PLAN_DESCRIPTOR, &plan)) != CUDNN_STATUS_SUCCESS) {
+			goto done;
+		}
+		if ((status = cudnnBackendSetAttribute(plan, CUDNN_ATTR_EXECUTION_PLAN_HANDLE, CUDNN_TYPE_HANDLE, 1, &handle)) == CUDNN_STATUS_SUCCESS &&
+			(status = cudnnBackendSetAttribute(plan, CUDNN_ATTR_EXECUTION_PLAN_ENGINE_CONFIG, CUDNN_TYPE_BACKEND_DESCRIPTOR, 1, &cfgs[i])) == CUDNN_STATUS_SUCCESS &&
+			(status = cudnnBackendFinalize(plan)) == CUDNN_STATUS_SUCCESS) {
+			*retVal = plan;
+		} else {
+			cudnnBackendDestroyDescriptor(plan);
+		}
+	}
+done:
+	if (cfgs != NULL) {
+		for (i = 0; i < created; i++) {
+			cudnnBackendDestroyDescriptor(cfgs[i]);
+		}
+		free(cfgs);
+	}
+	return status;
+}
+
+cudnnStatus_t gocudnnNewFusedConvPlan(cudnnHandle_t handle, void **plan, int64_t *workspaceSize,
+	cudnnDataType_t dataType, cudnnDataType_t computeType,
+	const int nbDims, const int64_t *dims, const int64_t *strides, const int hasResidual,
+	cudnnConvolutionMode_t convMode, const int64_t *padding, const int64_t *filterStrides, const int64_t *dilation,
+	double alpha1, double alpha2, cudnnActivationMode_t act, double coef) {
+
+	cudnnStatus_t status;
+	descriptors ds = {.n = 0};
+	cudnnBackendDescriptor_t x, w, b, y, z = NULL, conv, convOut, biasIn, biasOut, convDesc, graph, result = NULL;
+	cudnnBackendDescriptor_t ops[4];
+	int nbOps = 0;
+	int64_t spatialDims = nbDims - 2;
+	cudnnPointwiseMode_t actMode = CUDNN_POINTWISE_RELU_FWD;
+	int hasActivation = 1;
+	int64_t n;
+
+	switch (act) {
+	case CUDNN_ACTIVATION_IDENTITY:
+		hasActivation = 0;
+		break;
+	case CUDNN_ACTIVATION_RELU:
+		actMode = CUDNN_POINTWISE_RELU_FWD;
+		coef = 0;
+		break;
+	case CUDNN_ACTIVATION_CLIPPED_RELU:
+		actMode = CUDNN_POINTWISE_RELU_FWD;
+		break;
+	case CUDNN_ACTIVATION_TANH:
+		actMode = CUDNN_POINTWISE_TANH_FWD;
+		break;
+	case CUDNN_ACTIVATION_SIGMOID:
+		actMode = CUDNN_POINTWISE_SIGMOID_FWD;
+		break;
+	case CUDNN_ACTIVATION_ELU:
+		actMode = CUDNN_POINTWISE_ELU_FWD;
+		break;
+	default:
+		return CUDNN_STATUS_NOT_SUPPORTED;
+	}
+
+	// the virtual tensors are laid out as Y, and hold the results in the compute type
+	const int64_t *yDims = dims + 3*nbDims, *yStrides = strides + 3*nbDims;
+	CHECK(newTensor(&ds, &x, uidX, dataType, nbDims, dims, strides, false));
+	CHECK(newTensor(&ds, &w, uidW, dataType, nbDims, dims + nbDims, strides + nbDims, false));
+	CHECK(newTensor(&ds, &b, uidB, dataType, nbDims, dims + 2*nbDims, strides + 2*nbDims, false));
+	CHECK(newTensor(&ds, &y, uidY, dataType, nbDims, yDims, yStrides, false));
+	CHECK(newTensor(&ds, &convOut, uidConv, computeType, nbDims, yDims, yStrides, true));
+	biasIn = convOut;
+	if (hasResidual) {
+		CHECK(newTensor(&ds, &z, uidZ, dataType, nbDims, dims + 4*nbDims, strides + 4*nbDims, false));
+		CHECK(newTensor(&ds, &biasIn, uidResidual, computeType, nbDims, yDims, yStrides, true));
+	}
+	biasOut = y;
+	if (hasActivation) {
+		CHECK(newTensor(&ds, &biasOut, uidBias, computeType, nbDims, yDims, yStrides, true));
+	}
+
+	// conv
+	CHECK(create(&ds, CUDNN_BACKEND_CONVOLUTION_DESCRIPTOR, &convDesc));
+	CHECK(cudnnBackendSetAttribute(convDesc, CUDNN_ATTR_CONVOLUTION_COMP_TYPE, CUDNN_TYPE_DATA_TYPE, 1, &computeType));
+	CHECK(cudnnBackendSetAttribute(convDesc, CUDNN_ATTR_CONVOLUTION_CONV_MODE, CUDNN_TYPE_CONVOLUTION_MODE, 1, &convMode));
+	CHECK(cudnnBackendSetAttribute(convDesc, CUDNN_ATTR_CONVOLUTION_SPATIAL_DIMS, CUDNN_TYPE_INT64, 1, &spatialDims));
+	CHECK(cudnnBackendSetAttribute(convDesc, CUDNN_ATTR_CONVOLUTION_PRE_PADDINGS, CUDNN_TYPE_INT64, spatialDims, padding));
+	CHECK(cudnnBackendSetAttribute(convDesc, CUDNN_ATTR_CONVOLUTION_POST_PADDINGS, CUDNN_TYPE_INT64, spatialDims, padding));
+	CHECK(cudnnBackendSetAttribute(convDesc, CUDNN_ATTR_CONVOLUTION_FILTER_STRIDES, CUDNN_TYPE_INT64, spatialDims, filterStrides));
+	CHECK(cudnnBackendSetAttribute(convDesc, CUDNN_ATTR_CONVOLUTION_DILATIONS, CUDNN_TYPE_INT64, spatialDims, dilation));
+	CHECK(cudnnBackendFinalize(convDesc));
+
+	CHECK(create(&ds, CUDNN_BACKEND_OPERATION_CONVOLUTION_FORWARD_DESCRIPTOR, &conv));
+	CHECK(cudnnBackendSetAttribute(conv, CUDNN_ATTR_OPERATION_CONVOLUTION_FORWARD_X, CUDNN_TYPE_BACKEND_DESCRIPTOR, 1, &x));
+	CHECK(cudnnBackendSetAttribute(conv, CUDNN_ATTR_OPERATION_CONVOLUTION_FORWARD_W, CUDNN_TYPE_BACKEND_DESCRIPTOR, 1, &w));
+	CHECK(cudnnBackendSetAttribute(conv, CUDNN_ATTR_OPERATION_CONVOLUTION_FORWARD_Y, CUDNN_TYPE_BACKEND_DESCRIPTOR, 1, &convOut));
+	CHECK(cudnnBackendSetAttribute(conv, CUDNN_ATTR_OPERATION_CONVOLUTION_FORWARD_CONV_DESC, CUDNN_TYPE_BACKEND_DESCRIPTOR, 1, &convDesc));
+	CHECK(setScalar(conv, CUDNN_ATTR_OPERATION_CONVOLUTION_FORWARD_ALPHA, computeType, alpha1));
+	CHECK(setScalar(conv, CUDNN_ATTR_OPERATION_CONVOLUTION_FORWARD_BETA, computeType, 0));
+	CHECK(cudnnBackendFinalize(conv));
+	ops[nbOps++] = conv;
+
+	// + alpha2 * z
+	if (hasResidual) {
+		CHECK(newPointwise(&ds, &ops[nbOps++], CUDNN_POINTWISE_ADD, computeType, 0, convOut, z, alpha2, biasIn));
+	}
+	// + bias
+	CHECK(newPointwise(&ds, &ops[nbOps++], CUDNN_POINTWISE_ADD, computeType, 0, biasIn, b, 1, biasOut));
+	// act
+	if (hasActivation) {
+		CHECK(newPointwise(&ds, &ops[nbOps++], actMode, computeType, coef, biasOut, NULL, 0, y));
+	}
+
+	CHECK(create(&ds, CUDNN_BACKEND_OPERATIONGRAPH_DESCRIPTOR, &graph));
+	CHECK(cudnnBackendSetAttribute(graph, CUDNN_ATTR_OPERATIONGRAPH_OPS, CUDNN_TYPE_BACKEND_DESCRIPTOR, nbOps, ops));
+	CHECK(cudnnBackendSetAttribute(graph, CUDNN_ATTR_OPERATIONGRAPH_HANDLE, CUDNN_TYPE_HANDLE, 1, &handle));
+	CHECK(cudnnBackendFinalize(graph));
+
+	CHECK(newPlan(&ds, handle, graph, &result));
+	CHECK(cudnnBackendGetAttribute(result, CUDNN_ATTR_EXECUTION_PLAN_WORKSPACE_SIZE, CUDNN_TYPE_INT64, 1, &n, workspaceSize));
+	*plan = result;
+	result = NULL;
+done:
+	if (result != NULL) {
+		cudnnBackendDestroyDescriptor(result);
+	}
+	for (int i = ds.n - 1; i >= 0; i--) {
+		cudnnBackendDestroyDescriptor(ds.d[i]);
+	}
+	return status;
+}
+
+cudnnStatus_t gocudnnFusedConvExecute(cudnnHandle_t handle, void *plan, const int hasResidual,
+	void *x, void *w, void *b, void *y, void *z, void *workspace) {
+
+	cudnnStatus_t status;
+	cudnnBackendDescriptor_t pack;
+	void *ptrs[] = {x, w, b, y, z};
+	int64_t uids[] = {uidX, uidW, uidB, uidY, uidZ};
+	int64_t n = hasResidual ? 5 : 4;
+
+	if ((status = cudnnBackendCreateDescriptor(CUDNN_BACKEND_VARIANT_PACK_DESCRIPTOR, &pack)) != CUDNN_STATUS_SUCCESS) {
+		return status;
+	}
+	CHECK(cudnnBackendSetAttribute(pack, CUDNN_ATTR_VARIANT_PACK_DATA_POINTERS, CUDNN_TYPE_VOID_PTR, n, ptrs));
+	CHECK(cudnnBackendSetAttribute(pack, CUDNN_ATTR_VARIANT_PACK_UNIQUE_IDS, CUDNN_TYPE_INT64, n, uids));
+	CHECK(cudnnBackendSetAttribute(pack, CUDNN_ATTR_VARIANT_PACK_WORKSPACE, CUDNN_TYPE_VOID_PTR, 1, &workspace));
+	CHECK(cudnnBackendFinalize(pack));
+	CHECK(cudnnBackendExecute(handle, (cudnnBackendDescriptor_t)plan, pack));
+done:
+	cudnnBackendDestroyDescriptor(pack);
+	return status;
+}
+
+cudnnStatus_t gocudnnDestroyFusedConvPlan(void *plan) {
+	return cudnnBackendDestroyDescriptor((cudnnBackendDescriptor_t)plan);
+}
+
+#else // the backend API needs cuDNN 8
+
+cudnnStatus_t gocudnnNewFusedConvPlan(cudnnHandle_t handle, void **plan, int64_t *workspaceSize,
+	cudnnDataType_t dataType, cudnnDataType_t computeType,
+	const int nbDims, const int64_t *dims, const int64_t *strides, const int hasResidual,
+	cudnnConvolutionMode_t convMode, const int64_t *padding, const int64_t *filterStrides, const int64_t *dilation,
+	double alpha1, double alpha2, cudnnActivationMode_t act, double coef) {
+	return CUDNN_STATUS_NOT_SUPPORTED;
+}
+
+cudnnStatus_t gocudnnFusedConvExecute(cudnnHandle_t handle, void *plan, const int hasResidual,
+	void *x, void *w, void *b, void *y, void *z, void *workspace) {
+	return CUDNN_STATUS_NOT_SUPPORTED;
+}
+
+cudnnStatus_t gocudnnDestroyFusedConvPlan(void *plan) { return CUDNN_STATUS_NOT_SUPPORTED; }
+
+#endif
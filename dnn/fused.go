@@ -0,0 +1,355 @@
+package cudnn
+
+// #include <cudnn.h>
+// #include "fused.h"
+import "C"
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// FusedConv is a convolution fused with the addition of a bias and an activation, computed in a single call to
+// cudnnConvolutionBiasActivationForward:
+//
+//	y = act(alpha1 * conv(x, w) + alpha2 * z + bias)
+//
+// where z is an optional residual input of the same shape as y.
+//
+// This saves launching (and reading back from memory) the bias and activation separately, which matters for inference.
+// This call only fuses ReLU and Identity. Identity is only supported by ConvolutionFwdAlgoImplicitPrecompGemm.
+//
+// FusedConvEngine fuses more activations, with the engines of the cuDNN backend API (cuDNN 8.0 or later).
+type FusedConv struct {
+	X    *TensorDescriptor
+	W    *Filter
+	Conv *Convolution
+	Bias *TensorDescriptor // of shape (1, C, 1, 1) where C is the number of channels of Y
+	Y    *TensorDescriptor
+	Act  *Activation
+	Algo ConvolutionFwdAlgo
+
+	ctx   *Context
+	alloc Allocator
+	size  uintptr
+
+	sync.Mutex
+	workspace Memory
+}
+
+// NewFusedConv creates a FusedConv. The workspace is allocated with the given Allocator upon the first call.
+func NewFusedConv(ctx *Context, alloc Allocator, x *TensorDescriptor, w *Filter, conv *Convolution, bias *TensorDescriptor, y *TensorDescriptor, act *Activation, algo ConvolutionFwdAlgo) (*FusedConv, error) {
+	f := &FusedConv{
+		X:     x,
+		W:     w,
+		Conv:  conv,
+		Bias:  bias,
+		Y:     y,
+		Act:   act,
+		Algo:  algo,
+		ctx:   ctx,
+		alloc: alloc,
+	}
+	if err := f.check(); err != nil {
+		return nil, err
+	}
+	var err error
+	if f.size, err = ctx.GetConvolutionForwardWorkspaceSize(x, w, conv, y, algo); err != nil {
+		return nil, errors.Wrap(err, "Unable to get the workspace size of the fused convolution")
+	}
+	return f, nil
+}
+
+// WorkspaceSize returns the size of the workspace used by the fused convolution.
+func (f *FusedConv) WorkspaceSize() uintptr { return f.size }
+
+// Forward computes y = act(conv(x, w) + bias).
+func (f *FusedConv) Forward(x, w, bias, y Memory) error {
+	// z aliases y, and is ignored as alpha2 is 0
+	return f.ForwardResidual(1, x, w, 0, y, bias, y)
+}
+
+// ForwardResidual computes y = act(alpha1 * conv(x, w) + alpha2 * z + bias). z may alias y.
+func (f *FusedConv) ForwardResidual(alpha1 float64, x, w Memory, alpha2 float64, z, bias, y Memory) error {
+	ws, err := f.Workspace()
+	if err != nil {
+		return err
+	}
+	return f.ctx.ConvolutionBiasActivationForward(alpha1, f.X, x, f.W, w, f.Conv, f.Algo, ws, f.size, alpha2, f.Y, z, f.Bias, bias, f.Act, f.Y, y)
+}
+
+// Workspace returns the workspace, allocating it if it has not been allocated before.
+func (f *FusedConv) Workspace() (Memory, error) {
+	if f.size == 0 {
+		return noWorkspace{}, nil
+	}
+	f.Lock()
+	defer f.Unlock()
+	if f.workspace != nil {
+		return f.workspace, nil
+	}
+	ws, err := f.alloc.Alloc(f.size)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to allocate a workspace of %d bytes", f.size)
+	}
+	f.workspace = ws
+	return ws, nil
+}
+
+// Close frees the workspace.
+func (f *FusedConv) Close() error {
+	f.Lock()
+	defer f.Unlock()
+	if f.workspace == nil {
+		return nil
+	}
+	err := f.alloc.Free(f.workspace)
+	f.workspace = nil
+	return err
+}
+
+func (f *FusedConv) check() error {
+	switch {
+	case f.Act == nil:
+		return errors.New("FusedConv requires an activation. Use Identity for none")
+	case f.Act.Mode() == Identity && f.Algo != ConvolutionFwdAlgoImplicitPrecompGemm:
+		return errors.Errorf("Identity is only supported by ConvolutionFwdAlgoImplicitPrecompGemm. Got %v instead", f.Algo)
+	case f.Act.Mode() != ReLU && f.Act.Mode() != Identity:
+		return errors.Errorf("Only ReLU and Identity can be fused with a convolution. Got %v instead", f.Act.Mode())
+	}
+
+	return checkBias(f.Bias, f.Y)
+}
+
+// checkBias checks that the bias is of shape (1, C, 1, 1...), where C is the number of channels of y.
+func checkBias(bias, y *TensorDescriptor) error {
+	yShape := y.Shape()
+	bShape := bias.Shape()
+	if len(yShape) < 2 || len(bShape) != len(yShape) {
+		return errors.Errorf("Expected the bias to be of the same rank as y %v. Got %v instead", yShape, bShape)
+	}
+	for i, d := range bShape {
+		want := 1
+		if i == 1 {
+			want = yShape[1]
+		}
+		if d != want {
+			return errors.Errorf("Expected the bias to be of shape (1, %d, 1, 1). Got %v instead", yShape[1], bShape)
+		}
+	}
+	return nil
+}
+
+// FusedConvEngine is a convolution fused with the addition of a bias, of an optional residual input, and an activation,
+// computed by an engine of the cuDNN backend API (cuDNN 8.0 or later):
+//
+//	y = act(alpha1 * conv(x, w) + alpha2 * z + bias)
+//
+// The operations are described as a graph, and the heuristics of cuDNN pick the engine that runs it: unlike FusedConv,
+// no algorithm is chosen, and the activation may be ReLU, ClippedReLU, Tanh, Sigmoid, Elu or Identity. Which graphs
+// have an engine depends on the version of cuDNN and on the device - the runtime fusion engines mostly want NHWC tensors
+// of half precision. When none is found, NewFusedConvEngine returns NotSupported, and FusedConv is the fallback.
+//
+// The plan is bound to the handle of the Context it was made with, and runs on its stream. The memory of the tensors
+// must be aligned to 16 bytes.
+type FusedConvEngine struct {
+	X      *TensorDescriptor
+	W      *Filter
+	Conv   *Convolution
+	Z      *TensorDescriptor // the residual input, of the shape of Y. nil for none
+	Bias   *TensorDescriptor // of shape (1, C, 1, 1) where C is the number of channels of Y
+	Y      *TensorDescriptor
+	Act    *Activation
+	Alpha1 float64
+	Alpha2 float64
+
+	ctx   *Context
+	alloc Allocator
+	plan  unsafe.Pointer // cudnnBackendDescriptor_t
+	size  uintptr
+
+	sync.Mutex
+	workspace Memory
+}
+
+// NewFusedConvEngine builds the graph of the fused convolution, and finalizes the plan of the first engine the
+// heuristics propose. z may be nil, in which case alpha2 is ignored. The workspace is allocated with the given Allocator
+// upon the first call.
+func NewFusedConvEngine(ctx *Context, alloc Allocator, x *TensorDescriptor, w *Filter, conv *Convolution, z, bias, y *TensorDescriptor, act *Activation, alpha1, alpha2 float64) (*FusedConvEngine, error) {
+	f := &FusedConvEngine{
+		X:      x,
+		W:      w,
+		Conv:   conv,
+		Z:      z,
+		Bias:   bias,
+		Y:      y,
+		Act:    act,
+		Alpha1: alpha1,
+		Alpha2: alpha2,
+		ctx:    ctx,
+		alloc:  alloc,
+	}
+	if err := f.check(); err != nil {
+		return nil, err
+	}
+
+	// the dimensions and strides of X, W, B, Y and Z, one after the other
+	n := len(x.shape)
+	dims := make([]int64, 0, 5*n)
+	strides := make([]int64, 0, 5*n)
+	add := func(format TensorFormat, shape, explicit []int) {
+		for _, d := range shape {
+			dims = append(dims, int64(d))
+		}
+		strides = append(strides, backendStrides(format, shape, explicit)...)
+	}
+	add(x.format, x.shape, x.strides)
+	add(w.format, w.shape, nil)
+	add(bias.format, bias.shape, bias.strides)
+	add(y.format, y.shape, y.strides)
+	var hasResidual C.int
+	if z != nil {
+		add(z.format, z.shape, z.strides)
+		hasResidual = 1
+	}
+	padding, filterStrides, dilation := ints64(conv.padding), ints64(conv.filterStride), ints64(conv.dilation)
+
+	var plan unsafe.Pointer
+	var size C.int64_t
+	if err := result(C.gocudnnNewFusedConvPlan(ctx.internal, &plan, &size,
+		x.dataType.C(), conv.dataType.C(),
+		C.int(n), (*C.int64_t)(&dims[0]), (*C.int64_t)(&strides[0]), hasResidual,
+		conv.mode.C(), (*C.int64_t)(&padding[0]), (*C.int64_t)(&filterStrides[0]), (*C.int64_t)(&dilation[0]),
+		C.double(alpha1), C.double(alpha2), act.mode.C(), C.double(act.coef))); err != nil {
+		return nil, errors.Wrap(err, "Unable to plan the fused convolution")
+	}
+	f.plan, f.size = plan, uintptr(size)
+	return f, nil
+}
+
+// WorkspaceSize returns the size of the workspace used by the engine.
+func (f *FusedConvEngine) WorkspaceSize() uintptr { return f.size }
+
+// Forward computes y = act(alpha1 * conv(x, w) + alpha2 * z + bias). z is ignored if the engine has no residual input,
+// and may alias y otherwise.
+func (f *FusedConvEngine) Forward(x, w, z, bias, y Memory) error {
+	if f.plan == nil {
+		return errors.New("The FusedConvEngine is closed")
+	}
+	mems := []Memory{x, w, bias, y}
+	if f.Z != nil {
+		mems = append(mems, z)
+	}
+	for _, mem := range mems {
+		if mem.Uintptr()%16 != 0 {
+			return errors.Errorf("The memory of the tensors must be aligned to 16 bytes. Got %#x", mem.Uintptr())
+		}
+	}
+	ws, err := f.Workspace()
+	if err != nil {
+		return err
+	}
+	var zPtr unsafe.Pointer
+	var hasResidual C.int
+	if f.Z != nil {
+		zPtr, hasResidual = z.Pointer(), 1
+	}
+	return result(C.gocudnnFusedConvExecute(f.ctx.internal, f.plan, hasResidual,
+		x.Pointer(), w.Pointer(), bias.Pointer(), y.Pointer(), zPtr, ws.Pointer()))
+}
+
+// Workspace returns the workspace, allocating it if it has not been allocated before.
+func (f *FusedConvEngine) Workspace() (Memory, error) {
+	if f.size == 0 {
+		return noWorkspace{}, nil
+	}
+	f.Lock()
+	defer f.Unlock()
+	if f.workspace != nil {
+		return f.workspace, nil
+	}
+	ws, err := f.alloc.Alloc(f.size)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to allocate a workspace of %d bytes", f.size)
+	}
+	f.workspace = ws
+	return ws, nil
+}
+
+// Close destroys the plan, and frees the workspace.
+func (f *FusedConvEngine) Close() error {
+	f.Lock()
+	defer f.Unlock()
+	if f.plan != nil {
+		if err := result(C.gocudnnDestroyFusedConvPlan(f.plan)); err != nil {
+			return err
+		}
+		f.plan = nil
+	}
+	if f.workspace == nil {
+		return nil
+	}
+	err := f.alloc.Free(f.workspace)
+	f.workspace = nil
+	return err
+}
+
+func (f *FusedConvEngine) check() error {
+	if f.Act == nil {
+		return errors.New("FusedConvEngine requires an activation. Use Identity for none")
+	}
+	switch f.Act.Mode() {
+	case ReLU, ClippedReLU, Tanh, Sigmoid, Elu, Identity:
+	default:
+		return errors.Errorf("%v cannot be fused with a convolution", f.Act.Mode())
+	}
+	n := len(f.X.shape)
+	if n < 3 || len(f.W.shape) != n || len(f.Y.shape) != n {
+		return errors.Errorf("Expected x, w and y of the same rank, of at least 3. Got %v, %v and %v", f.X.shape, f.W.shape, f.Y.shape)
+	}
+	if len(f.Conv.padding) != n-2 || len(f.Conv.filterStride) != n-2 || len(f.Conv.dilation) != n-2 {
+		return errors.Errorf("Expected a convolution over %d spatial dimensions", n-2)
+	}
+	if f.Z != nil && !shapeEq(f.Z.shape, f.Y.shape) {
+		return errors.Errorf("Expected the residual input to be of the shape of y %v. Got %v instead", f.Y.shape, f.Z.shape)
+	}
+	return checkBias(f.Bias, f.Y)
+}
+
+// backendStrides returns the strides of a tensor of the given shape and format, for the backend API, which always
+// takes them explicitly. Explicit strides are returned as they are.
+func backendStrides(format TensorFormat, shape, strides []int) []int64 {
+	retVal := make([]int64, len(shape))
+	if len(strides) == len(shape) {
+		for i, s := range strides {
+			retVal[i] = int64(s)
+		}
+		return retVal
+	}
+	if format != NHWC || len(shape) < 3 {
+		acc := int64(1)
+		for i := len(shape) - 1; i >= 0; i-- {
+			retVal[i] = acc
+			acc *= int64(shape[i])
+		}
+		return retVal
+	}
+	// NHWC: the channels are the innermost dimension, followed by the spatial dimensions, and then the batch
+	acc := int64(shape[1])
+	retVal[1] = 1
+	for i := len(shape) - 1; i >= 2; i-- {
+		retVal[i] = acc
+		acc *= int64(shape[i])
+	}
+	retVal[0] = acc
+	return retVal
+}
+
+func ints64(a []int) []int64 {
+	retVal := make([]int64, len(a))
+	for i, v := range a {
+		retVal[i] = int64(v)
+	}
+	return retVal
+}
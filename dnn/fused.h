@@ -0,0 +1,16 @@
+#include <stdint.h>
+
+// The plans are cudnnBackendDescriptor_t, which only exist from cuDNN 8 on. They are passed as void* so that the
+// package still builds with cuDNN 7, where gocudnnNewFusedConvPlan returns CUDNN_STATUS_NOT_SUPPORTED.
+
+// The tensors are X, W, B (the bias), Y and, if hasResidual, Z: nbDims dimensions and strides each.
+extern cudnnStatus_t gocudnnNewFusedConvPlan(cudnnHandle_t handle, void **plan, int64_t *workspaceSize,
+	cudnnDataType_t dataType, cudnnDataType_t computeType,
+	const int nbDims, const int64_t *dims, const int64_t *strides, const int hasResidual,
+	cudnnConvolutionMode_t convMode, const int64_t *padding, const int64_t *filterStrides, const int64_t *dilation,
+	double alpha1, double alpha2, cudnnActivationMode_t act, double coef);
+
+extern cudnnStatus_t gocudnnFusedConvExecute(cudnnHandle_t handle, void *plan, const int hasResidual,
+	void *x, void *w, void *b, void *y, void *z, void *workspace);
+
+extern cudnnStatus_t gocudnnDestroyFusedConvPlan(void *plan);
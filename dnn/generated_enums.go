@@ -193,6 +193,7 @@ const (
 	Tanh        ActivationMode = C.CUDNN_ACTIVATION_TANH
 	ClippedReLU ActivationMode = C.CUDNN_ACTIVATION_CLIPPED_RELU
 	Elu         ActivationMode = C.CUDNN_ACTIVATION_ELU
+	Identity    ActivationMode = C.CUDNN_ACTIVATION_IDENTITY
 )
 
 // C returns the C representation of ActivationMode
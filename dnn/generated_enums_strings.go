@@ -124,6 +124,7 @@ var _ActivationModeNames = map[ActivationMode]string{
 	Tanh:        "Tanh",
 	ClippedReLU: "ClippedReLU",
 	Elu:         "Elu",
+	Identity:    "Identity",
 }
 
 func (e ActivationMode) String() string { return _ActivationModeNames[e] }
@@ -3,7 +3,11 @@ package cudnn
 // #include <cudnn.h>
 import "C"
 import (
+	"sync"
 	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
 )
 
 // Memory represents an instance of CUDA memory
@@ -19,8 +23,16 @@ type Memory interface {
 // Internally, the Context holds a cudnnHandle_t
 //
 // Once the context has been finished, do remember to call `Close` on the context.
+//
+// The calls made with a Context are executed on its stream. To make calls on many streams at once, use a Context per
+// stream (see OnStream) rather than setting the stream of a shared Context before each call.
 type Context struct {
 	internal C.cudnnHandle_t
+
+	parent *Context // the context that made this one with OnStream, if any
+
+	mu      sync.Mutex
+	streams map[uintptr]*Context // the contexts bound to streams by OnStream
 }
 
 // NewContext creates a new Context. This is the only function that will panic if it is unable to create the context.
@@ -29,20 +41,74 @@ func NewContext() (retVal *Context) {
 	if err := result(C.cudnnCreate(&internal)); err != nil {
 		panic(err)
 	}
-	retVal = &Context{internal}
+	retVal = &Context{internal: internal}
 	return retVal
 }
 
-//  Close destroys the underlying context.
+// Close destroys the underlying context, and the contexts made with OnStream. The contexts made with OnStream are
+// closed by the context that made them: closing them does nothing.
 func (ctx *Context) Close() error {
 	var empty C.cudnnHandle_t
-	if ctx.internal == empty {
+	if ctx.internal == empty || ctx.parent != nil {
 		return nil
 	}
 
+	ctx.mu.Lock()
+	for s, sctx := range ctx.streams {
+		if err := result(C.cudnnDestroy(sctx.internal)); err != nil {
+			ctx.mu.Unlock()
+			return err
+		}
+		sctx.internal = empty
+		delete(ctx.streams, s)
+	}
+	ctx.mu.Unlock()
+
 	if err := result(C.cudnnDestroy(ctx.internal)); err != nil {
 		return err
 	}
 	ctx.internal = empty
 	return nil
 }
+
+// SetStream sets the stream on which the cuDNN calls made with the context are executed. Setting the stream of a context
+// used by many goroutines races with their calls: use OnStream instead. The stream of a context made with OnStream
+// cannot be changed.
+func (ctx *Context) SetStream(stream cu.Stream) error {
+	if ctx.parent != nil {
+		return errors.New("The stream of a context made with OnStream cannot be changed")
+	}
+	return result(C.cudnnSetStream(ctx.internal, C.cudaStream_t(stream.Pointer())))
+}
+
+// OnStream returns a context whose calls are executed on the stream. It is created the first time it is asked for, with
+// a handle of its own, bound to the stream once: calls on different streams are made with different handles, and run
+// concurrently, without setting the stream of a shared handle before each call. Like the handles of NewContext, it
+// belongs to the CUDA context current on the calling thread.
+//
+// The contexts are closed with this one. OnStream is safe for concurrent use, but the calls made with one of the
+// contexts must not be made by many goroutines at once.
+func (ctx *Context) OnStream(stream cu.Stream) (*Context, error) {
+	if ctx.parent != nil {
+		return ctx.parent.OnStream(stream)
+	}
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if sctx, ok := ctx.streams[stream.Uintptr()]; ok {
+		return sctx, nil
+	}
+	var internal C.cudnnHandle_t
+	if err := result(C.cudnnCreate(&internal)); err != nil {
+		return nil, errors.Wrap(err, "Unable to create a cuDNN handle for the stream")
+	}
+	if err := result(C.cudnnSetStream(internal, C.cudaStream_t(stream.Pointer()))); err != nil {
+		C.cudnnDestroy(internal)
+		return nil, errors.Wrap(err, "Unable to set the stream of the cuDNN handle")
+	}
+	if ctx.streams == nil {
+		ctx.streams = make(map[uintptr]*Context)
+	}
+	sctx := &Context{internal: internal, parent: ctx}
+	ctx.streams[stream.Uintptr()] = sctx
+	return sctx, nil
+}
@@ -0,0 +1,179 @@
+package cudnn
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// Allocator allocates device memory for the workspaces of a ConvolutionPlanner.
+type Allocator interface {
+	Alloc(size uintptr) (Memory, error)
+	Free(mem Memory) error
+}
+
+// ConvolutionLayer describes a convolution layer, and the algorithms it uses.
+//
+// X and Y are the input and output tensors of the forward pass. Their gradients (dx and dy) are expected to share the same descriptors.
+// The algorithms for the backward passes are only considered if Backward is true.
+type ConvolutionLayer struct {
+	X    *TensorDescriptor
+	W    *Filter
+	Conv *Convolution
+	Y    *TensorDescriptor
+
+	Fwd       ConvolutionFwdAlgo
+	BwdData   ConvolutionBwdDataAlgo
+	BwdFilter ConvolutionBwdFilterAlgo
+	Backward  bool
+}
+
+// ConvolutionPlanner plans the workspace for a static set of convolution layers.
+//
+// Instead of allocating a workspace for every call, the planner computes the largest workspace required by any of the layers,
+// and allocates it once per stream. The workspace is then shared across all the layers executed on that stream.
+// Calls on the same stream are serialized by the stream, so sharing the workspace is safe.
+type ConvolutionPlanner struct {
+	ctx    *Context
+	alloc  Allocator
+	layers []ConvolutionLayer
+	size   uintptr
+
+	sync.Mutex
+	workspaces map[uintptr]Memory // keyed by stream
+}
+
+// NewConvolutionPlanner creates a planner for the given layers.
+func NewConvolutionPlanner(ctx *Context, alloc Allocator, layers ...ConvolutionLayer) (*ConvolutionPlanner, error) {
+	p := &ConvolutionPlanner{
+		ctx:        ctx,
+		alloc:      alloc,
+		layers:     layers,
+		workspaces: make(map[uintptr]Memory),
+	}
+	for i, l := range layers {
+		size, err := l.workspaceSize(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Unable to get the workspace size of layer %d", i)
+		}
+		if size > p.size {
+			p.size = size
+		}
+	}
+	return p, nil
+}
+
+// WorkspaceSize returns the size of the workspace that is shared by all the layers.
+func (p *ConvolutionPlanner) WorkspaceSize() uintptr { return p.size }
+
+// Layers returns the number of layers planned for.
+func (p *ConvolutionPlanner) Layers() int { return len(p.layers) }
+
+// Workspace returns the workspace for the given stream, allocating it if it has not been allocated before.
+func (p *ConvolutionPlanner) Workspace(stream cu.Stream) (Memory, error) {
+	if p.size == 0 {
+		return noWorkspace{}, nil
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	if ws, ok := p.workspaces[stream.Uintptr()]; ok {
+		return ws, nil
+	}
+	ws, err := p.alloc.Alloc(p.size)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to allocate a workspace of %d bytes", p.size)
+	}
+	p.workspaces[stream.Uintptr()] = ws
+	return ws, nil
+}
+
+// Forward performs the forward convolution of the ith layer on the given stream.
+func (p *ConvolutionPlanner) Forward(stream cu.Stream, i int, alpha float64, x, w Memory, beta float64, y Memory) error {
+	l, ctx, ws, err := p.prepare(stream, i)
+	if err != nil {
+		return err
+	}
+	return ctx.ConvolutionForward(alpha, l.X, x, l.W, w, l.Conv, l.Fwd, ws, p.size, beta, l.Y, y)
+}
+
+// BackwardData computes the gradient of the input of the ith layer on the given stream.
+func (p *ConvolutionPlanner) BackwardData(stream cu.Stream, i int, alpha float64, w, dy Memory, beta float64, dx Memory) error {
+	l, ctx, ws, err := p.prepare(stream, i)
+	if err != nil {
+		return err
+	}
+	if !l.Backward {
+		return errors.Errorf("Layer %d was not planned for the backward pass", i)
+	}
+	return ctx.ConvolutionBackwardData(alpha, l.W, w, l.Y, dy, l.Conv, l.BwdData, ws, p.size, beta, l.X, dx)
+}
+
+// BackwardFilter computes the gradient of the filter of the ith layer on the given stream.
+func (p *ConvolutionPlanner) BackwardFilter(stream cu.Stream, i int, alpha float64, x, dy Memory, beta float64, dw Memory) error {
+	l, ctx, ws, err := p.prepare(stream, i)
+	if err != nil {
+		return err
+	}
+	if !l.Backward {
+		return errors.Errorf("Layer %d was not planned for the backward pass", i)
+	}
+	return ctx.ConvolutionBackwardFilter(alpha, l.X, x, l.Y, dy, l.Conv, l.BwdFilter, ws, p.size, beta, l.W, dw)
+}
+
+// Close frees all the workspaces.
+func (p *ConvolutionPlanner) Close() error {
+	p.Lock()
+	defer p.Unlock()
+	var err error
+	for s, ws := range p.workspaces {
+		if e := p.alloc.Free(ws); e != nil && err == nil {
+			err = e
+		}
+		delete(p.workspaces, s)
+	}
+	return err
+}
+
+// prepare returns the ith layer, and the context and the workspace of the stream.
+func (p *ConvolutionPlanner) prepare(stream cu.Stream, i int) (l ConvolutionLayer, ctx *Context, ws Memory, err error) {
+	if i < 0 || i >= len(p.layers) {
+		return l, nil, nil, errors.Errorf("Layer %d out of range. There are %d layers", i, len(p.layers))
+	}
+	if ws, err = p.Workspace(stream); err != nil {
+		return l, nil, nil, err
+	}
+	if ctx, err = p.ctx.OnStream(stream); err != nil {
+		return l, nil, nil, err
+	}
+	return p.layers[i], ctx, ws, nil
+}
+
+func (l ConvolutionLayer) workspaceSize(ctx *Context) (size uintptr, err error) {
+	if size, err = ctx.GetConvolutionForwardWorkspaceSize(l.X, l.W, l.Conv, l.Y, l.Fwd); err != nil || !l.Backward {
+		return size, err
+	}
+	var s uintptr
+	if s, err = ctx.GetConvolutionBackwardDataWorkspaceSize(l.W, l.Y, l.Conv, l.X, l.BwdData); err != nil {
+		return 0, err
+	}
+	if s > size {
+		size = s
+	}
+	if s, err = ctx.GetConvolutionBackwardFilterWorkspaceSize(l.X, l.Y, l.Conv, l.W, l.BwdFilter); err != nil {
+		return 0, err
+	}
+	if s > size {
+		size = s
+	}
+	return size, nil
+}
+
+// noWorkspace is passed in when none of the layers require a workspace.
+type noWorkspace struct{}
+
+func (noWorkspace) Uintptr() uintptr           { return 0 }
+func (noWorkspace) Pointer() unsafe.Pointer    { return nil }
+func (noWorkspace) IsNativelyAccessible() bool { return false }
@@ -0,0 +1,286 @@
+package cudnn
+
+// #include <cudnn.h>
+import "C"
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// LinLayers returns the number of linear layers of each pseudo-layer of the RNN, which are indexed by the linLayerID of
+// GetRNNLinLayerMatrixParams and GetRNNLinLayerBiasParams: 2 for RNNReLU and RNNTanh, 8 for LSTM and 6 for GRU. The
+// first half of them apply to the input of the pseudo-layer, and the second half to its recurrent input.
+func (r *RNN) LinLayers() int {
+	switch r.mode {
+	case LSTM:
+		return 8
+	case GRU:
+		return 6
+	}
+	return 2
+}
+
+// Directions returns 2 for a bidirectional RNN, and 1 otherwise. A RNN has NumLayers()*Directions() pseudo-layers.
+func (r *RNN) Directions() int {
+	if r.directionMode == Bidirectional {
+		return 2
+	}
+	return 1
+}
+
+// GetRNNLinLayerMatrixParams returns the descriptor and the memory of the weight matrix linLayerID of the pseudo-layer
+// layer, within the weights w of the RNN. The memory points into w, so that the matrices can be initialized in place.
+func (co *Context) GetRNNLinLayerMatrixParams(rnnDesc *RNN, layer int, xDesc *TensorDescriptor, wDesc *Filter, w Memory, linLayerID int) (*Filter, Memory, error) {
+	return co.linLayerParams(rnnDesc, layer, xDesc, wDesc, w, linLayerID, false)
+}
+
+// GetRNNLinLayerBiasParams returns the descriptor and the memory of the bias linLayerID of the pseudo-layer layer,
+// within the weights w of the RNN (see GetRNNLinLayerMatrixParams).
+func (co *Context) GetRNNLinLayerBiasParams(rnnDesc *RNN, layer int, xDesc *TensorDescriptor, wDesc *Filter, w Memory, linLayerID int) (*Filter, Memory, error) {
+	return co.linLayerParams(rnnDesc, layer, xDesc, wDesc, w, linLayerID, true)
+}
+
+func (co *Context) linLayerParams(rnnDesc *RNN, layer int, xDesc *TensorDescriptor, wDesc *Filter, w Memory, linLayerID int, bias bool) (*Filter, Memory, error) {
+	if layers := rnnDesc.NumLayers() * rnnDesc.Directions(); layer < 0 || layer >= layers {
+		return nil, nil, errors.Errorf("Expected a pseudo-layer in [0, %d). Got %d", layers, layer)
+	}
+	if lin := rnnDesc.LinLayers(); linLayerID < 0 || linLayerID >= lin {
+		return nil, nil, errors.Errorf("Expected a linear layer in [0, %d) for %v. Got %d", lin, rnnDesc.Mode(), linLayerID)
+	}
+	var desc C.cudnnFilterDescriptor_t
+	if err := result(C.cudnnCreateFilterDescriptor(&desc)); err != nil {
+		return nil, nil, err
+	}
+	var ptr unsafe.Pointer
+	var err error
+	if bias {
+		err = result(C.cudnnGetRNNLinLayerBiasParams(co.internal, rnnDesc.internal, C.int(layer), xDesc.internal, wDesc.internal, w.Pointer(), C.int(linLayerID), desc, &ptr))
+	} else {
+		err = result(C.cudnnGetRNNLinLayerMatrixParams(co.internal, rnnDesc.internal, C.int(layer), xDesc.internal, wDesc.internal, w.Pointer(), C.int(linLayerID), desc, &ptr))
+	}
+	if err != nil {
+		C.cudnnDestroyFilterDescriptor(desc)
+		return nil, nil, err
+	}
+
+	// the shape of the parameters is read back from the descriptor cuDNN filled in
+	var dt C.cudnnDataType_t
+	var format C.cudnnTensorFormat_t
+	var nbDims C.int
+	dims := make([]C.int, 8)
+	if err = result(C.cudnnGetFilterNdDescriptor(desc, C.int(len(dims)), &dt, &format, &nbDims, &dims[0])); err != nil {
+		C.cudnnDestroyFilterDescriptor(desc)
+		return nil, nil, err
+	}
+	shape := make([]int, int(nbDims))
+	for i := range shape {
+		shape[i] = int(dims[i])
+	}
+	f := &Filter{
+		internal: desc,
+		dataType: DataType(dt),
+		format:   TensorFormat(format),
+		shape:    shape,
+	}
+	runtime.SetFinalizer(f, destroyFilter)
+	return f, rnnParams{ptr}, nil
+}
+
+// rnnParams is the memory of parameters within the weights of a RNN.
+type rnnParams struct{ ptr unsafe.Pointer }
+
+func (p rnnParams) Uintptr() uintptr           { return uintptr(p.ptr) }
+func (p rnnParams) Pointer() unsafe.Pointer    { return p.ptr }
+func (p rnnParams) IsNativelyAccessible() bool { return false }
+
+// RNNLayer runs a RNN over minibatches of sequences of a fixed length, and keeps the memory the calls need.
+//
+// The inputs x and the outputs y hold the sequences time step major, packed: the time step t of x is a matrix of
+// (minibatch, inputSize), and of y a matrix of (minibatch, hiddenSize*directions). The hidden states hx, cx, hy and cy,
+// and their gradients, are of shape (layers*directions, minibatch, hiddenSize). cx and cy are the cell states of an
+// LSTM, and are ignored otherwise. Any of hx, cx, hy, cy and their gradients may be nil: initial states are then zero,
+// and final states are not written.
+//
+// The weights of all the layers are packed in a buffer of ParamsSize bytes, described by W. GetRNNLinLayerMatrixParams
+// and GetRNNLinLayerBiasParams locate the matrices and biases within it.
+//
+// ForwardTraining keeps in a reserve what BackwardData and BackwardWeights need: they must follow it, on the same
+// inputs. The workspace and the reserve are shared by all the calls, which are expected to be serialized on the same
+// stream.
+type RNNLayer struct {
+	RNN *RNN
+	X   []*TensorDescriptor // one per time step
+	Y   []*TensorDescriptor // one per time step
+	H   *TensorDescriptor   // the hidden and cell states
+	W   *Filter             // the weights
+
+	ctx   *Context
+	alloc Allocator
+
+	paramsSize, workspaceSize, reserveSize uintptr
+
+	sync.Mutex
+	workspace Memory
+	reserve   Memory
+}
+
+// NewRNNLayer creates a RNNLayer of the given RNN, for sequences of seqLength time steps, minibatch sequences at once,
+// and inputs of inputSize. The workspace and the reserve are allocated with the given Allocator upon the first call.
+func NewRNNLayer(ctx *Context, alloc Allocator, rnn *RNN, seqLength, minibatch, inputSize int) (*RNNLayer, error) {
+	if seqLength <= 0 || minibatch <= 0 || inputSize <= 0 {
+		return nil, errors.Errorf("Invalid RNN layer of %d time steps of %d sequences of %d", seqLength, minibatch, inputSize)
+	}
+	dt := rnn.DataType()
+	outputSize := rnn.HiddenSize() * rnn.Directions()
+	l := &RNNLayer{
+		RNN:   rnn,
+		X:     make([]*TensorDescriptor, seqLength),
+		Y:     make([]*TensorDescriptor, seqLength),
+		ctx:   ctx,
+		alloc: alloc,
+	}
+
+	// cuDNN describes each time step with a packed 3D tensor
+	var err error
+	for t := 0; t < seqLength; t++ {
+		if l.X[t], err = NewTensorDescriptor(NCHW, dt, []int{minibatch, inputSize, 1}, []int{inputSize, 1, 1}); err != nil {
+			return nil, errors.Wrap(err, "Unable to describe the inputs")
+		}
+		if l.Y[t], err = NewTensorDescriptor(NCHW, dt, []int{minibatch, outputSize, 1}, []int{outputSize, 1, 1}); err != nil {
+			return nil, errors.Wrap(err, "Unable to describe the outputs")
+		}
+	}
+	layers, hidden := rnn.NumLayers()*rnn.Directions(), rnn.HiddenSize()
+	if l.H, err = NewTensorDescriptor(NCHW, dt, []int{layers, minibatch, hidden}, []int{minibatch * hidden, hidden, 1}); err != nil {
+		return nil, errors.Wrap(err, "Unable to describe the hidden states")
+	}
+
+	if l.paramsSize, err = ctx.GetRNNParamsSize(rnn, l.X[0], dt); err != nil {
+		return nil, errors.Wrap(err, "Unable to get the size of the weights")
+	}
+	if l.W, err = NewFilter(dt, NCHW, []int{int(l.paramsSize / dataTypeSize(dt)), 1, 1}); err != nil {
+		return nil, errors.Wrap(err, "Unable to describe the weights")
+	}
+	if l.workspaceSize, err = ctx.GetRNNWorkspaceSize(rnn, seqLength, l.X); err != nil {
+		return nil, errors.Wrap(err, "Unable to get the workspace size")
+	}
+	if l.reserveSize, err = ctx.GetRNNTrainingReserveSize(rnn, seqLength, l.X); err != nil {
+		return nil, errors.Wrap(err, "Unable to get the reserve size")
+	}
+	return l, nil
+}
+
+// SeqLength returns the number of time steps of the sequences.
+func (l *RNNLayer) SeqLength() int { return len(l.X) }
+
+// ParamsSize returns the size in bytes of the weights.
+func (l *RNNLayer) ParamsSize() uintptr { return l.paramsSize }
+
+// WorkspaceSize returns the size in bytes of the workspace.
+func (l *RNNLayer) WorkspaceSize() uintptr { return l.workspaceSize }
+
+// ReserveSize returns the size in bytes of the reserve kept by ForwardTraining.
+func (l *RNNLayer) ReserveSize() uintptr { return l.reserveSize }
+
+// Forward computes the outputs y, and the final states hy and cy, of the inputs x from the initial states hx and cx,
+// for inference.
+func (l *RNNLayer) Forward(x, hx, cx, w, y, hy, cy Memory) error {
+	l.Lock()
+	defer l.Unlock()
+	ws, err := l.buffers(false)
+	if err != nil {
+		return err
+	}
+	return l.ctx.RNNForwardInference(l.RNN, len(l.X), l.X, x, l.H, orNull(hx), l.H, orNull(cx), l.W, w, l.Y, y, l.H, orNull(hy), l.H, orNull(cy), ws, l.workspaceSize)
+}
+
+// ForwardTraining is Forward, keeping what the backward passes need in the reserve.
+func (l *RNNLayer) ForwardTraining(x, hx, cx, w, y, hy, cy Memory) error {
+	l.Lock()
+	defer l.Unlock()
+	ws, err := l.buffers(true)
+	if err != nil {
+		return err
+	}
+	return l.ctx.RNNForwardTraining(l.RNN, len(l.X), l.X, x, l.H, orNull(hx), l.H, orNull(cx), l.W, w, l.Y, y, l.H, orNull(hy), l.H, orNull(cy), ws, l.workspaceSize, l.reserve, l.reserveSize)
+}
+
+// BackwardData computes the gradients dx, dhx and dcx of the inputs and initial states, from the gradients dy, dhy
+// and dcy of the outputs and final states. y are the outputs of the last call to ForwardTraining.
+func (l *RNNLayer) BackwardData(y, dy, dhy, dcy, w, hx, cx, dx, dhx, dcx Memory) error {
+	l.Lock()
+	defer l.Unlock()
+	if l.reserve == nil {
+		return errors.New("BackwardData must follow ForwardTraining")
+	}
+	return l.ctx.RNNBackwardData(l.RNN, len(l.X), l.Y, y, l.Y, dy, l.H, orNull(dhy), l.H, orNull(dcy), l.W, w, l.H, orNull(hx), l.H, orNull(cx), l.X, dx, l.H, orNull(dhx), l.H, orNull(dcx), l.workspace, l.workspaceSize, l.reserve, l.reserveSize)
+}
+
+// BackwardWeights adds the gradients of the weights to dw. It must follow BackwardData, on the inputs x and hx and the
+// outputs y of the last call to ForwardTraining.
+func (l *RNNLayer) BackwardWeights(x, hx, y, dw Memory) error {
+	l.Lock()
+	defer l.Unlock()
+	if l.reserve == nil {
+		return errors.New("BackwardWeights must follow ForwardTraining")
+	}
+	return l.ctx.RNNBackwardWeights(l.RNN, len(l.X), l.X, x, l.H, orNull(hx), l.Y, y, l.workspace, l.workspaceSize, l.W, dw, l.reserve, l.reserveSize)
+}
+
+// buffers returns the workspace, allocating it, and the reserve if training, if they have not been allocated before.
+// The lock is expected to be held.
+func (l *RNNLayer) buffers(training bool) (Memory, error) {
+	if l.workspace == nil {
+		if l.workspaceSize == 0 {
+			l.workspace = noWorkspace{}
+		} else {
+			ws, err := l.alloc.Alloc(l.workspaceSize)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Unable to allocate a workspace of %d bytes", l.workspaceSize)
+			}
+			l.workspace = ws
+		}
+	}
+	if training && l.reserve == nil {
+		if l.reserveSize == 0 {
+			l.reserve = noWorkspace{}
+		} else {
+			r, err := l.alloc.Alloc(l.reserveSize)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Unable to allocate a reserve of %d bytes", l.reserveSize)
+			}
+			l.reserve = r
+		}
+	}
+	return l.workspace, nil
+}
+
+// Close frees the workspace and the reserve.
+func (l *RNNLayer) Close() error {
+	l.Lock()
+	defer l.Unlock()
+	var retVal error
+	for _, m := range []*Memory{&l.workspace, &l.reserve} {
+		if *m == nil {
+			continue
+		}
+		if _, ok := (*m).(noWorkspace); !ok {
+			if err := l.alloc.Free(*m); err != nil && retVal == nil {
+				retVal = err
+			}
+		}
+		*m = nil
+	}
+	return retVal
+}
+
+// orNull returns m, or a null pointer if m is nil.
+func orNull(m Memory) Memory {
+	if m == nil {
+		return noWorkspace{}
+	}
+	return m
+}
@@ -0,0 +1,214 @@
+package cudnn
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"runtime"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// The descriptors hold handles to cuDNN objects, which cannot be encoded. They are encoded as the parameters they were
+// created with instead, and decoding creates a new cuDNN object with the same parameters. This allows ConvolutionLayers,
+// along with the algorithms chosen for them, to be tuned on one machine and reused on another.
+//
+// Decoding replaces the cuDNN object held by the descriptor. A descriptor must be decoded into a *TensorDescriptor (or
+// *Filter, *Convolution) allocated on its own, as encoding/json and encoding/gob do for pointer fields.
+
+type tensorParams struct {
+	Format   TensorFormat `json:"format"`
+	DataType DataType     `json:"dataType"`
+	Shape    []int        `json:"shape"`
+	Strides  []int        `json:"strides,omitempty"`
+}
+
+type filterParams struct {
+	DataType DataType     `json:"dataType"`
+	Format   TensorFormat `json:"format"`
+	Shape    []int        `json:"shape"`
+}
+
+type convolutionParams struct {
+	MathType     MathType        `json:"mathType"`
+	GroupCount   int             `json:"groupCount"`
+	Padding      []int           `json:"padding"`
+	FilterStride []int           `json:"filterStride"`
+	Dilation     []int           `json:"dilation"`
+	Mode         ConvolutionMode `json:"mode"`
+	DataType     DataType        `json:"dataType"`
+}
+
+func (t *TensorDescriptor) params() tensorParams {
+	return tensorParams{Format: t.format, DataType: t.dataType, Shape: t.shape, Strides: t.strides}
+}
+
+func (t *TensorDescriptor) decoded(p tensorParams) error {
+	nt, err := NewTensorDescriptor(p.Format, p.DataType, p.Shape, p.Strides)
+	if err != nil {
+		return errors.Wrap(err, "Unable to decode a TensorDescriptor")
+	}
+	runtime.SetFinalizer(nt, nil)
+	if t.internal != nil {
+		runtime.SetFinalizer(t, nil)
+		destroyTensor(t)
+	}
+	*t = *nt
+	runtime.SetFinalizer(t, destroyTensor)
+	return nil
+}
+
+// MarshalJSON encodes the parameters of the descriptor.
+func (t *TensorDescriptor) MarshalJSON() ([]byte, error) { return json.Marshal(t.params()) }
+
+// UnmarshalJSON creates the descriptor from the parameters encoded by MarshalJSON.
+func (t *TensorDescriptor) UnmarshalJSON(data []byte) error {
+	var p tensorParams
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	return t.decoded(p)
+}
+
+// GobEncode encodes the parameters of the descriptor.
+func (t *TensorDescriptor) GobEncode() ([]byte, error) { return gobEncode(t.params()) }
+
+// GobDecode creates the descriptor from the parameters encoded by GobEncode.
+func (t *TensorDescriptor) GobDecode(data []byte) error {
+	var p tensorParams
+	if err := gobDecode(data, &p); err != nil {
+		return err
+	}
+	return t.decoded(p)
+}
+
+func (f *Filter) params() filterParams {
+	return filterParams{DataType: f.dataType, Format: f.format, Shape: f.shape}
+}
+
+func (f *Filter) decoded(p filterParams) error {
+	nf, err := NewFilter(p.DataType, p.Format, p.Shape)
+	if err != nil {
+		return errors.Wrap(err, "Unable to decode a Filter")
+	}
+	if f.internal != nil {
+		destroyFilter(f)
+	}
+	*f = *nf
+	return nil
+}
+
+// MarshalJSON encodes the parameters of the filter.
+func (f *Filter) MarshalJSON() ([]byte, error) { return json.Marshal(f.params()) }
+
+// UnmarshalJSON creates the filter from the parameters encoded by MarshalJSON.
+func (f *Filter) UnmarshalJSON(data []byte) error {
+	var p filterParams
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	return f.decoded(p)
+}
+
+// GobEncode encodes the parameters of the filter.
+func (f *Filter) GobEncode() ([]byte, error) { return gobEncode(f.params()) }
+
+// GobDecode creates the filter from the parameters encoded by GobEncode.
+func (f *Filter) GobDecode(data []byte) error {
+	var p filterParams
+	if err := gobDecode(data, &p); err != nil {
+		return err
+	}
+	return f.decoded(p)
+}
+
+func (c *Convolution) params() convolutionParams {
+	return convolutionParams{
+		MathType:     c.mathType,
+		GroupCount:   c.groupCount,
+		Padding:      c.padding,
+		FilterStride: c.filterStride,
+		Dilation:     c.dilation,
+		Mode:         c.mode,
+		DataType:     c.dataType,
+	}
+}
+
+func (c *Convolution) decoded(p convolutionParams) error {
+	nc, err := NewConvolution(p.MathType, p.GroupCount, p.Padding, p.FilterStride, p.Dilation, p.Mode, p.DataType)
+	if err != nil {
+		return errors.Wrap(err, "Unable to decode a Convolution")
+	}
+	runtime.SetFinalizer(nc, nil)
+	if c.internal != nil {
+		runtime.SetFinalizer(c, nil)
+		destroyConvolution(c)
+	}
+	*c = *nc
+	runtime.SetFinalizer(c, destroyConvolution)
+	return nil
+}
+
+// MarshalJSON encodes the parameters of the convolution.
+func (c *Convolution) MarshalJSON() ([]byte, error) { return json.Marshal(c.params()) }
+
+// UnmarshalJSON creates the convolution from the parameters encoded by MarshalJSON.
+func (c *Convolution) UnmarshalJSON(data []byte) error {
+	var p convolutionParams
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	return c.decoded(p)
+}
+
+// GobEncode encodes the parameters of the convolution.
+func (c *Convolution) GobEncode() ([]byte, error) { return gobEncode(c.params()) }
+
+// GobDecode creates the convolution from the parameters encoded by GobEncode.
+func (c *Convolution) GobDecode(data []byte) error {
+	var p convolutionParams
+	if err := gobDecode(data, &p); err != nil {
+		return err
+	}
+	return c.decoded(p)
+}
+
+// PlannerConfig is the configuration of a ConvolutionPlanner: its layers, with the algorithms chosen for them, and the
+// model of the GPU the algorithms were chosen on. It can be encoded with encoding/json or encoding/gob.
+type PlannerConfig struct {
+	Model  cu.DeviceModel     `json:"model"`
+	Layers []ConvolutionLayer `json:"layers"`
+}
+
+// Config returns the configuration of the planner, recording that it was tuned on the given device.
+func (p *ConvolutionPlanner) Config(dev cu.Device) (PlannerConfig, error) {
+	model, err := cu.ModelOf(dev)
+	if err != nil {
+		return PlannerConfig{}, err
+	}
+	layers := make([]ConvolutionLayer, len(p.layers))
+	copy(layers, p.layers)
+	return PlannerConfig{Model: model, Layers: layers}, nil
+}
+
+// NewConvolutionPlannerFromConfig creates a planner for the layers of the configuration. An error is returned if dev is
+// not of the model the configuration was tuned on.
+func NewConvolutionPlannerFromConfig(ctx *Context, alloc Allocator, cfg PlannerConfig, dev cu.Device) (*ConvolutionPlanner, error) {
+	if err := cfg.Model.Check(dev); err != nil {
+		return nil, errors.Wrap(err, "The configuration was tuned on another model of GPU")
+	}
+	return NewConvolutionPlanner(ctx, alloc, cfg.Layers...)
+}
+
+func gobEncode(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func gobDecode(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
@@ -0,0 +1,33 @@
+package cudnn
+
+// #include <cudnn.h>
+import "C"
+import (
+	"io"
+
+	"gorgonia.org/cu/session"
+)
+
+// sessionKey is the key of the cuDNN handle of a session.
+type sessionKey struct{}
+
+// SessionContext returns the cuDNN context of the session, which is created on first use, on the first stream of the
+// session, and closed with the session. Its calls must be made within (*session.Session).Do.
+func SessionContext(s *session.Session) (*Context, error) {
+	h, err := s.Handle(sessionKey{}, func() (io.Closer, error) {
+		var internal C.cudnnHandle_t
+		if err := result(C.cudnnCreate(&internal)); err != nil {
+			return nil, err
+		}
+		ctx := &Context{internal: internal}
+		if err := ctx.SetStream(s.Streams()[0]); err != nil {
+			ctx.Close()
+			return nil, err
+		}
+		return ctx, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return h.(*Context), nil
+}
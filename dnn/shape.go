@@ -1,5 +1,7 @@
 package cudnn
 
+import "github.com/pkg/errors"
+
 func isScalar(a []int) bool {
 	return len(a) == 0 || (len(a) == 1 && a[0] == 1)
 }
@@ -27,3 +29,69 @@ func cloneShape(a []int) []int {
 	copy(retVal, a)
 	return retVal
 }
+
+// ConvolutionOutputShape infers the shape of the output of a convolution, accounting for groups and dilation.
+// The input is of shape (N, C, spatial...), and the filter of shape (K, C/groups, kernel...).
+// padding, stride and dilation have one value per spatial dimension. The output is of shape (N, K, out...) where
+//
+//	out[i] = (spatial[i] + 2*padding[i] - dilation[i]*(kernel[i]-1) - 1) / stride[i] + 1
+func ConvolutionOutputShape(input, filter, padding, stride, dilation []int, groups int) ([]int, error) {
+	if err := checkConvolutionShapes(input, filter, padding, stride, dilation, groups); err != nil {
+		return nil, err
+	}
+	retVal := make([]int, len(input))
+	retVal[0] = input[0]
+	retVal[1] = filter[0]
+	for i := range padding {
+		extent := input[i+2] + 2*padding[i] - dilatedKernel(filter[i+2], dilation[i])
+		if extent < 0 {
+			return nil, errors.Errorf("The dilated kernel (%d) is larger than the padded input (%d) in spatial dimension %d", dilatedKernel(filter[i+2], dilation[i]), input[i+2]+2*padding[i], i)
+		}
+		retVal[i+2] = extent/stride[i] + 1
+	}
+	return retVal, nil
+}
+
+// GroupedFilterShape returns the shape of the filter of a convolution with the given number of output and input
+// channels split into groups: (out, in/groups, kernel...).
+func GroupedFilterShape(out, in, groups int, kernel ...int) ([]int, error) {
+	if groups < 1 {
+		return nil, errors.Errorf("Expected at least one group. Got %d", groups)
+	}
+	if in%groups != 0 || out%groups != 0 {
+		return nil, errors.Errorf("The number of input (%d) and output (%d) channels must be divisible by the number of groups (%d)", in, out, groups)
+	}
+	return append([]int{out, in / groups}, kernel...), nil
+}
+
+// dilatedKernel is the extent of a kernel of size k dilated by d.
+func dilatedKernel(k, d int) int { return d*(k-1) + 1 }
+
+func checkConvolutionShapes(input, filter, padding, stride, dilation []int, groups int) error {
+	spatial := len(input) - 2
+	switch {
+	case spatial < 2:
+		return errors.Errorf("Expected the input to have at least 2 spatial dimensions. Got %v", input)
+	case len(filter) != len(input):
+		return errors.Errorf("Expected the filter to be of the same rank as the input %v. Got %v", input, filter)
+	case len(padding) != spatial || len(stride) != spatial || len(dilation) != spatial:
+		return errors.Errorf("Expected %d paddings, strides and dilations. Got padding %v, stride %v, dilation %v", spatial, padding, stride, dilation)
+	case groups < 1:
+		return errors.Errorf("Expected at least one group. Got %d", groups)
+	case input[1]%groups != 0 || filter[0]%groups != 0:
+		return errors.Errorf("The number of input (%d) and output (%d) channels must be divisible by the number of groups (%d)", input[1], filter[0], groups)
+	case filter[1]*groups != input[1]:
+		return errors.Errorf("Expected the filter to have %d input channels per group (%d channels in %d groups). Got %d", input[1]/groups, input[1], groups, filter[1])
+	}
+	for i := 0; i < spatial; i++ {
+		switch {
+		case padding[i] < 0:
+			return errors.Errorf("Padding %v cannot be negative", padding)
+		case stride[i] < 1:
+			return errors.Errorf("Strides %v must be at least 1", stride)
+		case dilation[i] < 1:
+			return errors.Errorf("Dilations %v must be at least 1", dilation)
+		}
+	}
+	return nil
+}
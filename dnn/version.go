@@ -0,0 +1,22 @@
+package cudnn
+
+// #include <cudnn.h>
+import "C"
+import (
+	"fmt"
+
+	"gorgonia.org/cu"
+)
+
+func init() {
+	cu.RegisterLibrary("cudnn", func() (string, error) { return Version(), nil })
+}
+
+// Version returns the version of the cuDNN library, as major.minor.patch.
+func Version() string {
+	v := int(C.cudnnGetVersion())
+	if v >= 90000 { // cuDNN 9 encodes the major version in the ten thousands
+		return fmt.Sprintf("%d.%d.%d", v/10000, v%10000/100, v%100)
+	}
+	return fmt.Sprintf("%d.%d.%d", v/1000, v%1000/100, v%100)
+}
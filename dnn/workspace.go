@@ -0,0 +1,47 @@
+package cudnn
+
+// #include <cudnn.h>
+import "C"
+
+// GetConvolutionForwardWorkspaceSize returns the amount of GPU memory workspace the user needs to allocate to be able to call ConvolutionForward with the specified algorithm.
+func (co *Context) GetConvolutionForwardWorkspaceSize(xDesc *TensorDescriptor, wDesc *Filter, convDesc *Convolution, yDesc *TensorDescriptor, algo ConvolutionFwdAlgo) (sizeInBytes uintptr, err error) {
+	var sizeInBytesC C.size_t
+	// call cudnnGetConvolutionForwardWorkspaceSize
+	err = result(C.cudnnGetConvolutionForwardWorkspaceSize(co.internal, xDesc.internal, wDesc.internal, convDesc.internal, yDesc.internal, algo.C(), &sizeInBytesC))
+	sizeInBytes = uintptr(sizeInBytesC)
+	return
+}
+
+// GetConvolutionBackwardFilterWorkspaceSize returns the amount of GPU memory workspace the user needs to allocate to be able to call ConvolutionBackwardFilter with the specified algorithm.
+func (co *Context) GetConvolutionBackwardFilterWorkspaceSize(xDesc *TensorDescriptor, dyDesc *TensorDescriptor, convDesc *Convolution, dwDesc *Filter, algo ConvolutionBwdFilterAlgo) (sizeInBytes uintptr, err error) {
+	var sizeInBytesC C.size_t
+	// call cudnnGetConvolutionBackwardFilterWorkspaceSize
+	err = result(C.cudnnGetConvolutionBackwardFilterWorkspaceSize(co.internal, xDesc.internal, dyDesc.internal, convDesc.internal, dwDesc.internal, algo.C(), &sizeInBytesC))
+	sizeInBytes = uintptr(sizeInBytesC)
+	return
+}
+
+// GetConvolutionBackwardDataWorkspaceSize returns the amount of GPU memory workspace the user needs to allocate to be able to call ConvolutionBackwardData with the specified algorithm.
+func (co *Context) GetConvolutionBackwardDataWorkspaceSize(wDesc *Filter, dyDesc *TensorDescriptor, convDesc *Convolution, dxDesc *TensorDescriptor, algo ConvolutionBwdDataAlgo) (sizeInBytes uintptr, err error) {
+	var sizeInBytesC C.size_t
+	// call cudnnGetConvolutionBackwardDataWorkspaceSize
+	err = result(C.cudnnGetConvolutionBackwardDataWorkspaceSize(co.internal, wDesc.internal, dyDesc.internal, convDesc.internal, dxDesc.internal, algo.C(), &sizeInBytesC))
+	sizeInBytes = uintptr(sizeInBytesC)
+	return
+}
+
+// GetCTCLossWorkspaceSize returns the amount of GPU memory workspace the user needs to allocate to be able to call CTCLoss with the specified algorithm.
+func (co *Context) GetCTCLossWorkspaceSize(probsDesc *TensorDescriptor, gradientsDesc *TensorDescriptor, labels []int, labelLengths []int, inputLengths []int, algo CTCLossAlgo, ctcLossDesc *CTCLoss) (sizeInBytes uintptr, err error) {
+	labelsPtr, labelsPtrManaged := ints2CIntPtr(labels)
+	defer returnManaged(labelsPtrManaged)
+	labelLengthsPtr, labelLengthsPtrManaged := ints2CIntPtr(labelLengths)
+	defer returnManaged(labelLengthsPtrManaged)
+	inputLengthsPtr, inputLengthsPtrManaged := ints2CIntPtr(inputLengths)
+	defer returnManaged(inputLengthsPtrManaged)
+
+	var sizeInBytesC C.size_t
+	// call cudnnGetCTCLossWorkspaceSize
+	err = result(C.cudnnGetCTCLossWorkspaceSize(co.internal, probsDesc.internal, gradientsDesc.internal, labelsPtr, labelLengthsPtr, inputLengthsPtr, algo.C(), ctcLossDesc.internal, &sizeInBytesC))
+	sizeInBytes = uintptr(sizeInBytesC)
+	return
+}
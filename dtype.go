@@ -0,0 +1,107 @@
+package cu
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Dtype is the type of the elements held in device memory. It is used by the kernel library to select the right kernels.
+type Dtype byte
+
+const (
+	DtInvalid    Dtype = iota
+	DtFloat32          // float
+	DtFloat64          // double
+	DtFloat16          // half
+	DtBFloat16         // bfloat16
+	DtInt32            // int
+	DtInt64            // long long
+	DtUint8            // unsigned char
+	DtBool             // bool, stored as a byte
+	DtComplex64        // cuComplex
+	DtComplex128       // cuDoubleComplex
+	DtInt8             // signed char
+)
+
+var dtypeSizes = [...]int64{
+	DtInvalid:    0,
+	DtFloat32:    4,
+	DtFloat64:    8,
+	DtFloat16:    2,
+	DtBFloat16:   2,
+	DtInt32:      4,
+	DtInt64:      8,
+	DtUint8:      1,
+	DtBool:       1,
+	DtComplex64:  8,
+	DtComplex128: 16,
+	DtInt8:       1,
+}
+
+var dtypeNames = [...]string{
+	DtInvalid:    "invalid",
+	DtFloat32:    "float32",
+	DtFloat64:    "float64",
+	DtFloat16:    "float16",
+	DtBFloat16:   "bfloat16",
+	DtInt32:      "int32",
+	DtInt64:      "int64",
+	DtUint8:      "uint8",
+	DtBool:       "bool",
+	DtComplex64:  "complex64",
+	DtComplex128: "complex128",
+	DtInt8:       "int8",
+}
+
+// Size returns the size of an element, in bytes.
+func (dt Dtype) Size() int64 {
+	if int(dt) < len(dtypeSizes) {
+		return dtypeSizes[dt]
+	}
+	return 0
+}
+
+func (dt Dtype) String() string {
+	if int(dt) < len(dtypeNames) {
+		return dtypeNames[dt]
+	}
+	return fmt.Sprintf("UnknownDtype:%d", byte(dt))
+}
+
+// MarshalText encodes the Dtype by name, so that encoded descriptors do not depend on the order of the constants.
+func (dt Dtype) MarshalText() ([]byte, error) {
+	if dt == DtInvalid || int(dt) >= len(dtypeNames) {
+		return nil, errors.Errorf("Cannot encode %v", dt)
+	}
+	return []byte(dtypeNames[dt]), nil
+}
+
+// UnmarshalText decodes a Dtype encoded by MarshalText.
+func (dt *Dtype) UnmarshalText(text []byte) error {
+	for i, name := range dtypeNames {
+		if Dtype(i) != DtInvalid && name == string(text) {
+			*dt = Dtype(i)
+			return nil
+		}
+	}
+	return errors.Errorf("Unknown Dtype %q", text)
+}
+
+// GobEncode encodes the Dtype by name, as MarshalText does. encoding/gob does not use MarshalText by itself.
+func (dt Dtype) GobEncode() ([]byte, error) { return dt.MarshalText() }
+
+// GobDecode decodes a Dtype encoded by GobEncode.
+func (dt *Dtype) GobDecode(data []byte) error { return dt.UnmarshalText(data) }
+
+// IsFloat returns true if the Dtype is a real floating point type.
+func (dt Dtype) IsFloat() bool {
+	switch dt {
+	case DtFloat32, DtFloat64, DtFloat16, DtBFloat16:
+		return true
+	}
+	return false
+}
+
+// IsComplex returns true if the Dtype is a complex type.
+func (dt Dtype) IsComplex() bool { return dt == DtComplex64 || dt == DtComplex128 }
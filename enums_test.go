@@ -0,0 +1,34 @@
+package cu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnumStrings(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("MallocHeapSize", MallocHeapSize.String())
+	assert.Equal("SetAccessedBy", SetAccessedBy.String())
+	assert.Equal("NumRegs", NumRegs.String())
+	assert.Equal("JITOptMaxRegisters", JITOptMaxRegisters.String())
+	assert.Equal("HostNode", HostNode.String())
+	assert.Equal("Limit(200)", Limit(200).String())
+
+	// flags
+	assert.Equal("DisableTiming", DisableTiming.String())
+	assert.Equal("DisableTiming|InterprocessEvent", (DisableTiming | InterprocessEvent).String())
+	assert.Equal("SchedSpin|MapHost", (SchedSpin | MapHost).String())
+	assert.Equal("SchedAuto", SchedAuto.String())
+	assert.Equal("AttachGlobal|MemAttachFlags(0x80)", (AttachGlobal | 0x80).String())
+}
+
+func TestEnumIsValid(t *testing.T) {
+	assert := assert.New(t)
+	assert.True(SetReadMostly.IsValid())
+	assert.False(MemAdvice(0).IsValid())
+	assert.True(JITOptFastCompile.IsValid())
+	assert.True((DisableTiming | BlockingSyncEvent).IsValid())
+	assert.False(EventFlags(0x80).IsValid())
+	assert.True((SchedYield | MapHost).IsValid())
+}
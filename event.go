@@ -39,7 +39,7 @@ func (ctx *Ctx) MakeEvent(flags EventFlags) (event Event, err error) {
 
 func (ctx *Ctx) DestroyEvent(event *Event) {
 	f := func() error { return result(C.cuEventDestroy(event.ev)) }
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 	*event = Event{}
 	return
 }
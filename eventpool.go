@@ -0,0 +1,188 @@
+package cu
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// EventPool recycles events, so that events used per operation (for timing or for dependencies between streams)
+// do not have to be created and destroyed every time.
+//
+// Events belong to a context: the pool must only be used from a thread where the context it was first used in is current.
+type EventPool struct {
+	flags EventFlags
+
+	sync.Mutex
+	free        []Event
+	outstanding []*ScopedEvent
+	created     int
+}
+
+// NewEventPool creates an empty EventPool. The events are created with the given flags.
+// Use DisableTiming for events that are only used for dependencies, as they are cheaper to record.
+func NewEventPool(flags EventFlags) *EventPool {
+	return &EventPool{flags: flags}
+}
+
+// Get returns an event from the pool, creating one if there are no free events.
+// The event must be returned to the pool with Put.
+func (p *EventPool) Get() (Event, error) {
+	p.Lock()
+	defer p.Unlock()
+	if len(p.free) == 0 {
+		p.reclaim()
+	}
+	if n := len(p.free); n > 0 {
+		e := p.free[n-1]
+		p.free = p.free[:n-1]
+		return e, nil
+	}
+	e, err := MakeEvent(p.flags)
+	if err != nil {
+		return e, errors.Wrap(err, "EventPool")
+	}
+	p.created++
+	return e, nil
+}
+
+// Put returns an event to the pool.
+func (p *EventPool) Put(e Event) {
+	p.Lock()
+	p.free = append(p.free, e)
+	p.Unlock()
+}
+
+// RecordScoped records a pooled event on the stream. The event returns to the pool by itself once it is complete:
+// completed events are reclaimed when the pool runs out of free events.
+func (p *EventPool) RecordScoped(stream Stream) (*ScopedEvent, error) {
+	e, err := p.Get()
+	if err != nil {
+		return nil, err
+	}
+	if err = e.Record(stream); err != nil {
+		p.Put(e)
+		return nil, errors.Wrap(err, "RecordScoped")
+	}
+	se := &ScopedEvent{ev: e, pool: p}
+	p.Lock()
+	p.outstanding = append(p.outstanding, se)
+	p.Unlock()
+	return se, nil
+}
+
+// reclaim returns the completed outstanding events to the free list. The lock is expected to be held.
+func (p *EventPool) reclaim() {
+	pending := p.outstanding[:0]
+	for _, se := range p.outstanding {
+		se.Lock()
+		if !se.done && se.ev.Query() == nil {
+			se.done = true
+		}
+		if se.done {
+			p.free = append(p.free, se.ev)
+		} else {
+			pending = append(pending, se)
+		}
+		se.Unlock()
+	}
+	for i := len(pending); i < len(p.outstanding); i++ {
+		p.outstanding[i] = nil
+	}
+	p.outstanding = pending
+}
+
+// Stats returns the number of events created by the pool, the number of free events, and the number of
+// scoped events that have not been reclaimed yet.
+func (p *EventPool) Stats() (created, free, outstanding int) {
+	p.Lock()
+	defer p.Unlock()
+	return p.created, len(p.free), len(p.outstanding)
+}
+
+// Destroy destroys the free events. The outstanding scoped events are waited upon before being destroyed.
+// Events obtained with Get that have not been returned are not destroyed.
+func (p *EventPool) Destroy() error {
+	p.Lock()
+	defer p.Unlock()
+	for _, se := range p.outstanding {
+		se.Lock()
+		if !se.done {
+			if err := se.ev.Synchronize(); err != nil {
+				se.Unlock()
+				return errors.Wrap(err, "Unable to wait for an outstanding event")
+			}
+			se.done = true
+		}
+		p.free = append(p.free, se.ev)
+		se.Unlock()
+	}
+	p.outstanding = nil
+	for len(p.free) > 0 {
+		e := p.free[len(p.free)-1]
+		if err := DestroyEvent(&e); err != nil {
+			return errors.Wrap(err, "Unable to destroy an event")
+		}
+		p.free = p.free[:len(p.free)-1]
+	}
+	return nil
+}
+
+// ScopedEvent is a pooled event recorded on a stream. Once it has been found to be complete, the event belongs to the
+// pool again, and must no longer be used.
+type ScopedEvent struct {
+	sync.Mutex
+	ev   Event
+	pool *EventPool
+	done bool
+}
+
+// discard gives the event back to the pool before it is complete, for an event recorded in vain: the pool reclaims it
+// as if it were complete. Recording the event again replaces the pending record.
+func (se *ScopedEvent) discard() {
+	se.Lock()
+	se.done = true
+	se.Unlock()
+}
+
+// Done returns true if the work recorded before the event has completed. A NotReady result is not an error.
+func (se *ScopedEvent) Done() (bool, error) {
+	se.Lock()
+	defer se.Unlock()
+	if se.done {
+		return true, nil
+	}
+	switch err := se.ev.Query(); err {
+	case nil:
+		se.done = true
+		return true, nil
+	case NotReady:
+		return false, nil
+	default:
+		return false, errors.Wrap(err, "Unable to query the event")
+	}
+}
+
+// Wait blocks until the work recorded before the event has completed.
+func (se *ScopedEvent) Wait() error {
+	se.Lock()
+	defer se.Unlock()
+	if se.done {
+		return nil
+	}
+	if err := se.ev.Synchronize(); err != nil {
+		return errors.Wrap(err, "Unable to wait for the event")
+	}
+	se.done = true
+	return nil
+}
+
+// WaitOn makes all future work submitted to the stream wait for the event. It does nothing if the event is complete.
+func (se *ScopedEvent) WaitOn(stream Stream) error {
+	se.Lock()
+	defer se.Unlock()
+	if se.done {
+		return nil
+	}
+	return stream.Wait(se.ev, 0)
+}
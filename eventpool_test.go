@@ -0,0 +1,38 @@
+package cu
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestEventPool(t *testing.T) {
+	devices, _ := NumDevices()
+	if devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := Device(0).MakeContext(SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+
+	pool := NewEventPool(DisableTiming)
+	for i := 0; i < 10; i++ {
+		se, err := pool.RecordScoped(NoStream)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = se.Wait(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	created, _, _ := pool.Stats()
+	if created != 1 {
+		t.Errorf("Expected the completed events to be recycled. %d events were created", created)
+	}
+	if err = pool.Destroy(); err != nil {
+		t.Error(err)
+	}
+}
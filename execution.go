@@ -1,6 +1,7 @@
 package cu
 
 // #include <cuda.h>
+// #include "batch.h"
 import "C"
 import "unsafe"
 
@@ -13,19 +14,24 @@ func (fn Function) c() C.CUfunction { return fn.fn }
 
 const pointerSize = 8 // sorry, 64 bits only.
 
-// Launch launches a CUDA function
+// Launch launches a CUDA function. The launch is timed if kernels are being timed (see TimeKernels).
 func (fn Function) Launch(gridDimX, gridDimY, gridDimZ int, blockDimX, blockDimY, blockDimZ int, sharedMemBytes int, stream Stream, kernelParams []unsafe.Pointer) error {
-	// Since Go 1.6, a cgo argument cannot have a Go pointer to Go pointer,
-	// so we copy the argument values go C memory first.
-	argv := C.malloc(C.size_t(len(kernelParams) * pointerSize))
-	argp := C.malloc(C.size_t(len(kernelParams) * pointerSize))
-	defer C.free(argv)
-	defer C.free(argp)
-	for i := range kernelParams {
-		*((*unsafe.Pointer)(offset(argp, i))) = offset(argv, i)       // argp[i] = &argv[i]
-		*((*uint64)(offset(argv, i))) = *((*uint64)(kernelParams[i])) // argv[i] = *kernelParams[i]
+	if timingKernels() {
+		return timeLaunch(fn, stream, func() error {
+			return fn.launch(gridDimX, gridDimY, gridDimZ, blockDimX, blockDimY, blockDimZ, sharedMemBytes, stream, kernelParams)
+		})
 	}
+	return fn.launch(gridDimX, gridDimY, gridDimZ, blockDimX, blockDimY, blockDimZ, sharedMemBytes, stream, kernelParams)
+}
+
+func (fn Function) launch(gridDimX, gridDimY, gridDimZ int, blockDimX, blockDimY, blockDimZ int, sharedMemBytes int, stream Stream, kernelParams []unsafe.Pointer) error {
+	args := getKernelArgs(kernelParams)
+	defer args.put()
+	return fn.launchParams(gridDimX, gridDimY, gridDimZ, blockDimX, blockDimY, blockDimZ, sharedMemBytes, stream, args.params())
+}
 
+// launchParams launches the function with parameters already in C memory.
+func (fn Function) launchParams(gridDimX, gridDimY, gridDimZ int, blockDimX, blockDimY, blockDimZ int, sharedMemBytes int, stream Stream, params *unsafe.Pointer) error {
 	err := result(C.cuLaunchKernel(
 		fn.fn,
 		C.uint(gridDimX),
@@ -36,9 +42,12 @@ func (fn Function) Launch(gridDimX, gridDimY, gridDimZ int, blockDimX, blockDimY
 		C.uint(blockDimZ),
 		C.uint(sharedMemBytes),
 		stream.c(),
-		(*unsafe.Pointer)(argp),
+		params,
 		(*unsafe.Pointer)(nil)))
-	return err
+	if debugging(DebugAPI) {
+		debugf(DebugAPI, "Launch grid (%d, %d, %d) block (%d, %d, %d) shared %d on stream %#x: %v", gridDimX, gridDimY, gridDimZ, blockDimX, blockDimY, blockDimZ, sharedMemBytes, stream.Uintptr(), err)
+	}
+	return checkSync("Launch", stream, err)
 }
 
 func offset(ptr unsafe.Pointer, i int) unsafe.Pointer {
@@ -46,31 +55,13 @@ func offset(ptr unsafe.Pointer, i int) unsafe.Pointer {
 }
 
 func (ctx *Ctx) LaunchKernel(fn Function, gridDimX, gridDimY, gridDimZ int, blockDimX, blockDimY, blockDimZ int, sharedMemBytes int, stream Stream, kernelParams []unsafe.Pointer) {
-	// Since Go 1.6, a cgo argument cannot have a Go pointer to Go pointer,
-	// so we copy the argument values go C memory first.
-	argv := C.malloc(C.size_t(len(kernelParams) * pointerSize))
-	argp := C.malloc(C.size_t(len(kernelParams) * pointerSize))
-	defer C.free(argv)
-	defer C.free(argp)
-	for i := range kernelParams {
-		*((*unsafe.Pointer)(offset(argp, i))) = offset(argv, i)       // argp[i] = &argv[i]
-		*((*uint64)(offset(argv, i))) = *((*uint64)(kernelParams[i])) // argv[i] = *kernelParams[i]
-	}
-
-	f := func() error {
-		return result(C.cuLaunchKernel(
-			fn.fn,
-			C.uint(gridDimX),
-			C.uint(gridDimY),
-			C.uint(gridDimZ),
-			C.uint(blockDimX),
-			C.uint(blockDimY),
-			C.uint(blockDimZ),
-			C.uint(sharedMemBytes),
-			stream.c(),
-			(*unsafe.Pointer)(argp),
-			(*unsafe.Pointer)(nil)))
-	}
-
-	ctx.err = ctx.Do(f)
+	c := getCtxCall(C.fn_launchKernel)
+	c.args.kargs = getKernelArgs(kernelParams)
+	c.args.f = fn.fn
+	c.args.gridDimX, c.args.gridDimY, c.args.gridDimZ = C.uint(gridDimX), C.uint(gridDimY), C.uint(gridDimZ)
+	c.args.blockDimX, c.args.blockDimY, c.args.blockDimZ = C.uint(blockDimX), C.uint(blockDimY), C.uint(blockDimZ)
+	c.args.sharedMemBytes = C.uint(sharedMemBytes)
+	c.args.stream = stream.c()
+	c.args.kernelParams = c.args.kargs.params()
+	ctx.setErr(ctx.call(c))
 }
@@ -0,0 +1,184 @@
+package cu
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+// The environment variables read when the package is initialized. They allow debugging and performance features to be
+// toggled in production, without recompiling.
+const (
+	// DisablePoolEnv disables the caching of MemPools when set to a true value ("1", "true"...).
+	DisablePoolEnv = "CU_DISABLE_POOL"
+
+	// ForceSyncEnv makes kernel launches and asynchronous calls synchronous when set to a true value.
+	ForceSyncEnv = "CU_FORCE_SYNC"
+
+	// DebugEnv is a comma separated list of the debugging aids to enable: "api", "mem", "uninit", "bounds", "align", or
+	// "all".
+	DebugEnv = "CU_DEBUG"
+)
+
+// DebugFlags are the debugging aids enabled: the categories of calls logged, and the checks of memory accesses.
+type DebugFlags uint32
+
+const (
+	DebugAPI    DebugFlags = 1 << iota // log kernel launches, and calls made through a Ctx
+	DebugMem                           // log allocations and frees made through a MemPool or an Arena
+	DebugUninit                        // poison allocated memory, and check the synchronous copies to the host for it (see PoisonPattern)
+	DebugBounds                        // record allocations, and panic on offsets out of them (see DevicePtr.Offset)
+	DebugAlign                         // record allocations, and fail the calls given misaligned pointers, or lengths out of their allocations (see CheckPtr)
+
+	DebugAll = DebugAPI | DebugMem | DebugUninit | DebugBounds | DebugAlign
+)
+
+var debugNames = []struct {
+	name string
+	flag DebugFlags
+}{
+	{"api", DebugAPI},
+	{"mem", DebugMem},
+	{"uninit", DebugUninit},
+	{"bounds", DebugBounds},
+	{"align", DebugAlign},
+	{"all", DebugAll},
+}
+
+func (f DebugFlags) String() string {
+	var names []string
+	for _, n := range debugNames[:len(debugNames)-1] {
+		if f&n.flag != 0 {
+			names = append(names, n.name)
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// Features are the runtime features of the package. They are read from the environment when the package is
+// initialized, and can be changed at runtime with SetFeatures.
+type Features struct {
+	// DisablePool makes MemPools free memory as soon as it is freed, instead of caching it.
+	// This lets tools such as compute-sanitizer see every use after free.
+	DisablePool bool
+
+	// ForceSync synchronizes after every asynchronous call - kernel launches, asynchronous copies and memsets, the calls
+	// made through a Ctx and those of the batches of a BatchedContext - so that the failures of asynchronous work are
+	// returned by the call that caused it (as a SyncError), instead of a later call. It is like CUDA_LAUNCH_BLOCKING, and
+	// as slow.
+	ForceSync bool
+
+	// Debug are the debugging aids enabled. Calls are logged with the standard logger.
+	Debug DebugFlags
+}
+
+const (
+	featureDisablePool uint32 = 1 << iota
+	featureForceSync
+
+	debugShift = 8
+)
+
+var featureBits uint32
+
+func init() {
+	f, err := ParseFeatures(os.Getenv)
+	if err != nil {
+		log.Printf("cu: %v", err)
+	}
+	SetFeatures(f)
+}
+
+// ParseFeatures parses the features from the environment variables returned by getenv (usually os.Getenv).
+// Unparsable variables are reported in the error, and leave their features disabled.
+func ParseFeatures(getenv func(string) string) (Features, error) {
+	var f Features
+	var errs []string
+	parseBool := func(env string, dst *bool) {
+		v := getenv(env)
+		if v == "" {
+			return
+		}
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s=%q is not a boolean", env, v))
+			return
+		}
+		*dst = b
+	}
+	parseBool(DisablePoolEnv, &f.DisablePool)
+	parseBool(ForceSyncEnv, &f.ForceSync)
+
+	for _, cat := range strings.Split(getenv(DebugEnv), ",") {
+		cat = strings.ToLower(strings.TrimSpace(cat))
+		if cat == "" {
+			continue
+		}
+		found := false
+		for _, n := range debugNames {
+			if n.name == cat {
+				f.Debug |= n.flag
+				found = true
+			}
+		}
+		if !found {
+			errs = append(errs, fmt.Sprintf("Unknown %s category %q", DebugEnv, cat))
+		}
+	}
+
+	if len(errs) > 0 {
+		return f, errors.New(strings.Join(errs, "; "))
+	}
+	return f, nil
+}
+
+// CurrentFeatures returns the features in effect.
+func CurrentFeatures() Features {
+	bits := atomic.LoadUint32(&featureBits)
+	return Features{
+		DisablePool: bits&featureDisablePool != 0,
+		ForceSync:   bits&featureForceSync != 0,
+		Debug:       DebugFlags(bits >> debugShift),
+	}
+}
+
+// SetFeatures changes the features in effect. It is safe to call while other goroutines use the package.
+func SetFeatures(f Features) {
+	bits := uint32(f.Debug) << debugShift
+	if f.DisablePool {
+		bits |= featureDisablePool
+	}
+	if f.ForceSync {
+		bits |= featureForceSync
+	}
+	atomic.StoreUint32(&featureBits, bits)
+}
+
+func poolDisabled() bool { return atomic.LoadUint32(&featureBits)&featureDisablePool != 0 }
+
+func forceSync() bool { return atomic.LoadUint32(&featureBits)&featureForceSync != 0 }
+
+func debugging(f DebugFlags) bool {
+	return atomic.LoadUint32(&featureBits)&(uint32(f)<<debugShift) != 0
+}
+
+// debugf logs the message if the category is being debugged. On hot paths, check debugging first, so that the
+// arguments are not boxed when nothing is logged.
+func debugf(f DebugFlags, format string, args ...interface{}) { debugTo(nil, f, format, args...) }
+
+// debugTo is debugf, logging to l instead of the standard logger if it is not nil (see WithLogger).
+func debugTo(l *log.Logger, f DebugFlags, format string, args ...interface{}) {
+	if !debugging(f) {
+		return
+	}
+	if l == nil {
+		log.Printf("cu: "+format, args...)
+		return
+	}
+	l.Printf("cu: "+format, args...)
+}
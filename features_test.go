@@ -0,0 +1,79 @@
+package cu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFeatures(t *testing.T) {
+	assert := assert.New(t)
+	env := func(vars map[string]string) func(string) string {
+		return func(k string) string { return vars[k] }
+	}
+
+	f, err := ParseFeatures(env(nil))
+	assert.Nil(err)
+	assert.Equal(Features{}, f)
+
+	f, err = ParseFeatures(env(map[string]string{DisablePoolEnv: "1", ForceSyncEnv: "true", DebugEnv: "api, MEM"}))
+	assert.Nil(err)
+	assert.Equal(Features{DisablePool: true, ForceSync: true, Debug: DebugAPI | DebugMem}, f)
+	assert.Equal("api,mem", f.Debug.String())
+
+	f, err = ParseFeatures(env(map[string]string{DebugEnv: "all"}))
+	assert.Nil(err)
+	assert.Equal(DebugAll, f.Debug)
+
+	// valid variables are still parsed
+	f, err = ParseFeatures(env(map[string]string{DisablePoolEnv: "yes", ForceSyncEnv: "1", DebugEnv: "mem,gpu"}))
+	assert.NotNil(err)
+	assert.Equal(Features{ForceSync: true, Debug: DebugMem}, f)
+}
+
+func TestSetFeatures(t *testing.T) {
+	assert := assert.New(t)
+	old := CurrentFeatures()
+	defer SetFeatures(old)
+
+	want := Features{DisablePool: true, Debug: DebugMem}
+	SetFeatures(want)
+	assert.Equal(want, CurrentFeatures())
+	assert.True(poolDisabled())
+	assert.False(forceSync())
+	assert.True(debugging(DebugMem))
+	assert.False(debugging(DebugAPI))
+
+	// with the caching disabled, freed blocks are freed at once
+	var allocated, freed int
+	p := newFakePool(&allocated, &freed)
+	a, err := p.Alloc(100)
+	assert.Nil(err)
+	assert.Nil(p.Free(a))
+	assert.Equal(1, freed)
+	_, err = p.Alloc(100)
+	assert.Nil(err)
+	assert.Equal(2, allocated)
+	assert.Equal(PoolStats{InUse: 512, HighWater: 512, Allocs: 2, Frees: 1, Misses: 2}, p.Stats())
+}
+
+func TestCheckSync(t *testing.T) {
+	old := CurrentFeatures()
+	defer SetFeatures(old)
+
+	SetFeatures(Features{})
+	if err := checkSync("MemcpyAsync", Stream{}, nil); err != nil {
+		t.Errorf("Expected no synchronization when not forced. Got %v", err)
+	}
+	if err := checkSync("MemcpyAsync", Stream{}, InvalidValue); err != InvalidValue {
+		t.Errorf("Expected the error of the call to be returned. Got %v", err)
+	}
+
+	se := &SyncError{Call: "Launch", Err: IllegalAddress}
+	if !IsSticky(se) {
+		t.Errorf("Expected %v to be sticky", se)
+	}
+	if se.Error() != "Launch failed asynchronously: "+IllegalAddress.Error() {
+		t.Errorf("Unexpected message %q", se.Error())
+	}
+}
@@ -0,0 +1,111 @@
+// Package ffi provides the handles of package cu as plain uintptr types.
+//
+// The handle types in package cu wrap cgo types. This makes it awkward to store them in other packages:
+// the cgo types of one package are distinct from another's, and cgo's pointer passing rules get in the way
+// of storing them in interfaces and maps. The types in this package are plain values, and may be freely stored, compared and hashed.
+//
+// The handles are not reference counted. Converting a handle to its ffi form does not keep the underlying CUDA resource alive.
+package ffi
+
+import "gorgonia.org/cu"
+
+// Device is a CUDA device ordinal.
+type Device int
+
+// DevicePtr is a pointer to device memory.
+type DevicePtr uintptr
+
+// Context is a CUDA context handle.
+type Context uintptr
+
+// Stream is a CUDA stream handle.
+type Stream uintptr
+
+// Event is a CUDA event handle.
+type Event uintptr
+
+// Module is a CUDA module handle.
+type Module uintptr
+
+// Function is a CUDA function handle.
+type Function uintptr
+
+// TexRef is a CUDA texture reference handle.
+type TexRef uintptr
+
+// SurfRef is a CUDA surface reference handle.
+type SurfRef uintptr
+
+// Array is a CUDA array handle.
+type Array uintptr
+
+// Kernel is a function along with the module it was loaded from.
+type Kernel struct {
+	Module   Module
+	Function Function
+}
+
+// FromDevice converts a cu.Device.
+func FromDevice(d cu.Device) Device { return Device(d) }
+
+// Device converts the handle back to a cu.Device.
+func (d Device) Device() cu.Device { return cu.Device(d) }
+
+// FromDevicePtr converts a cu.DevicePtr.
+func FromDevicePtr(p cu.DevicePtr) DevicePtr { return DevicePtr(p) }
+
+// DevicePtr converts the handle back to a cu.DevicePtr.
+func (p DevicePtr) DevicePtr() cu.DevicePtr { return cu.DevicePtr(p) }
+
+// FromContext converts a cu.CUContext.
+func FromContext(ctx cu.CUContext) Context { return Context(ctx.Uintptr()) }
+
+// CUContext converts the handle back to a cu.CUContext.
+func (ctx Context) CUContext() cu.CUContext { return cu.CUContextFromUintptr(uintptr(ctx)) }
+
+// FromStream converts a cu.Stream.
+func FromStream(s cu.Stream) Stream { return Stream(s.Uintptr()) }
+
+// Stream converts the handle back to a cu.Stream.
+func (s Stream) Stream() cu.Stream { return cu.StreamFromUintptr(uintptr(s)) }
+
+// FromEvent converts a cu.Event.
+func FromEvent(e cu.Event) Event { return Event(e.Uintptr()) }
+
+// Event converts the handle back to a cu.Event.
+func (e Event) Event() cu.Event { return cu.EventFromUintptr(uintptr(e)) }
+
+// FromModule converts a cu.Module.
+func FromModule(m cu.Module) Module { return Module(m.Uintptr()) }
+
+// Module converts the handle back to a cu.Module.
+func (m Module) Module() cu.Module { return cu.ModuleFromUintptr(uintptr(m)) }
+
+// FromFunction converts a cu.Function.
+func FromFunction(fn cu.Function) Function { return Function(fn.Uintptr()) }
+
+// Function converts the handle back to a cu.Function.
+func (fn Function) Function() cu.Function { return cu.FunctionFromUintptr(uintptr(fn)) }
+
+// FromTexRef converts a cu.TexRef.
+func FromTexRef(t cu.TexRef) TexRef { return TexRef(t.Uintptr()) }
+
+// TexRef converts the handle back to a cu.TexRef.
+func (t TexRef) TexRef() cu.TexRef { return cu.TexRefFromUintptr(uintptr(t)) }
+
+// FromSurfRef converts a cu.SurfRef.
+func FromSurfRef(s cu.SurfRef) SurfRef { return SurfRef(s.Uintptr()) }
+
+// SurfRef converts the handle back to a cu.SurfRef.
+func (s SurfRef) SurfRef() cu.SurfRef { return cu.SurfRefFromUintptr(uintptr(s)) }
+
+// FromArray converts a cu.Array.
+func FromArray(arr cu.Array) Array { return Array(arr.Uintptr()) }
+
+// Array converts the handle back to a cu.Array.
+func (arr Array) Array() cu.Array { return cu.ArrayFromUintptr(uintptr(arr)) }
+
+// FromKernel converts a module and a function loaded from it.
+func FromKernel(m cu.Module, fn cu.Function) Kernel {
+	return Kernel{Module: FromModule(m), Function: FromFunction(fn)}
+}
@@ -0,0 +1,34 @@
+package ffi
+
+import (
+	"testing"
+
+	"gorgonia.org/cu"
+)
+
+func TestRoundTrip(t *testing.T) {
+	// the handles are never dereferenced, so any value will do
+	const h = 0xdeadbeef
+
+	if s := cu.StreamFromUintptr(h); FromStream(s).Stream() != s {
+		t.Errorf("Stream did not survive the round trip")
+	}
+	if e := cu.EventFromUintptr(h); FromEvent(e).Event() != e {
+		t.Errorf("Event did not survive the round trip")
+	}
+	if ctx := cu.CUContextFromUintptr(h); FromContext(ctx).CUContext() != ctx {
+		t.Errorf("Context did not survive the round trip")
+	}
+	if fn := cu.FunctionFromUintptr(h); FromFunction(fn).Function() != fn {
+		t.Errorf("Function did not survive the round trip")
+	}
+	if arr := cu.ArrayFromUintptr(h); FromArray(arr).Array().Uintptr() != arr.Uintptr() {
+		t.Errorf("Array did not survive the round trip")
+	}
+
+	// handles can be used as map keys
+	m := map[Kernel]string{FromKernel(cu.ModuleFromUintptr(h), cu.FunctionFromUintptr(h)): "kernel"}
+	if m[Kernel{Module: h, Function: h}] != "kernel" {
+		t.Errorf("Expected Kernel to be usable as a map key")
+	}
+}
@@ -0,0 +1,85 @@
+package cufft
+
+// #include <cufftXt.h>
+import "C"
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// CallbackType is the kind of a callback: a load callback returns each element the transform reads, and a store
+// callback stores each element it writes. The type of the elements must be the input type of the plan for a load
+// callback, and its output type for a store callback.
+type CallbackType int
+
+const (
+	LoadComplex        CallbackType = C.CUFFT_CB_LD_COMPLEX        // cufftCallbackLoadC, for C2C and C2R plans
+	LoadComplexDouble  CallbackType = C.CUFFT_CB_LD_COMPLEX_DOUBLE // cufftCallbackLoadZ, for Z2Z and Z2D plans
+	LoadReal           CallbackType = C.CUFFT_CB_LD_REAL           // cufftCallbackLoadR, for R2C plans
+	LoadRealDouble     CallbackType = C.CUFFT_CB_LD_REAL_DOUBLE    // cufftCallbackLoadD, for D2Z plans
+	StoreComplex       CallbackType = C.CUFFT_CB_ST_COMPLEX        // cufftCallbackStoreC, for C2C and R2C plans
+	StoreComplexDouble CallbackType = C.CUFFT_CB_ST_COMPLEX_DOUBLE // cufftCallbackStoreZ, for Z2Z and D2Z plans
+	StoreReal          CallbackType = C.CUFFT_CB_ST_REAL           // cufftCallbackStoreR, for C2R plans
+	StoreRealDouble    CallbackType = C.CUFFT_CB_ST_REAL_DOUBLE    // cufftCallbackStoreD, for Z2D plans
+)
+
+// callbackTypes returns the types of the load and store callbacks of a plan of the given type.
+func callbackTypes(t Type) (load, store CallbackType) {
+	switch t {
+	case C2C:
+		return LoadComplex, StoreComplex
+	case R2C:
+		return LoadReal, StoreComplex
+	case C2R:
+		return LoadComplex, StoreReal
+	case Z2Z:
+		return LoadComplexDouble, StoreComplexDouble
+	case D2Z:
+		return LoadRealDouble, StoreComplexDouble
+	case Z2D:
+		return LoadComplexDouble, StoreRealDouble
+	}
+	return C.CUFFT_CB_UNDEFINED, C.CUFFT_CB_UNDEFINED
+}
+
+// checkCallback returns an error if callbacks of the type cannot be set on p.
+func (p *Plan) checkCallback(typ CallbackType) error {
+	if !static {
+		return errors.New("cuFFT callbacks need the static cuFFT: build with the cufft_static tag")
+	}
+	if load, store := callbackTypes(p.typ); typ != load && typ != store {
+		return errors.Errorf("A %v plan has no callback of type %d", p.typ, int(typ))
+	}
+	return nil
+}
+
+// SetCallback sets a callback of the plan. fn is the device address of the callback function (see the package doc),
+// and info the device memory passed to it at each call, which may be 0. The callback replaces any callback of the same
+// type, and must be set before the transforms it applies to are enqueued.
+func (p *Plan) SetCallback(typ CallbackType, fn unsafe.Pointer, info cu.DevicePtr) error {
+	if err := p.checkCallback(typ); err != nil {
+		return err
+	}
+	routine, callerInfo := fn, info.Pointer()
+	err := result(C.cufftXtSetCallback(p.h, &routine, C.cufftXtCallbackType(typ), &callerInfo))
+	return errors.Wrap(err, "Unable to set the callback")
+}
+
+// ClearCallback removes the callback of the given type from the plan.
+func (p *Plan) ClearCallback(typ CallbackType) error {
+	if err := p.checkCallback(typ); err != nil {
+		return err
+	}
+	return result(C.cufftXtClearCallback(p.h, C.cufftXtCallbackType(typ)))
+}
+
+// SetCallbackSharedSize requests bytes of shared memory for the callback of the given type. The shared memory is
+// passed to the callback at each call, and is not preserved between calls.
+func (p *Plan) SetCallbackSharedSize(typ CallbackType, bytes int) error {
+	if err := p.checkCallback(typ); err != nil {
+		return err
+	}
+	return result(C.cufftXtSetCallbackSharedSize(p.h, C.cufftXtCallbackType(typ), C.size_t(bytes)))
+}
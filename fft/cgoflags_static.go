@@ -0,0 +1,35 @@
+// +build cufft_static
+
+package cufft
+
+////the static cuFFT, which callbacks need. The callbacks themselves are linked in by the user (see the package doc):
+//#cgo LDFLAGS:-lcufft_static -lculibos -lcudart_static -lstdc++
+//#cgo linux LDFLAGS:-lpthread -ldl -lrt
+//
+////default location:
+//#cgo linux,windows LDFLAGS:-L/usr/local/cuda/lib64 -L/usr/local/cuda/lib
+//#cgo linux,windows CFLAGS: -I/usr/local/cuda/include/
+//
+////default location if not properly symlinked:
+//#cgo linux LDFLAGS:-L/usr/local/cuda-11.0/lib64 -L/usr/local/cuda-11.0/lib
+//#cgo linux CFLAGS: -I/usr/local/cuda-11.0/include/
+//
+////Ubuntu 15.04:
+//#cgo linux LDFLAGS:-L/usr/lib/x86_64-linux-gnu/
+//#cgo linux CFLAGS: -I/usr/include
+//
+////arch linux:
+//#cgo linux LDFLAGS:-L/opt/cuda/lib64 -L/opt/cuda/lib
+//#cgo linux CFLAGS: -I/opt/cuda/include
+//
+////Darwin:
+//#cgo darwin LDFLAGS:-L/usr/local/cuda/lib
+//#cgo darwin CFLAGS: -I/usr/local/cuda/include/
+//
+////WINDOWS:
+//#cgo windows LDFLAGS:-LC:/cuda/v5.0/lib/x64 -LC:/cuda/v5.5/lib/x64 -LC:/cuda/v6.0/lib/x64 -LC:/cuda/v6.5/lib/x64 -LC:/cuda/v7.0/lib/x64 -LC:/cuda/v8.0/lib/x64 -LC:/cuda/v9.0/x64
+//#cgo windows CFLAGS: -IC:/cuda/v5.0/include -IC:/cuda/v5.5/include -IC:/cuda/v6.0/include -IC:/cuda/v6.5/include -IC:/cuda/v7.0/include -IC:/cuda/v8.0/include -IC:/cuda/v9.0/include
+import "C"
+
+// static is true when cuFFT is linked statically, which callbacks need.
+const static = true
@@ -0,0 +1,342 @@
+package cufft
+
+import (
+	"math"
+	"sync"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+	"gorgonia.org/cu/kernels"
+)
+
+// Convolver computes batched convolutions and correlations of real signals with FFTs: the signals are padded to a size
+// cuFFT transforms quickly, multiplied in the frequency domain with package kernels, and transformed back.
+//
+// A Convolver caches the plans of the sizes it has seen, and a workspace that grows to the largest of them, so that
+// repeated calls on signals of the same sizes do not plan nor allocate. It may be used by several goroutines, but the
+// calls are serialized.
+type Convolver struct {
+	stream cu.Stream
+
+	mu    sync.Mutex
+	plans map[convKey]*convPlan
+	ws    cu.DevicePtr
+	wsLen int64
+}
+
+// convKey identifies the plans of a convolution.
+type convKey struct {
+	n, batchA, batchB int // the padded size, and the batch sizes of the operands
+	dt                cu.Dtype
+}
+
+// convPlan holds the plans of a convolution, and the scale of its inverse transform.
+type convPlan struct {
+	fwdA, fwdB, inv *Plan
+	scale           cu.DevicePtr // 1/n, of the type of the signals
+}
+
+func (p *convPlan) destroy() {
+	for _, plan := range []*Plan{p.fwdA, p.fwdB, p.inv} {
+		if plan != nil {
+			plan.Destroy()
+		}
+	}
+	if p.scale != 0 {
+		cu.MemFree(p.scale)
+	}
+}
+
+// NewConvolver creates a Convolver, which works on the given stream.
+func NewConvolver(stream cu.Stream) *Convolver {
+	return &Convolver{stream: stream, plans: make(map[convKey]*convPlan)}
+}
+
+// fastSize returns the smallest size of at least n whose only prime factors are 2, 3, 5 and 7, which cuFFT transforms
+// with its fastest algorithms. Real transforms are planned on even sizes.
+func fastSize(n int) int {
+	for m := n; ; m++ {
+		if m%2 != 0 && m > 1 {
+			continue
+		}
+		r := m
+		for _, p := range []int{2, 3, 5, 7} {
+			for r > 1 && r%p == 0 {
+				r /= p
+			}
+		}
+		if r <= 1 {
+			return m
+		}
+	}
+}
+
+// signals returns the number of signals and the length of the signals of t, a [batch, n] or [n] tensor.
+func signals(t cu.DeviceTensor, name string) (batch, n int, err error) {
+	switch t.Dims() {
+	case 1:
+		batch, n = 1, t.Shape[0]
+	case 2:
+		batch, n = t.Shape[0], t.Shape[1]
+		if batch > 1 && t.Strides[0] < n {
+			return 0, 0, errors.Errorf("The rows of %s overlap", name)
+		}
+	default:
+		return 0, 0, errors.Errorf("%s must be a [batch, n] or [n] tensor. Got %v", name, t.Shape)
+	}
+	if n > 0 && t.Strides[t.Dims()-1] != 1 {
+		return 0, 0, errors.Errorf("The signals of %s must be contiguous", name)
+	}
+	return batch, n, nil
+}
+
+// pitch returns the distance in bytes between the signals of t.
+func pitch(t cu.DeviceTensor) int64 {
+	if t.Dims() == 1 {
+		return int64(t.Shape[0]) * t.Dtype.Size()
+	}
+	return int64(t.Strides[0]) * t.Dtype.Size()
+}
+
+// Convolve computes the full linear convolutions of the signals of a with the signals of b:
+//
+//	out[k][j] = Σ_i a[k][i] · b[k][j-i]
+//
+// a is a [batch, n] tensor, b a [batch, m] tensor, or a [1, m] or [m] tensor applied to every signal of a, and out a
+// [batch, n+m-1] tensor. The tensors are float32 or float64, and their signals must be contiguous, though their rows
+// may be strided. The work is enqueued on the stream of the Convolver.
+func (cv *Convolver) Convolve(out, a, b cu.DeviceTensor) error {
+	return errors.Wrap(cv.do(out, a, b, false), "Convolve")
+}
+
+// Correlate computes the full cross-correlations of the signals of a with the signals of b:
+//
+//	out[k][j] = Σ_i a[k][i+j-(m-1)] · b[k][i]
+//
+// so that out[k][m-1] is the correlation at lag 0. The tensors are as in Convolve.
+func (cv *Convolver) Correlate(out, a, b cu.DeviceTensor) error {
+	return errors.Wrap(cv.do(out, a, b, true), "Correlate")
+}
+
+func (cv *Convolver) do(out, a, b cu.DeviceTensor, correlate bool) error {
+	dt := a.Dtype
+	if dt != cu.DtFloat32 && dt != cu.DtFloat64 {
+		return errors.Errorf("Only float32 and float64 signals are supported. Got %v", dt)
+	}
+	if b.Dtype != dt || out.Dtype != dt {
+		return errors.Errorf("Mismatched types %v = %v, %v", out.Dtype, a.Dtype, b.Dtype)
+	}
+	batch, n, err := signals(a, "a")
+	if err != nil {
+		return err
+	}
+	batchB, m, err := signals(b, "b")
+	if err != nil {
+		return err
+	}
+	batchOut, l, err := signals(out, "out")
+	if err != nil {
+		return err
+	}
+	if batchB != batch && batchB != 1 {
+		return errors.Errorf("Cannot apply %d signals to %d signals", batchB, batch)
+	}
+	if n == 0 || m == 0 {
+		return errors.New("The signals must not be empty")
+	}
+	if batchOut != batch || l != n+m-1 {
+		return errors.Errorf("Expected out to be of shape (%d, %d). Got %v", batch, n+m-1, out.Shape)
+	}
+
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+	size := fastSize(l)
+	p, err := cv.plan(convKey{size, batch, batchB, dt})
+	if err != nil {
+		return err
+	}
+
+	// the workspace holds the padded signals, then their spectra
+	elem := dt.Size()
+	half := size/2 + 1
+	lenA, lenB := int64(batch*size)*elem, int64(batchB*size)*elem
+	specLenA := int64(batch*half) * 2 * elem
+	if err = cv.reserve(lenA + lenB + specLenA + int64(batchB*half)*2*elem); err != nil {
+		return err
+	}
+	padA, padB := cv.ws, cv.ws.Offset(lenA)
+	specA, specB := padB.Offset(lenB), padB.Offset(lenB+specLenA)
+
+	if err = cu.MemsetD8Async(cv.ws, 0, lenA+lenB, cv.stream); err != nil {
+		return err
+	}
+	if err = cv.copySignals(padA, int64(size)*elem, a.Ptr, pitch(a), int64(n)*elem, batch); err != nil {
+		return err
+	}
+	if err = cv.copySignals(padB, int64(size)*elem, b.Ptr, pitch(b), int64(m)*elem, batchB); err != nil {
+		return err
+	}
+	if err = p.fwdA.Exec(padA, specA, Forward); err != nil {
+		return err
+	}
+	if err = p.fwdB.Exec(padB, specB, Forward); err != nil {
+		return err
+	}
+
+	ct := cu.DtComplex64
+	if dt == cu.DtFloat64 {
+		ct = cu.DtComplex128
+	}
+	spectrumA := cu.NewDeviceTensor(specA, ct, batch, half)
+	spectrumB := cu.NewDeviceTensor(specB, ct, batchB, half)
+	if correlate {
+		if err = kernels.ComplexUnary(kernels.OpConj, spectrumB, spectrumB, cv.stream); err != nil {
+			return err
+		}
+	}
+	if err = kernels.Binary(kernels.OpMul, spectrumA, spectrumA, spectrumB, cv.stream); err != nil {
+		return err
+	}
+	if err = p.inv.Exec(specA, padA, Inverse); err != nil {
+		return err
+	}
+
+	// the circular result holds the negative lags of a correlation at its end
+	rowPitch := int64(size) * elem
+	if correlate && m > 1 {
+		neg := int64(m-1) * elem
+		if err = cv.copySignals(out.Ptr, pitch(out), padA.Offset(rowPitch-neg), rowPitch, neg, batch); err != nil {
+			return err
+		}
+		if err = cv.copySignals(out.Ptr.Offset(neg), pitch(out), padA, rowPitch, int64(n)*elem, batch); err != nil {
+			return err
+		}
+	} else if err = cv.copySignals(out.Ptr, pitch(out), padA, rowPitch, int64(l)*elem, batch); err != nil {
+		return err
+	}
+	return kernels.Binary(kernels.OpMul, out, out, cu.NewDeviceTensor(p.scale, dt), cv.stream)
+}
+
+// copySignals copies rows of width bytes between strided signals.
+func (cv *Convolver) copySignals(dst cu.DevicePtr, dstPitch int64, src cu.DevicePtr, srcPitch int64, width int64, rows int) error {
+	return cu.Memcpy2DAsync(cu.Memcpy2dParam{
+		Height:        int64(rows),
+		WidthInBytes:  width,
+		DstDevice:     dst,
+		DstMemoryType: cu.DeviceMemory,
+		DstPitch:      dstPitch,
+		SrcDevice:     src,
+		SrcMemoryType: cu.DeviceMemory,
+		SrcPitch:      srcPitch,
+	}, cv.stream)
+}
+
+// plan returns the cached plans of a convolution, creating them if needed.
+func (cv *Convolver) plan(key convKey) (_ *convPlan, err error) {
+	if p, ok := cv.plans[key]; ok {
+		return p, nil
+	}
+	fwd, inv := R2C, C2R
+	if key.dt == cu.DtFloat64 {
+		fwd, inv = D2Z, Z2D
+	}
+	p := new(convPlan)
+	defer func() {
+		if err != nil {
+			p.destroy()
+		}
+	}()
+	if p.fwdA, err = NewPlan1D(key.n, fwd, key.batchA); err != nil {
+		return nil, err
+	}
+	if p.fwdB, err = NewPlan1D(key.n, fwd, key.batchB); err != nil {
+		return nil, err
+	}
+	if p.inv, err = NewPlan1D(key.n, inv, key.batchA); err != nil {
+		return nil, err
+	}
+	for _, plan := range []*Plan{p.fwdA, p.fwdB, p.inv} {
+		if err = plan.SetStream(cv.stream); err != nil {
+			return nil, err
+		}
+	}
+
+	// cuFFT does not normalize: the inverse transform is scaled by 1/n
+	if p.scale, err = cu.MemAlloc(key.dt.Size()); err != nil {
+		return nil, err
+	}
+	if key.dt == cu.DtFloat64 {
+		s := 1 / float64(key.n)
+		err = cu.MemcpyHtoD(p.scale, unsafe.Pointer(&s), 8)
+	} else {
+		err = cu.MemsetD32(p.scale, math.Float32bits(1/float32(key.n)), 1)
+	}
+	if err != nil {
+		return nil, err
+	}
+	cv.plans[key] = p
+	return p, nil
+}
+
+// reserve grows the workspace to at least size bytes. The previous workspace is freed once the stream is done with it.
+func (cv *Convolver) reserve(size int64) error {
+	if size <= cv.wsLen {
+		return nil
+	}
+	if cv.ws != 0 {
+		if err := cv.stream.Synchronize(); err != nil {
+			return err
+		}
+		if err := cu.MemFree(cv.ws); err != nil {
+			return err
+		}
+		cv.ws, cv.wsLen = 0, 0
+	}
+	ws, err := cu.MemAlloc(size)
+	if err != nil {
+		return errors.Wrap(err, "Unable to allocate the workspace")
+	}
+	cv.ws, cv.wsLen = ws, size
+	return nil
+}
+
+// Close waits for the stream, and frees the plans and the workspace.
+func (cv *Convolver) Close() error {
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+	if err := cv.stream.Synchronize(); err != nil {
+		return err
+	}
+	for k, p := range cv.plans {
+		p.destroy()
+		delete(cv.plans, k)
+	}
+	if cv.ws != 0 {
+		cu.MemFree(cv.ws)
+		cv.ws, cv.wsLen = 0, 0
+	}
+	return nil
+}
+
+var defaultConvolver struct {
+	sync.Mutex
+	m map[cu.Stream]*Convolver
+}
+
+// FFTConvolve computes the full linear convolutions of the signals of a with the signals of b into out, on the given
+// stream (see Convolver.Convolve). The plans and the workspace are cached by stream, for the life of the process: use
+// a Convolver to free them.
+func FFTConvolve(out, a, b cu.DeviceTensor, stream cu.Stream) error {
+	defaultConvolver.Lock()
+	if defaultConvolver.m == nil {
+		defaultConvolver.m = make(map[cu.Stream]*Convolver)
+	}
+	cv, ok := defaultConvolver.m[stream]
+	if !ok {
+		cv = NewConvolver(stream)
+		defaultConvolver.m[stream] = cv
+	}
+	defaultConvolver.Unlock()
+	return cv.Convolve(out, a, b)
+}
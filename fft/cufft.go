@@ -0,0 +1,172 @@
+// Package cufft provides bindings to cuFFT, for the fast Fourier transforms of signal processing on the GPU.
+//
+// A Plan computes transforms of a given size and Type, on batches of signals in device memory:
+//
+//	plan, _ := NewPlan1D(n, C2C, batch)
+//	defer plan.Destroy()
+//	plan.Exec(signal, spectrum, Forward)
+//
+// Elementwise operations on the spectrum, such as filters, are provided by package kernels, for complex64 and
+// complex128. Convolutions and correlations of batches of signals, which are built from these, are computed by a
+// Convolver, or by FFTConvolve.
+//
+// Load and store callbacks are device functions that cuFFT calls on each element it reads or writes, so that a window
+// applied before a transform, or a scaling after it, is fused into the transform rather than done by a separate kernel
+// (see SetCallback). cuFFT only supports them when it is linked statically, with the cufft_static build tag, and the
+// callbacks must be compiled as relocatable device code and device linked against the static cuFFT:
+//
+//	nvcc -dc -Xcompiler -fPIC -arch=sm_70 callbacks.cu -o callbacks.o
+//	nvcc -dlink -Xcompiler -fPIC -arch=sm_70 callbacks.o -o callbacks_link.o -lcufft_static -lculibos
+//	ar rcs libcallbacks.a callbacks.o callbacks_link.o
+//	CGO_LDFLAGS="-L$PWD -lcallbacks" go build -tags cufft_static
+//
+// The device address of a callback is read from a __device__ variable, by a host function of callbacks.cu that the Go
+// code calls with cgo:
+//
+//	__device__ cufftComplex hann(void *in, size_t i, void *info, void *shared) { ... }
+//	__device__ cufftCallbackLoadC hannPtr = hann;
+//	extern "C" void *hannCallback() { void *p; cudaMemcpyFromSymbol(&p, hannPtr, sizeof(p)); return p; }
+//
+// with which the plan is given the callback:
+//
+//	plan.SetCallback(LoadComplex, C.hannCallback(), info)
+//
+// cuFFT work is asynchronous, on the stream of the plan.
+package cufft
+
+// #include <cufft.h>
+import "C"
+import (
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// Type is the type of a transform, after the types of its input and output.
+type Type int
+
+const (
+	R2C Type = C.CUFFT_R2C // float32 to complex64, forward
+	C2R Type = C.CUFFT_C2R // complex64 to float32, inverse
+	C2C Type = C.CUFFT_C2C // complex64 to complex64
+	D2Z Type = C.CUFFT_D2Z // float64 to complex128, forward
+	Z2D Type = C.CUFFT_Z2D // complex128 to float64, inverse
+	Z2Z Type = C.CUFFT_Z2Z // complex128 to complex128
+)
+
+func (t Type) String() string {
+	switch t {
+	case R2C:
+		return "R2C"
+	case C2R:
+		return "C2R"
+	case C2C:
+		return "C2C"
+	case D2Z:
+		return "D2Z"
+	case Z2D:
+		return "Z2D"
+	case Z2Z:
+		return "Z2Z"
+	}
+	return "UnknownType"
+}
+
+// Direction is the direction of a transform. The transforms are not normalized: a forward transform followed by an
+// inverse one scales the signal by its number of elements.
+type Direction int
+
+const (
+	Forward Direction = C.CUFFT_FORWARD
+	Inverse Direction = C.CUFFT_INVERSE
+)
+
+// Version returns the version of cuFFT.
+func Version() (int, error) {
+	var v C.int
+	err := result(C.cufftGetVersion(&v))
+	return int(v), err
+}
+
+// Plan is a cuFFT plan: the configuration of a transform, and the workspace it needs. It is bound to the context that
+// was current when it was created.
+type Plan struct {
+	h   C.cufftHandle
+	typ Type
+}
+
+// NewPlan1D creates a plan of batch one dimensional transforms of n elements. The signals are consecutive in memory.
+// For real to complex transforms, the complex side of each signal has n/2+1 elements.
+func NewPlan1D(n int, typ Type, batch int) (*Plan, error) {
+	p := &Plan{typ: typ}
+	if err := result(C.cufftPlan1d(&p.h, C.int(n), C.cufftType(typ), C.int(batch))); err != nil {
+		return nil, errors.Wrapf(err, "Unable to plan a %v transform of %d×%d elements", typ, batch, n)
+	}
+	return p, nil
+}
+
+// NewPlan2D creates a plan of a two dimensional transform of nx×ny elements, in row major order.
+func NewPlan2D(nx, ny int, typ Type) (*Plan, error) {
+	p := &Plan{typ: typ}
+	if err := result(C.cufftPlan2d(&p.h, C.int(nx), C.int(ny), C.cufftType(typ))); err != nil {
+		return nil, errors.Wrapf(err, "Unable to plan a %v transform of %d×%d elements", typ, nx, ny)
+	}
+	return p, nil
+}
+
+// NewPlan3D creates a plan of a three dimensional transform of nx×ny×nz elements, in row major order.
+func NewPlan3D(nx, ny, nz int, typ Type) (*Plan, error) {
+	p := &Plan{typ: typ}
+	if err := result(C.cufftPlan3d(&p.h, C.int(nx), C.int(ny), C.int(nz), C.cufftType(typ))); err != nil {
+		return nil, errors.Wrapf(err, "Unable to plan a %v transform of %d×%d×%d elements", typ, nx, ny, nz)
+	}
+	return p, nil
+}
+
+// Type returns the type of the transforms of the plan.
+func (p *Plan) Type() Type { return p.typ }
+
+// SetStream sets the stream the transforms are enqueued on.
+func (p *Plan) SetStream(stream cu.Stream) error {
+	return result(C.cufftSetStream(p.h, C.cudaStream_t(stream.Pointer())))
+}
+
+// Destroy destroys the plan, and frees its workspace.
+func (p *Plan) Destroy() error { return result(C.cufftDestroy(p.h)) }
+
+// Exec computes the transform of in into out, which may be the same memory. Real to complex transforms are forward,
+// and complex to real transforms inverse: dir must match them.
+func (p *Plan) Exec(in, out cu.DevicePtr, dir Direction) error {
+	if dir != Forward && dir != Inverse {
+		return errors.Errorf("Invalid direction %d", int(dir))
+	}
+	switch p.typ {
+	case R2C, D2Z:
+		if dir != Forward {
+			return errors.Errorf("A %v transform is forward", p.typ)
+		}
+	case C2R, Z2D:
+		if dir != Inverse {
+			return errors.Errorf("A %v transform is inverse", p.typ)
+		}
+	}
+
+	var err error
+	i, o := in.Pointer(), out.Pointer()
+	switch p.typ {
+	case C2C:
+		err = result(C.cufftExecC2C(p.h, (*C.cufftComplex)(i), (*C.cufftComplex)(o), C.int(dir)))
+	case R2C:
+		err = result(C.cufftExecR2C(p.h, (*C.cufftReal)(i), (*C.cufftComplex)(o)))
+	case C2R:
+		err = result(C.cufftExecC2R(p.h, (*C.cufftComplex)(i), (*C.cufftReal)(o)))
+	case Z2Z:
+		err = result(C.cufftExecZ2Z(p.h, (*C.cufftDoubleComplex)(i), (*C.cufftDoubleComplex)(o), C.int(dir)))
+	case D2Z:
+		err = result(C.cufftExecD2Z(p.h, (*C.cufftDoubleReal)(i), (*C.cufftDoubleComplex)(o)))
+	case Z2D:
+		err = result(C.cufftExecZ2D(p.h, (*C.cufftDoubleComplex)(i), (*C.cufftDoubleReal)(o)))
+	default:
+		return errors.Errorf("Unknown transform type %d", int(p.typ))
+	}
+	return errors.Wrapf(err, "%v transform", p.typ)
+}
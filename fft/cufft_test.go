@@ -0,0 +1,168 @@
+package cufft
+
+import (
+	"math"
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"gorgonia.org/cu"
+)
+
+func TestCheckCallback(t *testing.T) {
+	p := &Plan{typ: R2C}
+	if err := p.checkCallback(LoadReal); (err == nil) != static {
+		t.Errorf("Expected callbacks to be supported only with the static cuFFT. Got %v", err)
+	}
+	if err := p.checkCallback(LoadComplex); err == nil {
+		t.Error("Expected an error for a complex load callback of a R2C plan")
+	}
+	if err := p.checkCallback(StoreReal); err == nil {
+		t.Error("Expected an error for a real store callback of a R2C plan")
+	}
+}
+
+func TestExec(t *testing.T) {
+	if devices, _ := cu.NumDevices(); devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := cu.Device(0).MakeContext(cu.SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+
+	const n = 8
+	signal := make([]complex64, n)
+	signal[1] = 1
+	mem, err := cu.AllocAndCopy(unsafe.Pointer(&signal[0]), n*8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cu.MemFree(mem)
+
+	plan, err := NewPlan1D(n, C2C, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer plan.Destroy()
+	if err = plan.Exec(mem, mem, Forward); err != nil {
+		t.Fatal(err)
+	}
+	spectrum := make([]complex64, n)
+	if err = cu.MemcpyDtoH(unsafe.Pointer(&spectrum[0]), mem, n*8); err != nil {
+		t.Fatal(err)
+	}
+	// the spectrum of a shifted impulse is exp(-2πik/n)
+	for k, v := range spectrum {
+		re, im := math.Cos(-2*math.Pi*float64(k)/n), math.Sin(-2*math.Pi*float64(k)/n)
+		if math.Abs(float64(real(v))-re) > 1e-5 || math.Abs(float64(imag(v))-im) > 1e-5 {
+			t.Errorf("Expected %v at %d. Got %v", complex(re, im), k, v)
+		}
+	}
+
+	if err = plan.Exec(mem, mem, Inverse); err != nil {
+		t.Fatal(err)
+	}
+	if err = cu.MemcpyDtoH(unsafe.Pointer(&spectrum[0]), mem, n*8); err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range spectrum {
+		want := n * signal[i]
+		if math.Abs(float64(real(v-want))) > 1e-4 || math.Abs(float64(imag(v-want))) > 1e-4 {
+			t.Errorf("Expected %v at %d after the inverse transform. Got %v", want, i, v)
+		}
+	}
+
+	if err = plan.Exec(mem, mem, Direction(0)); err == nil {
+		t.Error("Expected an error for an invalid direction")
+	}
+}
+
+func TestFastSize(t *testing.T) {
+	for _, c := range []struct{ n, want int }{{1, 1}, {2, 2}, {3, 4}, {11, 12}, {13, 14}, {17, 18}, {97, 98}, {1025, 1050}} {
+		if got := fastSize(c.n); got != c.want {
+			t.Errorf("fastSize(%d): expected %d. Got %d", c.n, c.want, got)
+		}
+	}
+}
+
+func TestConvolve_Shapes(t *testing.T) {
+	cv := NewConvolver(cu.NoStream)
+	a := cu.NewDeviceTensor(0x1000, cu.DtFloat32, 2, 8)
+	b := cu.NewDeviceTensor(0x2000, cu.DtFloat32, 3)
+	if err := cv.Convolve(cu.NewDeviceTensor(0x3000, cu.DtFloat32, 2, 8), a, b); err == nil {
+		t.Error("Expected an error for an output of the wrong length")
+	}
+	if err := cv.Convolve(cu.NewDeviceTensor(0x3000, cu.DtFloat32, 2, 10), a, cu.NewDeviceTensor(0x2000, cu.DtFloat32, 3, 3)); err == nil {
+		t.Error("Expected an error for mismatched batches")
+	}
+	if err := cv.Convolve(cu.NewDeviceTensor(0x3000, cu.DtInt32, 2, 10), cu.NewDeviceTensor(0x1000, cu.DtInt32, 2, 8), cu.NewDeviceTensor(0x2000, cu.DtInt32, 3)); err == nil {
+		t.Error("Expected an error for integer signals")
+	}
+	transposed, _ := a.Transpose(1, 0)
+	if err := cv.Convolve(cu.NewDeviceTensor(0x3000, cu.DtFloat32, 8, 4), transposed, b); err == nil {
+		t.Error("Expected an error for signals that are not contiguous")
+	}
+}
+
+func TestConvolve(t *testing.T) {
+	if devices, _ := cu.NumDevices(); devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := cu.Device(0).MakeContext(cu.SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+
+	a := []float32{1, 2, 3, 4, 0, 1, 0, -1}
+	b := []float32{1, 0, -1}
+	mem, err := cu.MemAlloc(int64(len(a)+len(b)+2*6) * 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cu.MemFree(mem)
+	pa, pb, pout := mem, mem.Offset(int64(len(a))*4), mem.Offset(int64(len(a)+len(b))*4)
+	if err = cu.MemcpyHtoD(pa, unsafe.Pointer(&a[0]), int64(len(a))*4); err != nil {
+		t.Fatal(err)
+	}
+	if err = cu.MemcpyHtoD(pb, unsafe.Pointer(&b[0]), int64(len(b))*4); err != nil {
+		t.Fatal(err)
+	}
+	ta, tb := cu.NewDeviceTensor(pa, cu.DtFloat32, 2, 4), cu.NewDeviceTensor(pb, cu.DtFloat32, 3)
+	out := cu.NewDeviceTensor(pout, cu.DtFloat32, 2, 6)
+
+	cv := NewConvolver(cu.NoStream)
+	defer cv.Close()
+	check := func(name string, want []float32) {
+		if err := cu.Synchronize(); err != nil {
+			t.Fatal(err)
+		}
+		got := make([]float32, 12)
+		if err := cu.MemcpyDtoH(unsafe.Pointer(&got[0]), pout, 12*4); err != nil {
+			t.Fatal(err)
+		}
+		for i := range want {
+			if math.Abs(float64(got[i]-want[i])) > 1e-4 {
+				t.Errorf("%s: expected %v. Got %v", name, want, got)
+				return
+			}
+		}
+	}
+	if err = cv.Convolve(out, ta, tb); err != nil {
+		t.Fatal(err)
+	}
+	check("Convolve", []float32{1, 2, 2, 2, -3, -4, 0, 1, 0, -2, 0, 1})
+	if err = cv.Correlate(out, ta, tb); err != nil {
+		t.Fatal(err)
+	}
+	check("Correlate", []float32{-1, -2, -2, -2, 3, 4, 0, -1, 0, 2, 0, -1})
+	if len(cv.plans) != 1 {
+		t.Errorf("Expected the plans to be reused. Got %d plans", len(cv.plans))
+	}
+}
@@ -0,0 +1,61 @@
+package cufft
+
+// #include <cufft.h>
+import "C"
+
+// Status is the status returned by cuFFT.
+type Status int
+
+func (err Status) Error() string  { return err.String() }
+func (err Status) String() string { return resString[err] }
+
+func result(x C.cufftResult) error {
+	err := Status(x)
+	if err == Success {
+		return nil
+	}
+	if _, ok := resString[err]; !ok {
+		return InternalError
+	}
+	return err
+}
+
+const (
+	Success                 Status = C.CUFFT_SUCCESS
+	InvalidPlan             Status = C.CUFFT_INVALID_PLAN
+	AllocFailed             Status = C.CUFFT_ALLOC_FAILED
+	InvalidType             Status = C.CUFFT_INVALID_TYPE
+	InvalidValue            Status = C.CUFFT_INVALID_VALUE
+	InternalError           Status = C.CUFFT_INTERNAL_ERROR
+	ExecFailed              Status = C.CUFFT_EXEC_FAILED
+	SetupFailed             Status = C.CUFFT_SETUP_FAILED
+	InvalidSize             Status = C.CUFFT_INVALID_SIZE
+	UnalignedData           Status = C.CUFFT_UNALIGNED_DATA
+	IncompleteParameterList Status = C.CUFFT_INCOMPLETE_PARAMETER_LIST
+	InvalidDevice           Status = C.CUFFT_INVALID_DEVICE
+	ParseError              Status = C.CUFFT_PARSE_ERROR
+	NoWorkspace             Status = C.CUFFT_NO_WORKSPACE
+	NotImplemented          Status = C.CUFFT_NOT_IMPLEMENTED
+	LicenseError            Status = C.CUFFT_LICENSE_ERROR
+	NotSupported            Status = C.CUFFT_NOT_SUPPORTED
+)
+
+var resString = map[Status]string{
+	Success:                 "Success",
+	InvalidPlan:             "InvalidPlan",
+	AllocFailed:             "AllocFailed",
+	InvalidType:             "InvalidType",
+	InvalidValue:            "InvalidValue",
+	InternalError:           "InternalError",
+	ExecFailed:              "ExecFailed",
+	SetupFailed:             "SetupFailed",
+	InvalidSize:             "InvalidSize",
+	UnalignedData:           "UnalignedData",
+	IncompleteParameterList: "IncompleteParameterList",
+	InvalidDevice:           "InvalidDevice",
+	ParseError:              "ParseError",
+	NoWorkspace:             "NoWorkspace",
+	NotImplemented:          "NotImplemented",
+	LicenseError:            "LicenseError",
+	NotSupported:            "NotSupported",
+}
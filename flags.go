@@ -2,6 +2,10 @@ package cu
 
 //#include <cuda.h>
 import "C"
+import (
+	"fmt"
+	"strings"
+)
 
 // Format is the type of array (think array types)
 type Format byte
@@ -70,6 +74,25 @@ const (
 	AttachSingle MemAttachFlags = C.CU_MEM_ATTACH_SINGLE // Memory can only be accessed by a single stream on the associated device
 )
 
+// HostRegisterFlags are flags for registering host memory as page-locked (see MemHostRegister)
+type HostRegisterFlags byte
+
+const (
+	HostRegisterPortable  HostRegisterFlags = C.CU_MEMHOSTREGISTER_PORTABLE  // Memory is page-locked for all contexts, not just the current one
+	HostRegisterDeviceMap HostRegisterFlags = C.CU_MEMHOSTREGISTER_DEVICEMAP // Memory is mapped into the address space of the devices (see MemHostGetDevicePointer)
+	HostRegisterIOMemory  HostRegisterFlags = C.CU_MEMHOSTREGISTER_IOMEMORY  // Memory is I/O memory, such as the memory of another PCIe device
+	HostRegisterReadOnly  HostRegisterFlags = C.CU_MEMHOSTREGISTER_READ_ONLY // Memory is only read by the devices
+)
+
+// HostAllocFlags are flags for allocating page-locked host memory (see MemHostAlloc)
+type HostAllocFlags byte
+
+const (
+	HostAllocPortable      HostAllocFlags = C.CU_MEMHOSTALLOC_PORTABLE      // Memory is page-locked for all contexts, not just the current one
+	HostAllocDeviceMap     HostAllocFlags = C.CU_MEMHOSTALLOC_DEVICEMAP     // Memory is mapped into the address space of the devices (see MemHostGetDevicePointer)
+	HostAllocWriteCombined HostAllocFlags = C.CU_MEMHOSTALLOC_WRITECOMBINED // Memory is write-combined: faster to copy to the devices, but very slow to read from the host
+)
+
 // StreamFlags are flags for stream behaviours
 type StreamFlags byte
 
@@ -118,6 +141,22 @@ const (
 	InterprocessEvent EventFlags = C.CU_EVENT_INTERPROCESS   // Event is suitable for interprocess use. DisableTiming must be set
 )
 
+// IPCMemFlags are flags for opening the memory of another process (see OpenIPCMemHandle)
+type IPCMemFlags byte
+
+const (
+	IPCLazyEnablePeerAccess IPCMemFlags = C.CU_IPC_MEM_LAZY_ENABLE_PEER_ACCESS // Peer access to the device of the memory is enabled when it is first needed
+)
+
+// MemAccessFlags are the access a device has to mapped virtual memory (see MemSetAccess)
+type MemAccessFlags byte
+
+const (
+	MemAccessNone      MemAccessFlags = C.CU_MEM_ACCESS_FLAGS_PROT_NONE      // The memory cannot be accessed
+	MemAccessRead      MemAccessFlags = C.CU_MEM_ACCESS_FLAGS_PROT_READ      // The memory can be read
+	MemAccessReadWrite MemAccessFlags = C.CU_MEM_ACCESS_FLAGS_PROT_READWRITE // The memory can be read and written
+)
+
 // AddressMode are texture reference addressing modes
 type AddressMode byte
 
@@ -136,10 +175,99 @@ const (
 	LinearFilterMode FilterMode = C.CU_TR_FILTER_MODE_LINEAR // Linear filter mode
 )
 
+// TexRefFlags are flags for texture references
 type TexRefFlags byte
 
 const (
 	ReadAsInteger        TexRefFlags = C.CU_TRSF_READ_AS_INTEGER        // Override the texref format with a format inferred from the array.
 	NormalizeCoordinates TexRefFlags = C.CU_TRSF_NORMALIZED_COORDINATES // Use normalized texture coordinates in the range [0,1) instead of [0,dim).
-	SRGB                 TexRefFlags = C.CU_TRSF_READ_AS_INTEGER        // Perform sRGB->linear conversion during texture read.
+	SRGB                 TexRefFlags = C.CU_TRSF_SRGB                   // Perform sRGB->linear conversion during texture read.
+)
+
+// ArrayFlags are flags for creating 3D arrays, set in the Flags of an Array3Desc
+type ArrayFlags uint
+
+const (
+	ArrayLayered          ArrayFlags = C.CUDA_ARRAY3D_LAYERED        // The array is a stack of 1D or 2D layers, as many as its depth
+	ArraySurfaceLoadStore ArrayFlags = C.CUDA_ARRAY3D_SURFACE_LDST   // The array may be bound to a surface object, and written by kernels
+	ArrayCubemap          ArrayFlags = C.CUDA_ARRAY3D_CUBEMAP        // The array is a cubemap: 6 square layers, the faces of a cube
+	ArrayTextureGather    ArrayFlags = C.CUDA_ARRAY3D_TEXTURE_GATHER // The array is a 2D array for texture gather operations
+)
+
+// ResourceType is the type of the memory a texture or surface object reads (see ResourceDesc)
+type ResourceType byte
+
+const (
+	ResourceArray          ResourceType = C.CU_RESOURCE_TYPE_ARRAY           // A CUDA array
+	ResourceMipmappedArray ResourceType = C.CU_RESOURCE_TYPE_MIPMAPPED_ARRAY // A mipmapped CUDA array
+	ResourceLinear         ResourceType = C.CU_RESOURCE_TYPE_LINEAR          // Linear device memory
+	ResourcePitch2D        ResourceType = C.CU_RESOURCE_TYPE_PITCH2D         // Pitched 2D device memory
+)
+
+// ClusterSchedulingPolicy is the policy with which the blocks of the clusters of a launch are scheduled (see LaunchAttrs)
+type ClusterSchedulingPolicy byte
+
+const (
+	ClusterSchedulingDefault       ClusterSchedulingPolicy = C.CU_CLUSTER_SCHEDULING_POLICY_DEFAULT        // The default policy
+	ClusterSchedulingSpread        ClusterSchedulingPolicy = C.CU_CLUSTER_SCHEDULING_POLICY_SPREAD         // Spread the blocks of the clusters over the multiprocessors
+	ClusterSchedulingLoadBalancing ClusterSchedulingPolicy = C.CU_CLUSTER_SCHEDULING_POLICY_LOAD_BALANCING // Allow the hardware to load balance the blocks of the clusters
+)
+
+// MemSyncDomain is the domain of the memory flushes of a kernel (see LaunchAttrs). Kernels in different domains do not
+// wait for the memory operations of each other at their fences.
+type MemSyncDomain byte
+
+const (
+	MemSyncDomainDefault MemSyncDomain = C.CU_LAUNCH_MEM_SYNC_DOMAIN_DEFAULT // The domain of compute kernels
+	MemSyncDomainRemote  MemSyncDomain = C.CU_LAUNCH_MEM_SYNC_DOMAIN_REMOTE  // The domain of kernels that communicate with other devices, such as NCCL's
+)
+
+// GraphNodeType is the type of a node of a CUDA graph
+type GraphNodeType byte
+
+const (
+	KernelNode GraphNodeType = C.CU_GRAPH_NODE_TYPE_KERNEL // GPU kernel node
+	MemcpyNode GraphNodeType = C.CU_GRAPH_NODE_TYPE_MEMCPY // Memcpy node
+	MemsetNode GraphNodeType = C.CU_GRAPH_NODE_TYPE_MEMSET // Memset node
+	HostNode   GraphNodeType = C.CU_GRAPH_NODE_TYPE_HOST   // Host (executable) node
+	GraphNode  GraphNodeType = C.CU_GRAPH_NODE_TYPE_GRAPH  // Node which executes an embedded graph
+	EmptyNode  GraphNodeType = C.CU_GRAPH_NODE_TYPE_EMPTY  // Empty (no-op) node
 )
+
+type flagName struct {
+	v    uint64
+	name string
+}
+
+// flagString formats a set of flags. Values that are not named exactly are broken down into single bit flags.
+func flagString(v uint64, names []flagName, typ string) string {
+	var parts []string
+	rem := v
+	for _, n := range names {
+		if n.v == v {
+			return n.name
+		}
+		if n.v != 0 && n.v&(n.v-1) == 0 && rem&n.v != 0 {
+			parts = append(parts, n.name)
+			rem &^= n.v
+		}
+	}
+	if rem != 0 || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%s(0x%x)", typ, rem))
+	}
+	return strings.Join(parts, "|")
+}
+
+// flagsValid returns true if v is a named value, or if all the bits set in v are named single bit flags.
+func flagsValid(v uint64, names []flagName) bool {
+	var known uint64
+	for _, n := range names {
+		if n.v == v {
+			return true
+		}
+		if n.v != 0 && n.v&(n.v-1) == 0 {
+			known |= n.v
+		}
+	}
+	return v&^known == 0
+}
@@ -0,0 +1,36 @@
+package cu
+
+// #include <cuda.h>
+import "C"
+import "fmt"
+
+// SyncError is the error returned in force-synchronous mode (see Features.ForceSync) by an asynchronous call that
+// succeeded, when synchronizing right after it failed. Since every asynchronous call is synchronized in that mode, the
+// work that failed is the work enqueued by Call.
+type SyncError struct {
+	Call string // the call that enqueued the failing work
+	Err  error  // the error returned when synchronizing
+}
+
+func (e *SyncError) Error() string {
+	return fmt.Sprintf("%s failed asynchronously: %v", e.Call, e.Err)
+}
+
+// Cause returns the error returned when synchronizing.
+func (e *SyncError) Cause() error { return e.Err }
+
+// Unwrap returns the error returned when synchronizing.
+func (e *SyncError) Unwrap() error { return e.Err }
+
+// checkSync returns err, the result of an asynchronous call on the stream. In force-synchronous mode, if the call
+// succeeded, it synchronizes the stream and reports its failure as a SyncError. Capturing streams are not synchronized,
+// as it would invalidate the capture.
+func checkSync(call string, stream Stream, err error) error {
+	if err != nil || !forceSync() || stream.capturing() {
+		return err
+	}
+	if err = result(C.cuStreamSynchronize(stream.c())); err != nil {
+		return &SyncError{Call: call, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,512 @@
+package cu
+
+/* Generated by genenums. DO NOT EDIT */
+
+import "fmt"
+
+var _FormatNames = map[Format]string{
+	Uint8:   "Uint8",
+	Uint16:  "Uint16",
+	Uin32:   "Uin32",
+	Int8:    "Int8",
+	Int16:   "Int16",
+	Int32:   "Int32",
+	Float16: "Float16",
+	Float32: "Float32",
+}
+
+func (e Format) String() string {
+	if s, ok := _FormatNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("Format(%d)", e)
+}
+
+// IsValid returns true if e is a known Format.
+func (e Format) IsValid() bool { _, ok := _FormatNames[e]; return ok }
+
+var _FuncCacheConfigNames = map[FuncCacheConfig]string{
+	PreferNone:   "PreferNone",
+	PreferShared: "PreferShared",
+	PreferL1:     "PreferL1",
+	PreferEqual:  "PreferEqual",
+}
+
+func (e FuncCacheConfig) String() string {
+	if s, ok := _FuncCacheConfigNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("FuncCacheConfig(%d)", e)
+}
+
+// IsValid returns true if e is a known FuncCacheConfig.
+func (e FuncCacheConfig) IsValid() bool { _, ok := _FuncCacheConfigNames[e]; return ok }
+
+var _ContextFlagsNames = []flagName{
+	{uint64(SchedAuto), "SchedAuto"},
+	{uint64(SchedSpin), "SchedSpin"},
+	{uint64(SchedYield), "SchedYield"},
+	{uint64(SchedBlockingSync), "SchedBlockingSync"},
+	{uint64(MapHost), "MapHost"},
+	{uint64(LMemResizeToMax), "LMemResizeToMax"},
+}
+
+func (e ContextFlags) String() string {
+	return flagString(uint64(e), _ContextFlagsNames, "ContextFlags")
+}
+
+// IsValid returns true if all the flags set in e are known flags.
+func (e ContextFlags) IsValid() bool { return flagsValid(uint64(e), _ContextFlagsNames) }
+
+var _LimitNames = map[Limit]string{
+	StackSize:                    "StackSize",
+	PrintfFIFOSize:               "PrintfFIFOSize",
+	MallocHeapSize:               "MallocHeapSize",
+	DevRuntimeSyncDepth:          "DevRuntimeSyncDepth",
+	DevRuntimePendingLaunchCount: "DevRuntimePendingLaunchCount",
+}
+
+func (e Limit) String() string {
+	if s, ok := _LimitNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("Limit(%d)", e)
+}
+
+// IsValid returns true if e is a known Limit.
+func (e Limit) IsValid() bool { _, ok := _LimitNames[e]; return ok }
+
+var _SharedConfigNames = map[SharedConfig]string{
+	DefaultBankSize:   "DefaultBankSize",
+	FourByteBankSize:  "FourByteBankSize",
+	EightByteBankSize: "EightByteBankSize",
+}
+
+func (e SharedConfig) String() string {
+	if s, ok := _SharedConfigNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("SharedConfig(%d)", e)
+}
+
+// IsValid returns true if e is a known SharedConfig.
+func (e SharedConfig) IsValid() bool { _, ok := _SharedConfigNames[e]; return ok }
+
+var _MemAttachFlagsNames = []flagName{
+	{uint64(AttachGlobal), "AttachGlobal"},
+	{uint64(AttachHost), "AttachHost"},
+	{uint64(AttachSingle), "AttachSingle"},
+}
+
+func (e MemAttachFlags) String() string {
+	return flagString(uint64(e), _MemAttachFlagsNames, "MemAttachFlags")
+}
+
+// IsValid returns true if all the flags set in e are known flags.
+func (e MemAttachFlags) IsValid() bool { return flagsValid(uint64(e), _MemAttachFlagsNames) }
+
+var _StreamFlagsNames = map[StreamFlags]string{
+	DefaultStream: "DefaultStream",
+	NonBlocking:   "NonBlocking",
+}
+
+func (e StreamFlags) String() string {
+	if s, ok := _StreamFlagsNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("StreamFlags(%d)", e)
+}
+
+// IsValid returns true if e is a known StreamFlags.
+func (e StreamFlags) IsValid() bool { _, ok := _StreamFlagsNames[e]; return ok }
+
+var _MemAdviceNames = map[MemAdvice]string{
+	SetReadMostly:          "SetReadMostly",
+	UnsetReadMostly:        "UnsetReadMostly",
+	SetPreferredLocation:   "SetPreferredLocation",
+	UnsetPreferredLocation: "UnsetPreferredLocation",
+	SetAccessedBy:          "SetAccessedBy",
+	UnsetAccessedBy:        "UnsetAccessedBy",
+}
+
+func (e MemAdvice) String() string {
+	if s, ok := _MemAdviceNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("MemAdvice(%d)", e)
+}
+
+// IsValid returns true if e is a known MemAdvice.
+func (e MemAdvice) IsValid() bool { _, ok := _MemAdviceNames[e]; return ok }
+
+var _MemoryTypeNames = map[MemoryType]string{
+	HostMemory:    "HostMemory",
+	DeviceMemory:  "DeviceMemory",
+	ArrayMemory:   "ArrayMemory",
+	UnifiedMemory: "UnifiedMemory",
+}
+
+func (e MemoryType) String() string {
+	if s, ok := _MemoryTypeNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("MemoryType(%d)", e)
+}
+
+// IsValid returns true if e is a known MemoryType.
+func (e MemoryType) IsValid() bool { _, ok := _MemoryTypeNames[e]; return ok }
+
+var _OccupancyFlagsNames = map[OccupancyFlags]string{
+	DefaultOccupancy:       "DefaultOccupancy",
+	DisableCachingOverride: "DisableCachingOverride",
+}
+
+func (e OccupancyFlags) String() string {
+	if s, ok := _OccupancyFlagsNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("OccupancyFlags(%d)", e)
+}
+
+// IsValid returns true if e is a known OccupancyFlags.
+func (e OccupancyFlags) IsValid() bool { _, ok := _OccupancyFlagsNames[e]; return ok }
+
+var _EventFlagsNames = []flagName{
+	{uint64(DefaultEvent), "DefaultEvent"},
+	{uint64(BlockingSyncEvent), "BlockingSyncEvent"},
+	{uint64(DisableTiming), "DisableTiming"},
+	{uint64(InterprocessEvent), "InterprocessEvent"},
+}
+
+func (e EventFlags) String() string { return flagString(uint64(e), _EventFlagsNames, "EventFlags") }
+
+// IsValid returns true if all the flags set in e are known flags.
+func (e EventFlags) IsValid() bool { return flagsValid(uint64(e), _EventFlagsNames) }
+
+var _AddressModeNames = map[AddressMode]string{
+	WrapMode:   "WrapMode",
+	ClampMode:  "ClampMode",
+	MirrorMode: "MirrorMode",
+	BorderMode: "BorderMode",
+}
+
+func (e AddressMode) String() string {
+	if s, ok := _AddressModeNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("AddressMode(%d)", e)
+}
+
+// IsValid returns true if e is a known AddressMode.
+func (e AddressMode) IsValid() bool { _, ok := _AddressModeNames[e]; return ok }
+
+var _FilterModeNames = map[FilterMode]string{
+	PointFilterMode:  "PointFilterMode",
+	LinearFilterMode: "LinearFilterMode",
+}
+
+func (e FilterMode) String() string {
+	if s, ok := _FilterModeNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("FilterMode(%d)", e)
+}
+
+// IsValid returns true if e is a known FilterMode.
+func (e FilterMode) IsValid() bool { _, ok := _FilterModeNames[e]; return ok }
+
+var _TexRefFlagsNames = []flagName{
+	{uint64(ReadAsInteger), "ReadAsInteger"},
+	{uint64(NormalizeCoordinates), "NormalizeCoordinates"},
+	{uint64(SRGB), "SRGB"},
+}
+
+func (e TexRefFlags) String() string { return flagString(uint64(e), _TexRefFlagsNames, "TexRefFlags") }
+
+// IsValid returns true if all the flags set in e are known flags.
+func (e TexRefFlags) IsValid() bool { return flagsValid(uint64(e), _TexRefFlagsNames) }
+
+var _GraphNodeTypeNames = map[GraphNodeType]string{
+	KernelNode: "KernelNode",
+	MemcpyNode: "MemcpyNode",
+	MemsetNode: "MemsetNode",
+	HostNode:   "HostNode",
+	GraphNode:  "GraphNode",
+	EmptyNode:  "EmptyNode",
+}
+
+func (e GraphNodeType) String() string {
+	if s, ok := _GraphNodeTypeNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("GraphNodeType(%d)", e)
+}
+
+// IsValid returns true if e is a known GraphNodeType.
+func (e GraphNodeType) IsValid() bool { _, ok := _GraphNodeTypeNames[e]; return ok }
+
+var _DeviceAttributeNames = map[DeviceAttribute]string{
+	MaxThreadsPerBlock:                 "MaxThreadsPerBlock",
+	MaxBlockDimX:                       "MaxBlockDimX",
+	MaxBlockDimY:                       "MaxBlockDimY",
+	MaxBlockDimZ:                       "MaxBlockDimZ",
+	MaxGridDimX:                        "MaxGridDimX",
+	MaxGridDimY:                        "MaxGridDimY",
+	MaxGridDimZ:                        "MaxGridDimZ",
+	MaxSharedMemoryPerBlock:            "MaxSharedMemoryPerBlock",
+	TotalConstantMemory:                "TotalConstantMemory",
+	WarpSize:                           "WarpSize",
+	MaxPitch:                           "MaxPitch",
+	MaxRegistersPerBlock:               "MaxRegistersPerBlock",
+	ClockRate:                          "ClockRate",
+	TextureAlignment:                   "TextureAlignment",
+	GpuOverlap:                         "GpuOverlap",
+	MultiprocessorCount:                "MultiprocessorCount",
+	KernelExecTimeout:                  "KernelExecTimeout",
+	Integrated:                         "Integrated",
+	CanMapHostMemory:                   "CanMapHostMemory",
+	ComputeMode:                        "ComputeMode",
+	MaximumTexture1dWidth:              "MaximumTexture1dWidth",
+	MaximumTexture2dWidth:              "MaximumTexture2dWidth",
+	MaximumTexture2dHeight:             "MaximumTexture2dHeight",
+	MaximumTexture3dWidth:              "MaximumTexture3dWidth",
+	MaximumTexture3dHeight:             "MaximumTexture3dHeight",
+	MaximumTexture3dDepth:              "MaximumTexture3dDepth",
+	MaximumTexture2dLayeredWidth:       "MaximumTexture2dLayeredWidth",
+	MaximumTexture2dLayeredHeight:      "MaximumTexture2dLayeredHeight",
+	MaximumTexture2dLayeredLayers:      "MaximumTexture2dLayeredLayers",
+	SurfaceAlignment:                   "SurfaceAlignment",
+	ConcurrentKernels:                  "ConcurrentKernels",
+	EccEnabled:                         "EccEnabled",
+	PciBusID:                           "PciBusID",
+	PciDeviceID:                        "PciDeviceID",
+	TccDriver:                          "TccDriver",
+	MemoryClockRate:                    "MemoryClockRate",
+	GlobalMemoryBusWidth:               "GlobalMemoryBusWidth",
+	L2CacheSize:                        "L2CacheSize",
+	MaxThreadsPerMultiprocessor:        "MaxThreadsPerMultiprocessor",
+	AsyncEngineCount:                   "AsyncEngineCount",
+	UnifiedAddressing:                  "UnifiedAddressing",
+	MaximumTexture1dLayeredWidth:       "MaximumTexture1dLayeredWidth",
+	MaximumTexture1dLayeredLayers:      "MaximumTexture1dLayeredLayers",
+	CanTex2dGather:                     "CanTex2dGather",
+	MaximumTexture2dGatherWidth:        "MaximumTexture2dGatherWidth",
+	MaximumTexture2dGatherHeight:       "MaximumTexture2dGatherHeight",
+	MaximumTexture3dWidthAlternate:     "MaximumTexture3dWidthAlternate",
+	MaximumTexture3dHeightAlternate:    "MaximumTexture3dHeightAlternate",
+	MaximumTexture3dDepthAlternate:     "MaximumTexture3dDepthAlternate",
+	PciDomainID:                        "PciDomainID",
+	TexturePitchAlignment:              "TexturePitchAlignment",
+	MaximumTexturecubemapWidth:         "MaximumTexturecubemapWidth",
+	MaximumTexturecubemapLayeredWidth:  "MaximumTexturecubemapLayeredWidth",
+	MaximumTexturecubemapLayeredLayers: "MaximumTexturecubemapLayeredLayers",
+	MaximumSurface1dWidth:              "MaximumSurface1dWidth",
+	MaximumSurface2dWidth:              "MaximumSurface2dWidth",
+	MaximumSurface2dHeight:             "MaximumSurface2dHeight",
+	MaximumSurface3dWidth:              "MaximumSurface3dWidth",
+	MaximumSurface3dHeight:             "MaximumSurface3dHeight",
+	MaximumSurface3dDepth:              "MaximumSurface3dDepth",
+	MaximumSurface1dLayeredWidth:       "MaximumSurface1dLayeredWidth",
+	MaximumSurface1dLayeredLayers:      "MaximumSurface1dLayeredLayers",
+	MaximumSurface2dLayeredWidth:       "MaximumSurface2dLayeredWidth",
+	MaximumSurface2dLayeredHeight:      "MaximumSurface2dLayeredHeight",
+	MaximumSurface2dLayeredLayers:      "MaximumSurface2dLayeredLayers",
+	MaximumSurfacecubemapWidth:         "MaximumSurfacecubemapWidth",
+	MaximumSurfacecubemapLayeredWidth:  "MaximumSurfacecubemapLayeredWidth",
+	MaximumSurfacecubemapLayeredLayers: "MaximumSurfacecubemapLayeredLayers",
+	MaximumTexture1dLinearWidth:        "MaximumTexture1dLinearWidth",
+	MaximumTexture2dLinearWidth:        "MaximumTexture2dLinearWidth",
+	MaximumTexture2dLinearHeight:       "MaximumTexture2dLinearHeight",
+	MaximumTexture2dLinearPitch:        "MaximumTexture2dLinearPitch",
+	MaximumTexture2dMipmappedWidth:     "MaximumTexture2dMipmappedWidth",
+	MaximumTexture2dMipmappedHeight:    "MaximumTexture2dMipmappedHeight",
+	ComputeCapabilityMajor:             "ComputeCapabilityMajor",
+	ComputeCapabilityMinor:             "ComputeCapabilityMinor",
+	MaximumTexture1dMipmappedWidth:     "MaximumTexture1dMipmappedWidth",
+	StreamPrioritiesSupported:          "StreamPrioritiesSupported",
+	GlobalL1CacheSupported:             "GlobalL1CacheSupported",
+	LocalL1CacheSupported:              "LocalL1CacheSupported",
+	MaxSharedMemoryPerMultiprocessor:   "MaxSharedMemoryPerMultiprocessor",
+	MaxRegistersPerMultiprocessor:      "MaxRegistersPerMultiprocessor",
+	ManagedMemory:                      "ManagedMemory",
+	MultiGpuBoard:                      "MultiGpuBoard",
+	MultiGpuBoardGroupID:               "MultiGpuBoardGroupID",
+	HostNativeAtomicSupported:          "HostNativeAtomicSupported",
+	SingleToDoublePrecisionPerfRatio:   "SingleToDoublePrecisionPerfRatio",
+	PageableMemoryAccess:               "PageableMemoryAccess",
+	ConcurrentManagedAccess:            "ConcurrentManagedAccess",
+	ComputePreemptionSupported:         "ComputePreemptionSupported",
+	CanUseHostPointerForRegisteredMem:  "CanUseHostPointerForRegisteredMem",
+}
+
+func (e DeviceAttribute) String() string {
+	if s, ok := _DeviceAttributeNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("DeviceAttribute(%d)", e)
+}
+
+// IsValid returns true if e is a known DeviceAttribute.
+func (e DeviceAttribute) IsValid() bool { _, ok := _DeviceAttributeNames[e]; return ok }
+
+var _FunctionAttributeNames = map[FunctionAttribute]string{
+	FnMaxThreadsPerBlock: "FnMaxThreadsPerBlock",
+	SharedSizeBytes:      "SharedSizeBytes",
+	ConstSizeBytes:       "ConstSizeBytes",
+	LocalSizeBytes:       "LocalSizeBytes",
+	NumRegs:              "NumRegs",
+	PtxVersion:           "PtxVersion",
+	BinaryVersion:        "BinaryVersion",
+	CacheModeCa:          "CacheModeCa",
+}
+
+func (e FunctionAttribute) String() string {
+	if s, ok := _FunctionAttributeNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("FunctionAttribute(%d)", e)
+}
+
+// IsValid returns true if e is a known FunctionAttribute.
+func (e FunctionAttribute) IsValid() bool { _, ok := _FunctionAttributeNames[e]; return ok }
+
+var _PointerAttributeNames = map[PointerAttribute]string{
+	ContextAttr:       "ContextAttr",
+	MemoryTypeAttr:    "MemoryTypeAttr",
+	DevicePointerAttr: "DevicePointerAttr",
+	HostPointerAttr:   "HostPointerAttr",
+	P2PTokenAttr:      "P2PTokenAttr",
+	SymcMemopsAttr:    "SymcMemopsAttr",
+	BufferIDAttr:      "BufferIDAttr",
+	IsManagedAttr:     "IsManagedAttr",
+}
+
+func (e PointerAttribute) String() string {
+	if s, ok := _PointerAttributeNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("PointerAttribute(%d)", e)
+}
+
+// IsValid returns true if e is a known PointerAttribute.
+func (e PointerAttribute) IsValid() bool { _, ok := _PointerAttributeNames[e]; return ok }
+
+var _P2PAttributeNames = map[P2PAttribute]string{
+	PerformanceRank:         "PerformanceRank",
+	P2PAccessSupported:      "P2PAccessSupported",
+	P2PNativeAomicSupported: "P2PNativeAomicSupported",
+}
+
+func (e P2PAttribute) String() string {
+	if s, ok := _P2PAttributeNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("P2PAttribute(%d)", e)
+}
+
+// IsValid returns true if e is a known P2PAttribute.
+func (e P2PAttribute) IsValid() bool { _, ok := _P2PAttributeNames[e]; return ok }
+
+var _JITOptionTypeNames = map[JITOptionType]string{
+	JITOptMaxRegisters:            "JITOptMaxRegisters",
+	JITOptThreadsPerBlock:         "JITOptThreadsPerBlock",
+	JITOptWallTime:                "JITOptWallTime",
+	JITOptInfoLogBuffer:           "JITOptInfoLogBuffer",
+	JITOptInfoLogBufferSizeBytes:  "JITOptInfoLogBufferSizeBytes",
+	JITOptErrorLogBuffer:          "JITOptErrorLogBuffer",
+	JITOptErrorLogBufferSizeBytes: "JITOptErrorLogBufferSizeBytes",
+	JITOptOptimizationLevel:       "JITOptOptimizationLevel",
+	JITOptTargetFromContext:       "JITOptTargetFromContext",
+	JITOptTarget:                  "JITOptTarget",
+	JITOptFallbackStrategy:        "JITOptFallbackStrategy",
+	JITOptGenerateDebugInfo:       "JITOptGenerateDebugInfo",
+	JITOptLogVerbose:              "JITOptLogVerbose",
+	JITOptGenerateLineInfo:        "JITOptGenerateLineInfo",
+	JITOptCacheMode:               "JITOptCacheMode",
+	JITOptNewSm3xOpt:              "JITOptNewSm3xOpt",
+	JITOptFastCompile:             "JITOptFastCompile",
+}
+
+func (e JITOptionType) String() string {
+	if s, ok := _JITOptionTypeNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("JITOptionType(%d)", e)
+}
+
+// IsValid returns true if e is a known JITOptionType.
+func (e JITOptionType) IsValid() bool { _, ok := _JITOptionTypeNames[e]; return ok }
+
+var _JITTargetOptionNames = map[JITTargetOption]string{
+	JITTarget20: "JITTarget20",
+	JITTarget21: "JITTarget21",
+	JITTarget30: "JITTarget30",
+	JITTarget32: "JITTarget32",
+	JITTarget35: "JITTarget35",
+	JITTarget37: "JITTarget37",
+	JITTarget50: "JITTarget50",
+	JITTarget52: "JITTarget52",
+	JITTarget53: "JITTarget53",
+	JITTarget60: "JITTarget60",
+	JITTarget61: "JITTarget61",
+	JITTarget62: "JITTarget62",
+}
+
+func (e JITTargetOption) String() string {
+	if s, ok := _JITTargetOptionNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("JITTargetOption(%d)", e)
+}
+
+// IsValid returns true if e is a known JITTargetOption.
+func (e JITTargetOption) IsValid() bool { _, ok := _JITTargetOptionNames[e]; return ok }
+
+var _JITFallbackOptionNames = map[JITFallbackOption]string{
+	JITPreferPTX:    "JITPreferPTX",
+	JITPreferBinary: "JITPreferBinary",
+}
+
+func (e JITFallbackOption) String() string {
+	if s, ok := _JITFallbackOptionNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("JITFallbackOption(%d)", e)
+}
+
+// IsValid returns true if e is a known JITFallbackOption.
+func (e JITFallbackOption) IsValid() bool { _, ok := _JITFallbackOptionNames[e]; return ok }
+
+var _JITCacheModeOptionNames = map[JITCacheModeOption]string{
+	JITCacheNone: "JITCacheNone",
+	JITCacheCG:   "JITCacheCG",
+	JITCacheCA:   "JITCacheCA",
+}
+
+func (e JITCacheModeOption) String() string {
+	if s, ok := _JITCacheModeOptionNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("JITCacheModeOption(%d)", e)
+}
+
+// IsValid returns true if e is a known JITCacheModeOption.
+func (e JITCacheModeOption) IsValid() bool { _, ok := _JITCacheModeOptionNames[e]; return ok }
+
+var _JITInputTypeNames = map[JITInputType]string{
+	JITInputCUBIN:     "JITInputCUBIN",
+	JITInputPTX:       "JITInputPTX",
+	JITInputFatBinary: "JITInputFatBinary",
+	JITInputObject:    "JITInputObject",
+	JITInputLibrary:   "JITInputLibrary",
+}
+
+func (e JITInputType) String() string {
+	if s, ok := _JITInputTypeNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("JITInputType(%d)", e)
+}
+
+// IsValid returns true if e is a known JITInputType.
+func (e JITInputType) IsValid() bool { _, ok := _JITInputTypeNames[e]; return ok }
@@ -0,0 +1,205 @@
+package cu
+
+// #include <cuda.h>
+import "C"
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// Graph is a CUDA graph: work recorded once, for example by capturing a stream (see Stream.BeginCapture), to be
+// launched many times at a fraction of the cost of enqueuing the work again. A Graph is launched once instantiated.
+type Graph struct {
+	g C.CUgraph
+}
+
+// Uintptr returns the graph handle as a uintptr.
+func (g Graph) Uintptr() uintptr { return uintptr(unsafe.Pointer(g.g)) }
+
+// Destroy destroys the graph. Its instantiations are not affected.
+func (g Graph) Destroy() error { return result(C.cuGraphDestroy(g.g)) }
+
+// Nodes returns the types of the nodes of the graph, in no particular order.
+func (g Graph) Nodes() ([]GraphNodeType, error) {
+	var n C.size_t
+	if err := result(C.cuGraphGetNodes(g.g, nil, &n)); err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	nodes := make([]C.CUgraphNode, int(n))
+	if err := result(C.cuGraphGetNodes(g.g, &nodes[0], &n)); err != nil {
+		return nil, err
+	}
+	retVal := make([]GraphNodeType, int(n))
+	for i := range retVal {
+		var t C.CUgraphNodeType
+		if err := result(C.cuGraphNodeGetType(nodes[i], &t)); err != nil {
+			return nil, err
+		}
+		retVal[i] = GraphNodeType(t)
+	}
+	return retVal, nil
+}
+
+// Instantiate creates an executable graph from the graph. The log of the driver is added to the error, if any.
+func (g Graph) Instantiate() (GraphExec, error) {
+	var e GraphExec
+	var log [1024]C.char
+	if err := result(C.cuGraphInstantiate(&e.e, g.g, nil, &log[0], C.size_t(len(log)))); err != nil {
+		if msg := C.GoString(&log[0]); msg != "" {
+			return e, errors.Wrap(err, msg)
+		}
+		return e, err
+	}
+	return e, nil
+}
+
+// GraphExec is an executable graph, instantiated from a Graph.
+type GraphExec struct {
+	e C.CUgraphExec
+}
+
+// Launch enqueues the work of the graph on the stream. An executable graph runs once at a time: a launch waits for the
+// previous launch of the same graph to be done.
+func (e GraphExec) Launch(hStream Stream) error {
+	return checkSync("GraphLaunch", hStream, result(C.cuGraphLaunch(e.e, hStream.c())))
+}
+
+// Destroy destroys the executable graph, once the launches enqueued are done.
+func (e GraphExec) Destroy() error { return result(C.cuGraphExecDestroy(e.e)) }
+
+// StreamCaptureMode determines which calls are forbidden while a stream is capturing. Calls that may synchronize with
+// the captured work, such as MemAlloc or Synchronize, would invalidate the capture: the mode decides which threads they
+// fail on, rather than invalidate it.
+type StreamCaptureMode int
+
+const (
+	// GlobalCapture forbids these calls on every thread, as long as a stream captures in this mode, or in
+	// ThreadLocalCapture mode on the calling thread.
+	GlobalCapture StreamCaptureMode = C.CU_STREAM_CAPTURE_MODE_GLOBAL
+	// ThreadLocalCapture forbids these calls on the thread that began the capture only.
+	ThreadLocalCapture StreamCaptureMode = C.CU_STREAM_CAPTURE_MODE_THREAD_LOCAL
+	// RelaxedCapture does not forbid the calls. It is up to the caller not to synchronize with the captured work.
+	RelaxedCapture StreamCaptureMode = C.CU_STREAM_CAPTURE_MODE_RELAXED
+)
+
+// CaptureStatus is the capture status of a stream.
+type CaptureStatus int
+
+const (
+	NotCapturing       CaptureStatus = C.CU_STREAM_CAPTURE_STATUS_NONE        // the stream is not capturing
+	Capturing          CaptureStatus = C.CU_STREAM_CAPTURE_STATUS_ACTIVE      // the stream is capturing
+	CaptureInvalidated CaptureStatus = C.CU_STREAM_CAPTURE_STATUS_INVALIDATED // the capture failed, and must be ended
+)
+
+// BeginCapture starts capturing the stream: the work enqueued on it is recorded rather than executed, until
+// EndCapture returns it as a Graph. Work on other streams that waits on events recorded on the stream while it
+// captures is captured too.
+//
+// The null stream cannot be captured. In force-synchronous mode (see Features.ForceSync), the calls on a capturing
+// stream are not synchronized.
+func (hStream Stream) BeginCapture(mode StreamCaptureMode) error {
+	if hStream == NoStream {
+		return errors.New("The null stream cannot be captured")
+	}
+	return result(C.cuStreamBeginCapture(hStream.c(), C.CUstreamCaptureMode(mode)))
+}
+
+// EndCapture stops capturing the stream, and returns the work that was captured. It must be called on the thread that
+// began the capture, in ThreadLocalCapture and GlobalCapture modes. If the capture was invalidated, the error is
+// returned once the capture is ended.
+func (hStream Stream) EndCapture() (Graph, error) {
+	var g Graph
+	err := result(C.cuStreamEndCapture(hStream.c(), &g.g))
+	return g, err
+}
+
+// CaptureStatus returns whether the stream is capturing.
+func (hStream Stream) CaptureStatus() (CaptureStatus, error) {
+	var s C.CUstreamCaptureStatus
+	err := result(C.cuStreamIsCapturing(hStream.c(), &s))
+	return CaptureStatus(s), err
+}
+
+// capturing returns true if the stream is capturing, or if its capture was invalidated.
+func (hStream Stream) capturing() bool {
+	if hStream == NoStream {
+		return false
+	}
+	s, err := hStream.CaptureStatus()
+	return err == nil && s != NotCapturing
+}
+
+// ThreadExchangeStreamCaptureMode sets the capture mode of the calling thread, and returns the previous one. The mode
+// of a thread overrides the modes of the captures for the calls made on it: a thread that is known not to synchronize
+// with the captured work may set RelaxedCapture, and set the previous mode back when it is done.
+//
+// The mode belongs to the OS thread, so the goroutine must be locked to it, as in Ctx.Do.
+func ThreadExchangeStreamCaptureMode(mode StreamCaptureMode) (StreamCaptureMode, error) {
+	m := C.CUstreamCaptureMode(mode)
+	err := result(C.cuThreadExchangeStreamCaptureMode(&m))
+	return StreamCaptureMode(m), err
+}
+
+// BeginCapture starts capturing the stream, on the thread of the context (see Stream.BeginCapture).
+func (ctx *Ctx) BeginCapture(hStream Stream, mode StreamCaptureMode) {
+	ctx.setErr(ctx.Do(func() error { return hStream.BeginCapture(mode) }))
+}
+
+// EndCapture stops capturing the stream, on the thread of the context, and returns the captured work (see
+// Stream.EndCapture).
+func (ctx *Ctx) EndCapture(hStream Stream) (g Graph, err error) {
+	f := func() error {
+		var err error
+		g, err = hStream.EndCapture()
+		return err
+	}
+	if err = ctx.Do(f); err != nil {
+		err = errors.Wrap(err, "EndCapture")
+	}
+	return
+}
+
+// ThreadExchangeStreamCaptureMode sets the capture mode of the thread of the context, and returns the previous one.
+func (ctx *Ctx) ThreadExchangeStreamCaptureMode(mode StreamCaptureMode) (prev StreamCaptureMode, err error) {
+	f := func() error {
+		var err error
+		prev, err = ThreadExchangeStreamCaptureMode(mode)
+		return err
+	}
+	if err = ctx.Do(f); err != nil {
+		err = errors.Wrap(err, "ThreadExchangeStreamCaptureMode")
+	}
+	return
+}
+
+// BeginCapture processes the calls queued so far, then starts capturing the stream: the kernels launched on the
+// stream afterwards are captured when their batch is processed, until EndCapture. The BatchedContext must be run by
+// Run, which serves Do on the thread that processes the batches.
+func (ctx *BatchedContext) BeginCapture(hStream Stream, mode StreamCaptureMode) error {
+	return ctx.Do(func() error {
+		ctx.DoWork()
+		if err := hStream.BeginCapture(mode); err != nil {
+			return err
+		}
+		ctx.capturing++
+		return nil
+	})
+}
+
+// EndCapture processes the calls queued so far, then stops capturing the stream and returns the captured work.
+func (ctx *BatchedContext) EndCapture(hStream Stream) (g Graph, err error) {
+	err = ctx.Do(func() error {
+		ctx.DoWork()
+		if ctx.capturing > 0 {
+			ctx.capturing--
+		}
+		var err error
+		g, err = hStream.EndCapture()
+		return err
+	})
+	return g, errors.Wrap(err, "EndCapture")
+}
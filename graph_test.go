@@ -0,0 +1,91 @@
+package cu
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+)
+
+func TestStreamCapture(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	_, ctx, err := testSetup()
+	if err != nil {
+		if err.Error() == "NoDevice" {
+			t.Skip("NoDevice")
+		}
+		t.Fatal(err)
+	}
+	defer testTeardown(ctx, Module{})
+
+	if err = NoStream.BeginCapture(GlobalCapture); err == nil {
+		t.Error("Expected an error when capturing the null stream")
+	}
+
+	stream, err := MakeStream(NonBlocking)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Destroy()
+
+	const n = 1024
+	mem, err := MemAlloc(n * 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer MemFree(mem)
+
+	if err = stream.BeginCapture(ThreadLocalCapture); err != nil {
+		t.Fatal(err)
+	}
+	if s, err := stream.CaptureStatus(); err != nil || s != Capturing {
+		t.Errorf("Expected the stream to be capturing. Got %v (%v)", s, err)
+	}
+	if err = MemsetD32Async(mem, 7, n, stream); err != nil {
+		t.Fatal(err)
+	}
+	g, err := stream.EndCapture()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Destroy()
+	if nodes, err := g.Nodes(); err != nil || len(nodes) != 1 || nodes[0] != MemsetNode {
+		t.Errorf("Expected a single memset node. Got %v (%v)", nodes, err)
+	}
+
+	// nothing was executed while capturing
+	if err = MemsetD32(mem, 0, n); err != nil {
+		t.Fatal(err)
+	}
+	exec, err := g.Instantiate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer exec.Destroy()
+	if err = exec.Launch(stream); err != nil {
+		t.Fatal(err)
+	}
+	if err = stream.Synchronize(); err != nil {
+		t.Fatal(err)
+	}
+	got := make([]uint32, n)
+	if err = MemcpyDtoH(unsafe.Pointer(&got[0]), mem, n*4); err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range got {
+		if v != 7 {
+			t.Fatalf("Expected 7 at %d after launching the graph. Got %d", i, v)
+		}
+	}
+
+	prev, err := ThreadExchangeStreamCaptureMode(RelaxedCapture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prev != GlobalCapture {
+		t.Errorf("Expected the default mode of a thread to be GlobalCapture. Got %v", prev)
+	}
+	if _, err = ThreadExchangeStreamCaptureMode(prev); err != nil {
+		t.Fatal(err)
+	}
+}
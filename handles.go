@@ -0,0 +1,90 @@
+package cu
+
+// #include <cuda.h>
+import "C"
+import "unsafe"
+
+// This file lists the conversions of the handles to and from uintptr.
+// These are meant for consumers that need to store handles without referring to cgo types (see package ffi).
+// The handles are opaque to Go, so the usual cgo pointer passing rules do not apply to them.
+// The handles are not pointers to Go memory, which the garbage collector could move or free, so converting them back
+// from a uintptr is safe, although vet reports it as a possible misuse of unsafe.Pointer.
+//
+// The Pointer methods return the handles as unsafe.Pointers, for the packages that pass them to the C APIs of other
+// libraries (such as the cudaStream_t of the CUDA runtime API), which cannot use the cgo types of this package.
+
+// Uintptr returns the context handle as a uintptr.
+func (ctx CUContext) Uintptr() uintptr { return uintptr(unsafe.Pointer(ctx.ctx)) }
+
+// Pointer returns the context handle as an unsafe.Pointer, to be passed as a CUcontext to other C libraries.
+func (ctx CUContext) Pointer() unsafe.Pointer { return unsafe.Pointer(ctx.ctx) }
+
+// CUContextFromUintptr creates a CUContext from a handle previously obtained from (CUContext).Uintptr().
+func CUContextFromUintptr(p uintptr) CUContext { return CUContext{C.CUcontext(unsafe.Pointer(p))} }
+
+// Uintptr returns the stream handle as a uintptr.
+func (s Stream) Uintptr() uintptr { return uintptr(unsafe.Pointer(s.s)) }
+
+// Pointer returns the stream handle as an unsafe.Pointer, to be passed as a CUstream or a cudaStream_t to other C
+// libraries.
+func (s Stream) Pointer() unsafe.Pointer { return unsafe.Pointer(s.s) }
+
+// StreamFromUintptr creates a Stream from a handle previously obtained from (Stream).Uintptr().
+func StreamFromUintptr(p uintptr) Stream { return Stream{C.CUstream(unsafe.Pointer(p))} }
+
+// Uintptr returns the event handle as a uintptr.
+func (e Event) Uintptr() uintptr { return uintptr(unsafe.Pointer(e.ev)) }
+
+// Pointer returns the event handle as an unsafe.Pointer, to be passed as a CUevent to other C libraries.
+func (e Event) Pointer() unsafe.Pointer { return unsafe.Pointer(e.ev) }
+
+// EventFromUintptr creates an Event from a handle previously obtained from (Event).Uintptr().
+func EventFromUintptr(p uintptr) Event { return Event{C.CUevent(unsafe.Pointer(p))} }
+
+// Uintptr returns the module handle as a uintptr.
+func (m Module) Uintptr() uintptr { return uintptr(unsafe.Pointer(m.mod)) }
+
+// Pointer returns the module handle as an unsafe.Pointer, to be passed as a CUmodule to other C libraries.
+func (m Module) Pointer() unsafe.Pointer { return unsafe.Pointer(m.mod) }
+
+// ModuleFromUintptr creates a Module from a handle previously obtained from (Module).Uintptr().
+func ModuleFromUintptr(p uintptr) Module { return Module{C.CUmodule(unsafe.Pointer(p))} }
+
+// Uintptr returns the function handle as a uintptr.
+func (fn Function) Uintptr() uintptr { return uintptr(unsafe.Pointer(fn.fn)) }
+
+// Pointer returns the function handle as an unsafe.Pointer, to be passed as a CUfunction to other C libraries.
+func (fn Function) Pointer() unsafe.Pointer { return unsafe.Pointer(fn.fn) }
+
+// FunctionFromUintptr creates a Function from a handle previously obtained from (Function).Uintptr().
+func FunctionFromUintptr(p uintptr) Function { return Function{C.CUfunction(unsafe.Pointer(p))} }
+
+// Uintptr returns the texture reference handle as a uintptr.
+func (t TexRef) Uintptr() uintptr { return uintptr(unsafe.Pointer(t.ref)) }
+
+// TexRefFromUintptr creates a TexRef from a handle previously obtained from (TexRef).Uintptr().
+func TexRefFromUintptr(p uintptr) TexRef { return TexRef{C.CUtexref(unsafe.Pointer(p))} }
+
+// Uintptr returns the surface reference handle as a uintptr.
+func (s SurfRef) Uintptr() uintptr { return uintptr(unsafe.Pointer(s.ref)) }
+
+// SurfRefFromUintptr creates a SurfRef from a handle previously obtained from (SurfRef).Uintptr().
+func SurfRefFromUintptr(p uintptr) SurfRef { return SurfRef{C.CUsurfref(unsafe.Pointer(p))} }
+
+// Uintptr returns the array handle as a uintptr.
+func (arr Array) Uintptr() uintptr { return uintptr(arr.Pointer()) }
+
+// Pointer returns the array handle as an unsafe.Pointer, to be passed as a CUarray or a cudaArray_t to other C
+// libraries.
+func (arr Array) Pointer() unsafe.Pointer {
+	if arr.arr == nil {
+		return nil
+	}
+	return unsafe.Pointer(arr.c())
+}
+
+// ArrayFromUintptr creates an Array from a handle previously obtained from (Array).Uintptr().
+func ArrayFromUintptr(p uintptr) Array {
+	h := C.CUarray(unsafe.Pointer(p))
+	return goArray(&h)
+}
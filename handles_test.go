@@ -0,0 +1,30 @@
+package cu
+
+import "testing"
+
+func TestHandlePointers(t *testing.T) {
+	// the handles are never dereferenced, so any value will do
+	const h = 0xdeadbeef
+
+	if p := CUContextFromUintptr(h).Pointer(); uintptr(p) != h {
+		t.Errorf("Expected the context pointer to be %#x. Got %p", h, p)
+	}
+	if p := StreamFromUintptr(h).Pointer(); uintptr(p) != h {
+		t.Errorf("Expected the stream pointer to be %#x. Got %p", h, p)
+	}
+	if p := EventFromUintptr(h).Pointer(); uintptr(p) != h {
+		t.Errorf("Expected the event pointer to be %#x. Got %p", h, p)
+	}
+	if p := ModuleFromUintptr(h).Pointer(); uintptr(p) != h {
+		t.Errorf("Expected the module pointer to be %#x. Got %p", h, p)
+	}
+	if p := FunctionFromUintptr(h).Pointer(); uintptr(p) != h {
+		t.Errorf("Expected the function pointer to be %#x. Got %p", h, p)
+	}
+	if p := ArrayFromUintptr(h).Pointer(); uintptr(p) != h {
+		t.Errorf("Expected the array pointer to be %#x. Got %p", h, p)
+	}
+	if p := (Array{}).Uintptr(); p != 0 {
+		t.Errorf("Expected the zero array to have a nil handle. Got %#x", p)
+	}
+}
@@ -0,0 +1,14 @@
+#include <stdint.h>
+#include <cuda.h>
+#include "hostcallback.h"
+#include "_cgo_export.h"
+
+// streamCallback is called by the driver once the work enqueued before it on the stream is done. It must not call the
+// CUDA API, and hands over to Go, which finds the function registered under the handle.
+static void CUDA_CB streamCallback(CUstream stream, CUresult status, void* userData) {
+	goStreamCallback(status, (uintptr_t)userData);
+}
+
+CUresult cuStreamAddGoCallback(CUstream stream, uintptr_t handle) {
+	return cuStreamAddCallback(stream, streamCallback, (void*)handle, 0);
+}
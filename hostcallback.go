@@ -0,0 +1,51 @@
+package cu
+
+// #include <cuda.h>
+// #include "hostcallback.h"
+import "C"
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Go functions cannot be passed to C. The functions called back when streams reach them are registered under a handle,
+// and the handle is passed to the driver instead.
+var streamCallbacks struct {
+	sync.Mutex
+	next uintptr
+	fns  map[uintptr]func(error)
+}
+
+// addStreamCallback calls fn once the work enqueued on the stream so far is done, with the error of that work, if any.
+// fn is called on a thread of the driver, and must not call the CUDA API: functions that need to should hand over to
+// a goroutine.
+func addStreamCallback(stream Stream, fn func(error)) error {
+	streamCallbacks.Lock()
+	if streamCallbacks.fns == nil {
+		streamCallbacks.fns = make(map[uintptr]func(error))
+	}
+	streamCallbacks.next++
+	handle := streamCallbacks.next
+	streamCallbacks.fns[handle] = fn
+	streamCallbacks.Unlock()
+
+	if err := result(C.cuStreamAddGoCallback(stream.c(), C.uintptr_t(handle))); err != nil {
+		streamCallbacks.Lock()
+		delete(streamCallbacks.fns, handle)
+		streamCallbacks.Unlock()
+		return errors.Wrap(err, "Unable to add a callback to the stream")
+	}
+	return nil
+}
+
+//export goStreamCallback
+func goStreamCallback(status C.CUresult, handle C.uintptr_t) {
+	streamCallbacks.Lock()
+	fn := streamCallbacks.fns[uintptr(handle)]
+	delete(streamCallbacks.fns, uintptr(handle))
+	streamCallbacks.Unlock()
+	if fn != nil {
+		fn(result(status))
+	}
+}
@@ -0,0 +1,4 @@
+#include <stdint.h>
+#include <cuda.h>
+
+extern CUresult cuStreamAddGoCallback(CUstream stream, uintptr_t handle);
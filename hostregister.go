@@ -0,0 +1,89 @@
+package cu
+
+// #include <cuda.h>
+import "C"
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// MemHostAlloc allocates bytesize bytes of page-locked host memory, which the devices access directly: copies from and to
+// it are asynchronous, and faster than copies of pageable memory. The memory is page-locked for the current context,
+// unless HostAllocPortable is set. It must be freed with MemFreeHost.
+//
+// Page-locked memory is taken from the memory the operating system may page out, so allocating too much of it slows
+// the whole system down.
+func MemHostAlloc(bytesize int64, flags HostAllocFlags) (unsafe.Pointer, error) {
+	if bytesize <= 0 {
+		return nil, errors.Errorf("Cannot allocate %d bytes of pinned memory", bytesize)
+	}
+	var p unsafe.Pointer
+	if err := result(C.cuMemHostAlloc(&p, C.size_t(bytesize), C.uint(flags))); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// MemHostRegister page-locks the bytesize bytes of host memory at p, which was not allocated by CUDA, so that copies
+// from and to it are asynchronous, as they are for pinned memory. The memory is page-locked for the current context,
+// unless HostRegisterPortable is set.
+//
+// The memory may be memory of the Go heap, which does not move: it must then be kept alive, and unregistered before it
+// becomes unreachable. Page-locking is expensive, and is worth it for memory that is copied many times, or in large
+// transfers.
+func MemHostRegister(p unsafe.Pointer, bytesize int64, flags HostRegisterFlags) error {
+	if p == nil || bytesize <= 0 {
+		return errors.Errorf("Cannot register %d bytes at %p", bytesize, p)
+	}
+	return result(C.cuMemHostRegister(p, C.size_t(bytesize), C.uint(flags)))
+}
+
+// MemHostUnregister unlocks the host memory at p, which was registered with MemHostRegister.
+func MemHostUnregister(p unsafe.Pointer) error {
+	return result(C.cuMemHostUnregister(p))
+}
+
+// MemHostGetDevicePointer returns the device address of host memory that was registered with HostRegisterDeviceMap,
+// or allocated as mapped: kernels access the host memory through it, over the bus.
+func MemHostGetDevicePointer(p unsafe.Pointer) (DevicePtr, error) {
+	var d C.CUdeviceptr
+	err := result(C.cuMemHostGetDevicePointer(&d, p, 0))
+	return DevicePtr(d), err
+}
+
+// MemHostAlloc allocates page-locked host memory, on the thread of the context (see MemHostAlloc).
+func (ctx *Ctx) MemHostAlloc(bytesize int64, flags HostAllocFlags) (p unsafe.Pointer, err error) {
+	f := func() error {
+		var err error
+		p, err = MemHostAlloc(bytesize, flags)
+		return err
+	}
+	if err = ctx.Do(f); err != nil {
+		err = errors.Wrap(err, "MemHostAlloc")
+	}
+	return
+}
+
+// MemHostRegister page-locks host memory, on the thread of the context (see MemHostRegister).
+func (ctx *Ctx) MemHostRegister(p unsafe.Pointer, bytesize int64, flags HostRegisterFlags) {
+	ctx.setErr(ctx.Do(func() error { return MemHostRegister(p, bytesize, flags) }))
+}
+
+// MemHostUnregister unlocks host memory registered with MemHostRegister, on the thread of the context.
+func (ctx *Ctx) MemHostUnregister(p unsafe.Pointer) {
+	ctx.setErr(ctx.Do(func() error { return MemHostUnregister(p) }))
+}
+
+// MemHostGetDevicePointer returns the device address of mapped host memory, on the thread of the context.
+func (ctx *Ctx) MemHostGetDevicePointer(p unsafe.Pointer) (dptr DevicePtr, err error) {
+	f := func() error {
+		var err error
+		dptr, err = MemHostGetDevicePointer(p)
+		return err
+	}
+	if err = ctx.Do(f); err != nil {
+		err = errors.Wrap(err, "MemHostGetDevicePointer")
+	}
+	return
+}
@@ -0,0 +1,195 @@
+package cu
+
+// #include <cuda.h>
+// #include "batch.h"
+import "C"
+import (
+	"log"
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+/*
+This file holds what the hot paths (kernel launches, memory copies and batched calls) need to not allocate:
+the kernel parameters are copied to pooled C buffers instead of buffers allocated per launch, and the calls made
+through a Ctx or a BatchedContext are described by pooled fnargs instead of closures and fnargs allocated per call.
+*/
+
+// maxPooledParams is the number of kernel parameters held by pooled kernelArgs. Launches with more parameters
+// allocate their own.
+const maxPooledParams = 32
+
+// kernelArgs holds kernel parameters in C memory, in the form cuLaunchKernel expects: argp[i] = &argv[i].
+// The driver copies the parameters when the kernel is launched, so a kernelArgs can be reused as soon as the launch
+// call returns.
+type kernelArgs struct {
+	argv, argp unsafe.Pointer
+	n          int
+}
+
+var kernelArgsPool = sync.Pool{
+	New: func() interface{} { return newKernelArgs(maxPooledParams) },
+}
+
+func newKernelArgs(n int) *kernelArgs {
+	if n == 0 {
+		n = 1 // malloc(0) may return NULL
+	}
+	a := &kernelArgs{
+		argv: C.malloc(C.size_t(n * pointerSize)),
+		argp: C.malloc(C.size_t(n * pointerSize)),
+		n:    n,
+	}
+	for i := 0; i < n; i++ {
+		*((*unsafe.Pointer)(offset(a.argp, i))) = offset(a.argv, i) // argp[i] = &argv[i]
+	}
+	// pooled kernelArgs may be dropped by the pool at any GC
+	runtime.SetFinalizer(a, (*kernelArgs).free)
+	return a
+}
+
+// getKernelArgs copies the values of the kernel parameters to a kernelArgs. It must be returned with put.
+func getKernelArgs(kernelParams []unsafe.Pointer) *kernelArgs {
+	// Since Go 1.6, a cgo argument cannot have a Go pointer to Go pointer,
+	// so we copy the argument values go C memory first.
+	var a *kernelArgs
+	if len(kernelParams) <= maxPooledParams {
+		a = kernelArgsPool.Get().(*kernelArgs)
+	} else {
+		a = newKernelArgs(len(kernelParams))
+	}
+	for i := range kernelParams {
+		*((*uint64)(offset(a.argv, i))) = *((*uint64)(kernelParams[i])) // argv[i] = *kernelParams[i]
+	}
+	return a
+}
+
+func (a *kernelArgs) params() *unsafe.Pointer { return (*unsafe.Pointer)(a.argp) }
+
+func (a *kernelArgs) put() {
+	if a.n == maxPooledParams {
+		kernelArgsPool.Put(a)
+	}
+	// larger ones are freed by the finalizer
+}
+
+func (a *kernelArgs) free() {
+	C.free(a.argv)
+	C.free(a.argp)
+}
+
+var fnargsPool = sync.Pool{
+	New: func() interface{} { return new(fnargs) },
+}
+
+// getFnargs returns a fnargs from the pool. It must be returned with release once the call has been processed.
+func getFnargs() *fnargs { return fnargsPool.Get().(*fnargs) }
+
+func (fn *fnargs) release() {
+	if fn.kargs != nil {
+		fn.kargs.put()
+	}
+	*fn = fnargs{}
+	fnargsPool.Put(fn)
+}
+
+// ctxCall is a call made through a Ctx. do is bound to run once, when the ctxCall is created, so that passing it to
+// Ctx.Do does not allocate a closure per call.
+type ctxCall struct {
+	args   fnargs
+	do     func() error
+	logger *log.Logger // the logger of the context (see WithLogger)
+}
+
+var ctxCallPool = sync.Pool{
+	New: func() interface{} {
+		c := new(ctxCall)
+		c.do = c.run
+		return c
+	},
+}
+
+func getCtxCall(fn BatchFn) *ctxCall {
+	c := ctxCallPool.Get().(*ctxCall)
+	c.args.fn = C.batchFn(fn)
+	return c
+}
+
+func (c *ctxCall) run() error {
+	// fnargs starts like fnargs_t. The call is made with a copy of it, without the host pointer, which is often Go
+	// memory (the slices copied by MemcpyHtoD and the like): cgo forbids passing Go memory that holds Go pointers, so the
+	// host pointer is passed on its own.
+	args := *(*C.fnargs_t)(unsafe.Pointer(&c.args))
+	args.ptr0 = 0
+	err := result(C.processFnHost(&args, c.args.ptr0))
+	if debugging(DebugAPI) {
+		debugTo(c.logger, DebugAPI, "Ctx call %v: %v", &c.args, err)
+	}
+	if err == nil && forceSync() {
+		err = checkSync(c.args.String(), Stream{c.args.stream}, err)
+	}
+	return err
+}
+
+// call makes the call on the thread of the context, and returns the ctxCall to the pool.
+func (ctx *Ctx) call(c *ctxCall) error {
+	c.logger = ctx.logger
+	err := ctx.Do(c.do)
+	if c.args.kargs != nil {
+		c.args.kargs.put()
+	}
+	c.args, c.logger = fnargs{}, nil
+	ctxCallPool.Put(c)
+	return err
+}
+
+func (ctx *Ctx) Memcpy(dst DevicePtr, src DevicePtr, ByteCount int64) {
+	c := getCtxCall(C.fn_memcpy)
+	c.args.devptr0, c.args.devptr1, c.args.size = C.CUdeviceptr(dst), C.CUdeviceptr(src), C.size_t(ByteCount)
+	ctx.setErr(counted(Unified, NoStream, ByteCount, ctx.call(c)))
+}
+
+func (ctx *Ctx) MemcpyHtoD(dstDevice DevicePtr, srcHost unsafe.Pointer, ByteCount int64) {
+	c := getCtxCall(C.fn_memcpyHtoD)
+	c.args.devptr0, c.args.ptr0, c.args.size = C.CUdeviceptr(dstDevice), srcHost, C.size_t(ByteCount)
+	ctx.setErr(counted(HtoD, NoStream, ByteCount, ctx.call(c)))
+}
+
+func (ctx *Ctx) MemcpyDtoH(dstHost unsafe.Pointer, srcDevice DevicePtr, ByteCount int64) {
+	c := getCtxCall(C.fn_memcpyDtoH)
+	c.args.ptr0, c.args.devptr0, c.args.size = dstHost, C.CUdeviceptr(srcDevice), C.size_t(ByteCount)
+	err := ctx.call(c)
+	if err == nil {
+		countCopy(DtoH, NoStream, ByteCount)
+		err = checkInitialized(dstHost, srcDevice, ByteCount)
+	}
+	ctx.setErr(err)
+}
+
+func (ctx *Ctx) MemcpyDtoD(dstDevice DevicePtr, srcDevice DevicePtr, ByteCount int64) {
+	c := getCtxCall(C.fn_memcpyDtoD)
+	c.args.devptr0, c.args.devptr1, c.args.size = C.CUdeviceptr(dstDevice), C.CUdeviceptr(srcDevice), C.size_t(ByteCount)
+	ctx.setErr(counted(DtoD, NoStream, ByteCount, ctx.call(c)))
+}
+
+func (ctx *Ctx) MemcpyHtoDAsync(dstDevice DevicePtr, srcHost unsafe.Pointer, ByteCount int64, hStream Stream) {
+	c := getCtxCall(C.fn_memcpyHtoDAsync)
+	c.args.devptr0, c.args.ptr0, c.args.size = C.CUdeviceptr(dstDevice), srcHost, C.size_t(ByteCount)
+	c.args.stream = hStream.c()
+	ctx.setErr(counted(HtoD, hStream, ByteCount, ctx.call(c)))
+}
+
+func (ctx *Ctx) MemcpyDtoHAsync(dstHost unsafe.Pointer, srcDevice DevicePtr, ByteCount int64, hStream Stream) {
+	c := getCtxCall(C.fn_memcpyDtoHAsync)
+	c.args.ptr0, c.args.devptr0, c.args.size = dstHost, C.CUdeviceptr(srcDevice), C.size_t(ByteCount)
+	c.args.stream = hStream.c()
+	ctx.setErr(counted(DtoH, hStream, ByteCount, ctx.call(c)))
+}
+
+func (ctx *Ctx) MemcpyDtoDAsync(dstDevice DevicePtr, srcDevice DevicePtr, ByteCount int64, hStream Stream) {
+	c := getCtxCall(C.fn_memcpyDtoDAsync)
+	c.args.devptr0, c.args.devptr1, c.args.size = C.CUdeviceptr(dstDevice), C.CUdeviceptr(srcDevice), C.size_t(ByteCount)
+	c.args.stream = hStream.c()
+	ctx.setErr(counted(DtoD, hStream, ByteCount, ctx.call(c)))
+}
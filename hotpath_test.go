@@ -0,0 +1,201 @@
+package cu
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+)
+
+func TestKernelArgsAllocs(t *testing.T) {
+	var a, b DevicePtr
+	var n int64
+	params := []unsafe.Pointer{unsafe.Pointer(&a), unsafe.Pointer(&b), unsafe.Pointer(&n)}
+	allocs := testing.AllocsPerRun(100, func() {
+		args := getKernelArgs(params)
+		args.put()
+	})
+	if allocs != 0 {
+		t.Errorf("Expected pooled kernel arguments not to allocate. Got %v allocations per call", allocs)
+	}
+
+	// more parameters than the pooled buffers hold
+	many := make([]unsafe.Pointer, maxPooledParams+1)
+	for i := range many {
+		many[i] = unsafe.Pointer(&n)
+	}
+	n = 42
+	args := getKernelArgs(many)
+	argp := (*[maxPooledParams + 1]*uint64)(args.argp)
+	for i, p := range argp {
+		if *p != 42 {
+			t.Errorf("Expected parameter %d to be 42. Got %d", i, *p)
+		}
+	}
+	args.put()
+}
+
+func TestHotPathAllocs(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	dev, cuctx, err := testSetup()
+	if err != nil {
+		if err.Error() == "NoDevice" {
+			t.Skip("NoDevice")
+		}
+		t.Fatal(err)
+	}
+	mod, err := LoadData(add32PTX)
+	if err != nil {
+		t.Fatalf("Cannot load add32: %v", err)
+	}
+	defer testTeardown(cuctx, mod)
+	fn, err := mod.Function("add32")
+	if err != nil {
+		t.Fatalf("Cannot get add32(): %v", err)
+	}
+
+	a := make([]float32, 1024)
+	size := int64(len(a) * 4)
+	var memA, memB DevicePtr
+	if memA, err = MemAlloc(size); err != nil {
+		t.Fatal(err)
+	}
+	defer MemFree(memA)
+	if memB, err = MemAlloc(size); err != nil {
+		t.Fatal(err)
+	}
+	defer MemFree(memB)
+	args := []unsafe.Pointer{unsafe.Pointer(&memA), unsafe.Pointer(&memB), unsafe.Pointer(&size)}
+
+	check := func(name string, fn func()) {
+		if allocs := testing.AllocsPerRun(100, fn); allocs != 0 {
+			t.Errorf("Expected %v not to allocate. Got %v allocations per call", name, allocs)
+		}
+	}
+
+	check("Function.Launch", func() {
+		if err := fn.Launch(1, 1, 1, len(a), 1, 1, 0, Stream{}, args); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	ctx := NewContext(dev, SchedAuto)
+	defer ctx.Close()
+	mem, err := ctx.MemAlloc(size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.MemFree(mem)
+	check("Ctx.MemcpyHtoD", func() { ctx.MemcpyHtoD(mem, unsafe.Pointer(&a[0]), size) })
+	check("Ctx.MemcpyDtoH", func() { ctx.MemcpyDtoH(unsafe.Pointer(&a[0]), mem, size) })
+	if err = ctx.Error(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBatchedAllocs(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	dev, cuctx, err := testSetup()
+	if err != nil {
+		if err.Error() == "NoDevice" {
+			t.Skip("NoDevice")
+		}
+		t.Fatal(err)
+	}
+	mod, err := LoadData(add32PTX)
+	if err != nil {
+		t.Fatalf("Cannot load add32: %v", err)
+	}
+	defer testTeardown(cuctx, mod)
+	fn, err := mod.Function("add32")
+	if err != nil {
+		t.Fatalf("Cannot get add32(): %v", err)
+	}
+
+	a := make([]float32, 1024)
+	size := int64(len(a) * 4)
+	var memA, memB DevicePtr
+	if memA, err = MemAlloc(size); err != nil {
+		t.Fatal(err)
+	}
+	defer MemFree(memA)
+	if memB, err = MemAlloc(size); err != nil {
+		t.Fatal(err)
+	}
+	defer MemFree(memB)
+	args := []unsafe.Pointer{unsafe.Pointer(&memA), unsafe.Pointer(&memB), unsafe.Pointer(&size)}
+
+	bctx := NewBatchedContext(newContext(cuctx), dev)
+	allocs := testing.AllocsPerRun(100, func() {
+		bctx.MemcpyHtoD(memA, unsafe.Pointer(&a[0]), size)
+		bctx.LaunchKernel(fn, 1, 1, 1, len(a), 1, 1, 0, Stream{}, args)
+		bctx.MemcpyDtoH(unsafe.Pointer(&a[0]), memA, size)
+		bctx.Synchronize()
+		bctx.DoWork()
+	})
+	if allocs != 0 {
+		t.Errorf("Expected batched calls not to allocate. Got %v allocations per batch", allocs)
+	}
+	if err = bctx.Errors(); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkFunctionLaunch(b *testing.B) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	_, cuctx, err := testSetup()
+	if err != nil {
+		if err.Error() == "NoDevice" {
+			b.Skip("NoDevice")
+		}
+		b.Fatal(err)
+	}
+	mod, err := LoadData(add32PTX)
+	if err != nil {
+		b.Fatalf("Cannot load add32: %v", err)
+	}
+	defer testTeardown(cuctx, mod)
+	fn, err := mod.Function("add32")
+	if err != nil {
+		b.Fatalf("Cannot get add32(): %v", err)
+	}
+
+	size := int64(1024 * 4)
+	var memA, memB DevicePtr
+	if memA, err = MemAlloc(size); err != nil {
+		b.Fatal(err)
+	}
+	defer MemFree(memA)
+	if memB, err = MemAlloc(size); err != nil {
+		b.Fatal(err)
+	}
+	defer MemFree(memB)
+	args := []unsafe.Pointer{unsafe.Pointer(&memA), unsafe.Pointer(&memB), unsafe.Pointer(&size)}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err = fn.Launch(1, 1, 1, 1024, 1, 1, 0, Stream{}, args); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+	if err = Synchronize(); err != nil {
+		b.Fatal(err)
+	}
+}
+
+func TestCtxCallHostPointer(t *testing.T) {
+	// the host pointers of copies are Go memory, which cgo refuses in the pooled arguments: the call must not panic,
+	// whether or not there is a device to copy to.
+	host := make([]float32, 16)
+	c := getCtxCall(BatchMemcpyHtoD)
+	c.args.ptr0 = unsafe.Pointer(&host[0])
+	c.run()
+	runtime.KeepAlive(host)
+}
@@ -0,0 +1,192 @@
+package cu
+
+// #include <cuda.h>
+import "C"
+import (
+	"encoding/binary"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// IPCHandleSize is the size of the IPC handles of memory and events.
+const IPCHandleSize = 64
+
+// IPCMemHandle is a handle to an allocation of device memory, which other processes open to access the memory without
+// copies. Handles are plain bytes, and may be sent by any means, such as a unix socket.
+type IPCMemHandle [IPCHandleSize]byte
+
+// IPCEventHandle is a handle to an event created with InterprocessEvent, which other processes open to synchronize with
+// the work of the process that created it.
+type IPCEventHandle [IPCHandleSize]byte
+
+// IPCMemHandle returns the handle of the allocation d belongs to. The memory must have been allocated with MemAlloc: the
+// handle refers to the whole allocation, see ExportIPCMem to share a part of it.
+func (d DevicePtr) IPCMemHandle() (h IPCMemHandle, err error) {
+	var ch C.CUipcMemHandle
+	if err = result(C.cuIpcGetMemHandle(&ch, C.CUdeviceptr(d))); err != nil {
+		return h, errors.Wrap(err, "IpcGetMemHandle")
+	}
+	copy(h[:], (*[IPCHandleSize]byte)(unsafe.Pointer(&ch))[:])
+	return h, nil
+}
+
+// OpenIPCMemHandle maps the memory of the handle, exported by another process, in the current context. The memory is
+// accessed directly, and must be closed with CloseIPCMemHandle. A process cannot open the handles it exported itself.
+func OpenIPCMemHandle(h IPCMemHandle, flags IPCMemFlags) (DevicePtr, error) {
+	var ch C.CUipcMemHandle
+	copy((*[IPCHandleSize]byte)(unsafe.Pointer(&ch))[:], h[:])
+	var d C.CUdeviceptr
+	if err := result(C.cuIpcOpenMemHandle(&d, ch, C.uint(flags))); err != nil {
+		return 0, errors.Wrap(err, "IpcOpenMemHandle")
+	}
+	return DevicePtr(d), nil
+}
+
+// CloseIPCMemHandle unmaps memory opened with OpenIPCMemHandle. The memory is freed once the exporting process frees it,
+// and all the processes that opened it have closed it.
+func CloseIPCMemHandle(d DevicePtr) error {
+	return errors.Wrap(result(C.cuIpcCloseMemHandle(C.CUdeviceptr(d))), "IpcCloseMemHandle")
+}
+
+// IPCEventHandle returns the handle of the event, which must have been created with InterprocessEvent and
+// DisableTiming.
+func (e Event) IPCEventHandle() (h IPCEventHandle, err error) {
+	var ch C.CUipcEventHandle
+	if err = result(C.cuIpcGetEventHandle(&ch, e.ev)); err != nil {
+		return h, errors.Wrap(err, "IpcGetEventHandle")
+	}
+	copy(h[:], (*[IPCHandleSize]byte)(unsafe.Pointer(&ch))[:])
+	return h, nil
+}
+
+// OpenIPCEventHandle opens the event of the handle, exported by another process, in the current context. The event may
+// be waited for, and recorded; it must be destroyed with DestroyEvent.
+func OpenIPCEventHandle(h IPCEventHandle) (Event, error) {
+	var ch C.CUipcEventHandle
+	copy((*[IPCHandleSize]byte)(unsafe.Pointer(&ch))[:], h[:])
+	var e C.CUevent
+	if err := result(C.cuIpcOpenEventHandle(&e, ch)); err != nil {
+		return Event{}, errors.Wrap(err, "IpcOpenEventHandle")
+	}
+	return makeEvent(e), nil
+}
+
+// IPCMem is a part of an allocation of device memory shared with other processes. It is serialized with MarshalBinary,
+// and sent to the processes that open it:
+//
+//	// in the exporting process
+//	mem, err := cu.ExportIPCMem(ptr, size)
+//	b, err := mem.MarshalBinary()
+//	_, err = conn.Write(b)
+//
+//	// in the importing process
+//	b := make([]byte, cu.IPCMemSize)
+//	_, err := io.ReadFull(conn, b)
+//	var mem cu.IPCMem
+//	err = mem.UnmarshalBinary(b)
+//	ptr, err := mem.Open(cu.IPCLazyEnablePeerAccess)
+//	..
+//	err = cu.CloseIPCMemHandle(ptr - cu.DevicePtr(mem.Offset))
+//
+// The exporting process must keep the memory allocated while it is used by the others.
+type IPCMem struct {
+	Handle IPCMemHandle
+	Offset int64 // the offset of the part from the start of the allocation
+	Size   int64 // the size of the part in bytes
+}
+
+// IPCMemSize is the size of a serialized IPCMem.
+const IPCMemSize = IPCHandleSize + 16
+
+// ExportIPCMem returns the IPCMem of the size bytes at d, which may be anywhere in an allocation made with MemAlloc.
+func ExportIPCMem(d DevicePtr, size int64) (IPCMem, error) {
+	allocSize, base, err := d.AddressRange()
+	if err != nil {
+		return IPCMem{}, err
+	}
+	offset := int64(d - base)
+	if size < 0 || offset+size > allocSize {
+		return IPCMem{}, errors.Errorf("Cannot export %d bytes at offset %d of an allocation of %d bytes", size, offset, allocSize)
+	}
+	h, err := base.IPCMemHandle()
+	if err != nil {
+		return IPCMem{}, err
+	}
+	return IPCMem{Handle: h, Offset: offset, Size: size}, nil
+}
+
+// Open opens the allocation of the memory (see OpenIPCMemHandle), and returns the address of the part. The allocation
+// is closed with CloseIPCMemHandle, at the address minus the offset of the part.
+func (m IPCMem) Open(flags IPCMemFlags) (DevicePtr, error) {
+	base, err := OpenIPCMemHandle(m.Handle, flags)
+	if err != nil {
+		return 0, err
+	}
+	return base + DevicePtr(m.Offset), nil
+}
+
+// MarshalBinary returns the IPCMemSize bytes of the IPCMem.
+func (m IPCMem) MarshalBinary() ([]byte, error) {
+	b := make([]byte, IPCMemSize)
+	copy(b, m.Handle[:])
+	binary.LittleEndian.PutUint64(b[IPCHandleSize:], uint64(m.Offset))
+	binary.LittleEndian.PutUint64(b[IPCHandleSize+8:], uint64(m.Size))
+	return b, nil
+}
+
+// UnmarshalBinary sets the IPCMem from bytes returned by MarshalBinary.
+func (m *IPCMem) UnmarshalBinary(b []byte) error {
+	if len(b) != IPCMemSize {
+		return errors.Errorf("Expected %d bytes of IPCMem. Got %d", IPCMemSize, len(b))
+	}
+	copy(m.Handle[:], b)
+	m.Offset = int64(binary.LittleEndian.Uint64(b[IPCHandleSize:]))
+	m.Size = int64(binary.LittleEndian.Uint64(b[IPCHandleSize+8:]))
+	return nil
+}
+
+// IPCMemHandle returns the handle of the allocation d belongs to, on the thread of the context.
+func (ctx *Ctx) IPCMemHandle(d DevicePtr) (h IPCMemHandle, err error) {
+	err = ctx.Do(func() error {
+		var err error
+		h, err = d.IPCMemHandle()
+		return err
+	})
+	return
+}
+
+// OpenIPCMemHandle maps the memory of the handle in the context (see OpenIPCMemHandle).
+func (ctx *Ctx) OpenIPCMemHandle(h IPCMemHandle, flags IPCMemFlags) (d DevicePtr, err error) {
+	err = ctx.Do(func() error {
+		var err error
+		d, err = OpenIPCMemHandle(h, flags)
+		return err
+	})
+	return
+}
+
+// CloseIPCMemHandle unmaps memory opened with OpenIPCMemHandle, on the thread of the context.
+func (ctx *Ctx) CloseIPCMemHandle(d DevicePtr) {
+	ctx.setErr(ctx.Do(func() error { return CloseIPCMemHandle(d) }))
+}
+
+// IPCEventHandle returns the handle of the event, on the thread of the context.
+func (ctx *Ctx) IPCEventHandle(e Event) (h IPCEventHandle, err error) {
+	err = ctx.Do(func() error {
+		var err error
+		h, err = e.IPCEventHandle()
+		return err
+	})
+	return
+}
+
+// OpenIPCEventHandle opens the event of the handle in the context (see OpenIPCEventHandle).
+func (ctx *Ctx) OpenIPCEventHandle(h IPCEventHandle) (e Event, err error) {
+	err = ctx.Do(func() error {
+		var err error
+		e, err = OpenIPCEventHandle(h)
+		return err
+	})
+	return
+}
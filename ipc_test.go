@@ -0,0 +1,27 @@
+package cu
+
+import "testing"
+
+func TestIPCMem_MarshalBinary(t *testing.T) {
+	m := IPCMem{Offset: 4096, Size: 1 << 33}
+	for i := range m.Handle {
+		m.Handle[i] = byte(i * 7)
+	}
+	b, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != IPCMemSize {
+		t.Fatalf("Expected %d bytes. Got %d", IPCMemSize, len(b))
+	}
+	var got IPCMem
+	if err = got.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if got != m {
+		t.Errorf("Expected %v. Got %v", m, got)
+	}
+	if err = got.UnmarshalBinary(b[:IPCHandleSize]); err == nil {
+		t.Errorf("Expected an error for a truncated IPCMem")
+	}
+}
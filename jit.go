@@ -4,6 +4,7 @@ package cu
 import "C"
 
 import (
+	"fmt"
 	"unsafe"
 )
 
@@ -128,6 +129,30 @@ func jitBooleanOption(b bool) uintptr {
 	return 0
 }
 
+// JITOptionType is the kind of a JIT option, as passed to the driver. Each JITOption is made of one or more of them.
+type JITOptionType uint32
+
+const (
+	JITOptMaxRegisters            JITOptionType = C.CU_JIT_MAX_REGISTERS
+	JITOptThreadsPerBlock         JITOptionType = C.CU_JIT_THREADS_PER_BLOCK
+	JITOptWallTime                JITOptionType = C.CU_JIT_WALL_TIME
+	JITOptInfoLogBuffer           JITOptionType = C.CU_JIT_INFO_LOG_BUFFER
+	JITOptInfoLogBufferSizeBytes  JITOptionType = C.CU_JIT_INFO_LOG_BUFFER_SIZE_BYTES
+	JITOptErrorLogBuffer          JITOptionType = C.CU_JIT_ERROR_LOG_BUFFER
+	JITOptErrorLogBufferSizeBytes JITOptionType = C.CU_JIT_ERROR_LOG_BUFFER_SIZE_BYTES
+	JITOptOptimizationLevel       JITOptionType = C.CU_JIT_OPTIMIZATION_LEVEL
+	JITOptTargetFromContext       JITOptionType = C.CU_JIT_TARGET_FROM_CUCONTEXT
+	JITOptTarget                  JITOptionType = C.CU_JIT_TARGET
+	JITOptFallbackStrategy        JITOptionType = C.CU_JIT_FALLBACK_STRATEGY
+	JITOptGenerateDebugInfo       JITOptionType = C.CU_JIT_GENERATE_DEBUG_INFO
+	JITOptLogVerbose              JITOptionType = C.CU_JIT_LOG_VERBOSE
+	JITOptGenerateLineInfo        JITOptionType = C.CU_JIT_GENERATE_LINE_INFO
+	JITOptCacheMode               JITOptionType = C.CU_JIT_CACHE_MODE
+)
+
+func (o jitoption) String() string { return fmt.Sprintf("%v=%d", JITOptionType(o.option), o.value) }
+
+// JITTargetOption is the target architecture of the JIT compilation
 type JITTargetOption uint64
 
 const (
@@ -171,6 +196,7 @@ const (
 	JITCacheCA JITCacheModeOption = C.CU_JIT_CACHE_OPTION_CA
 )
 
+// JITInputType is the type of the inputs of the JIT linker
 type JITInputType uint64
 
 const (
@@ -0,0 +1,140 @@
+package cu
+
+// #include <cuda.h>
+// #include <string.h>
+import "C"
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// marshalledArgs are kernel arguments marshalled to C memory, in the form cuLaunchKernel expects: argp[i] points to the
+// value of the i-th argument in buf, aligned as its type is.
+type marshalledArgs struct {
+	buf, argp unsafe.Pointer
+	n         int
+}
+
+// marshalArgs marshals the arguments of a kernel (see LaunchArgs). The arguments must be freed with free.
+func marshalArgs(args ...interface{}) (*marshalledArgs, error) {
+	vals := make([]reflect.Value, len(args))
+	offsets := make([]uintptr, len(args))
+	var size uintptr
+	for i, arg := range args {
+		if arg == nil {
+			return nil, errors.Errorf("Kernel argument %d is nil", i)
+		}
+		v := reflect.ValueOf(arg)
+		if err := checkArgType(v.Type()); err != nil {
+			return nil, errors.Wrapf(err, "Kernel argument %d", i)
+		}
+		t := v.Type()
+		align := uintptr(t.Align())
+		size = (size + align - 1) / align * align
+		vals[i], offsets[i] = v, size
+		size += t.Size()
+	}
+
+	n := len(args)
+	if n == 0 {
+		n = 1 // malloc(0) may return NULL
+	}
+	if size == 0 {
+		size = 1
+	}
+	a := &marshalledArgs{
+		buf:  C.calloc(1, C.size_t(size)),
+		argp: C.malloc(C.size_t(n * pointerSize)),
+		n:    len(args),
+	}
+	for i, v := range vals {
+		dst := unsafe.Pointer(uintptr(a.buf) + offsets[i])
+		// copy the value through an addressable copy of it, whose memory holds the bytes of the value
+		cp := reflect.New(v.Type())
+		cp.Elem().Set(v)
+		C.memcpy(dst, unsafe.Pointer(cp.Pointer()), C.size_t(v.Type().Size()))
+		*((*unsafe.Pointer)(offset(a.argp, i))) = dst // argp[i] = &buf[offsets[i]]
+	}
+	return a, nil
+}
+
+// checkArgType returns an error if values of the type cannot be passed to a kernel: values that are, or hold, Go
+// pointers, whose memory the device cannot access.
+func checkArgType(t reflect.Type) error {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return nil
+	case reflect.Array:
+		return checkArgType(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if err := checkArgType(t.Field(i).Type); err != nil {
+				return errors.Wrapf(err, "field %s of %v", t.Field(i).Name, t)
+			}
+		}
+		return nil
+	case reflect.Ptr, reflect.UnsafePointer:
+		return errors.Errorf("%v is a host pointer. Pass the device memory as a DevicePtr", t)
+	default:
+		return errors.Errorf("%v cannot be passed to a kernel", t)
+	}
+}
+
+func (a *marshalledArgs) params() *unsafe.Pointer { return (*unsafe.Pointer)(a.argp) }
+
+func (a *marshalledArgs) free() {
+	C.free(a.buf)
+	C.free(a.argp)
+}
+
+// LaunchArgs launches a CUDA function with the configuration, with the arguments marshalled for it, so that they need
+// not be passed as pointers to their values:
+//
+//	err := fn.LaunchArgs(cfg, stream, out, in, int32(n), float32(alpha))
+//
+// The arguments may be booleans, numbers, DevicePtrs, TexObjects and the like, or arrays and structs of them. Each is
+// passed with the size of its Go type, so it must match the type of the parameter of the kernel: an int is 64 bits,
+// and a C int is an int32. Structs are laid out by the rules of Go, which are those of C for the same types of fields
+// on 64 bit platforms. Pointers, slices and the like are refused: device memory is passed as a DevicePtr.
+//
+// Marshalling allocates: on hot paths, use Launch. The launch is timed if kernels are being timed (see TimeKernels).
+func (fn Function) LaunchArgs(cfg LaunchConfig, stream Stream, args ...interface{}) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	a, err := marshalArgs(args...)
+	if err != nil {
+		return errors.Wrap(err, "LaunchArgs")
+	}
+	defer a.free()
+
+	launch := func() error {
+		if cfg.clustered() {
+			return fn.launchExParams(cfg, nil, stream, a.params())
+		}
+		return fn.launchParams(cfg.Grid[0], cfg.Grid[1], cfg.Grid[2], cfg.Block[0], cfg.Block[1], cfg.Block[2], cfg.SharedMem, stream, a.params())
+	}
+	if timingKernels() {
+		return timeLaunch(fn, stream, launch)
+	}
+	return launch()
+}
+
+// LaunchArgsAndSync launches a CUDA function as LaunchArgs does, and waits for the stream to finish the work issued
+// to it.
+func (fn Function) LaunchArgsAndSync(cfg LaunchConfig, stream Stream, args ...interface{}) error {
+	if err := fn.LaunchArgs(cfg, stream, args...); err != nil {
+		return err
+	}
+	return stream.Synchronize()
+}
+
+// LaunchArgs launches a CUDA function with the arguments marshalled for it (see Function.LaunchArgs), on the thread of
+// the context.
+func (ctx *Ctx) LaunchArgs(fn Function, cfg LaunchConfig, stream Stream, args ...interface{}) {
+	ctx.setErr(ctx.Do(func() error { return fn.LaunchArgs(cfg, stream, args...) }))
+}
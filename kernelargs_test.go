@@ -0,0 +1,45 @@
+package cu
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalArgs(t *testing.T) {
+	assert := assert.New(t)
+	type params struct {
+		Scale float32
+		Dims  [3]int32
+		Flag  bool
+	}
+	p := params{Scale: 0.5, Dims: [3]int32{1, 2, 3}, Flag: true}
+	a, err := marshalArgs(DevicePtr(0xdead0000), int8(-1), float64(2.5), p, int32(7))
+	if !assert.Nil(err) {
+		return
+	}
+	defer a.free()
+
+	arg := func(i int) unsafe.Pointer { return *(*unsafe.Pointer)(offset(a.argp, i)) }
+	assert.Equal(DevicePtr(0xdead0000), *(*DevicePtr)(arg(0)))
+	assert.Equal(int8(-1), *(*int8)(arg(1)))
+	assert.Equal(2.5, *(*float64)(arg(2)))
+	assert.Equal(p, *(*params)(arg(3)))
+	assert.Equal(int32(7), *(*int32)(arg(4)))
+
+	// aligned as their types are
+	assert.Equal(uintptr(8), uintptr(arg(1))-uintptr(arg(0)))
+	assert.Zero(uintptr(arg(2)) % 8)
+	assert.Zero(uintptr(arg(3)) % 4)
+
+	var x int
+	_, err = marshalArgs(&x)
+	assert.NotNil(err)
+	_, err = marshalArgs([]float32{1})
+	assert.NotNil(err)
+	_, err = marshalArgs(struct{ Name string }{"a"})
+	assert.NotNil(err)
+	_, err = marshalArgs(nil)
+	assert.NotNil(err)
+}
@@ -0,0 +1,178 @@
+package kernels
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// MaxAttentionHeadDim is the largest head dimension supported by Attention.
+const MaxAttentionHeadDim = 128
+
+// attentionSource holds a fused attention kernel in the style of FlashAttention: the keys and values are streamed
+// through shared memory in tiles, and the softmax is computed online, so that the matrix of the scores is never
+// written to device memory.
+//
+// Each block processes ATT_WARPS queries of one head, one query per warp. For each tile of keys, lane j of a warp
+// computes the score of key j, and the lanes then accumulate the output dimensions lane, lane+32...
+var attentionSource = &Source{
+	Name:   "attention",
+	Dtypes: []cu.Dtype{cu.DtFloat32, cu.DtFloat64, cu.DtFloat16, cu.DtBFloat16},
+	Code: `
+#define ATT_WARPS 8
+#define ATT_TILE 16
+#define ATT_MAX_DIM 128
+
+extern "C" __global__ void attention(T* out, const T* q, const T* k, const T* v, const unsigned char* mask,
+	long long batch, long long heads, long long lq, long long lk, long long d, double scale,
+	long long o0, long long o1, long long o2, long long o3,
+	long long q0, long long q1, long long q2, long long q3,
+	long long k0, long long k1, long long k2, long long k3,
+	long long v0, long long v1, long long v2, long long v3,
+	long long m0, long long m1, long long m2, long long m3) {
+	__shared__ T ks[ATT_TILE][ATT_MAX_DIM];
+	__shared__ T vs[ATT_TILE][ATT_MAX_DIM];
+	__shared__ acc_t qs[ATT_WARPS][ATT_MAX_DIM];
+	int lane = threadIdx.x & 31;
+	int warp = threadIdx.x >> 5;
+	long long qblocks = (lq + ATT_WARPS - 1) / ATT_WARPS;
+	ROWS(blk, batch * heads * qblocks) {
+		long long b = blk / qblocks / heads;
+		long long h = (blk / qblocks) % heads;
+		long long i = (blk % qblocks) * ATT_WARPS + warp;
+		bool active = warp < ATT_WARPS && i < lq;
+		if (active) {
+			for (long long e = lane; e < d; e += 32) {
+				qs[warp][e] = ld(q, b * q0 + h * q1 + i * q2 + e * q3) * (acc_t)scale;
+			}
+		}
+		acc_t m = -INFINITY;
+		acc_t l = 0;
+		acc_t acc[ATT_MAX_DIM / 32] = {0};
+
+		for (long long j0 = 0; j0 < lk; j0 += ATT_TILE) {
+			__syncthreads();
+			for (long long t = threadIdx.x; t < ATT_TILE * d; t += blockDim.x) {
+				long long jj = t / d;
+				long long e = t % d;
+				if (j0 + jj < lk) {
+					ks[jj][e] = k[b * k0 + h * k1 + (j0 + jj) * k2 + e * k3];
+					vs[jj][e] = v[b * v0 + h * v1 + (j0 + jj) * v2 + e * v3];
+				}
+			}
+			__syncthreads();
+			if (!active) {
+				continue;
+			}
+
+			long long j = j0 + lane;
+			acc_t s = -INFINITY;
+			if (lane < ATT_TILE && j < lk && (!mask || mask[b * m0 + h * m1 + i * m2 + j * m3])) {
+				s = 0;
+				for (long long e = 0; e < d; e++) {
+					s += qs[warp][e] * ld(ks[lane], e);
+				}
+			}
+			acc_t mt = s;
+			for (int o = 16; o > 0; o >>= 1) {
+				mt = fmax(mt, __shfl_xor_sync(0xffffffff, mt, o));
+			}
+			acc_t mn = fmax(m, mt);
+			if (mn == -INFINITY) {
+				// every key so far is masked
+				continue;
+			}
+			acc_t p = s == -INFINITY ? 0 : exp(s - mn);
+			acc_t corr = exp(m - mn);
+			acc_t ps = p;
+			for (int o = 16; o > 0; o >>= 1) {
+				ps += __shfl_xor_sync(0xffffffff, ps, o);
+			}
+			l = l * corr + ps;
+			for (int r = 0; r < ATT_MAX_DIM / 32; r++) {
+				acc[r] *= corr;
+			}
+			for (int jj = 0; jj < ATT_TILE; jj++) {
+				acc_t pj = __shfl_sync(0xffffffff, p, jj);
+				if (pj == 0) {
+					continue;
+				}
+				for (int r = 0; r < ATT_MAX_DIM / 32; r++) {
+					long long e = lane + 32 * r;
+					if (e < d) {
+						acc[r] += pj * ld(vs[jj], e);
+					}
+				}
+			}
+			m = mn;
+		}
+
+		if (active) {
+			for (int r = 0; r < ATT_MAX_DIM / 32; r++) {
+				long long e = lane + 32 * r;
+				if (e < d) {
+					st(out, b * o0 + h * o1 + i * o2 + e * o3, l > 0 ? acc[r] / l : (acc_t)0);
+				}
+			}
+		}
+	}
+}
+`,
+}
+
+// attentionWarps is the number of queries processed by a block of the attention kernel (ATT_WARPS).
+const attentionWarps = 8
+
+// Attention computes scaled dot product attention, on the given stream:
+//
+//	out = softmax(scale * q × kᵀ, masked) × v
+//
+// q and out are (batch, heads, queries, headDim) tensors, and k and v are (batch, heads, keys, headDim) tensors.
+// They are read and written through their strides, so that (batch, tokens, heads, headDim) layouts can be passed as
+// transposed views. The element type is float32, float64, float16 or bfloat16; halves are accumulated in float32.
+//
+// mask is optional (its pointer may be 0). It is a byte tensor that broadcasts to (batch, heads, queries, keys), and
+// a query attends to a key only if its mask is not 0. Queries that attend to no key produce zeros.
+// If scale is 0, 1/sqrt(headDim) is used. The head dimension is at most MaxAttentionHeadDim.
+func Attention(out, q, k, v, mask cu.DeviceTensor, scale float64, stream cu.Stream) error {
+	for _, t := range []cu.DeviceTensor{out, q, k, v} {
+		if err := t.Check(); err != nil {
+			return errors.Wrap(err, "Attention")
+		}
+		if t.Dims() != 4 || t.Dtype != q.Dtype {
+			return errors.Errorf("Expected rank 4 %v tensors. Got %v of shape %v", q.Dtype, t.Dtype, t.Shape)
+		}
+	}
+	batch, heads, lq, d := q.Shape[0], q.Shape[1], q.Shape[2], q.Shape[3]
+	lk := k.Shape[2]
+	if !sameShape(out, q) || !sameShape(k, v) || k.Shape[0] != batch || k.Shape[1] != heads || k.Shape[3] != d {
+		return errors.Errorf("Mismatched shapes: out %v, q %v, k %v, v %v", out.Shape, q.Shape, k.Shape, v.Shape)
+	}
+	if d > MaxAttentionHeadDim {
+		return errors.Errorf("The head dimension %d exceeds %d", d, MaxAttentionHeadDim)
+	}
+	if scale == 0 {
+		scale = 1 / math.Sqrt(float64(d))
+	}
+
+	var maskStrides [4]int
+	if mask.Ptr != 0 {
+		if mask.Dtype != cu.DtUint8 && mask.Dtype != cu.DtBool {
+			return errors.Errorf("Expected a byte mask. Got %v", mask.Dtype)
+		}
+		b, err := mask.BroadcastTo(batch, heads, lq, lk)
+		if err != nil {
+			return errors.Wrap(err, "mask")
+		}
+		copy(maskStrides[:], b.Strides)
+	}
+
+	blocks := batch * heads * ((lq + attentionWarps - 1) / attentionWarps)
+	args := []interface{}{out.Ptr, q.Ptr, k.Ptr, v.Ptr, mask.Ptr, batch, heads, lq, lk, d, scale}
+	for _, t := range []cu.DeviceTensor{out, q, k, v} {
+		args = append(args, t.Strides[0], t.Strides[1], t.Strides[2], t.Strides[3])
+	}
+	args = append(args, maskStrides[0], maskStrides[1], maskStrides[2], maskStrides[3])
+	return launchRows(attentionSource, q.Dtype, "attention", blocks, stream, args...)
+}
@@ -0,0 +1,42 @@
+package kernels
+
+import (
+	"math"
+	"testing"
+
+	"gorgonia.org/cu"
+)
+
+func TestAttention(t *testing.T) {
+	withContext(t, func() {
+		// one head, two queries, three keys
+		pq := upload32(t, []float32{1, 0, 0, 1})
+		pk := upload32(t, []float32{1, 0, 0, 1, 0, 0})
+		pv := upload32(t, []float32{1, 2, 3, 4, 5, 6})
+		pm := uploadMask(t, []uint8{1, 1, 0})
+		pOut := upload32(t, make([]float32, 4))
+		for _, mem := range []cu.DevicePtr{pq, pk, pv, pm, pOut} {
+			defer cu.MemFree(mem)
+		}
+		q := cu.NewDeviceTensor(pq, cu.DtFloat32, 1, 1, 2, 2)
+		k := cu.NewDeviceTensor(pk, cu.DtFloat32, 1, 1, 3, 2)
+		v := cu.NewDeviceTensor(pv, cu.DtFloat32, 1, 1, 3, 2)
+		out := cu.NewDeviceTensor(pOut, cu.DtFloat32, 1, 1, 2, 2)
+		mask := cu.NewDeviceTensor(pm, cu.DtUint8, 3) // the last key is masked for every query
+
+		if err := Attention(out, q, k, v, mask, 1, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		// the scores of the first query are (1, 0), and those of the second (0, 1)
+		e := math.E
+		w0 := float32(e / (e + 1))
+		w1 := 1 - w0
+		correct := []float32{
+			w0*1 + w1*3, w0*2 + w1*4,
+			w1*1 + w0*3, w1*2 + w0*4,
+		}
+		if got := download32(t, pOut, 4); !close32(got, correct, 1e-5) {
+			t.Errorf("Expected %v. Got %v", correct, got)
+		}
+	})
+}
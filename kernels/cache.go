@@ -0,0 +1,114 @@
+package kernels
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// CacheDirEnv is the environment variable that sets the directory of the DiskCache of the Default library.
+// If it is not set, the cache is kept in the user's cache directory. Setting it to "off" disables the cache.
+const CacheDirEnv = "CU_KERNEL_CACHE"
+
+// DiskCache caches compiled kernels on disk, so that processes do not compile the same sources again.
+//
+// Entries are stored in a directory per architecture, in files named after a hash of the source, the compiler options
+// and the version of the compiler. Entries are written to a temporary file that is then renamed, so that the cache can
+// be shared by concurrent processes: readers see either no entry, or a complete one.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache creates a DiskCache in dir, creating the directory if required.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "Unable to create the kernel cache directory")
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// DefaultCacheDir returns the directory of the cache of the Default library, as set by CacheDirEnv.
+// It returns "" if the cache is disabled.
+func DefaultCacheDir() (string, error) {
+	switch dir := os.Getenv(CacheDirEnv); dir {
+	case "off":
+		return "", nil
+	case "":
+	default:
+		return dir, nil
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", errors.Wrap(err, "No user cache directory")
+	}
+	return filepath.Join(base, "gorgonia-cu", "kernels"), nil
+}
+
+// Dir returns the directory of the cache.
+func (c *DiskCache) Dir() string { return c.dir }
+
+// Get returns the entry of the code compiled for arch with the given options and compiler.
+func (c *DiskCache) Get(code, arch string, options []string, compiler string) ([]byte, bool) {
+	data, err := ioutil.ReadFile(c.path(code, arch, options, compiler))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Put stores the entry of the code compiled for arch with the given options and compiler.
+func (c *DiskCache) Put(code, arch string, options []string, compiler string, data []byte) error {
+	path := c.path(code, arch, options, compiler)
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrap(err, "Unable to create the kernel cache directory")
+	}
+	f, err := ioutil.TempFile(dir, ".tmp-")
+	if err != nil {
+		return errors.Wrap(err, "Unable to create a kernel cache entry")
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		if _, serr := os.Stat(path); serr == nil {
+			// another process stored the entry first
+			return nil
+		}
+		return errors.Wrap(err, "Unable to write a kernel cache entry")
+	}
+	return nil
+}
+
+// Clear removes all the entries of the cache.
+func (c *DiskCache) Clear() error {
+	entries, err := ioutil.ReadDir(c.dir)
+	if err != nil {
+		return errors.Wrap(err, "Unable to read the kernel cache directory")
+	}
+	for _, e := range entries {
+		if err = os.RemoveAll(filepath.Join(c.dir, e.Name())); err != nil {
+			return errors.Wrap(err, "Unable to clear the kernel cache")
+		}
+	}
+	return nil
+}
+
+func (c *DiskCache) path(code, arch string, options []string, compiler string) string {
+	h := sha256.New()
+	for _, s := range []string{compiler, strings.Join(options, "\x00"), code} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return filepath.Join(c.dir, arch, hex.EncodeToString(h.Sum(nil)))
+}
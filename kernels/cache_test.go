@@ -0,0 +1,63 @@
+package kernels
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiskCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kernels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c, err := NewDiskCache(filepath.Join(dir, "cache"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := []string{"--gpu-architecture=compute_70"}
+	if _, ok := c.Get("code", "compute_70", opts, "nvrtc-11.0"); ok {
+		t.Fatal("Expected an empty cache")
+	}
+	if err = c.Put("code", "compute_70", opts, "nvrtc-11.0", []byte("ptx")); err != nil {
+		t.Fatal(err)
+	}
+	if data, ok := c.Get("code", "compute_70", opts, "nvrtc-11.0"); !ok || string(data) != "ptx" {
+		t.Errorf("Expected the cached entry. Got %q", data)
+	}
+	for _, key := range [][2]string{{"compute_80", "nvrtc-11.0"}, {"compute_70", "nvrtc-12.0"}} {
+		if _, ok := c.Get("code", key[0], opts, key[1]); ok {
+			t.Errorf("Expected no entry for %v", key)
+		}
+	}
+
+	if err = c.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get("code", "compute_70", opts, "nvrtc-11.0"); ok {
+		t.Error("Expected the cache to be cleared")
+	}
+}
+
+func TestDefaultCacheDir(t *testing.T) {
+	old, set := os.LookupEnv(CacheDirEnv)
+	defer func() {
+		if set {
+			os.Setenv(CacheDirEnv, old)
+		} else {
+			os.Unsetenv(CacheDirEnv)
+		}
+	}()
+
+	os.Setenv(CacheDirEnv, "off")
+	if dir, err := DefaultCacheDir(); err != nil || dir != "" {
+		t.Errorf("Expected the cache to be disabled. Got %q, %v", dir, err)
+	}
+	os.Setenv(CacheDirEnv, "/tmp/kernels")
+	if dir, _ := DefaultCacheDir(); dir != "/tmp/kernels" {
+		t.Errorf("Expected the directory of the environment. Got %q", dir)
+	}
+}
@@ -0,0 +1,163 @@
+package kernels
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// ComplexOp is an elementwise unary operation on complex numbers.
+type ComplexOp int
+
+const (
+	OpConj  ComplexOp = iota // the conjugate, complex
+	OpAbs                    // the modulus, real
+	OpPhase                  // the argument, real, in [-π, π]
+)
+
+var complexOpNames = [...]string{
+	OpConj:  "Conj",
+	OpAbs:   "Abs",
+	OpPhase: "Phase",
+}
+
+func (op ComplexOp) String() string {
+	if op >= 0 && int(op) < len(complexOpNames) {
+		return complexOpNames[op]
+	}
+	return fmt.Sprintf("UnknownComplexOp:%d", int(op))
+}
+
+// complexSource holds the elementwise kernels of complex numbers. The binary operations take the codes of BinaryOp.
+// Divisions use Smith's algorithm, which does not overflow where |y|² would.
+var complexSource = &Source{
+	Name:   "complex",
+	Dtypes: []cu.Dtype{cu.DtComplex64, cu.DtComplex128},
+	Code: `
+__device__ __forceinline__ T cbinop(long long op, T x, T y) {
+	switch (op) {
+	case 0: return cmake(x.x + y.x, x.y + y.y);
+	case 1: return cmake(x.x - y.x, x.y - y.y);
+	case 2: return cmake(x.x * y.x - x.y * y.y, x.x * y.y + x.y * y.x);
+	case 3:
+		if (fabs(y.x) >= fabs(y.y)) {
+			real_t r = y.y / y.x, d = y.x + y.y * r;
+			return cmake((x.x + x.y * r) / d, (x.y - x.x * r) / d);
+		} else {
+			real_t r = y.x / y.y, d = y.x * r + y.y;
+			return cmake((x.x * r + x.y) / d, (x.y * r - x.x) / d);
+		}
+	}
+	return cmake(0, 0);
+}
+
+__device__ __forceinline__ real_t creal_op(long long op, T x) {
+	return op == 1 ? hypot(x.x, x.y) : atan2(x.y, x.x);
+}
+
+extern "C" __global__ void complex_binary_flat(T* out, const T* a, const T* b, long long n, long long op) {
+	GRID_STRIDE(i, n) {
+		st(out, i, cbinop(op, ld(a, i), ld(b, i)));
+	}
+}
+
+extern "C" __global__ void complex_binary_nd(T* out, const T* a, const T* b, long long n, long long op,
+	long long s1, long long s2, long long s3,
+	long long o0, long long o1, long long o2, long long o3,
+	long long a0, long long a1, long long a2, long long a3,
+	long long b0, long long b1, long long b2, long long b3) {
+	GRID_STRIDE(i, n) {
+		T x = ld(a, nd_offset(i, s1, s2, s3, a0, a1, a2, a3));
+		T y = ld(b, nd_offset(i, s1, s2, s3, b0, b1, b2, b3));
+		st(out, nd_offset(i, s1, s2, s3, o0, o1, o2, o3), cbinop(op, x, y));
+	}
+}
+
+extern "C" __global__ void complex_conj_flat(T* out, const T* a, long long n) {
+	GRID_STRIDE(i, n) {
+		T x = ld(a, i);
+		st(out, i, cmake(x.x, -x.y));
+	}
+}
+
+extern "C" __global__ void complex_conj_nd(T* out, const T* a, long long n,
+	long long s1, long long s2, long long s3,
+	long long o0, long long o1, long long o2, long long o3,
+	long long a0, long long a1, long long a2, long long a3) {
+	GRID_STRIDE(i, n) {
+		T x = ld(a, nd_offset(i, s1, s2, s3, a0, a1, a2, a3));
+		st(out, nd_offset(i, s1, s2, s3, o0, o1, o2, o3), cmake(x.x, -x.y));
+	}
+}
+
+extern "C" __global__ void complex_real_flat(real_t* out, const T* a, long long n, long long op) {
+	GRID_STRIDE(i, n) {
+		out[i] = creal_op(op, ld(a, i));
+	}
+}
+
+extern "C" __global__ void complex_real_nd(real_t* out, const T* a, long long n, long long op,
+	long long s1, long long s2, long long s3,
+	long long o0, long long o1, long long o2, long long o3,
+	long long a0, long long a1, long long a2, long long a3) {
+	GRID_STRIDE(i, n) {
+		out[nd_offset(i, s1, s2, s3, o0, o1, o2, o3)] = creal_op(op, ld(a, nd_offset(i, s1, s2, s3, a0, a1, a2, a3)));
+	}
+}
+`,
+}
+
+// realOf returns the type of the real and imaginary parts of a complex type.
+func realOf(dt cu.Dtype) cu.Dtype {
+	switch dt {
+	case cu.DtComplex64:
+		return cu.DtFloat32
+	case cu.DtComplex128:
+		return cu.DtFloat64
+	}
+	return cu.DtInvalid
+}
+
+// complexBinary is Binary for complex types, which support OpAdd, OpSub, OpMul and OpDiv.
+func complexBinary(op BinaryOp, out, a, b cu.DeviceTensor, stream cu.Stream) error {
+	switch op {
+	case OpAdd, OpSub, OpMul, OpDiv:
+	default:
+		return errors.Errorf("%v is not supported for %v", op, out.Dtype)
+	}
+	l, err := makeLayout(out, a, b)
+	if err != nil {
+		return errors.Wrapf(err, "%v", op)
+	}
+	return launchStrided(complexSource, out.Dtype, "complex_binary_nd", "complex_binary_flat", l, []cu.DeviceTensor{out, a, b}, stream, int(op))
+}
+
+// ComplexUnary computes out = op(a) elementwise, on the given stream, where a is complex64 or complex128. out has the
+// type of a for OpConj, and the type of its parts (float32 or float64) for OpAbs and OpPhase.
+//
+// a is broadcast to the shape of out, and either may be a strided view, as with Binary. Complex numbers are also
+// supported by Binary, for OpAdd, OpSub, OpMul and OpDiv.
+func ComplexUnary(op ComplexOp, out, a cu.DeviceTensor, stream cu.Stream) error {
+	if op < 0 || int(op) >= len(complexOpNames) {
+		return errors.Errorf("Unknown complex op %d", int(op))
+	}
+	if !a.Dtype.IsComplex() {
+		return errors.Errorf("%v: %v is not a complex type", op, a.Dtype)
+	}
+	want := a.Dtype
+	if op != OpConj {
+		want = realOf(a.Dtype)
+	}
+	if out.Dtype != want {
+		return errors.Errorf("%v of %v: expected an output of %v. Got %v", op, a.Dtype, want, out.Dtype)
+	}
+	l, err := makeLayout(out, a)
+	if err != nil {
+		return errors.Wrapf(err, "%v", op)
+	}
+	if op == OpConj {
+		return launchStrided(complexSource, a.Dtype, "complex_conj_nd", "complex_conj_flat", l, []cu.DeviceTensor{out, a}, stream)
+	}
+	return launchStrided(complexSource, a.Dtype, "complex_real_nd", "complex_real_flat", l, []cu.DeviceTensor{out, a}, stream, int(op))
+}
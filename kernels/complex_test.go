@@ -0,0 +1,87 @@
+package kernels
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"gorgonia.org/cu"
+)
+
+func TestComplexUnary_Types(t *testing.T) {
+	c := cu.NewDeviceTensor(0x1000, cu.DtComplex64, 4)
+	if err := ComplexUnary(OpAbs, c, c, cu.NoStream); err == nil {
+		t.Error("Expected an error for a complex output of Abs")
+	}
+	f := cu.NewDeviceTensor(0x2000, cu.DtFloat32, 4)
+	if err := ComplexUnary(OpConj, f, f, cu.NoStream); err == nil {
+		t.Error("Expected an error for a real input")
+	}
+	if err := Binary(OpMax, c, c, c, cu.NoStream); err == nil {
+		t.Error("Expected an error for the maximum of complex numbers")
+	}
+}
+
+func TestComplex(t *testing.T) {
+	withContext(t, func() {
+		a := []complex64{1 + 2i, -3 + 4i, 0 - 1i, 1e20 + 1e20i}
+		b := []complex64{2 - 1i, 1 + 1i, 2i, 1e20 - 1e20i}
+		flat := func(cs []complex64) []float32 {
+			retVal := make([]float32, 0, 2*len(cs))
+			for _, c := range cs {
+				retVal = append(retVal, real(c), imag(c))
+			}
+			return retVal
+		}
+		pa, pb, pout := upload32(t, flat(a)), upload32(t, flat(b)), upload32(t, make([]float32, 8))
+		defer cu.MemFree(pa)
+		defer cu.MemFree(pb)
+		defer cu.MemFree(pout)
+		ta, tb := cu.NewDeviceTensor(pa, cu.DtComplex64, 4), cu.NewDeviceTensor(pb, cu.DtComplex64, 4)
+		out := cu.NewDeviceTensor(pout, cu.DtComplex64, 4)
+
+		for _, op := range []BinaryOp{OpAdd, OpMul, OpDiv} {
+			if err := Binary(op, out, ta, tb, cu.NoStream); err != nil {
+				t.Fatal(err)
+			}
+			got := download32(t, pout, 8)
+			for i := range a {
+				var want complex128
+				x, y := complex128(a[i]), complex128(b[i])
+				switch op {
+				case OpAdd:
+					want = x + y
+				case OpMul:
+					want = x * y
+				case OpDiv:
+					want = x / y
+				}
+				g := complex(float64(got[2*i]), float64(got[2*i+1]))
+				if cmplx.Abs(g-want) > 1e-5*cmplx.Abs(want) {
+					t.Errorf("%v of %v and %v: expected %v. Got %v", op, a[i], b[i], want, g)
+				}
+			}
+		}
+
+		if err := ComplexUnary(OpConj, out, ta, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := download32(t, pout, 8), flat([]complex64{1 - 2i, -3 - 4i, 0 + 1i, 1e20 - 1e20i}); !close32(got, want, 0) {
+			t.Errorf("Conj: expected %v. Got %v", want, got)
+		}
+
+		parts := cu.NewDeviceTensor(pout, cu.DtFloat32, 4)
+		if err := ComplexUnary(OpAbs, parts, ta, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := download32(t, pout, 4), []float32{float32(math.Sqrt(5)), 5, 1, float32(math.Sqrt2 * 1e20)}; !close32(got[:3], want[:3], 1e-6) || math.Abs(float64(got[3]/want[3]-1)) > 1e-6 {
+			t.Errorf("Abs: expected %v. Got %v", want, got)
+		}
+		if err := ComplexUnary(OpPhase, parts, ta, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := download32(t, pout, 4), []float32{float32(math.Atan2(2, 1)), float32(math.Atan2(4, -3)), -math.Pi / 2, math.Pi / 4}; !close32(got, want, 1e-6) {
+			t.Errorf("Phase: expected %v. Got %v", want, got)
+		}
+	})
+}
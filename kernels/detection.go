@@ -0,0 +1,318 @@
+package kernels
+
+import (
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// detectionSource holds the kernels that post-process the outputs of object detectors. Boxes are given by their
+// corners (x1, y1, x2, y2), and indices are int32, with -1 marking an empty slot.
+var detectionSource = &Source{
+	Name:   "detection",
+	Dtypes: []cu.Dtype{cu.DtFloat32, cu.DtFloat64},
+	Code: `
+// decode_boxes applies the deltas (dx, dy, dw, dh) of n images to the a anchors, as Faster R-CNN and SSD do.
+extern "C" __global__ void decode_boxes(T* out, const T* deltas, const T* anchors, long long n, long long a,
+	double wx, double wy, double ww, double wh, double clip) {
+	GRID_STRIDE(i, n * a) {
+		const T* anchor = anchors + (i % a) * 4;
+		const T* d = deltas + i * 4;
+		double w = (double)ld(anchor, 2) - ld(anchor, 0);
+		double h = (double)ld(anchor, 3) - ld(anchor, 1);
+		double cx = ld(anchor, 0) + 0.5 * w + ld(d, 0) / wx * w;
+		double cy = ld(anchor, 1) + 0.5 * h + ld(d, 1) / wy * h;
+		w *= exp(fmin(ld(d, 2) / ww, clip));
+		h *= exp(fmin(ld(d, 3) / wh, clip));
+		st(out, i * 4 + 0, (acc_t)(cx - 0.5 * w));
+		st(out, i * 4 + 1, (acc_t)(cy - 0.5 * h));
+		st(out, i * 4 + 2, (acc_t)(cx + 0.5 * w));
+		st(out, i * 4 + 3, (acc_t)(cy + 0.5 * h));
+	}
+}
+
+extern "C" __global__ void topk_init(T* scores, int* indices, int* counts, long long n, long long k) {
+	GRID_STRIDE(i, n * k) {
+		st(scores, i, 0);
+		indices[i] = -1;
+		if (i < n) {
+			counts[i] = 0;
+		}
+	}
+}
+
+// topk_rank ranks each score above the threshold among the a scores of its row, and writes it to its slot if its
+// rank is below k. Ties are ranked by index. It costs O(a) per score, which suits the few thousand scores of a row.
+extern "C" __global__ void topk_rank(T* scores, int* indices, int* counts, const T* x, long long n, long long a, long long k, double threshold) {
+	GRID_STRIDE(i, n * a) {
+		acc_t v = ld(x, i);
+		if (!(v > threshold)) {
+			continue;
+		}
+		const T* row = x + (i / a) * a;
+		long long j = i % a;
+		long long rank = 0;
+		for (long long m = 0; m < a && rank < k; m++) {
+			acc_t u = ld(row, m);
+			rank += u > v || (u == v && m < j);
+		}
+		if (rank < k) {
+			long long slot = (i / a) * k + rank;
+			st(scores, slot, v);
+			indices[slot] = (int)j;
+			atomicAdd(&counts[i / a], 1);
+		}
+	}
+}
+
+__device__ __forceinline__ double iou(const T* p, const T* q) {
+	double w = fmin((double)ld(p, 2), (double)ld(q, 2)) - fmax((double)ld(p, 0), (double)ld(q, 0));
+	double h = fmin((double)ld(p, 3), (double)ld(q, 3)) - fmax((double)ld(p, 1), (double)ld(q, 1));
+	if (w <= 0 || h <= 0) {
+		return 0;
+	}
+	double inter = w * h;
+	double areaP = ((double)ld(p, 2) - ld(p, 0)) * ((double)ld(p, 3) - ld(p, 1));
+	double areaQ = ((double)ld(q, 2) - ld(q, 0)) * ((double)ld(q, 3) - ld(q, 1));
+	return inter / (areaP + areaQ - inter);
+}
+
+// nms_mask computes, for each of the k candidates of each image, the bits of the later candidates that it suppresses.
+// The bits of candidate i are words words of 64 bits at mask[(image*k + i) * words].
+extern "C" __global__ void nms_mask(unsigned long long* mask, const T* boxes, const int* order, long long n, long long a, long long k, long long words, double threshold) {
+	GRID_STRIDE(t, n * k * words) {
+		long long w = t % words;
+		long long i = (t / words) % k;
+		long long img = t / (words * k);
+		const int* o = order + img * k;
+		unsigned long long bits = 0;
+		if (o[i] >= 0) {
+			const T* p = boxes + (img * a + o[i]) * 4;
+			long long end = min((w + 1) * 64, k);
+			for (long long j = max(w * 64, i + 1); j < end; j++) {
+				if (o[j] >= 0 && iou(p, boxes + (img * a + o[j]) * 4) > threshold) {
+					bits |= 1ULL << (j - w * 64);
+				}
+			}
+		}
+		mask[t] = bits;
+	}
+}
+
+// nms_reduce walks the candidates of each image in order, keeping the candidates that have not been suppressed by a
+// candidate kept before them. Each image is processed by one block. removed holds words words per image.
+extern "C" __global__ void nms_reduce(int* keep, int* kept, const int* order, const unsigned long long* mask, unsigned long long* removed,
+	long long n, long long k, long long m, long long words) {
+	ROWS(img, n) {
+		volatile unsigned long long* rm = removed + img * words;
+		const int* o = order + img * k;
+		BLOCK_STRIDE(w, words) {
+			rm[w] = 0;
+		}
+		BLOCK_STRIDE(j, m) {
+			keep[img * m + j] = -1;
+		}
+		__syncthreads();
+		long long count = 0;
+		for (long long i = 0; i < k && count < m && o[i] >= 0; i++) {
+			if ((rm[i / 64] >> (i % 64)) & 1) {
+				continue;
+			}
+			if (threadIdx.x == 0) {
+				keep[img * m + count] = o[i];
+			}
+			count++;
+			__syncthreads();
+			BLOCK_STRIDE(w, words) {
+				rm[w] |= mask[(img * k + i) * words + w];
+			}
+			__syncthreads();
+		}
+		if (threadIdx.x == 0) {
+			kept[img] = (int)count;
+		}
+	}
+}
+
+extern "C" __global__ void gather(T* out, const T* x, const int* indices, long long n, long long a, long long m, long long d) {
+	GRID_STRIDE(i, n * m * d) {
+		long long row = i / d;
+		int idx = indices[row];
+		acc_t v = 0;
+		if (idx >= 0 && idx < a) {
+			v = ld(x, ((row / m) * a + idx) * d + i % d);
+		}
+		st(out, i, v);
+	}
+}
+`,
+}
+
+// BoxWeights are the weights the deltas of the boxes are divided by before being applied to the anchors.
+// Faster R-CNN uses (1, 1, 1, 1), and SSD the reciprocals of its variances: (10, 10, 5, 5).
+type BoxWeights struct {
+	X, Y, W, H float64
+}
+
+// boxClip bounds the scaling of the width and height of the anchors when decoding boxes, so that exp does not overflow.
+const boxClip = 4.135166556742356 // log(1000/16)
+
+// DecodeBoxes applies the regression deltas of a batch of images to anchors, on the given stream.
+//
+// anchors is (A, 4), and holds the corners (x1, y1, x2, y2) of each anchor. deltas is (N, A, 4), and holds the deltas
+// (dx, dy, dw, dh) predicted for each anchor. The decoded corners are written to out, of the same shape as deltas.
+// All the tensors must be contiguous.
+func DecodeBoxes(out, deltas, anchors cu.DeviceTensor, weights BoxWeights, stream cu.Stream) error {
+	if err := checkBoxes(anchors, 2); err != nil {
+		return errors.Wrap(err, "anchors")
+	}
+	if err := checkBoxes(deltas, 3); err != nil {
+		return errors.Wrap(err, "deltas")
+	}
+	if err := checkBoxes(out, 3); err != nil {
+		return errors.Wrap(err, "out")
+	}
+	n, a := deltas.Shape[0], deltas.Shape[1]
+	if anchors.Shape[0] != a || !sameShape(out, deltas) || out.Dtype != deltas.Dtype || anchors.Dtype != deltas.Dtype {
+		return errors.Errorf("Cannot decode deltas %v %v with anchors %v %v into %v %v", deltas.Shape, deltas.Dtype, anchors.Shape, anchors.Dtype, out.Shape, out.Dtype)
+	}
+	if weights.X == 0 || weights.Y == 0 || weights.W == 0 || weights.H == 0 {
+		return errors.Errorf("The box weights cannot be 0. Got %+v", weights)
+	}
+	return launch(detectionSource, out.Dtype, "decode_boxes", n*a, stream, out.Ptr, deltas.Ptr, anchors.Ptr, n, a, weights.X, weights.Y, weights.W, weights.H, boxClip)
+}
+
+// TopK selects the k highest scores above threshold in each row of x, on the given stream.
+//
+// x is (N, A). The selected scores are written in decreasing order to scores (N, k), and their indices in the row to
+// indices (N, k), an int32 tensor. The unused slots hold a score of 0 and an index of -1. counts (an int32 vector of N)
+// receives the number of scores selected in each row.
+func TopK(scores, indices, counts, x cu.DeviceTensor, k int, threshold float64, stream cu.Stream) error {
+	if x.Dims() != 2 || !x.IsContiguous() {
+		return errors.Errorf("Expected the scores to be a contiguous matrix. Got shape %v, strides %v", x.Shape, x.Strides)
+	}
+	n, a := x.Shape[0], x.Shape[1]
+	if k < 1 {
+		return errors.Errorf("Cannot select the top %d scores", k)
+	}
+	if err := checkIndexed(scores, indices, x.Dtype, n, k); err != nil {
+		return errors.Wrap(err, "TopK")
+	}
+	if err := checkInt32(counts, n); err != nil {
+		return errors.Wrap(err, "counts")
+	}
+	if err := launch(detectionSource, x.Dtype, "topk_init", n*k, stream, scores.Ptr, indices.Ptr, counts.Ptr, n, k); err != nil {
+		return err
+	}
+	return launch(detectionSource, x.Dtype, "topk_rank", n*a, stream, scores.Ptr, indices.Ptr, counts.Ptr, x.Ptr, n, a, k, threshold)
+}
+
+// NMSWorkspaceSize returns the size (in bytes) of the workspace NMS requires for n images of k candidates.
+func NMSWorkspaceSize(n, k int) int64 {
+	words := int64(k+63) / 64
+	return int64(n) * (int64(k) + 1) * words * 8
+}
+
+// NMS performs greedy non-maximum suppression, on the given stream. A candidate is suppressed if its intersection
+// over union with a candidate of higher score that is kept exceeds iouThreshold.
+//
+// boxes is (N, A, 4), and holds the corners of the boxes of each image. order is an int32 (N, K) tensor of indices into
+// the boxes of each image, sorted by decreasing score, as written by TopK. Negative indices end the candidates.
+// The indices of the kept boxes are written in order to keep, an int32 (N, M) tensor, in which unused slots hold -1.
+// At most M boxes are kept per image. kept (an int32 vector of N) receives the number of boxes kept.
+//
+// workspace must hold at least NMSWorkspaceSize(N, K) bytes.
+func NMS(keep, kept, boxes, order cu.DeviceTensor, iouThreshold float64, workspace cu.DevicePtr, stream cu.Stream) error {
+	if err := checkBoxes(boxes, 3); err != nil {
+		return errors.Wrap(err, "boxes")
+	}
+	n, a := boxes.Shape[0], boxes.Shape[1]
+	if order.Dims() != 2 || order.Shape[0] != n {
+		return errors.Errorf("Expected the order to be of shape (%d, K). Got %v", n, order.Shape)
+	}
+	k := order.Shape[1]
+	if err := checkInt32(order, n*k); err != nil {
+		return errors.Wrap(err, "order")
+	}
+	if keep.Dims() != 2 || keep.Shape[0] != n {
+		return errors.Errorf("Expected keep to be of shape (%d, M). Got %v", n, keep.Shape)
+	}
+	m := keep.Shape[1]
+	if err := checkInt32(keep, n*m); err != nil {
+		return errors.Wrap(err, "keep")
+	}
+	if err := checkInt32(kept, n); err != nil {
+		return errors.Wrap(err, "kept")
+	}
+
+	words := (k + 63) / 64
+	mask := workspace
+	removed := workspace + cu.DevicePtr(n*k*words*8)
+	if err := launch(detectionSource, boxes.Dtype, "nms_mask", n*k*words, stream, mask, boxes.Ptr, order.Ptr, n, a, k, words, iouThreshold); err != nil {
+		return err
+	}
+	return launchRows(detectionSource, boxes.Dtype, "nms_reduce", n, stream, keep.Ptr, kept.Ptr, order.Ptr, mask, removed, n, k, m, words)
+}
+
+// Gather gathers rows of x by index, on the given stream: out[i, j] = x[i, indices[i, j]], or zeros if the index is
+// negative. It gathers the boxes and scores of the detections selected by TopK and NMS.
+//
+// x is (N, A) or (N, A, D), indices is an int32 (N, M) tensor, and out is (N, M) or (N, M, D). x and out must be contiguous.
+func Gather(out, x, indices cu.DeviceTensor, stream cu.Stream) error {
+	if (x.Dims() != 2 && x.Dims() != 3) || !x.IsContiguous() {
+		return errors.Errorf("Expected x to be a contiguous tensor of rank 2 or 3. Got shape %v, strides %v", x.Shape, x.Strides)
+	}
+	n, a, d := x.Shape[0], x.Shape[1], 1
+	if x.Dims() == 3 {
+		d = x.Shape[2]
+	}
+	if indices.Dims() != 2 || indices.Shape[0] != n {
+		return errors.Errorf("Expected the indices to be of shape (%d, M). Got %v", n, indices.Shape)
+	}
+	m := indices.Shape[1]
+	if err := checkInt32(indices, n*m); err != nil {
+		return errors.Wrap(err, "indices")
+	}
+	if out.Dims() != x.Dims() || !out.IsContiguous() || out.Len() != n*m*d || out.Dtype != x.Dtype {
+		return errors.Errorf("Expected out to be a contiguous %v tensor of %d×%d×%d elements. Got shape %v, strides %v, %v", x.Dtype, n, m, d, out.Shape, out.Strides, out.Dtype)
+	}
+	return launch(detectionSource, x.Dtype, "gather", n*m*d, stream, out.Ptr, x.Ptr, indices.Ptr, n, a, m, d)
+}
+
+// checkBoxes checks that t is a contiguous tensor of the given rank, whose last dimension holds the 4 corners of boxes.
+func checkBoxes(t cu.DeviceTensor, rank int) error {
+	if t.Dims() != rank || t.Shape[rank-1] != 4 || !t.IsContiguous() {
+		return errors.Errorf("Expected a contiguous tensor of rank %d of boxes. Got shape %v, strides %v", rank, t.Shape, t.Strides)
+	}
+	return nil
+}
+
+// checkIndexed checks that values and indices are contiguous (n, k) tensors, of type dt and int32 respectively.
+func checkIndexed(values, indices cu.DeviceTensor, dt cu.Dtype, n, k int) error {
+	if values.Dims() != 2 || values.Shape[0] != n || values.Shape[1] != k || !values.IsContiguous() || values.Dtype != dt {
+		return errors.Errorf("Expected the values to be a contiguous %v tensor of shape (%d, %d). Got %v %v", dt, n, k, values.Shape, values.Dtype)
+	}
+	if !sameShape(indices, values) {
+		return errors.Errorf("Expected the indices to be of shape (%d, %d). Got %v", n, k, indices.Shape)
+	}
+	return checkInt32(indices, n*k)
+}
+
+// checkInt32 checks that t is a contiguous int32 tensor of n elements.
+func checkInt32(t cu.DeviceTensor, n int) error {
+	if t.Dtype != cu.DtInt32 || t.Len() != n || !t.IsContiguous() {
+		return errors.Errorf("Expected a contiguous int32 tensor of %d elements. Got %v of shape %v, strides %v", n, t.Dtype, t.Shape, t.Strides)
+	}
+	return nil
+}
+
+func sameShape(a, b cu.DeviceTensor) bool {
+	if len(a.Shape) != len(b.Shape) {
+		return false
+	}
+	for i := range a.Shape {
+		if a.Shape[i] != b.Shape[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package kernels
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"gorgonia.org/cu"
+)
+
+func downloadInt32(t *testing.T, mem cu.DevicePtr, n int) []int32 {
+	retVal := make([]int32, n)
+	if err := cu.Synchronize(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cu.MemcpyDtoH(unsafe.Pointer(&retVal[0]), mem, int64(n*4)); err != nil {
+		t.Fatal(err)
+	}
+	return retVal
+}
+
+func TestDetection(t *testing.T) {
+	withContext(t, func() {
+		// one image with 4 boxes: box 1 overlaps box 0 heavily, box 3 is below the threshold
+		boxes := []float32{
+			0, 0, 10, 10,
+			1, 1, 11, 11,
+			20, 20, 30, 30,
+			40, 40, 50, 50,
+		}
+		scores := []float32{0.8, 0.9, 0.7, 0.1}
+		pb := upload32(t, boxes)
+		ps := upload32(t, scores)
+		pTopScores := upload32(t, make([]float32, 3))
+		pOrder := upload32(t, make([]float32, 3))
+		pCount := upload32(t, make([]float32, 1))
+		pKeep := upload32(t, make([]float32, 2))
+		pKept := upload32(t, make([]float32, 1))
+		pOut := upload32(t, make([]float32, 8))
+		ws, err := cu.MemAlloc(NMSWorkspaceSize(1, 3))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, mem := range []cu.DevicePtr{pb, ps, pTopScores, pOrder, pCount, pKeep, pKept, pOut, ws} {
+			defer cu.MemFree(mem)
+		}
+
+		x := cu.NewDeviceTensor(ps, cu.DtFloat32, 1, 4)
+		topScores := cu.NewDeviceTensor(pTopScores, cu.DtFloat32, 1, 3)
+		order := cu.NewDeviceTensor(pOrder, cu.DtInt32, 1, 3)
+		count := cu.NewDeviceTensor(pCount, cu.DtInt32, 1)
+		if err := TopK(topScores, order, count, x, 3, 0.5, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		if got := downloadInt32(t, pOrder, 3); !reflect.DeepEqual(got, []int32{1, 0, 2}) {
+			t.Errorf("TopK: expected the order [1 0 2]. Got %v", got)
+		}
+		if got := downloadInt32(t, pCount, 1); got[0] != 3 {
+			t.Errorf("TopK: expected 3 scores above the threshold. Got %d", got[0])
+		}
+
+		b := cu.NewDeviceTensor(pb, cu.DtFloat32, 1, 4, 4)
+		keep := cu.NewDeviceTensor(pKeep, cu.DtInt32, 1, 2)
+		kept := cu.NewDeviceTensor(pKept, cu.DtInt32, 1)
+		if err := NMS(keep, kept, b, order, 0.5, ws, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		if got := downloadInt32(t, pKeep, 2); !reflect.DeepEqual(got, []int32{1, 2}) {
+			t.Errorf("NMS: expected to keep [1 2]. Got %v", got)
+		}
+
+		out := cu.NewDeviceTensor(pOut, cu.DtFloat32, 1, 2, 4)
+		if err := Gather(out, b, keep, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		correct := []float32{1, 1, 11, 11, 20, 20, 30, 30}
+		if got := download32(t, pOut, 8); !close32(got, correct, 0) {
+			t.Errorf("Gather: expected %v. Got %v", correct, got)
+		}
+
+		// zero deltas decode to the anchors
+		pDeltas := upload32(t, make([]float32, 8))
+		defer cu.MemFree(pDeltas)
+		anchors := cu.NewDeviceTensor(pb, cu.DtFloat32, 2, 4)
+		deltas := cu.NewDeviceTensor(pDeltas, cu.DtFloat32, 1, 2, 4)
+		if err := DecodeBoxes(out, deltas, anchors, BoxWeights{1, 1, 1, 1}, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		if got := download32(t, pOut, 8); !close32(got, boxes[:8], 1e-5) {
+			t.Errorf("DecodeBoxes: expected %v. Got %v", boxes[:8], got)
+		}
+	})
+}
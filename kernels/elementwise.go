@@ -0,0 +1,99 @@
+package kernels
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// BinaryOp is an elementwise binary operation.
+type BinaryOp int
+
+const (
+	OpAdd BinaryOp = iota // a + b
+	OpSub                 // a - b
+	OpMul                 // a * b
+	OpDiv                 // a / b
+	OpMax                 // max(a, b), NaN if either is NaN
+	OpMin                 // min(a, b), NaN if either is NaN
+	OpPow                 // a to the power of b
+)
+
+var binaryOpNames = [...]string{
+	OpAdd: "Add",
+	OpSub: "Sub",
+	OpMul: "Mul",
+	OpDiv: "Div",
+	OpMax: "Max",
+	OpMin: "Min",
+	OpPow: "Pow",
+}
+
+func (op BinaryOp) String() string {
+	if op >= 0 && int(op) < len(binaryOpNames) {
+		return binaryOpNames[op]
+	}
+	return fmt.Sprintf("UnknownBinaryOp:%d", int(op))
+}
+
+var elementwiseSource = &Source{
+	Name:   "elementwise",
+	Dtypes: []cu.Dtype{cu.DtFloat32, cu.DtFloat64},
+	Code: `
+__device__ __forceinline__ acc_t binop(long long op, acc_t x, acc_t y) {
+	switch (op) {
+	case 0: return x + y;
+	case 1: return x - y;
+	case 2: return x * y;
+	case 3: return x / y;
+	case 4: return x > y || x != x ? x : y;
+	case 5: return x < y || x != x ? x : y;
+	case 6: return pow(x, y);
+	}
+	return 0;
+}
+
+extern "C" __global__ void binary_flat(T* out, const T* a, const T* b, long long n, long long op) {
+	GRID_STRIDE(i, n) {
+		st(out, i, binop(op, ld(a, i), ld(b, i)));
+	}
+}
+
+extern "C" __global__ void binary_nd(T* out, const T* a, const T* b, long long n, long long op,
+	long long s1, long long s2, long long s3,
+	long long o0, long long o1, long long o2, long long o3,
+	long long a0, long long a1, long long a2, long long a3,
+	long long b0, long long b1, long long b2, long long b3) {
+	GRID_STRIDE(i, n) {
+		acc_t x = ld(a, nd_offset(i, s1, s2, s3, a0, a1, a2, a3));
+		acc_t y = ld(b, nd_offset(i, s1, s2, s3, b0, b1, b2, b3));
+		st(out, nd_offset(i, s1, s2, s3, o0, o1, o2, o3), binop(op, x, y));
+	}
+}
+`,
+}
+
+// Binary computes out = op(a, b) elementwise, on the given stream.
+//
+// a and b are broadcast to the shape of out with NumPy's rules (see cu.BroadcastShapes), so there is no need to tile
+// them on the device. Any of the tensors may be strided views. The operation is performed in a single launch
+// when out has at most 4 dimensions once the dimensions that are contiguous in every tensor are merged.
+//
+// complex64 and complex128 support OpAdd, OpSub, OpMul and OpDiv (see also ComplexUnary).
+func Binary(op BinaryOp, out, a, b cu.DeviceTensor, stream cu.Stream) error {
+	if op < 0 || int(op) >= len(binaryOpNames) {
+		return errors.Errorf("Unknown binary op %d", int(op))
+	}
+	if a.Dtype != out.Dtype || b.Dtype != out.Dtype {
+		return errors.Errorf("%v: mismatched types %v = %v, %v", op, out.Dtype, a.Dtype, b.Dtype)
+	}
+	if out.Dtype.IsComplex() {
+		return complexBinary(op, out, a, b, stream)
+	}
+	l, err := makeLayout(out, a, b)
+	if err != nil {
+		return errors.Wrapf(err, "%v", op)
+	}
+	return launchStrided(elementwiseSource, out.Dtype, "binary_nd", "binary_flat", l, []cu.DeviceTensor{out, a, b}, stream, int(op))
+}
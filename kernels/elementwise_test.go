@@ -0,0 +1,98 @@
+package kernels
+
+import (
+	"reflect"
+	"testing"
+
+	"gorgonia.org/cu"
+)
+
+func TestMakeLayout(t *testing.T) {
+	f32 := func(shape ...int) cu.DeviceTensor { return cu.NewDeviceTensor(0x1000, cu.DtFloat32, shape...) }
+
+	// same shapes coalesce into a single contiguous dimension
+	l, err := makeLayout(f32(2, 3, 4), f32(2, 3, 4), f32(2, 3, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !l.contiguous() || l.len() != 24 {
+		t.Errorf("Expected a contiguous layout of 24 elements. Got %+v", l)
+	}
+
+	// a row vector broadcast over a matrix
+	l, err = makeLayout(f32(2, 3, 4), f32(2, 3, 4), f32(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(l.shape, []int{6, 4}) || !reflect.DeepEqual(l.strides[2], []int{0, 1}) {
+		t.Errorf("Unexpected layout %+v", l)
+	}
+
+	// the output cannot be broadcast
+	bc, _ := f32(1, 4).BroadcastTo(3, 4)
+	if _, err = makeLayout(bc, f32(3, 4)); err == nil {
+		t.Errorf("Expected an error when the output is a broadcast view")
+	}
+	if _, err = makeLayout(f32(3, 4), f32(3, 5)); err == nil {
+		t.Errorf("Expected an error for incompatible shapes")
+	}
+
+	// empty outputs have strides of 0, but are not broadcast views
+	if l, err = makeLayout(f32(3, 0), f32(3, 0), f32(0)); err != nil {
+		t.Fatal(err)
+	}
+	if l.len() != 0 {
+		t.Errorf("Expected an empty layout. Got %+v", l)
+	}
+}
+
+func TestLayout_ForEach(t *testing.T) {
+	// a 6 dimensional transposed view cannot be coalesced, so the 2 outer dimensions are iterated on the host
+	a := cu.NewDeviceTensor(0x1000, cu.DtFloat32, 2, 3, 2, 2, 2, 2)
+	view, err := a.Transpose(5, 4, 3, 2, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := cu.NewDeviceTensor(0x2000, cu.DtFloat32, view.Shape...)
+	l, err := makeLayout(out, view)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var calls int
+	seen := make(map[int]bool)
+	l.forEach(func(offsets []int, inner layout) error {
+		calls++
+		seen[offsets[0]] = true
+		if len(inner.shape) != maxRank || inner.len() != 2*2*3*2 {
+			t.Errorf("Unexpected inner layout %+v", inner)
+		}
+		return nil
+	})
+	if calls != 4 || len(seen) != 4 {
+		t.Errorf("Expected 4 distinct launches. Got %d calls with %d distinct offsets", calls, len(seen))
+	}
+}
+
+func TestBinary(t *testing.T) {
+	withContext(t, func() {
+		a := []float32{1, 2, 3, 4, 5, 6}
+		b := []float32{10, 20, 30}
+		pa, pb := upload32(t, a), upload32(t, b)
+		pout := upload32(t, make([]float32, 6))
+		defer cu.MemFree(pa)
+		defer cu.MemFree(pb)
+		defer cu.MemFree(pout)
+
+		ta := cu.NewDeviceTensor(pa, cu.DtFloat32, 2, 3)
+		tb := cu.NewDeviceTensor(pb, cu.DtFloat32, 3)
+		out := cu.NewDeviceTensor(pout, cu.DtFloat32, 2, 3)
+		if err := Binary(OpAdd, out, ta, tb, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		correct := []float32{11, 22, 33, 14, 25, 36}
+		if got := download32(t, pout, 6); !close32(got, correct, 0) {
+			t.Errorf("Expected %v. Got %v", correct, got)
+		}
+	})
+}
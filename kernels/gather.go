@@ -0,0 +1,67 @@
+package kernels
+
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// gatherSource holds the byte gather of GatherToHost. Byte i of the output is byte i % size of the element at
+// offsets[i / size], so that consecutive threads read consecutive bytes of an element.
+var gatherSource = &Source{
+	Name:   "gather",
+	Dtypes: []cu.Dtype{cu.DtUint8},
+	Code: `
+extern "C" __global__ void gather_bytes(unsigned char* out, const unsigned char* src, const long long* offsets, long long n, long long size) {
+	GRID_STRIDE(i, n * size) {
+		out[i] = src[offsets[i / size] + i % size];
+	}
+}
+`,
+}
+
+// GatherToHost reads the elements of elemSize bytes found at the given byte offsets from ptr, and returns them packed
+// in the order of the offsets.
+//
+// The elements are gathered into a staging buffer on the device, which is then copied to the host at once: reading
+// many small, scattered elements costs one copy instead of one per element. The call waits for the copy, and so for
+// the work enqueued on the stream before it.
+func GatherToHost(ptr cu.DevicePtr, offsets []int64, elemSize int, stream cu.Stream) ([]byte, error) {
+	if elemSize <= 0 {
+		return nil, errors.Errorf("GatherToHost: invalid element size %d", elemSize)
+	}
+	n := len(offsets)
+	if n == 0 {
+		return nil, nil
+	}
+	for i, off := range offsets {
+		if off < 0 {
+			return nil, errors.Errorf("GatherToHost: negative offset %d at %d", off, i)
+		}
+	}
+
+	// one allocation holds the offsets, followed by the staging buffer
+	offSize, outSize := int64(n*8), int64(n*elemSize)
+	mem, err := cu.MemAlloc(offSize + outSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "GatherToHost")
+	}
+	defer cu.MemFree(mem)
+	staging := mem.Offset(offSize)
+
+	if err = cu.MemcpyHtoDAsync(mem, unsafe.Pointer(&offsets[0]), offSize, stream); err != nil {
+		return nil, errors.Wrap(err, "GatherToHost")
+	}
+	if err = launch(gatherSource, cu.DtUint8, "gather_bytes", n*elemSize, stream, staging, ptr, mem, n, elemSize); err != nil {
+		return nil, errors.Wrap(err, "GatherToHost")
+	}
+	retVal := make([]byte, outSize)
+	if err = cu.MemcpyDtoHAsync(unsafe.Pointer(&retVal[0]), staging, outSize, stream); err != nil {
+		return nil, errors.Wrap(err, "GatherToHost")
+	}
+	if err = stream.Synchronize(); err != nil {
+		return nil, errors.Wrap(err, "GatherToHost")
+	}
+	return retVal, nil
+}
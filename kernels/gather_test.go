@@ -0,0 +1,52 @@
+package kernels
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"gorgonia.org/cu"
+)
+
+func TestGatherToHost(t *testing.T) {
+	withContext(t, func() {
+		data := make([]float32, 64)
+		for i := range data {
+			data[i] = float32(i)
+		}
+		mem := upload32(t, data)
+		defer cu.MemFree(mem)
+
+		// elements of 8 bytes (pairs of float32), at scattered and repeated offsets
+		offsets := []int64{40 * 4, 0, 63*4 - 4, 40 * 4, 2}
+		got, err := GatherToHost(mem, offsets, 8, cu.NoStream)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(offsets)*8 {
+			t.Fatalf("Expected %d bytes. Got %d", len(offsets)*8, len(got))
+		}
+		f := func(b []byte) float32 { return math.Float32frombits(binary.LittleEndian.Uint32(b)) }
+		for i, want := range [][2]float32{{40, 41}, {0, 1}, {62, 63}, {40, 41}} {
+			if a, b := f(got[i*8:]), f(got[i*8+4:]); a != want[0] || b != want[1] {
+				t.Errorf("Element %d: expected %v. Got [%v %v]", i, want, a, b)
+			}
+		}
+		// unaligned offsets read the bytes found there
+		if want := data2bytes(data)[2:10]; string(got[32:]) != string(want) {
+			t.Errorf("Expected the bytes 2 to 10. Got %v", got[32:])
+		}
+
+		if _, err = GatherToHost(mem, []int64{-1}, 4, cu.NoStream); err == nil {
+			t.Error("Expected an error for a negative offset")
+		}
+	})
+}
+
+func data2bytes(data []float32) []byte {
+	b := make([]byte, len(data)*4)
+	for i, v := range data {
+		binary.LittleEndian.PutUint32(b[i*4:], math.Float32bits(v))
+	}
+	return b
+}
@@ -0,0 +1,378 @@
+package kernels
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// ImageLayout is the order of the dimensions of a batch of images.
+type ImageLayout int
+
+const (
+	NCHW ImageLayout = iota // batch, channels, height, width
+	NHWC                    // batch, height, width, channels
+)
+
+func (l ImageLayout) String() string {
+	switch l {
+	case NCHW:
+		return "NCHW"
+	case NHWC:
+		return "NHWC"
+	}
+	return fmt.Sprintf("UnknownImageLayout:%d", int(l))
+}
+
+// Interpolation is the interpolation used to resize images.
+type Interpolation int
+
+const (
+	Nearest  Interpolation = iota // nearest neighbour
+	Bilinear                      // bilinear, with half pixel centers
+)
+
+func (i Interpolation) String() string {
+	switch i {
+	case Nearest:
+		return "Nearest"
+	case Bilinear:
+		return "Bilinear"
+	}
+	return fmt.Sprintf("UnknownInterpolation:%d", int(i))
+}
+
+// Image is a batch of images in device memory. The tensor is of rank 4, with its dimensions in the order given by
+// the layout. The tensor may be a view, as the strides of the tensor are honoured.
+type Image struct {
+	cu.DeviceTensor
+	Layout ImageLayout
+}
+
+// NewImage creates a contiguous Image of n images of c channels of h×w pixels.
+func NewImage(ptr cu.DevicePtr, dt cu.Dtype, layout ImageLayout, n, c, h, w int) Image {
+	shape := []int{n, c, h, w}
+	if layout == NHWC {
+		shape = []int{n, h, w, c}
+	}
+	return Image{DeviceTensor: cu.NewDeviceTensor(ptr, dt, shape...), Layout: layout}
+}
+
+// imageDims are the sizes and strides (in elements) of an Image, in NCHW order.
+type imageDims struct {
+	shape   [4]int
+	strides [4]int
+}
+
+func (im Image) dims() (d imageDims, err error) {
+	if err = im.Check(); err != nil {
+		return d, err
+	}
+	if im.Dims() != 4 {
+		return d, errors.Errorf("Expected an image of rank 4. Got %v instead", im.Shape)
+	}
+	perm := [4]int{0, 1, 2, 3}
+	switch im.Layout {
+	case NCHW:
+	case NHWC:
+		perm = [4]int{0, 3, 1, 2}
+	default:
+		return d, errors.Errorf("Unknown image layout %v", im.Layout)
+	}
+	for i, p := range perm {
+		d.shape[i] = im.Shape[p]
+		d.strides[i] = im.Strides[p]
+	}
+	return d, nil
+}
+
+func (d imageDims) len() int { return d.shape[0] * d.shape[1] * d.shape[2] * d.shape[3] }
+
+// args are the arguments describing the image in the image kernels.
+func (d imageDims) args() []interface{} {
+	return []interface{}{d.strides[0], d.strides[1], d.strides[2], d.strides[3]}
+}
+
+// imageSource holds the kernels that operate on images. The kernels iterate over the elements of the output in NCHW
+// order: n, c, y and x are computed from the index of the element and the shape of the output.
+var imageSource = &Source{
+	Name:   "image",
+	Dtypes: []cu.Dtype{cu.DtFloat32, cu.DtFloat64, cu.DtUint8},
+	Code: `
+#define OUT_PIXEL(i, n, c, y, x, C, H, W) \
+	long long x = i % W; \
+	long long y = (i / W) % H; \
+	long long c = (i / (W * H)) % C; \
+	long long n = i / (W * H * C);
+
+extern "C" __global__ void resize_nearest(T* out, const T* in, long long total,
+	long long C, long long H, long long W, long long o0, long long o1, long long o2, long long o3,
+	long long IH, long long IW, long long i0, long long i1, long long i2, long long i3) {
+	double sy = (double)IH / H;
+	double sx = (double)IW / W;
+	GRID_STRIDE(i, total) {
+		OUT_PIXEL(i, n, c, y, x, C, H, W)
+		long long iy = min((long long)floor(y * sy), IH - 1);
+		long long ix = min((long long)floor(x * sx), IW - 1);
+		st(out, n * o0 + c * o1 + y * o2 + x * o3, ld(in, n * i0 + c * i1 + iy * i2 + ix * i3));
+	}
+}
+
+extern "C" __global__ void resize_bilinear(T* out, const T* in, long long total,
+	long long C, long long H, long long W, long long o0, long long o1, long long o2, long long o3,
+	long long IH, long long IW, long long i0, long long i1, long long i2, long long i3) {
+	double sy = (double)IH / H;
+	double sx = (double)IW / W;
+	GRID_STRIDE(i, total) {
+		OUT_PIXEL(i, n, c, y, x, C, H, W)
+		double fy = fmax((y + 0.5) * sy - 0.5, 0.0);
+		double fx = fmax((x + 0.5) * sx - 0.5, 0.0);
+		long long y0 = min((long long)fy, IH - 1);
+		long long x0 = min((long long)fx, IW - 1);
+		long long y1 = min(y0 + 1, IH - 1);
+		long long x1 = min(x0 + 1, IW - 1);
+		double dy = fy - y0;
+		double dx = fx - x0;
+		const T* p = in + n * i0 + c * i1;
+		double top = ld(p, y0 * i2 + x0 * i3) * (1 - dx) + ld(p, y0 * i2 + x1 * i3) * dx;
+		double bottom = ld(p, y1 * i2 + x0 * i3) * (1 - dx) + ld(p, y1 * i2 + x1 * i3) * dx;
+		st(out, n * o0 + c * o1 + y * o2 + x * o3, (acc_t)(top * (1 - dy) + bottom * dy));
+	}
+}
+
+// window copies the input into the output, offset by (top, left). Output pixels that fall outside of the input are
+// set to value. A negative offset crops the input.
+extern "C" __global__ void window(T* out, const T* in, long long total,
+	long long C, long long H, long long W, long long o0, long long o1, long long o2, long long o3,
+	long long IH, long long IW, long long i0, long long i1, long long i2, long long i3,
+	long long top, long long left, double value) {
+	GRID_STRIDE(i, total) {
+		OUT_PIXEL(i, n, c, y, x, C, H, W)
+		long long iy = y - top;
+		long long ix = x - left;
+		acc_t v = (acc_t)value;
+		if (iy >= 0 && iy < IH && ix >= 0 && ix < IW) {
+			v = ld(in, n * i0 + c * i1 + iy * i2 + ix * i3);
+		}
+		st(out, n * o0 + c * o1 + y * o2 + x * o3, v);
+	}
+}
+
+// depth_to_space moves blocks of b×b channels into b×b spatial blocks: the output has C channels, and the input C*b*b.
+// Channel (by*b + bx)*C + c of the input pixel (y, x) becomes channel c of the output pixel (y*b + by, x*b + bx).
+extern "C" __global__ void depth_to_space(T* out, const T* in, long long total,
+	long long C, long long H, long long W, long long o0, long long o1, long long o2, long long o3,
+	long long b, long long i0, long long i1, long long i2, long long i3) {
+	GRID_STRIDE(i, total) {
+		OUT_PIXEL(i, n, c, y, x, C, H, W)
+		long long ic = ((y % b) * b + x % b) * C + c;
+		st(out, n * o0 + c * o1 + y * o2 + x * o3, ld(in, n * i0 + ic * i1 + (y / b) * i2 + (x / b) * i3));
+	}
+}
+
+// space_to_depth is the inverse of depth_to_space: the output has C channels, and the input C/(b*b).
+extern "C" __global__ void space_to_depth(T* out, const T* in, long long total,
+	long long C, long long H, long long W, long long o0, long long o1, long long o2, long long o3,
+	long long b, long long i0, long long i1, long long i2, long long i3) {
+	long long IC = C / (b * b);
+	GRID_STRIDE(i, total) {
+		OUT_PIXEL(i, n, c, y, x, C, H, W)
+		long long blk = c / IC;
+		long long ic = c % IC;
+		st(out, n * o0 + c * o1 + y * o2 + x * o3, ld(in, n * i0 + ic * i1 + (y * b + blk / b) * i2 + (x * b + blk % b) * i3));
+	}
+}
+`,
+}
+
+// normalizeSource holds the normalization kernels, which convert images to floating point.
+var normalizeSource = &Source{
+	Name:   "normalize",
+	Dtypes: []cu.Dtype{cu.DtFloat32, cu.DtFloat64},
+	Code: `
+#define NORMALIZE \
+	double m[4] = {m0, m1, m2, m3}; \
+	double r[4] = {r0, r1, r2, r3}; \
+	GRID_STRIDE(i, total) { \
+		long long x = i % W; \
+		long long y = (i / W) % H; \
+		long long c = (i / (W * H)) % C; \
+		long long n = i / (W * H * C); \
+		double v = (double)in[n * i0 + c * i1 + y * i2 + x * i3]; \
+		st(out, n * o0 + c * o1 + y * o2 + x * o3, (acc_t)((v * scale - m[c]) * r[c])); \
+	}
+
+// out = (in * scale - mean[c]) / std[c]. The reciprocals of the standard deviations are passed in r.
+extern "C" __global__ void normalize(T* out, const T* in, long long total,
+	long long C, long long H, long long W, long long o0, long long o1, long long o2, long long o3,
+	long long i0, long long i1, long long i2, long long i3,
+	double scale, double m0, double m1, double m2, double m3, double r0, double r1, double r2, double r3) {
+	NORMALIZE
+}
+
+extern "C" __global__ void normalize_u8(T* out, const unsigned char* in, long long total,
+	long long C, long long H, long long W, long long o0, long long o1, long long o2, long long o3,
+	long long i0, long long i1, long long i2, long long i3,
+	double scale, double m0, double m1, double m2, double m3, double r0, double r1, double r2, double r3) {
+	NORMALIZE
+}
+`,
+}
+
+// MaxNormalizeChannels is the maximum number of channels of the images normalized by Normalize.
+const MaxNormalizeChannels = 4
+
+// Resize resizes the images of in into out, which must have the same number of images and channels.
+// The layouts of in and out may differ, so that resizing also converts between layouts.
+func Resize(mode Interpolation, out, in Image, stream cu.Stream) error {
+	o, i, err := imagePair(out, in)
+	if err != nil {
+		return err
+	}
+	if o.shape[0] != i.shape[0] || o.shape[1] != i.shape[1] {
+		return errors.Errorf("Cannot resize %v into %v: the number of images and channels differ", in.Shape, out.Shape)
+	}
+	var name string
+	switch mode {
+	case Nearest:
+		name = "resize_nearest"
+	case Bilinear:
+		name = "resize_bilinear"
+	default:
+		return errors.Errorf("Unknown interpolation %v", mode)
+	}
+	args := append(imageArgs(out, in, o), i.shape[2], i.shape[3])
+	args = append(args, i.args()...)
+	return launch(imageSource, out.Dtype, name, o.len(), stream, args...)
+}
+
+// Pad copies the images of in into out, offset by top rows and left columns. The pixels of out that are not covered
+// by in are set to value. The size of out determines the padding at the bottom and on the right.
+func Pad(out, in Image, top, left int, value float64, stream cu.Stream) error {
+	if top < 0 || left < 0 {
+		return errors.Errorf("Cannot pad by a negative offset (%d, %d)", top, left)
+	}
+	return window(out, in, top, left, value, stream)
+}
+
+// Crop copies the region of in that starts at row top and column left into out. The size of the region is the size
+// of out, and it must lie within in.
+func Crop(out, in Image, top, left int, stream cu.Stream) error {
+	o, i, err := imagePair(out, in)
+	if err != nil {
+		return err
+	}
+	if top < 0 || left < 0 || top+o.shape[2] > i.shape[2] || left+o.shape[3] > i.shape[3] {
+		return errors.Errorf("Cannot crop a %d×%d region at (%d, %d) of %d×%d images", o.shape[2], o.shape[3], top, left, i.shape[2], i.shape[3])
+	}
+	return window(out, in, -top, -left, 0, stream)
+}
+
+// DepthToSpace rearranges blocks of blockSize×blockSize channels of in into spatial blocks of out. out has
+// blockSize×blockSize fewer channels than in, and is blockSize times as high and as wide.
+func DepthToSpace(out, in Image, blockSize int, stream cu.Stream) error {
+	o, i, err := imagePair(out, in)
+	if err != nil {
+		return err
+	}
+	b := blockSize
+	if b < 1 || o.shape[0] != i.shape[0] || o.shape[1]*b*b != i.shape[1] || o.shape[2] != i.shape[2]*b || o.shape[3] != i.shape[3]*b {
+		return errors.Errorf("Cannot rearrange %v into %v with blocks of %d", in.Shape, out.Shape, blockSize)
+	}
+	args := append(imageArgs(out, in, o), b)
+	args = append(args, i.args()...)
+	return launch(imageSource, out.Dtype, "depth_to_space", o.len(), stream, args...)
+}
+
+// SpaceToDepth is the inverse of DepthToSpace.
+func SpaceToDepth(out, in Image, blockSize int, stream cu.Stream) error {
+	o, i, err := imagePair(out, in)
+	if err != nil {
+		return err
+	}
+	b := blockSize
+	if b < 1 || o.shape[0] != i.shape[0] || i.shape[1]*b*b != o.shape[1] || i.shape[2] != o.shape[2]*b || i.shape[3] != o.shape[3]*b {
+		return errors.Errorf("Cannot rearrange %v into %v with blocks of %d", in.Shape, out.Shape, blockSize)
+	}
+	args := append(imageArgs(out, in, o), b)
+	args = append(args, i.args()...)
+	return launch(imageSource, out.Dtype, "space_to_depth", o.len(), stream, args...)
+}
+
+// Normalize computes out = (in * scale - mean[c]) / std[c] for each channel c. The images of in may be bytes, or
+// of the same element type as out, which must be floating point. Typically, scale is 1/255 for bytes.
+//
+// The layouts of in and out may differ, so that normalizing also converts between layouts.
+// At most MaxNormalizeChannels channels are supported.
+func Normalize(out, in Image, scale float64, mean, std []float64, stream cu.Stream) error {
+	o, err := out.dims()
+	if err != nil {
+		return errors.Wrap(err, "out")
+	}
+	i, err := in.dims()
+	if err != nil {
+		return errors.Wrap(err, "in")
+	}
+	if o.shape != i.shape {
+		return errors.Errorf("Cannot normalize %v %v into %v %v: the shapes differ", in.Shape, in.Layout, out.Shape, out.Layout)
+	}
+	c := o.shape[1]
+	if c > MaxNormalizeChannels || len(mean) != c || len(std) != c {
+		return errors.Errorf("Expected a mean and a standard deviation for each of the %d channels (at most %d). Got %d and %d", c, MaxNormalizeChannels, len(mean), len(std))
+	}
+	var m, r [MaxNormalizeChannels]float64
+	for k := range mean {
+		if std[k] == 0 {
+			return errors.Errorf("The standard deviation of channel %d is 0", k)
+		}
+		m[k], r[k] = mean[k], 1/std[k]
+	}
+
+	name := "normalize"
+	switch in.Dtype {
+	case out.Dtype:
+	case cu.DtUint8:
+		name = "normalize_u8"
+	default:
+		return errors.Errorf("Cannot normalize %v images into %v images", in.Dtype, out.Dtype)
+	}
+	args := append(imageArgs(out, in, o), i.args()...)
+	args = append(args, scale, m[0], m[1], m[2], m[3], r[0], r[1], r[2], r[3])
+	return launch(normalizeSource, out.Dtype, name, o.len(), stream, args...)
+}
+
+func window(out, in Image, top, left int, value float64, stream cu.Stream) error {
+	o, i, err := imagePair(out, in)
+	if err != nil {
+		return err
+	}
+	if o.shape[0] != i.shape[0] || o.shape[1] != i.shape[1] {
+		return errors.Errorf("Cannot copy %v into %v: the number of images and channels differ", in.Shape, out.Shape)
+	}
+	args := append(imageArgs(out, in, o), i.shape[2], i.shape[3])
+	args = append(args, i.args()...)
+	args = append(args, top, left, value)
+	return launch(imageSource, out.Dtype, "window", o.len(), stream, args...)
+}
+
+// imagePair checks that in and out are images of the same element type, and returns their dimensions.
+func imagePair(out, in Image) (o, i imageDims, err error) {
+	if o, err = out.dims(); err != nil {
+		return o, i, errors.Wrap(err, "out")
+	}
+	if i, err = in.dims(); err != nil {
+		return o, i, errors.Wrap(err, "in")
+	}
+	if out.Dtype != in.Dtype {
+		return o, i, errors.Errorf("Expected images of the same element type. Got %v and %v", out.Dtype, in.Dtype)
+	}
+	return o, i, nil
+}
+
+// imageArgs are the leading arguments of the image kernels: the pointers, the number of output elements, the shape
+// (C, H, W) of the output, and the strides of the output.
+func imageArgs(out, in Image, o imageDims) []interface{} {
+	args := []interface{}{out.Ptr, in.Ptr, o.len(), o.shape[1], o.shape[2], o.shape[3]}
+	return append(args, o.args()...)
+}
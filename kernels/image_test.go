@@ -0,0 +1,100 @@
+package kernels
+
+import (
+	"testing"
+
+	"gorgonia.org/cu"
+)
+
+func TestImageDims(t *testing.T) {
+	im := NewImage(0x1000, cu.DtFloat32, NHWC, 2, 3, 4, 5)
+	d, err := im.dims()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.shape != [4]int{2, 3, 4, 5} {
+		t.Errorf("Expected the NCHW shape (2, 3, 4, 5). Got %v", d.shape)
+	}
+	if d.strides != [4]int{60, 1, 15, 3} {
+		t.Errorf("Expected the NCHW strides (60, 1, 15, 3). Got %v", d.strides)
+	}
+
+	im.Layout = ImageLayout(5)
+	if _, err = im.dims(); err == nil {
+		t.Error("Expected an error for an unknown layout")
+	}
+}
+
+func TestImage(t *testing.T) {
+	withContext(t, func() {
+		// one 2×2 image of one channel
+		px := upload32(t, []float32{1, 2, 3, 4})
+		pout := upload32(t, make([]float32, 16))
+		for _, mem := range []cu.DevicePtr{px, pout} {
+			defer cu.MemFree(mem)
+		}
+		in := NewImage(px, cu.DtFloat32, NCHW, 1, 1, 2, 2)
+
+		big := NewImage(pout, cu.DtFloat32, NCHW, 1, 1, 4, 4)
+		if err := Resize(Nearest, big, in, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		correct := []float32{
+			1, 1, 2, 2,
+			1, 1, 2, 2,
+			3, 3, 4, 4,
+			3, 3, 4, 4,
+		}
+		if got := download32(t, pout, 16); !close32(got, correct, 0) {
+			t.Errorf("Resize: expected %v. Got %v", correct, got)
+		}
+
+		padded := NewImage(pout, cu.DtFloat32, NCHW, 1, 1, 3, 3)
+		if err := Pad(padded, in, 1, 0, -1, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		correct = []float32{
+			-1, -1, -1,
+			1, 2, -1,
+			3, 4, -1,
+		}
+		if got := download32(t, pout, 9); !close32(got, correct, 0) {
+			t.Errorf("Pad: expected %v. Got %v", correct, got)
+		}
+
+		crop := NewImage(pout, cu.DtFloat32, NCHW, 1, 1, 1, 2)
+		if err := Crop(crop, in, 1, 0, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		correct = []float32{3, 4}
+		if got := download32(t, pout, 2); !close32(got, correct, 0) {
+			t.Errorf("Crop: expected %v. Got %v", correct, got)
+		}
+		if err := Crop(crop, in, 1, 1, cu.NoStream); err == nil {
+			t.Error("Expected an error cropping outside of the image")
+		}
+
+		// 4 channels of 1×1 become one channel of 2×2
+		deep := NewImage(px, cu.DtFloat32, NCHW, 1, 4, 1, 1)
+		if err := DepthToSpace(big, deep, 2, cu.NoStream); err == nil {
+			t.Error("Expected an error for mismatched shapes")
+		}
+		flat := NewImage(pout, cu.DtFloat32, NCHW, 1, 1, 2, 2)
+		if err := DepthToSpace(flat, deep, 2, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		correct = []float32{1, 2, 3, 4}
+		if got := download32(t, pout, 4); !close32(got, correct, 0) {
+			t.Errorf("DepthToSpace: expected %v. Got %v", correct, got)
+		}
+
+		hwc := NewImage(pout, cu.DtFloat32, NHWC, 1, 1, 2, 2)
+		if err := Normalize(hwc, in, 0.5, []float64{1}, []float64{0.5}, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		correct = []float32{-1, 0, 1, 2}
+		if got := download32(t, pout, 4); !close32(got, correct, 1e-6) {
+			t.Errorf("Normalize: expected %v. Got %v", correct, got)
+		}
+	})
+}
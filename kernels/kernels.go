@@ -0,0 +1,253 @@
+// Package kernels is a library of CUDA kernels that operate on device memory.
+//
+// The kernels are written in CUDA C, and compiled at runtime with NVRTC for the compute capability of the device in use.
+// Each source is compiled once per element type, and loaded once per context. The compiled kernels are cached in a Library,
+// and on disk (see DiskCache), so that later processes do not compile them again.
+//
+// All the functions in this package launch kernels asynchronously on the given stream, and must be called from a
+// thread that has a current context (for example, from within (*cu.Ctx).Do).
+//
+// Scalar kernel parameters are passed as 8 byte values: integers as long long, and floating point values as double.
+package kernels // import "gorgonia.org/cu/kernels"
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+	"gorgonia.org/cu/nvrtc"
+)
+
+// Source is the CUDA C source of a set of kernels.
+//
+// The source is compiled once for every Dtype it is used with. A prelude is prepended to the source, which defines
+// T as the element type, acc_t as the type used for arithmetic, and the functions ld(p, i) and st(p, i, v) to load
+// and store elements as acc_t. Kernels should be declared extern "C" so that their names are not mangled.
+type Source struct {
+	Name   string
+	Code   string
+	Dtypes []cu.Dtype // the element types the source supports
+}
+
+// sources are the sources of the kernels of this package.
+var sources = []*Source{
+	attentionSource,
+	complexSource,
+	detectionSource,
+	elementwiseSource,
+	gatherSource,
+	imageSource,
+	kvSource,
+	lossSource,
+	maskSource,
+	normalizeSource,
+	optimSource,
+	samplingSource,
+	segmentSource,
+	transformerSource,
+}
+
+// Sources returns the sources of the kernels of this package, so that their kernels can be looked up by name.
+func Sources() []*Source { return append([]*Source(nil), sources...) }
+
+// Preload compiles and loads the kernels of this package for the given element types into the current context.
+// It is meant to be registered with cu.RegisterWarmup, so that cu.Preinitialize loads the kernels ahead of use.
+func Preload(dts ...cu.Dtype) error { return Default.Preload(sources, dts...) }
+
+func (src *Source) supports(dt cu.Dtype) bool {
+	for _, d := range src.Dtypes {
+		if d == dt {
+			return true
+		}
+	}
+	return false
+}
+
+type ptxKey struct {
+	src  string
+	dt   cu.Dtype
+	arch string
+}
+
+type moduleKey struct {
+	ctx uintptr
+	src string
+	dt  cu.Dtype
+}
+
+type funcKey struct {
+	moduleKey
+	name string
+}
+
+// Library compiles, loads and caches kernels.
+type Library struct {
+	sync.Mutex
+	ptx   map[ptxKey]string
+	mods  map[moduleKey]cu.Module
+	funcs map[funcKey]cu.Function
+	cache *DiskCache
+}
+
+// Default is the library used by the functions in this package. It caches the compiled kernels on disk, in the
+// directory given by DefaultCacheDir.
+var Default = newDefaultLibrary()
+
+func newDefaultLibrary() *Library {
+	lib := NewLibrary()
+	if dir, err := DefaultCacheDir(); err == nil && dir != "" {
+		// the cache is an optimization: the library works without it
+		lib.cache, _ = NewDiskCache(dir)
+	}
+	return lib
+}
+
+// NewLibrary creates a new, empty Library.
+func NewLibrary() *Library {
+	return &Library{
+		ptx:   make(map[ptxKey]string),
+		mods:  make(map[moduleKey]cu.Module),
+		funcs: make(map[funcKey]cu.Function),
+	}
+}
+
+// SetCache sets the DiskCache of the compiled kernels. A nil cache disables caching on disk.
+func (lib *Library) SetCache(c *DiskCache) {
+	lib.Lock()
+	lib.cache = c
+	lib.Unlock()
+}
+
+// Cache returns the DiskCache of the library, which may be nil.
+func (lib *Library) Cache() *DiskCache {
+	lib.Lock()
+	defer lib.Unlock()
+	return lib.cache
+}
+
+// Function returns the named kernel from the source, compiled for the given element type.
+// The kernel is loaded into the current context.
+func (lib *Library) Function(src *Source, dt cu.Dtype, name string) (fn cu.Function, err error) {
+	if !src.supports(dt) {
+		return fn, errors.Errorf("%v is not supported by the %v kernels", dt, src.Name)
+	}
+	var ctx cu.CUContext
+	if ctx, err = cu.CurrentContext(); err != nil {
+		return fn, errors.Wrap(err, "No current context")
+	}
+
+	key := funcKey{moduleKey{ctx.Uintptr(), src.Name, dt}, name}
+	lib.Lock()
+	defer lib.Unlock()
+	if fn, ok := lib.funcs[key]; ok {
+		return fn, nil
+	}
+
+	mod, err := lib.module(key.moduleKey, src, dt)
+	if err != nil {
+		return fn, err
+	}
+	if fn, err = mod.Function(name); err != nil {
+		return fn, errors.Wrapf(err, "Unable to find kernel %q in %v", name, src.Name)
+	}
+	lib.funcs[key] = fn
+	return fn, nil
+}
+
+// Preload compiles the sources for the given element types, and loads them into the current context, so that the
+// first launches of their kernels do not pay for it. Sources that do not support an element type are skipped.
+func (lib *Library) Preload(srcs []*Source, dts ...cu.Dtype) error {
+	ctx, err := cu.CurrentContext()
+	if err != nil {
+		return errors.Wrap(err, "No current context")
+	}
+	lib.Lock()
+	defer lib.Unlock()
+	for _, src := range srcs {
+		for _, dt := range dts {
+			if !src.supports(dt) {
+				continue
+			}
+			if _, err = lib.module(moduleKey{ctx.Uintptr(), src.Name, dt}, src, dt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// module returns the module of the source compiled for the element type, loading it into the current context if
+// required. The lock is expected to be held.
+func (lib *Library) module(key moduleKey, src *Source, dt cu.Dtype) (mod cu.Module, err error) {
+	if mod, ok := lib.mods[key]; ok {
+		return mod, nil
+	}
+	var arch string
+	if arch, err = currentArch(); err != nil {
+		return mod, err
+	}
+	var ptx string
+	if ptx, err = lib.compile(src, dt, arch); err != nil {
+		return mod, err
+	}
+	if mod, err = cu.LoadData(ptx); err != nil {
+		return mod, errors.Wrapf(err, "Unable to load the %v kernels for %v", src.Name, dt)
+	}
+	lib.mods[key] = mod
+	return mod, nil
+}
+
+// PTX returns the PTX of the source compiled for the given element type and architecture (e.g. "compute_60").
+func (lib *Library) PTX(src *Source, dt cu.Dtype, arch string) (string, error) {
+	if !src.supports(dt) {
+		return "", errors.Errorf("%v is not supported by the %v kernels", dt, src.Name)
+	}
+	lib.Lock()
+	defer lib.Unlock()
+	return lib.compile(src, dt, arch)
+}
+
+// compile compiles the source. The lock is expected to be held.
+func (lib *Library) compile(src *Source, dt cu.Dtype, arch string) (string, error) {
+	key := ptxKey{src.Name, dt, arch}
+	if ptx, ok := lib.ptx[key]; ok {
+		return ptx, nil
+	}
+
+	code := Prelude(dt) + src.Code
+	options := []string{"--gpu-architecture=" + arch}
+	var compiler string
+	if lib.cache != nil {
+		major, minor, _ := nvrtc.Version()
+		compiler = fmt.Sprintf("nvrtc-%d.%d", major, minor)
+		if ptx, ok := lib.cache.Get(code, arch, options, compiler); ok {
+			lib.ptx[key] = string(ptx)
+			return string(ptx), nil
+		}
+	}
+
+	ptx, _, err := nvrtc.CompilePTX(code, src.Name+".cu", nil, options...)
+	if err != nil {
+		return "", errors.Wrapf(err, "Unable to compile %v for %v (%v)", src.Name, dt, arch)
+	}
+	lib.ptx[key] = ptx
+	if lib.cache != nil {
+		// failing to cache only costs compiling again
+		lib.cache.Put(code, arch, options, compiler, []byte(ptx))
+	}
+	return ptx, nil
+}
+
+// currentArch returns the virtual architecture of the device of the current context.
+func currentArch() (string, error) {
+	dev, err := cu.CurrentDevice()
+	if err != nil {
+		return "", errors.Wrap(err, "No current device")
+	}
+	major, minor, err := dev.ComputeCapability()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("compute_%d%d", major, minor), nil
+}
@@ -0,0 +1,131 @@
+package kernels
+
+import (
+	"math"
+	"testing"
+
+	"gorgonia.org/cu"
+)
+
+func TestGridSize(t *testing.T) {
+	cases := []struct{ n, grid int }{
+		{0, 1},
+		{1, 1},
+		{BlockSize, 1},
+		{BlockSize + 1, 2},
+		{BlockSize * maxGrid * 2, maxGrid},
+	}
+	for _, c := range cases {
+		if g := GridSize(c.n); g != c.grid {
+			t.Errorf("GridSize(%d): expected %d. Got %d", c.n, c.grid, g)
+		}
+	}
+}
+
+func TestArgs(t *testing.T) {
+	params, err := Args(cu.DevicePtr(0x1000), 3, float32(0.5), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := *(*uint64)(params[0]); v != 0x1000 {
+		t.Errorf("Expected the pointer to be passed as is. Got 0x%x", v)
+	}
+	if v := *(*int64)(params[1]); v != 3 {
+		t.Errorf("Expected ints to be passed as long long. Got %d", v)
+	}
+	if v := *(*float64)(params[2]); v != 0.5 {
+		t.Errorf("Expected floats to be passed as double. Got %v", v)
+	}
+	if v := *(*int64)(params[3]); v != 1 {
+		t.Errorf("Expected true to be passed as 1. Got %d", v)
+	}
+
+	if _, err = Args("hello"); err == nil {
+		t.Errorf("Expected an error for unsupported arguments")
+	}
+}
+
+func TestAdam(t *testing.T) {
+	withContext(t, func() {
+		params := []float32{1, 2, 3, 4}
+		grads := []float32{0.1, -0.2, 0.3, -0.4}
+		p := upload32(t, params)
+		g := upload32(t, grads)
+		m := upload32(t, make([]float32, 4))
+		v := upload32(t, make([]float32, 4))
+		defer cu.MemFree(p)
+		defer cu.MemFree(g)
+		defer cu.MemFree(m)
+		defer cu.MemFree(v)
+
+		cfg := AdamConfig{LR: 0.1, Beta1: 0.9, Beta2: 0.999, Eps: 1e-8}
+		if err := Adam(cu.DtFloat32, p, g, m, v, 4, 1, cfg, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+
+		// on the first step, the bias corrected update is simply the sign of the gradient
+		correct := make([]float32, 4)
+		for i := range params {
+			correct[i] = params[i] - float32(cfg.LR*math.Copysign(1, float64(grads[i])))
+		}
+		if got := download32(t, p, 4); !close32(got, correct, 1e-4) {
+			t.Errorf("Expected %v. Got %v", correct, got)
+		}
+	})
+}
+
+func TestChunks(t *testing.T) {
+	lists := [][]cu.DevicePtr{
+		{0x1000, 0x8000},
+		{0x2000, 0x9000},
+	}
+	chunks, err := Chunks(cu.DtFloat32, lists, []int{10, 4}, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	correct := []Chunk{
+		{Tensor: 0, Offset: 0, N: 4, Ptrs: [MaxTensorLists]cu.DevicePtr{0x1000, 0x2000}},
+		{Tensor: 0, Offset: 4, N: 4, Ptrs: [MaxTensorLists]cu.DevicePtr{0x1010, 0x2010}},
+		{Tensor: 0, Offset: 8, N: 2, Ptrs: [MaxTensorLists]cu.DevicePtr{0x1020, 0x2020}},
+		{Tensor: 1, Offset: 0, N: 4, Ptrs: [MaxTensorLists]cu.DevicePtr{0x8000, 0x9000}},
+	}
+	if len(chunks) != len(correct) {
+		t.Fatalf("Expected %d chunks. Got %d", len(correct), len(chunks))
+	}
+	for i := range correct {
+		if chunks[i] != correct[i] {
+			t.Errorf("Chunk %d: expected %+v. Got %+v", i, correct[i], chunks[i])
+		}
+	}
+
+	if _, err = Chunks(cu.DtFloat32, [][]cu.DevicePtr{{0x1000}}, []int{10, 4}, 4); err == nil {
+		t.Errorf("Expected an error when the lists and sizes do not match")
+	}
+	if _, err = Chunks(cu.DtFloat32, make([][]cu.DevicePtr, MaxTensorLists+1), nil, 4); err == nil {
+		t.Errorf("Expected an error when there are too many lists")
+	}
+}
+
+func TestTensorLists(t *testing.T) {
+	params := []cu.DeviceTensor{
+		cu.NewDeviceTensor(0x1000, cu.DtFloat32, 2, 3),
+		cu.NewDeviceTensor(0x2000, cu.DtFloat32, 4),
+	}
+	grads := []cu.DeviceTensor{
+		cu.NewDeviceTensor(0x3000, cu.DtFloat32, 6),
+		cu.NewDeviceTensor(0x4000, cu.DtFloat32, 2, 2),
+	}
+	dt, ptrs, sizes, err := TensorLists(params, grads)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dt != cu.DtFloat32 || len(ptrs) != 2 || ptrs[1][1] != 0x4000 || sizes[0] != 6 || sizes[1] != 4 {
+		t.Errorf("Unexpected result %v %v %v", dt, ptrs, sizes)
+	}
+
+	view, _ := params[0].Transpose()
+	if _, _, _, err = TensorLists([]cu.DeviceTensor{view, params[1]}, grads); err == nil {
+		t.Errorf("Expected an error for non-contiguous tensors")
+	}
+}
@@ -0,0 +1,425 @@
+package kernels
+
+import (
+	"sort"
+	"sync"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// kvSource holds the kernels that move keys and values in and out of the blocks of a KVCache. table holds the
+// addresses of the blocks of a sequence. A block holds, for each layer, the keys then the values of blockSize tokens,
+// each token being a row of row elements.
+var kvSource = &Source{
+	Name:   "kvcache",
+	Dtypes: []cu.Dtype{cu.DtFloat32, cu.DtFloat64},
+	Code: `
+__device__ __forceinline__ T* kv_slot(const unsigned long long* table, long long tok, long long e, long long layer, long long blockSize, long long row) {
+	T* block = (T*)table[tok / blockSize];
+	return block + (layer * 2 * blockSize + tok % blockSize) * row + e;
+}
+
+extern "C" __global__ void kv_write(const unsigned long long* table, const T* k, const T* v, long long start, long long n,
+	long long layer, long long blockSize, long long row) {
+	GRID_STRIDE(i, n * row) {
+		T* slot = kv_slot(table, start + i / row, i % row, layer, blockSize, row);
+		slot[0] = k[i];
+		slot[blockSize * row] = v[i];
+	}
+}
+
+extern "C" __global__ void kv_gather(T* k, T* v, const unsigned long long* table, long long start, long long n,
+	long long layer, long long blockSize, long long row) {
+	GRID_STRIDE(i, n * row) {
+		const T* slot = kv_slot(table, start + i / row, i % row, layer, blockSize, row);
+		k[i] = slot[0];
+		v[i] = slot[blockSize * row];
+	}
+}
+`,
+}
+
+// KVCacheConfig describes the keys and values stored by a KVCache.
+type KVCacheConfig struct {
+	Layers    int
+	Heads     int
+	HeadDim   int
+	BlockSize int // number of tokens per block
+	Dtype     cu.Dtype
+}
+
+// blockBytes returns the size of a block.
+func (c KVCacheConfig) blockBytes() int64 {
+	return 2 * int64(c.Layers) * int64(c.BlockSize) * int64(c.Heads) * int64(c.HeadDim) * c.Dtype.Size()
+}
+
+// KVCacheStats are the statistics of a KVCache.
+type KVCacheStats struct {
+	Sequences int
+	Blocks    int // number of blocks held by the sequences
+	Tokens    int // number of tokens held by the sequences
+	Pending   int // number of blocks and tables freed while work using them may still be running
+}
+
+// kvSeq is a sequence of a KVCache.
+type kvSeq struct {
+	blocks   []cu.DevicePtr
+	table    cu.DevicePtr // the addresses of the blocks, in device memory
+	tableCap int
+	stale    bool // the table does not hold the addresses of all the blocks yet
+	len      int
+
+	used map[cu.Stream]*cu.ScopedEvent // the last work that used the sequence, per stream
+}
+
+// kvPending is memory freed by a sequence, which returns to the pool once the work that used it has completed.
+type kvPending struct {
+	ptrs   []cu.DevicePtr
+	events []*cu.ScopedEvent
+}
+
+// KVCache stores the keys and values of the attention layers of sequences being decoded, in paged device storage:
+// the tokens of a sequence are stored in fixed size blocks allocated from a MemPool, so that sequences grow without
+// being copied, and the blocks of evicted sequences are reused by the others.
+//
+// Keys and values are given and gathered as contiguous (tokens, Heads, HeadDim) tensors.
+// Like MemPool, a KVCache must be used from a thread where the context of the pool is current.
+//
+// MemPool is not ordered with streams, so the blocks freed by Truncate and Evict do not return to the pool until the
+// work that Write and Gather submitted on them has completed. They are returned by the following calls to Append, and
+// by Close, which waits for them.
+type KVCache struct {
+	cfg    KVCacheConfig
+	pool   *cu.MemPool
+	events *cu.EventPool
+
+	sync.Mutex
+	seqs    map[int]*kvSeq
+	pending []kvPending
+}
+
+// NewKVCache creates a KVCache whose blocks are allocated from pool.
+func NewKVCache(pool *cu.MemPool, cfg KVCacheConfig) (*KVCache, error) {
+	if pool == nil {
+		return nil, errors.New("NewKVCache requires a MemPool")
+	}
+	if cfg.Layers < 1 || cfg.Heads < 1 || cfg.HeadDim < 1 || cfg.BlockSize < 1 {
+		return nil, errors.Errorf("Invalid KVCacheConfig %+v", cfg)
+	}
+	if cfg.Dtype != cu.DtFloat32 && cfg.Dtype != cu.DtFloat64 {
+		return nil, errors.Errorf("Unsupported element type %v", cfg.Dtype)
+	}
+	return &KVCache{cfg: cfg, pool: pool, events: cu.NewEventPool(cu.DisableTiming), seqs: make(map[int]*kvSeq)}, nil
+}
+
+// Config returns the configuration of the cache.
+func (c *KVCache) Config() KVCacheConfig { return c.cfg }
+
+// Append reserves n more tokens for the sequence, allocating blocks as required, and returns the position of the
+// first token reserved. The sequence is created if it does not exist.
+func (c *KVCache) Append(seq, n int) (start int, err error) {
+	if n < 0 {
+		return 0, errors.Errorf("Cannot append %d tokens", n)
+	}
+	c.Lock()
+	defer c.Unlock()
+	if err = c.reclaim(false); err != nil {
+		return 0, err
+	}
+	s, ok := c.seqs[seq]
+	if !ok {
+		s = new(kvSeq)
+	}
+	start = s.len
+	need := (s.len + n + c.cfg.BlockSize - 1) / c.cfg.BlockSize
+	had := len(s.blocks)
+	for len(s.blocks) < need && err == nil {
+		var block cu.DevicePtr
+		if block, err = c.pool.Alloc(c.cfg.blockBytes()); err != nil {
+			err = errors.Wrapf(err, "Unable to allocate a block for sequence %d", seq)
+			break
+		}
+		s.blocks = append(s.blocks, block)
+	}
+	if err == nil && len(s.blocks) > had {
+		err = c.grow(s)
+	}
+	if err != nil {
+		// the blocks allocated here have not been used by any work yet
+		for _, block := range s.blocks[had:] {
+			if e := c.pool.Free(block); e != nil {
+				err = errors.Wrapf(err, "Unable to free a block (%v)", e)
+			}
+		}
+		s.blocks = s.blocks[:had]
+		return 0, err
+	}
+	c.seqs[seq] = s
+	s.len += n
+	return start, nil
+}
+
+// Len returns the number of tokens of the sequence, or 0 if it does not exist.
+func (c *KVCache) Len(seq int) int {
+	c.Lock()
+	defer c.Unlock()
+	if s, ok := c.seqs[seq]; ok {
+		return s.len
+	}
+	return 0
+}
+
+// Sequences returns the sequences in the cache, in increasing order.
+func (c *KVCache) Sequences() []int {
+	c.Lock()
+	defer c.Unlock()
+	retVal := make([]int, 0, len(c.seqs))
+	for seq := range c.seqs {
+		retVal = append(retVal, seq)
+	}
+	sort.Ints(retVal)
+	return retVal
+}
+
+// Truncate drops the tokens of the sequence from position n onwards, and frees the blocks that are no longer used
+// once the work submitted on them has completed.
+func (c *KVCache) Truncate(seq, n int) error {
+	c.Lock()
+	defer c.Unlock()
+	s, ok := c.seqs[seq]
+	if !ok {
+		return errors.Errorf("Unknown sequence %d", seq)
+	}
+	if n < 0 || n > s.len {
+		return errors.Errorf("Cannot truncate sequence %d of %d tokens to %d tokens", seq, s.len, n)
+	}
+	s.len = n
+	return c.shrink(s, (n+c.cfg.BlockSize-1)/c.cfg.BlockSize)
+}
+
+// Evict removes the sequence from the cache, and returns its blocks to the pool once the work submitted on them has
+// completed.
+func (c *KVCache) Evict(seq int) error {
+	c.Lock()
+	defer c.Unlock()
+	s, ok := c.seqs[seq]
+	if !ok {
+		return errors.Errorf("Unknown sequence %d", seq)
+	}
+	delete(c.seqs, seq)
+	return c.release(s)
+}
+
+// Stats returns the statistics of the cache.
+func (c *KVCache) Stats() KVCacheStats {
+	c.Lock()
+	defer c.Unlock()
+	stats := KVCacheStats{Sequences: len(c.seqs)}
+	for _, s := range c.seqs {
+		stats.Blocks += len(s.blocks)
+		stats.Tokens += s.len
+	}
+	for _, p := range c.pending {
+		stats.Pending += len(p.ptrs)
+	}
+	return stats
+}
+
+// Write stores the keys and values of n tokens of the sequence at the given layer, starting at position start, on
+// the given stream. k and v are contiguous (n, Heads, HeadDim) tensors. The tokens must have been reserved by Append.
+func (c *KVCache) Write(seq, layer, start int, k, v cu.DeviceTensor, stream cu.Stream) error {
+	return c.transfer("kv_write", seq, layer, start, k, v, stream)
+}
+
+// Gather copies the keys and values of n tokens of the sequence at the given layer, starting at position start, to
+// k and v, contiguous (n, Heads, HeadDim) tensors, on the given stream. It reconstructs the contiguous views of the
+// keys and values that attention kernels expect.
+func (c *KVCache) Gather(seq, layer, start int, k, v cu.DeviceTensor, stream cu.Stream) error {
+	return c.transfer("kv_gather", seq, layer, start, k, v, stream)
+}
+
+// Close evicts all the sequences, and waits for the work submitted on them to complete before returning their blocks
+// to the pool.
+func (c *KVCache) Close() error {
+	c.Lock()
+	defer c.Unlock()
+	var err error
+	for seq, s := range c.seqs {
+		if e := c.release(s); e != nil && err == nil {
+			err = e
+		}
+		delete(c.seqs, seq)
+	}
+	if e := c.reclaim(true); e != nil && err == nil {
+		err = e
+	}
+	if e := c.events.Destroy(); e != nil && err == nil {
+		err = e
+	}
+	return err
+}
+
+func (c *KVCache) transfer(name string, seq, layer, start int, k, v cu.DeviceTensor, stream cu.Stream) error {
+	row := c.cfg.Heads * c.cfg.HeadDim
+	if k.Dims() == 0 || !k.IsContiguous() || !sameShape(k, v) || !v.IsContiguous() || k.Len()%row != 0 {
+		return errors.Errorf("Expected the keys and values to be contiguous (tokens, %d, %d) tensors. Got %v and %v", c.cfg.Heads, c.cfg.HeadDim, k.Shape, v.Shape)
+	}
+	if k.Dtype != c.cfg.Dtype || v.Dtype != c.cfg.Dtype {
+		return errors.Errorf("Expected %v keys and values. Got %v and %v", c.cfg.Dtype, k.Dtype, v.Dtype)
+	}
+	if layer < 0 || layer >= c.cfg.Layers {
+		return errors.Errorf("Layer %d out of range [0, %d)", layer, c.cfg.Layers)
+	}
+	n := k.Len() / row
+
+	c.Lock()
+	defer c.Unlock()
+	s, ok := c.seqs[seq]
+	if !ok {
+		return errors.Errorf("Unknown sequence %d", seq)
+	}
+	if start < 0 || start+n > s.len {
+		return errors.Errorf("Tokens [%d, %d) out of range of sequence %d of %d tokens", start, start+n, seq, s.len)
+	}
+	if s.stale {
+		// the copy is ordered before the launch, and after the work that read the table on the stream
+		if err := cu.MemcpyHtoDAsync(s.table, unsafe.Pointer(&s.blocks[0]), int64(len(s.blocks))*8, stream); err != nil {
+			return errors.Wrapf(err, "Unable to upload the block table of sequence %d", seq)
+		}
+		s.stale = false
+	}
+	var err error
+	if name == "kv_write" {
+		err = launch(kvSource, c.cfg.Dtype, name, n*row, stream, s.table, k.Ptr, v.Ptr, start, n, layer, c.cfg.BlockSize, row)
+	} else {
+		err = launch(kvSource, c.cfg.Dtype, name, n*row, stream, k.Ptr, v.Ptr, s.table, start, n, layer, c.cfg.BlockSize, row)
+	}
+	if err != nil {
+		return err
+	}
+	return c.use(s, stream)
+}
+
+// use records that work using the sequence has been submitted on the stream. The lock is expected to be held.
+func (c *KVCache) use(s *kvSeq, stream cu.Stream) error {
+	ev, err := c.events.RecordScoped(stream)
+	if err != nil {
+		return errors.Wrap(err, "Unable to record the use of the sequence")
+	}
+	if s.used == nil {
+		s.used = make(map[cu.Stream]*cu.ScopedEvent)
+	}
+	s.used[stream] = ev
+	return nil
+}
+
+// grow grows the table of the sequence if it cannot hold the addresses of all the blocks, and marks it stale: the
+// addresses are copied by the next transfer, on its stream. The lock is expected to be held.
+func (c *KVCache) grow(s *kvSeq) error {
+	s.stale = true
+	if len(s.blocks) <= s.tableCap {
+		return nil
+	}
+	capacity := 2 * len(s.blocks)
+	table, err := c.pool.Alloc(int64(capacity) * 8)
+	if err != nil {
+		return errors.Wrap(err, "Unable to allocate a block table")
+	}
+	if s.table != 0 {
+		if err = c.free(s, s.table); err != nil {
+			if e := c.pool.Free(table); e != nil {
+				err = errors.Wrapf(err, "Unable to free a block table (%v)", e)
+			}
+			return err
+		}
+	}
+	s.table, s.tableCap = table, capacity
+	return nil
+}
+
+// shrink frees the blocks of the sequence beyond the first n. The lock is expected to be held.
+func (c *KVCache) shrink(s *kvSeq, n int) error {
+	if len(s.blocks) <= n {
+		return nil
+	}
+	err := c.free(s, s.blocks[n:]...)
+	s.blocks = s.blocks[:n]
+	return err
+}
+
+// release frees the blocks and the table of the sequence. The lock is expected to be held.
+func (c *KVCache) release(s *kvSeq) error {
+	ptrs := s.blocks
+	if s.table != 0 {
+		ptrs = append(ptrs, s.table)
+	}
+	err := c.free(s, ptrs...)
+	s.blocks, s.table, s.tableCap, s.used = nil, 0, 0, nil
+	return err
+}
+
+// free returns memory of the sequence to the pool, once the work submitted on the sequence has completed.
+// The lock is expected to be held.
+func (c *KVCache) free(s *kvSeq, ptrs ...cu.DevicePtr) error {
+	p := kvPending{ptrs: append([]cu.DevicePtr(nil), ptrs...)}
+	for _, ev := range s.used {
+		p.events = append(p.events, ev)
+	}
+	if len(p.events) == 0 {
+		return c.returnToPool(p.ptrs)
+	}
+	c.pending = append(c.pending, p)
+	return nil
+}
+
+// reclaim returns the pending memory whose work has completed to the pool. If wait is true, it waits for the work of
+// all the pending memory. The lock is expected to be held.
+func (c *KVCache) reclaim(wait bool) error {
+	var err error
+	pending := c.pending[:0]
+	for _, p := range c.pending {
+		done, e := c.completed(p.events, wait)
+		if e != nil || !done {
+			pending = append(pending, p)
+		} else {
+			e = c.returnToPool(p.ptrs)
+		}
+		if e != nil && err == nil {
+			err = e
+		}
+	}
+	for i := len(pending); i < len(c.pending); i++ {
+		c.pending[i] = kvPending{}
+	}
+	c.pending = pending
+	return err
+}
+
+// completed returns true if the work recorded before the events has completed, waiting for it if wait is true.
+func (c *KVCache) completed(events []*cu.ScopedEvent, wait bool) (bool, error) {
+	for _, ev := range events {
+		if wait {
+			if err := ev.Wait(); err != nil {
+				return false, err
+			}
+			continue
+		}
+		if done, err := ev.Done(); err != nil || !done {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// returnToPool frees the memory to the pool.
+func (c *KVCache) returnToPool(ptrs []cu.DevicePtr) error {
+	var err error
+	for _, ptr := range ptrs {
+		if e := c.pool.Free(ptr); e != nil && err == nil {
+			err = errors.Wrap(e, "Unable to return memory to the pool")
+		}
+	}
+	return err
+}
@@ -0,0 +1,94 @@
+package kernels
+
+import (
+	"testing"
+
+	"gorgonia.org/cu"
+)
+
+func TestKVCache(t *testing.T) {
+	withContext(t, func() {
+		pool := cu.NewMemPool()
+		defer pool.Close()
+		cfg := KVCacheConfig{Layers: 2, Heads: 1, HeadDim: 2, BlockSize: 2, Dtype: cu.DtFloat32}
+		c, err := NewKVCache(pool, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// 3 tokens span 2 blocks
+		start, err := c.Append(7, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if start != 0 || c.Len(7) != 3 {
+			t.Fatalf("Expected 3 tokens from 0. Got %d from %d", c.Len(7), start)
+		}
+		if s := c.Stats(); s != (KVCacheStats{Sequences: 1, Blocks: 2, Tokens: 3}) {
+			t.Errorf("Unexpected stats %+v", s)
+		}
+
+		pk := upload32(t, []float32{1, 2, 3, 4, 5, 6})
+		pv := upload32(t, []float32{-1, -2, -3, -4, -5, -6})
+		pk2 := upload32(t, make([]float32, 4))
+		pv2 := upload32(t, make([]float32, 4))
+		for _, mem := range []cu.DevicePtr{pk, pv, pk2, pv2} {
+			defer cu.MemFree(mem)
+		}
+		k := cu.NewDeviceTensor(pk, cu.DtFloat32, 3, 1, 2)
+		v := cu.NewDeviceTensor(pv, cu.DtFloat32, 3, 1, 2)
+		if err = c.Write(7, 1, 0, k, v, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		if err = c.Write(7, 1, 1, k, v, cu.NoStream); err == nil {
+			t.Error("Expected an error writing past the end of the sequence")
+		}
+
+		// the last two tokens straddle the blocks
+		k2 := cu.NewDeviceTensor(pk2, cu.DtFloat32, 2, 1, 2)
+		v2 := cu.NewDeviceTensor(pv2, cu.DtFloat32, 2, 1, 2)
+		if err = c.Gather(7, 1, 1, k2, v2, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		if got, correct := download32(t, pk2, 4), []float32{3, 4, 5, 6}; !close32(got, correct, 0) {
+			t.Errorf("Expected the keys %v. Got %v", correct, got)
+		}
+		if got, correct := download32(t, pv2, 4), []float32{-3, -4, -5, -6}; !close32(got, correct, 0) {
+			t.Errorf("Expected the values %v. Got %v", correct, got)
+		}
+
+		if err = c.Truncate(7, 2); err != nil {
+			t.Fatal(err)
+		}
+		if s := c.Stats(); s.Blocks != 1 {
+			t.Errorf("Expected one block after truncating. Got %d", s.Blocks)
+		}
+		if err = c.Evict(7); err != nil {
+			t.Fatal(err)
+		}
+		if err = c.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if inUse := pool.Stats().InUse; inUse != 0 {
+			t.Errorf("Expected the blocks to be returned to the pool. %d bytes are in use", inUse)
+		}
+	})
+}
+
+func TestKVCacheAppendFailure(t *testing.T) {
+	// the budget of the pool is too small for a block, so no device memory is allocated
+	pool := cu.NewMemPool(cu.WithBudget(1))
+	c, err := NewKVCache(pool, KVCacheConfig{Layers: 1, Heads: 1, HeadDim: 2, BlockSize: 2, Dtype: cu.DtFloat32})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.Append(3, 1); err == nil {
+		t.Fatal("Expected an error appending beyond the budget of the pool")
+	}
+	if seqs := c.Sequences(); len(seqs) != 0 {
+		t.Errorf("Expected the failed sequence to be removed. Got the sequences %v", seqs)
+	}
+	if s := c.Stats(); s != (KVCacheStats{}) {
+		t.Errorf("Unexpected stats %+v", s)
+	}
+}
@@ -0,0 +1,111 @@
+package kernels
+
+import (
+	"math"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+const (
+	// BlockSize is the number of threads per block used by Launch.
+	BlockSize = 256
+
+	// maxGrid is the maximum number of blocks launched by Launch. The kernels use grid-stride loops, so larger inputs are still covered.
+	maxGrid = 65535
+)
+
+// GridSize returns the number of blocks Launch uses for n elements.
+func GridSize(n int) int {
+	g := (n + BlockSize - 1) / BlockSize
+	switch {
+	case g < 1:
+		return 1
+	case g > maxGrid:
+		return maxGrid
+	}
+	return g
+}
+
+// Launch launches a kernel that processes n elements with a grid-stride loop, on the given stream.
+//
+// The arguments are marshalled as 8 byte values. The following types are accepted:
+// cu.DevicePtr, uintptr, int, int64, uint64, bool (as long long), float32 and float64 (as double).
+func Launch(fn cu.Function, n int, stream cu.Stream, args ...interface{}) error {
+	params, err := Args(args...)
+	if err != nil {
+		return err
+	}
+	return fn.Launch(GridSize(n), 1, 1, BlockSize, 1, 1, 0, stream, params)
+}
+
+// Args marshals the arguments into kernel parameters. See Launch for the accepted types.
+func Args(args ...interface{}) ([]unsafe.Pointer, error) {
+	vals := make([]uint64, len(args))
+	retVal := make([]unsafe.Pointer, len(args))
+	for i, arg := range args {
+		switch a := arg.(type) {
+		case cu.DevicePtr:
+			vals[i] = uint64(a)
+		case uintptr:
+			vals[i] = uint64(a)
+		case int:
+			vals[i] = uint64(int64(a))
+		case int64:
+			vals[i] = uint64(a)
+		case uint64:
+			vals[i] = a
+		case bool:
+			if a {
+				vals[i] = 1
+			}
+		case float32:
+			vals[i] = math.Float64bits(float64(a))
+		case float64:
+			vals[i] = math.Float64bits(a)
+		default:
+			return nil, errors.Errorf("Unsupported kernel argument %d of type %T", i, arg)
+		}
+		retVal[i] = unsafe.Pointer(&vals[i])
+	}
+	return retVal, nil
+}
+
+// launch looks up the named kernel in the Default library and launches it.
+func launch(src *Source, dt cu.Dtype, name string, n int, stream cu.Stream, args ...interface{}) error {
+	if n <= 0 {
+		return nil
+	}
+	fn, err := Default.Function(src, dt, name)
+	if err != nil {
+		return err
+	}
+	if err = Launch(fn, n, stream, args...); err != nil {
+		return errors.Wrapf(err, "Unable to launch %v", name)
+	}
+	return nil
+}
+
+// launchRows is like launch, but launches one block per row (up to maxGrid blocks) for kernels that reduce over rows.
+func launchRows(src *Source, dt cu.Dtype, name string, rows int, stream cu.Stream, args ...interface{}) error {
+	if rows <= 0 {
+		return nil
+	}
+	fn, err := Default.Function(src, dt, name)
+	if err != nil {
+		return err
+	}
+	params, err := Args(args...)
+	if err != nil {
+		return err
+	}
+	grid := rows
+	if grid > maxGrid {
+		grid = maxGrid
+	}
+	if err = fn.Launch(grid, 1, 1, BlockSize, 1, 1, 0, stream, params); err != nil {
+		return errors.Wrapf(err, "Unable to launch %v", name)
+	}
+	return nil
+}
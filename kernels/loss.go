@@ -0,0 +1,145 @@
+package kernels
+
+import (
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// lossSource holds the loss and metric kernels. Each row of the logits is processed by one block.
+// Labels are int32 class indices; rows with a label that is negative or out of range are ignored.
+var lossSource = &Source{
+	Name:   "loss",
+	Dtypes: []cu.Dtype{cu.DtFloat32, cu.DtFloat64},
+	Code: `
+// rowLogSumExp returns the maximum and the log-sum-exp of the row in every thread of the block.
+__device__ void rowLogSumExp(const T* x, long long classes, double* max, double* lse) {
+	__shared__ double bcast;
+	double m = -INFINITY;
+	BLOCK_STRIDE(j, classes) {
+		m = fmax(m, (double)ld(x, j));
+	}
+	m = blockReduceMax(m);
+	if (threadIdx.x == 0) {
+		bcast = m;
+	}
+	__syncthreads();
+	m = bcast;
+
+	double s = 0;
+	BLOCK_STRIDE(j, classes) {
+		s += exp((double)ld(x, j) - m);
+	}
+	s = blockReduceSum(s);
+	if (threadIdx.x == 0) {
+		bcast = log(s);
+	}
+	__syncthreads();
+	*max = m;
+	*lse = m + bcast;
+}
+
+// xent writes the cross-entropy of each row to loss. If dx is not null, the gradient of the loss (scaled by scale)
+// with regards to the logits is written to dx as well.
+extern "C" __global__ void xent(const T* x, const int* labels, T* loss, T* dx, long long n, long long classes, double scale) {
+	ROWS(r, n) {
+		const T* row = x + r * classes;
+		int label = labels[r];
+		bool skip = label < 0 || label >= classes;
+		double m, lse;
+		rowLogSumExp(row, classes, &m, &lse);
+		if (threadIdx.x == 0) {
+			st(loss, r, skip ? 0 : (acc_t)(lse - (double)ld(row, label)));
+		}
+		if (dx != 0) {
+			BLOCK_STRIDE(j, classes) {
+				double g = skip ? 0 : exp((double)ld(row, j) - lse) - (j == label ? 1.0 : 0.0);
+				st(dx, r * classes + j, (acc_t)(g * scale));
+			}
+		}
+	}
+}
+
+// xent_backward writes the gradient of the cross-entropy with regards to the logits to dx, given the gradient dloss of each row.
+extern "C" __global__ void xent_backward(const T* x, const int* labels, const T* dloss, T* dx, long long n, long long classes) {
+	ROWS(r, n) {
+		const T* row = x + r * classes;
+		int label = labels[r];
+		double m, lse;
+		rowLogSumExp(row, classes, &m, &lse);
+		double dl = (label < 0 || label >= classes) ? 0 : (double)ld(dloss, r);
+		BLOCK_STRIDE(j, classes) {
+			double g = exp((double)ld(row, j) - lse) - (j == label ? 1.0 : 0.0);
+			st(dx, r * classes + j, (acc_t)(g * dl));
+		}
+	}
+}
+
+// accuracy counts the rows whose argmax is the label. Ties are broken in favour of the lowest class.
+extern "C" __global__ void accuracy(const T* x, const int* labels, unsigned long long* correct, long long n, long long classes) {
+	__shared__ double bcast;
+	ROWS(r, n) {
+		const T* row = x + r * classes;
+		int label = labels[r];
+		if (label < 0 || label >= classes) {
+			continue;
+		}
+		double m = -INFINITY;
+		BLOCK_STRIDE(j, classes) {
+			m = fmax(m, (double)ld(row, j));
+		}
+		m = blockReduceMax(m);
+		if (threadIdx.x == 0) {
+			bcast = m;
+		}
+		__syncthreads();
+		m = bcast;
+
+		// the label is the argmax if it holds the maximum, and no lower class does
+		double first = classes;
+		BLOCK_STRIDE(j, classes) {
+			if ((double)ld(row, j) == m && j < first) {
+				first = j;
+			}
+		}
+		first = -blockReduceMax(-first);
+		if (threadIdx.x == 0 && (long long)first == label) {
+			atomicAdd(correct, 1ULL);
+		}
+	}
+}
+`,
+}
+
+// SoftmaxCrossEntropy computes the cross-entropy between softmax(logits) and the labels, for each of the n rows.
+//
+// logits is a row-major n×classes matrix, labels holds n int32 class indices and loss receives n values.
+// Rows whose label is negative (or out of range) are ignored and get a loss of 0. The softmax is computed with the log-sum-exp trick,
+// so large logits do not overflow.
+func SoftmaxCrossEntropy(dt cu.Dtype, logits, labels, loss cu.DevicePtr, n, classes int, stream cu.Stream) error {
+	return launchRows(lossSource, dt, "xent", n, stream, logits, labels, loss, cu.DevicePtr(0), n, classes, 1.0)
+}
+
+// SoftmaxCrossEntropyWithGrad is like SoftmaxCrossEntropy, but also writes the gradient of the loss with regards to
+// the logits into dlogits (an n×classes matrix), in the same pass. The gradient is multiplied by scale; use 1/n for
+// the gradient of the mean loss.
+func SoftmaxCrossEntropyWithGrad(dt cu.Dtype, logits, labels, loss, dlogits cu.DevicePtr, n, classes int, scale float64, stream cu.Stream) error {
+	if dlogits == 0 {
+		return errors.New("SoftmaxCrossEntropyWithGrad requires a gradient buffer")
+	}
+	return launchRows(lossSource, dt, "xent", n, stream, logits, labels, loss, dlogits, n, classes, scale)
+}
+
+// SoftmaxCrossEntropyBackward computes the gradient of the cross-entropy with regards to the logits into dlogits,
+// given dloss, the gradient with regards to the loss of each of the n rows.
+func SoftmaxCrossEntropyBackward(dt cu.Dtype, logits, labels, dloss, dlogits cu.DevicePtr, n, classes int, stream cu.Stream) error {
+	return launchRows(lossSource, dt, "xent_backward", n, stream, logits, labels, dloss, dlogits, n, classes)
+}
+
+// Accuracy counts the rows of logits whose argmax is the label. The count is written into correct, a device uint64,
+// which is cleared first. Rows with a negative label are not counted.
+func Accuracy(dt cu.Dtype, logits, labels, correct cu.DevicePtr, n, classes int, stream cu.Stream) error {
+	if err := cu.MemsetD8Async(correct, 0, 8, stream); err != nil {
+		return errors.Wrap(err, "Unable to clear the count")
+	}
+	return launchRows(lossSource, dt, "accuracy", n, stream, logits, labels, correct, n, classes)
+}
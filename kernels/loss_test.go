@@ -0,0 +1,88 @@
+package kernels
+
+import (
+	"math"
+	"testing"
+	"unsafe"
+
+	"gorgonia.org/cu"
+)
+
+func TestSoftmaxCrossEntropyWithGrad(t *testing.T) {
+	withContext(t, func() {
+		const n, classes = 3, 4
+		logits := []float32{
+			1, 2, 3, 4,
+			1000, 0, 0, 0, // large logits must not overflow
+			0, 0, 0, 0,
+		}
+		labels := []int32{3, 0, -1}
+
+		x := upload32(t, logits)
+		l, err := cu.MemAlloc(n * 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = cu.MemcpyHtoD(l, unsafe.Pointer(&labels[0]), n*4); err != nil {
+			t.Fatal(err)
+		}
+		loss := upload32(t, make([]float32, n))
+		dx := upload32(t, make([]float32, n*classes))
+		correct, err := cu.MemAlloc(8)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, mem := range []cu.DevicePtr{x, l, loss, dx, correct} {
+			defer cu.MemFree(mem)
+		}
+
+		if err = SoftmaxCrossEntropyWithGrad(cu.DtFloat32, x, l, loss, dx, n, classes, 1, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		if err = Accuracy(cu.DtFloat32, x, l, correct, n, classes, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+
+		// reference
+		wantLoss := make([]float32, n)
+		wantGrad := make([]float32, n*classes)
+		for r := 0; r < n; r++ {
+			if labels[r] < 0 {
+				continue
+			}
+			row := logits[r*classes : (r+1)*classes]
+			max := math.Inf(-1)
+			for _, v := range row {
+				max = math.Max(max, float64(v))
+			}
+			var sum float64
+			for _, v := range row {
+				sum += math.Exp(float64(v) - max)
+			}
+			lse := max + math.Log(sum)
+			wantLoss[r] = float32(lse - float64(row[labels[r]]))
+			for j, v := range row {
+				g := math.Exp(float64(v) - lse)
+				if j == int(labels[r]) {
+					g--
+				}
+				wantGrad[r*classes+j] = float32(g)
+			}
+		}
+
+		if got := download32(t, loss, n); !close32(got, wantLoss, 1e-5) {
+			t.Errorf("Expected loss %v. Got %v", wantLoss, got)
+		}
+		if got := download32(t, dx, n*classes); !close32(got, wantGrad, 1e-5) {
+			t.Errorf("Expected gradient %v. Got %v", wantGrad, got)
+		}
+
+		var count uint64
+		if err = cu.MemcpyDtoH(unsafe.Pointer(&count), correct, 8); err != nil {
+			t.Fatal(err)
+		}
+		if count != 2 {
+			t.Errorf("Expected 2 correct rows. Got %d", count)
+		}
+	})
+}
@@ -0,0 +1,183 @@
+package kernels
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// ReduceOp is a reduction.
+type ReduceOp int
+
+const (
+	ReduceSum  ReduceOp = iota // sum of the elements; 0 if there are none
+	ReduceMean                 // mean of the elements; 0 if there are none
+	ReduceMax                  // maximum of the elements, NaN if any is NaN; -Inf if there are none
+)
+
+func (op ReduceOp) String() string {
+	switch op {
+	case ReduceSum:
+		return "Sum"
+	case ReduceMean:
+		return "Mean"
+	case ReduceMax:
+		return "Max"
+	}
+	return fmt.Sprintf("UnknownReduceOp:%d", int(op))
+}
+
+// maskSource holds the masking kernels. Masks are bytes: an element is selected if its mask is not zero.
+var maskSource = &Source{
+	Name:   "mask",
+	Dtypes: []cu.Dtype{cu.DtFloat32, cu.DtFloat64},
+	Code: `
+typedef unsigned char mask_t;
+
+extern "C" __global__ void where_flat(T* out, const mask_t* cond, const T* a, const T* b, long long n) {
+	GRID_STRIDE(i, n) {
+		st(out, i, cond[i] ? ld(a, i) : ld(b, i));
+	}
+}
+
+extern "C" __global__ void where_nd(T* out, const mask_t* cond, const T* a, const T* b, long long n,
+	long long s1, long long s2, long long s3,
+	long long o0, long long o1, long long o2, long long o3,
+	long long c0, long long c1, long long c2, long long c3,
+	long long a0, long long a1, long long a2, long long a3,
+	long long b0, long long b1, long long b2, long long b3) {
+	GRID_STRIDE(i, n) {
+		acc_t v = cond[nd_offset(i, s1, s2, s3, c0, c1, c2, c3)]
+			? ld(a, nd_offset(i, s1, s2, s3, a0, a1, a2, a3))
+			: ld(b, nd_offset(i, s1, s2, s3, b0, b1, b2, b3));
+		st(out, nd_offset(i, s1, s2, s3, o0, o1, o2, o3), v);
+	}
+}
+
+extern "C" __global__ void masked_fill_flat(T* out, const T* x, const mask_t* mask, long long n, double value) {
+	GRID_STRIDE(i, n) {
+		st(out, i, mask[i] ? (acc_t)value : ld(x, i));
+	}
+}
+
+extern "C" __global__ void masked_fill_nd(T* out, const T* x, const mask_t* mask, long long n, double value,
+	long long s1, long long s2, long long s3,
+	long long o0, long long o1, long long o2, long long o3,
+	long long x0, long long x1, long long x2, long long x3,
+	long long m0, long long m1, long long m2, long long m3) {
+	GRID_STRIDE(i, n) {
+		acc_t v = mask[nd_offset(i, s1, s2, s3, m0, m1, m2, m3)] ? (acc_t)value : ld(x, nd_offset(i, s1, s2, s3, x0, x1, x2, x3));
+		st(out, nd_offset(i, s1, s2, s3, o0, o1, o2, o3), v);
+	}
+}
+
+// masked_reduce reduces each row of cols elements to out[r]. Element j of row r is element r*cols+j of the iteration space.
+extern "C" __global__ void masked_reduce(T* out, const T* x, const mask_t* mask, long long rows, long long cols, long long op,
+	long long s1, long long s2, long long s3,
+	long long x0, long long x1, long long x2, long long x3,
+	long long m0, long long m1, long long m2, long long m3) {
+	ROWS(r, rows) {
+		double acc = op == 2 ? -INFINITY : 0;
+		double count = 0;
+		BLOCK_STRIDE(j, cols) {
+			long long i = r * cols + j;
+			if (mask[nd_offset(i, s1, s2, s3, m0, m1, m2, m3)]) {
+				double v = ld(x, nd_offset(i, s1, s2, s3, x0, x1, x2, x3));
+				acc = op == 2 ? max_nan(acc, v) : acc + v;
+				count++;
+			}
+		}
+		acc = op == 2 ? blockReduceMax(acc) : blockReduceSum(acc);
+		count = blockReduceSum(count);
+		if (threadIdx.x == 0) {
+			st(out, r, (acc_t)(op == 1 ? (count > 0 ? acc / count : 0) : acc));
+		}
+	}
+}
+`,
+}
+
+func checkMask(mask cu.DeviceTensor) error {
+	if mask.Dtype != cu.DtUint8 && mask.Dtype != cu.DtBool {
+		return errors.Errorf("Masks must be uint8 or bool. Got %v", mask.Dtype)
+	}
+	return nil
+}
+
+// Where computes out = cond ? a : b elementwise, on the given stream. cond is a uint8 or bool mask.
+// cond, a and b are broadcast to the shape of out.
+func Where(out, cond, a, b cu.DeviceTensor, stream cu.Stream) error {
+	if err := checkMask(cond); err != nil {
+		return errors.Wrap(err, "Where")
+	}
+	if a.Dtype != out.Dtype || b.Dtype != out.Dtype {
+		return errors.Errorf("Where: mismatched types %v = %v, %v", out.Dtype, a.Dtype, b.Dtype)
+	}
+	l, err := makeLayout(out, cond, a, b)
+	if err != nil {
+		return errors.Wrap(err, "Where")
+	}
+	return launchStrided(maskSource, out.Dtype, "where_nd", "where_flat", l, []cu.DeviceTensor{out, cond, a, b}, stream)
+}
+
+// MaskedFill computes out = mask ? value : x elementwise, on the given stream. mask is a uint8 or bool mask.
+// x and mask are broadcast to the shape of out. out may be x, to fill in place.
+//
+// This is typically used to mask attention scores with -Inf before a softmax.
+func MaskedFill(out, x, mask cu.DeviceTensor, value float64, stream cu.Stream) error {
+	if err := checkMask(mask); err != nil {
+		return errors.Wrap(err, "MaskedFill")
+	}
+	if x.Dtype != out.Dtype {
+		return errors.Errorf("MaskedFill: mismatched types %v = %v", out.Dtype, x.Dtype)
+	}
+	l, err := makeLayout(out, x, mask)
+	if err != nil {
+		return errors.Wrap(err, "MaskedFill")
+	}
+	return launchStrided(maskSource, out.Dtype, "masked_fill_nd", "masked_fill_flat", l, []cu.DeviceTensor{out, x, mask}, stream, value)
+}
+
+// MaskedReduce reduces the last dimension of x, only taking the elements whose mask is set into account.
+// mask is a uint8 or bool mask, broadcast to the shape of x. out must be contiguous, with the shape of x without its
+// last dimension.
+func MaskedReduce(op ReduceOp, out, x, mask cu.DeviceTensor, stream cu.Stream) error {
+	if op < ReduceSum || op > ReduceMax {
+		return errors.Errorf("Unknown reduction %d", int(op))
+	}
+	if err := checkMask(mask); err != nil {
+		return errors.Wrapf(err, "Masked%v", op)
+	}
+	if x.Dims() == 0 {
+		return errors.Errorf("Masked%v: cannot reduce a scalar", op)
+	}
+	if x.Dtype != out.Dtype {
+		return errors.Errorf("Masked%v: mismatched types %v = %v", op, out.Dtype, x.Dtype)
+	}
+	cols := x.Shape[x.Dims()-1]
+	rows := x.Len() / max(cols, 1)
+	if !out.IsContiguous() || out.Len() != rows {
+		return errors.Errorf("Masked%v: expected a contiguous output of %d elements. Got shape %v, strides %v", op, rows, out.Shape, out.Strides)
+	}
+
+	// the iteration space is x, in row-major order
+	space := cu.NewDeviceTensor(0, x.Dtype, x.Shape...)
+	l, err := makeLayout(space, x, mask)
+	if err != nil {
+		return errors.Wrapf(err, "Masked%v", op)
+	}
+	if len(l.shape) > maxRank {
+		return errors.Errorf("Masked%v: x and mask have more than %d dimensions that cannot be merged", op, maxRank)
+	}
+	shape, xs, ms := pad(l.shape, 1), pad(l.strides[1], 0), pad(l.strides[2], 0)
+	return launchRows(maskSource, x.Dtype, "masked_reduce", rows, stream, out.Ptr, x.Ptr, mask.Ptr, rows, cols, int(op),
+		shape[1], shape[2], shape[3], xs[0], xs[1], xs[2], xs[3], ms[0], ms[1], ms[2], ms[3])
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
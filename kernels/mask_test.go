@@ -0,0 +1,56 @@
+package kernels
+
+import (
+	"testing"
+	"unsafe"
+
+	"gorgonia.org/cu"
+)
+
+func uploadMask(t *testing.T, mask []uint8) cu.DevicePtr {
+	mem, err := cu.MemAlloc(int64(len(mask)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cu.MemcpyHtoD(mem, unsafe.Pointer(&mask[0]), int64(len(mask))); err != nil {
+		t.Fatal(err)
+	}
+	return mem
+}
+
+func TestMasking(t *testing.T) {
+	withContext(t, func() {
+		x := []float32{
+			1, 2, 3,
+			4, 5, 6,
+		}
+		px := upload32(t, x)
+		pm := uploadMask(t, []uint8{1, 0, 1}) // broadcast over the rows
+		pout := upload32(t, make([]float32, 6))
+		pred := upload32(t, make([]float32, 2))
+		for _, mem := range []cu.DevicePtr{px, pm, pout, pred} {
+			defer cu.MemFree(mem)
+		}
+
+		tx := cu.NewDeviceTensor(px, cu.DtFloat32, 2, 3)
+		mask := cu.NewDeviceTensor(pm, cu.DtUint8, 3)
+		out := cu.NewDeviceTensor(pout, cu.DtFloat32, 2, 3)
+		red := cu.NewDeviceTensor(pred, cu.DtFloat32, 2)
+
+		if err := MaskedFill(out, tx, mask, -1, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		correct := []float32{-1, 2, -1, -1, 5, -1}
+		if got := download32(t, pout, 6); !close32(got, correct, 0) {
+			t.Errorf("MaskedFill: expected %v. Got %v", correct, got)
+		}
+
+		if err := MaskedReduce(ReduceMean, red, tx, mask, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		correct = []float32{2, 5}
+		if got := download32(t, pred, 2); !close32(got, correct, 1e-6) {
+			t.Errorf("MaskedReduce: expected %v. Got %v", correct, got)
+		}
+	})
+}
@@ -0,0 +1,251 @@
+package kernels
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+const (
+	// MaxTensorLists is the maximum number of tensor lists a multi-tensor kernel can operate on.
+	MaxTensorLists = 5
+
+	// DefaultChunkSize is the default number of elements in a chunk.
+	DefaultChunkSize = 1 << 16
+
+	// chunkWords is the number of 8 byte words in the device representation of a Chunk (struct mta_chunk).
+	chunkWords = MaxTensorLists + 2
+)
+
+// Chunk is a contiguous range of elements of a tensor, processed by a single block of a multi-tensor launch.
+type Chunk struct {
+	Tensor int                          // index of the tensor the chunk belongs to
+	Offset int                          // offset (in elements) of the chunk in the tensor
+	N      int                          // number of elements in the chunk
+	Ptrs   [MaxTensorLists]cu.DevicePtr // address of the first element of the chunk, in each list
+}
+
+// Chunks splits the tensors into chunks of at most chunkSize elements.
+//
+// lists holds the tensor lists that are operated on together: lists[l][t] is the address of tensor t in list l
+// (for example, the parameters, gradients and moments of an optimizer). All the lists hold tensors of type dt,
+// and tensor t of every list has sizes[t] elements.
+func Chunks(dt cu.Dtype, lists [][]cu.DevicePtr, sizes []int, chunkSize int) ([]Chunk, error) {
+	if len(lists) == 0 || len(lists) > MaxTensorLists {
+		return nil, errors.Errorf("Expected between 1 and %d tensor lists. Got %d", MaxTensorLists, len(lists))
+	}
+	for l, list := range lists {
+		if len(list) != len(sizes) {
+			return nil, errors.Errorf("Expected %d tensors in list %d. Got %d", len(sizes), l, len(list))
+		}
+	}
+	if chunkSize <= 0 {
+		return nil, errors.Errorf("Invalid chunk size %d", chunkSize)
+	}
+	elemSize := uintptr(dt.Size())
+	if elemSize == 0 {
+		return nil, errors.Errorf("Unsupported Dtype %v", dt)
+	}
+
+	var retVal []Chunk
+	for t, size := range sizes {
+		for off := 0; off < size; off += chunkSize {
+			c := Chunk{Tensor: t, Offset: off, N: size - off}
+			if c.N > chunkSize {
+				c.N = chunkSize
+			}
+			for l, list := range lists {
+				c.Ptrs[l] = list[t] + cu.DevicePtr(uintptr(off)*elemSize)
+			}
+			retVal = append(retVal, c)
+		}
+	}
+	return retVal, nil
+}
+
+// TensorLists converts lists of tensors into the pointers and sizes expected by Chunks. Tensor t of every list must be
+// contiguous and have the same number of elements, and all the tensors must be of the same Dtype.
+func TensorLists(lists ...[]cu.DeviceTensor) (dt cu.Dtype, ptrs [][]cu.DevicePtr, sizes []int, err error) {
+	if len(lists) == 0 || len(lists[0]) == 0 {
+		return
+	}
+	dt = lists[0][0].Dtype
+	sizes = make([]int, len(lists[0]))
+	for t, tensor := range lists[0] {
+		sizes[t] = tensor.Len()
+	}
+	ptrs = make([][]cu.DevicePtr, len(lists))
+	for l, list := range lists {
+		if len(list) != len(sizes) {
+			return dt, nil, nil, errors.Errorf("Expected %d tensors in list %d. Got %d", len(sizes), l, len(list))
+		}
+		ptrs[l] = make([]cu.DevicePtr, len(list))
+		for t, tensor := range list {
+			switch {
+			case tensor.Dtype != dt:
+				return dt, nil, nil, errors.Errorf("Tensor %d of list %d is %v. Expected %v", t, l, tensor.Dtype, dt)
+			case tensor.Len() != sizes[t]:
+				return dt, nil, nil, errors.Errorf("Tensor %d of list %d has %d elements. Expected %d", t, l, tensor.Len(), sizes[t])
+			case !tensor.IsContiguous():
+				return dt, nil, nil, errors.Errorf("Tensor %d of list %d is not contiguous", t, l)
+			}
+			ptrs[l][t] = tensor.Ptr
+		}
+	}
+	return dt, ptrs, sizes, nil
+}
+
+// MultiTensorApplier launches a kernel over many tensors at once, saving the launch overhead of one kernel per tensor.
+//
+// The tensors are split into chunks (see Chunks), and the metadata of the chunks is uploaded to the device.
+// A single kernel is then launched, with each block processing one or more chunks. The kernel must take a pointer to
+// the chunks and the number of chunks as its first two parameters, followed by the extra arguments passed to Apply:
+//
+//	extern "C" __global__ void scale(const mta_chunk* chunks, long long nchunks, double alpha) {
+//		MTA_CHUNKS(k, nchunks) {
+//			const mta_chunk c = chunks[k];
+//			T* x = (T*)c.ptrs[0];
+//			BLOCK_STRIDE(i, c.n) {
+//				st(x, i, ld(x, i) * (acc_t)alpha);
+//			}
+//		}
+//	}
+//
+// The metadata is kept in a device buffer per stream, which is reused across launches. Launches on the same stream
+// are ordered by the stream, so the buffer is never overwritten while a previous kernel is reading it.
+type MultiTensorApplier struct {
+	ChunkSize int
+
+	sync.Mutex
+	meta map[metaKey]metaBuf
+}
+
+type metaKey struct {
+	ctx    uintptr
+	stream uintptr
+}
+
+type metaBuf struct {
+	ptr  cu.DevicePtr
+	size int64
+}
+
+// DefaultApplier is the MultiTensorApplier used by the multi-tensor functions in this package.
+var DefaultApplier = NewMultiTensorApplier(DefaultChunkSize)
+
+// NewMultiTensorApplier creates a MultiTensorApplier that splits tensors into chunks of chunkSize elements.
+func NewMultiTensorApplier(chunkSize int) *MultiTensorApplier {
+	return &MultiTensorApplier{
+		ChunkSize: chunkSize,
+		meta:      make(map[metaKey]metaBuf),
+	}
+}
+
+// Apply launches fn over the tensors on the given stream. See Chunks for the meaning of lists and sizes,
+// and Launch for the accepted types of args.
+func (a *MultiTensorApplier) Apply(fn cu.Function, dt cu.Dtype, lists [][]cu.DevicePtr, sizes []int, stream cu.Stream, args ...interface{}) error {
+	chunks, err := Chunks(dt, lists, sizes, a.ChunkSize)
+	if err != nil {
+		return err
+	}
+	if len(chunks) == 0 {
+		return nil
+	}
+
+	host := make([]int64, 0, len(chunks)*chunkWords)
+	for _, c := range chunks {
+		for _, p := range c.Ptrs {
+			host = append(host, int64(p))
+		}
+		host = append(host, int64(c.N), int64(c.Tensor))
+	}
+	size := int64(len(host) * 8)
+
+	a.Lock()
+	defer a.Unlock()
+	var meta cu.DevicePtr
+	if meta, err = a.buffer(stream, size); err != nil {
+		return err
+	}
+	if err = cu.MemcpyHtoDAsync(meta, unsafe.Pointer(&host[0]), size, stream); err != nil {
+		return errors.Wrap(err, "Unable to upload the chunk metadata")
+	}
+
+	params, err := Args(append([]interface{}{meta, len(chunks)}, args...)...)
+	if err != nil {
+		return err
+	}
+	grid := len(chunks)
+	if grid > maxGrid {
+		grid = maxGrid
+	}
+	return fn.Launch(grid, 1, 1, BlockSize, 1, 1, 0, stream, params)
+}
+
+// ApplyTensors is like Apply, but takes lists of tensors. See TensorLists.
+func (a *MultiTensorApplier) ApplyTensors(fn cu.Function, lists [][]cu.DeviceTensor, stream cu.Stream, args ...interface{}) error {
+	dt, ptrs, sizes, err := TensorLists(lists...)
+	if err != nil {
+		return err
+	}
+	if len(sizes) == 0 {
+		return nil
+	}
+	return a.Apply(fn, dt, ptrs, sizes, stream, args...)
+}
+
+// buffer returns a metadata buffer of at least size bytes for the stream. The lock is expected to be held.
+func (a *MultiTensorApplier) buffer(stream cu.Stream, size int64) (cu.DevicePtr, error) {
+	ctx, err := cu.CurrentContext()
+	if err != nil {
+		return 0, errors.Wrap(err, "No current context")
+	}
+	key := metaKey{ctx.Uintptr(), stream.Uintptr()}
+	buf, ok := a.meta[key]
+	if ok && buf.size >= size {
+		return buf.ptr, nil
+	}
+	if ok {
+		// the previous launches on the stream may still be reading the buffer
+		if err = stream.Synchronize(); err != nil {
+			return 0, errors.Wrap(err, "Unable to synchronize the stream")
+		}
+		if err = cu.MemFree(buf.ptr); err != nil {
+			return 0, errors.Wrap(err, "Unable to free the chunk metadata")
+		}
+		delete(a.meta, key)
+	}
+	if buf.ptr, err = cu.MemAlloc(size); err != nil {
+		return 0, errors.Wrapf(err, "Unable to allocate %d bytes of chunk metadata", size)
+	}
+	buf.size = size
+	a.meta[key] = buf
+	return buf.ptr, nil
+}
+
+// Close frees the metadata buffers. All the kernels launched by the applier must have completed.
+func (a *MultiTensorApplier) Close() error {
+	a.Lock()
+	defer a.Unlock()
+	for key, buf := range a.meta {
+		if err := cu.MemFree(buf.ptr); err != nil {
+			return errors.Wrap(err, "Unable to free the chunk metadata")
+		}
+		delete(a.meta, key)
+	}
+	return nil
+}
+
+// multiLaunch looks up the named kernel in the Default library and applies it with the DefaultApplier.
+func multiLaunch(src *Source, dt cu.Dtype, name string, lists [][]cu.DevicePtr, sizes []int, stream cu.Stream, args ...interface{}) error {
+	fn, err := Default.Function(src, dt, name)
+	if err != nil {
+		return err
+	}
+	if err = DefaultApplier.Apply(fn, dt, lists, sizes, stream, args...); err != nil {
+		return errors.Wrapf(err, "Unable to launch %v", name)
+	}
+	return nil
+}
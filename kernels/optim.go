@@ -0,0 +1,290 @@
+package kernels
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+var optimSource = &Source{
+	Name:   "optim",
+	Dtypes: []cu.Dtype{cu.DtFloat32, cu.DtFloat64},
+	Code: `
+extern "C" __global__ void sgd(T* p, const T* g, T* buf, long long n, double lr, double momentum, double dampening, double wd, long long nesterov, long long first) {
+	GRID_STRIDE(i, n) {
+		acc_t param = ld(p, i);
+		acc_t grad = ld(g, i);
+		if (wd != 0) {
+			grad += (acc_t)wd * param;
+		}
+		if (momentum != 0) {
+			acc_t b = first ? grad : (acc_t)momentum * ld(buf, i) + (acc_t)(1 - dampening) * grad;
+			st(buf, i, b);
+			grad = nesterov ? grad + (acc_t)momentum * b : b;
+		}
+		st(p, i, param - (acc_t)lr * grad);
+	}
+}
+
+// bc1 and bc2 are the bias corrections: 1 - beta1^step and 1 - beta2^step
+extern "C" __global__ void adam(T* p, const T* g, T* m, T* v, long long n, double lr, double beta1, double beta2, double eps, double wd, long long decoupled, double bc1, double bc2) {
+	GRID_STRIDE(i, n) {
+		acc_t param = ld(p, i);
+		acc_t grad = ld(g, i);
+		if (wd != 0 && !decoupled) {
+			grad += (acc_t)wd * param;
+		}
+		acc_t mi = (acc_t)beta1 * ld(m, i) + (acc_t)(1 - beta1) * grad;
+		acc_t vi = (acc_t)beta2 * ld(v, i) + (acc_t)(1 - beta2) * grad * grad;
+		st(m, i, mi);
+		st(v, i, vi);
+		acc_t update = (mi / (acc_t)bc1) / (sqrt(vi / (acc_t)bc2) + (acc_t)eps);
+		if (wd != 0 && decoupled) {
+			update += (acc_t)wd * param;
+		}
+		st(p, i, param - (acc_t)lr * update);
+	}
+}
+
+// lamb_update computes the update direction into u, and accumulates the squared norms of p and u into norms[0] and norms[1].
+extern "C" __global__ void lamb_update(const T* p, const T* g, T* m, T* v, T* u, long long n, double beta1, double beta2, double eps, double wd, double bc1, double bc2, double* norms) {
+	double pn = 0;
+	double un = 0;
+	GRID_STRIDE(i, n) {
+		acc_t param = ld(p, i);
+		acc_t grad = ld(g, i);
+		acc_t mi = (acc_t)beta1 * ld(m, i) + (acc_t)(1 - beta1) * grad;
+		acc_t vi = (acc_t)beta2 * ld(v, i) + (acc_t)(1 - beta2) * grad * grad;
+		st(m, i, mi);
+		st(v, i, vi);
+		acc_t update = (mi / (acc_t)bc1) / (sqrt(vi / (acc_t)bc2) + (acc_t)eps) + (acc_t)wd * param;
+		st(u, i, update);
+		pn += (double)param * (double)param;
+		un += (double)update * (double)update;
+	}
+	pn = blockReduceSum(pn);
+	un = blockReduceSum(un);
+	if (threadIdx.x == 0) {
+		atomicAddF64(&norms[0], pn);
+		atomicAddF64(&norms[1], un);
+	}
+}
+
+// lamb_apply applies the update in u, scaled by the trust ratio ||p|| / ||u||.
+extern "C" __global__ void lamb_apply(T* p, const T* u, long long n, double lr, const double* norms) {
+	double pn = sqrt(norms[0]);
+	double un = sqrt(norms[1]);
+	double trust = (pn > 0 && un > 0) ? pn / un : 1.0;
+	acc_t step = (acc_t)(lr * trust);
+	GRID_STRIDE(i, n) {
+		st(p, i, ld(p, i) - step * ld(u, i));
+	}
+}
+
+// mta_sgd is the multi-tensor version of sgd. The lists are the parameters, gradients and (optionally) the momentum buffers.
+extern "C" __global__ void mta_sgd(const mta_chunk* chunks, long long nchunks, double lr, double momentum, double dampening, double wd, long long nesterov, long long first) {
+	MTA_CHUNKS(k, nchunks) {
+		const mta_chunk c = chunks[k];
+		T* p = (T*)c.ptrs[0];
+		const T* g = (const T*)c.ptrs[1];
+		T* buf = (T*)c.ptrs[2];
+		BLOCK_STRIDE(i, c.n) {
+			acc_t param = ld(p, i);
+			acc_t grad = ld(g, i);
+			if (wd != 0) {
+				grad += (acc_t)wd * param;
+			}
+			if (momentum != 0) {
+				acc_t b = first ? grad : (acc_t)momentum * ld(buf, i) + (acc_t)(1 - dampening) * grad;
+				st(buf, i, b);
+				grad = nesterov ? grad + (acc_t)momentum * b : b;
+			}
+			st(p, i, param - (acc_t)lr * grad);
+		}
+	}
+}
+
+// mta_adam is the multi-tensor version of adam. The lists are the parameters, gradients, first and second moments.
+extern "C" __global__ void mta_adam(const mta_chunk* chunks, long long nchunks, double lr, double beta1, double beta2, double eps, double wd, long long decoupled, double bc1, double bc2) {
+	MTA_CHUNKS(k, nchunks) {
+		const mta_chunk c = chunks[k];
+		T* p = (T*)c.ptrs[0];
+		const T* g = (const T*)c.ptrs[1];
+		T* m = (T*)c.ptrs[2];
+		T* v = (T*)c.ptrs[3];
+		BLOCK_STRIDE(i, c.n) {
+			acc_t param = ld(p, i);
+			acc_t grad = ld(g, i);
+			if (wd != 0 && !decoupled) {
+				grad += (acc_t)wd * param;
+			}
+			acc_t mi = (acc_t)beta1 * ld(m, i) + (acc_t)(1 - beta1) * grad;
+			acc_t vi = (acc_t)beta2 * ld(v, i) + (acc_t)(1 - beta2) * grad * grad;
+			st(m, i, mi);
+			st(v, i, vi);
+			acc_t update = (mi / (acc_t)bc1) / (sqrt(vi / (acc_t)bc2) + (acc_t)eps);
+			if (wd != 0 && decoupled) {
+				update += (acc_t)wd * param;
+			}
+			st(p, i, param - (acc_t)lr * update);
+		}
+	}
+}
+
+// mta_sumsq accumulates the sum of the squares of all the tensors into out.
+extern "C" __global__ void mta_sumsq(const mta_chunk* chunks, long long nchunks, double* out) {
+	double acc = 0;
+	MTA_CHUNKS(k, nchunks) {
+		const mta_chunk c = chunks[k];
+		const T* x = (const T*)c.ptrs[0];
+		BLOCK_STRIDE(i, c.n) {
+			double xi = ld(x, i);
+			acc += xi * xi;
+		}
+	}
+	acc = blockReduceSum(acc);
+	if (threadIdx.x == 0) {
+		atomicAddF64(out, acc);
+	}
+}
+
+// mta_clip_norm scales all the tensors such that their global norm (whose square is in sq) does not exceed maxNorm.
+extern "C" __global__ void mta_clip_norm(const mta_chunk* chunks, long long nchunks, double maxNorm, const double* sq) {
+	double scale = maxNorm / (sqrt(*sq) + 1e-6);
+	if (scale >= 1) {
+		return;
+	}
+	MTA_CHUNKS(k, nchunks) {
+		const mta_chunk c = chunks[k];
+		T* x = (T*)c.ptrs[0];
+		BLOCK_STRIDE(i, c.n) {
+			st(x, i, ld(x, i) * (acc_t)scale);
+		}
+	}
+}
+
+extern "C" __global__ void clip_value(T* x, long long n, double lo, double hi) {
+	GRID_STRIDE(i, n) {
+		acc_t xi = ld(x, i);
+		st(x, i, xi < (acc_t)lo ? (acc_t)lo : (xi > (acc_t)hi ? (acc_t)hi : xi));
+	}
+}
+`,
+}
+
+// SGDConfig configures stochastic gradient descent.
+type SGDConfig struct {
+	LR          float64
+	Momentum    float64
+	Dampening   float64
+	WeightDecay float64
+	Nesterov    bool
+}
+
+// AdamConfig configures Adam. If Decoupled is true, the weight decay is decoupled from the gradient (AdamW).
+type AdamConfig struct {
+	LR          float64
+	Beta1       float64
+	Beta2       float64
+	Eps         float64
+	WeightDecay float64
+	Decoupled   bool
+}
+
+// LAMBConfig configures LAMB (layer-wise adaptive moments).
+type LAMBConfig struct {
+	LR          float64
+	Beta1       float64
+	Beta2       float64
+	Eps         float64
+	WeightDecay float64
+}
+
+// SGD performs a fused SGD update of the n parameters in place.
+// momentum holds the momentum buffer, and is only used if cfg.Momentum is not zero. step is the 1-based step number.
+func SGD(dt cu.Dtype, params, grads, momentum cu.DevicePtr, n, step int, cfg SGDConfig, stream cu.Stream) error {
+	if cfg.Momentum != 0 && momentum == 0 {
+		return errors.New("SGD with momentum requires a momentum buffer")
+	}
+	return launch(optimSource, dt, "sgd", n, stream, params, grads, momentum, n, cfg.LR, cfg.Momentum, cfg.Dampening, cfg.WeightDecay, cfg.Nesterov, step <= 1)
+}
+
+// Adam performs a fused Adam (or AdamW) update of the n parameters in place. m and v hold the first and second moments.
+// step is the 1-based step number, used for the bias correction.
+func Adam(dt cu.Dtype, params, grads, m, v cu.DevicePtr, n, step int, cfg AdamConfig, stream cu.Stream) error {
+	if step < 1 {
+		return errors.Errorf("Adam expects a 1-based step. Got %d", step)
+	}
+	bc1, bc2 := biasCorrections(cfg.Beta1, cfg.Beta2, step)
+	return launch(optimSource, dt, "adam", n, stream, params, grads, m, v, n, cfg.LR, cfg.Beta1, cfg.Beta2, cfg.Eps, cfg.WeightDecay, cfg.Decoupled, bc1, bc2)
+}
+
+// LAMB performs a LAMB update of the n parameters in place. m and v hold the first and second moments.
+// update is a scratch buffer of n elements, and norms is a scratch buffer of two float64s.
+// step is the 1-based step number, used for the bias correction.
+func LAMB(dt cu.Dtype, params, grads, m, v, update, norms cu.DevicePtr, n, step int, cfg LAMBConfig, stream cu.Stream) error {
+	if step < 1 {
+		return errors.Errorf("LAMB expects a 1-based step. Got %d", step)
+	}
+	if n <= 0 {
+		return nil
+	}
+	if err := cu.MemsetD8Async(norms, 0, 16, stream); err != nil {
+		return errors.Wrap(err, "Unable to clear norms")
+	}
+	bc1, bc2 := biasCorrections(cfg.Beta1, cfg.Beta2, step)
+	if err := launch(optimSource, dt, "lamb_update", n, stream, params, grads, m, v, update, n, cfg.Beta1, cfg.Beta2, cfg.Eps, cfg.WeightDecay, bc1, bc2, norms); err != nil {
+		return err
+	}
+	return launch(optimSource, dt, "lamb_apply", n, stream, params, update, n, cfg.LR, norms)
+}
+
+// MultiSGD performs a fused SGD update of many parameter tensors in place, in a single launch.
+// Tensor i of params, grads and momentum has sizes[i] elements. momentum is only used if cfg.Momentum is not zero.
+func MultiSGD(dt cu.Dtype, params, grads, momentum []cu.DevicePtr, sizes []int, step int, cfg SGDConfig, stream cu.Stream) error {
+	lists := [][]cu.DevicePtr{params, grads}
+	if cfg.Momentum != 0 {
+		lists = append(lists, momentum)
+	}
+	return multiLaunch(optimSource, dt, "mta_sgd", lists, sizes, stream, cfg.LR, cfg.Momentum, cfg.Dampening, cfg.WeightDecay, cfg.Nesterov, step <= 1)
+}
+
+// MultiAdam performs a fused Adam (or AdamW) update of many parameter tensors in place, in a single launch.
+// Tensor i of params, grads, m and v has sizes[i] elements. step is the 1-based step number, used for the bias correction.
+func MultiAdam(dt cu.Dtype, params, grads, m, v []cu.DevicePtr, sizes []int, step int, cfg AdamConfig, stream cu.Stream) error {
+	if step < 1 {
+		return errors.Errorf("Adam expects a 1-based step. Got %d", step)
+	}
+	bc1, bc2 := biasCorrections(cfg.Beta1, cfg.Beta2, step)
+	lists := [][]cu.DevicePtr{params, grads, m, v}
+	return multiLaunch(optimSource, dt, "mta_adam", lists, sizes, stream, cfg.LR, cfg.Beta1, cfg.Beta2, cfg.Eps, cfg.WeightDecay, cfg.Decoupled, bc1, bc2)
+}
+
+// ClipGradNorm scales the gradients such that their global L2 norm does not exceed maxNorm.
+// grads and sizes list the gradient buffers and their number of elements.
+// sq is a scratch buffer of one float64, which holds the squared global norm (before clipping) once the kernels have completed.
+//
+// The norm is computed and applied with two multi-tensor launches, regardless of the number of gradients.
+func ClipGradNorm(dt cu.Dtype, grads []cu.DevicePtr, sizes []int, maxNorm float64, sq cu.DevicePtr, stream cu.Stream) error {
+	if len(grads) != len(sizes) {
+		return errors.Errorf("Expected a size for each of the %d gradients. Got %d", len(grads), len(sizes))
+	}
+	if err := cu.MemsetD8Async(sq, 0, 8, stream); err != nil {
+		return errors.Wrap(err, "Unable to clear the norm")
+	}
+	lists := [][]cu.DevicePtr{grads}
+	if err := multiLaunch(optimSource, dt, "mta_sumsq", lists, sizes, stream, sq); err != nil {
+		return err
+	}
+	return multiLaunch(optimSource, dt, "mta_clip_norm", lists, sizes, stream, maxNorm, sq)
+}
+
+// ClipGradValue clamps the n gradients to [-clip, clip].
+func ClipGradValue(dt cu.Dtype, grads cu.DevicePtr, n int, clip float64, stream cu.Stream) error {
+	return launch(optimSource, dt, "clip_value", n, stream, grads, n, -clip, clip)
+}
+
+func biasCorrections(beta1, beta2 float64, step int) (bc1, bc2 float64) {
+	return 1 - math.Pow(beta1, float64(step)), 1 - math.Pow(beta2, float64(step))
+}
@@ -0,0 +1,185 @@
+package kernels
+
+import "gorgonia.org/cu"
+
+// common is prepended to every source, after the element type definitions.
+const common = `
+#define GRID_STRIDE(i, n) for (long long i = (long long)blockIdx.x * blockDim.x + threadIdx.x; i < (n); i += (long long)blockDim.x * gridDim.x)
+#define BLOCK_STRIDE(i, n) for (long long i = threadIdx.x; i < (n); i += blockDim.x)
+#define ROWS(r, n) for (long long r = blockIdx.x; r < (n); r += gridDim.x)
+
+// mta_chunk is the metadata of a chunk of a multi-tensor launch. ptrs[l] is the address of the first element of the
+// chunk in list l. Blocks iterate over the chunks with MTA_CHUNKS.
+#define MTA_MAX_LISTS 5
+struct mta_chunk {
+	unsigned long long ptrs[MTA_MAX_LISTS];
+	long long n;
+	long long tensor;
+};
+#define MTA_CHUNKS(k, nchunks) for (long long k = blockIdx.x; k < (nchunks); k += gridDim.x)
+
+// nd_offset returns the offset of element i of a 4 dimensional iteration space of shape (s0, s1, s2, s3),
+// in an operand with strides (t0, t1, t2, t3).
+__device__ __forceinline__ long long nd_offset(long long i, long long s1, long long s2, long long s3, long long t0, long long t1, long long t2, long long t3) {
+	long long i3 = i % s3;
+	i /= s3;
+	long long i2 = i % s2;
+	i /= s2;
+	long long i1 = i % s1;
+	i /= s1;
+	return i * t0 + i1 * t1 + i2 * t2 + i3 * t3;
+}
+
+__device__ __forceinline__ double atomicAddF64(double* addr, double v) {
+#if __CUDA_ARCH__ >= 600
+	return atomicAdd(addr, v);
+#else
+	unsigned long long* p = (unsigned long long*)addr;
+	unsigned long long old = *p, assumed;
+	do {
+		assumed = old;
+		old = atomicCAS(p, assumed, __double_as_longlong(v + __longlong_as_double(assumed)));
+	} while (assumed != old);
+	return __longlong_as_double(old);
+#endif
+}
+
+// max_nan returns the maximum of a and b, or NaN if either is NaN (where fmax returns the other value).
+__device__ __forceinline__ double max_nan(double a, double b) {
+	return a > b || a != a ? a : b;
+}
+
+// blockReduceSum sums v across the block. The result is only valid in thread 0.
+__device__ double blockReduceSum(double v) {
+	__shared__ double partials[32];
+	int lane = threadIdx.x & 31;
+	int warp = threadIdx.x >> 5;
+	__syncthreads();
+	for (int o = 16; o > 0; o >>= 1) {
+		v += __shfl_down_sync(0xffffffff, v, o);
+	}
+	if (lane == 0) {
+		partials[warp] = v;
+	}
+	__syncthreads();
+	v = threadIdx.x < (blockDim.x >> 5) ? partials[lane] : 0;
+	if (warp == 0) {
+		for (int o = 16; o > 0; o >>= 1) {
+			v += __shfl_down_sync(0xffffffff, v, o);
+		}
+	}
+	return v;
+}
+
+// blockReduceMax is like blockReduceSum, but returns the maximum of v across the block, or NaN if any v is NaN.
+__device__ double blockReduceMax(double v) {
+	__shared__ double partials[32];
+	int lane = threadIdx.x & 31;
+	int warp = threadIdx.x >> 5;
+	__syncthreads();
+	for (int o = 16; o > 0; o >>= 1) {
+		v = max_nan(v, __shfl_down_sync(0xffffffff, v, o));
+	}
+	if (lane == 0) {
+		partials[warp] = v;
+	}
+	__syncthreads();
+	v = threadIdx.x < (blockDim.x >> 5) ? partials[lane] : -INFINITY;
+	if (warp == 0) {
+		for (int o = 16; o > 0; o >>= 1) {
+			v = max_nan(v, __shfl_down_sync(0xffffffff, v, o));
+		}
+	}
+	return v;
+}
+`
+
+const preludeF32 = `
+typedef float T;
+typedef float acc_t;
+__device__ __forceinline__ acc_t ld(const T* p, long long i) { return p[i]; }
+__device__ __forceinline__ void st(T* p, long long i, acc_t v) { p[i] = v; }
+`
+
+const preludeF64 = `
+typedef double T;
+typedef double acc_t;
+__device__ __forceinline__ acc_t ld(const T* p, long long i) { return p[i]; }
+__device__ __forceinline__ void st(T* p, long long i, acc_t v) { p[i] = v; }
+`
+
+const preludeU8 = `
+typedef unsigned char T;
+typedef float acc_t;
+__device__ __forceinline__ acc_t ld(const T* p, long long i) { return p[i]; }
+__device__ __forceinline__ void st(T* p, long long i, acc_t v) { p[i] = (T)fminf(fmaxf(rintf(v), 0.0f), 255.0f); }
+`
+
+// preludeF16 stores halves as their bits, and converts them with PTX, so that sources do not depend on cuda_fp16.h.
+const preludeF16 = `
+typedef unsigned short T;
+typedef float acc_t;
+__device__ __forceinline__ acc_t ld(const T* p, long long i) {
+	float f;
+	asm("cvt.f32.f16 %0, %1;" : "=f"(f) : "h"(p[i]));
+	return f;
+}
+__device__ __forceinline__ void st(T* p, long long i, acc_t v) {
+	unsigned short h;
+	asm("cvt.rn.f16.f32 %0, %1;" : "=h"(h) : "f"(v));
+	p[i] = h;
+}
+`
+
+// preludeBF16 stores bfloat16 as their bits: the upper half of the bits of a float. Stores round to nearest even.
+const preludeBF16 = `
+typedef unsigned short T;
+typedef float acc_t;
+__device__ __forceinline__ acc_t ld(const T* p, long long i) { return __uint_as_float((unsigned int)p[i] << 16); }
+__device__ __forceinline__ void st(T* p, long long i, acc_t v) {
+	unsigned int u = __float_as_uint(v);
+	p[i] = isnan(v) ? (unsigned short)0x7fc0 : (unsigned short)((u + 0x7fff + ((u >> 16) & 1)) >> 16);
+}
+`
+
+// preludeC64 and preludeC128 store complex numbers as vectors of their real and imaginary parts, which is the layout of
+// complex64 and complex128. real_t is the type of the parts, and cmake builds a complex number from them. acc_t is the
+// element type itself: sources that support complex types use the complex operations, not the arithmetic operators.
+const preludeC64 = `
+typedef float2 T;
+typedef float2 acc_t;
+typedef float real_t;
+__device__ __forceinline__ acc_t ld(const T* p, long long i) { return p[i]; }
+__device__ __forceinline__ void st(T* p, long long i, acc_t v) { p[i] = v; }
+__device__ __forceinline__ T cmake(real_t re, real_t im) { return make_float2(re, im); }
+`
+
+const preludeC128 = `
+typedef double2 T;
+typedef double2 acc_t;
+typedef double real_t;
+__device__ __forceinline__ acc_t ld(const T* p, long long i) { return p[i]; }
+__device__ __forceinline__ void st(T* p, long long i, acc_t v) { p[i] = v; }
+__device__ __forceinline__ T cmake(real_t re, real_t im) { return make_double2(re, im); }
+`
+
+// Prelude returns the prelude that is prepended to sources compiled for the given element type.
+func Prelude(dt cu.Dtype) string {
+	switch dt {
+	case cu.DtFloat32:
+		return preludeF32 + common
+	case cu.DtFloat64:
+		return preludeF64 + common
+	case cu.DtUint8:
+		return preludeU8 + common
+	case cu.DtFloat16:
+		return preludeF16 + common
+	case cu.DtBFloat16:
+		return preludeBF16 + common
+	case cu.DtComplex64:
+		return preludeC64 + common
+	case cu.DtComplex128:
+		return preludeC128 + common
+	}
+	return common
+}
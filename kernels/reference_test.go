@@ -0,0 +1,620 @@
+package kernels
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"unsafe"
+
+	"gorgonia.org/cu"
+)
+
+// This file compares the kernels with reference implementations on the CPU, over random shapes, strides and element
+// types. The random sources are seeded, so that failures can be reproduced.
+
+const referenceTrials = 100
+
+var referenceDtypes = []cu.Dtype{cu.DtFloat32, cu.DtFloat64}
+
+// referenceTol returns the relative tolerance of the results of kernels of the given element type.
+func referenceTol(dt cu.Dtype) float64 {
+	if dt == cu.DtFloat32 {
+		return 1e-5
+	}
+	return 1e-12
+}
+
+// deviceBuffer is device memory holding a tensor, or a view of it.
+type deviceBuffer struct {
+	mem  cu.DevicePtr
+	n    int // number of elements of the memory
+	view cu.DeviceTensor
+}
+
+// upload copies data, the elements of a tensor of the given shape in row-major order, to the device. If transpose,
+// the tensor is stored with its dimensions in a random order, and the view is a non-contiguous tensor.
+func upload(t *testing.T, r *rand.Rand, dt cu.Dtype, shape []int, data []float64, transpose bool) deviceBuffer {
+	perm := identity(len(shape))
+	if transpose {
+		perm = r.Perm(len(shape))
+	}
+	stored := make([]int, len(shape))
+	inv := make([]int, len(shape))
+	for i, p := range perm {
+		stored[i] = shape[p]
+		inv[p] = i
+	}
+	n := product(shape)
+	mem, err := cu.MemAlloc(int64(max(n, 1)) * dt.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	view, err := cu.NewDeviceTensor(mem, dt, stored...).Transpose(inv...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := deviceBuffer{mem: mem, n: n, view: view}
+	if n == 0 {
+		return b
+	}
+	buf := make([]float64, n)
+	forIndex(shape, func(k int, idx []int) {
+		buf[offsetOf(idx, view.Strides)] = data[k]
+	})
+	b.write(t, buf)
+	return b
+}
+
+// write copies the elements of the memory of the buffer, in storage order.
+func (b deviceBuffer) write(t *testing.T, buf []float64) {
+	var src unsafe.Pointer
+	switch b.view.Dtype {
+	case cu.DtFloat32:
+		f := make([]float32, len(buf))
+		for i, v := range buf {
+			f[i] = float32(v)
+		}
+		src = unsafe.Pointer(&f[0])
+	case cu.DtFloat64:
+		src = unsafe.Pointer(&buf[0])
+	}
+	if err := cu.MemcpyHtoD(b.mem, src, int64(len(buf))*b.view.Dtype.Size()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// read returns the elements of the view, in row-major order.
+func (b deviceBuffer) read(t *testing.T) []float64 {
+	if b.n == 0 {
+		return nil
+	}
+	if err := cu.Synchronize(); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]float64, b.n)
+	switch b.view.Dtype {
+	case cu.DtFloat32:
+		f := make([]float32, b.n)
+		if err := cu.MemcpyDtoH(unsafe.Pointer(&f[0]), b.mem, int64(b.n*4)); err != nil {
+			t.Fatal(err)
+		}
+		for i, v := range f {
+			buf[i] = float64(v)
+		}
+	case cu.DtFloat64:
+		if err := cu.MemcpyDtoH(unsafe.Pointer(&buf[0]), b.mem, int64(b.n*8)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	retVal := make([]float64, b.n)
+	forIndex(b.view.Shape, func(k int, idx []int) {
+		retVal[k] = buf[offsetOf(idx, b.view.Strides)]
+	})
+	return retVal
+}
+
+func (b deviceBuffer) free() { cu.MemFree(b.mem) }
+
+// uploadBytes copies a byte tensor to the device. It may be empty.
+func uploadBytes(t *testing.T, dt cu.Dtype, shape []int, data []uint8) deviceBuffer {
+	mem := uploadMask(t, append(data, 0))
+	return deviceBuffer{mem: mem, n: len(data), view: cu.NewDeviceTensor(mem, dt, shape...)}
+}
+
+// uploadOffsets copies segment offsets to the device, as int32 or int64.
+func uploadOffsets(t *testing.T, dt cu.Dtype, offsets []int) deviceBuffer {
+	size := int64(len(offsets)) * dt.Size()
+	mem, err := cu.MemAlloc(size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var src unsafe.Pointer
+	if dt == cu.DtInt32 {
+		o := make([]int32, len(offsets))
+		for i, v := range offsets {
+			o[i] = int32(v)
+		}
+		src = unsafe.Pointer(&o[0])
+	} else {
+		o := make([]int64, len(offsets))
+		for i, v := range offsets {
+			o[i] = int64(v)
+		}
+		src = unsafe.Pointer(&o[0])
+	}
+	if err = cu.MemcpyHtoD(mem, src, size); err != nil {
+		t.Fatal(err)
+	}
+	return deviceBuffer{mem: mem, n: len(offsets), view: cu.NewDeviceTensor(mem, dt, len(offsets))}
+}
+
+// checkClose reports the elements of got that differ from want by more than the relative tolerance.
+// NaNs are expected where want has NaNs, and infinities must match exactly.
+func checkClose(t *testing.T, name string, got, want []float64, tol float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%v: expected %d elements. Got %d", name, len(want), len(got))
+		return
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		switch {
+		case math.IsNaN(w):
+			if !math.IsNaN(g) {
+				t.Errorf("%v: expected NaN at %d. Got %v", name, i, g)
+				return
+			}
+		case math.IsInf(w, 0) || math.IsNaN(g):
+			if g != w {
+				t.Errorf("%v: expected %v at %d. Got %v", name, w, i, g)
+				return
+			}
+		case math.Abs(g-w) > tol*(1+math.Abs(w)):
+			t.Errorf("%v: expected %v at %d. Got %v", name, w, i, g)
+			return
+		}
+	}
+}
+
+// randomShape returns a shape of 1 to maxDims dimensions, of sizes 1 to 5. If empty, a dimension is 0.
+func randomShape(r *rand.Rand, maxDims int, empty bool) []int {
+	shape := make([]int, 1+r.Intn(maxDims))
+	for i := range shape {
+		shape[i] = 1 + r.Intn(5)
+	}
+	if empty {
+		shape[r.Intn(len(shape))] = 0
+	}
+	return shape
+}
+
+// broadcastable returns a shape that broadcasts to shape: leading dimensions may be dropped, and others set to 1.
+func broadcastable(r *rand.Rand, shape []int) []int {
+	retVal := append([]int(nil), shape[r.Intn(len(shape)):]...)
+	for i := range retVal {
+		if r.Intn(3) == 0 {
+			retVal[i] = 1
+		}
+	}
+	return retVal
+}
+
+// randomData returns n values uniformly distributed in [lo, hi). If nans, some are NaN.
+func randomData(r *rand.Rand, n int, lo, hi float64, nans bool) []float64 {
+	retVal := make([]float64, n)
+	for i := range retVal {
+		retVal[i] = lo + (hi-lo)*r.Float64()
+		if nans && r.Intn(8) == 0 {
+			retVal[i] = math.NaN()
+		}
+	}
+	return retVal
+}
+
+// broadcastIndex returns the element of a tensor of the given shape, in row-major order, that idx broadcasts from.
+func broadcastIndex(idx, shape []int) int {
+	k := 0
+	offset := len(idx) - len(shape)
+	for i, s := range shape {
+		k *= s
+		if s != 1 {
+			k += idx[offset+i]
+		}
+	}
+	return k
+}
+
+// forIndex calls fn with the row-major index k and the multi-dimensional index of each element of the shape.
+func forIndex(shape []int, fn func(k int, idx []int)) {
+	n := product(shape)
+	idx := make([]int, len(shape))
+	for k := 0; k < n; k++ {
+		fn(k, idx)
+		for d := len(shape) - 1; d >= 0; d-- {
+			if idx[d]++; idx[d] < shape[d] {
+				break
+			}
+			idx[d] = 0
+		}
+	}
+}
+
+func offsetOf(idx, strides []int) int {
+	o := 0
+	for i, v := range idx {
+		o += v * strides[i]
+	}
+	return o
+}
+
+func product(shape []int) int {
+	n := 1
+	for _, s := range shape {
+		n *= s
+	}
+	return n
+}
+
+func identity(n int) []int {
+	retVal := make([]int, n)
+	for i := range retVal {
+		retVal[i] = i
+	}
+	return retVal
+}
+
+// refMax is max, with NaN if either is NaN.
+func refMax(a, b float64) float64 {
+	if a > b || math.IsNaN(a) {
+		return a
+	}
+	return b
+}
+
+func refMin(a, b float64) float64 {
+	if a < b || math.IsNaN(a) {
+		return a
+	}
+	return b
+}
+
+func refBinary(op BinaryOp, a, b float64) float64 {
+	switch op {
+	case OpAdd:
+		return a + b
+	case OpSub:
+		return a - b
+	case OpMul:
+		return a * b
+	case OpDiv:
+		return a / b
+	case OpMax:
+		return refMax(a, b)
+	case OpMin:
+		return refMin(a, b)
+	case OpPow:
+		return math.Pow(a, b)
+	}
+	panic("unreachable")
+}
+
+func TestReferenceBinary(t *testing.T) {
+	withContext(t, func() {
+		r := rand.New(rand.NewSource(1))
+		for trial := 0; trial < referenceTrials; trial++ {
+			dt := referenceDtypes[trial%len(referenceDtypes)]
+			op := BinaryOp(r.Intn(len(binaryOpNames)))
+			shape := randomShape(r, 6, trial%10 == 9)
+			as, bs := broadcastable(r, shape), broadcastable(r, shape)
+			nans := trial%4 == 3
+
+			lo, hi := -2.0, 2.0
+			if op == OpPow {
+				lo = 0.1
+			}
+			a := randomData(r, product(as), lo, hi, nans)
+			b := randomData(r, product(bs), lo, hi, nans)
+			if op == OpDiv {
+				// keep away from 0
+				for i := range b {
+					b[i] = math.Copysign(0.5+math.Abs(b[i]), b[i])
+				}
+			}
+			want := make([]float64, product(shape))
+			forIndex(shape, func(k int, idx []int) {
+				x, y := a[broadcastIndex(idx, as)], b[broadcastIndex(idx, bs)]
+				if dt == cu.DtFloat32 {
+					x, y = float64(float32(x)), float64(float32(y))
+				}
+				want[k] = refBinary(op, x, y)
+			})
+
+			da := upload(t, r, dt, as, a, r.Intn(2) == 0)
+			db := upload(t, r, dt, bs, b, r.Intn(2) == 0)
+			dout := upload(t, r, dt, shape, make([]float64, len(want)), r.Intn(2) == 0)
+			name := fmt.Sprintf("trial %d: %v %v %v = %v, %v", trial, op, dt, shape, as, bs)
+			if err := Binary(op, dout.view, da.view, db.view, cu.NoStream); err != nil {
+				t.Fatalf("%v: %v", name, err)
+			}
+			checkClose(t, name, dout.read(t), want, referenceTol(dt))
+			da.free()
+			db.free()
+			dout.free()
+		}
+	})
+}
+
+func TestReferenceMasked(t *testing.T) {
+	withContext(t, func() {
+		r := rand.New(rand.NewSource(2))
+		for trial := 0; trial < referenceTrials; trial++ {
+			dt := referenceDtypes[trial%len(referenceDtypes)]
+			shape := randomShape(r, maxRank, trial%10 == 9)
+			ms := broadcastable(r, shape)
+			x := randomData(r, product(shape), -4, 4, trial%4 == 3)
+			mask := make([]uint8, product(ms))
+			for i := range mask {
+				mask[i] = uint8(r.Intn(2))
+			}
+			value := -1e4
+			name := fmt.Sprintf("trial %d: %v %v masked by %v", trial, dt, shape, ms)
+
+			dx := upload(t, r, dt, shape, x, r.Intn(2) == 0)
+			dm := uploadBytes(t, cu.DtUint8, ms, mask)
+
+			// MaskedFill
+			want := make([]float64, len(x))
+			forIndex(shape, func(k int, idx []int) {
+				want[k] = x[k]
+				if mask[broadcastIndex(idx, ms)] != 0 {
+					want[k] = value
+				}
+				if dt == cu.DtFloat32 {
+					want[k] = float64(float32(want[k]))
+				}
+			})
+			dout := upload(t, r, dt, shape, make([]float64, len(x)), r.Intn(2) == 0)
+			if err := MaskedFill(dout.view, dx.view, dm.view, value, cu.NoStream); err != nil {
+				t.Fatalf("MaskedFill %v: %v", name, err)
+			}
+			checkClose(t, "MaskedFill "+name, dout.read(t), want, 0)
+			dout.free()
+
+			// MaskedReduce
+			cols := shape[len(shape)-1]
+			rows := product(shape[:len(shape)-1])
+			if cols == 0 {
+				rows = 0
+			}
+			for op := ReduceSum; op <= ReduceMax; op++ {
+				acc := make([]float64, rows)
+				count := make([]int, rows)
+				for i := range acc {
+					if op == ReduceMax {
+						acc[i] = math.Inf(-1)
+					}
+				}
+				forIndex(shape, func(k int, idx []int) {
+					if mask[broadcastIndex(idx, ms)] == 0 {
+						return
+					}
+					v := x[k]
+					if dt == cu.DtFloat32 {
+						v = float64(float32(v))
+					}
+					row := k / cols
+					if op == ReduceMax {
+						acc[row] = refMax(acc[row], v)
+					} else {
+						acc[row] += v
+					}
+					count[row]++
+				})
+				if op == ReduceMean {
+					for i := range acc {
+						if count[i] > 0 {
+							acc[i] /= float64(count[i])
+						}
+					}
+				}
+				dred := upload(t, r, dt, []int{rows}, make([]float64, rows), false)
+				if err := MaskedReduce(op, dred.view, dx.view, dm.view, cu.NoStream); err != nil {
+					t.Fatalf("Masked%v %v: %v", op, name, err)
+				}
+				checkClose(t, fmt.Sprintf("Masked%v %v", op, name), dred.read(t), acc, referenceTol(dt))
+				dred.free()
+			}
+			dx.free()
+			dm.free()
+		}
+	})
+}
+
+func TestReferenceSegment(t *testing.T) {
+	withContext(t, func() {
+		r := rand.New(rand.NewSource(3))
+		for trial := 0; trial < referenceTrials; trial++ {
+			dt := referenceDtypes[trial%len(referenceDtypes)]
+			odt := cu.DtInt32
+			if trial%4 >= 2 {
+				odt = cu.DtInt64
+			}
+			segments := 1 + r.Intn(6)
+			d := 1 + r.Intn(300) // more than a block
+			offsets := []int{0}
+			for s := 0; s < segments; s++ {
+				offsets = append(offsets, offsets[s]+r.Intn(4)) // segments may be empty
+			}
+			rows := offsets[segments]
+			x := randomData(r, max(rows, 1)*d, -4, 4, trial%5 == 4)
+			name := fmt.Sprintf("trial %d: %v %d rows of %d in segments %v", trial, dt, rows, d, offsets)
+
+			dx := upload(t, r, dt, []int{max(rows, 1), d}, x, false)
+			do := uploadOffsets(t, odt, offsets)
+			for op := ReduceSum; op <= ReduceMax; op++ {
+				want := make([]float64, segments*d)
+				for s := 0; s < segments; s++ {
+					for j := 0; j < d; j++ {
+						acc := 0.0
+						if op == ReduceMax {
+							acc = math.Inf(-1)
+						}
+						for row := offsets[s]; row < offsets[s+1]; row++ {
+							v := x[row*d+j]
+							if dt == cu.DtFloat32 {
+								v = float64(float32(v))
+							}
+							if op == ReduceMax {
+								acc = refMax(acc, v)
+							} else {
+								acc += v
+							}
+						}
+						if n := offsets[s+1] - offsets[s]; op == ReduceMean && n > 0 {
+							acc /= float64(n)
+						}
+						want[s*d+j] = acc
+					}
+				}
+				dout := upload(t, r, dt, []int{segments, d}, make([]float64, len(want)), false)
+				if err := SegmentReduce(op, dout.view, dx.view, do.view, cu.NoStream); err != nil {
+					t.Fatalf("Segment%v %v: %v", op, name, err)
+				}
+				checkClose(t, fmt.Sprintf("Segment%v %v", op, name), dout.read(t), want, referenceTol(dt))
+
+				// expand the reduction back
+				if rows > 0 {
+					expanded := upload(t, r, dt, []int{rows, d}, make([]float64, rows*d), false)
+					if err := SegmentExpand(expanded.view, dout.view, do.view, cu.NoStream); err != nil {
+						t.Fatalf("SegmentExpand %v: %v", name, err)
+					}
+					wantExpanded := make([]float64, rows*d)
+					for s := 0; s < segments; s++ {
+						for row := offsets[s]; row < offsets[s+1]; row++ {
+							for j := 0; j < d; j++ {
+								wantExpanded[row*d+j] = want[s*d+j]
+							}
+						}
+					}
+					checkClose(t, "SegmentExpand "+name, expanded.read(t), wantExpanded, referenceTol(dt))
+					expanded.free()
+				}
+				dout.free()
+			}
+			dx.free()
+			do.free()
+		}
+	})
+}
+
+func TestReferenceTransformer(t *testing.T) {
+	withContext(t, func() {
+		r := rand.New(rand.NewSource(4))
+		for trial := 0; trial < referenceTrials; trial++ {
+			dt := referenceDtypes[trial%len(referenceDtypes)]
+			shape := randomShape(r, 3, false)
+			shape[len(shape)-1] = 1 + r.Intn(600) // rows longer than a block
+			d := shape[len(shape)-1]
+			n := product(shape)
+			rows := n / d
+			round := func(v float64) float64 {
+				if dt == cu.DtFloat32 {
+					return float64(float32(v))
+				}
+				return v
+			}
+			x := randomData(r, n, -3, 3, trial%8 == 7)
+			res := randomData(r, n, -3, 3, false)
+			bias := randomData(r, d, -1, 1, false)
+			gamma := randomData(r, d, 0.5, 1.5, false)
+			beta := randomData(r, d, -1, 1, false)
+			name := fmt.Sprintf("trial %d: %v %v", trial, dt, shape)
+
+			dx := upload(t, r, dt, shape, x, false)
+			dres := upload(t, r, dt, shape, res, false)
+			dbias := upload(t, r, dt, []int{d}, bias, false)
+			dgamma := upload(t, r, dt, []int{d}, gamma, false)
+			dbeta := upload(t, r, dt, []int{d}, beta, false)
+			dout := upload(t, r, dt, shape, make([]float64, n), false)
+			dsum := upload(t, r, dt, shape, make([]float64, n), false)
+
+			// BiasGELU
+			approximate := trial%2 == 0
+			want := make([]float64, n)
+			for i := range want {
+				v := round(x[i]) + round(bias[i%d])
+				if approximate {
+					want[i] = 0.5 * v * (1 + math.Tanh(math.Sqrt(2/math.Pi)*(v+0.044715*v*v*v)))
+				} else {
+					want[i] = 0.5 * v * (1 + math.Erf(v/math.Sqrt2))
+				}
+			}
+			if err := BiasGELU(dout.view, dx.view, dbias.view, approximate, cu.NoStream); err != nil {
+				t.Fatalf("BiasGELU %v: %v", name, err)
+			}
+			checkClose(t, "BiasGELU "+name, dout.read(t), want, referenceTol(dt))
+
+			// AddLayerNorm
+			const eps = 1e-5
+			sum := make([]float64, n)
+			for row := 0; row < rows; row++ {
+				h := sum[row*d : (row+1)*d]
+				mean := 0.0
+				for j := range h {
+					h[j] = round(round(x[row*d+j]) + round(res[row*d+j]))
+					mean += h[j]
+				}
+				mean /= float64(d)
+				variance := 0.0
+				for _, v := range h {
+					variance += (v - mean) * (v - mean)
+				}
+				rstd := 1 / math.Sqrt(variance/float64(d)+eps)
+				for j, v := range h {
+					want[row*d+j] = (v-mean)*rstd*round(gamma[j]) + round(beta[j])
+				}
+			}
+			if err := AddLayerNorm(dout.view, dsum.view, dx.view, dres.view, dgamma.view, dbeta.view, eps, cu.NoStream); err != nil {
+				t.Fatalf("AddLayerNorm %v: %v", name, err)
+			}
+			checkClose(t, "AddLayerNorm sum "+name, dsum.read(t), sum, referenceTol(dt))
+			checkClose(t, "AddLayerNorm "+name, dout.read(t), want, 10*referenceTol(dt))
+
+			for _, b := range []deviceBuffer{dx, dres, dbias, dgamma, dbeta, dout, dsum} {
+				b.free()
+			}
+		}
+	})
+}
+
+func TestReferenceHelpers(t *testing.T) {
+	// the helpers of the reference tests do not need a device
+	var got [][]int
+	forIndex([]int{2, 3}, func(k int, idx []int) {
+		if k != len(got) {
+			t.Errorf("Expected index %d. Got %d", len(got), k)
+		}
+		got = append(got, append([]int(nil), idx...))
+	})
+	if len(got) != 6 || got[4][0] != 1 || got[4][1] != 1 {
+		t.Errorf("Unexpected indices %v", got)
+	}
+	forIndex([]int{2, 0}, func(k int, idx []int) { t.Error("Expected no elements") })
+
+	if k := broadcastIndex([]int{1, 2}, []int{1, 3}); k != 2 {
+		t.Errorf("Expected 2. Got %d", k)
+	}
+	if k := broadcastIndex([]int{1, 2}, []int{3}); k != 2 {
+		t.Errorf("Expected 2. Got %d", k)
+	}
+	if k := broadcastIndex([]int{1, 2}, []int{2, 1}); k != 1 {
+		t.Errorf("Expected 1. Got %d", k)
+	}
+	if v := refMax(math.NaN(), 1); !math.IsNaN(v) {
+		t.Errorf("Expected NaN. Got %v", v)
+	}
+	if v := refMin(1, math.NaN()); !math.IsNaN(v) {
+		t.Errorf("Expected NaN. Got %v", v)
+	}
+}
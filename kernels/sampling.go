@@ -0,0 +1,421 @@
+package kernels
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+	"gorgonia.org/cu/curand"
+)
+
+// MaxBeams is the maximum width of the beams of BeamSearchStep.
+const MaxBeams = 64
+
+// samplingSource holds the decoding kernels. Each row of logits is processed by one block.
+var samplingSource = &Source{
+	Name:   "sampling",
+	Dtypes: []cu.Dtype{cu.DtFloat32, cu.DtFloat64},
+	Code: `
+#define MAX_BEAMS 64
+#define NO_INDEX 0x7fffffffffffffffLL
+
+// blockAllSum and blockAllMax are like blockReduceSum and blockReduceMax, but the result is valid in every thread.
+__device__ double blockAllSum(double v) {
+	__shared__ double r;
+	v = blockReduceSum(v);
+	if (threadIdx.x == 0) {
+		r = v;
+	}
+	__syncthreads();
+	return r;
+}
+
+__device__ double blockAllMax(double v) {
+	__shared__ double r;
+	v = blockReduceMax(v);
+	if (threadIdx.x == 0) {
+		r = v;
+	}
+	__syncthreads();
+	return r;
+}
+
+// blockArgMax reduces (v, i) to the largest v across the block, and the smallest i among ties.
+// The result is only valid in thread 0.
+__device__ void blockArgMax(double& v, long long& i) {
+	__shared__ double vs[32];
+	__shared__ long long is[32];
+	int lane = threadIdx.x & 31;
+	int warp = threadIdx.x >> 5;
+	__syncthreads();
+	for (int o = 16; o > 0; o >>= 1) {
+		double ov = __shfl_down_sync(0xffffffff, v, o);
+		long long oi = __shfl_down_sync(0xffffffff, i, o);
+		if (ov > v || (ov == v && oi < i)) {
+			v = ov;
+			i = oi;
+		}
+	}
+	if (lane == 0) {
+		vs[warp] = v;
+		is[warp] = i;
+	}
+	__syncthreads();
+	if (warp == 0) {
+		bool valid = threadIdx.x < (blockDim.x >> 5);
+		v = valid ? vs[lane] : -INFINITY;
+		i = valid ? is[lane] : NO_INDEX;
+		for (int o = 16; o > 0; o >>= 1) {
+			double ov = __shfl_down_sync(0xffffffff, v, o);
+			long long oi = __shfl_down_sync(0xffffffff, i, o);
+			if (ov > v || (ov == v && oi < i)) {
+				v = ov;
+				i = oi;
+			}
+		}
+	}
+}
+
+extern "C" __global__ void greedy(int* tokens, const T* logits, long long n, long long v) {
+	ROWS(r, n) {
+		const T* x = logits + r * v;
+		double best = -INFINITY;
+		long long bi = NO_INDEX;
+		BLOCK_STRIDE(j, v) {
+			double a = ld(x, j);
+			if (a > best || (a == best && j < bi)) {
+				best = a;
+				bi = j;
+			}
+		}
+		blockArgMax(best, bi);
+		if (threadIdx.x == 0) {
+			tokens[r] = (int)bi;
+		}
+	}
+}
+
+// mass returns the sum of exp(a - mx) over the scaled logits a that are at least t.
+__device__ double mass(const T* x, long long v, double invTemp, double mx, double t) {
+	double z = 0;
+	BLOCK_STRIDE(j, v) {
+		double a = ld(x, j) * invTemp;
+		if (a >= t) {
+			z += exp(a - mx);
+		}
+	}
+	return blockAllSum(z);
+}
+
+// sample draws a token from the softmax of each row of logits scaled by invTemp, restricted to the k highest logits
+// (if k > 0), and to the smallest set of highest logits whose probability is at least p (if p < 1).
+// Both restrictions are thresholds on the logits, found by bisection. u holds a uniform number in (0, 1] per row.
+extern "C" __global__ void sample(int* tokens, const T* logits, const float* u, long long n, long long v, double invTemp, long long k, double p) {
+	__shared__ double sums[1024];
+	__shared__ long long chosen;
+	__shared__ double before;
+	ROWS(r, n) {
+		const T* x = logits + r * v;
+		double mx = -INFINITY;
+		double mn = INFINITY;
+		BLOCK_STRIDE(j, v) {
+			double a = ld(x, j) * invTemp;
+			mx = fmax(mx, a);
+			mn = fmin(mn, a);
+		}
+		mx = blockAllMax(mx);
+		mn = -blockAllMax(-mn);
+
+		// the largest threshold that keeps at least k logits
+		double t = mn;
+		if (k > 0 && k < v) {
+			double lo = mn, hi = mx;
+			for (int it = 0; it < 64; it++) {
+				double mid = lo + (hi - lo) / 2;
+				if (mid <= lo || mid >= hi) {
+					break;
+				}
+				double c = 0;
+				BLOCK_STRIDE(j, v) {
+					c += ld(x, j) * invTemp >= mid;
+				}
+				if (blockAllSum(c) >= k) {
+					lo = mid;
+				} else {
+					hi = mid;
+				}
+			}
+			t = lo;
+		}
+
+		// the largest threshold above that keeps a probability of at least p
+		double z = mass(x, v, invTemp, mx, t);
+		if (p < 1) {
+			double lo = t, hi = mx, target = p * z;
+			for (int it = 0; it < 64; it++) {
+				double mid = lo + (hi - lo) / 2;
+				if (mid <= lo || mid >= hi) {
+					break;
+				}
+				if (mass(x, v, invTemp, mx, mid) >= target) {
+					lo = mid;
+				} else {
+					hi = mid;
+				}
+			}
+			t = lo;
+			z = mass(x, v, invTemp, mx, t);
+		}
+
+		// invert the cumulative distribution: each thread sums a contiguous chunk of the row, thread 0 finds the
+		// chunk the target falls in, and the thread of that chunk finds the token.
+		double target = u[r] * z;
+		long long chunk = (v + blockDim.x - 1) / blockDim.x;
+		long long begin = threadIdx.x * chunk;
+		long long end = min(begin + chunk, v);
+		double s = 0;
+		for (long long j = begin; j < end; j++) {
+			double a = ld(x, j) * invTemp;
+			s += a >= t ? exp(a - mx) : 0;
+		}
+		sums[threadIdx.x] = s;
+		__syncthreads();
+		if (threadIdx.x == 0) {
+			double acc = 0;
+			chosen = -1;
+			for (int i = 0; i < blockDim.x; i++) {
+				if (sums[i] > 0) {
+					chosen = i;
+					before = acc;
+				}
+				acc += sums[i];
+				if (sums[i] > 0 && acc >= target) {
+					break;
+				}
+			}
+			if (chosen < 0) {
+				tokens[r] = -1;
+			}
+		}
+		__syncthreads();
+		if (threadIdx.x == chosen) {
+			double acc = before;
+			long long token = -1;
+			for (long long j = begin; j < end; j++) {
+				double a = ld(x, j) * invTemp;
+				if (a < t) {
+					continue;
+				}
+				token = j;
+				acc += exp(a - mx);
+				if (acc >= target) {
+					break;
+				}
+			}
+			tokens[r] = (int)token;
+		}
+		__syncthreads();
+	}
+}
+
+// beam_step extends each of the w beams of each of the n sequences with each of the v tokens, and selects the w
+// candidates of highest score. The score of a candidate is the score of its beam plus the log softmax of its token.
+extern "C" __global__ void beam_step(T* newScores, int* parents, int* tokens, const T* logits, const T* scores, long long n, long long w, long long v) {
+	__shared__ double logz[MAX_BEAMS];
+	__shared__ long long chosen[MAX_BEAMS];
+	ROWS(b, n) {
+		for (long long i = 0; i < w; i++) {
+			const T* x = logits + (b * w + i) * v;
+			double m = -INFINITY;
+			BLOCK_STRIDE(j, v) {
+				m = fmax(m, (double)ld(x, j));
+			}
+			m = blockAllMax(m);
+			double s = 0;
+			BLOCK_STRIDE(j, v) {
+				s += exp(ld(x, j) - m);
+			}
+			s = blockAllSum(s);
+			if (threadIdx.x == 0) {
+				logz[i] = m + log(s);
+			}
+		}
+		__syncthreads();
+
+		for (long long r = 0; r < w; r++) {
+			double best = -INFINITY;
+			long long bi = NO_INDEX;
+			BLOCK_STRIDE(c, w * v) {
+				bool taken = false;
+				for (long long q = 0; q < r; q++) {
+					taken |= chosen[q] == c;
+				}
+				if (taken) {
+					continue;
+				}
+				double sc = ld(scores, b * w + c / v) + ld(logits, b * w * v + c) - logz[c / v];
+				if (sc > best || (sc == best && c < bi)) {
+					best = sc;
+					bi = c;
+				}
+			}
+			blockArgMax(best, bi);
+			if (threadIdx.x == 0) {
+				chosen[r] = bi;
+				st(newScores, b * w + r, (acc_t)best);
+				parents[b * w + r] = (int)(bi / v);
+				tokens[b * w + r] = (int)(bi % v);
+			}
+			__syncthreads();
+		}
+	}
+}
+`,
+}
+
+// SamplingConfig configures the sampling of tokens from logits.
+type SamplingConfig struct {
+	Temperature float64 // the logits are divided by the temperature. 0 selects the most likely token (greedy decoding)
+	TopK        int     // if positive, only the TopK most likely tokens are sampled from
+	TopP        float64 // if in (0, 1), only the most likely tokens of cumulative probability TopP are sampled from (nucleus sampling)
+}
+
+// Sample draws a token from each row of logits, on the given stream.
+//
+// logits is a contiguous (N, V) matrix, and the tokens are written to tokens, a contiguous int32 vector of N.
+// uniform holds N float32 uniformly distributed in (0, 1], as generated by curand: see Sampler, which generates them.
+// uniform is not read if cfg.Temperature is 0.
+//
+// The top-k and top-p restrictions are found by bisection on the values of the logits, so logits that are equal
+// (within the resolution of the bisection) at the boundary are all kept.
+func Sample(tokens, logits cu.DeviceTensor, uniform cu.DevicePtr, cfg SamplingConfig, stream cu.Stream) error {
+	if logits.Dims() != 2 || !logits.IsContiguous() {
+		return errors.Errorf("Expected the logits to be a contiguous matrix. Got shape %v, strides %v", logits.Shape, logits.Strides)
+	}
+	n, v := logits.Shape[0], logits.Shape[1]
+	if err := checkInt32(tokens, n); err != nil {
+		return errors.Wrap(err, "tokens")
+	}
+	switch {
+	case cfg.Temperature < 0:
+		return errors.Errorf("Expected a non-negative temperature. Got %v", cfg.Temperature)
+	case cfg.TopK < 0:
+		return errors.Errorf("Expected a non-negative TopK. Got %d", cfg.TopK)
+	case cfg.TopP < 0 || cfg.TopP > 1:
+		return errors.Errorf("Expected TopP in [0, 1]. Got %v", cfg.TopP)
+	case cfg.Temperature == 0:
+		return launchRows(samplingSource, logits.Dtype, "greedy", n, stream, tokens.Ptr, logits.Ptr, n, v)
+	}
+	p := cfg.TopP
+	if p == 0 {
+		p = 1
+	}
+	return launchRows(samplingSource, logits.Dtype, "sample", n, stream, tokens.Ptr, logits.Ptr, uniform, n, v, 1/cfg.Temperature, cfg.TopK, p)
+}
+
+// Sampler samples tokens with random numbers generated by cuRAND, so that decoding loops stay on the device.
+//
+// A Sampler is bound to the context that was current when it was created.
+type Sampler struct {
+	sync.Mutex
+	gen     *curand.Generator
+	uniform cu.DevicePtr
+	size    int
+}
+
+// NewSampler creates a Sampler whose random numbers are seeded with seed.
+func NewSampler(seed uint64) (*Sampler, error) {
+	gen, err := curand.NewGenerator(curand.PseudoPhilox43210)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to create a random number generator")
+	}
+	if err = gen.SetSeed(seed); err != nil {
+		gen.Close()
+		return nil, errors.Wrap(err, "Unable to seed the random number generator")
+	}
+	return &Sampler{gen: gen}, nil
+}
+
+// Sample draws a token from each row of logits, on the given stream. See the function Sample.
+func (s *Sampler) Sample(tokens, logits cu.DeviceTensor, cfg SamplingConfig, stream cu.Stream) error {
+	s.Lock()
+	defer s.Unlock()
+	if cfg.Temperature == 0 {
+		return Sample(tokens, logits, 0, cfg, stream)
+	}
+	if logits.Dims() != 2 {
+		return errors.Errorf("Expected the logits to be a matrix. Got shape %v", logits.Shape)
+	}
+	n := logits.Shape[0]
+	if err := s.reserve(n); err != nil {
+		return err
+	}
+	if err := s.gen.SetStream(stream); err != nil {
+		return errors.Wrap(err, "Unable to set the stream of the random number generator")
+	}
+	if err := s.gen.Uniform(s.uniform, n); err != nil {
+		return errors.Wrap(err, "Unable to generate random numbers")
+	}
+	return Sample(tokens, logits, s.uniform, cfg, stream)
+}
+
+// reserve grows the buffer of random numbers to hold at least n numbers. The lock is expected to be held.
+func (s *Sampler) reserve(n int) error {
+	if n <= s.size {
+		return nil
+	}
+	mem, err := cu.MemAlloc(int64(n) * 4)
+	if err != nil {
+		return errors.Wrapf(err, "Unable to allocate %d random numbers", n)
+	}
+	if s.uniform != 0 {
+		cu.MemFree(s.uniform)
+	}
+	s.uniform, s.size = mem, n
+	return nil
+}
+
+// Close frees the random numbers and the generator.
+func (s *Sampler) Close() error {
+	s.Lock()
+	defer s.Unlock()
+	if s.uniform != 0 {
+		if err := cu.MemFree(s.uniform); err != nil {
+			return err
+		}
+		s.uniform, s.size = 0, 0
+	}
+	return s.gen.Close()
+}
+
+// BeamSearchStep advances a beam search by one token, on the given stream.
+//
+// logits is a contiguous (N×W, V) matrix of the logits of the next token of each of the W beams of N sequences, and
+// scores is a contiguous (N, W) matrix of the cumulative log probabilities of the beams. Each beam is extended with
+// each token, and the W extensions of highest score of each sequence are written, in decreasing order of score, to
+// newScores (N, W). parents and tokens (int32 (N, W) tensors) receive the beam each extension extends, and its token.
+//
+// newScores must not alias scores. W is at most MaxBeams. On the first step, give the first beam a score of 0 and the
+// others a score of -Inf, so that the beams do not all select the same tokens.
+func BeamSearchStep(newScores, parents, tokens, logits, scores cu.DeviceTensor, stream cu.Stream) error {
+	if scores.Dims() != 2 || !scores.IsContiguous() {
+		return errors.Errorf("Expected the scores to be a contiguous matrix. Got shape %v, strides %v", scores.Shape, scores.Strides)
+	}
+	n, w := scores.Shape[0], scores.Shape[1]
+	if w < 1 || w > MaxBeams {
+		return errors.Errorf("Expected between 1 and %d beams. Got %d", MaxBeams, w)
+	}
+	if logits.Dims() != 2 || logits.Shape[0] != n*w || !logits.IsContiguous() || logits.Dtype != scores.Dtype {
+		return errors.Errorf("Expected the logits to be a contiguous %v matrix of %d rows. Got %v of shape %v", scores.Dtype, n*w, logits.Dtype, logits.Shape)
+	}
+	if err := checkIndexed(newScores, parents, scores.Dtype, n, w); err != nil {
+		return errors.Wrap(err, "BeamSearchStep")
+	}
+	if err := checkInt32(tokens, n*w); err != nil {
+		return errors.Wrap(err, "tokens")
+	}
+	if newScores.Ptr == scores.Ptr {
+		return errors.New("newScores cannot alias scores")
+	}
+	return launchRows(samplingSource, scores.Dtype, "beam_step", n, stream, newScores.Ptr, parents.Ptr, tokens.Ptr, logits.Ptr, scores.Ptr, n, w, logits.Shape[1])
+}
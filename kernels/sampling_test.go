@@ -0,0 +1,86 @@
+package kernels
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gorgonia.org/cu"
+)
+
+func TestSampling(t *testing.T) {
+	withContext(t, func() {
+		logits := []float32{
+			0, 5, 1, 2,
+			3, 0, 0, 9,
+		}
+		pl := upload32(t, logits)
+		pTokens := upload32(t, make([]float32, 2))
+		for _, mem := range []cu.DevicePtr{pl, pTokens} {
+			defer cu.MemFree(mem)
+		}
+		x := cu.NewDeviceTensor(pl, cu.DtFloat32, 2, 4)
+		tokens := cu.NewDeviceTensor(pTokens, cu.DtInt32, 2)
+
+		if err := Sample(tokens, x, 0, SamplingConfig{}, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		if got := downloadInt32(t, pTokens, 2); !reflect.DeepEqual(got, []int32{1, 3}) {
+			t.Errorf("Greedy: expected [1 3]. Got %v", got)
+		}
+
+		// with TopK 1, sampling is greedy whatever the random numbers
+		s, err := NewSampler(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer s.Close()
+		if err = s.Sample(tokens, x, SamplingConfig{Temperature: 1, TopK: 1}, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		if got := downloadInt32(t, pTokens, 2); !reflect.DeepEqual(got, []int32{1, 3}) {
+			t.Errorf("TopK 1: expected [1 3]. Got %v", got)
+		}
+	})
+}
+
+func TestBeamSearchStep(t *testing.T) {
+	withContext(t, func() {
+		// one sequence, two beams, three tokens
+		logits := []float32{
+			0, 0, 0,
+			0, 10, 0,
+		}
+		scores := []float32{-1, -1.5}
+		pl := upload32(t, logits)
+		ps := upload32(t, scores)
+		pNew := upload32(t, make([]float32, 2))
+		pParents := upload32(t, make([]float32, 2))
+		pTokens := upload32(t, make([]float32, 2))
+		for _, mem := range []cu.DevicePtr{pl, ps, pNew, pParents, pTokens} {
+			defer cu.MemFree(mem)
+		}
+
+		err := BeamSearchStep(
+			cu.NewDeviceTensor(pNew, cu.DtFloat32, 1, 2),
+			cu.NewDeviceTensor(pParents, cu.DtInt32, 1, 2),
+			cu.NewDeviceTensor(pTokens, cu.DtInt32, 1, 2),
+			cu.NewDeviceTensor(pl, cu.DtFloat32, 2, 3),
+			cu.NewDeviceTensor(ps, cu.DtFloat32, 1, 2),
+			cu.NoStream)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := downloadInt32(t, pParents, 2); !reflect.DeepEqual(got, []int32{1, 0}) {
+			t.Errorf("Expected the parents [1 0]. Got %v", got)
+		}
+		if got := downloadInt32(t, pTokens, 2); !reflect.DeepEqual(got, []int32{1, 0}) {
+			t.Errorf("Expected the tokens [1 0]. Got %v", got)
+		}
+		logz := math.Log(2 + math.Exp(10))
+		correct := []float32{float32(-1.5 + 10 - logz), float32(-1 - math.Log(3))}
+		if got := download32(t, pNew, 2); !close32(got, correct, 1e-5) {
+			t.Errorf("Expected the scores %v. Got %v", correct, got)
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package kernels
+
+import (
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// segmentSource holds the kernels over ragged (CSR style) layouts. Segment s is made of rows offsets[s] to
+// offsets[s+1] of the values, and each row has d elements. Each segment is processed by one block.
+var segmentSource = &Source{
+	Name:   "segment",
+	Dtypes: []cu.Dtype{cu.DtFloat32, cu.DtFloat64},
+	Code: `
+template <typename I>
+__device__ void segment_reduce(T* out, const T* x, const I* offsets, long long segments, long long d, long long op) {
+	ROWS(s, segments) {
+		long long begin = offsets[s];
+		long long end = offsets[s + 1];
+		BLOCK_STRIDE(j, d) {
+			double acc = op == 2 ? -INFINITY : 0;
+			for (long long r = begin; r < end; r++) {
+				double v = ld(x, r * d + j);
+				acc = op == 2 ? max_nan(acc, v) : acc + v;
+			}
+			if (op == 1 && end > begin) {
+				acc /= (double)(end - begin);
+			}
+			st(out, s * d + j, (acc_t)acc);
+		}
+	}
+}
+
+template <typename I>
+__device__ void segment_expand(T* out, const T* x, const I* offsets, long long segments, long long d) {
+	ROWS(s, segments) {
+		long long begin = offsets[s];
+		long long end = offsets[s + 1];
+		for (long long r = begin; r < end; r++) {
+			BLOCK_STRIDE(j, d) {
+				st(out, r * d + j, ld(x, s * d + j));
+			}
+		}
+	}
+}
+
+extern "C" __global__ void segment_reduce_i32(T* out, const T* x, const int* offsets, long long segments, long long d, long long op) {
+	segment_reduce<int>(out, x, offsets, segments, d, op);
+}
+
+extern "C" __global__ void segment_reduce_i64(T* out, const T* x, const long long* offsets, long long segments, long long d, long long op) {
+	segment_reduce<long long>(out, x, offsets, segments, d, op);
+}
+
+extern "C" __global__ void segment_expand_i32(T* out, const T* x, const int* offsets, long long segments, long long d) {
+	segment_expand<int>(out, x, offsets, segments, d);
+}
+
+extern "C" __global__ void segment_expand_i64(T* out, const T* x, const long long* offsets, long long segments, long long d) {
+	segment_expand<long long>(out, x, offsets, segments, d);
+}
+`,
+}
+
+// segmentLayout checks the offsets, and returns the number of segments and the suffix of the kernel names for the offsets.
+func segmentLayout(offsets cu.DeviceTensor) (segments int, suffix string, err error) {
+	switch offsets.Dtype {
+	case cu.DtInt32:
+		suffix = "_i32"
+	case cu.DtInt64:
+		suffix = "_i64"
+	default:
+		return 0, "", errors.Errorf("Offsets must be int32 or int64. Got %v", offsets.Dtype)
+	}
+	if offsets.Dims() != 1 || offsets.Shape[0] < 1 || !offsets.IsContiguous() {
+		return 0, "", errors.Errorf("Offsets must be a contiguous vector of at least one element. Got shape %v, strides %v", offsets.Shape, offsets.Strides)
+	}
+	return offsets.Shape[0] - 1, suffix, nil
+}
+
+// rowSize returns the number of elements in each row of t, which must be contiguous.
+func rowSize(t cu.DeviceTensor) (int, error) {
+	if t.Dims() == 0 || !t.IsContiguous() {
+		return 0, errors.Errorf("Expected a contiguous tensor of at least one dimension. Got shape %v, strides %v", t.Shape, t.Strides)
+	}
+	return t.Len() / max(t.Shape[0], 1), nil
+}
+
+// SegmentReduce reduces the segments of rows of x described by offsets, on the given stream.
+//
+// offsets is a vector of S+1 int32 or int64, such that segment s consists of the rows offsets[s] to offsets[s+1] of x
+// (a CSR style ragged layout). The offsets must be non-decreasing, and within the rows of x. x and out must be
+// contiguous, and out must have S rows of the same size as the rows of x.
+func SegmentReduce(op ReduceOp, out, x, offsets cu.DeviceTensor, stream cu.Stream) error {
+	if op < ReduceSum || op > ReduceMax {
+		return errors.Errorf("Unknown reduction %d", int(op))
+	}
+	segments, suffix, err := segmentLayout(offsets)
+	if err != nil {
+		return errors.Wrapf(err, "Segment%v", op)
+	}
+	if err = checkSegmentOperands(out, x, segments); err != nil {
+		return errors.Wrapf(err, "Segment%v", op)
+	}
+	d, _ := rowSize(x)
+	return launchRows(segmentSource, x.Dtype, "segment_reduce"+suffix, segments, stream, out.Ptr, x.Ptr, offsets.Ptr, segments, d, int(op))
+}
+
+// SegmentExpand copies row s of x to every row of segment s of out: it is the inverse of SegmentReduce, and the
+// backward pass of a segment sum. See SegmentReduce for the layout.
+func SegmentExpand(out, x, offsets cu.DeviceTensor, stream cu.Stream) error {
+	segments, suffix, err := segmentLayout(offsets)
+	if err != nil {
+		return errors.Wrap(err, "SegmentExpand")
+	}
+	if err = checkSegmentOperands(x, out, segments); err != nil {
+		return errors.Wrap(err, "SegmentExpand")
+	}
+	d, _ := rowSize(x)
+	return launchRows(segmentSource, x.Dtype, "segment_expand"+suffix, segments, stream, out.Ptr, x.Ptr, offsets.Ptr, segments, d)
+}
+
+// checkSegmentOperands checks that reduced has a row per segment, of the same size as the rows of ragged.
+func checkSegmentOperands(reduced, ragged cu.DeviceTensor, segments int) error {
+	if reduced.Dtype != ragged.Dtype {
+		return errors.Errorf("Mismatched types %v and %v", reduced.Dtype, ragged.Dtype)
+	}
+	d, err := rowSize(ragged)
+	if err != nil {
+		return err
+	}
+	rd, err := rowSize(reduced)
+	if err != nil {
+		return err
+	}
+	if reduced.Shape[0] != segments || rd != d {
+		return errors.Errorf("Expected %d rows of %d elements. Got shape %v", segments, d, reduced.Shape)
+	}
+	return nil
+}
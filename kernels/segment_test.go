@@ -0,0 +1,121 @@
+package kernels
+
+import (
+	"math"
+	"testing"
+	"unsafe"
+
+	"gorgonia.org/cu"
+)
+
+// uploadSegmentOffsets copies the offsets to the device as int32 or int64.
+func uploadSegmentOffsets(t *testing.T, dt cu.Dtype, offsets []int) cu.DevicePtr {
+	var p unsafe.Pointer
+	var size int64
+	switch dt {
+	case cu.DtInt32:
+		o := make([]int32, len(offsets))
+		for i, v := range offsets {
+			o[i] = int32(v)
+		}
+		p, size = unsafe.Pointer(&o[0]), int64(len(o)*4)
+	default:
+		o := make([]int64, len(offsets))
+		for i, v := range offsets {
+			o[i] = int64(v)
+		}
+		p, size = unsafe.Pointer(&o[0]), int64(len(o)*8)
+	}
+	mem, err := cu.MemAlloc(size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cu.MemcpyHtoD(mem, p, size); err != nil {
+		t.Fatal(err)
+	}
+	return mem
+}
+
+func TestSegmentOperands(t *testing.T) {
+	x := cu.NewDeviceTensor(0x1000, cu.DtFloat32, 5, 2)
+	out := cu.NewDeviceTensor(0x2000, cu.DtFloat32, 3, 2)
+	offsets := cu.NewDeviceTensor(0x3000, cu.DtInt32, 4)
+
+	cases := []struct {
+		name            string
+		out, x, offsets cu.DeviceTensor
+		op              ReduceOp
+	}{
+		{"float offsets", out, x, cu.NewDeviceTensor(0x3000, cu.DtFloat32, 4), ReduceSum},
+		{"matrix of offsets", out, x, cu.NewDeviceTensor(0x3000, cu.DtInt64, 2, 2), ReduceSum},
+		{"no offsets", out, x, cu.NewDeviceTensor(0x3000, cu.DtInt64, 0), ReduceSum},
+		{"rows of out", cu.NewDeviceTensor(0x2000, cu.DtFloat32, 2, 2), x, offsets, ReduceSum},
+		{"row size", cu.NewDeviceTensor(0x2000, cu.DtFloat32, 3, 3), x, offsets, ReduceSum},
+		{"types", cu.NewDeviceTensor(0x2000, cu.DtFloat64, 3, 2), x, offsets, ReduceSum},
+		{"op", out, x, offsets, ReduceOp(7)},
+	}
+	for _, c := range cases {
+		if err := SegmentReduce(c.op, c.out, c.x, c.offsets, cu.NoStream); err == nil {
+			t.Errorf("Expected SegmentReduce to refuse the %s", c.name)
+		}
+		if c.op == ReduceSum {
+			if err := SegmentExpand(c.x, c.out, c.offsets, cu.NoStream); err == nil {
+				t.Errorf("Expected SegmentExpand to refuse the %s", c.name)
+			}
+		}
+	}
+}
+
+func TestSegment(t *testing.T) {
+	withContext(t, func() {
+		// 3 segments of rows of 2 elements: rows 0-1, none, and rows 2-4
+		offsets := []int{0, 2, 2, 5}
+		x := []float32{
+			1, 2,
+			3, 4,
+			5, 6,
+			7, 8,
+			-9, 10,
+		}
+		inf := float32(math.Inf(-1))
+		want := map[ReduceOp][]float32{
+			ReduceSum:  {4, 6, 0, 0, 3, 24},
+			ReduceMean: {2, 3, 0, 0, 1, 8},
+			ReduceMax:  {3, 4, inf, inf, 7, 10},
+		}
+
+		px := upload32(t, x)
+		defer cu.MemFree(px)
+		pout := upload32(t, make([]float32, 6))
+		defer cu.MemFree(pout)
+		pexp := upload32(t, make([]float32, len(x)))
+		defer cu.MemFree(pexp)
+		tx := cu.NewDeviceTensor(px, cu.DtFloat32, 5, 2)
+		out := cu.NewDeviceTensor(pout, cu.DtFloat32, 3, 2)
+		expanded := cu.NewDeviceTensor(pexp, cu.DtFloat32, 5, 2)
+
+		for _, dt := range []cu.Dtype{cu.DtInt32, cu.DtInt64} {
+			po := uploadSegmentOffsets(t, dt, offsets)
+			defer cu.MemFree(po)
+			to := cu.NewDeviceTensor(po, dt, len(offsets))
+
+			for _, op := range []ReduceOp{ReduceSum, ReduceMean, ReduceMax} {
+				if err := SegmentReduce(op, out, tx, to, cu.NoStream); err != nil {
+					t.Fatalf("Segment%v with %v offsets: %v", op, dt, err)
+				}
+				if got := download32(t, pout, 6); !close32(got, want[op], 1e-5) {
+					t.Errorf("Segment%v with %v offsets: expected %v. Got %v", op, dt, want[op], got)
+				}
+			}
+
+			// the rows of each segment receive the row of the segment
+			if err := SegmentExpand(expanded, out, to, cu.NoStream); err != nil {
+				t.Fatalf("SegmentExpand with %v offsets: %v", dt, err)
+			}
+			wantExpanded := []float32{3, 4, 3, 4, 7, 10, 7, 10, 7, 10}
+			if got := download32(t, pexp, len(x)); !close32(got, wantExpanded, 0) {
+				t.Errorf("SegmentExpand with %v offsets: expected %v. Got %v", dt, wantExpanded, got)
+			}
+		}
+	})
+}
@@ -0,0 +1,186 @@
+package kernels
+
+import (
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// maxRank is the number of dimensions of the iteration space of the strided kernels (see nd_offset).
+// Operands of higher rank are handled by launching a kernel for each index of the outer dimensions.
+const maxRank = 4
+
+// layout is the iteration space of an elementwise operation: the shape, and the strides of each operand in it.
+// Operand 0 is the output.
+type layout struct {
+	shape   []int
+	strides [][]int
+}
+
+// makeLayout broadcasts the inputs to the shape of the output, and coalesces the dimensions that are contiguous
+// in every operand.
+func makeLayout(out cu.DeviceTensor, ins ...cu.DeviceTensor) (l layout, err error) {
+	if err = out.Check(); err != nil {
+		return l, errors.Wrap(err, "Invalid output")
+	}
+	for i, s := range out.Strides {
+		// empty outputs are not written, and may have strides of 0 (see cu.RowMajorStrides)
+		if s == 0 && out.Shape[i] > 1 && out.Len() > 0 {
+			return l, errors.Errorf("The output cannot be a broadcast view (shape %v, strides %v)", out.Shape, out.Strides)
+		}
+	}
+	l.strides = [][]int{out.Strides}
+	for i, in := range ins {
+		if err = in.Check(); err != nil {
+			return l, errors.Wrapf(err, "Invalid operand %d", i)
+		}
+		var b cu.DeviceTensor
+		if b, err = in.BroadcastTo(out.Shape...); err != nil {
+			return l, errors.Wrapf(err, "Operand %d", i)
+		}
+		l.strides = append(l.strides, b.Strides)
+	}
+
+	// coalesce, from the innermost dimension outwards, dropping dimensions of size 1
+	var shape []int
+	strides := make([][]int, len(l.strides))
+	for d := len(out.Shape) - 1; d >= 0; d-- {
+		size := out.Shape[d]
+		if size == 1 {
+			continue
+		}
+		merge := len(shape) > 0
+		for k, s := range l.strides {
+			if merge && s[d] != strides[k][0]*shape[0] {
+				merge = false
+			}
+		}
+		if merge {
+			shape[0] *= size
+			continue
+		}
+		shape = append([]int{size}, shape...)
+		for k, s := range l.strides {
+			strides[k] = append([]int{s[d]}, strides[k]...)
+		}
+	}
+	l.shape = shape
+	l.strides = strides
+	return l, nil
+}
+
+// contiguous returns true if every operand is contiguous in the iteration space.
+func (l layout) contiguous() bool {
+	if len(l.shape) > 1 {
+		return false
+	}
+	for _, s := range l.strides {
+		if len(s) == 1 && s[0] != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// len returns the number of elements in the iteration space.
+func (l layout) len() int {
+	n := 1
+	for _, s := range l.shape {
+		n *= s
+	}
+	return n
+}
+
+// forEach calls fn for each index of the dimensions beyond maxRank, with the element offset of each operand and
+// the inner layout, which has exactly maxRank dimensions (padded with leading dimensions of size 1).
+func (l layout) forEach(fn func(offsets []int, inner layout) error) error {
+	outer := len(l.shape) - maxRank
+	if outer < 0 {
+		outer = 0
+	}
+	inner := layout{shape: pad(l.shape[outer:], 1)}
+	for _, s := range l.strides {
+		inner.strides = append(inner.strides, pad(s[outer:], 0))
+	}
+
+	offsets := make([]int, len(l.strides))
+	idx := make([]int, outer)
+	for {
+		if err := fn(offsets, inner); err != nil {
+			return err
+		}
+		// increment the outer index, and update the offsets
+		d := outer - 1
+		for ; d >= 0; d-- {
+			idx[d]++
+			for k, s := range l.strides {
+				offsets[k] += s[d]
+			}
+			if idx[d] < l.shape[d] {
+				break
+			}
+			for k, s := range l.strides {
+				offsets[k] -= s[d] * idx[d]
+			}
+			idx[d] = 0
+		}
+		if d < 0 {
+			return nil
+		}
+	}
+}
+
+// pad pads a to maxRank dimensions with leading values of v.
+func pad(a []int, v int) []int {
+	retVal := make([]int, maxRank)
+	for i := range retVal {
+		retVal[i] = v
+	}
+	copy(retVal[maxRank-len(a):], a)
+	return retVal
+}
+
+// launchStrided launches a kernel over the iteration space of the layout of the operands (the output first).
+// The kernel takes the operand pointers, followed by the number of elements, the extra arguments, the sizes of
+// dimensions 1 to 3 of the iteration space, and the 4 strides of each operand:
+//
+//	kernel(T* out, const T* in..., long long n, args..., long long s1, long long s2, long long s3, long long o0, ..., long long o3, ...)
+//
+// If every operand is contiguous, flat is launched instead, without the sizes and strides.
+func launchStrided(src *Source, dt cu.Dtype, strided, flat string, l layout, operands []cu.DeviceTensor, stream cu.Stream, args ...interface{}) error {
+	n := l.len()
+	if n == 0 {
+		return nil
+	}
+	for _, o := range operands {
+		if err := o.CheckMemory(); err != nil {
+			return errors.Wrapf(err, "Unable to launch %v", strided)
+		}
+	}
+	if l.contiguous() && flat != "" {
+		params := make([]interface{}, 0, len(operands)+1+len(args))
+		for _, o := range operands {
+			params = append(params, o.Ptr)
+		}
+		params = append(params, n)
+		return launch(src, dt, flat, n, stream, append(params, args...)...)
+	}
+
+	return l.forEach(func(offsets []int, inner layout) error {
+		innerN := inner.len()
+		params := make([]interface{}, 0, len(operands)+1+len(args)+3+len(operands)*maxRank)
+		for k, o := range operands {
+			params = append(params, o.Ptr+cu.DevicePtr(int64(offsets[k])*o.Dtype.Size()))
+		}
+		params = append(params, innerN)
+		params = append(params, args...)
+		for _, s := range inner.shape[1:] {
+			params = append(params, s)
+		}
+		for _, strides := range inner.strides {
+			for _, s := range strides {
+				params = append(params, s)
+			}
+		}
+		return launch(src, dt, strided, innerN, stream, params...)
+	})
+}
@@ -0,0 +1,61 @@
+package kernels
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"gorgonia.org/cu"
+)
+
+// withContext runs fn on a locked thread with a current context. The test is skipped if there is no device.
+func withContext(t *testing.T, fn func()) {
+	if devices, _ := cu.NumDevices(); devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	ctx, err := cu.Device(0).MakeContext(cu.SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+	fn()
+}
+
+func upload32(t *testing.T, data []float32) cu.DevicePtr {
+	size := int64(len(data) * 4)
+	mem, err := cu.MemAlloc(size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cu.MemcpyHtoD(mem, unsafe.Pointer(&data[0]), size); err != nil {
+		t.Fatal(err)
+	}
+	return mem
+}
+
+func download32(t *testing.T, mem cu.DevicePtr, n int) []float32 {
+	retVal := make([]float32, n)
+	if err := cu.Synchronize(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cu.MemcpyDtoH(unsafe.Pointer(&retVal[0]), mem, int64(n*4)); err != nil {
+		t.Fatal(err)
+	}
+	return retVal
+}
+
+func close32(a, b []float32, tol float32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		d := a[i] - b[i]
+		if d > tol || d < -tol {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,181 @@
+package kernels
+
+import (
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// transformerSource holds fused kernels of the small operations of transformer layers, which would otherwise each
+// take a launch and a round trip through device memory.
+var transformerSource = &Source{
+	Name:   "transformer",
+	Dtypes: []cu.Dtype{cu.DtFloat32, cu.DtFloat64},
+	Code: `
+// rope rotates pairs of the first rot elements of each head of each token by an angle of pos * base^(-2j/rot), where
+// j is the index of the pair. Pairs are (2j, 2j+1) if interleaved, and (j, j+rot/2) otherwise.
+extern "C" __global__ void rope(T* out, const T* x, const int* positions, long long tokens, long long heads, long long dim, long long rot, double base, long long interleaved) {
+	long long half = rot / 2;
+	GRID_STRIDE(i, tokens * heads * half) {
+		long long j = i % half;
+		long long h = i / half;
+		long long tok = h / heads;
+		long long a = interleaved ? 2 * j : j;
+		long long b = interleaved ? 2 * j + 1 : j + half;
+		double theta = positions[tok] * pow(base, -2.0 * j / rot);
+		double c = cos(theta), s = sin(theta);
+		const T* p = x + h * dim;
+		T* q = out + h * dim;
+		double xa = ld(p, a), xb = ld(p, b);
+		st(q, a, (acc_t)(xa * c - xb * s));
+		st(q, b, (acc_t)(xa * s + xb * c));
+		if (out != x) {
+			for (long long e = rot + j; e < dim; e += half) {
+				st(q, e, ld(p, e));
+			}
+		}
+	}
+}
+
+// add_layer_norm computes h = x + residual, and normalizes each row of d elements of h. h is written to sum, unless
+// it is null. sum may alias x or residual, so once it has been written h is read back from it. Each row is processed
+// by one block.
+extern "C" __global__ void add_layer_norm(T* out, T* sum, const T* x, const T* residual, const T* gamma, const T* beta, long long rows, long long d, double eps) {
+	__shared__ double mean, rstd;
+	ROWS(r, rows) {
+		const T* xr = x + r * d;
+		const T* rr = residual + r * d;
+		T* sr = sum ? sum + r * d : 0;
+		double s = 0;
+		BLOCK_STRIDE(j, d) {
+			double h = (double)ld(xr, j) + ld(rr, j);
+			s += h;
+			if (sr) {
+				st(sr, j, (acc_t)h);
+			}
+		}
+#define H(j) (sr ? (double)ld(sr, j) : (double)ld(xr, j) + ld(rr, j))
+		s = blockReduceSum(s);
+		if (threadIdx.x == 0) {
+			mean = s / d;
+		}
+		__syncthreads();
+		double sq = 0;
+		BLOCK_STRIDE(j, d) {
+			double h = H(j) - mean;
+			sq += h * h;
+		}
+		sq = blockReduceSum(sq);
+		if (threadIdx.x == 0) {
+			rstd = rsqrt(sq / d + eps);
+		}
+		__syncthreads();
+		BLOCK_STRIDE(j, d) {
+			double h = (H(j) - mean) * rstd;
+			st(out, r * d + j, (acc_t)(h * ld(gamma, j) + ld(beta, j)));
+		}
+#undef H
+	}
+}
+
+// bias_gelu computes gelu(x + bias), where the bias is broadcast over rows of d elements.
+extern "C" __global__ void bias_gelu(T* out, const T* x, const T* bias, long long n, long long d, long long approximate) {
+	GRID_STRIDE(i, n) {
+		double v = (double)ld(x, i) + ld(bias, i % d);
+		double g;
+		if (approximate) {
+			g = 0.5 * v * (1 + tanh(0.7978845608028654 * (v + 0.044715 * v * v * v)));
+		} else {
+			g = 0.5 * v * (1 + erf(v * 0.7071067811865476));
+		}
+		st(out, i, (acc_t)g);
+	}
+}
+`,
+}
+
+// RoPEConfig configures rotary position embeddings.
+type RoPEConfig struct {
+	Base        float64 // base of the frequencies, typically 10000
+	RotaryDim   int     // number of leading elements of each head that are rotated. 0 rotates the whole head
+	Interleaved bool    // rotate the pairs of adjacent elements (GPT-J) instead of the pairs of elements RotaryDim/2 apart (GPT-NeoX, LLaMA)
+}
+
+// RoPE applies rotary position embeddings to x, on the given stream.
+//
+// x and out are contiguous (tokens, heads, headDim) tensors, and positions is an int32 vector of the position of each
+// token. out may alias x.
+func RoPE(out, x, positions cu.DeviceTensor, cfg RoPEConfig, stream cu.Stream) error {
+	if x.Dims() != 3 || !x.IsContiguous() || !sameShape(out, x) || !out.IsContiguous() || out.Dtype != x.Dtype {
+		return errors.Errorf("Expected x and out to be contiguous (tokens, heads, headDim) tensors of the same type. Got %v %v and %v %v", x.Shape, x.Dtype, out.Shape, out.Dtype)
+	}
+	tokens, heads, dim := x.Shape[0], x.Shape[1], x.Shape[2]
+	if err := checkInt32(positions, tokens); err != nil {
+		return errors.Wrap(err, "positions")
+	}
+	rot := cfg.RotaryDim
+	if rot == 0 {
+		rot = dim
+	}
+	if rot < 2 || rot > dim || rot%2 != 0 {
+		return errors.Errorf("The rotary dimension must be even, and at most the head dimension %d. Got %d", dim, rot)
+	}
+	if cfg.Base <= 0 {
+		return errors.Errorf("Expected a positive base. Got %v", cfg.Base)
+	}
+	return launch(transformerSource, x.Dtype, "rope", tokens*heads*rot/2, stream, out.Ptr, x.Ptr, positions.Ptr, tokens, heads, dim, rot, cfg.Base, cfg.Interleaved)
+}
+
+// AddLayerNorm adds the residual to x, and applies layer normalization over the last dimension, on the given stream:
+//
+//	sum = x + residual
+//	out = (sum - mean(sum)) / sqrt(var(sum) + eps) * gamma + beta
+//
+// x, residual and out are contiguous tensors of the same shape, and gamma and beta are vectors of the size of the last
+// dimension. sum receives the sum, which is the residual of the next layer; it is not written if its pointer is 0.
+// sum may alias x or residual, and out may alias any of them.
+func AddLayerNorm(out, sum, x, residual, gamma, beta cu.DeviceTensor, eps float64, stream cu.Stream) error {
+	if err := checkRows(x); err != nil {
+		return errors.Wrap(err, "AddLayerNorm")
+	}
+	d := x.Shape[x.Dims()-1]
+	operands := []cu.DeviceTensor{out, residual}
+	if sum.Ptr != 0 {
+		operands = append(operands, sum)
+	}
+	for _, t := range operands {
+		if !sameShape(t, x) || !t.IsContiguous() || t.Dtype != x.Dtype {
+			return errors.Errorf("Expected contiguous %v tensors of shape %v. Got %v of shape %v", x.Dtype, x.Shape, t.Dtype, t.Shape)
+		}
+	}
+	for _, t := range []cu.DeviceTensor{gamma, beta} {
+		if t.Len() != d || !t.IsContiguous() || t.Dtype != x.Dtype {
+			return errors.Errorf("Expected gamma and beta to be contiguous %v vectors of %d elements. Got %v of shape %v", x.Dtype, d, t.Dtype, t.Shape)
+		}
+	}
+	rows := x.Len() / d
+	return launchRows(transformerSource, x.Dtype, "add_layer_norm", rows, stream, out.Ptr, sum.Ptr, x.Ptr, residual.Ptr, gamma.Ptr, beta.Ptr, rows, d, eps)
+}
+
+// BiasGELU computes out = gelu(x + bias) on the given stream, where bias is a vector broadcast over the last dimension
+// of x. If approximate, the tanh approximation of GELU is used, as GPT-2 does. out may alias x.
+func BiasGELU(out, x, bias cu.DeviceTensor, approximate bool, stream cu.Stream) error {
+	if err := checkRows(x); err != nil {
+		return errors.Wrap(err, "BiasGELU")
+	}
+	d := x.Shape[x.Dims()-1]
+	if !sameShape(out, x) || !out.IsContiguous() || out.Dtype != x.Dtype {
+		return errors.Errorf("Expected out to be a contiguous %v tensor of shape %v. Got %v of shape %v", x.Dtype, x.Shape, out.Dtype, out.Shape)
+	}
+	if bias.Len() != d || !bias.IsContiguous() || bias.Dtype != x.Dtype {
+		return errors.Errorf("Expected the bias to be a contiguous %v vector of %d elements. Got %v of shape %v", x.Dtype, d, bias.Dtype, bias.Shape)
+	}
+	return launch(transformerSource, x.Dtype, "bias_gelu", x.Len(), stream, out.Ptr, x.Ptr, bias.Ptr, x.Len(), d, approximate)
+}
+
+// checkRows checks that t is a contiguous, non empty tensor, whose last dimension is made of rows.
+func checkRows(t cu.DeviceTensor) error {
+	if t.Dims() == 0 || t.Len() == 0 || !t.IsContiguous() {
+		return errors.Errorf("Expected a contiguous, non empty tensor of at least one dimension. Got shape %v, strides %v", t.Shape, t.Strides)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package kernels
+
+import (
+	"math"
+	"testing"
+
+	"gorgonia.org/cu"
+)
+
+func TestTransformer(t *testing.T) {
+	withContext(t, func() {
+		px := upload32(t, []float32{1, 0, 0, 1})
+		pr := upload32(t, []float32{1, 2, 3, 4})
+		pOnes := upload32(t, []float32{1, 1})
+		pZeros := upload32(t, []float32{0, 0})
+		pOut := upload32(t, make([]float32, 4))
+		pSum := upload32(t, make([]float32, 4))
+		pPos := upload32(t, make([]float32, 2))
+		for _, mem := range []cu.DevicePtr{px, pr, pOnes, pZeros, pOut, pSum, pPos} {
+			defer cu.MemFree(mem)
+		}
+		x := cu.NewDeviceTensor(px, cu.DtFloat32, 2, 2)
+		residual := cu.NewDeviceTensor(pr, cu.DtFloat32, 2, 2)
+		ones := cu.NewDeviceTensor(pOnes, cu.DtFloat32, 2)
+		zeros := cu.NewDeviceTensor(pZeros, cu.DtFloat32, 2)
+		out := cu.NewDeviceTensor(pOut, cu.DtFloat32, 2, 2)
+		sum := cu.NewDeviceTensor(pSum, cu.DtFloat32, 2, 2)
+
+		if err := AddLayerNorm(out, sum, x, residual, ones, zeros, 1e-5, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		if got, correct := download32(t, pSum, 4), []float32{2, 2, 3, 5}; !close32(got, correct, 0) {
+			t.Errorf("AddLayerNorm: expected the sum %v. Got %v", correct, got)
+		}
+		if got, correct := download32(t, pOut, 4), []float32{0, 0, -1, 1}; !close32(got, correct, 1e-5) {
+			t.Errorf("AddLayerNorm: expected %v. Got %v", correct, got)
+		}
+
+		if err := BiasGELU(out, x, zeros, false, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		g1 := float32(0.5 * (1 + math.Erf(1/math.Sqrt2)))
+		if got, correct := download32(t, pOut, 4), []float32{g1, 0, 0, g1}; !close32(got, correct, 1e-6) {
+			t.Errorf("BiasGELU: expected %v. Got %v", correct, got)
+		}
+
+		// positions 0 and 1: the first token is unchanged, the second is rotated by 1 radian
+		if err := cu.MemsetD32(pPos+4, 1, 1); err != nil {
+			t.Fatal(err)
+		}
+		positions := cu.NewDeviceTensor(pPos, cu.DtInt32, 2)
+		heads := cu.NewDeviceTensor(px, cu.DtFloat32, 2, 1, 2)
+		rotated := cu.NewDeviceTensor(pOut, cu.DtFloat32, 2, 1, 2)
+		if err := RoPE(rotated, heads, positions, RoPEConfig{Base: 10000}, cu.NoStream); err != nil {
+			t.Fatal(err)
+		}
+		s, c := math.Sincos(1)
+		if got, correct := download32(t, pOut, 4), []float32{1, 0, float32(-s), float32(c)}; !close32(got, correct, 1e-6) {
+			t.Errorf("RoPE: expected %v. Got %v", correct, got)
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package cu
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// kernelNames are the names the functions were looked up with (see Module.Function), by handle.
+var kernelNames sync.Map
+
+// Name returns the name the function was looked up with, or an empty string if it was not looked up by name.
+func (fn Function) Name() string {
+	if name, ok := kernelNames.Load(fn.Uintptr()); ok {
+		return name.(string)
+	}
+	return ""
+}
+
+// collectEvery is the number of timed launches after which the completed ones are passed to the observer.
+const collectEvery = 64
+
+var kernelTiming struct {
+	on uint32 // read atomically
+
+	sync.Mutex
+	observe  func(name string, d time.Duration)
+	accts    map[CUContext]*Accountant
+	launches int
+}
+
+// TimeKernels turns on the timing of the kernel launches made with (Function).Launch. The duration of every launch is
+// passed to observe, with the name of its kernel, once it is complete: every so often as kernels are launched, and when
+// CollectKernelTimes is called. A nil observe turns the timing off.
+//
+// Timing records a pair of events around every launch, which costs a few microseconds of the launching thread.
+//
+// Typically, the observer is a histogram registry of package metrics:
+//
+//	cu.TimeKernels(metrics.Kernels.Observe)
+func TimeKernels(observe func(name string, d time.Duration)) {
+	kernelTiming.Lock()
+	defer kernelTiming.Unlock()
+	kernelTiming.observe = observe
+	if observe == nil {
+		atomic.StoreUint32(&kernelTiming.on, 0)
+		return
+	}
+	for _, a := range kernelTiming.accts {
+		a.Lock()
+		a.observe = observe
+		a.Unlock()
+	}
+	atomic.StoreUint32(&kernelTiming.on, 1)
+}
+
+// CollectKernelTimes passes the durations of the completed launches made in the current context to the observer of
+// TimeKernels.
+func CollectKernelTimes() error {
+	a, err := kernelAccountant()
+	if err != nil || a == nil {
+		return err
+	}
+	return a.Collect()
+}
+
+func timingKernels() bool { return atomic.LoadUint32(&kernelTiming.on) != 0 }
+
+// kernelAccountant returns the Accountant of the launches of the current context, creating it if needed.
+func kernelAccountant() (*Accountant, error) {
+	ctx, err := CurrentContext()
+	if err != nil {
+		return nil, err
+	}
+	kernelTiming.Lock()
+	defer kernelTiming.Unlock()
+	a, ok := kernelTiming.accts[ctx]
+	if !ok && kernelTiming.observe != nil {
+		a = NewAccountant()
+		a.observe = kernelTiming.observe
+		if kernelTiming.accts == nil {
+			kernelTiming.accts = make(map[CUContext]*Accountant)
+		}
+		kernelTiming.accts[ctx] = a
+	}
+	return a, nil
+}
+
+// timeLaunch times the launch of the kernel.
+func timeLaunch(fn Function, stream Stream, launch func() error) error {
+	a, err := kernelAccountant()
+	if err != nil || a == nil {
+		return launch()
+	}
+	name := fn.Name()
+	if name == "" {
+		name = fmt.Sprintf("function %#x", fn.Uintptr())
+	}
+	if err = a.Measure(name, stream, launch); err != nil {
+		return err
+	}
+
+	kernelTiming.Lock()
+	kernelTiming.launches++
+	collect := kernelTiming.launches%collectEvery == 0
+	kernelTiming.Unlock()
+	if collect {
+		return a.Collect()
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package cu
+
+// #include <cuda.h>
+import "C"
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// launchAttrValueSize is the size of a CUlaunchAttributeValue, a union that cgo exposes as bytes.
+const launchAttrValueSize = C.sizeof_CUlaunchAttributeValue
+
+// launchAttr is an attribute of a launch, with the bytes of its value.
+type launchAttr struct {
+	id    C.CUlaunchAttributeID
+	value [launchAttrValueSize]byte
+}
+
+// programmaticEvent and launchCompletionEvent are the layouts of members of the union of CUlaunchAttributeValue.
+type programmaticEvent struct {
+	event               C.CUevent
+	flags               C.int
+	triggerAtBlockStart C.int
+}
+
+type launchCompletionEvent struct {
+	event C.CUevent
+	flags C.int
+}
+
+// LaunchAttrs are the attributes of a launch by LaunchWithAttrs. They are set with the methods, which may be chained:
+//
+//	var attrs cu.LaunchAttrs
+//	attrs.ProgrammaticStreamSerialization(true).Priority(1)
+//	err := fn.LaunchWithAttrs(cfg, &attrs, stream, args)
+//
+// Attributes the methods do not cover yet are set with Raw. Setting an attribute twice keeps the last value.
+type LaunchAttrs struct {
+	attrs []launchAttr
+	err   error
+}
+
+func (a *LaunchAttrs) set(id C.CUlaunchAttributeID, value func(v unsafe.Pointer)) *LaunchAttrs {
+	attr := launchAttr{id: id}
+	value(unsafe.Pointer(&attr.value[0]))
+	for i := range a.attrs {
+		if a.attrs[i].id == id {
+			a.attrs[i] = attr
+			return a
+		}
+	}
+	a.attrs = append(a.attrs, attr)
+	return a
+}
+
+func cbool(b bool) C.int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Cooperative makes the blocks of the launch run at the same time, so that they can synchronize with each other, as
+// cuLaunchCooperativeKernel does.
+func (a *LaunchAttrs) Cooperative(on bool) *LaunchAttrs {
+	return a.set(C.CU_LAUNCH_ATTRIBUTE_COOPERATIVE, func(v unsafe.Pointer) { *(*C.int)(v) = cbool(on) })
+}
+
+// ClusterSchedulingPolicy sets the policy with which the blocks of the clusters are scheduled (see LaunchConfig.Cluster).
+func (a *LaunchAttrs) ClusterSchedulingPolicy(p ClusterSchedulingPolicy) *LaunchAttrs {
+	return a.set(C.CU_LAUNCH_ATTRIBUTE_CLUSTER_SCHEDULING_POLICY_PREFERENCE, func(v unsafe.Pointer) {
+		*(*C.CUclusterSchedulingPolicy)(v) = C.CUclusterSchedulingPolicy(p)
+	})
+}
+
+// ProgrammaticStreamSerialization allows the kernel to start before the previous kernel of the stream has finished
+// (programmatic dependent launch). The kernel waits for the results of the previous one with
+// cudaGridDependencySynchronize, and the previous kernel lets it start early with
+// cudaTriggerProgrammaticLaunchCompletion.
+func (a *LaunchAttrs) ProgrammaticStreamSerialization(allowed bool) *LaunchAttrs {
+	return a.set(C.CU_LAUNCH_ATTRIBUTE_PROGRAMMATIC_STREAM_SERIALIZATION, func(v unsafe.Pointer) { *(*C.int)(v) = cbool(allowed) })
+}
+
+// ProgrammaticEvent records the event once all the blocks of the kernel have called
+// cudaTriggerProgrammaticLaunchCompletion, or, if triggerAtBlockStart, once they have started. Work waiting for the
+// event may start before the kernel has finished, but it does not see the memory the kernel writes after the trigger.
+// The event must be created with DisableTiming.
+func (a *LaunchAttrs) ProgrammaticEvent(e Event, triggerAtBlockStart bool) *LaunchAttrs {
+	return a.set(C.CU_LAUNCH_ATTRIBUTE_PROGRAMMATIC_EVENT, func(v unsafe.Pointer) {
+		*(*programmaticEvent)(v) = programmaticEvent{event: e.ev, triggerAtBlockStart: cbool(triggerAtBlockStart)}
+	})
+}
+
+// LaunchCompletionEvent records the event once all the blocks of the kernel have started, which lets the next kernel
+// overlap the tail of this one. The event must be created with DisableTiming.
+func (a *LaunchAttrs) LaunchCompletionEvent(e Event) *LaunchAttrs {
+	return a.set(C.CU_LAUNCH_ATTRIBUTE_LAUNCH_COMPLETION_EVENT, func(v unsafe.Pointer) {
+		*(*launchCompletionEvent)(v) = launchCompletionEvent{event: e.ev}
+	})
+}
+
+// Priority sets the priority of the kernel, as the priority of a stream does (see MakeStreamWithPriority), but for
+// this launch only.
+func (a *LaunchAttrs) Priority(priority int) *LaunchAttrs {
+	return a.set(C.CU_LAUNCH_ATTRIBUTE_PRIORITY, func(v unsafe.Pointer) { *(*C.int)(v) = C.int(priority) })
+}
+
+// MemSyncDomain sets the domain of the memory flushes of the kernel. Kernels that write to the memory of other
+// devices, such as the communication kernels of NCCL, run in MemSyncDomainRemote, so that the fences of the compute
+// kernels do not wait for their remote writes.
+func (a *LaunchAttrs) MemSyncDomain(d MemSyncDomain) *LaunchAttrs {
+	return a.set(C.CU_LAUNCH_ATTRIBUTE_MEM_SYNC_DOMAIN, func(v unsafe.Pointer) {
+		*(*C.CUlaunchMemSyncDomain)(v) = C.CUlaunchMemSyncDomain(d)
+	})
+}
+
+// MemSyncDomainMap maps the logical domains of MemSyncDomain to the physical domains of the device, for this launch.
+func (a *LaunchAttrs) MemSyncDomainMap(defaultDomain, remoteDomain byte) *LaunchAttrs {
+	return a.set(C.CU_LAUNCH_ATTRIBUTE_MEM_SYNC_DOMAIN_MAP, func(v unsafe.Pointer) {
+		*(*[2]byte)(v) = [2]byte{defaultDomain, remoteDomain}
+	})
+}
+
+// Raw sets the attribute of the id (a CUlaunchAttributeID) to the bytes of its CUlaunchAttributeValue, for the
+// attributes that have no method yet. value must be laid out as the member of the union for the attribute, and be at
+// most 64 bytes. An invalid value is reported by the launch.
+func (a *LaunchAttrs) Raw(id int, value []byte) *LaunchAttrs {
+	if len(value) > launchAttrValueSize {
+		if a.err == nil {
+			a.err = errors.Errorf("The value of launch attribute %d has %d bytes. At most %d are allowed", id, len(value), launchAttrValueSize)
+		}
+		return a
+	}
+	return a.set(C.CUlaunchAttributeID(id), func(v unsafe.Pointer) {
+		copy((*[launchAttrValueSize]byte)(v)[:], value)
+	})
+}
+
+// Len returns the number of attributes set.
+func (a *LaunchAttrs) Len() int {
+	if a == nil {
+		return 0
+	}
+	return len(a.attrs)
+}
+
+// LaunchWithAttrs launches a CUDA function with the configuration and the attributes, with cuLaunchKernelEx. The
+// attributes may be nil. The launch is timed if kernels are being timed (see TimeKernels).
+func (fn Function) LaunchWithAttrs(cfg LaunchConfig, attrs *LaunchAttrs, stream Stream, kernelParams []unsafe.Pointer) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if attrs != nil && attrs.err != nil {
+		return attrs.err
+	}
+	if timingKernels() {
+		return timeLaunch(fn, stream, func() error { return fn.launchEx(cfg, attrs, stream, kernelParams) })
+	}
+	return fn.launchEx(cfg, attrs, stream, kernelParams)
+}
+
+// LaunchWithAttrs launches a CUDA function with the configuration and the attributes (see Function.LaunchWithAttrs)
+// on the thread of the context.
+func (ctx *Ctx) LaunchWithAttrs(fn Function, cfg LaunchConfig, attrs *LaunchAttrs, stream Stream, kernelParams []unsafe.Pointer) {
+	ctx.setErr(ctx.Do(func() error { return fn.LaunchWithAttrs(cfg, attrs, stream, kernelParams) }))
+}
@@ -0,0 +1,33 @@
+package cu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLaunchAttrs(t *testing.T) {
+	assert := assert.New(t)
+
+	var attrs LaunchAttrs
+	attrs.ProgrammaticStreamSerialization(true).Priority(1).MemSyncDomain(MemSyncDomainRemote)
+	assert.Equal(3, attrs.Len())
+	attrs.Priority(2)
+	assert.Equal(3, attrs.Len(), "setting an attribute twice keeps the last value")
+	assert.Equal(byte(2), attrs.attrs[1].value[0])
+
+	attrs.Raw(42, make([]byte, 65))
+	assert.NotNil(attrs.err)
+	assert.Equal(3, attrs.Len())
+
+	cfg := LaunchConfig{Grid: [3]int{8, 1, 1}, Block: [3]int{128, 1, 1}, Cluster: [3]int{2, 1, 1}}
+	c := launchConfig(cfg, &attrs, NoStream)
+	defer freeLaunchConfig(&c)
+	assert.EqualValues(4, c.numAttrs)
+
+	var none *LaunchAttrs
+	assert.Equal(0, none.Len())
+	c2 := launchConfig(LaunchConfig{Grid: [3]int{1, 1, 1}, Block: [3]int{1, 1, 1}}, none, NoStream)
+	assert.EqualValues(0, c2.numAttrs)
+	assert.Nil(c2.attrs)
+}
@@ -0,0 +1,85 @@
+package cu
+
+// #include <cuda.h>
+import "C"
+import "unsafe"
+
+// clusterDim is the layout of the clusterDim member of the union of CUlaunchAttributeValue, which cgo does not expose.
+type clusterDim struct {
+	x, y, z C.uint
+}
+
+// launchConfig returns the configuration of cuLaunchKernelEx, with the cluster dimensions of cfg and the attributes,
+// which may be nil. The attributes are copied to C memory, which must be freed with freeLaunchConfig.
+func launchConfig(cfg LaunchConfig, attrs *LaunchAttrs, stream Stream) C.CUlaunchConfig {
+	c := C.CUlaunchConfig{
+		gridDimX:       C.uint(cfg.Grid[0]),
+		gridDimY:       C.uint(cfg.Grid[1]),
+		gridDimZ:       C.uint(cfg.Grid[2]),
+		blockDimX:      C.uint(cfg.Block[0]),
+		blockDimY:      C.uint(cfg.Block[1]),
+		blockDimZ:      C.uint(cfg.Block[2]),
+		sharedMemBytes: C.uint(cfg.SharedMem),
+		hStream:        stream.c(),
+	}
+	n := attrs.Len()
+	if cfg.clustered() {
+		n++
+	}
+	if n == 0 {
+		return c
+	}
+	c.attrs = (*C.CUlaunchAttribute)(C.calloc(C.size_t(n), C.sizeof_CUlaunchAttribute))
+	c.numAttrs = C.uint(n)
+	cattrs := (*[1 << 20]C.CUlaunchAttribute)(unsafe.Pointer(c.attrs))[:n:n]
+	if cfg.clustered() {
+		cattrs[0].id = C.CU_LAUNCH_ATTRIBUTE_CLUSTER_DIMENSION
+		*(*clusterDim)(unsafe.Pointer(&cattrs[0].value[0])) = clusterDim{C.uint(cfg.Cluster[0]), C.uint(cfg.Cluster[1]), C.uint(cfg.Cluster[2])}
+		cattrs = cattrs[1:]
+	}
+	for i := 0; i < attrs.Len(); i++ {
+		cattrs[i].id = attrs.attrs[i].id
+		copy((*[launchAttrValueSize]byte)(unsafe.Pointer(&cattrs[i].value[0]))[:], attrs.attrs[i].value[:])
+	}
+	return c
+}
+
+func freeLaunchConfig(c *C.CUlaunchConfig) {
+	if c.attrs != nil {
+		C.free(unsafe.Pointer(c.attrs))
+	}
+}
+
+// launchEx launches the function with cuLaunchKernelEx. The configuration is expected to be valid.
+func (fn Function) launchEx(cfg LaunchConfig, attrs *LaunchAttrs, stream Stream, kernelParams []unsafe.Pointer) error {
+	args := getKernelArgs(kernelParams)
+	defer args.put()
+	return fn.launchExParams(cfg, attrs, stream, args.params())
+}
+
+// launchExParams is launchEx, with parameters already in C memory.
+func (fn Function) launchExParams(cfg LaunchConfig, attrs *LaunchAttrs, stream Stream, params *unsafe.Pointer) error {
+	c := launchConfig(cfg, attrs, stream)
+	defer freeLaunchConfig(&c)
+
+	err := result(C.cuLaunchKernelEx(&c, fn.fn, params, (*unsafe.Pointer)(nil)))
+	if debugging(DebugAPI) {
+		debugf(DebugAPI, "LaunchEx grid %v block %v cluster %v shared %d with %d attributes on stream %#x: %v", cfg.Grid, cfg.Block, cfg.Cluster, cfg.SharedMem, attrs.Len(), stream.Uintptr(), err)
+	}
+	return checkSync("LaunchEx", stream, err)
+}
+
+// SetAttribute sets an attribute of the function, such as MaxDynamicSharedSizeBytes or NonPortableClusterSizeAllowed.
+func (fn Function) SetAttribute(attrib FunctionAttribute, value int) error {
+	return result(C.cuFuncSetAttribute(fn.fn, C.CUfunction_attribute(attrib), C.int(value)))
+}
+
+// LaunchWith launches a CUDA function with the configuration (see Function.LaunchWith) on the thread of the context.
+func (ctx *Ctx) LaunchWith(fn Function, cfg LaunchConfig, stream Stream, kernelParams []unsafe.Pointer) {
+	ctx.setErr(ctx.Do(func() error { return fn.LaunchWith(cfg, stream, kernelParams) }))
+}
+
+// SetFunctionAttribute sets an attribute of the function on the thread of the context.
+func (ctx *Ctx) SetFunctionAttribute(fn Function, attrib FunctionAttribute, value int) {
+	ctx.setErr(ctx.Do(func() error { return fn.SetAttribute(attrib, value) }))
+}
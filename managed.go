@@ -0,0 +1,84 @@
+package cu
+
+// #include <cuda.h>
+import "C"
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// ManagedBytes returns a view of count bytes of managed memory (see MemAllocManaged), which the host may read and
+// write directly. The view must not be used once the memory is freed, nor while kernels run on devices that do not
+// support concurrent managed access (see ConcurrentManagedAccess), unless the memory is attached to the host or to a
+// stream that is idle (see AttachManaged).
+func ManagedBytes(d DevicePtr, count int64) []byte {
+	if count <= 0 {
+		return nil
+	}
+	return (*[1 << 40]byte)(d.Pointer())[:count:count]
+}
+
+// AttachManaged attaches managed memory to the stream, so that the host may access it while other streams run
+// (AttachSingle), or detaches it from the devices (AttachHost) or attaches it to all of them (AttachGlobal). It is
+// AttachMemAsync with typed flags. A length of 0 attaches the whole allocation.
+func (hStream Stream) AttachManaged(dptr DevicePtr, length int64, flags MemAttachFlags) error {
+	return hStream.AttachMemAsync(dptr, length, uint(flags))
+}
+
+// MemRangeReadMostly returns whether all the pages of the range have the SetReadMostly advice.
+func (d DevicePtr) MemRangeReadMostly(count int64) (bool, error) {
+	var v C.int
+	err := d.memRangeAttribute(count, C.CU_MEM_RANGE_ATTRIBUTE_READ_MOSTLY, unsafe.Pointer(&v), 4)
+	return v != 0, err
+}
+
+// MemRangePreferredLocation returns the preferred location of the range: a device, CPU, or BadDevice if the pages of the
+// range do not all have the same preferred location.
+func (d DevicePtr) MemRangePreferredLocation(count int64) (Device, error) {
+	var v C.int
+	err := d.memRangeAttribute(count, C.CU_MEM_RANGE_ATTRIBUTE_PREFERRED_LOCATION, unsafe.Pointer(&v), 4)
+	return Device(v), err
+}
+
+// MemRangeLastPrefetchLocation returns where the range was last prefetched to: a device, CPU, or BadDevice if it was not
+// prefetched, or if its pages were not all prefetched to the same location.
+func (d DevicePtr) MemRangeLastPrefetchLocation(count int64) (Device, error) {
+	var v C.int
+	err := d.memRangeAttribute(count, C.CU_MEM_RANGE_ATTRIBUTE_LAST_PREFETCH_LOCATION, unsafe.Pointer(&v), 4)
+	return Device(v), err
+}
+
+// MemRangeAccessedBy returns the devices that have the SetAccessedBy advice on the range, out of the first n devices.
+func (d DevicePtr) MemRangeAccessedBy(count int64, n int) ([]Device, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	v := make([]C.int, n)
+	if err := d.memRangeAttribute(count, C.CU_MEM_RANGE_ATTRIBUTE_ACCESSED_BY, unsafe.Pointer(&v[0]), 4*n); err != nil {
+		return nil, err
+	}
+	var retVal []Device
+	for _, dev := range v {
+		if dev >= 0 || Device(dev) == CPU {
+			retVal = append(retVal, Device(dev))
+		}
+	}
+	return retVal, nil
+}
+
+func (d DevicePtr) memRangeAttribute(count int64, attr C.CUmem_range_attribute, data unsafe.Pointer, size int) error {
+	err := result(C.cuMemRangeGetAttribute(data, C.size_t(size), attr, C.CUdeviceptr(d), C.size_t(count)))
+	return errors.Wrap(err, "MemRangeGetAttribute")
+}
+
+// MemAdvise advises the Unified Memory subsystem about the usage of managed memory (see (DevicePtr).MemAdvise).
+func (ctx *Ctx) MemAdvise(d DevicePtr, count int64, advice MemAdvice, dev Device) {
+	ctx.setErr(ctx.Do(func() error { return d.MemAdvise(count, advice, dev) }))
+}
+
+// MemPrefetchAsync prefetches managed memory to the device, or to the host if dst is CPU (see
+// (DevicePtr).MemPrefetchAsync).
+func (ctx *Ctx) MemPrefetchAsync(d DevicePtr, count int64, dst Device, hStream Stream) {
+	ctx.setErr(ctx.Do(func() error { return d.MemPrefetchAsync(count, dst, hStream) }))
+}
@@ -0,0 +1,56 @@
+package cu
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestManaged(t *testing.T) {
+	devices, _ := NumDevices()
+	if devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := Device(0).MakeContext(SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+	if managed, _ := Device(0).Attribute(ManagedMemory); managed == 0 {
+		t.Skip("Managed memory is not supported")
+	}
+
+	const size = 1 << 16
+	mem, err := MemAllocManaged(size, AttachGlobal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer MemFree(mem)
+
+	buf := ManagedBytes(mem, size)
+	for i := range buf {
+		buf[i] = byte(i)
+	}
+	if err = mem.MemAdvise(size, SetReadMostly, Device(0)); err != nil {
+		t.Fatal(err)
+	}
+	if readMostly, err := mem.MemRangeReadMostly(size); err != nil || !readMostly {
+		t.Errorf("Expected the range to be read mostly. Got %v, %v", readMostly, err)
+	}
+
+	if concurrent, _ := Device(0).Attribute(ConcurrentManagedAccess); concurrent != 0 {
+		if err = mem.MemPrefetchAsync(size, Device(0), NoStream); err != nil {
+			t.Fatal(err)
+		}
+		if err = Synchronize(); err != nil {
+			t.Fatal(err)
+		}
+		if loc, err := mem.MemRangeLastPrefetchLocation(size); err != nil || loc != Device(0) {
+			t.Errorf("Expected the range to be prefetched to device 0. Got %v, %v", loc, err)
+		}
+	}
+	if buf[size-1] != 0xff {
+		t.Errorf("Expected the memory to keep its contents. Got %d", buf[size-1])
+	}
+}
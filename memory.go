@@ -28,3 +28,41 @@ func (d DevicePtr) Uintptr() uintptr { return uintptr(d) }
 
 // IsCUDAMemory returns true.
 func (d DevicePtr) IsCUDAMemory() bool { return true }
+
+// MemAlloc allocates memory on the device. The memory is poisoned if uninitialized reads are being detected, and
+// recorded if offsets or pointers are being checked (see DebugBounds and DebugAlign).
+func MemAlloc(bytesize int64) (dptr DevicePtr, err error) {
+	Cbytesize := C.size_t(bytesize)
+	var Cdptr C.CUdeviceptr
+	if err = result(C.cuMemAlloc(&Cdptr, Cbytesize)); err != nil {
+		return
+	}
+	if err = poison(DevicePtr(Cdptr), bytesize); err != nil {
+		C.cuMemFree(Cdptr)
+		return 0, errors.Wrap(err, "Unable to poison the allocated memory")
+	}
+	trackAlloc(DevicePtr(Cdptr), bytesize)
+	return DevicePtr(Cdptr), nil
+}
+
+// MemFree frees memory allocated with MemAlloc.
+func MemFree(dptr DevicePtr) (err error) {
+	untrackAlloc(dptr) // before the address can be reused
+	return result(C.cuMemFree(C.CUdeviceptr(dptr)))
+}
+
+func (ctx *Ctx) MemAlloc(bytesize int64) (dptr DevicePtr, err error) {
+	f := func() error {
+		var err error
+		dptr, err = MemAlloc(bytesize)
+		return err
+	}
+	if err = ctx.Do(f); err != nil {
+		err = errors.Wrap(err, "MemAlloc")
+	}
+	return
+}
+
+func (ctx *Ctx) MemFree(dptr DevicePtr) {
+	ctx.setErr(ctx.Do(func() error { return MemFree(dptr) }))
+}
@@ -0,0 +1,224 @@
+package cu
+
+// #include <cuda.h>
+import "C"
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// MemoryPool is a pool of device memory managed by the driver, from which memory is allocated and freed in stream
+// order (see MemAllocAsync). Memory freed to the pool is reused by later allocations on the same stream, or on streams
+// ordered after the free, without synchronizing the device as MemAlloc and MemFree do.
+//
+// Unlike MemPool, which caches blocks allocated with MemAlloc, a MemoryPool is an object of the driver: its memory can be
+// shared with other processes, and returned to the system by TrimTo.
+type MemoryPool struct {
+	p C.CUmemoryPool
+}
+
+// MemPoolAttribute is an attribute of a MemoryPool.
+type MemPoolAttribute int
+
+const (
+	// PoolReuseFollowEventDependencies allows memory freed on a stream to be reused on another stream that waits on an
+	// event recorded after the free. It is a boolean, on by default.
+	PoolReuseFollowEventDependencies MemPoolAttribute = C.CU_MEMPOOL_ATTR_REUSE_FOLLOW_EVENT_DEPENDENCIES
+	// PoolReuseAllowOpportunistic allows memory to be reused on another stream once the free is known to be done. It
+	// is a boolean, on by default.
+	PoolReuseAllowOpportunistic MemPoolAttribute = C.CU_MEMPOOL_ATTR_REUSE_ALLOW_OPPORTUNISTIC
+	// PoolReuseAllowInternalDependencies allows the driver to make an allocation wait on the free of the memory it
+	// reuses. It is a boolean, on by default.
+	PoolReuseAllowInternalDependencies MemPoolAttribute = C.CU_MEMPOOL_ATTR_REUSE_ALLOW_INTERNAL_DEPENDENCIES
+	// PoolReleaseThreshold is the number of bytes the pool keeps reserved when the streams synchronize. The rest is
+	// returned to the system. It is 0 by default: set it to keep memory across the iterations of a loop.
+	PoolReleaseThreshold MemPoolAttribute = C.CU_MEMPOOL_ATTR_RELEASE_THRESHOLD
+	// PoolReservedMemCurrent is the number of bytes of device memory reserved by the pool. It is read only.
+	PoolReservedMemCurrent MemPoolAttribute = C.CU_MEMPOOL_ATTR_RESERVED_MEM_CURRENT
+	// PoolReservedMemHigh is the high water mark of PoolReservedMemCurrent. It can only be reset, by setting it to 0.
+	PoolReservedMemHigh MemPoolAttribute = C.CU_MEMPOOL_ATTR_RESERVED_MEM_HIGH
+	// PoolUsedMemCurrent is the number of bytes allocated from the pool. It is read only.
+	PoolUsedMemCurrent MemPoolAttribute = C.CU_MEMPOOL_ATTR_USED_MEM_CURRENT
+	// PoolUsedMemHigh is the high water mark of PoolUsedMemCurrent. It can only be reset, by setting it to 0.
+	PoolUsedMemHigh MemPoolAttribute = C.CU_MEMPOOL_ATTR_USED_MEM_HIGH
+)
+
+// isBool returns true if the attribute is a boolean, stored by the driver as an int rather than a 64 bit integer.
+func (attr MemPoolAttribute) isBool() bool {
+	return attr == PoolReuseFollowEventDependencies || attr == PoolReuseAllowOpportunistic || attr == PoolReuseAllowInternalDependencies
+}
+
+// MemHandleType is the type of the operating system handles a MemoryPool can be exported to.
+type MemHandleType int
+
+const (
+	MemHandleNone     MemHandleType = C.CU_MEM_HANDLE_TYPE_NONE                  // not exportable
+	MemHandlePosixFD  MemHandleType = C.CU_MEM_HANDLE_TYPE_POSIX_FILE_DESCRIPTOR // a file descriptor
+	MemHandleWin32    MemHandleType = C.CU_MEM_HANDLE_TYPE_WIN32                 // a shared NT handle
+	MemHandleWin32KMT MemHandleType = C.CU_MEM_HANDLE_TYPE_WIN32_KMT             // a global D3DKMT handle
+)
+
+// PoolPtrExportData describes an allocation of an exported MemoryPool, for another process to import it.
+type PoolPtrExportData [64]byte
+
+// MakeMemoryPool creates a pool of memory on the device. The pool can be exported to handles of the given type, which
+// may be MemHandleNone.
+func MakeMemoryPool(dev Device, handleTypes MemHandleType) (MemoryPool, error) {
+	var props C.CUmemPoolProps
+	props.allocType = C.CU_MEM_ALLOCATION_TYPE_PINNED
+	props.handleTypes = C.CUmemAllocationHandleType(handleTypes)
+	props.location._type = C.CU_MEM_LOCATION_TYPE_DEVICE
+	props.location.id = C.int(dev)
+	var p MemoryPool
+	err := result(C.cuMemPoolCreate(&p.p, &props))
+	return p, err
+}
+
+// DefaultMemoryPool returns the pool the device was created with.
+func (d Device) DefaultMemoryPool() (MemoryPool, error) {
+	var p MemoryPool
+	err := result(C.cuDeviceGetDefaultMemPool(&p.p, C.CUdevice(d)))
+	return p, err
+}
+
+// MemoryPool returns the current pool of the device, which MemAllocAsync allocates from. It is the default pool,
+// unless it was changed by SetMemoryPool.
+func (d Device) MemoryPool() (MemoryPool, error) {
+	var p MemoryPool
+	err := result(C.cuDeviceGetMemPool(&p.p, C.CUdevice(d)))
+	return p, err
+}
+
+// SetMemoryPool sets the current pool of the device.
+func (d Device) SetMemoryPool(p MemoryPool) error {
+	return result(C.cuDeviceSetMemPool(C.CUdevice(d), p.p))
+}
+
+// Uintptr returns the pool handle as a uintptr.
+func (p MemoryPool) Uintptr() uintptr { return uintptr(unsafe.Pointer(p.p)) }
+
+// Destroy destroys the pool. Memory allocated from it that is not freed yet is freed once the streams of the
+// allocations are done with it.
+func (p MemoryPool) Destroy() error { return result(C.cuMemPoolDestroy(p.p)) }
+
+// TrimTo returns the memory of the pool that is not in use to the system, keeping at least keep bytes reserved.
+func (p MemoryPool) TrimTo(keep int64) error {
+	return result(C.cuMemPoolTrimTo(p.p, C.size_t(keep)))
+}
+
+// Attribute returns the value of an attribute of the pool. Booleans are returned as 0 or 1.
+func (p MemoryPool) Attribute(attr MemPoolAttribute) (uint64, error) {
+	if attr.isBool() {
+		var v C.int
+		err := result(C.cuMemPoolGetAttribute(p.p, C.CUmemPool_attribute(attr), unsafe.Pointer(&v)))
+		return uint64(v), err
+	}
+	var v C.cuuint64_t
+	err := result(C.cuMemPoolGetAttribute(p.p, C.CUmemPool_attribute(attr), unsafe.Pointer(&v)))
+	return uint64(v), err
+}
+
+// SetAttribute sets the value of an attribute of the pool. Booleans are set with 0 or 1.
+func (p MemoryPool) SetAttribute(attr MemPoolAttribute, value uint64) error {
+	if attr.isBool() {
+		v := C.int(value)
+		return result(C.cuMemPoolSetAttribute(p.p, C.CUmemPool_attribute(attr), unsafe.Pointer(&v)))
+	}
+	v := C.cuuint64_t(value)
+	return result(C.cuMemPoolSetAttribute(p.p, C.CUmemPool_attribute(attr), unsafe.Pointer(&v)))
+}
+
+// ExportToShareableHandle exports the pool to a handle of the given type, for another process to import it with
+// ImportMemoryPool. The pool must have been created to be exported to that type. A file descriptor is returned as a
+// uintptr, and must be closed by the caller.
+func (p MemoryPool) ExportToShareableHandle(handleType MemHandleType) (uintptr, error) {
+	if handleType == MemHandlePosixFD {
+		var fd C.int
+		err := result(C.cuMemPoolExportToShareableHandle(unsafe.Pointer(&fd), p.p, C.CUmemAllocationHandleType(handleType), 0))
+		return uintptr(fd), err
+	}
+	var h unsafe.Pointer
+	err := result(C.cuMemPoolExportToShareableHandle(unsafe.Pointer(&h), p.p, C.CUmemAllocationHandleType(handleType), 0))
+	return uintptr(h), err
+}
+
+// ImportMemoryPool imports a pool exported by another process. Allocations of the pool are then imported with
+// ImportPointer.
+func ImportMemoryPool(shareable uintptr, handleType MemHandleType) (MemoryPool, error) {
+	var p MemoryPool
+	err := result(C.cuMemPoolImportFromShareableHandle(&p.p, unsafe.Pointer(shareable), C.CUmemAllocationHandleType(handleType), 0))
+	return p, err
+}
+
+// ExportPoolPointer exports an allocation of an exported pool, for another process to import it with ImportPointer.
+func ExportPoolPointer(ptr DevicePtr) (PoolPtrExportData, error) {
+	var data PoolPtrExportData
+	err := result(C.cuMemPoolExportPointer((*C.CUmemPoolPtrExportData)(unsafe.Pointer(&data[0])), C.CUdeviceptr(ptr)))
+	return data, err
+}
+
+// ImportPointer imports an allocation exported by ExportPoolPointer from the pool this one was imported from. The
+// memory must be freed by the importing process before the exporting one frees it.
+func (p MemoryPool) ImportPointer(data PoolPtrExportData) (DevicePtr, error) {
+	var ptr C.CUdeviceptr
+	err := result(C.cuMemPoolImportPointer(&ptr, p.p, (*C.CUmemPoolPtrExportData)(unsafe.Pointer(&data[0]))))
+	return DevicePtr(ptr), err
+}
+
+// MemAllocAsync allocates bytesize bytes of device memory from the current pool of the device of the stream. The
+// allocation is ordered on the stream: the memory may only be used by work enqueued on the stream afterwards, or
+// ordered after it by events.
+func MemAllocAsync(bytesize int64, hStream Stream) (DevicePtr, error) {
+	var dptr C.CUdeviceptr
+	if err := result(C.cuMemAllocAsync(&dptr, C.size_t(bytesize), hStream.c())); err != nil {
+		return 0, err
+	}
+	return allocatedAsync(DevicePtr(dptr), bytesize, hStream, "MemAllocAsync")
+}
+
+// MemAllocFromPoolAsync is MemAllocAsync, from the given pool.
+func MemAllocFromPoolAsync(bytesize int64, pool MemoryPool, hStream Stream) (DevicePtr, error) {
+	var dptr C.CUdeviceptr
+	if err := result(C.cuMemAllocFromPoolAsync(&dptr, C.size_t(bytesize), pool.p, hStream.c())); err != nil {
+		return 0, err
+	}
+	return allocatedAsync(DevicePtr(dptr), bytesize, hStream, "MemAllocFromPoolAsync")
+}
+
+// allocatedAsync poisons and tracks memory allocated in stream order, like MemAlloc does.
+func allocatedAsync(ptr DevicePtr, bytesize int64, hStream Stream, call string) (DevicePtr, error) {
+	if err := poisonAsync(ptr, bytesize, hStream); err != nil {
+		C.cuMemFreeAsync(C.CUdeviceptr(ptr), hStream.c())
+		return 0, errors.Wrap(err, "Unable to poison the allocated memory")
+	}
+	if err := checkSync(call, hStream, nil); err != nil {
+		C.cuMemFreeAsync(C.CUdeviceptr(ptr), hStream.c())
+		return 0, err
+	}
+	trackAlloc(ptr, bytesize)
+	return ptr, nil
+}
+
+// MemFreeAsync frees memory allocated with MemAllocAsync or MemAllocFromPoolAsync, in stream order: the memory returns
+// to its pool once the work enqueued on the stream before the free is done.
+func MemFreeAsync(dptr DevicePtr, hStream Stream) error {
+	untrackAlloc(dptr)
+	return checkSync("MemFreeAsync", hStream, result(C.cuMemFreeAsync(C.CUdeviceptr(dptr), hStream.c())))
+}
+
+func (ctx *Ctx) MemAllocAsync(bytesize int64, hStream Stream) (dptr DevicePtr, err error) {
+	f := func() error {
+		var err error
+		dptr, err = MemAllocAsync(bytesize, hStream)
+		return err
+	}
+	if err = ctx.Do(f); err != nil {
+		err = errors.Wrap(err, "MemAllocAsync")
+	}
+	return
+}
+
+func (ctx *Ctx) MemFreeAsync(dptr DevicePtr, hStream Stream) {
+	ctx.setErr(ctx.Do(func() error { return MemFreeAsync(dptr, hStream) }))
+}
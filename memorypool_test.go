@@ -0,0 +1,77 @@
+package cu
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestMemAllocAsync(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	dev, ctx, err := testSetup()
+	if err != nil {
+		if err.Error() == "NoDevice" {
+			t.Skip("NoDevice")
+		}
+		t.Fatal(err)
+	}
+	defer testTeardown(ctx, Module{})
+
+	stream, err := MakeStream(NonBlocking)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Destroy()
+
+	pool, err := MakeMemoryPool(dev, MemHandleNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Destroy()
+	if err = pool.SetAttribute(PoolReleaseThreshold, 1<<20); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := pool.Attribute(PoolReleaseThreshold); err != nil || v != 1<<20 {
+		t.Errorf("Expected a release threshold of 1MiB. Got %d (%v)", v, err)
+	}
+	if v, err := pool.Attribute(PoolReuseAllowOpportunistic); err != nil || v != 1 {
+		t.Errorf("Expected opportunistic reuse to be on. Got %d (%v)", v, err)
+	}
+
+	const size = 4096
+	mem, err := MemAllocFromPoolAsync(size, pool, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = MemsetD32Async(mem, 7, size/4, stream); err != nil {
+		t.Fatal(err)
+	}
+	if used, err := pool.Attribute(PoolUsedMemCurrent); err != nil || used < size {
+		t.Errorf("Expected at least %d bytes in use. Got %d (%v)", size, used, err)
+	}
+	if err = MemFreeAsync(mem, stream); err != nil {
+		t.Fatal(err)
+	}
+
+	// the memory of the default pool is ordered on the stream as well
+	mem, err = MemAllocAsync(size, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = MemFreeAsync(mem, stream); err != nil {
+		t.Fatal(err)
+	}
+	if err = stream.Synchronize(); err != nil {
+		t.Fatal(err)
+	}
+
+	if used, err := pool.Attribute(PoolUsedMemCurrent); err != nil || used != 0 {
+		t.Errorf("Expected no memory in use. Got %d (%v)", used, err)
+	}
+	if err = pool.TrimTo(0); err != nil {
+		t.Fatal(err)
+	}
+	if reserved, err := pool.Attribute(PoolReservedMemCurrent); err != nil || reserved != 0 {
+		t.Errorf("Expected no memory reserved after trimming. Got %d (%v)", reserved, err)
+	}
+}
@@ -0,0 +1,308 @@
+package cu
+
+import (
+	"log"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// poolGranularity is the granularity of the sizes of the blocks allocated by a MemPool.
+// Requests are rounded up so that blocks of nearly equal sizes can be reused for one another.
+const poolGranularity = 512
+
+// PoolStats are the statistics of a MemPool. Sizes are in bytes.
+type PoolStats struct {
+	InUse     int64 // bytes held by live allocations
+	Cached    int64 // bytes held by free blocks kept for reuse
+	HighWater int64 // largest value InUse has had since the pool was created, or last reset
+
+	Allocs int // number of calls to Alloc
+	Frees  int // number of calls to Free
+	Hits   int // number of allocations served from the cache
+	Misses int // number of allocations that required allocating device memory
+}
+
+// Reserved returns the number of bytes of device memory held by the pool.
+func (s PoolStats) Reserved() int64 { return s.InUse + s.Cached }
+
+// MemPool is a caching allocator of device memory. Freed blocks are kept and reused by later allocations of the
+// same (rounded) size, instead of being returned to the driver.
+//
+// Like MemAlloc, the pool must be used from a thread where the context it was first used in is current.
+type MemPool struct {
+	alloc  func(int64) (DevicePtr, error)
+	free   func(DevicePtr) error
+	budget int64 // the bytes the pool may hold, or 0 for no limit
+	logger *log.Logger
+
+	sync.Mutex
+	live   map[DevicePtr]int64
+	cache  map[int64][]DevicePtr
+	stats  PoolStats
+	closed bool
+}
+
+// NewMemPool creates an empty MemPool. It uses the WithBudget and WithLogger options.
+func NewMemPool(opts ...Option) *MemPool {
+	o := makeOptions(opts)
+	return &MemPool{
+		alloc:  MemAlloc,
+		free:   MemFree,
+		budget: o.budget,
+		logger: o.logger,
+		live:   make(map[DevicePtr]int64),
+		cache:  make(map[int64][]DevicePtr),
+	}
+}
+
+// Alloc allocates size bytes of device memory. Reused blocks are poisoned again when uninitialized reads are being
+// detected, like fresh ones (see DebugUninit).
+func (p *MemPool) Alloc(size int64) (DevicePtr, error) {
+	if size <= 0 {
+		return 0, errors.Errorf("Cannot allocate %d bytes", size)
+	}
+	size = roundUp(size, poolGranularity)
+
+	p.Lock()
+	defer p.Unlock()
+	if p.closed {
+		return 0, errors.New("MemPool is closed")
+	}
+	p.stats.Allocs++
+	if ptr, ok := p.take(size); ok {
+		p.stats.Hits++
+		debugTo(p.logger, DebugMem, "MemPool %p: reused %v (%d bytes)", p, ptr, size)
+		return ptr, poison(ptr, size)
+	}
+	if err := p.reserve(size); err != nil {
+		return 0, err
+	}
+	ptr, err := p.alloc(size)
+	if err != nil {
+		return 0, errors.Wrapf(err, "MemPool failed to allocate %d bytes", size)
+	}
+	p.stats.Misses++
+	p.use(ptr, size)
+	debugTo(p.logger, DebugMem, "MemPool %p: allocated %v (%d bytes)", p, ptr, size)
+	return ptr, nil
+}
+
+// Free returns the memory to the pool. The memory is not freed until the pool is trimmed or closed, unless the
+// caching is disabled (see Features).
+func (p *MemPool) Free(ptr DevicePtr) error {
+	p.Lock()
+	defer p.Unlock()
+	size, ok := p.live[ptr]
+	if !ok {
+		return errors.Errorf("%v was not allocated by the MemPool, or has already been freed", ptr)
+	}
+	p.stats.Frees++
+	if poolDisabled() {
+		delete(p.live, ptr)
+		p.stats.InUse -= size
+		debugTo(p.logger, DebugMem, "MemPool %p: freed %v (%d bytes)", p, ptr, size)
+		return errors.Wrap(p.free(ptr), "MemPool failed to free a block")
+	}
+	p.release(ptr, size)
+	debugTo(p.logger, DebugMem, "MemPool %p: cached %v (%d bytes)", p, ptr, size)
+	return nil
+}
+
+// Size returns the size of the block of a live allocation. The size of the block may be larger than requested.
+func (p *MemPool) Size(ptr DevicePtr) (int64, bool) {
+	p.Lock()
+	defer p.Unlock()
+	size, ok := p.live[ptr]
+	return size, ok
+}
+
+// Stats returns the statistics of the pool.
+func (p *MemPool) Stats() PoolStats {
+	p.Lock()
+	defer p.Unlock()
+	return p.stats
+}
+
+// ResetHighWater resets the high-water mark to the number of bytes currently in use.
+func (p *MemPool) ResetHighWater() {
+	p.Lock()
+	p.stats.HighWater = p.stats.InUse
+	p.Unlock()
+}
+
+// Trim frees the cached blocks.
+func (p *MemPool) Trim() error {
+	p.Lock()
+	defer p.Unlock()
+	return p.trim()
+}
+
+// Close frees the cached blocks, as well as the live allocations. The pool cannot be used after it has been closed.
+func (p *MemPool) Close() error {
+	p.Lock()
+	defer p.Unlock()
+	for ptr, size := range p.live {
+		p.release(ptr, size)
+	}
+	p.closed = true
+	return p.trim()
+}
+
+// PoolSnapshot is the state of a MemPool at the time Snapshot was called.
+type PoolSnapshot struct {
+	Stats PoolStats
+	live  map[DevicePtr]int64
+}
+
+// Live returns the number of live allocations at the time of the snapshot.
+func (s PoolSnapshot) Live() int { return len(s.live) }
+
+// Snapshot records the state of the pool: its live allocations and its statistics.
+//
+// Together with Restore, it allows benchmarks to reset the pool between iterations:
+//
+//	snap := pool.Snapshot()
+//	for i := 0; i < b.N; i++ {
+//		// allocate from the pool, launch kernels...
+//		if err := pool.Restore(snap); err != nil {
+//			b.Fatal(err)
+//		}
+//	}
+func (p *MemPool) Snapshot() PoolSnapshot {
+	p.Lock()
+	defer p.Unlock()
+	live := make(map[DevicePtr]int64, len(p.live))
+	for ptr, size := range p.live {
+		live[ptr] = size
+	}
+	return PoolSnapshot{Stats: p.stats, live: live}
+}
+
+// Restore returns the pool to the state recorded by the snapshot. Allocations made since the snapshot are returned to
+// the cache, so that later iterations reuse the same blocks and do not allocate device memory. The statistics,
+// including the high-water mark, are restored as well.
+//
+// Allocations that were live at the time of the snapshot, and freed since, are taken back from the cache.
+// If such a block is no longer cached (because the pool was trimmed) the pool cannot be restored, and an error is returned.
+//
+// Memory allocated since the snapshot must no longer be used once the pool has been restored.
+func (p *MemPool) Restore(s PoolSnapshot) error {
+	p.Lock()
+	defer p.Unlock()
+	if p.closed {
+		return errors.New("MemPool is closed")
+	}
+	// check first, so that a failed Restore leaves the pool untouched
+	var missing []DevicePtr
+	for ptr, size := range s.live {
+		if _, ok := p.live[ptr]; !ok && !p.cached(ptr, size) {
+			missing = append(missing, ptr)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Slice(missing, func(i, j int) bool { return missing[i] < missing[j] })
+		return errors.Errorf("Cannot restore the MemPool: %d blocks live at the time of the snapshot have been freed (first: %v)", len(missing), missing[0])
+	}
+
+	for ptr, size := range p.live {
+		if _, ok := s.live[ptr]; !ok {
+			p.release(ptr, size)
+		}
+	}
+	for ptr, size := range s.live {
+		if _, ok := p.live[ptr]; !ok {
+			p.uncache(ptr, size)
+			p.use(ptr, size)
+		}
+	}
+	cached := p.stats.Cached
+	p.stats = s.Stats
+	p.stats.Cached = cached
+	return nil
+}
+
+// take takes a block of the given size from the cache. The lock is expected to be held.
+func (p *MemPool) take(size int64) (DevicePtr, bool) {
+	blocks := p.cache[size]
+	if len(blocks) == 0 {
+		return 0, false
+	}
+	ptr := blocks[len(blocks)-1]
+	p.cache[size] = blocks[:len(blocks)-1]
+	p.stats.Cached -= size
+	p.use(ptr, size)
+	return ptr, true
+}
+
+// reserve makes room for a block of size bytes within the budget, by freeing the cached blocks if needed. The lock is
+// expected to be held.
+func (p *MemPool) reserve(size int64) error {
+	if p.budget <= 0 || p.stats.Reserved()+size <= p.budget {
+		return nil
+	}
+	if err := p.trim(); err != nil {
+		return err
+	}
+	if p.stats.InUse+size > p.budget {
+		return errors.Errorf("MemPool cannot allocate %d bytes: %d of its budget of %d bytes are in use", size, p.stats.InUse, p.budget)
+	}
+	return nil
+}
+
+// use marks the block as live. The lock is expected to be held.
+func (p *MemPool) use(ptr DevicePtr, size int64) {
+	p.live[ptr] = size
+	p.stats.InUse += size
+	if p.stats.InUse > p.stats.HighWater {
+		p.stats.HighWater = p.stats.InUse
+	}
+}
+
+// release moves a live block to the cache. The lock is expected to be held.
+func (p *MemPool) release(ptr DevicePtr, size int64) {
+	delete(p.live, ptr)
+	p.stats.InUse -= size
+	p.cache[size] = append(p.cache[size], ptr)
+	p.stats.Cached += size
+}
+
+func (p *MemPool) cached(ptr DevicePtr, size int64) bool {
+	for _, b := range p.cache[size] {
+		if b == ptr {
+			return true
+		}
+	}
+	return false
+}
+
+// uncache removes the block from the cache. The lock is expected to be held.
+func (p *MemPool) uncache(ptr DevicePtr, size int64) {
+	blocks := p.cache[size]
+	for i, b := range blocks {
+		if b == ptr {
+			p.cache[size] = append(blocks[:i], blocks[i+1:]...)
+			p.stats.Cached -= size
+			return
+		}
+	}
+}
+
+// trim frees the cached blocks. The lock is expected to be held.
+func (p *MemPool) trim() error {
+	for size, blocks := range p.cache {
+		for len(blocks) > 0 {
+			if err := p.free(blocks[len(blocks)-1]); err != nil {
+				p.cache[size] = blocks
+				return errors.Wrap(err, "MemPool failed to free a cached block")
+			}
+			blocks = blocks[:len(blocks)-1]
+			p.stats.Cached -= size
+		}
+		delete(p.cache, size)
+	}
+	return nil
+}
+
+func roundUp(n, multiple int64) int64 { return (n + multiple - 1) / multiple * multiple }
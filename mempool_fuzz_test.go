@@ -0,0 +1,438 @@
+package cu
+
+import (
+	"bytes"
+	"math/rand"
+	"runtime"
+	"sort"
+	"testing"
+	"unsafe"
+)
+
+// The tests in this file generate random sequences of operations on a MemPool - allocations, frees, copies between
+// views of the allocations, trims, snapshots and restores - and mirror them on a host model of the pool. After each
+// operation the pool is checked against the model: live blocks must not overlap, and the sizes, statistics and
+// contents of the blocks must be those of the model.
+//
+// Views of the allocations are DeviceTensors of bytes, sliced at random.
+
+// poolModel is the host model of a MemPool.
+type poolModel struct {
+	live   map[DevicePtr]*modelBlock
+	cached map[int64]map[DevicePtr]bool
+	stats  PoolStats
+
+	snap     *poolModel
+	snapshot PoolSnapshot
+}
+
+// modelBlock is a live block of the model. data holds the expected contents of the requested bytes, when the pool
+// allocates device memory.
+type modelBlock struct {
+	requested int64
+	size      int64
+	data      []byte
+}
+
+func newPoolModel() *poolModel {
+	return &poolModel{
+		live:   make(map[DevicePtr]*modelBlock),
+		cached: make(map[int64]map[DevicePtr]bool),
+	}
+}
+
+func (m *poolModel) clone() *poolModel {
+	c := newPoolModel()
+	for ptr, b := range m.live {
+		bb := *b
+		c.live[ptr] = &bb
+	}
+	c.stats = m.stats
+	return c
+}
+
+// pick returns a random live block, in a deterministic order.
+func (m *poolModel) pick(r *rand.Rand) (DevicePtr, *modelBlock) {
+	if len(m.live) == 0 {
+		return 0, nil
+	}
+	ptrs := make([]DevicePtr, 0, len(m.live))
+	for ptr := range m.live {
+		ptrs = append(ptrs, ptr)
+	}
+	// map iteration is random; sort so that a seed always replays the same sequence
+	sort.Slice(ptrs, func(i, j int) bool { return ptrs[i] < ptrs[j] })
+	ptr := ptrs[r.Intn(len(ptrs))]
+	return ptr, m.live[ptr]
+}
+
+func (m *poolModel) cachedBytes() (n int64) {
+	for size, blocks := range m.cached {
+		n += size * int64(len(blocks))
+	}
+	return n
+}
+
+// poolFuzzer applies random operations to a pool and its model.
+type poolFuzzer struct {
+	t      *testing.T
+	r      *rand.Rand
+	p      *MemPool
+	m      *poolModel
+	device bool // whether the pool allocates device memory, whose contents can be checked
+
+	allocated, freed int
+	step             int
+	op               string
+}
+
+func (f *poolFuzzer) fatalf(format string, args ...interface{}) {
+	f.t.Helper()
+	f.t.Fatalf("step %d (%s): "+format, append([]interface{}{f.step, f.op}, args...)...)
+}
+
+func (f *poolFuzzer) run(steps int) {
+	ops := []struct {
+		name   string
+		weight int
+		fn     func()
+	}{
+		{"alloc", 6, f.alloc},
+		{"free", 4, f.free},
+		{"bad free", 1, f.badFree},
+		{"copy", 4, f.copy},
+		{"read", 3, f.read},
+		{"trim", 1, f.trim},
+		{"snapshot", 1, f.takeSnapshot},
+		{"restore", 1, f.restore},
+	}
+	total := 0
+	for _, op := range ops {
+		total += op.weight
+	}
+	for f.step = 0; f.step < steps; f.step++ {
+		n := f.r.Intn(total)
+		for _, op := range ops {
+			if n < op.weight {
+				f.op = op.name
+				op.fn()
+				break
+			}
+			n -= op.weight
+		}
+		f.check()
+	}
+}
+
+func (f *poolFuzzer) alloc() {
+	var requested int64
+	switch f.r.Intn(3) {
+	case 0:
+		requested = 1 + f.r.Int63n(poolGranularity) // the smallest size class
+	case 1:
+		requested = poolGranularity * (1 + f.r.Int63n(4)) // exact multiples
+	default:
+		requested = 1 + f.r.Int63n(8*poolGranularity)
+	}
+	ptr, err := f.p.Alloc(requested)
+	if err != nil {
+		f.fatalf("Alloc(%d) failed: %v", requested, err)
+	}
+	size := roundUp(requested, poolGranularity)
+	if size < requested {
+		f.fatalf("Block of %d bytes is smaller than the %d requested", size, requested)
+	}
+	if _, ok := f.m.live[ptr]; ok {
+		f.fatalf("Alloc returned %v, which is live", ptr)
+	}
+	for other, b := range f.m.live {
+		if ptr < other+DevicePtr(b.size) && other < ptr+DevicePtr(size) {
+			f.fatalf("Block %v of %d bytes overlaps live block %v of %d bytes", ptr, size, other, b.size)
+		}
+	}
+
+	f.m.stats.Allocs++
+	if cached := f.m.cached[size]; len(cached) > 0 {
+		if !cached[ptr] {
+			f.fatalf("Expected a cached block of %d bytes to be reused. Got %v", size, ptr)
+		}
+		delete(cached, ptr)
+		f.m.stats.Hits++
+		f.m.stats.Cached -= size
+	} else {
+		f.m.stats.Misses++
+		f.allocated++
+	}
+	f.m.stats.InUse += size
+	if f.m.stats.InUse > f.m.stats.HighWater {
+		f.m.stats.HighWater = f.m.stats.InUse
+	}
+	b := &modelBlock{requested: requested, size: size}
+	f.m.live[ptr] = b
+	f.fill(ptr, b)
+}
+
+// fill writes random contents to the requested bytes of the block.
+func (f *poolFuzzer) fill(ptr DevicePtr, b *modelBlock) {
+	if !f.device {
+		return
+	}
+	b.data = make([]byte, b.requested)
+	f.r.Read(b.data)
+	if err := MemcpyHtoD(ptr, unsafe.Pointer(&b.data[0]), b.requested); err != nil {
+		f.fatalf("Unable to fill %v: %v", ptr, err)
+	}
+}
+
+func (f *poolFuzzer) free() {
+	ptr, b := f.m.pick(f.r)
+	if b == nil {
+		return
+	}
+	if err := f.p.Free(ptr); err != nil {
+		f.fatalf("Free(%v) failed: %v", ptr, err)
+	}
+	delete(f.m.live, ptr)
+	if f.m.cached[b.size] == nil {
+		f.m.cached[b.size] = make(map[DevicePtr]bool)
+	}
+	f.m.cached[b.size][ptr] = true
+	f.m.stats.Frees++
+	f.m.stats.InUse -= b.size
+	f.m.stats.Cached += b.size
+}
+
+// badFree frees a pointer that is not live: a cached block, an interior pointer of a live block, or a pointer the
+// pool never returned. The pool must return an error and leave its state untouched.
+func (f *poolFuzzer) badFree() {
+	var ptr DevicePtr
+	switch f.r.Intn(3) {
+	case 0:
+		for _, blocks := range f.m.cached {
+			for p := range blocks {
+				ptr = p
+			}
+		}
+	case 1:
+		if p, b := f.m.pick(f.r); b != nil {
+			ptr = p + DevicePtr(1+f.r.Int63n(b.size-1))
+		}
+	}
+	if ptr == 0 {
+		ptr = DevicePtr(1 + f.r.Int63n(poolGranularity-1)) // not aligned, so never a block
+	}
+	if err := f.p.Free(ptr); err == nil {
+		f.fatalf("Expected an error freeing %v", ptr)
+	}
+}
+
+// view returns a random view of n bytes of the requested bytes of the block.
+func (f *poolFuzzer) view(ptr DevicePtr, b *modelBlock, n int) (DeviceTensor, int) {
+	whole := NewDeviceTensor(ptr, DtUint8, int(b.requested))
+	start := f.r.Intn(int(b.requested) - n + 1)
+	v, err := whole.Slice(0, start, start+n)
+	if err != nil {
+		f.fatalf("Slice [%d:%d] of %v failed: %v", start, start+n, whole, err)
+	}
+	if v.Ptr < ptr || v.Ptr+DevicePtr(v.Len()) > ptr+DevicePtr(b.size) {
+		f.fatalf("View %v escapes block %v of %d bytes", v, ptr, b.size)
+	}
+	return v, start
+}
+
+func (f *poolFuzzer) copy() {
+	src, sb := f.m.pick(f.r)
+	dst, db := f.m.pick(f.r)
+	if sb == nil || src == dst {
+		return
+	}
+	n := 1 + f.r.Intn(int(min64(sb.requested, db.requested)))
+	sv, so := f.view(src, sb, n)
+	dv, do := f.view(dst, db, n)
+	if !f.device {
+		return
+	}
+	if err := MemcpyDtoD(dv.Ptr, sv.Ptr, int64(n)); err != nil {
+		f.fatalf("MemcpyDtoD of %d bytes from %v to %v failed: %v", n, sv, dv, err)
+	}
+	copy(db.data[do:do+n], sb.data[so:so+n])
+}
+
+func (f *poolFuzzer) read() {
+	ptr, b := f.m.pick(f.r)
+	if b == nil || !f.device {
+		return
+	}
+	n := 1 + f.r.Intn(int(b.requested))
+	v, start := f.view(ptr, b, n)
+	got := make([]byte, n)
+	if err := MemcpyDtoH(unsafe.Pointer(&got[0]), v.Ptr, int64(n)); err != nil {
+		f.fatalf("MemcpyDtoH of %v failed: %v", v, err)
+	}
+	if want := b.data[start : start+n]; !bytes.Equal(got, want) {
+		f.fatalf("Contents of %v differ from the model at %d bytes", v, diffCount(got, want))
+	}
+}
+
+func (f *poolFuzzer) trim() {
+	if err := f.p.Trim(); err != nil {
+		f.fatalf("Trim failed: %v", err)
+	}
+	for _, blocks := range f.m.cached {
+		f.freed += len(blocks)
+	}
+	f.m.cached = make(map[int64]map[DevicePtr]bool)
+	f.m.stats.Cached = 0
+}
+
+func (f *poolFuzzer) takeSnapshot() {
+	f.m.snapshot = f.p.Snapshot()
+	f.m.snap = f.m.clone()
+	if f.m.snapshot.Live() != len(f.m.live) {
+		f.fatalf("Snapshot has %d live blocks, the model %d", f.m.snapshot.Live(), len(f.m.live))
+	}
+	if f.m.snapshot.Stats != f.m.stats {
+		f.fatalf("Snapshot has stats %+v, the model %+v", f.m.snapshot.Stats, f.m.stats)
+	}
+}
+
+func (f *poolFuzzer) restore() {
+	snap := f.m.snap
+	if snap == nil {
+		return
+	}
+	restorable := true
+	for ptr, b := range snap.live {
+		if _, ok := f.m.live[ptr]; !ok && !f.m.cached[b.size][ptr] {
+			restorable = false
+		}
+	}
+	err := f.p.Restore(f.m.snapshot)
+	if !restorable {
+		if err == nil {
+			f.fatalf("Expected Restore to fail, as blocks of the snapshot have been trimmed")
+		}
+		return
+	}
+	if err != nil {
+		f.fatalf("Restore failed: %v", err)
+	}
+
+	for ptr, b := range f.m.live {
+		if _, ok := snap.live[ptr]; !ok {
+			delete(f.m.live, ptr)
+			if f.m.cached[b.size] == nil {
+				f.m.cached[b.size] = make(map[DevicePtr]bool)
+			}
+			f.m.cached[b.size][ptr] = true
+		}
+	}
+	for ptr, b := range snap.live {
+		if _, ok := f.m.live[ptr]; ok {
+			continue
+		}
+		delete(f.m.cached[b.size], ptr)
+		// the block may have been reused and overwritten since the snapshot
+		bb := *b
+		f.m.live[ptr] = &bb
+		f.fill(ptr, &bb)
+	}
+	f.m.stats = snap.stats
+	f.m.stats.Cached = f.m.cachedBytes()
+}
+
+// check checks the pool against the model.
+func (f *poolFuzzer) check() {
+	f.t.Helper()
+	for ptr, b := range f.m.live {
+		size, ok := f.p.Size(ptr)
+		if !ok {
+			f.fatalf("%v is live in the model, but not in the pool", ptr)
+		}
+		if size != b.size {
+			f.fatalf("Size(%v) = %d. Expected %d", ptr, size, b.size)
+		}
+	}
+	for _, blocks := range f.m.cached {
+		for ptr := range blocks {
+			if _, ok := f.p.Size(ptr); ok {
+				f.fatalf("%v is cached in the model, but live in the pool", ptr)
+			}
+		}
+	}
+	if s := f.p.Stats(); s != f.m.stats {
+		f.fatalf("Stats %+v differ from the model %+v", s, f.m.stats)
+	}
+	if f.m.stats.Cached != f.m.cachedBytes() {
+		f.fatalf("The model caches %d bytes, but counts %d", f.m.cachedBytes(), f.m.stats.Cached)
+	}
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func diffCount(a, b []byte) (n int) {
+	for i := range a {
+		if a[i] != b[i] {
+			n++
+		}
+	}
+	return n
+}
+
+// TestMemPool_Fuzz runs random sequences of operations on a pool that hands out fake addresses, checking the
+// bookkeeping of the pool.
+func TestMemPool_Fuzz(t *testing.T) {
+	steps := 5000
+	if testing.Short() {
+		steps = 500
+	}
+	for seed := int64(0); seed < 8; seed++ {
+		f := &poolFuzzer{t: t, r: rand.New(rand.NewSource(seed)), m: newPoolModel()}
+		var allocated, freed int
+		f.p = newFakePool(&allocated, &freed)
+		f.run(steps)
+
+		if allocated != f.allocated || freed != f.freed {
+			t.Errorf("seed %d: the pool allocated %d and freed %d blocks. The model allocated %d and freed %d", seed, allocated, freed, f.allocated, f.freed)
+		}
+		if err := f.p.Close(); err != nil {
+			t.Errorf("seed %d: Close failed: %v", seed, err)
+		}
+		if allocated != freed {
+			t.Errorf("seed %d: %d blocks were allocated, but %d freed after Close", seed, allocated, freed)
+		}
+	}
+}
+
+// TestMemPool_FuzzDevice runs random sequences of operations on a pool of device memory, additionally checking the
+// contents of the blocks through copies between views of them.
+func TestMemPool_FuzzDevice(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	_, cuctx, err := testSetup()
+	if err != nil {
+		if err.Error() == "NoDevice" {
+			t.Skip("NoDevice")
+		}
+		t.Fatal(err)
+	}
+	defer testTeardown(cuctx, Module{})
+
+	steps := 1000
+	if testing.Short() {
+		steps = 200
+	}
+	for seed := int64(0); seed < 4; seed++ {
+		f := &poolFuzzer{t: t, r: rand.New(rand.NewSource(seed)), p: NewMemPool(), m: newPoolModel(), device: true}
+		f.run(steps)
+		if err := f.p.Close(); err != nil {
+			t.Errorf("seed %d: Close failed: %v", seed, err)
+		}
+	}
+}
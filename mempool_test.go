@@ -0,0 +1,147 @@
+package cu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakePool creates a MemPool that hands out fake addresses, and records the blocks allocated and freed.
+func newFakePool(allocated, freed *int) *MemPool {
+	p := NewMemPool()
+	next := DevicePtr(0x10000)
+	p.alloc = func(size int64) (DevicePtr, error) {
+		ptr := next
+		next += DevicePtr(size)
+		*allocated++
+		return ptr, nil
+	}
+	p.free = func(DevicePtr) error { *freed++; return nil }
+	return p
+}
+
+func TestMemPool(t *testing.T) {
+	assert := assert.New(t)
+	var allocated, freed int
+	p := newFakePool(&allocated, &freed)
+
+	a, err := p.Alloc(100)
+	assert.Nil(err)
+	size, ok := p.Size(a)
+	assert.True(ok)
+	assert.Equal(int64(poolGranularity), size)
+
+	assert.Nil(p.Free(a))
+	assert.NotNil(p.Free(a), "double free")
+
+	b, _ := p.Alloc(200) // same size class
+	assert.Equal(a, b)
+	assert.Equal(1, allocated)
+
+	s := p.Stats()
+	assert.Equal(PoolStats{InUse: 512, HighWater: 512, Allocs: 2, Frees: 1, Hits: 1, Misses: 1}, s)
+
+	assert.Nil(p.Free(b))
+	assert.Nil(p.Trim())
+	assert.Equal(1, freed)
+	assert.Equal(int64(0), p.Stats().Reserved())
+}
+
+func TestMemPool_SnapshotRestore(t *testing.T) {
+	assert := assert.New(t)
+	var allocated, freed int
+	p := newFakePool(&allocated, &freed)
+
+	weights, _ := p.Alloc(1024)
+	scratch, _ := p.Alloc(512)
+	snap := p.Snapshot()
+	assert.Equal(2, snap.Live())
+
+	iteration := func() []DevicePtr {
+		x, _ := p.Alloc(4096)
+		y, _ := p.Alloc(512)
+		assert.Nil(p.Free(scratch))
+		return []DevicePtr{x, y}
+	}
+
+	first := iteration()
+	assert.Equal(int64(1024+512+4096+512), p.Stats().HighWater)
+	assert.Nil(p.Restore(snap))
+	s := p.Stats()
+	assert.Equal(int64(4096+512), s.Cached, "blocks allocated since the snapshot are cached")
+	s.Cached = 0
+	assert.Equal(snap.Stats, s)
+	size, ok := p.Size(scratch)
+	assert.True(ok, "blocks live at the time of the snapshot are live again")
+	assert.Equal(int64(512), size)
+	_, ok = p.Size(first[0])
+	assert.False(ok)
+
+	// the second iteration reuses the blocks of the first
+	before := allocated
+	second := iteration()
+	assert.Equal(before, allocated)
+	assert.ElementsMatch(first, second)
+	assert.Nil(p.Restore(snap))
+
+	// a block freed since the snapshot that is no longer cached cannot be restored
+	assert.Nil(p.Free(weights))
+	assert.Nil(p.Trim())
+	assert.NotNil(p.Restore(snap))
+
+	assert.Nil(p.Close())
+	assert.Equal(allocated, freed)
+}
+
+func TestArena(t *testing.T) {
+	assert := assert.New(t)
+	var allocated, freed int
+	p := newFakePool(&allocated, &freed)
+
+	a, err := NewArena(1000, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(int64(1024), a.Stats().Capacity)
+
+	x, _ := a.Alloc(10)
+	snap := a.Snapshot()
+	y, _ := a.Alloc(300)
+	assert.Equal(x+arenaAlignment, y)
+	_, err = a.Alloc(1024)
+	assert.NotNil(err)
+	assert.Equal(int64(768), a.Stats().HighWater)
+
+	assert.Nil(a.Restore(snap))
+	z, _ := a.Alloc(300)
+	assert.Equal(y, z)
+
+	m := a.Mark()
+	a.Alloc(256)
+	assert.Nil(a.Rewind(m))
+	assert.Equal(int64(m), a.Stats().Used)
+
+	a.Reset()
+	assert.Equal(int64(0), a.Stats().Used)
+	assert.Nil(a.Close())
+	assert.Equal(int64(0), p.Stats().InUse)
+}
+
+func TestMemPool_Budget(t *testing.T) {
+	assert := assert.New(t)
+	var allocated, freed int
+	p := newFakePool(&allocated, &freed)
+	p.budget = 1024
+
+	a, err := p.Alloc(512)
+	assert.Nil(err)
+	assert.Nil(p.Free(a))
+	b, err := p.Alloc(1024) // the cached block is freed to make room
+	assert.Nil(err)
+	assert.Equal(1, freed)
+	_, err = p.Alloc(1)
+	assert.NotNil(err, "over budget")
+
+	assert.Nil(p.Free(b))
+	assert.Equal(int64(1024), NewMemPool(WithBudget(1024)).budget)
+}
@@ -0,0 +1,178 @@
+// Package metrics keeps latency histograms by name, such as the durations of the launches of every kernel, so that
+// finding which kernel regressed is a call to TopK rather than a profiling session:
+//
+//	cu.TimeKernels(metrics.Kernels.Observe)
+//	..launch kernels..
+//	if err := cu.CollectKernelTimes(); err != nil { // on the thread of the context
+//		..error handling..
+//	}
+//	for _, s := range metrics.Kernels.TopK(10) {
+//		fmt.Println(s)
+//	}
+//
+// The package does not depend on a device, and may record any latency.
+package metrics // import "gorgonia.org/cu/metrics"
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+	"sync"
+	"time"
+)
+
+// numBuckets is the number of buckets of a Histogram. Bucket i counts the durations of up to 2^i microseconds, so the
+// last bucket is about 6 days.
+const numBuckets = 40
+
+// Histogram is a histogram of durations, with buckets of exponentially growing sizes. The quantiles it returns are the
+// upper bounds of the buckets they fall in, so they are within a factor of 2 of the actual quantiles.
+type Histogram struct {
+	sync.Mutex
+	buckets [numBuckets]int
+	count   int
+	total   time.Duration
+	max     time.Duration
+}
+
+// Observe records a duration.
+func (h *Histogram) Observe(d time.Duration) {
+	i := 0
+	if us := int64(d / time.Microsecond); us > 1 {
+		i = bits.Len64(uint64(us - 1))
+	}
+	if i >= numBuckets {
+		i = numBuckets - 1
+	}
+	h.Lock()
+	h.buckets[i]++
+	h.count++
+	h.total += d
+	if d > h.max {
+		h.max = d
+	}
+	h.Unlock()
+}
+
+// Quantile returns an upper bound of the q-quantile of the durations, for q between 0 and 1.
+func (h *Histogram) Quantile(q float64) time.Duration {
+	h.Lock()
+	defer h.Unlock()
+	return h.quantile(q)
+}
+
+// quantile is Quantile. The lock is expected to be held.
+func (h *Histogram) quantile(q float64) time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	rank := int(q*float64(h.count) + 0.5)
+	if rank < 1 {
+		rank = 1
+	}
+	seen := 0
+	for i, n := range h.buckets {
+		if seen += n; seen >= rank {
+			if bound := time.Duration(1<<uint(i)) * time.Microsecond; bound < h.max {
+				return bound
+			}
+			break
+		}
+	}
+	return h.max
+}
+
+// Summary summarizes a Histogram.
+type Summary struct {
+	Name          string
+	Count         int
+	Total, Mean   time.Duration
+	P50, P90, P99 time.Duration
+	Max           time.Duration
+}
+
+func (s Summary) String() string {
+	return fmt.Sprintf("%-32s %8d calls  total %-12v mean %-12v p50 %-10v p90 %-10v p99 %-10v max %v",
+		s.Name, s.Count, s.Total, s.Mean, s.P50, s.P90, s.P99, s.Max)
+}
+
+// Summary returns the summary of the histogram, without a name.
+func (h *Histogram) Summary() Summary {
+	h.Lock()
+	defer h.Unlock()
+	s := Summary{
+		Count: h.count,
+		Total: h.total,
+		P50:   h.quantile(0.5),
+		P90:   h.quantile(0.9),
+		P99:   h.quantile(0.99),
+		Max:   h.max,
+	}
+	if h.count > 0 {
+		s.Mean = h.total / time.Duration(h.count)
+	}
+	return s
+}
+
+// Registry is a set of histograms, by name. It is safe for concurrent use.
+type Registry struct {
+	sync.Mutex
+	m map[string]*Histogram
+}
+
+// Kernels is the registry of the durations of the kernel launches (see cu.TimeKernels).
+var Kernels = NewRegistry()
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry { return &Registry{m: make(map[string]*Histogram)} }
+
+// Histogram returns the named histogram, creating it if needed.
+func (r *Registry) Histogram(name string) *Histogram {
+	r.Lock()
+	defer r.Unlock()
+	h, ok := r.m[name]
+	if !ok {
+		h = new(Histogram)
+		r.m[name] = h
+	}
+	return h
+}
+
+// Observe records a duration in the named histogram.
+func (r *Registry) Observe(name string, d time.Duration) { r.Histogram(name).Observe(d) }
+
+// Summaries returns the summaries of the histograms, by name.
+func (r *Registry) Summaries() []Summary {
+	r.Lock()
+	hs := make(map[string]*Histogram, len(r.m))
+	for name, h := range r.m {
+		hs[name] = h
+	}
+	r.Unlock()
+
+	retVal := make([]Summary, 0, len(hs))
+	for name, h := range hs {
+		s := h.Summary()
+		s.Name = name
+		retVal = append(retVal, s)
+	}
+	sort.Slice(retVal, func(i, j int) bool { return retVal[i].Name < retVal[j].Name })
+	return retVal
+}
+
+// TopK returns the summaries of the k histograms with the largest total durations, the largest first.
+func (r *Registry) TopK(k int) []Summary {
+	s := r.Summaries()
+	sort.SliceStable(s, func(i, j int) bool { return s[i].Total > s[j].Total })
+	if k >= 0 && k < len(s) {
+		s = s[:k]
+	}
+	return s
+}
+
+// Reset removes the histograms.
+func (r *Registry) Reset() {
+	r.Lock()
+	r.m = make(map[string]*Histogram)
+	r.Unlock()
+}
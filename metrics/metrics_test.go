@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistogram(t *testing.T) {
+	var h Histogram
+	for i := 0; i < 99; i++ {
+		h.Observe(100 * time.Microsecond)
+	}
+	h.Observe(10 * time.Millisecond)
+
+	s := h.Summary()
+	if s.Count != 100 || s.Max != 10*time.Millisecond {
+		t.Errorf("Expected 100 durations up to 10ms. Got %v", s)
+	}
+	if s.P50 != 128*time.Microsecond {
+		t.Errorf("Expected the median to be bounded by its bucket. Got %v", s.P50)
+	}
+	if s.P99 > 128*time.Microsecond || h.Quantile(1) != 10*time.Millisecond {
+		t.Errorf("Expected the outlier to be past the 99th percentile. Got %v and %v", s.P99, h.Quantile(1))
+	}
+}
+
+func TestRegistry_TopK(t *testing.T) {
+	r := NewRegistry()
+	r.Observe("add", time.Millisecond)
+	r.Observe("gemm", 5*time.Millisecond)
+	r.Observe("softmax", 3*time.Millisecond)
+	r.Observe("add", time.Millisecond)
+
+	top := r.TopK(2)
+	if len(top) != 2 || top[0].Name != "gemm" || top[1].Name != "softmax" {
+		t.Errorf("Expected gemm then softmax. Got %v", top)
+	}
+	if all := r.TopK(-1); len(all) != 3 {
+		t.Errorf("Expected all the histograms. Got %v", all)
+	}
+	r.Reset()
+	if len(r.Summaries()) != 0 {
+		t.Error("Expected no histograms once reset")
+	}
+}
@@ -3,6 +3,7 @@ package cu
 // #include <cuda.h>
 import "C"
 import (
+	"fmt"
 	"unsafe"
 
 	"github.com/pkg/errors"
@@ -25,6 +26,9 @@ func Load(name string) (Module, error) {
 	cstr := C.CString(name)
 	defer C.free(unsafe.Pointer(cstr))
 	err := result(C.cuModuleLoad(&mod.mod, cstr))
+	if err == nil {
+		registerModule(mod, "file "+name)
+	}
 	return mod, err
 }
 
@@ -34,6 +38,9 @@ func LoadData(image string) (Module, error) {
 	cstr := C.CString(image)
 	defer C.free(unsafe.Pointer(cstr))
 	err := result(C.cuModuleLoadData(&mod.mod, unsafe.Pointer(cstr)))
+	if err == nil {
+		registerModule(mod, fmt.Sprintf("image of %d bytes", len(image)))
+	}
 	return mod, err
 }
 
@@ -45,6 +52,9 @@ func LoadDataEx(image string, options ...JITOption) (Module, error) {
 
 	argcount, args, argvals := encodeArguments(options)
 	err := result(C.cuModuleLoadDataEx(&mod.mod, unsafe.Pointer(cstr), argcount, args, argvals))
+	if err == nil {
+		registerModule(mod, fmt.Sprintf("image of %d bytes, with %d JIT options", len(image), len(options)))
+	}
 	return mod, err
 }
 
@@ -54,6 +64,9 @@ func LoadFatBinary(image string) (Module, error) {
 	cstr := C.CString(image)
 	defer C.free(unsafe.Pointer(cstr))
 	err := result(C.cuModuleLoadFatBinary(&mod.mod, unsafe.Pointer(cstr)))
+	if err == nil {
+		registerModule(mod, fmt.Sprintf("fat binary of %d bytes", len(image)))
+	}
 	return mod, err
 }
 
@@ -62,8 +75,11 @@ func (m Module) Function(name string) (Function, error) {
 	var fn Function
 	cstr := C.CString(name)
 	defer C.free(unsafe.Pointer(cstr))
-	err := result(C.cuModuleGetFunction(&fn.fn, m.mod, cstr))
-	return fn, err
+	if err := result(C.cuModuleGetFunction(&fn.fn, m.mod, cstr)); err != nil {
+		return fn, err
+	}
+	kernelNames.Store(fn.Uintptr(), name)
+	return fn, nil
 }
 
 // Global returns a global pointer as defined in a module. It returns a pointer to the memory in the device.
@@ -88,9 +104,22 @@ func (ctx *Ctx) Load(name string) (m Module, err error) {
 		return
 	}
 	m = Module{mod}
+	registerModule(m, "file "+name)
 	return
 }
 
+// Unload unloads the module from the current context.
+func (m Module) Unload() error {
+	if err := result(C.cuModuleUnload(m.mod)); err != nil {
+		return err
+	}
+	unregisterModule(m)
+	return nil
+}
+
+// Unload unloads the module from the context. The error, if any, is returned by Error.
+func (ctx *Ctx) Unload(m Module) { ctx.setErr(ctx.Do(m.Unload)) }
+
 func (ctx *Ctx) ModuleFunction(m Module, name string) (function Function, err error) {
 	var fn C.CUfunction
 	cstr := C.CString(name)
@@ -0,0 +1,97 @@
+package nccl
+
+// #include <nccl.h>
+import "C"
+import (
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// Op is a reduction operation.
+type Op int
+
+const (
+	Sum  Op = C.ncclSum
+	Prod Op = C.ncclProd
+	Max  Op = C.ncclMax
+	Min  Op = C.ncclMin
+	Avg  Op = C.ncclAvg // the sum divided by the number of ranks, since NCCL 2.10
+)
+
+func dataType(dt cu.Dtype) (C.ncclDataType_t, error) {
+	switch dt {
+	case cu.DtInt8:
+		return C.ncclInt8, nil
+	case cu.DtUint8, cu.DtBool:
+		return C.ncclUint8, nil
+	case cu.DtInt32:
+		return C.ncclInt32, nil
+	case cu.DtInt64:
+		return C.ncclInt64, nil
+	case cu.DtFloat16:
+		return C.ncclFloat16, nil
+	case cu.DtBFloat16:
+		return C.ncclBfloat16, nil
+	case cu.DtFloat32:
+		return C.ncclFloat32, nil
+	case cu.DtFloat64:
+		return C.ncclFloat64, nil
+	}
+	return 0, errors.Errorf("NCCL does not support %v", dt)
+}
+
+func stream(s cu.Stream) C.cudaStream_t { return C.cudaStream_t(s.Pointer()) }
+
+// AllReduce reduces the count elements of send of all the ranks with op, into the recv of every rank. send and recv
+// may be the same buffer, for an in-place reduction.
+func (c *Comm) AllReduce(send, recv cu.DevicePtr, count int, dt cu.Dtype, op Op, s cu.Stream) error {
+	t, err := dataType(dt)
+	if err != nil {
+		return err
+	}
+	err = result(C.ncclAllReduce(send.Pointer(), recv.Pointer(), C.size_t(count), t, C.ncclRedOp_t(op), c.c, stream(s)))
+	return errors.Wrap(err, "AllReduce")
+}
+
+// Reduce reduces the count elements of send of all the ranks with op, into the recv of the rank root. recv is only
+// used on root.
+func (c *Comm) Reduce(send, recv cu.DevicePtr, count int, dt cu.Dtype, op Op, root int, s cu.Stream) error {
+	t, err := dataType(dt)
+	if err != nil {
+		return err
+	}
+	err = result(C.ncclReduce(send.Pointer(), recv.Pointer(), C.size_t(count), t, C.ncclRedOp_t(op), C.int(root), c.c, stream(s)))
+	return errors.Wrap(err, "Reduce")
+}
+
+// Broadcast copies the count elements of send of the rank root into the recv of every rank. send is only used on root.
+func (c *Comm) Broadcast(send, recv cu.DevicePtr, count int, dt cu.Dtype, root int, s cu.Stream) error {
+	t, err := dataType(dt)
+	if err != nil {
+		return err
+	}
+	err = result(C.ncclBroadcast(send.Pointer(), recv.Pointer(), C.size_t(count), t, C.int(root), c.c, stream(s)))
+	return errors.Wrap(err, "Broadcast")
+}
+
+// ReduceScatter reduces the recvCount×nranks elements of send of all the ranks with op, and scatters the result: the
+// rank i receives the block i of recvCount elements into recv.
+func (c *Comm) ReduceScatter(send, recv cu.DevicePtr, recvCount int, dt cu.Dtype, op Op, s cu.Stream) error {
+	t, err := dataType(dt)
+	if err != nil {
+		return err
+	}
+	err = result(C.ncclReduceScatter(send.Pointer(), recv.Pointer(), C.size_t(recvCount), t, C.ncclRedOp_t(op), c.c, stream(s)))
+	return errors.Wrap(err, "ReduceScatter")
+}
+
+// AllGather gathers the sendCount elements of send of all the ranks into the recv of every rank, in the order of the
+// ranks: recv holds sendCount×nranks elements.
+func (c *Comm) AllGather(send, recv cu.DevicePtr, sendCount int, dt cu.Dtype, s cu.Stream) error {
+	t, err := dataType(dt)
+	if err != nil {
+		return err
+	}
+	err = result(C.ncclAllGather(send.Pointer(), recv.Pointer(), C.size_t(sendCount), t, c.c, stream(s)))
+	return errors.Wrap(err, "AllGather")
+}
@@ -0,0 +1,155 @@
+// Package nccl provides bindings to NCCL, the collective communications of multiple GPUs: the reductions and the
+// exchanges of data parallel training.
+//
+// A collective involves a group of communicators, one per device (a rank), created together: all at once by one
+// thread with InitAll, or one by one by as many threads or processes with InitRank, which share a UniqueID.
+// A collective is called on every communicator of the group, and completes once all of them have called it.
+//
+// The collectives are asynchronous, on the given stream. A thread that drives several devices must call the
+// collectives of their communicators within a group (see Group), as a collective does not return until the collective
+// has been called on all the communicators otherwise.
+package nccl // import "gorgonia.org/cu/nccl"
+
+// #include <nccl.h>
+import "C"
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// Version returns the version of NCCL, as major×10000 + minor×100 + patch since NCCL 2.9.
+func Version() (int, error) {
+	var v C.int
+	err := result(C.ncclGetVersion(&v))
+	return int(v), err
+}
+
+// UniqueID identifies a group of communicators created by InitRank. It is created by one rank, and sent to the others,
+// by any means.
+type UniqueID [C.NCCL_UNIQUE_ID_BYTES]byte
+
+// NewUniqueID creates a UniqueID.
+func NewUniqueID() (UniqueID, error) {
+	var id C.ncclUniqueId
+	var retVal UniqueID
+	if err := result(C.ncclGetUniqueId(&id)); err != nil {
+		return retVal, errors.Wrap(err, "Unable to create a unique ID")
+	}
+	copy(retVal[:], (*[C.NCCL_UNIQUE_ID_BYTES]byte)(unsafe.Pointer(&id.internal[0]))[:])
+	return retVal, nil
+}
+
+// Comm is the communicator of a rank.
+type Comm struct {
+	c C.ncclComm_t
+}
+
+// InitAll creates a group of communicators, one for each device, within a single process. The rank of each
+// communicator is its index in devices.
+func InitAll(devices ...cu.Device) ([]*Comm, error) {
+	if len(devices) == 0 {
+		return nil, errors.New("Expected at least one device")
+	}
+	comms := make([]C.ncclComm_t, len(devices))
+	devs := make([]C.int, len(devices))
+	for i, d := range devices {
+		devs[i] = C.int(d)
+	}
+	if err := result(C.ncclCommInitAll(&comms[0], C.int(len(devices)), &devs[0])); err != nil {
+		return nil, errors.Wrap(err, "Unable to create the communicators")
+	}
+	retVal := make([]*Comm, len(comms))
+	for i, c := range comms {
+		retVal[i] = &Comm{c: c}
+	}
+	return retVal, nil
+}
+
+// InitRank creates the communicator of the given rank, in a group of nranks communicators identified by id. It must be
+// called with the context of the device of the rank current, and does not return until all the ranks have called it:
+// the ranks must call it concurrently, from different threads or processes, or within a group (see Group).
+func InitRank(nranks int, id UniqueID, rank int) (*Comm, error) {
+	if rank < 0 || rank >= nranks {
+		return nil, errors.Errorf("Invalid rank %d of %d", rank, nranks)
+	}
+	var cid C.ncclUniqueId
+	copy((*[C.NCCL_UNIQUE_ID_BYTES]byte)(unsafe.Pointer(&cid.internal[0]))[:], id[:])
+	var c C.ncclComm_t
+	if err := result(C.ncclCommInitRank(&c, C.int(nranks), cid, C.int(rank))); err != nil {
+		return nil, errors.Wrapf(err, "Unable to create the communicator of rank %d", rank)
+	}
+	return &Comm{c: c}, nil
+}
+
+// Count returns the number of ranks of the group of the communicator.
+func (c *Comm) Count() (int, error) {
+	var n C.int
+	err := result(C.ncclCommCount(c.c, &n))
+	return int(n), err
+}
+
+// Device returns the device of the communicator.
+func (c *Comm) Device() (cu.Device, error) {
+	var d C.int
+	err := result(C.ncclCommCuDevice(c.c, &d))
+	return cu.Device(d), err
+}
+
+// Rank returns the rank of the communicator.
+func (c *Comm) Rank() (int, error) {
+	var r C.int
+	err := result(C.ncclCommUserRank(c.c, &r))
+	return int(r), err
+}
+
+// AsyncError returns the error of the communication of the communicator with the other ranks, if any, such as the
+// failure of a remote rank. A communicator that failed must be aborted (see Abort).
+func (c *Comm) AsyncError() error {
+	var r C.ncclResult_t
+	if err := result(C.ncclCommGetAsyncError(c.c, &r)); err != nil {
+		return err
+	}
+	return result(r)
+}
+
+// Destroy destroys the communicator, once its work is done.
+func (c *Comm) Destroy() error {
+	if c.c == nil {
+		return nil
+	}
+	err := result(C.ncclCommDestroy(c.c))
+	c.c = nil
+	return err
+}
+
+// Abort destroys the communicator, and aborts its work. It is how collectives that will never complete, because a rank
+// failed, are given up.
+func (c *Comm) Abort() error {
+	if c.c == nil {
+		return nil
+	}
+	err := result(C.ncclCommAbort(c.c))
+	c.c = nil
+	return err
+}
+
+// GroupStart starts a group of calls: the collectives (and the InitRank) called until GroupEnd are enqueued together,
+// once GroupEnd is called. Groups may be nested.
+func GroupStart() error { return result(C.ncclGroupStart()) }
+
+// GroupEnd ends a group of calls (see GroupStart).
+func GroupEnd() error { return result(C.ncclGroupEnd()) }
+
+// Group calls fn within a group. The group is ended even if fn fails, and the error of fn is returned first.
+func Group(fn func() error) error {
+	if err := GroupStart(); err != nil {
+		return err
+	}
+	err := fn()
+	if err2 := GroupEnd(); err == nil {
+		err = err2
+	}
+	return err
+}
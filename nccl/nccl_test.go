@@ -0,0 +1,96 @@
+package nccl
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+func TestDataType(t *testing.T) {
+	for _, dt := range []cu.Dtype{cu.DtFloat32, cu.DtFloat64, cu.DtFloat16, cu.DtInt32, cu.DtInt64, cu.DtUint8} {
+		if _, err := dataType(dt); err != nil {
+			t.Errorf("%v: %v", dt, err)
+		}
+	}
+	if _, err := dataType(cu.DtComplex64); err == nil {
+		t.Error("Expected an error for complex64")
+	}
+	if err := new(Comm).AllReduce(0, 0, 1, cu.DtComplex128, Sum, cu.NoStream); err == nil {
+		t.Error("Expected an error for complex128")
+	}
+	if _, err := InitRank(2, UniqueID{}, 2); err == nil {
+		t.Error("Expected an error for a rank out of the group")
+	}
+}
+
+func TestGroup(t *testing.T) {
+	fail := errors.New("fail")
+	if err := Group(func() error { return fail }); err != fail {
+		t.Errorf("Expected the error of the function. Got %v", err)
+	}
+}
+
+func TestAllReduce(t *testing.T) {
+	devices, _ := cu.NumDevices()
+	if devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	devs := make([]cu.Device, devices)
+	for i := range devs {
+		devs[i] = cu.Device(i)
+	}
+	comms, err := InitAll(devs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctxs := make([]cu.CUContext, devices)
+	bufs := make([]cu.DevicePtr, devices)
+	for i := range devs {
+		if ctxs[i], err = devs[i].MakeContext(cu.SchedAuto); err != nil {
+			t.Fatal(err)
+		}
+		defer ctxs[i].Destroy()
+		defer comms[i].Destroy()
+		vals := []float32{float32(i), 1}
+		if bufs[i], err = cu.MemAlloc(8); err != nil {
+			t.Fatal(err)
+		}
+		if err = cu.MemcpyHtoD(bufs[i], unsafe.Pointer(&vals[0]), 8); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = Group(func() error {
+		for i, c := range comms {
+			if err := cu.SetCurrentContext(ctxs[i]); err != nil {
+				return err
+			}
+			if err := c.AllReduce(bufs[i], bufs[i], 2, cu.DtFloat32, Sum, cu.NoStream); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range devs {
+		if err = cu.SetCurrentContext(ctxs[i]); err != nil {
+			t.Fatal(err)
+		}
+		got := make([]float32, 2)
+		if err = cu.MemcpyDtoH(unsafe.Pointer(&got[0]), bufs[i], 8); err != nil {
+			t.Fatal(err)
+		}
+		if want := float32(devices * (devices - 1) / 2); got[0] != want || got[1] != float32(devices) {
+			t.Errorf("Rank %d: expected [%v %v]. Got %v", i, want, devices, got)
+		}
+		cu.MemFree(bufs[i])
+	}
+}
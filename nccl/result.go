@@ -0,0 +1,43 @@
+package nccl
+
+// #include <nccl.h>
+import "C"
+
+// Result is the result of a call to NCCL.
+type Result int
+
+func (err Result) Error() string  { return err.String() }
+func (err Result) String() string { return resString[err] }
+
+func result(x C.ncclResult_t) error {
+	err := Result(x)
+	if err == Success {
+		return nil
+	}
+	if _, ok := resString[err]; !ok {
+		return InternalError
+	}
+	return err
+}
+
+const (
+	Success            Result = C.ncclSuccess
+	UnhandledCudaError Result = C.ncclUnhandledCudaError
+	SystemError        Result = C.ncclSystemError
+	InternalError      Result = C.ncclInternalError
+	InvalidArgument    Result = C.ncclInvalidArgument
+	InvalidUsage       Result = C.ncclInvalidUsage
+	RemoteError        Result = C.ncclRemoteError
+	InProgress         Result = C.ncclInProgress
+)
+
+var resString = map[Result]string{
+	Success:            "Success",
+	UnhandledCudaError: "UnhandledCudaError",
+	SystemError:        "SystemError",
+	InternalError:      "InternalError",
+	InvalidArgument:    "InvalidArgument",
+	InvalidUsage:       "InvalidUsage",
+	RemoteError:        "RemoteError",
+	InProgress:         "InProgress",
+}
@@ -0,0 +1,305 @@
+// Package nvcomp provides bindings to nvCOMP, for decompressing data on the GPU: compressed data is copied to the
+// device as is, and decompressed directly into device buffers, rather than decompressed on the host and copied.
+//
+// The decompression is batched: a Decompressor decompresses many independent chunks at once, each compressed with
+// LZ4, Snappy or Zstd, without framing (see Format). The chunks are buffers in device memory:
+//
+//	d, _ := NewDecompressor(Snappy, stream)
+//	defer d.Close()
+//	sizes, _ := d.UncompressedSizes(src) // if the sizes are not known in advance
+//	... // allocate dst after sizes
+//	d.Decompress(dst, src)
+//	actual, err := d.Results()
+//
+// The pages of the columns of Parquet files are such chunks: once the page headers are parsed on the host, the
+// compressed pages are read into one device buffer, and the chunks point into it. Parquet's SNAPPY, ZSTD and LZ4_RAW
+// codecs are supported; its LZ4 codec has a Hadoop framing, which must be stripped first.
+package nvcomp
+
+/*
+#include <nvcomp/lz4.h>
+#include <nvcomp/snappy.h>
+#include <nvcomp/zstd.h>
+*/
+import "C"
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// Format is a compression format.
+type Format int
+
+const (
+	LZ4    Format = iota // LZ4 blocks
+	Snappy               // raw Snappy, as Parquet compresses it
+	Zstd                 // Zstandard frames
+)
+
+var formatNames = [...]string{
+	LZ4:    "LZ4",
+	Snappy: "Snappy",
+	Zstd:   "Zstd",
+}
+
+func (f Format) String() string {
+	if f >= 0 && int(f) < len(formatNames) {
+		return formatNames[f]
+	}
+	return fmt.Sprintf("UnknownFormat:%d", int(f))
+}
+
+// Chunk is a buffer in device memory: a compressed chunk, or the buffer a chunk is decompressed to.
+type Chunk struct {
+	Ptr  cu.DevicePtr
+	Size int64 // in bytes
+}
+
+// Decompressor decompresses batches of chunks of a format on a stream.
+//
+// A Decompressor keeps a workspace and the metadata of the chunks in device memory, which grow to the largest batch
+// seen, so that repeated calls do not allocate. It may be used by several goroutines, but the calls are serialized.
+type Decompressor struct {
+	format Format
+	stream cu.Stream
+
+	mu      sync.Mutex
+	temp    cu.DevicePtr
+	tempLen int64
+	meta    cu.DevicePtr
+	metaLen int64
+	n       int // the number of chunks of the last call to Decompress
+}
+
+// NewDecompressor creates a Decompressor of the given format, which works on the given stream.
+func NewDecompressor(format Format, stream cu.Stream) (*Decompressor, error) {
+	if format < 0 || int(format) >= len(formatNames) {
+		return nil, errors.Errorf("Unknown format %d", int(format))
+	}
+	return &Decompressor{format: format, stream: stream}, nil
+}
+
+// Format returns the format of the decompressor.
+func (d *Decompressor) Format() Format { return d.format }
+
+// The metadata of n chunks is laid out as arrays of n words:
+//
+//	the compressed pointers, the compressed sizes, the uncompressed sizes, the uncompressed pointers, the actual
+//	uncompressed sizes
+//
+// followed by n statuses. The first four arrays are uploaded, the last ones are written by nvCOMP.
+const (
+	compressedPtrs = iota
+	compressedSizes
+	uncompressedSizes
+	uncompressedPtrs
+	actualSizes
+	metaArrays
+)
+
+// array returns the pointer to the given array of the metadata of n chunks.
+func (d *Decompressor) array(a, n int) unsafe.Pointer {
+	return d.meta.Offset(int64(a*n) * 8).Pointer()
+}
+
+// UncompressedSizes returns the sizes of the chunks once decompressed, read from the chunks, so that the buffers they
+// are decompressed to can be allocated. It waits for the stream.
+func (d *Decompressor) UncompressedSizes(src []Chunk) ([]int64, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := len(src)
+	if n == 0 {
+		return nil, nil
+	}
+	host := make([]uint64, 3*n)
+	for i, c := range src {
+		host[compressedPtrs*n+i] = uint64(c.Ptr)
+		host[compressedSizes*n+i] = uint64(c.Size)
+	}
+	if err := d.upload(host, n); err != nil {
+		return nil, errors.Wrap(err, "UncompressedSizes")
+	}
+
+	ptrs := (*unsafe.Pointer)(d.array(compressedPtrs, n))
+	sizes := (*C.size_t)(d.array(compressedSizes, n))
+	out := (*C.size_t)(d.array(uncompressedSizes, n))
+	stream := C.cudaStream_t(d.stream.Pointer())
+	var err error
+	switch d.format {
+	case LZ4:
+		err = result(C.nvcompBatchedLZ4GetDecompressSizeAsync(ptrs, sizes, out, C.size_t(n), stream))
+	case Snappy:
+		err = result(C.nvcompBatchedSnappyGetDecompressSizeAsync(ptrs, sizes, out, C.size_t(n), stream))
+	case Zstd:
+		err = result(C.nvcompBatchedZstdGetDecompressSizeAsync(ptrs, sizes, out, C.size_t(n), stream))
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "UncompressedSizes")
+	}
+	retVal, err := d.download(uncompressedSizes, n)
+	return retVal, errors.Wrap(err, "UncompressedSizes")
+}
+
+// Decompress enqueues the decompression of the chunks of src into the chunks of dst on the stream. The size of a chunk
+// of dst is the room it has, which must be at least the size of the chunk once decompressed. The results, which are
+// per chunk, are returned by Results.
+func (d *Decompressor) Decompress(dst, src []Chunk) error {
+	if len(dst) != len(src) {
+		return errors.Errorf("Decompress: %d chunks cannot be decompressed to %d buffers", len(src), len(dst))
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := len(src)
+	d.n = n
+	if n == 0 {
+		return nil
+	}
+	host := make([]uint64, 4*n)
+	var maxSize int64
+	for i := range src {
+		host[compressedPtrs*n+i] = uint64(src[i].Ptr)
+		host[compressedSizes*n+i] = uint64(src[i].Size)
+		host[uncompressedSizes*n+i] = uint64(dst[i].Size)
+		host[uncompressedPtrs*n+i] = uint64(dst[i].Ptr)
+		if dst[i].Size > maxSize {
+			maxSize = dst[i].Size
+		}
+	}
+	if err := d.reserveTemp(n, maxSize); err != nil {
+		return errors.Wrap(err, "Decompress")
+	}
+	if err := d.upload(host, n); err != nil {
+		return errors.Wrap(err, "Decompress")
+	}
+
+	cPtrs := (*unsafe.Pointer)(d.array(compressedPtrs, n))
+	cSizes := (*C.size_t)(d.array(compressedSizes, n))
+	uSizes := (*C.size_t)(d.array(uncompressedSizes, n))
+	uPtrs := (*unsafe.Pointer)(d.array(uncompressedPtrs, n))
+	actual := (*C.size_t)(d.array(actualSizes, n))
+	statuses := (*C.nvcompStatus_t)(d.array(metaArrays, n))
+	temp, tempLen := d.temp.Pointer(), C.size_t(d.tempLen)
+	stream := C.cudaStream_t(d.stream.Pointer())
+	var err error
+	switch d.format {
+	case LZ4:
+		err = result(C.nvcompBatchedLZ4DecompressAsync(cPtrs, cSizes, uSizes, actual, C.size_t(n), temp, tempLen, uPtrs, statuses, stream))
+	case Snappy:
+		err = result(C.nvcompBatchedSnappyDecompressAsync(cPtrs, cSizes, uSizes, actual, C.size_t(n), temp, tempLen, uPtrs, statuses, stream))
+	case Zstd:
+		err = result(C.nvcompBatchedZstdDecompressAsync(cPtrs, cSizes, uSizes, actual, C.size_t(n), temp, tempLen, uPtrs, statuses, stream))
+	}
+	return errors.Wrap(err, "Decompress")
+}
+
+// Results waits for the stream, and returns the actual sizes of the chunks decompressed by the last call to
+// Decompress. If chunks failed to decompress, the error lists them, with their status.
+func (d *Decompressor) Results() ([]int64, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := d.n
+	if n == 0 {
+		return nil, nil
+	}
+	sizes, err := d.download(actualSizes, n)
+	if err != nil {
+		return nil, errors.Wrap(err, "Results")
+	}
+	statuses := make([]C.nvcompStatus_t, n)
+	if err = cu.MemcpyDtoH(unsafe.Pointer(&statuses[0]), d.meta.Offset(int64(metaArrays*n)*8), int64(n)*int64(unsafe.Sizeof(statuses[0]))); err != nil {
+		return nil, errors.Wrap(err, "Results")
+	}
+	var failed []string
+	for i, s := range statuses {
+		if s != C.nvcompSuccess {
+			failed = append(failed, fmt.Sprintf("chunk %d: %v", i, Status(s)))
+		}
+	}
+	if len(failed) > 0 {
+		return sizes, errors.Errorf("%d of %d chunks failed to decompress: %v", len(failed), n, failed)
+	}
+	return sizes, nil
+}
+
+// upload copies the first arrays of the metadata of n chunks to the device, growing the metadata if needed.
+func (d *Decompressor) upload(host []uint64, n int) error {
+	if err := d.reserveMeta(int64(metaArrays*n)*8 + int64(n)*4); err != nil {
+		return err
+	}
+	return cu.MemcpyHtoDAsync(d.meta, unsafe.Pointer(&host[0]), int64(len(host))*8, d.stream)
+}
+
+// download waits for the stream, and copies the given array of the metadata of n chunks to the host.
+func (d *Decompressor) download(a, n int) ([]int64, error) {
+	if err := d.stream.Synchronize(); err != nil {
+		return nil, err
+	}
+	retVal := make([]int64, n)
+	err := cu.MemcpyDtoH(unsafe.Pointer(&retVal[0]), d.meta.Offset(int64(a*n)*8), int64(n)*8)
+	return retVal, err
+}
+
+// reserveTemp grows the workspace to what nvCOMP needs to decompress n chunks of at most maxSize bytes.
+func (d *Decompressor) reserveTemp(n int, maxSize int64) error {
+	var size C.size_t
+	var err error
+	switch d.format {
+	case LZ4:
+		err = result(C.nvcompBatchedLZ4DecompressGetTempSize(C.size_t(n), C.size_t(maxSize), &size))
+	case Snappy:
+		err = result(C.nvcompBatchedSnappyDecompressGetTempSize(C.size_t(n), C.size_t(maxSize), &size))
+	case Zstd:
+		err = result(C.nvcompBatchedZstdDecompressGetTempSize(C.size_t(n), C.size_t(maxSize), &size))
+	}
+	if err != nil {
+		return errors.Wrap(err, "Unable to get the size of the workspace")
+	}
+	return d.reserve(&d.temp, &d.tempLen, int64(size))
+}
+
+// reserveMeta grows the metadata to at least size bytes.
+func (d *Decompressor) reserveMeta(size int64) error { return d.reserve(&d.meta, &d.metaLen, size) }
+
+// reserve grows the buffer *p of *length bytes to at least size bytes. The previous buffer is freed once the stream is
+// done with it.
+func (d *Decompressor) reserve(p *cu.DevicePtr, length *int64, size int64) error {
+	if size <= *length {
+		return nil
+	}
+	if *p != 0 {
+		if err := d.stream.Synchronize(); err != nil {
+			return err
+		}
+		if err := cu.MemFree(*p); err != nil {
+			return err
+		}
+		*p, *length = 0, 0
+	}
+	mem, err := cu.MemAlloc(size)
+	if err != nil {
+		return errors.Wrapf(err, "Unable to allocate %d bytes", size)
+	}
+	*p, *length = mem, size
+	return nil
+}
+
+// Close waits for the stream, and frees the workspace and the metadata.
+func (d *Decompressor) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.stream.Synchronize(); err != nil {
+		return err
+	}
+	for _, p := range []*cu.DevicePtr{&d.temp, &d.meta} {
+		if *p != 0 {
+			cu.MemFree(*p)
+			*p = 0
+		}
+	}
+	d.tempLen, d.metaLen, d.n = 0, 0, 0
+	return nil
+}
@@ -0,0 +1,85 @@
+package nvcomp
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"gorgonia.org/cu"
+)
+
+func TestNewDecompressor(t *testing.T) {
+	if _, err := NewDecompressor(Format(len(formatNames)), cu.NoStream); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+	d, err := NewDecompressor(Zstd, cu.NoStream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = d.Decompress(make([]Chunk, 2), make([]Chunk, 3)); err == nil {
+		t.Error("Expected an error for mismatched chunks")
+	}
+}
+
+func TestDecompress(t *testing.T) {
+	if devices, _ := cu.NumDevices(); devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := cu.Device(0).MakeContext(cu.SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+
+	// "hello", as a LZ4 block of literals only, and as raw Snappy: the length, then a literal
+	cases := []struct {
+		format     Format
+		compressed []byte
+	}{
+		{LZ4, []byte{0x50, 'h', 'e', 'l', 'l', 'o'}},
+		{Snappy, []byte{5, 0x10, 'h', 'e', 'l', 'l', 'o'}},
+	}
+	for _, c := range cases {
+		src, err := cu.AllocAndCopy(unsafe.Pointer(&c.compressed[0]), int64(len(c.compressed)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst, err := cu.MemAlloc(16)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, err := NewDecompressor(c.format, cu.NoStream)
+		if err != nil {
+			t.Fatal(err)
+		}
+		srcs := []Chunk{{src, int64(len(c.compressed))}}
+		if c.format == Snappy {
+			sizes, err := d.UncompressedSizes(srcs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(sizes) != 1 || sizes[0] != 5 {
+				t.Errorf("%v: expected an uncompressed size of 5. Got %v", c.format, sizes)
+			}
+		}
+		if err = d.Decompress([]Chunk{{dst, 16}}, srcs); err != nil {
+			t.Fatal(err)
+		}
+		sizes, err := d.Results()
+		if err != nil {
+			t.Fatalf("%v: %v", c.format, err)
+		}
+		got := make([]byte, 5)
+		if err = cu.MemcpyDtoH(unsafe.Pointer(&got[0]), dst, 5); err != nil {
+			t.Fatal(err)
+		}
+		if sizes[0] != 5 || string(got) != "hello" {
+			t.Errorf("%v: expected \"hello\". Got %q (%d bytes)", c.format, got, sizes[0])
+		}
+		d.Close()
+		cu.MemFree(src)
+		cu.MemFree(dst)
+	}
+}
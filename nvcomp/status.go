@@ -0,0 +1,43 @@
+package nvcomp
+
+// #include <nvcomp/shared_types.h>
+import "C"
+
+// Status is the status returned by nvCOMP, for a call or for a chunk.
+type Status int
+
+func (err Status) Error() string  { return err.String() }
+func (err Status) String() string { return resString[err] }
+
+func result(x C.nvcompStatus_t) error {
+	err := Status(x)
+	if err == Success {
+		return nil
+	}
+	if _, ok := resString[err]; !ok {
+		return ErrorInternal
+	}
+	return err
+}
+
+const (
+	Success                    Status = C.nvcompSuccess
+	ErrorInvalidValue          Status = C.nvcompErrorInvalidValue
+	ErrorNotSupported          Status = C.nvcompErrorNotSupported
+	ErrorCannotDecompress      Status = C.nvcompErrorCannotDecompress
+	ErrorBadChecksum           Status = C.nvcompErrorBadChecksum
+	ErrorCannotVerifyChecksums Status = C.nvcompErrorCannotVerifyChecksums
+	ErrorCudaError             Status = C.nvcompErrorCudaError
+	ErrorInternal              Status = C.nvcompErrorInternal
+)
+
+var resString = map[Status]string{
+	Success:                    "Success",
+	ErrorInvalidValue:          "ErrorInvalidValue",
+	ErrorNotSupported:          "ErrorNotSupported",
+	ErrorCannotDecompress:      "ErrorCannotDecompress",
+	ErrorBadChecksum:           "ErrorBadChecksum",
+	ErrorCannotVerifyChecksums: "ErrorCannotVerifyChecksums",
+	ErrorCudaError:             "ErrorCudaError",
+	ErrorInternal:              "ErrorInternal",
+}
@@ -0,0 +1,221 @@
+// Package nvml binds NVML, the management library of the NVIDIA driver, to monitor devices from within a program,
+// instead of running nvidia-smi: their temperature, utilization, power draw, memory, clocks and ECC errors.
+//
+// Devices are looked up by the cu.Device the driver API uses, so that the telemetry is that of the device the work
+// runs on, whatever the order NVML enumerates them in:
+//
+//	if err := nvml.Init(); err != nil {
+//		..error handling..
+//	}
+//	defer nvml.Shutdown()
+//	s, err := nvml.Sample(cu.Device(0))
+package nvml // import "gorgonia.org/cu/nvml"
+
+// #include <stdlib.h>
+// #include <nvml.h>
+import "C"
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// Init initializes NVML. It must be called before the other functions of the package, and may be called more than
+// once, as long as Shutdown is called as many times.
+func Init() error { return result(C.nvmlInit_v2()) }
+
+// Shutdown releases the resources of NVML, once it has been called as many times as Init.
+func Shutdown() error {
+	devices.Lock()
+	devices.m = nil
+	devices.Unlock()
+	return result(C.nvmlShutdown())
+}
+
+// DriverVersion returns the version of the driver, such as "535.104.05".
+func DriverVersion() (string, error) {
+	var buf [C.NVML_SYSTEM_DRIVER_VERSION_BUFFER_SIZE]C.char
+	if err := result(C.nvmlSystemGetDriverVersion(&buf[0], C.uint(len(buf)))); err != nil {
+		return "", err
+	}
+	return C.GoString(&buf[0]), nil
+}
+
+// devices are the NVML handles of the devices looked up, which are valid until Shutdown.
+var devices struct {
+	sync.Mutex
+	m map[cu.Device]C.nvmlDevice_t
+}
+
+// Device is the NVML handle of a device.
+type Device struct {
+	cu.Device
+	h C.nvmlDevice_t
+}
+
+// Lookup returns the NVML handle of the device. The device is found by its PCI address, which NVML and the driver API
+// agree on, unlike the ordinals.
+func Lookup(d cu.Device) (Device, error) {
+	devices.Lock()
+	defer devices.Unlock()
+	if h, ok := devices.m[d]; ok {
+		return Device{d, h}, nil
+	}
+
+	pci, err := d.Attributes(cu.PciDomainID, cu.PciBusID, cu.PciDeviceID)
+	if err != nil {
+		return Device{}, errors.Wrapf(err, "Unable to get the PCI address of device %d", int(d))
+	}
+	busID := C.CString(fmt.Sprintf("%08x:%02x:%02x.0", pci[0], pci[1], pci[2]))
+	defer C.free(unsafe.Pointer(busID))
+	var h C.nvmlDevice_t
+	if err = result(C.nvmlDeviceGetHandleByPciBusId_v2(busID, &h)); err != nil {
+		return Device{}, errors.Wrapf(err, "Unable to find device %d", int(d))
+	}
+	if devices.m == nil {
+		devices.m = make(map[cu.Device]C.nvmlDevice_t)
+	}
+	devices.m[d] = h
+	return Device{d, h}, nil
+}
+
+// Temperature returns the temperature of the GPU, in degrees Celsius.
+func (d Device) Temperature() (int, error) {
+	var t C.uint
+	err := result(C.nvmlDeviceGetTemperature(d.h, C.NVML_TEMPERATURE_GPU, &t))
+	return int(t), err
+}
+
+// Utilization is the percentage of time over the last sample period during which the device was busy.
+type Utilization struct {
+	GPU    int // one or more kernels were running
+	Memory int // the device memory was being read or written
+}
+
+// Utilization returns the utilization of the device.
+func (d Device) Utilization() (Utilization, error) {
+	var u C.nvmlUtilization_t
+	err := result(C.nvmlDeviceGetUtilizationRates(d.h, &u))
+	return Utilization{GPU: int(u.gpu), Memory: int(u.memory)}, err
+}
+
+// Power returns the power draw of the device, in milliwatts.
+func (d Device) Power() (int, error) {
+	var p C.uint
+	err := result(C.nvmlDeviceGetPowerUsage(d.h, &p))
+	return int(p), err
+}
+
+// PowerLimit returns the power limit the device is held to, in milliwatts.
+func (d Device) PowerLimit() (int, error) {
+	var p C.uint
+	err := result(C.nvmlDeviceGetEnforcedPowerLimit(d.h, &p))
+	return int(p), err
+}
+
+// MemInfo is the device memory of a device, in bytes.
+type MemInfo struct {
+	Total, Free, Used int64
+}
+
+// MemInfo returns the device memory of the device. Unlike cu.MemInfo, it counts the memory of all the processes.
+func (d Device) MemInfo() (MemInfo, error) {
+	var m C.nvmlMemory_t
+	err := result(C.nvmlDeviceGetMemoryInfo(d.h, &m))
+	return MemInfo{Total: int64(m.total), Free: int64(m.free), Used: int64(m.used)}, err
+}
+
+// ClockType is a clock domain of a device.
+type ClockType int
+
+const (
+	GraphicsClock ClockType = C.NVML_CLOCK_GRAPHICS
+	SMClock       ClockType = C.NVML_CLOCK_SM
+	MemClock      ClockType = C.NVML_CLOCK_MEM
+	VideoClock    ClockType = C.NVML_CLOCK_VIDEO
+)
+
+// Clock returns the current speed of the clock, in MHz.
+func (d Device) Clock(clock ClockType) (int, error) {
+	var mhz C.uint
+	err := result(C.nvmlDeviceGetClockInfo(d.h, C.nvmlClockType_t(clock), &mhz))
+	return int(mhz), err
+}
+
+// MaxClock returns the maximum speed of the clock, in MHz.
+func (d Device) MaxClock(clock ClockType) (int, error) {
+	var mhz C.uint
+	err := result(C.nvmlDeviceGetMaxClockInfo(d.h, C.nvmlClockType_t(clock), &mhz))
+	return int(mhz), err
+}
+
+// ECCErrors are the counts of the ECC errors of a device.
+type ECCErrors struct {
+	Corrected   int64 // single bit errors, which were corrected
+	Uncorrected int64 // double bit errors, which corrupted data
+}
+
+// ECCErrors returns the ECC errors of the device since the driver was loaded, or, if aggregate, over the life of the
+// device. Devices without ECC, or with ECC disabled, return NotSupported.
+func (d Device) ECCErrors(aggregate bool) (ECCErrors, error) {
+	counter := C.nvmlEccCounterType_t(C.NVML_VOLATILE_ECC)
+	if aggregate {
+		counter = C.NVML_AGGREGATE_ECC
+	}
+	var corrected, uncorrected C.ulonglong
+	if err := result(C.nvmlDeviceGetTotalEccErrors(d.h, C.NVML_MEMORY_ERROR_TYPE_CORRECTED, counter, &corrected)); err != nil {
+		return ECCErrors{}, err
+	}
+	if err := result(C.nvmlDeviceGetTotalEccErrors(d.h, C.NVML_MEMORY_ERROR_TYPE_UNCORRECTED, counter, &uncorrected)); err != nil {
+		return ECCErrors{}, err
+	}
+	return ECCErrors{Corrected: int64(corrected), Uncorrected: int64(uncorrected)}, nil
+}
+
+// Stats are the telemetry of a device at one point in time. Clocks are in MHz, power in milliwatts.
+type Stats struct {
+	Device      cu.Device
+	Temperature int // degrees Celsius
+	Utilization Utilization
+	Power       int
+	PowerLimit  int
+	Memory      MemInfo
+	SMClock     int
+	MemClock    int
+	ECC         ECCErrors // zero if the device does not support ECC
+}
+
+// Sample returns the telemetry of the device. Measures the device does not support are left at zero.
+func Sample(d cu.Device) (s Stats, err error) {
+	dev, err := Lookup(d)
+	if err != nil {
+		return s, err
+	}
+	s.Device = d
+	keep := func(e error) {
+		if e != nil && e != NotSupported && err == nil {
+			err = e
+		}
+	}
+	var e error
+	s.Temperature, e = dev.Temperature()
+	keep(e)
+	s.Utilization, e = dev.Utilization()
+	keep(e)
+	s.Power, e = dev.Power()
+	keep(e)
+	s.PowerLimit, e = dev.PowerLimit()
+	keep(e)
+	s.Memory, e = dev.MemInfo()
+	keep(e)
+	s.SMClock, e = dev.Clock(SMClock)
+	keep(e)
+	s.MemClock, e = dev.Clock(MemClock)
+	keep(e)
+	s.ECC, e = dev.ECCErrors(false)
+	keep(e)
+	return s, err
+}
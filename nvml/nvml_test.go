@@ -0,0 +1,35 @@
+package nvml
+
+import (
+	"testing"
+
+	"gorgonia.org/cu"
+)
+
+func TestResult(t *testing.T) {
+	if NotSupported.Error() != "NotSupported" {
+		t.Errorf("Expected the name of the result. Got %q", NotSupported.Error())
+	}
+}
+
+func TestSample(t *testing.T) {
+	if devices, _ := cu.NumDevices(); devices == 0 {
+		t.Skip("NoDevice")
+	}
+	if err := Init(); err != nil {
+		t.Skipf("NVML is not available: %v", err)
+	}
+	defer Shutdown()
+
+	s, err := Sample(cu.Device(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Memory.Total == 0 || s.Memory.Used > s.Memory.Total {
+		t.Errorf("Expected the memory of the device. Got %+v", s.Memory)
+	}
+	if s.Utilization.GPU > 100 || s.Utilization.Memory > 100 {
+		t.Errorf("Expected percentages. Got %+v", s.Utilization)
+	}
+	t.Logf("%+v", s)
+}
@@ -0,0 +1,75 @@
+package nvml
+
+// #include <nvml.h>
+import "C"
+
+// Result is the result of a call to NVML.
+type Result int
+
+func (err Result) Error() string  { return err.String() }
+func (err Result) String() string { return resString[err] }
+
+func result(x C.nvmlReturn_t) error {
+	err := Result(x)
+	if err == Success {
+		return nil
+	}
+	if _, ok := resString[err]; !ok {
+		return Unknown
+	}
+	return err
+}
+
+const (
+	Success              Result = C.NVML_SUCCESS
+	Uninitialized        Result = C.NVML_ERROR_UNINITIALIZED
+	InvalidArgument      Result = C.NVML_ERROR_INVALID_ARGUMENT
+	NotSupported         Result = C.NVML_ERROR_NOT_SUPPORTED
+	NoPermission         Result = C.NVML_ERROR_NO_PERMISSION
+	AlreadyInitialized   Result = C.NVML_ERROR_ALREADY_INITIALIZED
+	NotFound             Result = C.NVML_ERROR_NOT_FOUND
+	InsufficientSize     Result = C.NVML_ERROR_INSUFFICIENT_SIZE
+	InsufficientPower    Result = C.NVML_ERROR_INSUFFICIENT_POWER
+	DriverNotLoaded      Result = C.NVML_ERROR_DRIVER_NOT_LOADED
+	Timeout              Result = C.NVML_ERROR_TIMEOUT
+	IRQIssue             Result = C.NVML_ERROR_IRQ_ISSUE
+	LibraryNotFound      Result = C.NVML_ERROR_LIBRARY_NOT_FOUND
+	FunctionNotFound     Result = C.NVML_ERROR_FUNCTION_NOT_FOUND
+	CorruptedInforom     Result = C.NVML_ERROR_CORRUPTED_INFOROM
+	GPUIsLost            Result = C.NVML_ERROR_GPU_IS_LOST
+	ResetRequired        Result = C.NVML_ERROR_RESET_REQUIRED
+	OperatingSystem      Result = C.NVML_ERROR_OPERATING_SYSTEM
+	LibRMVersionMismatch Result = C.NVML_ERROR_LIB_RM_VERSION_MISMATCH
+	InUse                Result = C.NVML_ERROR_IN_USE
+	InsufficientMemory   Result = C.NVML_ERROR_MEMORY
+	NoData               Result = C.NVML_ERROR_NO_DATA
+	VGPUECCNotSupported  Result = C.NVML_ERROR_VGPU_ECC_NOT_SUPPORTED
+	Unknown              Result = C.NVML_ERROR_UNKNOWN
+)
+
+var resString = map[Result]string{
+	Success:              "Success",
+	Uninitialized:        "Uninitialized",
+	InvalidArgument:      "InvalidArgument",
+	NotSupported:         "NotSupported",
+	NoPermission:         "NoPermission",
+	AlreadyInitialized:   "AlreadyInitialized",
+	NotFound:             "NotFound",
+	InsufficientSize:     "InsufficientSize",
+	InsufficientPower:    "InsufficientPower",
+	DriverNotLoaded:      "DriverNotLoaded",
+	Timeout:              "Timeout",
+	IRQIssue:             "IRQIssue",
+	LibraryNotFound:      "LibraryNotFound",
+	FunctionNotFound:     "FunctionNotFound",
+	CorruptedInforom:     "CorruptedInforom",
+	GPUIsLost:            "GPUIsLost",
+	ResetRequired:        "ResetRequired",
+	OperatingSystem:      "OperatingSystem",
+	LibRMVersionMismatch: "LibRMVersionMismatch",
+	InUse:                "InUse",
+	InsufficientMemory:   "InsufficientMemory",
+	NoData:               "NoData",
+	VGPUECCNotSupported:  "VGPUECCNotSupported",
+	Unknown:              "Unknown",
+}
@@ -0,0 +1,49 @@
+package nvrtc
+
+import "fmt"
+
+// CompileError is the error of a program that failed to compile. Log is the log of the compiler, which says why.
+type CompileError struct {
+	Name string
+	Log  string
+	Err  error
+}
+
+func (err *CompileError) Error() string {
+	return fmt.Sprintf("Unable to compile %v: %v\n%s", err.Name, err.Err, err.Log)
+}
+
+// Cause returns the error of NVRTC.
+func (err *CompileError) Cause() error { return err.Err }
+
+// CompilePTX compiles the CUDA C source into PTX, which cu.LoadData loads into a module. The lowered names of the name
+// expressions (see AddNameExpression) are returned in the same order, so that templated kernels may be looked up in
+// the module. The options are those of Compile. A failure to compile is returned as a *CompileError.
+func CompilePTX(source, name string, names []string, options ...string) (ptx string, lowered []string, err error) {
+	program, err := CreateProgram(source, name)
+	if err != nil {
+		return "", nil, err
+	}
+	defer program.Destroy()
+
+	for _, n := range names {
+		if err = program.AddNameExpression(n); err != nil {
+			return "", nil, err
+		}
+	}
+	if err = program.Compile(options...); err != nil {
+		log, _ := program.GetLog()
+		return "", nil, &CompileError{Name: name, Log: log, Err: err}
+	}
+	for _, n := range names {
+		var l string
+		if l, err = program.GetLoweredName(n); err != nil {
+			return "", nil, err
+		}
+		lowered = append(lowered, l)
+	}
+	if ptx, err = program.GetPTX(); err != nil {
+		return "", nil, err
+	}
+	return ptx, lowered, nil
+}
@@ -1,4 +1,14 @@
-package nvrtc
+// Package nvrtc binds NVRTC, the runtime compilation library of CUDA. It compiles CUDA C source into PTX, which
+// cu.LoadData loads into a module, so that kernels may be generated at run time instead of shipped as cubins:
+//
+//	ptx, names, err := nvrtc.CompilePTX(source, "saxpy.cu", []string{"saxpy<float>"}, "--gpu-architecture=compute_70")
+//	if err != nil {
+//		..error handling, err may be a *CompileError with the log of the compiler..
+//	}
+//	mod, err := cu.LoadData(ptx)
+//	..
+//	fn, err := mod.Function(names[0])
+package nvrtc // import "gorgonia.org/cu/nvrtc"
 
 //#include <nvrtc.h>
 import "C"
@@ -6,21 +16,42 @@ import (
 	"unsafe"
 )
 
+// Version returns the version of NVRTC.
 func Version() (major, minor int, err error) {
 	var maj, min C.int
 	err = result(C.nvrtcVersion(&maj, &min))
 	return int(maj), int(min), err
 }
 
+// SupportedArchs returns the compute capabilities NVRTC compiles for, such as 70 for compute_70.
+func SupportedArchs() ([]int, error) {
+	var n C.int
+	if err := result(C.nvrtcGetNumSupportedArchs(&n)); err != nil || n == 0 {
+		return nil, err
+	}
+	archs := make([]C.int, n)
+	if err := result(C.nvrtcGetSupportedArchs(&archs[0])); err != nil {
+		return nil, err
+	}
+	retVal := make([]int, n)
+	for i, a := range archs {
+		retVal[i] = int(a)
+	}
+	return retVal, nil
+}
+
+// Program is a CUDA C program being compiled.
 type Program struct {
 	c C.nvrtcProgram
 }
 
+// Include is a header, which the source of a Program includes by its name.
 type Include struct {
 	Source string
 	Name   string
 }
 
+// CreateProgram creates a program from the CUDA C source. The name is used in the messages of the compiler.
 func CreateProgram(source, name string, headers ...Include) (Program, error) {
 	var program Program
 	csource := C.CString(source)
@@ -52,12 +83,15 @@ func CreateProgram(source, name string, headers ...Include) (Program, error) {
 	return program, err
 }
 
+// Destroy frees the program.
 func (program *Program) Destroy() error {
 	err := result(C.nvrtcDestroyProgram(&program.c))
 	*program = Program{}
 	return err
 }
 
+// Compile compiles the program with the options of nvcc NVRTC supports, such as "--gpu-architecture=compute_70".
+// When it fails, the reason is in the log (see GetLog).
 func (program *Program) Compile(options ...string) error {
 	if len(options) == 0 {
 		return result(C.nvrtcCompileProgram(program.c, 0, nil))
@@ -77,6 +111,7 @@ func (program *Program) Compile(options ...string) error {
 	return result(C.nvrtcCompileProgram(program.c, C.int(numOptions), &coptions[0]))
 }
 
+// GetPTX returns the PTX of the compiled program.
 func (program *Program) GetPTX() (string, error) {
 	var size C.size_t
 	err := result(C.nvrtcGetPTXSize(program.c, &size))
@@ -89,6 +124,21 @@ func (program *Program) GetPTX() (string, error) {
 	return string(data[:size]), err
 }
 
+// GetCUBIN returns the cubin of the compiled program. There is one only if the program was compiled for a real
+// architecture, such as "--gpu-architecture=sm_70".
+func (program *Program) GetCUBIN() ([]byte, error) {
+	var size C.size_t
+	err := result(C.nvrtcGetCUBINSize(program.c, &size))
+	if err != nil || size == 0 {
+		return nil, err
+	}
+
+	data := make([]byte, size)
+	err = result(C.nvrtcGetCUBIN(program.c, (*C.char)(unsafe.Pointer(&data[0]))))
+	return data, err
+}
+
+// GetLog returns the log of the compiler.
 func (program *Program) GetLog() (string, error) {
 	var size C.size_t
 	err := result(C.nvrtcGetProgramLogSize(program.c, &size))
@@ -101,12 +151,16 @@ func (program *Program) GetLog() (string, error) {
 	return string(data[:size]), err
 }
 
+// AddNameExpression adds the name of a __global__ function or __device__ variable, such as "f<float>", whose
+// lowered (mangled) name is wanted. It must be called before Compile.
 func (program *Program) AddNameExpression(nameExpression string) error {
 	cstr := C.CString(nameExpression)
 	defer C.free(unsafe.Pointer(cstr))
 	return result(C.nvrtcAddNameExpression(program.c, cstr))
 }
 
+// GetLoweredName returns the lowered name of a name expression added with AddNameExpression, which is the name the
+// function has in the module the PTX is loaded into. It must be called after Compile.
 func (program *Program) GetLoweredName(nameExpression string) (string, error) {
 	cstr := C.CString(nameExpression)
 	defer C.free(unsafe.Pointer(cstr))
@@ -3,6 +3,7 @@ package nvrtc_test
 import (
 	"testing"
 
+	"github.com/pkg/errors"
 	"gorgonia.org/cu/nvrtc"
 )
 
@@ -48,3 +49,27 @@ func TestCompile(t *testing.T) {
 	}
 	t.Logf("program log: %v", programLog)
 }
+
+func TestCompilePTX(t *testing.T) {
+	ptx, names, err := nvrtc.CompilePTX(`
+		template <typename T> __global__
+		void scale(T a, T *x, size_t n) {
+			size_t tid = blockIdx.x * blockDim.x + threadIdx.x;
+			if (tid < n) {
+				x[tid] *= a;
+			}
+		}
+	`, `scale.cu`, []string{`scale<float>`, `scale<double>`})
+	if err != nil {
+		t.Fatalf("failed to CompilePTX: %v", err)
+	}
+	if len(names) != 2 {
+		t.Fatalf("expected 2 lowered names, got %v", names)
+	}
+	t.Logf("lowered names: %v, ptx: %d bytes", names, len(ptx))
+
+	err = &nvrtc.CompileError{Name: "bad.cu", Log: "bad.cu(1): error", Err: nvrtc.Compilation}
+	if errors.Cause(err) != nvrtc.Compilation {
+		t.Errorf("expected the cause to be the error of NVRTC, got %v", errors.Cause(err))
+	}
+}
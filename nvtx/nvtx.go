@@ -0,0 +1,187 @@
+// Package nvtx binds NVTX, the annotation API of the NVIDIA tools, so that the code paths of a Go program show up as
+// labeled ranges in the timelines of Nsight Systems, next to the kernels they launch:
+//
+//	nvtx.WithRange("forward", func() {
+//		..launch kernels..
+//	})
+//
+// The calls of NVTX do nothing unless the program runs under a tool that records them, so they may be left in
+// production code.
+//
+// Ranges pushed with RangePush belong to the OS thread, and goroutines move between threads: a range must be popped on
+// the thread it was pushed on, which WithRange takes care of. Ranges started with RangeStart may end anywhere.
+package nvtx // import "gorgonia.org/cu/nvtx"
+
+/*
+#include <stdlib.h>
+#include <nvtx3/nvToolsExt.h>
+#include <nvtx3/nvToolsExtCuda.h>
+
+static nvtxEventAttributes_t attributes(const char* message, uint32_t color) {
+	nvtxEventAttributes_t a = {0};
+	a.version = NVTX_VERSION;
+	a.size = NVTX_EVENT_ATTRIB_STRUCT_SIZE;
+	if (color != 0) {
+		a.colorType = NVTX_COLOR_ARGB;
+		a.color = color;
+	}
+	a.messageType = NVTX_MESSAGE_TYPE_ASCII;
+	a.message.ascii = message;
+	return a;
+}
+
+static void domainMark(nvtxDomainHandle_t d, const char* message, uint32_t color) {
+	nvtxEventAttributes_t a = attributes(message, color);
+	nvtxDomainMarkEx(d, &a);
+}
+
+static int domainRangePush(nvtxDomainHandle_t d, const char* message, uint32_t color) {
+	nvtxEventAttributes_t a = attributes(message, color);
+	return nvtxDomainRangePushEx(d, &a);
+}
+
+static nvtxRangeId_t domainRangeStart(nvtxDomainHandle_t d, const char* message, uint32_t color) {
+	nvtxEventAttributes_t a = attributes(message, color);
+	return nvtxDomainRangeStartEx(d, &a);
+}
+*/
+import "C"
+import (
+	"runtime"
+	"unsafe"
+
+	"gorgonia.org/cu"
+)
+
+// Mark marks an instant in the timeline.
+func Mark(message string) {
+	cmsg := C.CString(message)
+	defer C.free(unsafe.Pointer(cmsg))
+	C.nvtxMarkA(cmsg)
+}
+
+// RangePush starts a range nested in the current range of the OS thread, and returns its depth, or a negative number
+// if no tool is recording.
+func RangePush(message string) int {
+	cmsg := C.CString(message)
+	defer C.free(unsafe.Pointer(cmsg))
+	return int(C.nvtxRangePushA(cmsg))
+}
+
+// RangePop ends the current range of the OS thread, and returns its depth.
+func RangePop() int { return int(C.nvtxRangePop()) }
+
+// WithRange runs fn within a range, on one OS thread.
+func WithRange(message string, fn func()) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	RangePush(message)
+	defer RangePop()
+	fn()
+}
+
+// Range is a range started with RangeStart, which may end on any thread.
+type Range struct {
+	domain C.nvtxDomainHandle_t
+	id     C.nvtxRangeId_t
+}
+
+// RangeStart starts a range, which is not nested in the ranges of the thread.
+func RangeStart(message string) Range {
+	cmsg := C.CString(message)
+	defer C.free(unsafe.Pointer(cmsg))
+	return Range{id: C.nvtxRangeStartA(cmsg)}
+}
+
+// End ends the range.
+func (r Range) End() {
+	if r.domain != nil {
+		C.nvtxDomainRangeEnd(r.domain, r.id)
+		return
+	}
+	C.nvtxRangeEnd(r.id)
+}
+
+// Domain groups the annotations of a library or a component, so that they can be told apart, and filtered, in the
+// tools.
+type Domain struct {
+	h C.nvtxDomainHandle_t
+
+	// Color is the ARGB color of the marks and ranges of the domain, such as 0xff76b900. Zero leaves the choice to the
+	// tool.
+	Color uint32
+}
+
+// NewDomain creates a domain.
+func NewDomain(name string) *Domain {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	return &Domain{h: C.nvtxDomainCreateA(cname)}
+}
+
+// Mark marks an instant in the timeline of the domain.
+func (d *Domain) Mark(message string) {
+	cmsg := C.CString(message)
+	defer C.free(unsafe.Pointer(cmsg))
+	C.domainMark(d.h, cmsg, C.uint32_t(d.Color))
+}
+
+// RangePush starts a range of the domain, nested in its current range on the OS thread.
+func (d *Domain) RangePush(message string) int {
+	cmsg := C.CString(message)
+	defer C.free(unsafe.Pointer(cmsg))
+	return int(C.domainRangePush(d.h, cmsg, C.uint32_t(d.Color)))
+}
+
+// RangePop ends the current range of the domain on the OS thread.
+func (d *Domain) RangePop() int { return int(C.nvtxDomainRangePop(d.h)) }
+
+// WithRange runs fn within a range of the domain, on one OS thread.
+func (d *Domain) WithRange(message string, fn func()) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	d.RangePush(message)
+	defer d.RangePop()
+	fn()
+}
+
+// RangeStart starts a range of the domain, which may end on any thread.
+func (d *Domain) RangeStart(message string) Range {
+	cmsg := C.CString(message)
+	defer C.free(unsafe.Pointer(cmsg))
+	return Range{domain: d.h, id: C.domainRangeStart(d.h, cmsg, C.uint32_t(d.Color))}
+}
+
+// Destroy destroys the domain.
+func (d *Domain) Destroy() {
+	C.nvtxDomainDestroy(d.h)
+	d.h = nil
+}
+
+// NameDevice names the device in the timeline.
+func NameDevice(dev cu.Device, name string) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	C.nvtxNameCuDeviceA(C.CUdevice(dev), cname)
+}
+
+// NameContext names the context in the timeline.
+func NameContext(ctx cu.CUContext, name string) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	C.nvtxNameCuContextA(C.CUcontext(ctx.Pointer()), cname)
+}
+
+// NameStream names the stream in the timeline, so that the work of the stream shows up under its name.
+func NameStream(s cu.Stream, name string) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	C.nvtxNameCuStreamA(C.CUstream(s.Pointer()), cname)
+}
+
+// NameEvent names the event in the timeline.
+func NameEvent(e cu.Event, name string) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	C.nvtxNameCuEventA(C.CUevent(e.Pointer()), cname)
+}
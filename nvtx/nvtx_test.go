@@ -0,0 +1,33 @@
+package nvtx
+
+import (
+	"testing"
+
+	"gorgonia.org/cu"
+)
+
+func TestRanges(t *testing.T) {
+	ran := false
+	WithRange("outer", func() {
+		Mark("inside")
+		ran = true
+	})
+	if !ran {
+		t.Error("Expected the function to run")
+	}
+
+	d := NewDomain("test")
+	d.Color = 0xff76b900
+	d.WithRange("domain", func() { d.Mark("inside") })
+	r := d.RangeStart("async")
+	done := make(chan struct{})
+	go func() {
+		r.End()
+		close(done)
+	}()
+	<-done
+	RangeStart("global").End()
+	d.Destroy()
+
+	NameStream(cu.NoStream, "null stream")
+}
@@ -2,6 +2,7 @@ package cu
 
 // #include <cuda.h>
 import "C"
+import "github.com/pkg/errors"
 
 // MaxActiveBlocksPerMultiProcessor returns the number of the maximum active blocks per streaming multiprocessor.
 func (fn Function) MaxActiveBlocksPerMultiProcessor(blockSize int, dynamicSmemSize int64) (int, error) {
@@ -29,59 +30,108 @@ func (fn Function) MaxActiveBlocksPerMultiProcessorWithFlags(blockSize int, dyna
 	return int(numBlocks), nil
 }
 
-// MaxPotentialBlockSize suggest a reasonable block size that can achieve the maximum occupancy (or, the maximum number of active warps with the fewest blocks per multiprocessor
-// and the minimum grid size to achieve the maximum occupancy.
+// MaxPotentialBlockSize returns the block size that achieves the maximum occupancy (the maximum number of active warps
+// with the fewest blocks per multiprocessor), and the minimum grid size to achieve the maximum occupancy.
 //
-// If blockSizeLimit is 0, the configurator will use the maximum block size permitted by the device / function instead.
-//
-// If per-block dynamic shared memory allocation is not needed, the user should leave both bs2ds and dynamicSMemSize as 0.
-//
-// If per-block dynamic shared memory allocation is needed, then if the dynamic shared memory size is constant regardless of block size, the size should be passed through dynamicSMemSize, and blockSizeToDynamicSMemSize should be NULL.
-//
-// Otherwise, if the per-block dynamic shared memory size varies with different block sizes, the user needs to provide a unary function through blockSizeToDynamicSMemSize that computes the dynamic shared memory needed by func for any given block size.
-// dynamicSMemSize is ignored. An example signature is:
-//		// Take block size, returns dynamic shared memory needed
-//          size_t blockToSmem(int blockSize);
-// func (fn Function) MaxPotentialBlockSize(blockSizeToDynamicSMemSize int, dynamicSmemSize int64, blockSizeLimit int) (minGridSize, blockSize int, err error) {
-// 	bs2dsm := C.CUoccupancyB2DSize(blockSizeToDynamicSMemSize)
-// 	dss := C.size_t(dynamicSmemSize)
-// 	f := C.CUfunction(fn)
-// 	bsl := C.int(blockSizeLimit)
-
-// 	var mgs, bs C.int
-// 	if err = result(C.cuOccupancyMaxPotentialBlockSize(&mgs, &bs, f, bs2dsm, dss, bsl)); err != nil {
-// 		return
-// 	}
-// 	minGridSize = int(mgs)
-// 	blockSize = int(bs)
-// 	return
-// }
+// dynamicSmemSize is the dynamic shared memory of a block, whatever its size (see OptimalLaunchConfig for memory that
+// grows with the block size). If blockSizeLimit is 0, the block size is limited by the device and the function only.
+func (fn Function) MaxPotentialBlockSize(dynamicSmemSize int64, blockSizeLimit int) (minGridSize, blockSize int, err error) {
+	return fn.MaxPotentialBlockSizeWithFlags(dynamicSmemSize, blockSizeLimit, DefaultOccupancy)
+}
 
-// MaxPotentialBlockSizeWithFlags suggest a reasonable block size that can achieve the maximum occupancy (or, the maximum number of active warps with the fewest blocks per multiprocessor
-// and the minimum grid size to achieve the maximum occupancy.
+// MaxPotentialBlockSizeWithFlags is MaxPotentialBlockSize, with flags that control how special cases are handled.
+func (fn Function) MaxPotentialBlockSizeWithFlags(dynamicSmemSize int64, blockSizeLimit int, flags OccupancyFlags) (minGridSize, blockSize int, err error) {
+	var mgs, bs C.int
+	if err = result(C.cuOccupancyMaxPotentialBlockSizeWithFlags(&mgs, &bs, fn.fn, nil, C.size_t(dynamicSmemSize), C.int(blockSizeLimit), C.uint(flags))); err != nil {
+		return
+	}
+	return int(mgs), int(bs), nil
+}
 
-// If blockSizeLimit is 0, the configurator will use the maximum block size permitted by the device / function instead.
+// MaxPotentialClusterSize returns the largest cluster size, in blocks, with which the function can be launched with the
+// grid, block and shared memory of the configuration. Its Cluster is ignored.
+func (fn Function) MaxPotentialClusterSize(cfg LaunchConfig) (int, error) {
+	cfg.Cluster = [3]int{}
+	c := launchConfig(cfg, nil, NoStream)
+	defer freeLaunchConfig(&c)
+	var size C.int
+	if err := result(C.cuOccupancyMaxPotentialClusterSize(&size, fn.fn, &c)); err != nil {
+		return 0, err
+	}
+	return int(size), nil
+}
 
-// If per-block dynamic shared memory allocation is not needed, the user should leave both bs2ds and dynamicSMemSize as 0.
+// MaxActiveClusters returns the maximum number of clusters of the configuration that can run at the same time on the
+// device.
+func (fn Function) MaxActiveClusters(cfg LaunchConfig) (int, error) {
+	if err := cfg.Validate(); err != nil {
+		return 0, err
+	}
+	c := launchConfig(cfg, nil, NoStream)
+	defer freeLaunchConfig(&c)
+	var n C.int
+	if err := result(C.cuOccupancyMaxActiveClusters(&n, fn.fn, &c)); err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
 
-// If per-block dynamic shared memory allocation is needed, then if the dynamic shared memory size is constant regardless of block size, the size should be passed through dynamicSMemSize, and blockSizeToDynamicSMemSize should be NULL.
+// OptimalLaunchConfig returns the one dimensional configuration that achieves the maximum occupancy of the current
+// device, for a function that uses sharedMemPerThread bytes of dynamic shared memory per thread of its blocks. The grid
+// is the smallest that achieves the occupancy: grids larger than it do not run more blocks at once. Blocks are a
+// multiple of the warp size, at most blockSizeLimit threads, or the maximum of the function if it is 0. Of the block
+// sizes with the same occupancy, the largest is chosen.
+func (fn Function) OptimalLaunchConfig(sharedMemPerThread, blockSizeLimit int) (LaunchConfig, error) {
+	maxThreads, err := fn.Attribute(FnMaxThreadsPerBlock)
+	if err != nil {
+		return LaunchConfig{}, err
+	}
+	dev, err := CurrentDevice()
+	if err != nil {
+		return LaunchConfig{}, err
+	}
+	warp, err := dev.Attribute(WarpSize)
+	if err != nil {
+		return LaunchConfig{}, err
+	}
+	sms, err := dev.Attribute(MultiprocessorCount)
+	if err != nil {
+		return LaunchConfig{}, err
+	}
+	if blockSizeLimit <= 0 || blockSizeLimit > maxThreads {
+		blockSizeLimit = maxThreads
+	}
 
-// Otherwise, if the per-block dynamic shared memory size varies with different block sizes, the user needs to provide a unary function through blockSizeToDynamicSMemSize that computes the dynamic shared memory needed by func for any given block size.
-// dynamicSMemSize is ignored. An example signature is:
-// 		// Take block size, returns dynamic shared memory needed
-//          size_t blockToSmem(int blockSize);
-// func (fn Function) MaxPotentialBlockSizeWithFlags(blockSizeToDynamicSMemSize int, dynamicSmemSize int64, blockSizeLimit int, flags OccupancyFlags) (minGridSize, blockSize int, err error) {
-// 	bs2dsm := C.CUoccupancyB2DSize(blockSizeToDynamicSMemSize)
-// 	dss := C.size_t(dynamicSmemSize)
-// 	f := C.CUfunction(fn)
-// 	bsl := C.int(blockSizeLimit)
-// 	of := CUoccupancy_flags(flags)
+	start := blockSizeLimit - blockSizeLimit%warp
+	if start == 0 {
+		start = blockSizeLimit
+	}
+	var best LaunchConfig
+	var bestOccupancy int
+	for bs := start; bs > 0; bs -= warp {
+		smem := bs * sharedMemPerThread
+		blocks, err := fn.MaxActiveBlocksPerMultiProcessor(bs, int64(smem))
+		if err != nil {
+			return LaunchConfig{}, err
+		}
+		if occupancy := blocks * bs; occupancy > bestOccupancy {
+			bestOccupancy = occupancy
+			best = LaunchConfig{Grid: [3]int{blocks * sms, 1, 1}, Block: [3]int{bs, 1, 1}, SharedMem: smem}
+		}
+	}
+	if bestOccupancy == 0 {
+		return LaunchConfig{}, errors.Errorf("No block of up to %d threads fits on the device with %d bytes of shared memory per thread", blockSizeLimit, sharedMemPerThread)
+	}
+	return best, nil
+}
 
-// 	var mgs, bs C.int
-// 	if err = result(C.cuOccupancyMaxPotentialBlockSize(&mgs, &bs, f, bs2dsm, dss, bsl, of)); err != nil {
-// 		return
-// 	}
-// 	minGridSize = int(mgs)
-// 	blockSize = int(bs)
-// 	return
-// }
+// OptimalLaunchConfig returns the configuration that achieves the maximum occupancy of the device of the context (see
+// Function.OptimalLaunchConfig).
+func (ctx *Ctx) OptimalLaunchConfig(fn Function, sharedMemPerThread, blockSizeLimit int) (cfg LaunchConfig, err error) {
+	err = ctx.Do(func() error {
+		var err error
+		cfg, err = fn.OptimalLaunchConfig(sharedMemPerThread, blockSizeLimit)
+		return err
+	})
+	return
+}
@@ -0,0 +1,65 @@
+package cu
+
+import (
+	"io"
+	"log"
+)
+
+// Option configures the objects created by the constructors of this package: NewContext, NewManuallyManagedContext,
+// NewBatchedContext and NewMemPool. Each constructor documents the options it uses; the others are ignored, so that
+// one set of options may be passed to all of them:
+//
+//	opts := []cu.Option{cu.WithLogger(logger), cu.WithBatchSize(256)}
+//	ctx := cu.NewContext(d, cu.SchedAuto, opts...)
+//	bctx := cu.NewBatchedContext(ctx, d, opts...)
+type Option func(o *options)
+
+type options struct {
+	logger    *log.Logger
+	batchSize int
+	budget    int64
+	trace     io.Writer
+	highWater int
+	lanes     [2]Stream
+}
+
+func makeOptions(opts []Option) options {
+	o := options{batchSize: workBufLen}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithLogger sets the logger the debugging messages (see DebugFlags) are written to, instead of the standard logger.
+// It is used by contexts, for the calls made through them, and by pools.
+func WithLogger(l *log.Logger) Option { return func(o *options) { o.logger = l } }
+
+// WithBatchSize sets the number of calls a BatchedContext queues before the queue is processed. It is 64 by default.
+// Values smaller than 2 are ignored.
+func WithBatchSize(n int) Option {
+	return func(o *options) {
+		if n >= 2 {
+			o.batchSize = n
+		}
+	}
+}
+
+// WithTrace makes a BatchedContext write its processed calls to w (see (*BatchedContext).Trace).
+func WithTrace(w io.Writer) Option { return func(o *options) { o.trace = w } }
+
+// WithHighWater sets the number of calls a lane of a BatchedContext may have queued and not yet processed, above which
+// Submit waits for the queue to be processed, and TrySubmit fails. It is 4 times the batch size by default.
+func WithHighWater(calls int) Option { return func(o *options) { o.highWater = calls } }
+
+// WithLanes sets the streams the calls of the bulk and urgent lanes of a BatchedContext are enqueued on (see
+// SubmitPriority). The urgent stream would usually be created with a greater priority (see MakeStreamWithPriority). Both
+// are the null stream by default.
+func WithLanes(bulk, urgent Stream) Option {
+	return func(o *options) { o.lanes = [2]Stream{bulk, urgent} }
+}
+
+// WithBudget sets the number of bytes of device memory a MemPool may hold, or of pinned memory a PinnedPool may hold,
+// in use and cached. When an allocation would exceed it, the cached blocks are freed first; if that is not enough, the
+// allocation fails. A budget of 0, the default, is no limit.
+func WithBudget(bytes int64) Option { return func(o *options) { o.budget = bytes } }
@@ -0,0 +1,108 @@
+package cu
+
+import (
+	"runtime"
+
+	"github.com/pkg/errors"
+)
+
+// PeerLink describes the link from a device to another, over which the first accesses the memory of the second.
+type PeerLink struct {
+	Src, Dst      Device
+	Accessible    bool // whether Src can access the memory of Dst once peer access is enabled
+	Rank          int  // the relative performance of the link, the lower the better (see PerformanceRank)
+	NativeAtomics bool // whether atomics over the link are native
+}
+
+// PeerTopology returns the links between all the pairs of devices.
+func PeerTopology() ([]PeerLink, error) {
+	n, err := NumDevices()
+	if err != nil {
+		return nil, err
+	}
+	var links []PeerLink
+	for src := Device(0); src < Device(n); src++ {
+		for dst := Device(0); dst < Device(n); dst++ {
+			if src == dst {
+				continue
+			}
+			l := PeerLink{Src: src, Dst: dst}
+			can, err := src.CanAccessPeer(dst)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Unable to tell whether device %d can access device %d", src, dst)
+			}
+			if l.Accessible = can != 0; l.Accessible {
+				if l.Rank, err = src.P2PAttribute(PerformanceRank, dst); err != nil {
+					return nil, errors.Wrapf(err, "Unable to get the rank of the link from device %d to device %d", src, dst)
+				}
+				atomics, err := src.P2PAttribute(P2PNativeAomicSupported, dst)
+				if err != nil {
+					return nil, errors.Wrapf(err, "Unable to get the atomics of the link from device %d to device %d", src, dst)
+				}
+				l.NativeAtomics = atomics != 0
+			}
+			links = append(links, l)
+		}
+	}
+	return links, nil
+}
+
+// ContextDevice returns the device of the context.
+func ContextDevice(ctx CUContext) (dev Device, err error) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	if err = PushCurrentCtx(ctx); err != nil {
+		return
+	}
+	dev, err = CurrentDevice()
+	if _, popErr := PopCurrentCtx(); err == nil {
+		err = popErr
+	}
+	return
+}
+
+// EnablePeers enables peer access between all the pairs of contexts whose devices support it, in both directions, so
+// that kernels running in any of them access the memory of the others directly, and copies between them (see
+// MemcpyPeer) go over the link between the devices instead of through host memory. Pairs already enabled are left
+// as they are. It returns the number of directions enabled.
+func EnablePeers(ctxs ...CUContext) (enabled int, err error) {
+	devs := make([]Device, len(ctxs))
+	for i, ctx := range ctxs {
+		if devs[i], err = ContextDevice(ctx); err != nil {
+			return
+		}
+	}
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	for i, ctx := range ctxs {
+		for j, peer := range ctxs {
+			if i == j || devs[i] == devs[j] {
+				continue
+			}
+			var can int
+			if can, err = devs[i].CanAccessPeer(devs[j]); err != nil {
+				return
+			}
+			if can == 0 {
+				continue
+			}
+			if err = PushCurrentCtx(ctx); err != nil {
+				return
+			}
+			err = peer.EnablePeerAccess(0)
+			if _, popErr := PopCurrentCtx(); err == nil {
+				err = popErr
+			}
+			switch err {
+			case nil:
+				enabled++
+			case PeerAccessAlreadyEnabled:
+				err = nil
+			default:
+				return enabled, errors.Wrapf(err, "Unable to enable the access of device %d to device %d", devs[i], devs[j])
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,50 @@
+package cu
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestEnablePeers(t *testing.T) {
+	devices, _ := NumDevices()
+	if devices < 2 {
+		t.Skip("Fewer than two devices")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	links, err := PeerTopology()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(links) != devices*(devices-1) {
+		t.Errorf("Expected %d links. Got %d", devices*(devices-1), len(links))
+	}
+	accessible := 0
+	for _, l := range links {
+		if l.Accessible {
+			accessible++
+		}
+	}
+
+	ctxs := make([]CUContext, 2)
+	for i := range ctxs {
+		if ctxs[i], err = Device(i).MakeContext(SchedAuto); err != nil {
+			t.Fatal(err)
+		}
+		defer ctxs[i].Destroy()
+	}
+	if dev, err := ContextDevice(ctxs[1]); err != nil || dev != Device(1) {
+		t.Errorf("Expected device 1. Got %v, %v", dev, err)
+	}
+	enabled, err := EnablePeers(ctxs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if devices == 2 && enabled != accessible {
+		t.Errorf("Expected %d directions to be enabled. Got %d", accessible, enabled)
+	}
+	if enabled, err = EnablePeers(ctxs...); err != nil || enabled != 0 {
+		t.Errorf("Expected the directions enabled already to be skipped. Got %d, %v", enabled, err)
+	}
+}
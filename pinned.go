@@ -0,0 +1,222 @@
+package cu
+
+// #include <cuda.h>
+import "C"
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// PinnedBuffer is page-locked host memory, viewed as Go slices. Copies from and to pinned memory are truly
+// asynchronous: copies of pageable memory, such as the memory of Go slices, are made synchronously by the driver, even
+// when issued with MemcpyHtoDAsync or MemcpyDtoHAsync.
+//
+// The views are valid until the buffer is freed. The memory is not managed by the Go runtime: a buffer that becomes
+// unreachable without being freed is leaked.
+type PinnedBuffer struct {
+	p    unsafe.Pointer
+	n    int64 // the number of bytes requested
+	size int64 // the number of bytes allocated
+
+	pool       *PinnedPool // if not nil, the pool the buffer is returned to
+	registered []byte      // if not nil, the Go memory that was page-locked by PinSlice
+}
+
+// AllocPinned allocates a pinned buffer of n bytes (see MemHostAlloc).
+func AllocPinned(n int64, flags HostAllocFlags) (*PinnedBuffer, error) {
+	p, err := MemHostAlloc(n, flags)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to allocate %d bytes of pinned memory", n)
+	}
+	return &PinnedBuffer{p: p, n: n, size: n}, nil
+}
+
+// PinSlice page-locks the memory of the slice (see MemHostRegister), so that it can be copied asynchronously. The slice
+// is kept alive by the buffer, and is unlocked when the buffer is freed.
+func PinSlice(b []byte, flags HostRegisterFlags) (*PinnedBuffer, error) {
+	if len(b) == 0 {
+		return nil, errors.New("Cannot pin an empty slice")
+	}
+	p := unsafe.Pointer(&b[0])
+	if err := MemHostRegister(p, int64(len(b)), flags); err != nil {
+		return nil, errors.Wrapf(err, "Unable to pin %d bytes", len(b))
+	}
+	return &PinnedBuffer{p: p, n: int64(len(b)), size: int64(len(b)), registered: b}, nil
+}
+
+// Pointer returns the address of the buffer, to be passed to the copies.
+func (b *PinnedBuffer) Pointer() unsafe.Pointer { return b.p }
+
+// Len returns the size of the buffer in bytes.
+func (b *PinnedBuffer) Len() int64 { return b.n }
+
+// Bytes returns a view of the buffer.
+func (b *PinnedBuffer) Bytes() []byte {
+	if b.registered != nil {
+		return b.registered
+	}
+	return (*[1 << 40]byte)(b.p)[:b.n:b.n]
+}
+
+// Float32s returns a view of the buffer as float32s. Trailing bytes that do not make up a whole element are left out.
+func (b *PinnedBuffer) Float32s() []float32 {
+	n := b.n / 4
+	return (*[1 << 38]float32)(b.p)[:n:n]
+}
+
+// Float64s returns a view of the buffer as float64s. Trailing bytes that do not make up a whole element are left out.
+func (b *PinnedBuffer) Float64s() []float64 {
+	n := b.n / 8
+	return (*[1 << 37]float64)(b.p)[:n:n]
+}
+
+// Int32s returns a view of the buffer as int32s. Trailing bytes that do not make up a whole element are left out.
+func (b *PinnedBuffer) Int32s() []int32 {
+	n := b.n / 4
+	return (*[1 << 38]int32)(b.p)[:n:n]
+}
+
+// Int64s returns a view of the buffer as int64s. Trailing bytes that do not make up a whole element are left out.
+func (b *PinnedBuffer) Int64s() []int64 {
+	n := b.n / 8
+	return (*[1 << 37]int64)(b.p)[:n:n]
+}
+
+// CopyToDevice copies the buffer to the device memory at dst, asynchronously: the buffer must not be modified or freed
+// until the copy is complete.
+func (b *PinnedBuffer) CopyToDevice(dst DevicePtr, stream Stream) error {
+	return MemcpyHtoDAsync(dst, b.p, b.n, stream)
+}
+
+// CopyFromDevice copies the device memory at src to the buffer, asynchronously: the buffer must not be read or freed
+// until the copy is complete.
+func (b *PinnedBuffer) CopyFromDevice(src DevicePtr, stream Stream) error {
+	return MemcpyDtoHAsync(b.p, src, b.n, stream)
+}
+
+// Free frees the buffer, or returns it to its pool. The views of the buffer must not be used afterwards.
+func (b *PinnedBuffer) Free() error {
+	if b.p == nil {
+		return errors.New("The pinned buffer was already freed")
+	}
+	var err error
+	switch {
+	case b.pool != nil:
+		b.pool.put(b.p, b.size)
+	case b.registered != nil:
+		err = MemHostUnregister(b.p)
+	default:
+		err = MemFreeHost(b.p)
+	}
+	b.p, b.registered = nil, nil
+	return err
+}
+
+// pinnedMin is the size of the smallest buffer of a PinnedPool. Buffers are sized in powers of two from there, so that
+// buffers are reused by requests of similar sizes.
+const pinnedMin = 4096
+
+// PinnedPool keeps freed pinned buffers for reuse, as pinning memory is much more expensive than allocating pageable
+// memory. A budget on the pinned memory the pool holds may be set with WithBudget; the other options are ignored.
+//
+// A PinnedPool is safe for concurrent use.
+type PinnedPool struct {
+	flags  HostAllocFlags
+	budget int64
+
+	sync.Mutex
+	free     map[int64][]unsafe.Pointer // by size
+	held     int64                      // the bytes allocated by the pool: in use and free
+	freeSize int64                      // the bytes of the free buffers
+}
+
+// NewPinnedPool creates a pool of pinned buffers allocated with the flags. Use HostAllocPortable for buffers shared
+// by several contexts.
+func NewPinnedPool(flags HostAllocFlags, opts ...Option) *PinnedPool {
+	o := makeOptions(opts)
+	return &PinnedPool{
+		flags:  flags,
+		budget: o.budget,
+		free:   make(map[int64][]unsafe.Pointer),
+	}
+}
+
+func pinnedSize(n int64) int64 {
+	size := int64(pinnedMin)
+	for size < n {
+		size <<= 1
+	}
+	return size
+}
+
+// Get returns a pinned buffer of n bytes, reusing a freed buffer of the pool if there is one. The buffer is returned to
+// the pool by Free.
+func (p *PinnedPool) Get(n int64) (*PinnedBuffer, error) {
+	if n <= 0 {
+		return nil, errors.Errorf("Cannot allocate %d bytes of pinned memory", n)
+	}
+	size := pinnedSize(n)
+	p.Lock()
+	defer p.Unlock()
+	if l := p.free[size]; len(l) > 0 {
+		ptr := l[len(l)-1]
+		p.free[size] = l[:len(l)-1]
+		p.freeSize -= size
+		return &PinnedBuffer{p: ptr, n: n, size: size, pool: p}, nil
+	}
+
+	if p.budget > 0 && p.held+size > p.budget {
+		if err := p.release(); err != nil {
+			return nil, err
+		}
+		if p.held+size > p.budget {
+			return nil, errors.Errorf("PinnedPool cannot allocate %d bytes: %d of its budget of %d bytes are in use", size, p.held, p.budget)
+		}
+	}
+	ptr, err := MemHostAlloc(size, p.flags)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to allocate %d bytes of pinned memory", size)
+	}
+	p.held += size
+	return &PinnedBuffer{p: ptr, n: n, size: size, pool: p}, nil
+}
+
+func (p *PinnedPool) put(ptr unsafe.Pointer, size int64) {
+	p.Lock()
+	p.free[size] = append(p.free[size], ptr)
+	p.freeSize += size
+	p.Unlock()
+}
+
+// Held returns the number of bytes of pinned memory the pool holds, and how many of them are free.
+func (p *PinnedPool) Held() (held, free int64) {
+	p.Lock()
+	defer p.Unlock()
+	return p.held, p.freeSize
+}
+
+// Release frees the free buffers of the pool. The buffers in use are kept.
+func (p *PinnedPool) Release() error {
+	p.Lock()
+	defer p.Unlock()
+	return p.release()
+}
+
+// release frees the free buffers. The lock is expected to be held.
+func (p *PinnedPool) release() error {
+	for size, l := range p.free {
+		for len(l) > 0 {
+			if err := result(C.cuMemFreeHost(l[len(l)-1])); err != nil {
+				p.free[size] = l
+				return errors.Wrap(err, "Unable to free a pinned buffer")
+			}
+			l = l[:len(l)-1]
+			p.held -= size
+			p.freeSize -= size
+		}
+		delete(p.free, size)
+	}
+	return nil
+}
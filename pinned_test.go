@@ -0,0 +1,75 @@
+package cu
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestPinnedPool(t *testing.T) {
+	devices, _ := NumDevices()
+	if devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := Device(0).MakeContext(SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+
+	pool := NewPinnedPool(HostAllocPortable, WithBudget(3*pinnedMin))
+	defer pool.Release()
+	a, err := pool.Get(1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Float32s()) != 250 || len(a.Bytes()) != 1000 {
+		t.Errorf("Unexpected views of %d floats and %d bytes", len(a.Float32s()), len(a.Bytes()))
+	}
+	ptr := a.Pointer()
+	if err = a.Free(); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := pool.Get(2000); err != nil || b.Pointer() != ptr {
+		t.Errorf("Expected the freed buffer to be reused. Got %v, %v", b, err)
+	} else {
+		b.Free()
+	}
+	if _, err = pool.Get(4 * pinnedMin); err == nil {
+		t.Errorf("Expected the budget to be exceeded")
+	}
+
+	mem, err := MemAlloc(1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer MemFree(mem)
+	host := make([]byte, 1000)
+	for i := range host {
+		host[i] = byte(i)
+	}
+	pinned, err := PinSlice(host, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, _ := AllocPinned(1000, 0)
+	if err = pinned.CopyToDevice(mem, NoStream); err != nil {
+		t.Fatal(err)
+	}
+	if err = out.CopyFromDevice(mem, NoStream); err != nil {
+		t.Fatal(err)
+	}
+	if err = Synchronize(); err != nil {
+		t.Fatal(err)
+	}
+	if out.Bytes()[999] != host[999] {
+		t.Errorf("Expected the bytes to be copied back")
+	}
+	if err = pinned.Free(); err != nil {
+		t.Error(err)
+	}
+	if err = out.Free(); err != nil {
+		t.Error(err)
+	}
+}
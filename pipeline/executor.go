@@ -0,0 +1,255 @@
+package pipeline
+
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+	"gorgonia.org/cu/kernels"
+)
+
+// Executor runs a pipeline. It owns the buffers and the streams of the pipeline, and the graph the steps are captured
+// in, if any.
+//
+// The functions of an Executor must be called from a thread that has a current context, as those of package kernels,
+// and are not safe for concurrent use.
+type Executor struct {
+	spec *Spec
+	plan *plan
+
+	buffers map[string]cu.DevicePtr
+	streams []cu.Stream
+	fork    cu.Event
+	events  []cu.Event // recorded after each step
+	joins   []cu.Event // recorded at the end of each stream but the first
+	fns     []cu.Function
+	params  [][]unsafe.Pointer
+
+	graph cu.Graph
+	exec  cu.GraphExec
+}
+
+// New validates the spec against the registry, then allocates the buffers and the streams of the pipeline, and loads
+// its kernels from the library (kernels.Default if nil). If the spec asks for a graph, the steps are captured.
+func New(spec *Spec, reg *Registry, lib *kernels.Library) (e *Executor, err error) {
+	if reg == nil {
+		reg = DefaultRegistry
+	}
+	if lib == nil {
+		lib = kernels.Default
+	}
+	p, err := spec.validate(reg)
+	if err != nil {
+		return nil, err
+	}
+	e = &Executor{
+		spec:    spec,
+		plan:    p,
+		buffers: make(map[string]cu.DevicePtr),
+		events:  make([]cu.Event, len(spec.Steps)),
+		fns:     make([]cu.Function, len(spec.Steps)),
+		params:  make([][]unsafe.Pointer, len(spec.Steps)),
+	}
+	defer func() {
+		if err != nil {
+			e.Close()
+			e = nil
+		}
+	}()
+
+	for _, b := range spec.Buffers {
+		size := int64(b.Len) * b.Dtype.Size()
+		var mem cu.DevicePtr
+		if mem, err = cu.MemAlloc(size); err != nil {
+			return nil, errors.Wrapf(err, "Unable to allocate buffer %q", b.Name)
+		}
+		e.buffers[b.Name] = mem
+		if b.Zero {
+			if err = cu.MemsetD8(mem, 0, size); err != nil {
+				return nil, errors.Wrapf(err, "Unable to zero buffer %q", b.Name)
+			}
+		}
+	}
+	for i := 0; i < p.nStream; i++ {
+		var s cu.Stream
+		if s, err = cu.MakeStream(cu.NonBlocking); err != nil {
+			return nil, errors.Wrap(err, "Unable to create a stream")
+		}
+		e.streams = append(e.streams, s)
+		if i > 0 {
+			var ev cu.Event
+			if ev, err = cu.MakeEvent(cu.DisableTiming); err != nil {
+				return nil, errors.Wrap(err, "Unable to create an event")
+			}
+			e.joins = append(e.joins, ev)
+		}
+	}
+	if e.fork, err = cu.MakeEvent(cu.DisableTiming); err != nil {
+		return nil, errors.Wrap(err, "Unable to create an event")
+	}
+
+	for i, st := range spec.Steps {
+		src, _ := reg.Lookup(st.Source)
+		if e.fns[i], err = lib.Function(src, st.Dtype, st.Kernel); err != nil {
+			return nil, errors.Wrapf(err, "Step %q", st.Name)
+		}
+		if e.params[i], err = kernels.Args(e.args(st)...); err != nil {
+			return nil, errors.Wrapf(err, "Step %q", st.Name)
+		}
+		if e.events[i], err = cu.MakeEvent(cu.DisableTiming); err != nil {
+			return nil, errors.Wrap(err, "Unable to create an event")
+		}
+	}
+
+	if spec.Graph {
+		if err = e.capture(); err != nil {
+			return nil, errors.Wrap(err, "Unable to capture the pipeline")
+		}
+	}
+	return e, nil
+}
+
+// args returns the values of the arguments of a step.
+func (e *Executor) args(st StepSpec) []interface{} {
+	retVal := make([]interface{}, len(st.Args))
+	for i, a := range st.Args {
+		switch {
+		case a.Buffer != "":
+			retVal[i] = e.buffers[a.Buffer]
+		case a.Len != "":
+			retVal[i] = e.plan.buffers[a.Len].Len
+		case a.Int != nil:
+			retVal[i] = *a.Int
+		case a.Float != nil:
+			retVal[i] = *a.Float
+		}
+	}
+	return retVal
+}
+
+// Buffer returns the named buffer, to fill the inputs of the pipeline or read its outputs.
+func (e *Executor) Buffer(name string) (cu.DeviceTensor, error) {
+	mem, ok := e.buffers[name]
+	if !ok {
+		return cu.DeviceTensor{}, errors.Errorf("Unknown buffer %q", name)
+	}
+	b := e.plan.buffers[name]
+	return cu.NewDeviceTensor(mem, b.Dtype, b.Len), nil
+}
+
+// Stream returns the stream the runs are ordered on: work enqueued on it before a run happens before the run, and
+// work enqueued after a run happens after the run.
+func (e *Executor) Stream() cu.Stream { return e.streams[0] }
+
+// Run enqueues a run of the pipeline. It does not wait for the run to be done (see Wait).
+func (e *Executor) Run() error {
+	if e.spec.Graph {
+		return e.exec.Launch(e.streams[0])
+	}
+	return e.enqueue()
+}
+
+// Wait waits for the runs enqueued to be done.
+func (e *Executor) Wait() error { return e.streams[0].Synchronize() }
+
+// enqueue enqueues the steps. The other streams fork from the first one, and join it at the end, so that a run is
+// ordered on the first stream, and can be captured from it.
+func (e *Executor) enqueue() error {
+	first := e.streams[0]
+	if len(e.streams) > 1 {
+		if err := e.fork.Record(first); err != nil {
+			return err
+		}
+		for _, s := range e.streams[1:] {
+			if err := s.Wait(e.fork, 0); err != nil {
+				return err
+			}
+		}
+	}
+	for _, i := range e.plan.order {
+		st := e.spec.Steps[i]
+		stream := e.streams[e.plan.streams[i]]
+		for _, d := range e.plan.deps[i] {
+			if e.plan.streams[d] == e.plan.streams[i] {
+				continue
+			}
+			if err := stream.Wait(e.events[d], 0); err != nil {
+				return err
+			}
+		}
+		var err error
+		if st.Threads > 0 {
+			err = e.fns[i].Launch(kernels.GridSize(st.Threads), 1, 1, kernels.BlockSize, 1, 1, st.SharedMem, stream, e.params[i])
+		} else {
+			err = e.fns[i].Launch(st.Grid[0], st.Grid[1], st.Grid[2], st.Block[0], st.Block[1], st.Block[2], st.SharedMem, stream, e.params[i])
+		}
+		if err != nil {
+			return errors.Wrapf(err, "Unable to launch step %q", st.Name)
+		}
+		if err = e.events[i].Record(stream); err != nil {
+			return err
+		}
+	}
+	for i, s := range e.streams[1:] {
+		if err := e.joins[i].Record(s); err != nil {
+			return err
+		}
+		if err := first.Wait(e.joins[i], 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// capture captures the steps into a graph, and instantiates it.
+func (e *Executor) capture() (err error) {
+	if err = e.streams[0].BeginCapture(cu.ThreadLocalCapture); err != nil {
+		return err
+	}
+	enqueued := e.enqueue()
+	if e.graph, err = e.streams[0].EndCapture(); err != nil {
+		return err
+	}
+	if enqueued != nil {
+		return enqueued
+	}
+	e.exec, err = e.graph.Instantiate()
+	return err
+}
+
+// Close waits for the runs enqueued, and frees the resources of the pipeline.
+func (e *Executor) Close() error {
+	var retVal error
+	keep := func(err error) {
+		if err != nil && retVal == nil {
+			retVal = err
+		}
+	}
+	if len(e.streams) > 0 {
+		keep(e.Wait())
+	}
+	if e.exec != (cu.GraphExec{}) {
+		keep(e.exec.Destroy())
+		e.exec = cu.GraphExec{}
+	}
+	if e.graph != (cu.Graph{}) {
+		keep(e.graph.Destroy())
+		e.graph = cu.Graph{}
+	}
+	for _, evs := range [][]cu.Event{e.events, e.joins, {e.fork}} {
+		for i := range evs {
+			if evs[i] != (cu.Event{}) {
+				keep(cu.DestroyEvent(&evs[i]))
+			}
+		}
+	}
+	for i := range e.streams {
+		keep(e.streams[i].Destroy())
+	}
+	e.streams = nil
+	for name, mem := range e.buffers {
+		keep(cu.MemFree(mem))
+		delete(e.buffers, name)
+	}
+	return retVal
+}
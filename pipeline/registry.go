@@ -0,0 +1,46 @@
+package pipeline
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu/kernels"
+)
+
+// Registry holds the kernel sources the steps of pipelines refer to, by name. A Registry is safe for concurrent use.
+type Registry struct {
+	sync.RWMutex
+	srcs map[string]*kernels.Source
+}
+
+// DefaultRegistry holds the sources of package kernels. Programs register their own sources in it, for the pipelines
+// they run.
+var DefaultRegistry = NewRegistry(kernels.Sources()...)
+
+// NewRegistry creates a Registry of the given sources.
+func NewRegistry(srcs ...*kernels.Source) *Registry {
+	r := &Registry{srcs: make(map[string]*kernels.Source)}
+	for _, src := range srcs {
+		r.srcs[src.Name] = src
+	}
+	return r
+}
+
+// Register adds a source to the registry. Its name must not be taken.
+func (r *Registry) Register(src *kernels.Source) error {
+	r.Lock()
+	defer r.Unlock()
+	if _, ok := r.srcs[src.Name]; ok {
+		return errors.Errorf("A source named %q is already registered", src.Name)
+	}
+	r.srcs[src.Name] = src
+	return nil
+}
+
+// Lookup returns the source of the given name.
+func (r *Registry) Lookup(name string) (*kernels.Source, bool) {
+	r.RLock()
+	defer r.RUnlock()
+	src, ok := r.srcs[name]
+	return src, ok
+}
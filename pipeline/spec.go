@@ -0,0 +1,239 @@
+// Package pipeline runs GPU pipelines described declaratively, so that a pipeline can be changed without recompiling
+// the program that runs it.
+//
+// A pipeline is a set of device buffers, and of steps that launch kernels on them. The kernels are looked up by name in
+// a Registry of kernel sources, which holds the sources of package kernels and the sources registered by the program.
+// The steps run in the order of their dependencies, spread over several streams, and may be captured into a graph
+// once, so that each run is a single launch:
+//
+//	{
+//		"streams": 2,
+//		"graph": true,
+//		"buffers": [
+//			{"name": "x", "dtype": "float32", "len": 1024},
+//			{"name": "y", "dtype": "float32", "len": 1024, "zero": true}
+//		],
+//		"steps": [
+//			{"name": "scale", "source": "mine", "kernel": "scale", "dtype": "float32", "threads": 1024,
+//			 "args": [{"buffer": "y"}, {"buffer": "x"}, {"len": "x"}, {"float": 2}]},
+//			{"name": "shift", "source": "mine", "kernel": "shift", "dtype": "float32", "threads": 1024,
+//			 "args": [{"buffer": "y"}, {"len": "y"}, {"float": 1}], "after": ["scale"]}
+//		]
+//	}
+//
+// Scalar arguments are passed as kernels.Launch passes them: as 8 byte long long and double.
+package pipeline
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// Spec describes a pipeline.
+type Spec struct {
+	Buffers []BufferSpec `json:"buffers"`
+	Steps   []StepSpec   `json:"steps"`
+	Streams int          `json:"streams,omitempty"` // the number of streams the steps are spread over, 1 by default
+	Graph   bool         `json:"graph,omitempty"`   // whether the steps are captured into a graph, launched by each run
+}
+
+// BufferSpec describes a buffer of device memory.
+type BufferSpec struct {
+	Name  string   `json:"name"`
+	Dtype cu.Dtype `json:"dtype"`
+	Len   int      `json:"len"`            // the number of elements
+	Zero  bool     `json:"zero,omitempty"` // whether the buffer is zeroed once allocated
+}
+
+// StepSpec describes the launch of a kernel. The launch is either configured by Threads, with the block size of
+// package kernels, for kernels with grid-stride loops, or by Grid and Block.
+type StepSpec struct {
+	Name   string   `json:"name"`
+	Source string   `json:"source"` // the name of the source of the kernel in the Registry
+	Kernel string   `json:"kernel"` // the name of the kernel in the source
+	Dtype  cu.Dtype `json:"dtype"`  // the element type the source is compiled for
+
+	Threads   int    `json:"threads,omitempty"`
+	Grid      [3]int `json:"grid,omitempty"`
+	Block     [3]int `json:"block,omitempty"`
+	SharedMem int    `json:"shared_mem,omitempty"`
+
+	Args  []Arg    `json:"args"`
+	After []string `json:"after,omitempty"` // the steps that must be done before this one
+}
+
+// Arg is an argument of a kernel. Exactly one of its fields is set.
+type Arg struct {
+	Buffer string   `json:"buffer,omitempty"` // the pointer to a buffer
+	Len    string   `json:"len,omitempty"`    // the number of elements of a buffer
+	Int    *int64   `json:"int,omitempty"`
+	Float  *float64 `json:"float,omitempty"`
+}
+
+// Parse decodes a Spec from JSON. Unknown fields are errors, so that misspelt fields are not silently ignored.
+func Parse(r io.Reader) (*Spec, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	s := new(Spec)
+	if err := dec.Decode(s); err != nil {
+		return nil, errors.Wrap(err, "Unable to decode the pipeline")
+	}
+	return s, nil
+}
+
+// plan is a validated Spec: the steps in an order that satisfies their dependencies, and their streams.
+type plan struct {
+	buffers map[string]BufferSpec
+	order   []int // indices of the steps
+	streams []int // the stream of each step
+	deps    [][]int
+	nStream int
+}
+
+// validate checks the spec against the registry, and plans it.
+func (s *Spec) validate(reg *Registry) (*plan, error) {
+	p := &plan{buffers: make(map[string]BufferSpec), nStream: s.Streams}
+	if p.nStream <= 0 {
+		p.nStream = 1
+	}
+	for _, b := range s.Buffers {
+		if _, ok := p.buffers[b.Name]; ok || b.Name == "" {
+			return nil, errors.Errorf("Invalid or duplicate buffer name %q", b.Name)
+		}
+		if b.Dtype.Size() == 0 || b.Len <= 0 {
+			return nil, errors.Errorf("Buffer %q: invalid %d elements of %v", b.Name, b.Len, b.Dtype)
+		}
+		p.buffers[b.Name] = b
+	}
+
+	steps := make(map[string]int)
+	for i, st := range s.Steps {
+		if _, ok := steps[st.Name]; ok || st.Name == "" {
+			return nil, errors.Errorf("Invalid or duplicate step name %q", st.Name)
+		}
+		steps[st.Name] = i
+	}
+	p.deps = make([][]int, len(s.Steps))
+	for i, st := range s.Steps {
+		if err := p.checkStep(st, reg); err != nil {
+			return nil, errors.Wrapf(err, "Step %q", st.Name)
+		}
+		for _, d := range st.After {
+			j, ok := steps[d]
+			if !ok {
+				return nil, errors.Errorf("Step %q: unknown step %q", st.Name, d)
+			}
+			p.deps[i] = append(p.deps[i], j)
+		}
+	}
+	if err := p.sort(s.Steps); err != nil {
+		return nil, err
+	}
+	p.assignStreams()
+	return p, nil
+}
+
+func (p *plan) checkStep(st StepSpec, reg *Registry) error {
+	src, ok := reg.Lookup(st.Source)
+	if !ok {
+		return errors.Errorf("unknown source %q", st.Source)
+	}
+	supported := false
+	for _, dt := range src.Dtypes {
+		supported = supported || dt == st.Dtype
+	}
+	if !supported {
+		return errors.Errorf("%v is not supported by the %v kernels", st.Dtype, src.Name)
+	}
+	if st.Kernel == "" {
+		return errors.New("no kernel")
+	}
+	explicit := st.Grid != [3]int{} || st.Block != [3]int{}
+	if (st.Threads > 0) == explicit {
+		return errors.New("expected either threads, or grid and block")
+	}
+	if explicit {
+		for i := range st.Grid {
+			if st.Grid[i] <= 0 || st.Block[i] <= 0 {
+				return errors.Errorf("invalid grid %v and block %v", st.Grid, st.Block)
+			}
+		}
+	}
+	for i, a := range st.Args {
+		set := 0
+		for _, ok := range []bool{a.Buffer != "", a.Len != "", a.Int != nil, a.Float != nil} {
+			if ok {
+				set++
+			}
+		}
+		if set != 1 {
+			return errors.Errorf("argument %d: expected exactly one of buffer, len, int and float", i)
+		}
+		for _, name := range []string{a.Buffer, a.Len} {
+			if _, ok := p.buffers[name]; name != "" && !ok {
+				return errors.Errorf("argument %d: unknown buffer %q", i, name)
+			}
+		}
+	}
+	return nil
+}
+
+// sort orders the steps after their dependencies, keeping the order of the spec where it is free.
+func (p *plan) sort(steps []StepSpec) error {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(steps))
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visiting:
+			return errors.Errorf("Step %q depends on itself", steps[i].Name)
+		case done:
+			return nil
+		}
+		state[i] = visiting
+		for _, d := range p.deps[i] {
+			if err := visit(d); err != nil {
+				return err
+			}
+		}
+		state[i] = done
+		p.order = append(p.order, i)
+		return nil
+	}
+	for i := range steps {
+		if err := visit(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// assignStreams puts each step on the stream of its first dependency, if no other step continued that stream yet, so
+// that chains of steps run on one stream, and spreads the other steps over the streams in turn.
+func (p *plan) assignStreams() {
+	p.streams = make([]int, len(p.deps))
+	last := make([]int, p.nStream) // the last step on each stream, +1
+	next := 0
+	for _, i := range p.order {
+		s := -1
+		for _, d := range p.deps[i] {
+			if last[p.streams[d]] == d+1 {
+				s = p.streams[d]
+				break
+			}
+		}
+		if s < 0 {
+			s = next
+			next = (next + 1) % p.nStream
+		}
+		p.streams[i] = s
+		last[s] = i + 1
+	}
+}
@@ -0,0 +1,142 @@
+package pipeline
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"gorgonia.org/cu"
+	"gorgonia.org/cu/kernels"
+)
+
+var testSource = &kernels.Source{
+	Name:   "pipeline_test",
+	Dtypes: []cu.Dtype{cu.DtFloat32},
+	Code: `
+extern "C" __global__ void scale(T* y, const T* x, long long n, double a) {
+	GRID_STRIDE(i, n) {
+		st(y, i, a * ld(x, i));
+	}
+}
+
+extern "C" __global__ void shift(T* y, long long n, double b) {
+	GRID_STRIDE(i, n) {
+		st(y, i, ld(y, i) + b);
+	}
+}
+`,
+}
+
+const testSpec = `{
+	"streams": 2,
+	"buffers": [
+		{"name": "x", "dtype": "float32", "len": 1000},
+		{"name": "y", "dtype": "float32", "len": 1000, "zero": true},
+		{"name": "z", "dtype": "float32", "len": 1000}
+	],
+	"steps": [
+		{"name": "shift", "source": "pipeline_test", "kernel": "shift", "dtype": "float32", "threads": 1000,
+		 "args": [{"buffer": "y"}, {"len": "y"}, {"float": 1}], "after": ["scale"]},
+		{"name": "scale", "source": "pipeline_test", "kernel": "scale", "dtype": "float32", "threads": 1000,
+		 "args": [{"buffer": "y"}, {"buffer": "x"}, {"len": "x"}, {"float": 2}]},
+		{"name": "copy", "source": "pipeline_test", "kernel": "scale", "dtype": "float32", "grid": [4, 1, 1], "block": [256, 1, 1],
+		 "args": [{"buffer": "z"}, {"buffer": "x"}, {"len": "x"}, {"float": 1}]}
+	]
+}`
+
+func TestSpec_Validate(t *testing.T) {
+	reg := NewRegistry(testSource)
+	spec, err := Parse(strings.NewReader(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := spec.validate(reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 0, 2}; len(p.order) != 3 || p.order[0] != want[0] || p.order[1] != want[1] || p.order[2] != want[2] {
+		t.Errorf("Expected the order %v. Got %v", want, p.order)
+	}
+	// shift continues the stream of scale, and copy runs on the other stream
+	if p.streams[0] != p.streams[1] || p.streams[2] == p.streams[1] {
+		t.Errorf("Unexpected streams %v", p.streams)
+	}
+
+	bad := []struct {
+		name string
+		edit func(s *Spec)
+	}{
+		{"cycle", func(s *Spec) { s.Steps[1].After = []string{"shift"} }},
+		{"unknown step", func(s *Spec) { s.Steps[0].After = []string{"nope"} }},
+		{"unknown source", func(s *Spec) { s.Steps[0].Source = "nope" }},
+		{"unsupported dtype", func(s *Spec) { s.Steps[0].Dtype = cu.DtFloat64 }},
+		{"unknown buffer", func(s *Spec) { s.Steps[0].Args[0].Buffer = "nope" }},
+		{"ambiguous arg", func(s *Spec) { s.Steps[0].Args[0].Len = "y" }},
+		{"launch config", func(s *Spec) { s.Steps[2].Threads = 1000 }},
+		{"duplicate buffer", func(s *Spec) { s.Buffers[1].Name = "x" }},
+	}
+	for _, c := range bad {
+		spec, _ := Parse(strings.NewReader(testSpec))
+		c.edit(spec)
+		if _, err := spec.validate(reg); err == nil {
+			t.Errorf("%s: expected an error", c.name)
+		}
+	}
+
+	if _, err := Parse(strings.NewReader(`{"stages": []}`)); err == nil {
+		t.Error("Expected an error for an unknown field")
+	}
+}
+
+func TestExecutor(t *testing.T) {
+	if devices, _ := cu.NumDevices(); devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := cu.Device(0).MakeContext(cu.SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+
+	reg := NewRegistry(testSource)
+	for _, graph := range []bool{false, true} {
+		spec, err := Parse(strings.NewReader(testSpec))
+		if err != nil {
+			t.Fatal(err)
+		}
+		spec.Graph = graph
+		e, err := New(spec, reg, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		x := make([]float32, 1000)
+		for i := range x {
+			x[i] = float32(i)
+		}
+		xs, _ := e.Buffer("x")
+		if err = cu.MemcpyHtoD(xs.Ptr, unsafe.Pointer(&x[0]), 4000); err != nil {
+			t.Fatal(err)
+		}
+		if err = e.Run(); err != nil {
+			t.Fatal(err)
+		}
+		if err = e.Wait(); err != nil {
+			t.Fatal(err)
+		}
+		y := make([]float32, 1000)
+		ys, _ := e.Buffer("y")
+		if err = cu.MemcpyDtoH(unsafe.Pointer(&y[0]), ys.Ptr, 4000); err != nil {
+			t.Fatal(err)
+		}
+		for i, v := range y {
+			if v != 2*float32(i)+1 {
+				t.Errorf("graph %v: expected y[%d] = %v. Got %v", graph, i, 2*float32(i)+1, v)
+				break
+			}
+		}
+		e.Close()
+	}
+}
@@ -0,0 +1,115 @@
+package cu
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// WarmupFunc is a function run by Preinitialize for each device, with the primary context of the device current.
+// Packages that load modules register one with RegisterWarmup, so that their modules are JIT compiled ahead of use.
+type WarmupFunc func(dev Device) error
+
+var warmups struct {
+	sync.Mutex
+	fns []WarmupFunc
+}
+
+// RegisterWarmup registers a function to be run by Preinitialize. For example, to compile and load kernels:
+//
+//	cu.RegisterWarmup(func(cu.Device) error { return kernels.Preload(cu.DtFloat32) })
+func RegisterWarmup(fn WarmupFunc) {
+	warmups.Lock()
+	warmups.fns = append(warmups.fns, fn)
+	warmups.Unlock()
+}
+
+// Preinit is the result of Preinitialize.
+type Preinit struct {
+	done chan struct{}
+	err  error
+
+	mu       sync.Mutex
+	retained []Device
+}
+
+// Preinitialize initializes the given devices (or all the devices, if none are given) on a background goroutine:
+// it retains the primary context of each device, and runs the registered warmup functions with it current.
+//
+// Creating a context and JIT compiling modules take hundreds of milliseconds. Calling Preinitialize at the start of
+// a server moves that cost out of the path of the first requests. Contexts created later on the devices with
+// RetainPrimaryCtx share the primary contexts retained here, which stay alive until Release is called.
+func Preinitialize(devices ...Device) *Preinit {
+	p := &Preinit{done: make(chan struct{})}
+	go func() {
+		defer close(p.done)
+		p.err = p.run(devices)
+	}()
+	return p
+}
+
+// Done returns a channel that is closed once the preinitialization is over.
+func (p *Preinit) Done() <-chan struct{} { return p.done }
+
+// Wait waits for the preinitialization to be over, and returns the first error that occurred, if any.
+func (p *Preinit) Wait() error {
+	<-p.done
+	return p.err
+}
+
+// Release waits for the preinitialization to be over, and releases the primary contexts it retained.
+func (p *Preinit) Release() error {
+	<-p.done
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var err error
+	for _, dev := range p.retained {
+		if e := dev.ReleasePrimaryCtx(); e != nil && err == nil {
+			err = errors.Wrapf(e, "Unable to release the primary context of %v", dev)
+		}
+	}
+	p.retained = nil
+	return err
+}
+
+func (p *Preinit) run(devices []Device) error {
+	if initErr != nil {
+		return initErr
+	}
+	if len(devices) == 0 {
+		n, err := NumDevices()
+		if err != nil {
+			return errors.Wrap(err, "Unable to count the devices")
+		}
+		for i := 0; i < n; i++ {
+			devices = append(devices, Device(i))
+		}
+	}
+
+	warmups.Lock()
+	fns := append([]WarmupFunc(nil), warmups.fns...)
+	warmups.Unlock()
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	defer SetCurrentContext(CUContext{})
+	for _, dev := range devices {
+		ctx, err := dev.RetainPrimaryCtx()
+		if err != nil {
+			return errors.Wrapf(err, "Unable to retain the primary context of %v", dev)
+		}
+		p.mu.Lock()
+		p.retained = append(p.retained, dev)
+		p.mu.Unlock()
+		if err = SetCurrentContext(ctx); err != nil {
+			return errors.Wrapf(err, "Unable to set the primary context of %v", dev)
+		}
+		for _, fn := range fns {
+			if err = fn(dev); err != nil {
+				return errors.Wrapf(err, "Warmup of %v failed", dev)
+			}
+		}
+	}
+	return nil
+}
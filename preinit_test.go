@@ -0,0 +1,30 @@
+package cu
+
+import "testing"
+
+func TestPreinitialize(t *testing.T) {
+	devices, _ := NumDevices()
+	if devices == 0 {
+		t.Skip("NoDevice")
+	}
+	var warmed []Device
+	RegisterWarmup(func(dev Device) error {
+		if _, err := CurrentContext(); err != nil {
+			return err
+		}
+		warmed = append(warmed, dev)
+		return nil
+	})
+	defer func() { warmups.fns = nil }()
+
+	p := Preinitialize(Device(0))
+	if err := p.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if len(warmed) != 1 || warmed[0] != Device(0) {
+		t.Errorf("Expected the warmup to run for device 0. Got %v", warmed)
+	}
+	if err := p.Release(); err != nil {
+		t.Error(err)
+	}
+}
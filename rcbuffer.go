@@ -0,0 +1,101 @@
+package cu
+
+import (
+	"fmt"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// RcBuffer is a reference counted device buffer. It suits pipelines that pass buffers between stages running in
+// different goroutines, where no single stage knows when the buffer is no longer used.
+//
+// Each *RcBuffer is one reference: Clone returns a new reference to the same memory, to hand to another goroutine, and
+// every reference is released once with Release. The memory is freed by the last Release, whichever goroutine makes
+// it. Clone and Release are safe for concurrent use.
+//
+// The memory is freed on the thread of the last Release: the context of the buffer must be current there, unless it
+// was allocated from a MemPool used with Ctx.Do, or similar.
+type RcBuffer struct {
+	ptr      DevicePtr
+	size     int64
+	ref      *memRef
+	released uint32 // atomic
+}
+
+// NewRcBuffer allocates a buffer of size bytes with MemAlloc. The caller holds the only reference.
+func NewRcBuffer(size int64) (*RcBuffer, error) {
+	ptr, err := MemAlloc(size)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to allocate an RcBuffer of %d bytes", size)
+	}
+	return &RcBuffer{ptr: ptr, size: size, ref: &memRef{base: ptr, free: MemFree, refs: 1}}, nil
+}
+
+// AllocRc allocates a buffer of size bytes from the pool. The memory is returned to the pool by the last Release.
+func (p *MemPool) AllocRc(size int64) (*RcBuffer, error) {
+	ptr, err := p.Alloc(size)
+	if err != nil {
+		return nil, err
+	}
+	return &RcBuffer{ptr: ptr, size: size, ref: &memRef{base: ptr, free: p.Free, refs: 1}}, nil
+}
+
+// Ptr returns the start of the buffer.
+func (b *RcBuffer) Ptr() DevicePtr { return b.ptr }
+
+// Len returns the size of the buffer, in bytes.
+func (b *RcBuffer) Len() int64 { return b.size }
+
+// Refs returns the number of references to the buffer. It is only meaningful when no other goroutine clones or
+// releases the buffer.
+func (b *RcBuffer) Refs() int { return int(atomic.LoadInt32(&b.ref.refs)) }
+
+// Clone returns a new reference to the buffer. Cloning a released reference is a use after free, and panics.
+func (b *RcBuffer) Clone() *RcBuffer {
+	if atomic.LoadUint32(&b.released) != 0 {
+		panic(fmt.Sprintf("Clone of a released reference to %v", b))
+	}
+	b.ref.retain()
+	return &RcBuffer{ptr: b.ptr, size: b.size, ref: b.ref}
+}
+
+// Slice returns a DeviceSlice of the bytes [lo, hi) of the buffer. The slice holds a reference of its own, released
+// when it is freed.
+func (b *RcBuffer) Slice(lo, hi int64) (*DeviceSlice, error) {
+	if atomic.LoadUint32(&b.released) != 0 {
+		return nil, errors.Errorf("Cannot slice %v: the reference has been released", b)
+	}
+	if lo < 0 || hi > b.size || lo > hi {
+		return nil, errors.Errorf("Invalid slice [%d:%d] of %v", lo, hi, b)
+	}
+	b.ref.retain()
+	return &DeviceSlice{ptr: b.ptr.Offset(lo), size: hi - lo, ref: b.ref}, nil
+}
+
+// Release releases the reference, and frees the memory if it was the last one. Releasing a reference twice returns an
+// error, and leaves the count of references untouched.
+func (b *RcBuffer) Release() error {
+	if !atomic.CompareAndSwapUint32(&b.released, 0, 1) {
+		return errors.Errorf("A reference to %v has been released twice", b)
+	}
+	return b.ref.release()
+}
+
+// Uintptr returns the start of the buffer in form of a uintptr.
+func (b *RcBuffer) Uintptr() uintptr { return uintptr(b.ptr) }
+
+// Pointer returns the start of the buffer in form of an unsafe.Pointer.
+// The pointer is on the device, and must not be dereferenced by the host.
+func (b *RcBuffer) Pointer() unsafe.Pointer { return b.ptr.Pointer() }
+
+// IsNativelyAccessible returns false.
+func (b *RcBuffer) IsNativelyAccessible() bool { return false }
+
+// IsCUDAMemory returns true.
+func (b *RcBuffer) IsCUDAMemory() bool { return true }
+
+func (b *RcBuffer) String() string {
+	return fmt.Sprintf("RcBuffer{%v %d bytes}", b.ptr, b.size)
+}
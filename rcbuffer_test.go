@@ -0,0 +1,79 @@
+package cu
+
+import "testing"
+
+func TestRcBuffer_Concurrent(t *testing.T) {
+	var allocated, freed int
+	p := newFakePool(&allocated, &freed)
+	defer p.Close()
+
+	n := stressIterations()
+	for i := 0; i < n/10; i++ {
+		b, err := p.AllocRc(512)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// every goroutine gets its own reference, and releases it when done
+		refs := make([]*RcBuffer, stressGoroutines)
+		for g := range refs {
+			refs[g] = b.Clone()
+		}
+		if b.Refs() != stressGoroutines+1 {
+			t.Fatalf("Expected %d references. Got %d", stressGoroutines+1, b.Refs())
+		}
+		if err = b.Release(); err != nil {
+			t.Fatal(err)
+		}
+		stress(func(g int) {
+			s, err := refs[g].Slice(int64(g), int64(g+1))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if err := refs[g].Release(); err != nil {
+				t.Error(err)
+			}
+			if err := s.Free(); err != nil {
+				t.Error(err)
+			}
+		})
+		if s := p.Stats(); s.InUse != 0 || s.Frees != i+1 {
+			t.Fatalf("Expected the buffer to be freed once by its last reference. Got %+v", s)
+		}
+	}
+}
+
+func TestRcBuffer_Misuse(t *testing.T) {
+	var allocated, freed int
+	p := newFakePool(&allocated, &freed)
+	defer p.Close()
+
+	b, err := p.AllocRc(512)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := b.Clone()
+	if err = b.Release(); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Release(); err == nil {
+		t.Error("Expected an error releasing a reference twice")
+	}
+	if c.Refs() != 1 {
+		t.Errorf("A double release changed the count of references to %d", c.Refs())
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected Clone of a released reference to panic")
+			}
+		}()
+		b.Clone()
+	}()
+	if err = c.Release(); err != nil {
+		t.Fatal(err)
+	}
+	if s := p.Stats(); s.InUse != 0 {
+		t.Errorf("Expected the memory to be returned to the pool. Got %+v", s)
+	}
+}
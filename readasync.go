@@ -0,0 +1,108 @@
+package cu
+
+// #include <cuda.h>
+import "C"
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// readBufferMin is the size of the smallest pinned buffer used by ReadAsync. Buffers are sized in powers of two from
+// there, so that buffers are reused by reads of similar sizes.
+const readBufferMin = 4096
+
+// readBuffers are the pinned buffers of ReadAsync that are not in use, by size. They are allocated as portable, so that
+// reads in any context can use them.
+var readBuffers struct {
+	sync.Mutex
+	free map[int64][]unsafe.Pointer
+}
+
+func readBufferSize(n int64) int64 {
+	size := int64(readBufferMin)
+	for size < n {
+		size <<= 1
+	}
+	return size
+}
+
+func getReadBuffer(n int64) (unsafe.Pointer, int64, error) {
+	size := readBufferSize(n)
+	readBuffers.Lock()
+	if l := readBuffers.free[size]; len(l) > 0 {
+		p := l[len(l)-1]
+		readBuffers.free[size] = l[:len(l)-1]
+		readBuffers.Unlock()
+		return p, size, nil
+	}
+	readBuffers.Unlock()
+
+	var p unsafe.Pointer
+	if err := result(C.cuMemHostAlloc(&p, C.size_t(size), C.CU_MEMHOSTALLOC_PORTABLE)); err != nil {
+		return nil, 0, errors.Wrapf(err, "Unable to allocate %d bytes of pinned memory", size)
+	}
+	return p, size, nil
+}
+
+func putReadBuffer(p unsafe.Pointer, size int64) {
+	readBuffers.Lock()
+	if readBuffers.free == nil {
+		readBuffers.free = make(map[int64][]unsafe.Pointer)
+	}
+	readBuffers.free[size] = append(readBuffers.free[size], p)
+	readBuffers.Unlock()
+}
+
+// FreeReadBuffers frees the pinned buffers kept for reuse by ReadAsync. The buffers of reads in flight are kept.
+func FreeReadBuffers() error {
+	readBuffers.Lock()
+	defer readBuffers.Unlock()
+	for size, l := range readBuffers.free {
+		for len(l) > 0 {
+			if err := result(C.cuMemFreeHost(l[len(l)-1])); err != nil {
+				readBuffers.free[size] = l
+				return errors.Wrap(err, "Unable to free a pinned buffer")
+			}
+			l = l[:len(l)-1]
+		}
+		delete(readBuffers.free, size)
+	}
+	return nil
+}
+
+// ReadAsync copies n bytes at ptr to the host once the work already enqueued on the stream is done, and calls fn with
+// them. It returns without waiting, so the results of earlier work can be consumed while the device keeps working.
+//
+// The bytes are copied to a pinned buffer, which is reused once fn returns: fn must copy the bytes it keeps. fn is
+// called from a goroutine of its own, so the callbacks of several reads may run concurrently. If the work on the stream
+// failed, fn is called with nil, and the error is returned by the next synchronization of the stream.
+func ReadAsync(ptr DevicePtr, n int64, stream Stream, fn func([]byte)) error {
+	if n <= 0 {
+		return errors.Errorf("Cannot read %d bytes", n)
+	}
+	buf, size, err := getReadBuffer(n)
+	if err != nil {
+		return err
+	}
+	if err = result(C.cuMemcpyDtoHAsync(buf, C.CUdeviceptr(ptr), C.size_t(n), stream.c())); err != nil {
+		putReadBuffer(buf, size)
+		return errors.Wrap(err, "ReadAsync")
+	}
+	err = addStreamCallback(stream, func(err error) {
+		go func() {
+			defer putReadBuffer(buf, size)
+			if err != nil {
+				fn(nil)
+				return
+			}
+			fn((*[1 << 40]byte)(buf)[:n:n])
+		}()
+	})
+	if err != nil {
+		// the copy may still be writing to the buffer: it cannot be reused
+		return errors.Wrap(err, "ReadAsync")
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package cu
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestReadBufferSize(t *testing.T) {
+	for _, c := range []struct{ n, size int64 }{
+		{1, 4096},
+		{4096, 4096},
+		{4097, 8192},
+		{1 << 20, 1 << 20},
+		{1<<20 + 1, 2 << 20},
+	} {
+		if got := readBufferSize(c.n); got != c.size {
+			t.Errorf("Expected a buffer of %d bytes for %d bytes. Got %d", c.size, c.n, got)
+		}
+	}
+}
+
+func TestReadAsync(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	_, ctx, err := testSetup()
+	if err != nil {
+		if err.Error() == "NoDevice" {
+			t.Skip("NoDevice")
+		}
+		t.Fatal(err)
+	}
+	defer testTeardown(ctx, Module{})
+	defer FreeReadBuffers()
+
+	stream, err := MakeStream(NonBlocking)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Destroy()
+
+	const reads, size = 8, 1024
+	mem, err := MemAlloc(reads * size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer MemFree(mem)
+
+	done := make(chan []byte, reads)
+	for i := 0; i < reads; i++ {
+		part := mem.Offset(int64(i * size))
+		if err = MemsetD8Async(part, byte(i), size, stream); err != nil {
+			t.Fatal(err)
+		}
+		if err = ReadAsync(part, size, stream, func(b []byte) {
+			done <- append([]byte(nil), b...)
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	seen := make(map[byte]bool)
+	for i := 0; i < reads; i++ {
+		b := <-done
+		if len(b) != size {
+			t.Fatalf("Expected %d bytes. Got %d", size, len(b))
+		}
+		for _, v := range b {
+			if v != b[0] {
+				t.Fatalf("Read %d and %d in the same part", b[0], v)
+			}
+		}
+		seen[b[0]] = true
+	}
+	if len(seen) != reads {
+		t.Errorf("Expected %d distinct parts. Got %v", reads, seen)
+	}
+}
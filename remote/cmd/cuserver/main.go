@@ -0,0 +1,83 @@
+// cuserver serves a GPU to other processes (see package gorgonia.org/cu/remote/cuserver).
+//
+// By default it serves a single client on its standard input and output, as the child of a supervisor (see package
+// gorgonia.org/cu/remote/supervisor). With -listen, it serves the clients that connect to the address instead.
+//
+// The kernels the clients may launch are those of pipeline.DefaultRegistry, and those of the file given with -sources:
+// a JSON array of kernels.Source, such as
+//
+//	[{"Name": "scale", "Code": "extern \"C\" __global__ void scale(...) {...}", "Dtypes": ["float32"]}]
+//
+// cuserver exits with status 2 once a device error has made its context unusable, so that its supervisor restarts it.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"net"
+	"os"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+	"gorgonia.org/cu/kernels"
+	"gorgonia.org/cu/pipeline"
+	"gorgonia.org/cu/remote"
+	"gorgonia.org/cu/remote/cuserver"
+)
+
+var (
+	device  = flag.Int("device", 0, "the device to serve")
+	listen  = flag.String("listen", "", "the TCP address to listen on, instead of serving standard input and output")
+	sources = flag.String("sources", "", "a JSON file of the kernel sources to register")
+)
+
+// register registers the kernel sources of the file.
+func register(file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var srcs []*kernels.Source
+	if err = json.Unmarshal(data, &srcs); err != nil {
+		return err
+	}
+	for _, src := range srcs {
+		if src.Name == "" || len(src.Dtypes) == 0 {
+			return errors.New("Expected sources with a name and element types")
+		}
+		if err = pipeline.DefaultRegistry.Register(src); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func main() {
+	flag.Parse()
+	log.SetOutput(os.Stderr)
+
+	if *sources != "" {
+		if err := register(*sources); err != nil {
+			log.Fatalf("cuserver: %v", err)
+		}
+	}
+	ctx := cu.NewContext(cu.Device(*device), cu.SchedAuto)
+	go func() {
+		for err := range ctx.Errors() {
+			log.Printf("cuserver: %v", err)
+			os.Exit(2)
+		}
+	}()
+	s := cuserver.New(ctx, nil, nil)
+
+	if *listen == "" {
+		s.ServeConn(remote.Stdio())
+		return
+	}
+	l, err := net.Listen("tcp", *listen)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Fatal(s.Serve(l))
+}
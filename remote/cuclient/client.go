@@ -0,0 +1,118 @@
+// Package cuclient uses a GPU served by another process (see package cuserver), with the gRPC service of package
+// remote. It does not use cgo, so it builds and runs on hosts without CUDA.
+//
+// The work a client sends is done in the order it is sent. Launch, Gemm and Copy return once the work is enqueued on
+// the server; the errors of the work itself are returned by the next CopyOut or Synchronize.
+package cuclient // import "gorgonia.org/cu/remote/cuclient"
+
+import (
+	"context"
+	"io"
+	"net"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"gorgonia.org/cu/remote"
+)
+
+// Client is a connection to a server. A Client is safe for concurrent use, but the order of concurrent calls is not
+// defined.
+type Client struct {
+	conn *grpc.ClientConn
+	c    remote.CUClient
+}
+
+// Dial connects to the server at the address (see net.Dial). The connection is not encrypted, unless opts set up
+// transport credentials.
+func Dial(network, address string, opts ...grpc.DialOption) (*Client, error) {
+	dial := func(ctx context.Context, addr string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, network, addr)
+	}
+	return newClient(address, append([]grpc.DialOption{grpc.WithContextDialer(dial)}, opts...))
+}
+
+// NewClient creates a Client that talks to a server over the connection, for example the pipes of a child process.
+// The connection cannot be redialled: once it breaks, the calls of the Client fail.
+func NewClient(conn io.ReadWriteCloser) (*Client, error) {
+	used := false
+	dial := func(ctx context.Context, addr string) (net.Conn, error) {
+		if used {
+			return nil, errors.New("The connection of the client is closed")
+		}
+		used = true
+		return remote.NetConn(conn), nil
+	}
+	return newClient("pipe", []grpc.DialOption{grpc.WithContextDialer(dial)})
+}
+
+func newClient(target string, opts []grpc.DialOption) (*Client, error) {
+	opts = append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(remote.MaxMessageSize), grpc.MaxCallSendMsgSize(remote.MaxMessageSize)),
+	}, opts...)
+	conn, err := grpc.Dial("passthrough:///"+target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, c: remote.NewCUClient(conn)}, nil
+}
+
+// Close closes the connection. The server frees the memory the client did not free.
+func (c *Client) Close() error { return c.conn.Close() }
+
+// Alloc allocates size bytes of device memory.
+func (c *Client) Alloc(size int64) (remote.Handle, error) {
+	reply, err := c.c.Alloc(context.Background(), &remote.AllocArgs{Size: size})
+	if err != nil {
+		return 0, err
+	}
+	return remote.Handle(reply.Mem), nil
+}
+
+// Free frees device memory.
+func (c *Client) Free(h remote.Handle) error {
+	_, err := c.c.Free(context.Background(), &remote.FreeArgs{Mem: uint64(h)})
+	return err
+}
+
+// CopyIn copies data to the device memory at offset bytes into dst.
+func (c *Client) CopyIn(dst remote.Handle, offset int64, data []byte) error {
+	_, err := c.c.CopyIn(context.Background(), &remote.CopyInArgs{Dst: uint64(dst), Offset: offset, Data: data})
+	return err
+}
+
+// CopyOut returns size bytes of the device memory at offset bytes into src, once the work sent before is done.
+func (c *Client) CopyOut(src remote.Handle, offset, size int64) ([]byte, error) {
+	reply, err := c.c.CopyOut(context.Background(), &remote.CopyOutArgs{Src: uint64(src), Offset: offset, Size: size})
+	if err != nil {
+		return nil, err
+	}
+	return reply.Data, nil
+}
+
+// Copy copies size bytes of device memory, from srcOffset bytes into src to dstOffset bytes into dst.
+func (c *Client) Copy(dst remote.Handle, dstOffset int64, src remote.Handle, srcOffset, size int64) error {
+	args := &remote.CopyArgs{Dst: uint64(dst), Src: uint64(src), DstOffset: dstOffset, SrcOffset: srcOffset, Size: size}
+	_, err := c.c.Copy(context.Background(), args)
+	return err
+}
+
+// Launch launches a kernel registered with the server.
+func (c *Client) Launch(args *remote.LaunchArgs) error {
+	_, err := c.c.Launch(context.Background(), args)
+	return err
+}
+
+// Gemm multiplies matrices with cuBLAS (see remote.GemmArgs).
+func (c *Client) Gemm(args *remote.GemmArgs) error {
+	_, err := c.c.Gemm(context.Background(), args)
+	return err
+}
+
+// Synchronize waits for the work sent before to be done.
+func (c *Client) Synchronize() error {
+	_, err := c.c.Synchronize(context.Background(), &remote.Empty{})
+	return err
+}
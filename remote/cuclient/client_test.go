@@ -0,0 +1,73 @@
+package cuclient
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"gorgonia.org/cu/remote"
+)
+
+// hostService serves the memory methods of the protocol from host memory.
+type hostService struct {
+	remote.UnimplementedCUServer
+	mem [][]byte
+}
+
+func (s *hostService) Alloc(ctx context.Context, args *remote.AllocArgs) (*remote.AllocReply, error) {
+	s.mem = append(s.mem, make([]byte, args.Size))
+	return &remote.AllocReply{Mem: uint64(len(s.mem))}, nil
+}
+
+func (s *hostService) CopyIn(ctx context.Context, args *remote.CopyInArgs) (*remote.Empty, error) {
+	copy(s.mem[args.Dst-1][args.Offset:], args.Data)
+	return &remote.Empty{}, nil
+}
+
+func (s *hostService) CopyOut(ctx context.Context, args *remote.CopyOutArgs) (*remote.CopyOutReply, error) {
+	return &remote.CopyOutReply{Data: s.mem[args.Src-1][args.Offset : args.Offset+args.Size]}, nil
+}
+
+func (s *hostService) Launch(ctx context.Context, args *remote.LaunchArgs) (*remote.Empty, error) {
+	if len(args.Args) != 2 || args.Args[0].GetMem() != 1 || args.Args[0].Offset != 4 || args.Args[1].GetFloat() != 0.5 {
+		return nil, errors.Errorf("Unexpected arguments %v", args.Args)
+	}
+	return &remote.Empty{}, nil
+}
+
+func TestClient(t *testing.T) {
+	srv := grpc.NewServer()
+	defer srv.Stop()
+	remote.RegisterCUServer(srv, new(hostService))
+	a, b := net.Pipe()
+	go srv.Serve(remote.ConnListener(a))
+	c, err := NewClient(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	h, err := c.Alloc(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.CopyIn(h, 2, []byte{1, 2, 3}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.CopyOut(h, 0, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{0, 0, 1, 2, 3, 0, 0, 0}; !bytes.Equal(got, want) {
+		t.Errorf("Expected %v. Got %v", want, got)
+	}
+	if err = c.Launch(&remote.LaunchArgs{Args: []*remote.Arg{remote.MemArg(h, 4), remote.FloatArg(0.5)}}); err != nil {
+		t.Error(err)
+	}
+	if err = c.Free(h); err == nil {
+		t.Error("Expected an error for a method the server does not have")
+	}
+}
@@ -0,0 +1,437 @@
+// Package cuserver serves a GPU to other processes, with the gRPC service of package remote. The clients (see package
+// cuclient) need neither CUDA nor cgo, so that CPU-only processes can farm work out to a GPU host, and so that a crash of
+// the driver takes down the server and not its clients.
+//
+// A Server runs all the work of its clients on the thread of one context, in the order it arrives. The work is
+// enqueued on the default stream of the context, so that the copies from the device happen after the work enqueued
+// before them. The memory a client allocates is freed when its connection closes.
+//
+// The kernels the clients launch are the sources of the registry of the server, which the program serving the GPU
+// fills: clients cannot register sources (see the trust model of package remote).
+package cuserver // import "gorgonia.org/cu/remote/cuserver"
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/blas"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+	"gorgonia.org/cu"
+	cublas "gorgonia.org/cu/blas"
+	"gorgonia.org/cu/kernels"
+	"gorgonia.org/cu/pipeline"
+	"gorgonia.org/cu/remote"
+)
+
+// Server serves the context it is created with. The kernels its clients launch are looked up in a registry of sources,
+// as the steps of package pipeline.
+type Server struct {
+	ctx *cu.Ctx
+	reg *pipeline.Registry
+	lib *kernels.Library
+	srv *grpc.Server
+
+	blas *cublas.Standard // only used on the thread of the context
+}
+
+// New creates a Server of the context. The kernels are looked up in reg (pipeline.DefaultRegistry if nil), and loaded
+// from lib (kernels.Default if nil). opts are passed to the gRPC server, for example to set up TLS.
+func New(ctx *cu.Ctx, reg *pipeline.Registry, lib *kernels.Library, opts ...grpc.ServerOption) *Server {
+	if reg == nil {
+		reg = pipeline.DefaultRegistry
+	}
+	if lib == nil {
+		lib = kernels.Default
+	}
+	s := &Server{ctx: ctx, reg: reg, lib: lib}
+	opts = append([]grpc.ServerOption{
+		grpc.MaxRecvMsgSize(remote.MaxMessageSize),
+		grpc.MaxSendMsgSize(remote.MaxMessageSize),
+		grpc.StatsHandler(sessions{s}),
+	}, opts...)
+	s.srv = grpc.NewServer(opts...)
+	remote.RegisterCUServer(s.srv, service{})
+	return s
+}
+
+// Serve accepts connections on the listener, and serves each of them in its own goroutine. It returns when the listener
+// fails, for example once it is closed.
+func (s *Server) Serve(l net.Listener) error { return s.srv.Serve(l) }
+
+// ServeConn serves a single connection, such as the standard input and output of a child process (see remote.Stdio),
+// until the client hangs up. Then it frees the memory the client did not free.
+func (s *Server) ServeConn(conn io.ReadWriteCloser) {
+	c := remote.NetConn(conn)
+	ended := make(chan struct{})
+	s.srv.Serve(remote.ConnListener(connWithAddr{c, connAddr{c.RemoteAddr(), ended}}))
+	// the memory of the connection is freed by the stats handler, once the connection has ended
+	<-ended
+}
+
+// Close closes the connections of the server, and releases its resources. The context is left to its owner.
+func (s *Server) Close() error {
+	s.srv.Stop()
+	return s.ctx.Do(func() error {
+		if s.blas == nil {
+			return nil
+		}
+		err := s.blas.Close()
+		s.blas = nil
+		return err
+	})
+}
+
+// cublas returns the cuBLAS handle of the server, creating it on first use. It must be called on the thread of the
+// context.
+func (s *Server) cublas() (*cublas.Standard, error) {
+	if s.blas == nil {
+		impl := new(cublas.Standard)
+		if err := impl.Init(); err != nil {
+			return nil, err
+		}
+		s.blas = impl
+	}
+	return s.blas, nil
+}
+
+type allocation struct {
+	ptr  cu.DevicePtr
+	size int64
+}
+
+// session is the state of a connection: the memory its client allocated.
+type session struct {
+	s     *Server
+	ended chan struct{} // closed once the memory is freed, if the connection is served by ServeConn
+
+	sync.Mutex
+	mem  map[remote.Handle]allocation
+	next remote.Handle
+}
+
+type sessionKey struct{}
+
+// sessionOf returns the session of the connection of a call.
+func sessionOf(ctx context.Context) (*session, error) {
+	sess, ok := ctx.Value(sessionKey{}).(*session)
+	if !ok {
+		return nil, errors.New("The call has no session")
+	}
+	return sess, nil
+}
+
+// lookup returns the device pointer size bytes at offset into the allocation, if they are in the allocation.
+func (sess *session) lookup(h uint64, offset, size int64) (cu.DevicePtr, error) {
+	sess.Lock()
+	a, ok := sess.mem[remote.Handle(h)]
+	sess.Unlock()
+	if !ok {
+		return 0, errors.Errorf("Unknown handle %d", h)
+	}
+	if offset < 0 || size < 0 || offset > a.size || size > a.size-offset {
+		return 0, errors.Errorf("%d bytes at offset %d are out of the %d bytes of handle %d", size, offset, a.size, h)
+	}
+	return a.ptr + cu.DevicePtr(offset), nil
+}
+
+// close frees the memory of the session.
+func (sess *session) close() {
+	sess.Lock()
+	mem := sess.mem
+	sess.mem = make(map[remote.Handle]allocation)
+	sess.Unlock()
+	sess.s.ctx.Do(func() error {
+		for _, a := range mem {
+			cu.MemFree(a.ptr)
+		}
+		return nil
+	})
+	if sess.ended != nil {
+		close(sess.ended)
+	}
+}
+
+// sessions creates the session of each connection, and closes it when the connection ends.
+type sessions struct{ s *Server }
+
+func (h sessions) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	sess := &session{s: h.s, mem: make(map[remote.Handle]allocation)}
+	if addr, ok := info.RemoteAddr.(connAddr); ok {
+		sess.ended = addr.ended
+	}
+	return context.WithValue(ctx, sessionKey{}, sess)
+}
+
+func (h sessions) HandleConn(ctx context.Context, s stats.ConnStats) {
+	if _, ok := s.(*stats.ConnEnd); !ok {
+		return
+	}
+	if sess, err := sessionOf(ctx); err == nil {
+		sess.close()
+	}
+}
+
+func (sessions) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context { return ctx }
+func (sessions) HandleRPC(ctx context.Context, s stats.RPCStats)                    {}
+
+// connAddr is the remote address of the connection of ServeConn. It carries the channel the session of the connection
+// closes once its memory is freed, as the stats handler only sees the addresses of the connection.
+type connAddr struct {
+	net.Addr
+	ended chan struct{}
+}
+
+type connWithAddr struct {
+	net.Conn
+	addr connAddr
+}
+
+func (c connWithAddr) RemoteAddr() net.Addr { return c.addr }
+
+// service implements the methods of the service, on the session of the connection of each call.
+type service struct {
+	remote.UnimplementedCUServer
+}
+
+func (service) Alloc(ctx context.Context, args *remote.AllocArgs) (*remote.AllocReply, error) {
+	sess, err := sessionOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if args.Size <= 0 {
+		return nil, errors.Errorf("Cannot allocate %d bytes", args.Size)
+	}
+	var ptr cu.DevicePtr
+	err = sess.s.ctx.Do(func() (err error) {
+		ptr, err = cu.MemAlloc(args.Size)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to allocate %d bytes", args.Size)
+	}
+	sess.Lock()
+	defer sess.Unlock()
+	sess.next++
+	sess.mem[sess.next] = allocation{ptr, args.Size}
+	return &remote.AllocReply{Mem: uint64(sess.next)}, nil
+}
+
+func (service) Free(ctx context.Context, args *remote.FreeArgs) (*remote.Empty, error) {
+	sess, err := sessionOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess.Lock()
+	a, ok := sess.mem[remote.Handle(args.Mem)]
+	delete(sess.mem, remote.Handle(args.Mem))
+	sess.Unlock()
+	if !ok {
+		return nil, errors.Errorf("Unknown handle %d", args.Mem)
+	}
+	return &remote.Empty{}, sess.s.ctx.Do(func() error { return cu.MemFree(a.ptr) })
+}
+
+func (service) CopyIn(ctx context.Context, args *remote.CopyInArgs) (*remote.Empty, error) {
+	sess, err := sessionOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ptr, err := sess.lookup(args.Dst, args.Offset, int64(len(args.Data)))
+	if err != nil || len(args.Data) == 0 {
+		return &remote.Empty{}, err
+	}
+	return &remote.Empty{}, sess.s.ctx.Do(func() error {
+		return cu.MemcpyHtoD(ptr, unsafe.Pointer(&args.Data[0]), int64(len(args.Data)))
+	})
+}
+
+func (service) CopyOut(ctx context.Context, args *remote.CopyOutArgs) (*remote.CopyOutReply, error) {
+	sess, err := sessionOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ptr, err := sess.lookup(args.Src, args.Offset, args.Size)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, args.Size)
+	if args.Size > 0 {
+		if err = sess.s.ctx.Do(func() error { return cu.MemcpyDtoH(unsafe.Pointer(&buf[0]), ptr, args.Size) }); err != nil {
+			return nil, err
+		}
+	}
+	return &remote.CopyOutReply{Data: buf}, nil
+}
+
+func (service) Copy(ctx context.Context, args *remote.CopyArgs) (*remote.Empty, error) {
+	sess, err := sessionOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := sess.lookup(args.Dst, args.DstOffset, args.Size)
+	if err != nil {
+		return nil, err
+	}
+	src, err := sess.lookup(args.Src, args.SrcOffset, args.Size)
+	if err != nil || args.Size == 0 {
+		return &remote.Empty{}, err
+	}
+	return &remote.Empty{}, sess.s.ctx.Do(func() error { return cu.MemcpyDtoDAsync(dst, src, args.Size, cu.NoStream) })
+}
+
+func (service) Launch(ctx context.Context, args *remote.LaunchArgs) (*remote.Empty, error) {
+	sess, err := sessionOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var dt cu.Dtype
+	if err = dt.UnmarshalText([]byte(args.Dtype)); err != nil {
+		return nil, err
+	}
+	src, ok := sess.s.reg.Lookup(args.Source)
+	if !ok {
+		return nil, errors.Errorf("Unknown source %q", args.Source)
+	}
+	explicit := len(args.Grid) > 0 || len(args.Block) > 0
+	if (args.Threads > 0) == explicit {
+		return nil, errors.New("Expected either threads, or grid and block")
+	}
+	var grid, block [3]int
+	if explicit {
+		if len(args.Grid) != 3 || len(args.Block) != 3 {
+			return nil, errors.Errorf("Expected a grid and a block of 3 dimensions. Got %v and %v", args.Grid, args.Block)
+		}
+		for i := range grid {
+			if args.Grid[i] <= 0 || args.Block[i] <= 0 {
+				return nil, errors.Errorf("Invalid grid %v and block %v", args.Grid, args.Block)
+			}
+			grid[i], block[i] = int(args.Grid[i]), int(args.Block[i])
+		}
+	}
+	vals := make([]interface{}, len(args.Args))
+	for i, a := range args.Args {
+		switch v := a.GetValue().(type) {
+		case *remote.Arg_Mem:
+			// only the start of the argument is checked: the kernel is trusted with the rest of the allocation
+			ptr, err := sess.lookup(v.Mem, a.Offset, 0)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Argument %d", i)
+			}
+			vals[i] = ptr
+		case *remote.Arg_Int:
+			vals[i] = v.Int
+		case *remote.Arg_Float:
+			vals[i] = v.Float
+		default:
+			return nil, errors.Errorf("Argument %d: expected one of a handle, an int and a float", i)
+		}
+	}
+	params, err := kernels.Args(vals...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &remote.Empty{}, sess.s.ctx.Do(func() error {
+		fn, err := sess.s.lib.Function(src, dt, args.Kernel)
+		if err != nil {
+			return err
+		}
+		if args.Threads > 0 {
+			return fn.Launch(kernels.GridSize(int(args.Threads)), 1, 1, kernels.BlockSize, 1, 1, int(args.SharedMem), cu.NoStream, params)
+		}
+		return fn.Launch(grid[0], grid[1], grid[2], block[0], block[1], block[2], int(args.SharedMem), cu.NoStream, params)
+	})
+}
+
+func (service) Gemm(ctx context.Context, args *remote.GemmArgs) (*remote.Empty, error) {
+	sess, err := sessionOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var dt cu.Dtype
+	if err = dt.UnmarshalText([]byte(args.Dtype)); err != nil {
+		return nil, err
+	}
+	var compute cublas.CublasComputeType
+	switch dt {
+	case cu.DtFloat64:
+		compute = cublas.Compute64F
+	case cu.DtFloat32, cu.DtFloat16, cu.DtBFloat16:
+		compute = cublas.Compute32F
+	default:
+		return nil, errors.Errorf("Gemm does not support %v", dt)
+	}
+	m, n, k := int(args.M), int(args.N), int(args.K)
+	if m < 0 || n < 0 || k < 0 {
+		return nil, errors.Errorf("Invalid sizes m=%d, n=%d, k=%d", m, n, k)
+	}
+	if m == 0 || n == 0 {
+		return &remote.Empty{}, nil
+	}
+
+	// the operands, as stored: rows × cols, with their leading dimension
+	ar, ac := m, k
+	if args.TransA {
+		ar, ac = ac, ar
+	}
+	br, bc := k, n
+	if args.TransB {
+		br, bc = bc, br
+	}
+	ptrs := make([]cu.DevicePtr, 3)
+	for i, op := range []struct {
+		name       string
+		mem        uint64
+		rows, cols int
+		ld         int
+	}{
+		{"A", args.A, ar, ac, int(args.Lda)},
+		{"B", args.B, br, bc, int(args.Ldb)},
+		{"C", args.C, m, n, int(args.Ldc)},
+	} {
+		if op.ld < op.rows || op.ld < 1 {
+			return nil, errors.Errorf("The leading dimension of %s, %d, is less than its %d rows", op.name, op.ld, op.rows)
+		}
+		var size int64
+		if op.rows > 0 && op.cols > 0 {
+			size = (int64(op.ld)*int64(op.cols-1) + int64(op.rows)) * dt.Size()
+		}
+		if ptrs[i], err = sess.lookup(op.mem, 0, size); err != nil {
+			return nil, errors.Wrapf(err, "%s", op.name)
+		}
+	}
+
+	tA, tB := blas.NoTrans, blas.NoTrans
+	if args.TransA {
+		tA = blas.Trans
+	}
+	if args.TransB {
+		tB = blas.Trans
+	}
+	return &remote.Empty{}, sess.s.ctx.Do(func() error {
+		impl, err := sess.s.cublas()
+		if err != nil {
+			return err
+		}
+		impl.GemmEx(tA, tB, m, n, k, args.Alpha, ptrs[0], dt, int(args.Lda), ptrs[1], dt, int(args.Ldb), args.Beta, ptrs[2], dt, int(args.Ldc), compute, cublas.GemmDefault)
+		if err = impl.Err(); err != nil {
+			// the error of a handle sticks, so the handle is replaced
+			impl.Close()
+			sess.s.blas = nil
+		}
+		return err
+	})
+}
+
+func (service) Synchronize(ctx context.Context, args *remote.Empty) (*remote.Empty, error) {
+	sess, err := sessionOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &remote.Empty{}, sess.s.ctx.Do(cu.NoStream.Synchronize)
+}
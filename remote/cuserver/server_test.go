@@ -0,0 +1,115 @@
+package cuserver
+
+import (
+	"encoding/binary"
+	"math"
+	"net"
+	"testing"
+
+	"gorgonia.org/cu"
+	"gorgonia.org/cu/kernels"
+	"gorgonia.org/cu/pipeline"
+	"gorgonia.org/cu/remote"
+	"gorgonia.org/cu/remote/cuclient"
+)
+
+var testSource = &kernels.Source{
+	Name:   "cuserver_test",
+	Dtypes: []cu.Dtype{cu.DtFloat32},
+	Code: `
+extern "C" __global__ void scale(T* x, long long n, double a) {
+	GRID_STRIDE(i, n) {
+		st(x, i, a * ld(x, i));
+	}
+}
+`,
+}
+
+func float32s(b []byte) []float32 {
+	retVal := make([]float32, len(b)/4)
+	for i := range retVal {
+		retVal[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+	return retVal
+}
+
+func bytesOf(fs ...float32) []byte {
+	retVal := make([]byte, 4*len(fs))
+	for i, f := range fs {
+		binary.LittleEndian.PutUint32(retVal[4*i:], math.Float32bits(f))
+	}
+	return retVal
+}
+
+func TestServer(t *testing.T) {
+	if devices, _ := cu.NumDevices(); devices == 0 {
+		t.Skip("NoDevice")
+	}
+	ctx := cu.NewContext(cu.Device(0), cu.SchedAuto)
+	defer ctx.Close()
+	s := New(ctx, pipeline.NewRegistry(testSource), nil)
+	defer s.Close()
+
+	a, b := net.Pipe()
+	go s.ServeConn(a)
+	c, err := cuclient.NewClient(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	x, err := c.Alloc(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.CopyIn(x, 0, bytesOf(1, 2, 3, 4)); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.CopyIn(x, 8, make([]byte, 16)); err == nil {
+		t.Error("Expected an error for a copy out of the allocation")
+	}
+	launch := &remote.LaunchArgs{
+		Source:  "cuserver_test",
+		Kernel:  "scale",
+		Dtype:   "float32",
+		Threads: 4,
+		Args:    []*remote.Arg{remote.MemArg(x, 0), remote.IntArg(4), remote.FloatArg(2)},
+	}
+	if err = c.Launch(launch); err != nil {
+		t.Fatal(err)
+	}
+
+	// y = xᵀx, with x a 4×1 column
+	y, err := c.Alloc(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gemm := &remote.GemmArgs{Dtype: "float32", TransA: true, M: 1, N: 1, K: 4, Alpha: 1, A: uint64(x), B: uint64(x), C: uint64(y), Lda: 4, Ldb: 4, Ldc: 1}
+	if err = c.Gemm(gemm); err != nil {
+		t.Fatal(err)
+	}
+	gemm.K = 5
+	if err = c.Gemm(gemm); err == nil {
+		t.Error("Expected an error for a matrix out of its allocation")
+	}
+
+	got, err := c.CopyOut(x, 0, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if xs := float32s(got); xs[0] != 2 || xs[3] != 8 {
+		t.Errorf("Expected [2 4 6 8]. Got %v", xs)
+	}
+	if got, err = c.CopyOut(y, 0, 4); err != nil {
+		t.Fatal(err)
+	}
+	if ys := float32s(got); ys[0] != 120 {
+		t.Errorf("Expected 120. Got %v", ys)
+	}
+	if err = c.Free(x); err != nil {
+		t.Error(err)
+	}
+	if err = c.Free(x); err == nil {
+		t.Error("Expected an error for a handle freed twice")
+	}
+}
@@ -0,0 +1,21 @@
+module gorgonia.org/cu/remote
+
+go 1.17
+
+require (
+	github.com/pkg/errors v0.8.1
+	gonum.org/v1/gonum v0.0.0-20190902003836-43865b531bee
+	google.golang.org/grpc v1.56.3
+	google.golang.org/protobuf v1.31.0
+	gorgonia.org/cu v0.9.0-beta
+)
+
+require (
+	github.com/golang/protobuf v1.5.3 // indirect
+	golang.org/x/net v0.9.0 // indirect
+	golang.org/x/sys v0.7.0 // indirect
+	golang.org/x/text v0.9.0 // indirect
+	google.golang.org/genproto v0.0.0-20230410155749-daa745c078e1 // indirect
+)
+
+replace gorgonia.org/cu => ../
@@ -0,0 +1,129 @@
+// Package remote defines the protocol between cuserver, which serves a GPU to other processes, and cuclient, which
+// uses it. The protocol is a gRPC service (see remote.proto), so that clients need neither CUDA nor cgo, and may be
+// written in any language gRPC supports. The Go types of the messages and the stubs of the service are generated
+// from remote.proto.
+//
+// The protocol is a subset of the Context API: allocating and freeing device memory, copying to, from and within it,
+// launching the kernels registered with the server, and multiplying matrices with cuBLAS. It may be carried by any
+// connection gRPC can use, including the pipes of a child process (see Stdio and NetConn).
+//
+// The protocol lives in a module of its own, gorgonia.org/cu/remote, so that gorgonia.org/cu does not depend on gRPC.
+//
+// # Trust model
+//
+// Serving a GPU over the protocol separates the clients from the driver, not the clients from each other. A crash of
+// the driver, or a device error that makes the context unusable, takes down the server and not its clients; but all
+// the clients of a server share its context, so such an error fails the work of all of them.
+//
+// Device memory is referred to by Handle, never by device pointer, and the copies and matrix multiplications are
+// checked against the sizes of the allocations. The kernels are not: a kernel receives the device pointers of its
+// arguments, and may read and write anywhere the context can. The code that runs on the device is therefore only
+// the code of the sources registered with the server, which only the server registers; clients cannot submit code.
+// A client is trusted as far as the kernels of the server are: a kernel that does not check its bounds lets a client
+// reach the memory of the other clients.
+package remote // import "gorgonia.org/cu/remote"
+
+import (
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// MaxMessageSize is the size of the largest message the server and the client accept, in bytes. Copies of more than
+// about as much must be split.
+const MaxMessageSize = 1 << 30
+
+// Handle refers to an allocation of device memory on the server. The zero Handle refers to no allocation.
+type Handle uint64
+
+// MemArg is an argument that points offset bytes into an allocation.
+func MemArg(h Handle, offset int64) *Arg {
+	return &Arg{Value: &Arg_Mem{Mem: uint64(h)}, Offset: offset}
+}
+
+// IntArg is an argument passed as a long long.
+func IntArg(v int64) *Arg { return &Arg{Value: &Arg_Int{Int: v}} }
+
+// FloatArg is an argument passed as a double.
+func FloatArg(v float64) *Arg { return &Arg{Value: &Arg_Float{Float: v}} }
+
+// ConnListener returns a listener of a single connection, to serve it with a grpc.Server: Accept returns the
+// connection once, then blocks until the connection is closed, and fails with io.EOF.
+func ConnListener(conn io.ReadWriteCloser) net.Listener {
+	return &connListener{conn: NetConn(conn), closed: make(chan struct{})}
+}
+
+type connListener struct {
+	conn   net.Conn
+	closed chan struct{}
+
+	sync.Mutex
+	accepted bool
+	once     sync.Once
+}
+
+func (l *connListener) Accept() (net.Conn, error) {
+	l.Lock()
+	accepted := l.accepted
+	l.accepted = true
+	l.Unlock()
+	if !accepted {
+		return closeConn{l.conn, l}, nil
+	}
+	<-l.closed
+	return nil, io.EOF
+}
+
+func (l *connListener) Close() error   { return nil }
+func (l *connListener) Addr() net.Addr { return l.conn.LocalAddr() }
+
+// closeConn is the connection of a connListener, which stops the listener when it is closed.
+type closeConn struct {
+	net.Conn
+	l *connListener
+}
+
+func (c closeConn) Close() error {
+	c.l.once.Do(func() { close(c.l.closed) })
+	return c.Conn.Close()
+}
+
+// Stdio returns the connection of a server run as a child process: its standard input and output.
+func Stdio() net.Conn { return NetConn(stdio{}) }
+
+type stdio struct{}
+
+func (stdio) Read(p []byte) (int, error)  { return os.Stdin.Read(p) }
+func (stdio) Write(p []byte) (int, error) { return os.Stdout.Write(p) }
+
+func (stdio) Close() error {
+	err := os.Stdin.Close()
+	if err2 := os.Stdout.Close(); err == nil {
+		err = err2
+	}
+	return err
+}
+
+// NetConn returns the connection as a net.Conn, for gRPC, if it is not one already. The deadlines of the net.Conn
+// returned are not supported, and its addresses are placeholders.
+func NetConn(conn io.ReadWriteCloser) net.Conn {
+	if c, ok := conn.(net.Conn); ok {
+		return c
+	}
+	return rwcConn{conn}
+}
+
+type rwcConn struct{ io.ReadWriteCloser }
+
+func (rwcConn) LocalAddr() net.Addr                { return pipeAddr{} }
+func (rwcConn) RemoteAddr() net.Addr               { return pipeAddr{} }
+func (rwcConn) SetDeadline(t time.Time) error      { return nil }
+func (rwcConn) SetReadDeadline(t time.Time) error  { return nil }
+func (rwcConn) SetWriteDeadline(t time.Time) error { return nil }
+
+type pipeAddr struct{}
+
+func (pipeAddr) Network() string { return "pipe" }
+func (pipeAddr) String() string  { return "pipe" }
@@ -0,0 +1,1150 @@
+// The protocol between cuserver and cuclient. See the documentation of package remote.
+//
+// The Go code is generated with protoc-gen-go and protoc-gen-go-grpc:
+//
+//	protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative remote.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: remote.proto
+
+package remote
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Empty is the argument and the reply of the methods that have none.
+type Empty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remote_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_remote_proto_rawDescGZIP(), []int{0}
+}
+
+type AllocArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Size int64 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"` // in bytes
+}
+
+func (x *AllocArgs) Reset() {
+	*x = AllocArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remote_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AllocArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocArgs) ProtoMessage() {}
+
+func (x *AllocArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocArgs.ProtoReflect.Descriptor instead.
+func (*AllocArgs) Descriptor() ([]byte, []int) {
+	return file_remote_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AllocArgs) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type AllocReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mem uint64 `protobuf:"varint,1,opt,name=mem,proto3" json:"mem,omitempty"`
+}
+
+func (x *AllocReply) Reset() {
+	*x = AllocReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remote_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AllocReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocReply) ProtoMessage() {}
+
+func (x *AllocReply) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocReply.ProtoReflect.Descriptor instead.
+func (*AllocReply) Descriptor() ([]byte, []int) {
+	return file_remote_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AllocReply) GetMem() uint64 {
+	if x != nil {
+		return x.Mem
+	}
+	return 0
+}
+
+type FreeArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mem uint64 `protobuf:"varint,1,opt,name=mem,proto3" json:"mem,omitempty"`
+}
+
+func (x *FreeArgs) Reset() {
+	*x = FreeArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remote_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FreeArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FreeArgs) ProtoMessage() {}
+
+func (x *FreeArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FreeArgs.ProtoReflect.Descriptor instead.
+func (*FreeArgs) Descriptor() ([]byte, []int) {
+	return file_remote_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FreeArgs) GetMem() uint64 {
+	if x != nil {
+		return x.Mem
+	}
+	return 0
+}
+
+// CopyInArgs copies data to the device memory at offset bytes into dst.
+type CopyInArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dst    uint64 `protobuf:"varint,1,opt,name=dst,proto3" json:"dst,omitempty"`
+	Offset int64  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Data   []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *CopyInArgs) Reset() {
+	*x = CopyInArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remote_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CopyInArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyInArgs) ProtoMessage() {}
+
+func (x *CopyInArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyInArgs.ProtoReflect.Descriptor instead.
+func (*CopyInArgs) Descriptor() ([]byte, []int) {
+	return file_remote_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CopyInArgs) GetDst() uint64 {
+	if x != nil {
+		return x.Dst
+	}
+	return 0
+}
+
+func (x *CopyInArgs) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *CopyInArgs) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// CopyOutArgs copies size bytes of device memory from offset bytes into src.
+type CopyOutArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Src    uint64 `protobuf:"varint,1,opt,name=src,proto3" json:"src,omitempty"`
+	Offset int64  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Size   int64  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (x *CopyOutArgs) Reset() {
+	*x = CopyOutArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remote_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CopyOutArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyOutArgs) ProtoMessage() {}
+
+func (x *CopyOutArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyOutArgs.ProtoReflect.Descriptor instead.
+func (*CopyOutArgs) Descriptor() ([]byte, []int) {
+	return file_remote_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CopyOutArgs) GetSrc() uint64 {
+	if x != nil {
+		return x.Src
+	}
+	return 0
+}
+
+func (x *CopyOutArgs) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *CopyOutArgs) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type CopyOutReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *CopyOutReply) Reset() {
+	*x = CopyOutReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remote_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CopyOutReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyOutReply) ProtoMessage() {}
+
+func (x *CopyOutReply) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyOutReply.ProtoReflect.Descriptor instead.
+func (*CopyOutReply) Descriptor() ([]byte, []int) {
+	return file_remote_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CopyOutReply) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// CopyArgs copies size bytes between allocations on the device.
+type CopyArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dst       uint64 `protobuf:"varint,1,opt,name=dst,proto3" json:"dst,omitempty"`
+	Src       uint64 `protobuf:"varint,2,opt,name=src,proto3" json:"src,omitempty"`
+	DstOffset int64  `protobuf:"varint,3,opt,name=dst_offset,json=dstOffset,proto3" json:"dst_offset,omitempty"`
+	SrcOffset int64  `protobuf:"varint,4,opt,name=src_offset,json=srcOffset,proto3" json:"src_offset,omitempty"`
+	Size      int64  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (x *CopyArgs) Reset() {
+	*x = CopyArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remote_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CopyArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyArgs) ProtoMessage() {}
+
+func (x *CopyArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyArgs.ProtoReflect.Descriptor instead.
+func (*CopyArgs) Descriptor() ([]byte, []int) {
+	return file_remote_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CopyArgs) GetDst() uint64 {
+	if x != nil {
+		return x.Dst
+	}
+	return 0
+}
+
+func (x *CopyArgs) GetSrc() uint64 {
+	if x != nil {
+		return x.Src
+	}
+	return 0
+}
+
+func (x *CopyArgs) GetDstOffset() int64 {
+	if x != nil {
+		return x.DstOffset
+	}
+	return 0
+}
+
+func (x *CopyArgs) GetSrcOffset() int64 {
+	if x != nil {
+		return x.SrcOffset
+	}
+	return 0
+}
+
+func (x *CopyArgs) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// LaunchArgs launches a kernel of a source registered with the server. The launch is either configured by threads,
+// for kernels with grid-stride loops, or by grid and block, of 3 dimensions each, as in package pipeline.
+type LaunchArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source    string  `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"` // the name of the source in the registry of the server
+	Kernel    string  `protobuf:"bytes,2,opt,name=kernel,proto3" json:"kernel,omitempty"`
+	Dtype     string  `protobuf:"bytes,3,opt,name=dtype,proto3" json:"dtype,omitempty"` // the element type the source is compiled for, as cu.Dtype marshals it
+	Threads   int32   `protobuf:"varint,4,opt,name=threads,proto3" json:"threads,omitempty"`
+	Grid      []int32 `protobuf:"varint,5,rep,packed,name=grid,proto3" json:"grid,omitempty"`
+	Block     []int32 `protobuf:"varint,6,rep,packed,name=block,proto3" json:"block,omitempty"`
+	SharedMem int32   `protobuf:"varint,7,opt,name=shared_mem,json=sharedMem,proto3" json:"shared_mem,omitempty"`
+	Args      []*Arg  `protobuf:"bytes,8,rep,name=args,proto3" json:"args,omitempty"`
+}
+
+func (x *LaunchArgs) Reset() {
+	*x = LaunchArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remote_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LaunchArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LaunchArgs) ProtoMessage() {}
+
+func (x *LaunchArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LaunchArgs.ProtoReflect.Descriptor instead.
+func (*LaunchArgs) Descriptor() ([]byte, []int) {
+	return file_remote_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *LaunchArgs) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *LaunchArgs) GetKernel() string {
+	if x != nil {
+		return x.Kernel
+	}
+	return ""
+}
+
+func (x *LaunchArgs) GetDtype() string {
+	if x != nil {
+		return x.Dtype
+	}
+	return ""
+}
+
+func (x *LaunchArgs) GetThreads() int32 {
+	if x != nil {
+		return x.Threads
+	}
+	return 0
+}
+
+func (x *LaunchArgs) GetGrid() []int32 {
+	if x != nil {
+		return x.Grid
+	}
+	return nil
+}
+
+func (x *LaunchArgs) GetBlock() []int32 {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *LaunchArgs) GetSharedMem() int32 {
+	if x != nil {
+		return x.SharedMem
+	}
+	return 0
+}
+
+func (x *LaunchArgs) GetArgs() []*Arg {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+// Arg is an argument of a kernel: the device pointer of an allocation, a long long or a double.
+type Arg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Value:
+	//	*Arg_Mem
+	//	*Arg_Int
+	//	*Arg_Float
+	Value  isArg_Value `protobuf_oneof:"value"`
+	Offset int64       `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"` // in bytes, into mem
+}
+
+func (x *Arg) Reset() {
+	*x = Arg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remote_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Arg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Arg) ProtoMessage() {}
+
+func (x *Arg) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Arg.ProtoReflect.Descriptor instead.
+func (*Arg) Descriptor() ([]byte, []int) {
+	return file_remote_proto_rawDescGZIP(), []int{9}
+}
+
+func (m *Arg) GetValue() isArg_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (x *Arg) GetMem() uint64 {
+	if x, ok := x.GetValue().(*Arg_Mem); ok {
+		return x.Mem
+	}
+	return 0
+}
+
+func (x *Arg) GetInt() int64 {
+	if x, ok := x.GetValue().(*Arg_Int); ok {
+		return x.Int
+	}
+	return 0
+}
+
+func (x *Arg) GetFloat() float64 {
+	if x, ok := x.GetValue().(*Arg_Float); ok {
+		return x.Float
+	}
+	return 0
+}
+
+func (x *Arg) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type isArg_Value interface {
+	isArg_Value()
+}
+
+type Arg_Mem struct {
+	Mem uint64 `protobuf:"varint,1,opt,name=mem,proto3,oneof"`
+}
+
+type Arg_Int struct {
+	Int int64 `protobuf:"varint,2,opt,name=int,proto3,oneof"`
+}
+
+type Arg_Float struct {
+	Float float64 `protobuf:"fixed64,3,opt,name=float,proto3,oneof"`
+}
+
+func (*Arg_Mem) isArg_Value() {}
+
+func (*Arg_Int) isArg_Value() {}
+
+func (*Arg_Float) isArg_Value() {}
+
+// GemmArgs computes
+//
+//	C = beta * C + alpha * op(A) * op(B)
+//
+// on column-major matrices of dtype elements, as cuBLAS does. float16 matrices are accumulated in float32.
+type GemmArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dtype  string  `protobuf:"bytes,1,opt,name=dtype,proto3" json:"dtype,omitempty"`
+	TransA bool    `protobuf:"varint,2,opt,name=trans_a,json=transA,proto3" json:"trans_a,omitempty"`
+	TransB bool    `protobuf:"varint,3,opt,name=trans_b,json=transB,proto3" json:"trans_b,omitempty"`
+	M      int32   `protobuf:"varint,4,opt,name=m,proto3" json:"m,omitempty"`
+	N      int32   `protobuf:"varint,5,opt,name=n,proto3" json:"n,omitempty"`
+	K      int32   `protobuf:"varint,6,opt,name=k,proto3" json:"k,omitempty"`
+	Alpha  float64 `protobuf:"fixed64,7,opt,name=alpha,proto3" json:"alpha,omitempty"`
+	Beta   float64 `protobuf:"fixed64,8,opt,name=beta,proto3" json:"beta,omitempty"`
+	A      uint64  `protobuf:"varint,9,opt,name=a,proto3" json:"a,omitempty"`
+	B      uint64  `protobuf:"varint,10,opt,name=b,proto3" json:"b,omitempty"`
+	C      uint64  `protobuf:"varint,11,opt,name=c,proto3" json:"c,omitempty"`
+	Lda    int32   `protobuf:"varint,12,opt,name=lda,proto3" json:"lda,omitempty"`
+	Ldb    int32   `protobuf:"varint,13,opt,name=ldb,proto3" json:"ldb,omitempty"`
+	Ldc    int32   `protobuf:"varint,14,opt,name=ldc,proto3" json:"ldc,omitempty"`
+}
+
+func (x *GemmArgs) Reset() {
+	*x = GemmArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_remote_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GemmArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GemmArgs) ProtoMessage() {}
+
+func (x *GemmArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GemmArgs.ProtoReflect.Descriptor instead.
+func (*GemmArgs) Descriptor() ([]byte, []int) {
+	return file_remote_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GemmArgs) GetDtype() string {
+	if x != nil {
+		return x.Dtype
+	}
+	return ""
+}
+
+func (x *GemmArgs) GetTransA() bool {
+	if x != nil {
+		return x.TransA
+	}
+	return false
+}
+
+func (x *GemmArgs) GetTransB() bool {
+	if x != nil {
+		return x.TransB
+	}
+	return false
+}
+
+func (x *GemmArgs) GetM() int32 {
+	if x != nil {
+		return x.M
+	}
+	return 0
+}
+
+func (x *GemmArgs) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *GemmArgs) GetK() int32 {
+	if x != nil {
+		return x.K
+	}
+	return 0
+}
+
+func (x *GemmArgs) GetAlpha() float64 {
+	if x != nil {
+		return x.Alpha
+	}
+	return 0
+}
+
+func (x *GemmArgs) GetBeta() float64 {
+	if x != nil {
+		return x.Beta
+	}
+	return 0
+}
+
+func (x *GemmArgs) GetA() uint64 {
+	if x != nil {
+		return x.A
+	}
+	return 0
+}
+
+func (x *GemmArgs) GetB() uint64 {
+	if x != nil {
+		return x.B
+	}
+	return 0
+}
+
+func (x *GemmArgs) GetC() uint64 {
+	if x != nil {
+		return x.C
+	}
+	return 0
+}
+
+func (x *GemmArgs) GetLda() int32 {
+	if x != nil {
+		return x.Lda
+	}
+	return 0
+}
+
+func (x *GemmArgs) GetLdb() int32 {
+	if x != nil {
+		return x.Ldb
+	}
+	return 0
+}
+
+func (x *GemmArgs) GetLdc() int32 {
+	if x != nil {
+		return x.Ldc
+	}
+	return 0
+}
+
+var File_remote_proto protoreflect.FileDescriptor
+
+var file_remote_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12,
+	0x67, 0x6f, 0x72, 0x67, 0x6f, 0x6e, 0x69, 0x61, 0x2e, 0x63, 0x75, 0x2e, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1f, 0x0a, 0x09, 0x41,
+	0x6c, 0x6c, 0x6f, 0x63, 0x41, 0x72, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x1e, 0x0a, 0x0a,
+	0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x65,
+	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6d, 0x65, 0x6d, 0x22, 0x1c, 0x0a, 0x08,
+	0x46, 0x72, 0x65, 0x65, 0x41, 0x72, 0x67, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x65, 0x6d, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6d, 0x65, 0x6d, 0x22, 0x4a, 0x0a, 0x0a, 0x43, 0x6f,
+	0x70, 0x79, 0x49, 0x6e, 0x41, 0x72, 0x67, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x73, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x64, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x4b, 0x0a, 0x0b, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x75,
+	0x74, 0x41, 0x72, 0x67, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x72, 0x63, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x03, 0x73, 0x72, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x22, 0x22, 0x0a, 0x0c, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x80, 0x01, 0x0a, 0x08, 0x43, 0x6f, 0x70, 0x79,
+	0x41, 0x72, 0x67, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x03, 0x64, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x72, 0x63, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x72, 0x63, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x73, 0x74, 0x5f,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x64, 0x73,
+	0x74, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x72, 0x63, 0x5f, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x72, 0x63,
+	0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x22, 0xe2, 0x01, 0x0a, 0x0a, 0x4c,
+	0x61, 0x75, 0x6e, 0x63, 0x68, 0x41, 0x72, 0x67, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x64, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x72, 0x69,
+	0x64, 0x18, 0x05, 0x20, 0x03, 0x28, 0x05, 0x52, 0x04, 0x67, 0x72, 0x69, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x06, 0x20, 0x03, 0x28, 0x05, 0x52, 0x05, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x6d, 0x65,
+	0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x4d,
+	0x65, 0x6d, 0x12, 0x2b, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x67, 0x6f, 0x72, 0x67, 0x6f, 0x6e, 0x69, 0x61, 0x2e, 0x63, 0x75, 0x2e, 0x72,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x41, 0x72, 0x67, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x22,
+	0x66, 0x0a, 0x03, 0x41, 0x72, 0x67, 0x12, 0x12, 0x0a, 0x03, 0x6d, 0x65, 0x6d, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x03, 0x6d, 0x65, 0x6d, 0x12, 0x12, 0x0a, 0x03, 0x69, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x03, 0x69, 0x6e, 0x74, 0x12, 0x16,
+	0x0a, 0x05, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52,
+	0x05, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x42, 0x07,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x86, 0x02, 0x0a, 0x08, 0x47, 0x65, 0x6d, 0x6d,
+	0x41, 0x72, 0x67, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x64, 0x74, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x5f, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x41, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x5f, 0x62, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x42, 0x12, 0x0c, 0x0a, 0x01,
+	0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x6d, 0x12, 0x0c, 0x0a, 0x01, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x6e, 0x12, 0x0c, 0x0a, 0x01, 0x6b, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x01, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x12, 0x12, 0x0a, 0x04,
+	0x62, 0x65, 0x74, 0x61, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x62, 0x65, 0x74, 0x61,
+	0x12, 0x0c, 0x0a, 0x01, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x01, 0x61, 0x12, 0x0c,
+	0x0a, 0x01, 0x62, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x01, 0x62, 0x12, 0x0c, 0x0a, 0x01,
+	0x63, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x01, 0x63, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x64,
+	0x61, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6c, 0x64, 0x61, 0x12, 0x10, 0x0a, 0x03,
+	0x6c, 0x64, 0x62, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6c, 0x64, 0x62, 0x12, 0x10,
+	0x0a, 0x03, 0x6c, 0x64, 0x63, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6c, 0x64, 0x63,
+	0x32, 0xac, 0x04, 0x0a, 0x02, 0x43, 0x55, 0x12, 0x46, 0x0a, 0x05, 0x41, 0x6c, 0x6c, 0x6f, 0x63,
+	0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x72, 0x67, 0x6f, 0x6e, 0x69, 0x61, 0x2e, 0x63, 0x75, 0x2e, 0x72,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x41, 0x72, 0x67, 0x73, 0x1a,
+	0x1e, 0x2e, 0x67, 0x6f, 0x72, 0x67, 0x6f, 0x6e, 0x69, 0x61, 0x2e, 0x63, 0x75, 0x2e, 0x72, 0x65,
+	0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x3f, 0x0a, 0x04, 0x46, 0x72, 0x65, 0x65, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x72, 0x67, 0x6f, 0x6e,
+	0x69, 0x61, 0x2e, 0x63, 0x75, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x46, 0x72, 0x65,
+	0x65, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x19, 0x2e, 0x67, 0x6f, 0x72, 0x67, 0x6f, 0x6e, 0x69, 0x61,
+	0x2e, 0x63, 0x75, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x12, 0x43, 0x0a, 0x06, 0x43, 0x6f, 0x70, 0x79, 0x49, 0x6e, 0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x72,
+	0x67, 0x6f, 0x6e, 0x69, 0x61, 0x2e, 0x63, 0x75, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e,
+	0x43, 0x6f, 0x70, 0x79, 0x49, 0x6e, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x19, 0x2e, 0x67, 0x6f, 0x72,
+	0x67, 0x6f, 0x6e, 0x69, 0x61, 0x2e, 0x63, 0x75, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x4c, 0x0a, 0x07, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74,
+	0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x72, 0x67, 0x6f, 0x6e, 0x69, 0x61, 0x2e, 0x63, 0x75, 0x2e, 0x72,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x41, 0x72, 0x67,
+	0x73, 0x1a, 0x20, 0x2e, 0x67, 0x6f, 0x72, 0x67, 0x6f, 0x6e, 0x69, 0x61, 0x2e, 0x63, 0x75, 0x2e,
+	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x3f, 0x0a, 0x04, 0x43, 0x6f, 0x70, 0x79, 0x12, 0x1c, 0x2e, 0x67, 0x6f,
+	0x72, 0x67, 0x6f, 0x6e, 0x69, 0x61, 0x2e, 0x63, 0x75, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x2e, 0x43, 0x6f, 0x70, 0x79, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x19, 0x2e, 0x67, 0x6f, 0x72, 0x67,
+	0x6f, 0x6e, 0x69, 0x61, 0x2e, 0x63, 0x75, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x12, 0x43, 0x0a, 0x06, 0x4c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x12, 0x1e,
+	0x2e, 0x67, 0x6f, 0x72, 0x67, 0x6f, 0x6e, 0x69, 0x61, 0x2e, 0x63, 0x75, 0x2e, 0x72, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x2e, 0x4c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x19,
+	0x2e, 0x67, 0x6f, 0x72, 0x67, 0x6f, 0x6e, 0x69, 0x61, 0x2e, 0x63, 0x75, 0x2e, 0x72, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3f, 0x0a, 0x04, 0x47, 0x65, 0x6d,
+	0x6d, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x72, 0x67, 0x6f, 0x6e, 0x69, 0x61, 0x2e, 0x63, 0x75, 0x2e,
+	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x47, 0x65, 0x6d, 0x6d, 0x41, 0x72, 0x67, 0x73, 0x1a,
+	0x19, 0x2e, 0x67, 0x6f, 0x72, 0x67, 0x6f, 0x6e, 0x69, 0x61, 0x2e, 0x63, 0x75, 0x2e, 0x72, 0x65,
+	0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x43, 0x0a, 0x0b, 0x53, 0x79,
+	0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x65, 0x12, 0x19, 0x2e, 0x67, 0x6f, 0x72, 0x67,
+	0x6f, 0x6e, 0x69, 0x61, 0x2e, 0x63, 0x75, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x67, 0x6f, 0x72, 0x67, 0x6f, 0x6e, 0x69, 0x61, 0x2e,
+	0x63, 0x75, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42,
+	0x18, 0x5a, 0x16, 0x67, 0x6f, 0x72, 0x67, 0x6f, 0x6e, 0x69, 0x61, 0x2e, 0x6f, 0x72, 0x67, 0x2f,
+	0x63, 0x75, 0x2f, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_remote_proto_rawDescOnce sync.Once
+	file_remote_proto_rawDescData = file_remote_proto_rawDesc
+)
+
+func file_remote_proto_rawDescGZIP() []byte {
+	file_remote_proto_rawDescOnce.Do(func() {
+		file_remote_proto_rawDescData = protoimpl.X.CompressGZIP(file_remote_proto_rawDescData)
+	})
+	return file_remote_proto_rawDescData
+}
+
+var file_remote_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_remote_proto_goTypes = []interface{}{
+	(*Empty)(nil),        // 0: gorgonia.cu.remote.Empty
+	(*AllocArgs)(nil),    // 1: gorgonia.cu.remote.AllocArgs
+	(*AllocReply)(nil),   // 2: gorgonia.cu.remote.AllocReply
+	(*FreeArgs)(nil),     // 3: gorgonia.cu.remote.FreeArgs
+	(*CopyInArgs)(nil),   // 4: gorgonia.cu.remote.CopyInArgs
+	(*CopyOutArgs)(nil),  // 5: gorgonia.cu.remote.CopyOutArgs
+	(*CopyOutReply)(nil), // 6: gorgonia.cu.remote.CopyOutReply
+	(*CopyArgs)(nil),     // 7: gorgonia.cu.remote.CopyArgs
+	(*LaunchArgs)(nil),   // 8: gorgonia.cu.remote.LaunchArgs
+	(*Arg)(nil),          // 9: gorgonia.cu.remote.Arg
+	(*GemmArgs)(nil),     // 10: gorgonia.cu.remote.GemmArgs
+}
+var file_remote_proto_depIdxs = []int32{
+	9,  // 0: gorgonia.cu.remote.LaunchArgs.args:type_name -> gorgonia.cu.remote.Arg
+	1,  // 1: gorgonia.cu.remote.CU.Alloc:input_type -> gorgonia.cu.remote.AllocArgs
+	3,  // 2: gorgonia.cu.remote.CU.Free:input_type -> gorgonia.cu.remote.FreeArgs
+	4,  // 3: gorgonia.cu.remote.CU.CopyIn:input_type -> gorgonia.cu.remote.CopyInArgs
+	5,  // 4: gorgonia.cu.remote.CU.CopyOut:input_type -> gorgonia.cu.remote.CopyOutArgs
+	7,  // 5: gorgonia.cu.remote.CU.Copy:input_type -> gorgonia.cu.remote.CopyArgs
+	8,  // 6: gorgonia.cu.remote.CU.Launch:input_type -> gorgonia.cu.remote.LaunchArgs
+	10, // 7: gorgonia.cu.remote.CU.Gemm:input_type -> gorgonia.cu.remote.GemmArgs
+	0,  // 8: gorgonia.cu.remote.CU.Synchronize:input_type -> gorgonia.cu.remote.Empty
+	2,  // 9: gorgonia.cu.remote.CU.Alloc:output_type -> gorgonia.cu.remote.AllocReply
+	0,  // 10: gorgonia.cu.remote.CU.Free:output_type -> gorgonia.cu.remote.Empty
+	0,  // 11: gorgonia.cu.remote.CU.CopyIn:output_type -> gorgonia.cu.remote.Empty
+	6,  // 12: gorgonia.cu.remote.CU.CopyOut:output_type -> gorgonia.cu.remote.CopyOutReply
+	0,  // 13: gorgonia.cu.remote.CU.Copy:output_type -> gorgonia.cu.remote.Empty
+	0,  // 14: gorgonia.cu.remote.CU.Launch:output_type -> gorgonia.cu.remote.Empty
+	0,  // 15: gorgonia.cu.remote.CU.Gemm:output_type -> gorgonia.cu.remote.Empty
+	0,  // 16: gorgonia.cu.remote.CU.Synchronize:output_type -> gorgonia.cu.remote.Empty
+	9,  // [9:17] is the sub-list for method output_type
+	1,  // [1:9] is the sub-list for method input_type
+	1,  // [1:1] is the sub-list for extension type_name
+	1,  // [1:1] is the sub-list for extension extendee
+	0,  // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_remote_proto_init() }
+func file_remote_proto_init() {
+	if File_remote_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_remote_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_remote_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AllocArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_remote_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AllocReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_remote_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FreeArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_remote_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopyInArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_remote_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopyOutArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_remote_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopyOutReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_remote_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopyArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_remote_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LaunchArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_remote_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Arg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_remote_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GemmArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_remote_proto_msgTypes[9].OneofWrappers = []interface{}{
+		(*Arg_Mem)(nil),
+		(*Arg_Int)(nil),
+		(*Arg_Float)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_remote_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_remote_proto_goTypes,
+		DependencyIndexes: file_remote_proto_depIdxs,
+		MessageInfos:      file_remote_proto_msgTypes,
+	}.Build()
+	File_remote_proto = out.File
+	file_remote_proto_rawDesc = nil
+	file_remote_proto_goTypes = nil
+	file_remote_proto_depIdxs = nil
+}
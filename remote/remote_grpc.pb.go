@@ -0,0 +1,390 @@
+// The protocol between cuserver and cuclient. See the documentation of package remote.
+//
+// The Go code is generated with protoc-gen-go and protoc-gen-go-grpc:
+//
+//	protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative remote.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: remote.proto
+
+package remote
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	CU_Alloc_FullMethodName       = "/gorgonia.cu.remote.CU/Alloc"
+	CU_Free_FullMethodName        = "/gorgonia.cu.remote.CU/Free"
+	CU_CopyIn_FullMethodName      = "/gorgonia.cu.remote.CU/CopyIn"
+	CU_CopyOut_FullMethodName     = "/gorgonia.cu.remote.CU/CopyOut"
+	CU_Copy_FullMethodName        = "/gorgonia.cu.remote.CU/Copy"
+	CU_Launch_FullMethodName      = "/gorgonia.cu.remote.CU/Launch"
+	CU_Gemm_FullMethodName        = "/gorgonia.cu.remote.CU/Gemm"
+	CU_Synchronize_FullMethodName = "/gorgonia.cu.remote.CU/Synchronize"
+)
+
+// CUClient is the client API for CU service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CUClient interface {
+	// Alloc allocates device memory.
+	Alloc(ctx context.Context, in *AllocArgs, opts ...grpc.CallOption) (*AllocReply, error)
+	// Free frees device memory.
+	Free(ctx context.Context, in *FreeArgs, opts ...grpc.CallOption) (*Empty, error)
+	// CopyIn copies data to device memory.
+	CopyIn(ctx context.Context, in *CopyInArgs, opts ...grpc.CallOption) (*Empty, error)
+	// CopyOut copies device memory out, once the work enqueued before it is done.
+	CopyOut(ctx context.Context, in *CopyOutArgs, opts ...grpc.CallOption) (*CopyOutReply, error)
+	// Copy copies between allocations on the device.
+	Copy(ctx context.Context, in *CopyArgs, opts ...grpc.CallOption) (*Empty, error)
+	// Launch launches a kernel of a source registered with the server.
+	Launch(ctx context.Context, in *LaunchArgs, opts ...grpc.CallOption) (*Empty, error)
+	// Gemm multiplies matrices with cuBLAS.
+	Gemm(ctx context.Context, in *GemmArgs, opts ...grpc.CallOption) (*Empty, error)
+	// Synchronize waits for the work enqueued before it to be done.
+	Synchronize(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type cUClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCUClient(cc grpc.ClientConnInterface) CUClient {
+	return &cUClient{cc}
+}
+
+func (c *cUClient) Alloc(ctx context.Context, in *AllocArgs, opts ...grpc.CallOption) (*AllocReply, error) {
+	out := new(AllocReply)
+	err := c.cc.Invoke(ctx, CU_Alloc_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cUClient) Free(ctx context.Context, in *FreeArgs, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, CU_Free_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cUClient) CopyIn(ctx context.Context, in *CopyInArgs, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, CU_CopyIn_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cUClient) CopyOut(ctx context.Context, in *CopyOutArgs, opts ...grpc.CallOption) (*CopyOutReply, error) {
+	out := new(CopyOutReply)
+	err := c.cc.Invoke(ctx, CU_CopyOut_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cUClient) Copy(ctx context.Context, in *CopyArgs, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, CU_Copy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cUClient) Launch(ctx context.Context, in *LaunchArgs, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, CU_Launch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cUClient) Gemm(ctx context.Context, in *GemmArgs, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, CU_Gemm_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cUClient) Synchronize(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, CU_Synchronize_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CUServer is the server API for CU service.
+// All implementations must embed UnimplementedCUServer
+// for forward compatibility
+type CUServer interface {
+	// Alloc allocates device memory.
+	Alloc(context.Context, *AllocArgs) (*AllocReply, error)
+	// Free frees device memory.
+	Free(context.Context, *FreeArgs) (*Empty, error)
+	// CopyIn copies data to device memory.
+	CopyIn(context.Context, *CopyInArgs) (*Empty, error)
+	// CopyOut copies device memory out, once the work enqueued before it is done.
+	CopyOut(context.Context, *CopyOutArgs) (*CopyOutReply, error)
+	// Copy copies between allocations on the device.
+	Copy(context.Context, *CopyArgs) (*Empty, error)
+	// Launch launches a kernel of a source registered with the server.
+	Launch(context.Context, *LaunchArgs) (*Empty, error)
+	// Gemm multiplies matrices with cuBLAS.
+	Gemm(context.Context, *GemmArgs) (*Empty, error)
+	// Synchronize waits for the work enqueued before it to be done.
+	Synchronize(context.Context, *Empty) (*Empty, error)
+	mustEmbedUnimplementedCUServer()
+}
+
+// UnimplementedCUServer must be embedded to have forward compatible implementations.
+type UnimplementedCUServer struct {
+}
+
+func (UnimplementedCUServer) Alloc(context.Context, *AllocArgs) (*AllocReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Alloc not implemented")
+}
+func (UnimplementedCUServer) Free(context.Context, *FreeArgs) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Free not implemented")
+}
+func (UnimplementedCUServer) CopyIn(context.Context, *CopyInArgs) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopyIn not implemented")
+}
+func (UnimplementedCUServer) CopyOut(context.Context, *CopyOutArgs) (*CopyOutReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopyOut not implemented")
+}
+func (UnimplementedCUServer) Copy(context.Context, *CopyArgs) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Copy not implemented")
+}
+func (UnimplementedCUServer) Launch(context.Context, *LaunchArgs) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Launch not implemented")
+}
+func (UnimplementedCUServer) Gemm(context.Context, *GemmArgs) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Gemm not implemented")
+}
+func (UnimplementedCUServer) Synchronize(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Synchronize not implemented")
+}
+func (UnimplementedCUServer) mustEmbedUnimplementedCUServer() {}
+
+// UnsafeCUServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CUServer will
+// result in compilation errors.
+type UnsafeCUServer interface {
+	mustEmbedUnimplementedCUServer()
+}
+
+func RegisterCUServer(s grpc.ServiceRegistrar, srv CUServer) {
+	s.RegisterService(&CU_ServiceDesc, srv)
+}
+
+func _CU_Alloc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AllocArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CUServer).Alloc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CU_Alloc_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CUServer).Alloc(ctx, req.(*AllocArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CU_Free_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreeArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CUServer).Free(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CU_Free_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CUServer).Free(ctx, req.(*FreeArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CU_CopyIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyInArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CUServer).CopyIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CU_CopyIn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CUServer).CopyIn(ctx, req.(*CopyInArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CU_CopyOut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyOutArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CUServer).CopyOut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CU_CopyOut_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CUServer).CopyOut(ctx, req.(*CopyOutArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CU_Copy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CUServer).Copy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CU_Copy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CUServer).Copy(ctx, req.(*CopyArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CU_Launch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LaunchArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CUServer).Launch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CU_Launch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CUServer).Launch(ctx, req.(*LaunchArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CU_Gemm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GemmArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CUServer).Gemm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CU_Gemm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CUServer).Gemm(ctx, req.(*GemmArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CU_Synchronize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CUServer).Synchronize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CU_Synchronize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CUServer).Synchronize(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CU_ServiceDesc is the grpc.ServiceDesc for CU service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CU_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gorgonia.cu.remote.CU",
+	HandlerType: (*CUServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Alloc",
+			Handler:    _CU_Alloc_Handler,
+		},
+		{
+			MethodName: "Free",
+			Handler:    _CU_Free_Handler,
+		},
+		{
+			MethodName: "CopyIn",
+			Handler:    _CU_CopyIn_Handler,
+		},
+		{
+			MethodName: "CopyOut",
+			Handler:    _CU_CopyOut_Handler,
+		},
+		{
+			MethodName: "Copy",
+			Handler:    _CU_Copy_Handler,
+		},
+		{
+			MethodName: "Launch",
+			Handler:    _CU_Launch_Handler,
+		},
+		{
+			MethodName: "Gemm",
+			Handler:    _CU_Gemm_Handler,
+		},
+		{
+			MethodName: "Synchronize",
+			Handler:    _CU_Synchronize_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "remote.proto",
+}
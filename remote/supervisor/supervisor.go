@@ -0,0 +1,246 @@
+// Package supervisor runs GPU work in a child process, and restarts the child when it dies, so that a fatal device
+// error, or a crash of the driver, takes down one child instead of a service that serves many tenants.
+//
+// The child is a server of the protocol of package remote on its standard input and output, such as cmd/cuserver.
+// The child registers the kernel sources itself, as clients cannot (cmd/cuserver loads them with -sources). Each time
+// it is started, the child is set up from a Manifest: the buffers are allocated and filled with their initial contents. The work in flight when the child dies, and what it wrote to
+// the buffers, is lost: Do reports it with a *RestartError, and the caller decides whether to do it again.
+package supervisor // import "gorgonia.org/cu/remote/supervisor"
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorgonia.org/cu/remote"
+	"gorgonia.org/cu/remote/cuclient"
+)
+
+// Manifest is what a child is set up with each time it is started.
+type Manifest struct {
+	Buffers []Buffer
+}
+
+// Buffer is a named allocation of device memory.
+type Buffer struct {
+	Name string
+	Size int64  // in bytes
+	Data []byte // the initial contents, if any
+}
+
+// RestartError is the error of a call that failed because the child died. The child has been restarted, or failed to
+// restart, when the error is returned.
+type RestartError struct {
+	Err     error // the error of the call
+	Exit    error // how the child exited
+	Restart error // the error of the restart, if it failed
+}
+
+func (e *RestartError) Error() string {
+	if e.Restart != nil {
+		return fmt.Sprintf("child died (%v): %v; and failed to restart: %v", e.Exit, e.Err, e.Restart)
+	}
+	return fmt.Sprintf("child died (%v), and was restarted: %v", e.Exit, e.Err)
+}
+
+// Cause returns the error of the call.
+func (e *RestartError) Cause() error { return e.Err }
+
+// Supervisor runs a child, and restarts it when it dies. A Supervisor is safe for concurrent use: the calls to Do are
+// done one at a time.
+type Supervisor struct {
+	command  func() *exec.Cmd
+	manifest Manifest
+
+	sync.Mutex
+	child    *child
+	restarts int
+}
+
+// child is a running child process, and the connection to it.
+type child struct {
+	cmd     *exec.Cmd
+	client  *cuclient.Client
+	buffers map[string]remote.Handle
+
+	done chan struct{} // closed once the process has exited
+	exit error
+}
+
+// New starts a child, with the command returned by command, and sets it up from the manifest. command is called again
+// for each restart, as an exec.Cmd can only be started once. The standard input and output of the command are
+// connected to the supervisor; its standard error is left as set by command.
+func New(command func() *exec.Cmd, m Manifest) (*Supervisor, error) {
+	s := &Supervisor{command: command, manifest: m}
+	var err error
+	if s.child, err = s.start(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Do calls fn with the client of the child, and the handles of the buffers of the manifest, by name. If fn fails
+// because the connection to the child broke, the child is killed if it did not die, and restarted, and Do returns a
+// *RestartError. The handles are only valid during fn.
+func (s *Supervisor) Do(fn func(c *cuclient.Client, buffers map[string]remote.Handle) error) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.child == nil {
+		ch, err := s.start()
+		if err != nil {
+			return err
+		}
+		s.child = ch
+	}
+
+	err := fn(s.child.client, s.child.buffers)
+	if err == nil || !s.child.broken(err) {
+		return err
+	}
+	s.child.client.Close()
+	s.child.kill()
+	retVal := &RestartError{Err: err, Exit: s.child.exit}
+	s.child, retVal.Restart = s.start()
+	s.restarts++
+	return retVal
+}
+
+// Restarts returns the number of times the child was restarted.
+func (s *Supervisor) Restarts() int {
+	s.Lock()
+	defer s.Unlock()
+	return s.restarts
+}
+
+// Close closes the connection to the child, and kills it.
+func (s *Supervisor) Close() error {
+	s.Lock()
+	defer s.Unlock()
+	if s.child == nil {
+		return nil
+	}
+	s.child.client.Close()
+	s.child.kill()
+	s.child = nil
+	return nil
+}
+
+// start starts a child, and sets it up from the manifest.
+func (s *Supervisor) start() (*child, error) {
+	cmd := s.command()
+	// the pipes are made here rather than by cmd, as cmd.Wait would close them under the reads of the client
+	inR, inW, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		inR.Close()
+		inW.Close()
+		return nil, err
+	}
+	cmd.Stdin, cmd.Stdout = inR, outW
+	err = cmd.Start()
+	inR.Close()
+	outW.Close()
+	if err != nil {
+		inW.Close()
+		outR.Close()
+		return nil, errors.Wrap(err, "Unable to start the child")
+	}
+	client, err := cuclient.NewClient(pipes{outR, inW})
+	if err != nil {
+		inW.Close()
+		outR.Close()
+		cmd.Process.Kill()
+		cmd.Wait()
+		return nil, errors.Wrap(err, "Unable to connect to the child")
+	}
+	ch := &child{
+		cmd:     cmd,
+		client:  client,
+		buffers: make(map[string]remote.Handle),
+		done:    make(chan struct{}),
+	}
+	go func() {
+		ch.exit = cmd.Wait()
+		close(ch.done)
+	}()
+
+	if err = s.setup(ch); err != nil {
+		ch.client.Close()
+		ch.kill()
+		return nil, errors.Wrap(err, "Unable to set up the child")
+	}
+	return ch, nil
+}
+
+// setup allocates the buffers of the manifest in the child.
+func (s *Supervisor) setup(ch *child) error {
+	for _, b := range s.manifest.Buffers {
+		if _, ok := ch.buffers[b.Name]; ok {
+			return errors.Errorf("Duplicate buffer %q", b.Name)
+		}
+		h, err := ch.client.Alloc(b.Size)
+		if err != nil {
+			return errors.Wrapf(err, "Buffer %q", b.Name)
+		}
+		ch.buffers[b.Name] = h
+		if len(b.Data) > 0 {
+			if err = ch.client.CopyIn(h, 0, b.Data); err != nil {
+				return errors.Wrapf(err, "Buffer %q", b.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// kill kills the process if it has not exited, and waits for it.
+func (ch *child) kill() {
+	select {
+	case <-ch.done:
+		return
+	default:
+	}
+	ch.cmd.Process.Kill()
+	<-ch.done
+}
+
+// broken returns whether err is an error of the connection, or whether the process exited, rather than an error
+// returned by the server or by the caller.
+func (ch *child) broken(err error) bool {
+	switch errors.Cause(err) {
+	case io.EOF, io.ErrUnexpectedEOF, io.ErrClosedPipe:
+		return true
+	}
+	if status.Code(errors.Cause(err)) == codes.Unavailable {
+		return true
+	}
+	select {
+	case <-ch.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// pipes is the connection to a child: its standard output, and its standard input.
+type pipes struct {
+	io.ReadCloser
+	w io.WriteCloser
+}
+
+func (p pipes) Write(b []byte) (int, error) { return p.w.Write(b) }
+
+func (p pipes) Close() error {
+	err := p.w.Close()
+	if err2 := p.ReadCloser.Close(); err == nil {
+		err = err2
+	}
+	return err
+}
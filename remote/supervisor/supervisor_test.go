@@ -0,0 +1,112 @@
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"gorgonia.org/cu/remote"
+	"gorgonia.org/cu/remote/cuclient"
+)
+
+// hostService serves the protocol from host memory, in a child process. Its only source is "mine", and launching its
+// kernel "crash" kills the child.
+type hostService struct {
+	remote.UnimplementedCUServer
+	mem [][]byte
+}
+
+func (s *hostService) Alloc(ctx context.Context, args *remote.AllocArgs) (*remote.AllocReply, error) {
+	s.mem = append(s.mem, make([]byte, args.Size))
+	return &remote.AllocReply{Mem: uint64(len(s.mem))}, nil
+}
+
+func (s *hostService) CopyIn(ctx context.Context, args *remote.CopyInArgs) (*remote.Empty, error) {
+	copy(s.mem[args.Dst-1][args.Offset:], args.Data)
+	return &remote.Empty{}, nil
+}
+
+func (s *hostService) CopyOut(ctx context.Context, args *remote.CopyOutArgs) (*remote.CopyOutReply, error) {
+	return &remote.CopyOutReply{Data: s.mem[args.Src-1][args.Offset : args.Offset+args.Size]}, nil
+}
+
+func (s *hostService) Launch(ctx context.Context, args *remote.LaunchArgs) (*remote.Empty, error) {
+	if args.Source != "mine" {
+		return nil, errors.Errorf("Unknown source %q", args.Source)
+	}
+	if args.Kernel == "crash" {
+		os.Exit(2)
+	}
+	return &remote.Empty{}, nil
+}
+
+func TestMain(m *testing.M) {
+	if os.Getenv("SUPERVISOR_TEST_CHILD") == "1" {
+		srv := grpc.NewServer()
+		remote.RegisterCUServer(srv, new(hostService))
+		srv.Serve(remote.ConnListener(remote.Stdio()))
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestSupervisor(t *testing.T) {
+	command := func() *exec.Cmd {
+		cmd := exec.Command(os.Args[0])
+		cmd.Env = append(os.Environ(), "SUPERVISOR_TEST_CHILD=1")
+		return cmd
+	}
+	m := Manifest{
+		Buffers: []Buffer{{Name: "x", Size: 4, Data: []byte{1, 2, 3, 4}}, {Name: "y", Size: 2}},
+	}
+	s, err := New(command, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	write := func(c *cuclient.Client, bufs map[string]remote.Handle) error {
+		return c.CopyIn(bufs["x"], 0, []byte{5, 6})
+	}
+	read := func(want []byte) func(c *cuclient.Client, bufs map[string]remote.Handle) error {
+		return func(c *cuclient.Client, bufs map[string]remote.Handle) error {
+			got, err := c.CopyOut(bufs["x"], 0, 4)
+			if err == nil && !bytes.Equal(got, want) {
+				t.Errorf("Expected %v. Got %v", want, got)
+			}
+			return err
+		}
+	}
+	if err = s.Do(write); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Do(read([]byte{5, 6, 3, 4})); err != nil {
+		t.Fatal(err)
+	}
+
+	// an error of the server does not restart the child
+	err = s.Do(func(c *cuclient.Client, bufs map[string]remote.Handle) error {
+		return c.Launch(&remote.LaunchArgs{Source: "theirs"})
+	})
+	if _, ok := err.(*RestartError); err == nil || ok {
+		t.Errorf("Expected an error of the server. Got %v", err)
+	}
+
+	err = s.Do(func(c *cuclient.Client, bufs map[string]remote.Handle) error {
+		return c.Launch(&remote.LaunchArgs{Source: "mine", Kernel: "crash"})
+	})
+	if re, ok := err.(*RestartError); !ok || re.Restart != nil {
+		t.Fatalf("Expected the child to be restarted. Got %v", err)
+	}
+	if s.Restarts() != 1 {
+		t.Errorf("Expected 1 restart. Got %d", s.Restarts())
+	}
+	// the restarted child is set up from the manifest again
+	if err = s.Do(read([]byte{1, 2, 3, 4})); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,257 @@
+package cu
+
+// #include <cuda.h>
+import "C"
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// recentErrLen is the number of errors kept for Report.
+const recentErrLen = 32
+
+// recentError is a failed call, as written by Report.
+type recentError struct {
+	at     time.Time
+	caller string
+	err    error
+}
+
+// recentErrs is the ring of the last errors returned by the driver, and of the failed batches of BatchedContexts.
+var recentErrs struct {
+	sync.Mutex
+	errs [recentErrLen]recentError
+	n    int // the number of errors recorded
+}
+
+// recordError records the error for Report. skip is the number of frames to skip to find the caller, as in
+// runtime.Caller.
+func recordError(err error, skip int) {
+	caller := "?"
+	if pc, _, _, ok := runtime.Caller(skip + 1); ok {
+		if fn := runtime.FuncForPC(pc); fn != nil {
+			caller = strings.TrimPrefix(fn.Name(), "gorgonia.org/cu.")
+		}
+	}
+	recentErrs.Lock()
+	recentErrs.errs[recentErrs.n%recentErrLen] = recentError{at: time.Now(), caller: caller, err: err}
+	recentErrs.n++
+	recentErrs.Unlock()
+}
+
+// recentErrors returns the recorded errors, oldest first.
+func recentErrors() []recentError {
+	recentErrs.Lock()
+	defer recentErrs.Unlock()
+	var errs []recentError
+	start := 0
+	if recentErrs.n > recentErrLen {
+		start = recentErrs.n - recentErrLen
+	}
+	for i := start; i < recentErrs.n; i++ {
+		errs = append(errs, recentErrs.errs[i%recentErrLen])
+	}
+	return errs
+}
+
+// loadedModule is a module loaded by the package, as written by Report.
+type loadedModule struct {
+	seq    int
+	source string
+	at     time.Time
+}
+
+var modules struct {
+	sync.Mutex
+	m   map[C.CUmodule]loadedModule
+	seq int
+}
+
+func registerModule(m Module, source string) {
+	modules.Lock()
+	defer modules.Unlock()
+	if modules.m == nil {
+		modules.m = make(map[C.CUmodule]loadedModule)
+	}
+	modules.seq++
+	modules.m[m.mod] = loadedModule{seq: modules.seq, source: source, at: time.Now()}
+}
+
+func unregisterModule(m Module) {
+	modules.Lock()
+	delete(modules.m, m.mod)
+	modules.Unlock()
+}
+
+var libraries struct {
+	sync.Mutex
+	names    []string
+	versions map[string]func() (string, error)
+}
+
+// RegisterLibrary registers the version of a library built on the package, such as cuBLAS or cuDNN, for Report to
+// write. The packages of the libraries register themselves when they are imported.
+func RegisterLibrary(name string, version func() (string, error)) {
+	libraries.Lock()
+	defer libraries.Unlock()
+	if libraries.versions == nil {
+		libraries.versions = make(map[string]func() (string, error))
+	}
+	if _, ok := libraries.versions[name]; !ok {
+		libraries.names = append(libraries.names, name)
+	}
+	libraries.versions[name] = version
+}
+
+// Report writes the state of the GPUs and of the package, to be attached to bug reports: the driver version, the
+// properties of the devices, the flags, memory and limits of the context current on the calling thread, the modules
+// loaded, the last errors returned by the driver, and the versions of the libraries registered with RegisterLibrary.
+//
+// The current context is that of the thread of the calling goroutine, which should be locked to it (see
+// runtime.LockOSThread). Failures to query the state are written in the report, in place of the state.
+func Report(w io.Writer) error {
+	errs := recentErrors() // before the queries below record their own failures
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	r := &reportWriter{w: w}
+	r.printf("cu report, %v\n", time.Now().Format(time.RFC3339))
+	v := Version()
+	r.printf("driver: %d (%d.%d)\n", v, v/1000, v%1000/10)
+	if initErr != nil {
+		r.printf("init: %v\n", initErr)
+	}
+	f := CurrentFeatures()
+	r.printf("features: DisablePool=%t ForceSync=%t Debug=%q\n", f.DisablePool, f.ForceSync, f.Debug.String())
+	r.printf("go: %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+
+	r.devices()
+	r.context()
+	r.modules()
+
+	r.printf("\nerrors: %d, most recent last\n", len(errs))
+	for _, e := range errs {
+		r.printf("\t%v %s: %v\n", e.at.Format("15:04:05.000"), e.caller, e.err)
+	}
+
+	libraries.Lock()
+	names := append([]string(nil), libraries.names...)
+	versions := libraries.versions
+	libraries.Unlock()
+	r.printf("\nlibraries:\n")
+	for _, name := range names {
+		v, err := versions[name]()
+		if err != nil {
+			v = fmt.Sprintf("unknown (%v)", err)
+		}
+		r.printf("\t%s: %s\n", name, v)
+	}
+	return r.err
+}
+
+// reportWriter writes a report, and keeps the first error of the writer.
+type reportWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (r *reportWriter) printf(format string, args ...interface{}) {
+	if r.err == nil {
+		_, r.err = fmt.Fprintf(r.w, format, args...)
+	}
+}
+
+func (r *reportWriter) devices() {
+	n, err := NumDevices()
+	if err != nil {
+		r.printf("\ndevices: %v\n", err)
+		return
+	}
+	r.printf("\ndevices: %d\n", n)
+	for d := Device(0); d < Device(n); d++ {
+		name, err := d.Name()
+		if err != nil {
+			r.printf("\tdevice %d: %v\n", d, err)
+			continue
+		}
+		major, minor, _ := d.ComputeCapability()
+		mem, _ := d.TotalMem()
+		r.printf("\tdevice %d: %s, compute capability %d.%d, %d MiB\n", d, name, major, minor, mem>>20)
+		attrs := []struct {
+			name string
+			attr DeviceAttribute
+		}{
+			{"multiprocessors", MultiprocessorCount},
+			{"clock (kHz)", ClockRate},
+			{"memory clock (kHz)", MemoryClockRate},
+			{"memory bus width (bits)", GlobalMemoryBusWidth},
+			{"L2 cache (bytes)", L2CacheSize},
+			{"ECC", EccEnabled},
+			{"PCI domain", PciDomainID},
+			{"PCI bus", PciBusID},
+			{"PCI device", PciDeviceID},
+		}
+		for _, a := range attrs {
+			if v, err := d.Attribute(a.attr); err == nil {
+				r.printf("\t\t%s: %d\n", a.name, v)
+			}
+		}
+	}
+}
+
+func (r *reportWriter) context() {
+	ctx, err := CurrentContext()
+	if err != nil || ctx.ctx == nil {
+		r.printf("\ncontext: none current (%v)\n", err)
+		return
+	}
+	r.printf("\ncontext: %v\n", ctx)
+	if dev, err := CurrentDevice(); err == nil {
+		r.printf("\tdevice: %d\n", dev)
+	}
+	if flags, err := CurrentFlags(); err == nil {
+		r.printf("\tflags: %#x\n", uint(flags))
+	}
+	if free, total, err := MemInfo(); err == nil {
+		r.printf("\tmemory: %d MiB free of %d MiB\n", free>>20, total>>20)
+	}
+	limits := []struct {
+		name  string
+		limit Limit
+	}{
+		{"stack size", StackSize},
+		{"printf FIFO size", PrintfFIFOSize},
+		{"malloc heap size", MallocHeapSize},
+	}
+	for _, l := range limits {
+		if v, err := Limits(l.limit); err == nil {
+			r.printf("\t%s: %d\n", l.name, v)
+		}
+	}
+}
+
+func (r *reportWriter) modules() {
+	type mod struct {
+		m C.CUmodule
+		loadedModule
+	}
+	modules.Lock()
+	mods := make([]mod, 0, len(modules.m))
+	for m, l := range modules.m {
+		mods = append(mods, mod{m, l})
+	}
+	modules.Unlock()
+	sort.Slice(mods, func(i, j int) bool { return mods[i].seq < mods[j].seq })
+
+	r.printf("\nmodules: %d\n", len(mods))
+	for _, m := range mods {
+		r.printf("\t0x%x: %s, loaded %v\n", uintptr(unsafe.Pointer(m.m)), m.source, m.at.Format("15:04:05.000"))
+	}
+}
@@ -0,0 +1,32 @@
+package cu
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReport(t *testing.T) {
+	assert := assert.New(t)
+	for i := 0; i < recentErrLen+2; i++ {
+		recordError(fmt.Errorf("failure %d", i), 0)
+	}
+	RegisterLibrary("testlib", func() (string, error) { return "1.2.3", nil })
+	RegisterLibrary("brokenlib", func() (string, error) { return "", errors.New("not loaded") })
+
+	var buf bytes.Buffer
+	assert.Nil(Report(&buf))
+	out := buf.String()
+	assert.Contains(out, "driver: ")
+	assert.Contains(out, "devices: ")
+	assert.Contains(out, "TestReport: failure 33")
+	assert.NotContains(out, "failure 1\n", "the oldest errors are dropped")
+	assert.Contains(out, "testlib: 1.2.3")
+	assert.Contains(out, "brokenlib: unknown (not loaded)")
+
+	errs := recentErrors()
+	assert.Len(errs, recentErrLen)
+}
@@ -18,9 +18,13 @@ func (err cuResult) String() string {
 
 func result(x C.CUresult) error {
 	err := cuResult(x)
-	if err == Success {
+	switch err {
+	case Success:
 		return nil
+	case NotReady: // the answer of the queries of streams and events, not a failure
+		return err
 	}
+	recordError(err, 1)
 	return err
 }
 
@@ -0,0 +1,117 @@
+package cu
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// SyncPolicy is how the host thread waits for the device when synchronizing with a stream or an event.
+//
+// The scheduling flags of a context (SchedSpin, SchedYield, SchedBlockingSync) set the policy of every synchronization
+// in that context. SyncPolicy allows a different choice to be made for a single synchronization: for example, a
+// service may spin on its latency critical path, and block everywhere else.
+type SyncPolicy byte
+
+const (
+	SyncAuto     SyncPolicy = iota // use the scheduling policy of the context
+	SyncSpin                       // poll continuously. Lowest latency, but uses a full CPU core while waiting
+	SyncYield                      // poll, yielding the processor to other goroutines between polls
+	SyncBlocking                   // sleep until the work has completed. Lowest CPU usage, but higher latency
+)
+
+func (p SyncPolicy) String() string {
+	switch p {
+	case SyncAuto:
+		return "SyncAuto"
+	case SyncSpin:
+		return "SyncSpin"
+	case SyncYield:
+		return "SyncYield"
+	case SyncBlocking:
+		return "SyncBlocking"
+	}
+	return fmt.Sprintf("UnknownSyncPolicy:%d", p)
+}
+
+// maxBackoff is the longest sleep between two polls of an event with SyncBlocking.
+const maxBackoff = time.Millisecond
+
+// SynchronizeWith waits for the work in the stream to complete, with the given policy.
+//
+// With SyncBlocking, a blocking event is recorded on the stream and waited upon, so the calling thread sleeps in the
+// driver regardless of the scheduling flags of the context.
+func (hStream Stream) SynchronizeWith(policy SyncPolicy) error {
+	switch policy {
+	case SyncAuto:
+		return hStream.Synchronize()
+	case SyncSpin, SyncYield:
+		return poll(hStream.Query, policy)
+	case SyncBlocking:
+		return hStream.syncBlocking()
+	}
+	return errors.Errorf("Unknown SyncPolicy %v", policy)
+}
+
+// syncBlocking records a blocking event on the stream, waits for it, and destroys it. An error destroying the event is
+// returned if nothing failed before.
+func (hStream Stream) syncBlocking() (err error) {
+	ev, err := MakeEvent(BlockingSyncEvent | DisableTiming)
+	if err != nil {
+		return errors.Wrap(err, "SynchronizeWith")
+	}
+	defer func() {
+		if derr := DestroyEvent(&ev); derr != nil && err == nil {
+			err = errors.Wrap(derr, "SynchronizeWith: unable to destroy the event")
+		}
+	}()
+	if err = ev.Record(hStream); err != nil {
+		return errors.Wrap(err, "SynchronizeWith")
+	}
+	return ev.Synchronize()
+}
+
+// SynchronizeWith waits for the event to complete, with the given policy.
+//
+// Whether an event blocks is decided when it is created (see BlockingSyncEvent). For events that were not created with
+// BlockingSyncEvent, SyncBlocking polls the event with an increasing sleep (of up to a millisecond) between polls.
+func (hEvent Event) SynchronizeWith(policy SyncPolicy) error {
+	switch policy {
+	case SyncAuto:
+		return hEvent.Synchronize()
+	case SyncSpin, SyncYield, SyncBlocking:
+		return poll(hEvent.Query, policy)
+	}
+	return errors.Errorf("Unknown SyncPolicy %v", policy)
+}
+
+// poll calls query until it stops returning NotReady.
+func poll(query func() error, policy SyncPolicy) error {
+	backoff := time.Microsecond
+	for {
+		err := query()
+		if err != NotReady {
+			return err
+		}
+		switch policy {
+		case SyncYield:
+			runtime.Gosched()
+		case SyncBlocking:
+			time.Sleep(backoff)
+			if backoff *= 2; backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+		}
+	}
+}
+
+// LowLatencyCtx creates a context whose synchronizations spin (SchedSpin).
+// It suits latency critical work, at the cost of a CPU core being busy whenever the host waits for the device.
+func LowLatencyCtx(d Device) *Ctx { return NewContext(d, SchedSpin) }
+
+// LowCPUCtx creates a context whose synchronizations block (SchedBlockingSync).
+// It suits services that share the host with other work, where a waiting thread should not burn a CPU core.
+// Synchronizations take somewhat longer to return once the device is done.
+func LowCPUCtx(d Device) *Ctx { return NewContext(d, SchedBlockingSync) }
@@ -0,0 +1,53 @@
+package cu
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncPolicyString(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("SyncAuto", SyncAuto.String())
+	assert.Equal("SyncSpin", SyncSpin.String())
+	assert.Equal("SyncYield", SyncYield.String())
+	assert.Equal("SyncBlocking", SyncBlocking.String())
+	assert.Equal("UnknownSyncPolicy:9", SyncPolicy(9).String())
+}
+
+func TestPoll(t *testing.T) {
+	failed := errors.New("failed")
+	cases := []struct {
+		notReady int   // the number of polls that find the work not ready
+		final    error // what the poll after them returns
+	}{
+		{0, nil},
+		{3, nil},
+		{20, nil}, // past the longest backoff of SyncBlocking
+		{0, failed},
+		{5, failed},
+		{2, IllegalAddress},
+	}
+	for _, policy := range []SyncPolicy{SyncAuto, SyncSpin, SyncYield, SyncBlocking} {
+		for _, c := range cases {
+			calls := 0
+			query := func() error {
+				calls++
+				if calls <= c.notReady {
+					return NotReady
+				}
+				return c.final
+			}
+			err := poll(query, policy)
+			assert.Equal(t, c.final, err, "%v, not ready %d times", policy, c.notReady)
+			assert.Equal(t, c.notReady+1, calls, "%v, not ready %d times", policy, c.notReady)
+		}
+	}
+}
+
+func TestSynchronizeWithUnknownPolicy(t *testing.T) {
+	assert := assert.New(t)
+	assert.Error(Stream{}.SynchronizeWith(SyncPolicy(9)))
+	assert.Error(Event{}.SynchronizeWith(SyncPolicy(9)))
+}
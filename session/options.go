@@ -0,0 +1,80 @@
+package session
+
+import (
+	"gorgonia.org/cu"
+	cublas "gorgonia.org/cu/blas"
+	"gorgonia.org/cu/kernels"
+	"gorgonia.org/cu/pipeline"
+)
+
+// Option configures a Session.
+type Option func(o *options)
+
+type options struct {
+	device cu.Device
+	flags  cu.ContextFlags
+	ctx    *cu.Ctx
+
+	streams     int
+	streamFlags cu.StreamFlags
+
+	mem      *cu.MemPool
+	events   *cu.EventPool
+	lib      *kernels.Library
+	reg      *pipeline.Registry
+	blasOpts []cublas.ConsOpt
+}
+
+func defaults() options {
+	return options{
+		flags:       cu.SchedAuto,
+		streams:     1,
+		streamFlags: cu.NonBlocking,
+		lib:         kernels.Default,
+		reg:         pipeline.DefaultRegistry,
+	}
+}
+
+// WithDevice sets the device the context of the session is created on. It is device 0 by default.
+func WithDevice(d cu.Device) Option { return func(o *options) { o.device = d } }
+
+// WithContextFlags sets the flags the context of the session is created with. They are cu.SchedAuto by default.
+func WithContextFlags(flags cu.ContextFlags) Option { return func(o *options) { o.flags = flags } }
+
+// WithContext makes the session use an existing context, instead of creating one. The session does not close it.
+func WithContext(ctx *cu.Ctx) Option { return func(o *options) { o.ctx = ctx } }
+
+// WithStreams sets the number of streams of the pool of the session, and the flags they are created with. The pool
+// has one non-blocking stream by default.
+func WithStreams(n int, flags cu.StreamFlags) Option {
+	return func(o *options) {
+		o.streams = n
+		o.streamFlags = flags
+	}
+}
+
+// WithMemPool makes the session use an existing pool of device memory, which must belong to the context of the session.
+// The session closes it.
+func WithMemPool(p *cu.MemPool) Option { return func(o *options) { o.mem = p } }
+
+// WithEventPool makes the session use an existing pool of events, which must belong to the context of the session.
+// The session destroys its events.
+func WithEventPool(p *cu.EventPool) Option { return func(o *options) { o.events = p } }
+
+// WithKernels sets the library the kernels are loaded from, and the registry of their sources. They are kernels.Default
+// and pipeline.DefaultRegistry by default. A nil argument keeps the default.
+func WithKernels(lib *kernels.Library, reg *pipeline.Registry) Option {
+	return func(o *options) {
+		if lib != nil {
+			o.lib = lib
+		}
+		if reg != nil {
+			o.reg = reg
+		}
+	}
+}
+
+// WithBLAS sets the options the cuBLAS handle of the session is created with.
+func WithBLAS(opts ...cublas.ConsOpt) Option {
+	return func(o *options) { o.blasOpts = append(o.blasOpts, opts...) }
+}
@@ -0,0 +1,240 @@
+// Package session ties together what most programs set up before doing work on a GPU: a context, a pool of streams, a
+// pool of device memory and one of events, a cuBLAS handle, and the kernels they launch. A Session is the one object
+// such programs pass around.
+//
+// A Session is created with functional options, which default to a context on device 0, one non-blocking stream, and
+// the kernels of package kernels:
+//
+//	s, err := session.New(session.WithDevice(1), session.WithStreams(4, cu.NonBlocking))
+//	if err != nil {
+//		..error handling..
+//	}
+//	defer s.Close()
+//	err = s.Do(func() error {
+//		mem, err := s.MemPool().Alloc(1 << 20)
+//		..
+//	})
+//
+// The work of a Session runs on the thread of its context, within Do. The handles of other libraries are kept by the
+// session too (see Handle), so that they are closed with it: package cudnn keeps its handle there, for instance.
+package session // import "gorgonia.org/cu/session"
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+	cublas "gorgonia.org/cu/blas"
+	"gorgonia.org/cu/kernels"
+	"gorgonia.org/cu/pipeline"
+)
+
+// Session owns a context, and the resources of the work done in it. The functions of a Session are safe for concurrent
+// use, but the resources they return must be used within Do.
+type Session struct {
+	ctx     *cu.Ctx
+	ownsCtx bool
+
+	streams []cu.Stream
+	next    uint32
+	mem     *cu.MemPool
+	events  *cu.EventPool
+	lib     *kernels.Library
+	reg     *pipeline.Registry
+
+	blasOpts []cublas.ConsOpt
+
+	sync.Mutex
+	blas    *cublas.Standard
+	handles map[interface{}]io.Closer
+	opened  []interface{} // the keys of the handles, in the order they were opened
+	closed  bool
+}
+
+// New creates a Session.
+func New(opts ...Option) (s *Session, err error) {
+	o := defaults()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.streams < 1 {
+		return nil, errors.Errorf("Expected at least one stream. Got %d", o.streams)
+	}
+
+	s = &Session{
+		ctx:      o.ctx,
+		mem:      o.mem,
+		events:   o.events,
+		lib:      o.lib,
+		reg:      o.reg,
+		blasOpts: o.blasOpts,
+		handles:  make(map[interface{}]io.Closer),
+	}
+	if s.ctx == nil {
+		if s.ctx, err = newContext(o.device, o.flags); err != nil {
+			return nil, err
+		}
+		s.ownsCtx = true
+	}
+	if s.mem == nil {
+		s.mem = cu.NewMemPool()
+	}
+	if s.events == nil {
+		s.events = cu.NewEventPool(cu.DisableTiming)
+	}
+
+	err = s.ctx.Do(func() error {
+		for i := 0; i < o.streams; i++ {
+			stream, err := cu.MakeStream(o.streamFlags)
+			if err != nil {
+				return errors.Wrap(err, "Unable to create a stream")
+			}
+			s.streams = append(s.streams, stream)
+		}
+		return nil
+	})
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// newContext creates a context, without panicking as cu.NewContext does.
+func newContext(d cu.Device, flags cu.ContextFlags) (ctx *cu.Ctx, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if err, _ = r.(error); err == nil {
+				err = errors.Errorf("%v", r)
+			}
+			err = errors.Wrapf(err, "Unable to create a context on device %d", int(d))
+		}
+	}()
+	return cu.NewContext(d, flags), nil
+}
+
+// Ctx returns the context of the session.
+func (s *Session) Ctx() *cu.Ctx { return s.ctx }
+
+// Do runs fn on the thread of the context (see (*cu.Ctx).Do).
+func (s *Session) Do(fn func() error) error { return s.ctx.Do(fn) }
+
+// Stream returns a stream of the pool, each of them in turn, so that independent work spreads over the streams.
+func (s *Session) Stream() cu.Stream {
+	i := atomic.AddUint32(&s.next, 1) - 1
+	return s.streams[int(i)%len(s.streams)]
+}
+
+// Streams returns the streams of the pool.
+func (s *Session) Streams() []cu.Stream { return append([]cu.Stream(nil), s.streams...) }
+
+// MemPool returns the pool of device memory of the session.
+func (s *Session) MemPool() *cu.MemPool { return s.mem }
+
+// Events returns the pool of events of the session.
+func (s *Session) Events() *cu.EventPool { return s.events }
+
+// Library returns the library the kernels of the session are loaded from.
+func (s *Session) Library() *kernels.Library { return s.lib }
+
+// Registry returns the registry of the kernel sources of the session.
+func (s *Session) Registry() *pipeline.Registry { return s.reg }
+
+// Function returns the named kernel of the named source of the registry, compiled for dt, and loaded in the context.
+// It must be called within Do.
+func (s *Session) Function(source string, dt cu.Dtype, kernel string) (cu.Function, error) {
+	src, ok := s.reg.Lookup(source)
+	if !ok {
+		return cu.Function{}, errors.Errorf("Unknown source %q", source)
+	}
+	return s.lib.Function(src, dt, kernel)
+}
+
+// BLAS returns the cuBLAS handle of the session, created on first use, with the options of WithBLAS. Its calls must be
+// made within Do, but BLAS itself must not be called within Do, as it creates the handle there.
+func (s *Session) BLAS() (*cublas.Standard, error) {
+	s.Lock()
+	defer s.Unlock()
+	if s.closed {
+		return nil, errors.New("Session is closed")
+	}
+	if s.blas != nil {
+		return s.blas, nil
+	}
+	impl := new(cublas.Standard)
+	opts := append([]cublas.ConsOpt{cublas.WithContext(s.ctx)}, s.blasOpts...)
+	if err := s.ctx.Do(func() error { return impl.Init(opts...) }); err != nil {
+		return nil, err
+	}
+	s.blas = impl
+	return impl, nil
+}
+
+// Handle returns the handle stored under key, opening it with open on first use. open is run within Do. The handles
+// are closed with the session, in the reverse order they were opened. Handle must not be called within Do.
+//
+// Handle is how packages that this package does not import keep their handles in a session: the key is of a type of
+// that package, so that it does not collide with the keys of others.
+func (s *Session) Handle(key interface{}, open func() (io.Closer, error)) (io.Closer, error) {
+	s.Lock()
+	defer s.Unlock()
+	if s.closed {
+		return nil, errors.New("Session is closed")
+	}
+	if h, ok := s.handles[key]; ok {
+		return h, nil
+	}
+	var h io.Closer
+	err := s.ctx.Do(func() (err error) {
+		h, err = open()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.handles[key] = h
+	s.opened = append(s.opened, key)
+	return h, nil
+}
+
+// Close waits for the work of the streams, and releases the resources of the session: the handles, the pools, the
+// streams, and the context if the session created it.
+func (s *Session) Close() error {
+	s.Lock()
+	defer s.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	var retVal error
+	keep := func(err error) {
+		if err != nil && retVal == nil {
+			retVal = err
+		}
+	}
+	keep(s.ctx.Do(func() error {
+		for _, stream := range s.streams {
+			keep(stream.Synchronize())
+		}
+		for i := len(s.opened) - 1; i >= 0; i-- {
+			keep(s.handles[s.opened[i]].Close())
+		}
+		if s.blas != nil {
+			keep(s.blas.Close())
+		}
+		keep(s.events.Destroy())
+		keep(s.mem.Close())
+		for i := range s.streams {
+			keep(s.streams[i].Destroy())
+		}
+		return nil
+	}))
+	s.handles, s.opened, s.blas, s.streams = nil, nil, nil, nil
+	if s.ownsCtx {
+		keep(s.ctx.Close())
+	}
+	return retVal
+}
@@ -0,0 +1,70 @@
+package session
+
+import (
+	"io"
+	"testing"
+
+	"gorgonia.org/cu"
+)
+
+type closer struct{ closed *[]string }
+
+func (c closer) Close() error {
+	*c.closed = append(*c.closed, "handle")
+	return nil
+}
+
+func TestSession(t *testing.T) {
+	if devices, _ := cu.NumDevices(); devices == 0 {
+		t.Skip("NoDevice")
+	}
+	if _, err := New(WithStreams(0, cu.NonBlocking)); err == nil {
+		t.Error("Expected an error for a session without streams")
+	}
+
+	s, err := New(WithStreams(2, cu.NonBlocking))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a, b, c := s.Stream(), s.Stream(), s.Stream(); a == b || a != c {
+		t.Errorf("Expected the streams to be used in turn. Got %v, %v and %v", a, b, c)
+	}
+
+	err = s.Do(func() error {
+		mem, err := s.MemPool().Alloc(1024)
+		if err != nil {
+			return err
+		}
+		return s.MemPool().Free(mem)
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if _, err = s.BLAS(); err != nil {
+		t.Error(err)
+	}
+
+	var closed []string
+	opens := 0
+	open := func() (io.Closer, error) {
+		opens++
+		return closer{&closed}, nil
+	}
+	for i := 0; i < 2; i++ {
+		if _, err = s.Handle("test", open); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if opens != 1 {
+		t.Errorf("Expected the handle to be opened once. Got %d", opens)
+	}
+	if err = s.Close(); err != nil {
+		t.Error(err)
+	}
+	if len(closed) != 1 {
+		t.Errorf("Expected the handle to be closed with the session")
+	}
+	if _, err = s.Handle("other", open); err == nil {
+		t.Error("Expected an error once the session is closed")
+	}
+}
@@ -0,0 +1,194 @@
+// Package cusolver provides bindings to the dense routines of cuSOLVER: the Cholesky (Potrf) and LU (Getrf)
+// factorizations and the solvers that use them, the singular value decomposition (Gesvd), and the eigendecomposition of
+// symmetric matrices (Syevd). Together with package cublas, this covers the linear algebra of dense matrices on the GPU.
+//
+// The matrices are in column major order, of float32 or float64. The routines need a workspace, whose size depends on
+// the routine and the shape of its matrices: the ...Workspace functions return it. A Handle keeps the largest
+// workspace it needed so far, so that repeated calls on matrices of the same shape do not allocate.
+//
+// The routines report the failures of a factorization (a matrix that is not positive definite, a zero pivot, an SVD
+// that did not converge) on the device. They wait for the stream of the handle to read that report, and return it as
+// an *InfoError.
+package cusolver // import "gorgonia.org/cu/solver"
+
+// #include <cusolverDn.h>
+import "C"
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// Handle is a handle to the dense routines of cuSOLVER. It is bound to the context that was current when it was
+// created. A Handle is not safe for concurrent use.
+type Handle struct {
+	h      C.cusolverDnHandle_t
+	stream cu.Stream
+
+	work     cu.DevicePtr
+	workSize int64
+	info     cu.DevicePtr // an int on the device, where the routines report their failures
+}
+
+// NewHandle creates a handle, which works on the null stream unless the WithStream option is given.
+func NewHandle(opts ...Option) (*Handle, error) {
+	var h C.cusolverDnHandle_t
+	if err := result(C.cusolverDnCreate(&h)); err != nil {
+		return nil, errors.Wrap(err, "Unable to create a cuSOLVER handle")
+	}
+	info, err := cu.MemAlloc(4)
+	if err != nil {
+		C.cusolverDnDestroy(h)
+		return nil, errors.Wrap(err, "Unable to allocate the info of a cuSOLVER handle")
+	}
+	return newHandle(&Handle{h: h, info: info}, opts)
+}
+
+func newHandle(h *Handle, opts []Option) (*Handle, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.stream != cu.NoStream {
+		if err := h.SetStream(o.stream); err != nil {
+			h.Close()
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// Option configures a Handle when it is created.
+type Option func(o *options)
+
+type options struct {
+	stream cu.Stream
+}
+
+// WithStream sets the stream the work of the handle is enqueued on (see SetStream).
+func WithStream(stream cu.Stream) Option { return func(o *options) { o.stream = stream } }
+
+// SetStream sets the stream the work of the handle is enqueued on.
+func (h *Handle) SetStream(stream cu.Stream) error {
+	if err := result(C.cusolverDnSetStream(h.h, C.cudaStream_t(stream.Pointer()))); err != nil {
+		return err
+	}
+	h.stream = stream
+	return nil
+}
+
+// Close frees the workspace of the handle, and destroys it.
+func (h *Handle) Close() error {
+	if h.h == nil {
+		return nil
+	}
+	h.stream.Synchronize()
+	for _, p := range []*cu.DevicePtr{&h.work, &h.info} {
+		if *p != 0 {
+			cu.MemFree(*p)
+			*p = 0
+		}
+	}
+	h.workSize = 0
+	err := result(C.cusolverDnDestroy(h.h))
+	h.h = nil
+	return err
+}
+
+// workspace returns a workspace of at least size bytes. A smaller workspace is freed: the work that used it is done,
+// as the routines wait for the stream of the handle.
+func (h *Handle) workspace(size int64) (cu.DevicePtr, error) {
+	if size <= h.workSize {
+		return h.work, nil
+	}
+	if h.work != 0 {
+		cu.MemFree(h.work)
+		h.work, h.workSize = 0, 0
+	}
+	var err error
+	if h.work, err = cu.MemAlloc(size); err != nil {
+		h.work = 0
+		return 0, errors.Wrapf(err, "Unable to allocate a workspace of %d bytes", size)
+	}
+	h.workSize = size
+	return h.work, nil
+}
+
+// check waits for the routine, and returns the failure it reported, if any.
+func (h *Handle) check(routine string) error {
+	if err := h.stream.Synchronize(); err != nil {
+		return errors.Wrap(err, routine)
+	}
+	var info int32
+	if err := cu.MemcpyDtoH(unsafe.Pointer(&info), h.info, 4); err != nil {
+		return errors.Wrapf(err, "%s: unable to read the info", routine)
+	}
+	if info != 0 {
+		return &InfoError{Routine: routine, Info: int(info)}
+	}
+	return nil
+}
+
+// InfoError is a failure reported by a routine. A negative Info means that the parameter -Info was invalid (counting
+// from 1, the handle included). A positive Info means that the routine failed on the matrix: see Error.
+type InfoError struct {
+	Routine string
+	Info    int
+}
+
+func (e *InfoError) Error() string {
+	if e.Info < 0 {
+		return fmt.Sprintf("%s: parameter %d is invalid", e.Routine, -e.Info)
+	}
+	switch e.Routine {
+	case "Potrf":
+		return fmt.Sprintf("Potrf: the leading minor of order %d is not positive definite", e.Info)
+	case "Getrf":
+		return fmt.Sprintf("Getrf: U(%d,%d) is exactly zero, so U is singular", e.Info, e.Info)
+	case "Gesvd":
+		return fmt.Sprintf("Gesvd: %d superdiagonals did not converge to zero", e.Info)
+	case "Syevd":
+		return fmt.Sprintf("Syevd: %d off-diagonal elements did not converge to zero", e.Info)
+	}
+	return fmt.Sprintf("%s: info %d", e.Routine, e.Info)
+}
+
+// Fill tells which triangle of a symmetric matrix is used.
+type Fill int
+
+const (
+	Lower Fill = C.CUBLAS_FILL_MODE_LOWER
+	Upper Fill = C.CUBLAS_FILL_MODE_UPPER
+)
+
+// Matrix is a dense matrix in device memory, in column major order: the element (i,j) is at Ptr + (j*LD + i)*size.
+type Matrix struct {
+	Rows, Cols, LD int
+	Dtype          cu.Dtype
+	Ptr            cu.DevicePtr
+}
+
+// check checks the shape and the type of m.
+func (m Matrix) check(name string, dt cu.Dtype) error {
+	if m.Rows < 0 || m.Cols < 0 || m.LD < m.Rows || m.LD < 1 {
+		return errors.Errorf("Invalid %s: %d×%d with a leading dimension of %d", name, m.Rows, m.Cols, m.LD)
+	}
+	if m.Dtype != dt {
+		return errors.Errorf("%s is %v, instead of %v", name, m.Dtype, dt)
+	}
+	return nil
+}
+
+// checkDtype checks that cuSOLVER supports the type of a.
+func checkDtype(a Matrix) error {
+	if a.Dtype != cu.DtFloat32 && a.Dtype != cu.DtFloat64 {
+		return errors.Errorf("cuSOLVER does not support %v", a.Dtype)
+	}
+	return a.check("A", a.Dtype)
+}
+
+func floats(p cu.DevicePtr) *C.float   { return (*C.float)(p.Pointer()) }
+func doubles(p cu.DevicePtr) *C.double { return (*C.double)(p.Pointer()) }
+func ints(p cu.DevicePtr) *C.int       { return (*C.int)(p.Pointer()) }
@@ -0,0 +1,116 @@
+package cusolver
+
+import (
+	"math"
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"gorgonia.org/cu"
+)
+
+func TestInvalid(t *testing.T) {
+	h := new(Handle)
+	if err := h.Potrf(Lower, Matrix{Rows: 2, Cols: 3, LD: 2, Dtype: cu.DtFloat32}); err == nil {
+		t.Error("Expected an error for a matrix that is not square")
+	}
+	if err := h.Potrf(Lower, Matrix{Rows: 2, Cols: 2, LD: 2, Dtype: cu.DtInt32}); err == nil {
+		t.Error("Expected an error for an unsupported Dtype")
+	}
+	if err := h.Getrf(Matrix{Rows: 3, Cols: 2, LD: 2, Dtype: cu.DtFloat64}, 0); err == nil {
+		t.Error("Expected an error for a leading dimension less than the rows")
+	}
+	a := Matrix{Rows: 3, Cols: 2, LD: 3, Dtype: cu.DtFloat32}
+	if err := h.Gesvd(SVDAll, SVDNone, a, 0, Matrix{Rows: 3, Cols: 2, LD: 3, Dtype: cu.DtFloat32}, Matrix{}); err == nil {
+		t.Error("Expected an error for a U of the wrong shape")
+	}
+	if err := h.Gesvd(SVDOverwrite, SVDOverwrite, a, 0, Matrix{}, Matrix{}); err == nil {
+		t.Error("Expected an error for both U and Vᵀ in place of A")
+	}
+	a.Rows, a.Cols = 2, 3
+	if err := h.Gesvd(SVDNone, SVDNone, a, 0, Matrix{}, Matrix{}); err == nil {
+		t.Error("Expected an error for a wide matrix")
+	}
+
+	if got := (&InfoError{Routine: "Potrf", Info: 2}).Error(); got != "Potrf: the leading minor of order 2 is not positive definite" {
+		t.Errorf("Unexpected message %q", got)
+	}
+	if got := (&InfoError{Routine: "Syevd", Info: -4}).Error(); got != "Syevd: parameter 4 is invalid" {
+		t.Errorf("Unexpected message %q", got)
+	}
+}
+
+func TestFactorizations(t *testing.T) {
+	if devices, _ := cu.NumDevices(); devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := cu.Device(0).MakeContext(cu.SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+
+	h, err := NewHandle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	mem, err := cu.MemAlloc(8 * 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cu.MemFree(mem)
+	a := Matrix{Rows: 2, Cols: 2, LD: 2, Dtype: cu.DtFloat64, Ptr: mem}
+	b := Matrix{Rows: 2, Cols: 1, LD: 2, Dtype: cu.DtFloat64, Ptr: mem + 32}
+	w := mem + 48
+	upload := func(vals ...float64) {
+		if err := cu.MemcpyHtoD(mem, unsafe.Pointer(&vals[0]), int64(len(vals))*8); err != nil {
+			t.Fatal(err)
+		}
+	}
+	download := func() []float64 {
+		retVal := make([]float64, 8)
+		if err := cu.MemcpyDtoH(unsafe.Pointer(&retVal[0]), mem, 64); err != nil {
+			t.Fatal(err)
+		}
+		return retVal
+	}
+	near := func(got, want []float64) bool {
+		for i := range want {
+			if math.Abs(got[i]-want[i]) > 1e-9 {
+				return false
+			}
+		}
+		return true
+	}
+
+	// [4 2; 2 3]·x = [2; 1], so x = [0.5; 0]
+	upload(4, 2, 2, 3, 2, 1)
+	if err = h.Potrf(Lower, a); err != nil {
+		t.Fatal(err)
+	}
+	if err = h.Potrs(Lower, a, b); err != nil {
+		t.Fatal(err)
+	}
+	if got := download(); !near(got[4:6], []float64{0.5, 0}) {
+		t.Errorf("Potrs: expected [0.5 0]. Got %v", got[4:6])
+	}
+
+	upload(1, 2, 2, 1)
+	err = h.Potrf(Lower, a)
+	if ie, ok := err.(*InfoError); !ok || ie.Info != 2 {
+		t.Errorf("Expected the second leading minor not to be positive definite. Got %v", err)
+	}
+
+	// the eigenvalues of [2 1; 1 2] are 1 and 3
+	upload(2, 1, 1, 2)
+	if err = h.Syevd(true, Upper, a, w); err != nil {
+		t.Fatal(err)
+	}
+	if got := download(); !near(got[6:8], []float64{1, 3}) {
+		t.Errorf("Syevd: expected [1 3]. Got %v", got[6:8])
+	}
+}
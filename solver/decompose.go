@@ -0,0 +1,158 @@
+package cusolver
+
+// #include <cusolverDn.h>
+import "C"
+import (
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// SVDJob tells which singular vectors Gesvd computes, and where.
+type SVDJob byte
+
+const (
+	SVDAll       SVDJob = 'A' // all the vectors, in U or VT
+	SVDSlim      SVDJob = 'S' // the first min(m, n) vectors, in U or VT
+	SVDOverwrite SVDJob = 'O' // the first min(m, n) vectors, in place of a
+	SVDNone      SVDJob = 'N' // no vectors
+)
+
+// GesvdWorkspace returns the size in bytes of the workspace of Gesvd on a.
+func (h *Handle) GesvdWorkspace(a Matrix) (int64, error) {
+	if err := checkDtype(a); err != nil {
+		return 0, errors.Wrap(err, "Gesvd")
+	}
+	var lwork C.int
+	var err error
+	switch a.Dtype {
+	case cu.DtFloat32:
+		err = result(C.cusolverDnSgesvd_bufferSize(h.h, C.int(a.Rows), C.int(a.Cols), &lwork))
+	case cu.DtFloat64:
+		err = result(C.cusolverDnDgesvd_bufferSize(h.h, C.int(a.Rows), C.int(a.Cols), &lwork))
+	}
+	return int64(lwork) * a.Dtype.Size(), errors.Wrap(err, "Gesvd")
+}
+
+// Gesvd computes the singular value decomposition of the m×n matrix a: a = U·Σ·Vᵀ. s receives the min(m, n) singular
+// values, in descending order. jobu and jobvt tell which of the columns of U and of the rows of Vᵀ are computed; they
+// cannot both be SVDOverwrite. a is destroyed.
+//
+// cuSOLVER only decomposes matrices with at least as many rows as columns: decompose the transpose of the others,
+// whose U and V are swapped.
+func (h *Handle) Gesvd(jobu, jobvt SVDJob, a Matrix, s cu.DevicePtr, u, vt Matrix) error {
+	if err := checkDtype(a); err != nil {
+		return errors.Wrap(err, "Gesvd")
+	}
+	m, n := a.Rows, a.Cols
+	if m < n {
+		return errors.Errorf("Gesvd: cannot decompose a %d×%d matrix, which has fewer rows than columns", m, n)
+	}
+	if jobu == SVDOverwrite && jobvt == SVDOverwrite {
+		return errors.New("Gesvd: U and Vᵀ cannot both overwrite A")
+	}
+	var err error
+	switch jobu {
+	case SVDAll:
+		err = checkShape("U", u, a.Dtype, m, m)
+	case SVDSlim:
+		err = checkShape("U", u, a.Dtype, m, n)
+	case SVDOverwrite, SVDNone:
+		u = Matrix{LD: 1}
+	default:
+		err = errors.Errorf("Invalid job %q", byte(jobu))
+	}
+	if err != nil {
+		return errors.Wrap(err, "Gesvd")
+	}
+	switch jobvt {
+	case SVDAll, SVDSlim:
+		err = checkShape("Vᵀ", vt, a.Dtype, n, n) // n = min(m, n)
+	case SVDOverwrite, SVDNone:
+		vt = Matrix{LD: 1}
+	default:
+		err = errors.Errorf("Invalid job %q", byte(jobvt))
+	}
+	if err != nil {
+		return errors.Wrap(err, "Gesvd")
+	}
+
+	size, err := h.GesvdWorkspace(a)
+	if err != nil {
+		return err
+	}
+	work, err := h.workspace(size)
+	if err != nil {
+		return errors.Wrap(err, "Gesvd")
+	}
+	lwork := C.int(size / a.Dtype.Size())
+	ju, jvt := C.schar(jobu), C.schar(jobvt)
+	switch a.Dtype {
+	case cu.DtFloat32:
+		err = result(C.cusolverDnSgesvd(h.h, ju, jvt, C.int(m), C.int(n), floats(a.Ptr), C.int(a.LD), floats(s), floats(u.Ptr), C.int(u.LD), floats(vt.Ptr), C.int(vt.LD), floats(work), lwork, nil, ints(h.info)))
+	case cu.DtFloat64:
+		err = result(C.cusolverDnDgesvd(h.h, ju, jvt, C.int(m), C.int(n), doubles(a.Ptr), C.int(a.LD), doubles(s), doubles(u.Ptr), C.int(u.LD), doubles(vt.Ptr), C.int(vt.LD), doubles(work), lwork, nil, ints(h.info)))
+	}
+	if err != nil {
+		return errors.Wrap(err, "Gesvd")
+	}
+	return h.check("Gesvd")
+}
+
+// SyevdWorkspace returns the size in bytes of the workspace of Syevd on a.
+func (h *Handle) SyevdWorkspace(vectors bool, uplo Fill, a Matrix) (int64, error) {
+	if err := checkSquare(a); err != nil {
+		return 0, errors.Wrap(err, "Syevd")
+	}
+	var lwork C.int
+	var err error
+	switch a.Dtype {
+	case cu.DtFloat32:
+		err = result(C.cusolverDnSsyevd_bufferSize(h.h, eigMode(vectors), C.cublasFillMode_t(uplo), C.int(a.Rows), floats(a.Ptr), C.int(a.LD), nil, &lwork))
+	case cu.DtFloat64:
+		err = result(C.cusolverDnDsyevd_bufferSize(h.h, eigMode(vectors), C.cublasFillMode_t(uplo), C.int(a.Rows), doubles(a.Ptr), C.int(a.LD), nil, &lwork))
+	}
+	return int64(lwork) * a.Dtype.Size(), errors.Wrap(err, "Syevd")
+}
+
+// Syevd computes the eigenvalues of the symmetric matrix a, and its eigenvectors if vectors is true, with a divide and
+// conquer algorithm. w receives the Rows eigenvalues, in ascending order. Only the triangle uplo of a is read. a is
+// overwritten by the orthonormal eigenvectors, in the order of w, if vectors is true, and is destroyed otherwise.
+func (h *Handle) Syevd(vectors bool, uplo Fill, a Matrix, w cu.DevicePtr) error {
+	size, err := h.SyevdWorkspace(vectors, uplo, a)
+	if err != nil {
+		return err
+	}
+	work, err := h.workspace(size)
+	if err != nil {
+		return errors.Wrap(err, "Syevd")
+	}
+	lwork := C.int(size / a.Dtype.Size())
+	switch a.Dtype {
+	case cu.DtFloat32:
+		err = result(C.cusolverDnSsyevd(h.h, eigMode(vectors), C.cublasFillMode_t(uplo), C.int(a.Rows), floats(a.Ptr), C.int(a.LD), floats(w), floats(work), lwork, ints(h.info)))
+	case cu.DtFloat64:
+		err = result(C.cusolverDnDsyevd(h.h, eigMode(vectors), C.cublasFillMode_t(uplo), C.int(a.Rows), doubles(a.Ptr), C.int(a.LD), doubles(w), doubles(work), lwork, ints(h.info)))
+	}
+	if err != nil {
+		return errors.Wrap(err, "Syevd")
+	}
+	return h.check("Syevd")
+}
+
+func eigMode(vectors bool) C.cusolverEigMode_t {
+	if vectors {
+		return C.CUSOLVER_EIG_MODE_VECTOR
+	}
+	return C.CUSOLVER_EIG_MODE_NOVECTOR
+}
+
+// checkShape checks that m is a rows×cols matrix of dt.
+func checkShape(name string, m Matrix, dt cu.Dtype, rows, cols int) error {
+	if err := m.check(name, dt); err != nil {
+		return err
+	}
+	if m.Rows != rows || m.Cols != cols {
+		return errors.Errorf("Expected %s to be %d×%d. Got %d×%d", name, rows, cols, m.Rows, m.Cols)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package cusolver
+
+// #include <cusolverDn.h>
+import "C"
+import (
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// PotrfWorkspace returns the size in bytes of the workspace of Potrf on a.
+func (h *Handle) PotrfWorkspace(uplo Fill, a Matrix) (int64, error) {
+	if err := checkSquare(a); err != nil {
+		return 0, errors.Wrap(err, "Potrf")
+	}
+	var lwork C.int
+	var err error
+	switch a.Dtype {
+	case cu.DtFloat32:
+		err = result(C.cusolverDnSpotrf_bufferSize(h.h, C.cublasFillMode_t(uplo), C.int(a.Rows), floats(a.Ptr), C.int(a.LD), &lwork))
+	case cu.DtFloat64:
+		err = result(C.cusolverDnDpotrf_bufferSize(h.h, C.cublasFillMode_t(uplo), C.int(a.Rows), doubles(a.Ptr), C.int(a.LD), &lwork))
+	}
+	return int64(lwork) * a.Dtype.Size(), errors.Wrap(err, "Potrf")
+}
+
+// Potrf computes the Cholesky factorization of the symmetric positive definite matrix a: a = L·Lᵀ if uplo is Lower,
+// a = Uᵀ·U if it is Upper. Only the triangle uplo of a is read, and it is overwritten by the factor.
+func (h *Handle) Potrf(uplo Fill, a Matrix) error {
+	size, err := h.PotrfWorkspace(uplo, a)
+	if err != nil {
+		return err
+	}
+	work, err := h.workspace(size)
+	if err != nil {
+		return errors.Wrap(err, "Potrf")
+	}
+	lwork := C.int(size / a.Dtype.Size())
+	switch a.Dtype {
+	case cu.DtFloat32:
+		err = result(C.cusolverDnSpotrf(h.h, C.cublasFillMode_t(uplo), C.int(a.Rows), floats(a.Ptr), C.int(a.LD), floats(work), lwork, ints(h.info)))
+	case cu.DtFloat64:
+		err = result(C.cusolverDnDpotrf(h.h, C.cublasFillMode_t(uplo), C.int(a.Rows), doubles(a.Ptr), C.int(a.LD), doubles(work), lwork, ints(h.info)))
+	}
+	if err != nil {
+		return errors.Wrap(err, "Potrf")
+	}
+	return h.check("Potrf")
+}
+
+// Potrs solves a·x = b, where a was factored by Potrf with the same uplo. b is overwritten by x.
+func (h *Handle) Potrs(uplo Fill, a, b Matrix) error {
+	if err := checkSquare(a); err != nil {
+		return errors.Wrap(err, "Potrs")
+	}
+	if err := b.check("B", a.Dtype); err != nil {
+		return errors.Wrap(err, "Potrs")
+	}
+	if b.Rows != a.Rows {
+		return errors.Errorf("Potrs: cannot solve a %d×%d system for a %d×%d right hand side", a.Rows, a.Cols, b.Rows, b.Cols)
+	}
+	var err error
+	switch a.Dtype {
+	case cu.DtFloat32:
+		err = result(C.cusolverDnSpotrs(h.h, C.cublasFillMode_t(uplo), C.int(a.Rows), C.int(b.Cols), floats(a.Ptr), C.int(a.LD), floats(b.Ptr), C.int(b.LD), ints(h.info)))
+	case cu.DtFloat64:
+		err = result(C.cusolverDnDpotrs(h.h, C.cublasFillMode_t(uplo), C.int(a.Rows), C.int(b.Cols), doubles(a.Ptr), C.int(a.LD), doubles(b.Ptr), C.int(b.LD), ints(h.info)))
+	}
+	if err != nil {
+		return errors.Wrap(err, "Potrs")
+	}
+	return h.check("Potrs")
+}
+
+// GetrfWorkspace returns the size in bytes of the workspace of Getrf on a.
+func (h *Handle) GetrfWorkspace(a Matrix) (int64, error) {
+	if err := checkDtype(a); err != nil {
+		return 0, errors.Wrap(err, "Getrf")
+	}
+	var lwork C.int
+	var err error
+	switch a.Dtype {
+	case cu.DtFloat32:
+		err = result(C.cusolverDnSgetrf_bufferSize(h.h, C.int(a.Rows), C.int(a.Cols), floats(a.Ptr), C.int(a.LD), &lwork))
+	case cu.DtFloat64:
+		err = result(C.cusolverDnDgetrf_bufferSize(h.h, C.int(a.Rows), C.int(a.Cols), doubles(a.Ptr), C.int(a.LD), &lwork))
+	}
+	return int64(lwork) * a.Dtype.Size(), errors.Wrap(err, "Getrf")
+}
+
+// Getrf computes the LU factorization of a: a = P·L·U, where P is a permutation, L is lower triangular with a unit
+// diagonal, and U is upper triangular. a is overwritten by L and U.
+//
+// ipiv receives min(Rows, Cols) int32 pivots: the row i was swapped with the row ipiv[i] (counting from 1). If ipiv is 0,
+// there is no pivoting, which is only stable for matrices such as the diagonally dominant ones.
+func (h *Handle) Getrf(a Matrix, ipiv cu.DevicePtr) error {
+	size, err := h.GetrfWorkspace(a)
+	if err != nil {
+		return err
+	}
+	work, err := h.workspace(size)
+	if err != nil {
+		return errors.Wrap(err, "Getrf")
+	}
+	switch a.Dtype {
+	case cu.DtFloat32:
+		err = result(C.cusolverDnSgetrf(h.h, C.int(a.Rows), C.int(a.Cols), floats(a.Ptr), C.int(a.LD), floats(work), ints(ipiv), ints(h.info)))
+	case cu.DtFloat64:
+		err = result(C.cusolverDnDgetrf(h.h, C.int(a.Rows), C.int(a.Cols), doubles(a.Ptr), C.int(a.LD), doubles(work), ints(ipiv), ints(h.info)))
+	}
+	if err != nil {
+		return errors.Wrap(err, "Getrf")
+	}
+	return h.check("Getrf")
+}
+
+// Getrs solves a·x = b, or aᵀ·x = b if trans is true, where a was factored by Getrf with the pivots ipiv. b is
+// overwritten by x.
+func (h *Handle) Getrs(trans bool, a Matrix, ipiv cu.DevicePtr, b Matrix) error {
+	if err := checkSquare(a); err != nil {
+		return errors.Wrap(err, "Getrs")
+	}
+	if err := b.check("B", a.Dtype); err != nil {
+		return errors.Wrap(err, "Getrs")
+	}
+	if b.Rows != a.Rows {
+		return errors.Errorf("Getrs: cannot solve a %d×%d system for a %d×%d right hand side", a.Rows, a.Cols, b.Rows, b.Cols)
+	}
+	op := C.cublasOperation_t(C.CUBLAS_OP_N)
+	if trans {
+		op = C.CUBLAS_OP_T
+	}
+	var err error
+	switch a.Dtype {
+	case cu.DtFloat32:
+		err = result(C.cusolverDnSgetrs(h.h, op, C.int(a.Rows), C.int(b.Cols), floats(a.Ptr), C.int(a.LD), ints(ipiv), floats(b.Ptr), C.int(b.LD), ints(h.info)))
+	case cu.DtFloat64:
+		err = result(C.cusolverDnDgetrs(h.h, op, C.int(a.Rows), C.int(b.Cols), doubles(a.Ptr), C.int(a.LD), ints(ipiv), doubles(b.Ptr), C.int(b.LD), ints(h.info)))
+	}
+	if err != nil {
+		return errors.Wrap(err, "Getrs")
+	}
+	return h.check("Getrs")
+}
+
+// checkSquare checks that a is a square matrix of a type cuSOLVER supports.
+func checkSquare(a Matrix) error {
+	if err := checkDtype(a); err != nil {
+		return err
+	}
+	if a.Rows != a.Cols {
+		return errors.Errorf("Expected a square matrix. Got %d×%d", a.Rows, a.Cols)
+	}
+	return nil
+}
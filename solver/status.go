@@ -0,0 +1,51 @@
+package cusolver
+
+// #include <cusolverDn.h>
+import "C"
+
+// Status is the status returned by cuSOLVER.
+type Status int
+
+func (err Status) Error() string  { return err.String() }
+func (err Status) String() string { return resString[err] }
+
+func result(x C.cusolverStatus_t) error {
+	err := Status(x)
+	if err == Success {
+		return nil
+	}
+	if _, ok := resString[err]; !ok {
+		return InternalError
+	}
+	return err
+}
+
+const (
+	Success                Status = C.CUSOLVER_STATUS_SUCCESS
+	NotInitialized         Status = C.CUSOLVER_STATUS_NOT_INITIALIZED
+	AllocFailed            Status = C.CUSOLVER_STATUS_ALLOC_FAILED
+	InvalidValue           Status = C.CUSOLVER_STATUS_INVALID_VALUE
+	ArchMismatch           Status = C.CUSOLVER_STATUS_ARCH_MISMATCH
+	MappingError           Status = C.CUSOLVER_STATUS_MAPPING_ERROR
+	ExecFailed             Status = C.CUSOLVER_STATUS_EXECUTION_FAILED
+	InternalError          Status = C.CUSOLVER_STATUS_INTERNAL_ERROR
+	MatrixTypeNotSupported Status = C.CUSOLVER_STATUS_MATRIX_TYPE_NOT_SUPPORTED
+	NotSupported           Status = C.CUSOLVER_STATUS_NOT_SUPPORTED
+	ZeroPivot              Status = C.CUSOLVER_STATUS_ZERO_PIVOT
+	InvalidLicense         Status = C.CUSOLVER_STATUS_INVALID_LICENSE
+)
+
+var resString = map[Status]string{
+	Success:                "Success",
+	NotInitialized:         "NotInitialized",
+	AllocFailed:            "AllocFailed",
+	InvalidValue:           "InvalidValue",
+	ArchMismatch:           "ArchMismatch",
+	MappingError:           "MappingError",
+	ExecFailed:             "ExecFailed",
+	InternalError:          "InternalError",
+	MatrixTypeNotSupported: "MatrixTypeNotSupported",
+	NotSupported:           "NotSupported",
+	ZeroPivot:              "ZeroPivot",
+	InvalidLicense:         "InvalidLicense",
+}
@@ -0,0 +1,209 @@
+// Package cusparse provides bindings to cuSPARSE, for the sparse matrices used by graph analytics on the GPU.
+//
+// Matrices are in the CSR format, with zero based int32 indices (see CSR). A graph is its adjacency matrix: the row i
+// holds the edges leaving the vertex i. With this, products of sparse matrices (SpGEMM) compute paths - the entry (i,j)
+// of A·A counts the paths of length 2 from i to j - and Transpose reverses the edges:
+//
+//	h, _ := NewHandle()
+//	a, _ := AllocCSR(vertices, vertices, edges, cu.DtFloat32)
+//	... // fill in a
+//	paths, _ := h.SpGEMM(1, a, a)
+//	defer paths.Free()
+//
+// Triangles are counted from the same building blocks: with L the strictly lower triangular part of the adjacency
+// matrix of an undirected graph, the number of triangles is the sum of the entries of L·L at the positions of the
+// entries of L.
+//
+// Sparse matrices multiply dense vectors (see Vector) with SpMV, and dense matrices (see Dense) with SpMM. When whether
+// a dense matrix is sparse enough for this to pay off is not known in advance, Hybrid measures its density and picks
+// the faster route.
+//
+// cuSPARSE work is asynchronous, on the stream of the handle.
+package cusparse
+
+// #include <cusparse.h>
+import "C"
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// Handle is a handle to the cuSPARSE library. It is bound to the context that was current when it was created.
+type Handle struct {
+	h      C.cusparseHandle_t
+	stream cu.Stream
+}
+
+// NewHandle creates a handle, which works on the null stream unless the WithStream option is given.
+func NewHandle(opts ...Option) (*Handle, error) {
+	var h C.cusparseHandle_t
+	if err := result(C.cusparseCreate(&h)); err != nil {
+		return nil, errors.Wrap(err, "Unable to create a cuSPARSE handle")
+	}
+	return newHandle(&Handle{h: h}, opts)
+}
+
+func newHandle(h *Handle, opts []Option) (*Handle, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.stream != cu.NoStream {
+		if err := h.SetStream(o.stream); err != nil {
+			h.Close()
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// Version returns the version of cuSPARSE.
+func (h *Handle) Version() (int, error) {
+	var v C.int
+	err := result(C.cusparseGetVersion(h.h, &v))
+	return int(v), err
+}
+
+// Option configures a Handle when it is created.
+type Option func(o *options)
+
+type options struct {
+	stream cu.Stream
+}
+
+// WithStream sets the stream the work of the handle is enqueued on (see SetStream).
+func WithStream(stream cu.Stream) Option { return func(o *options) { o.stream = stream } }
+
+// SetStream sets the stream the work of the handle is enqueued on.
+func (h *Handle) SetStream(stream cu.Stream) error {
+	if err := result(C.cusparseSetStream(h.h, C.cudaStream_t(stream.Pointer()))); err != nil {
+		return err
+	}
+	h.stream = stream
+	return nil
+}
+
+// Close destroys the handle.
+func (h *Handle) Close() error {
+	if h.h == nil {
+		return nil
+	}
+	err := result(C.cusparseDestroy(h.h))
+	h.h = nil
+	return err
+}
+
+// CSR is a sparse matrix in the compressed sparse row format, in device memory. The indices are zero based int32:
+//
+//	RowOffsets holds Rows+1 offsets: the entries of the row i are the entries RowOffsets[i] to RowOffsets[i+1]
+//	ColIndices holds the column of each of the NNZ entries, sorted within each row
+//	Values holds the value of each entry, of type Dtype
+type CSR struct {
+	Rows, Cols, NNZ int
+	Dtype           cu.Dtype
+
+	RowOffsets cu.DevicePtr
+	ColIndices cu.DevicePtr
+	Values     cu.DevicePtr
+}
+
+// AllocCSR allocates the memory of a rows×cols CSR matrix with nnz entries. The memory is not initialized. Free it with
+// Free.
+func AllocCSR(rows, cols, nnz int, dt cu.Dtype) (*CSR, error) {
+	if rows < 0 || cols < 0 || nnz < 0 {
+		return nil, errors.Errorf("Invalid CSR matrix of %d×%d with %d entries", rows, cols, nnz)
+	}
+	if _, err := dataType(dt); err != nil {
+		return nil, err
+	}
+	m := &CSR{Rows: rows, Cols: cols, NNZ: nnz, Dtype: dt}
+	if err := m.alloc(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// alloc allocates the arrays of m, after its sizes. A matrix without entries has no indices nor values.
+func (m *CSR) alloc() (err error) {
+	if m.RowOffsets, err = cu.MemAlloc(int64(m.Rows+1) * 4); err != nil {
+		return errors.Wrap(err, "Unable to allocate the row offsets")
+	}
+	return m.allocEntries()
+}
+
+// allocEntries allocates the column indices and the values of m, after its number of entries.
+func (m *CSR) allocEntries() (err error) {
+	if m.NNZ == 0 {
+		return nil
+	}
+	if m.ColIndices, err = cu.MemAlloc(int64(m.NNZ) * 4); err != nil {
+		m.Free()
+		return errors.Wrap(err, "Unable to allocate the column indices")
+	}
+	if m.Values, err = cu.MemAlloc(int64(m.NNZ) * m.Dtype.Size()); err != nil {
+		m.Free()
+		return errors.Wrap(err, "Unable to allocate the values")
+	}
+	return nil
+}
+
+// Free frees the memory of a matrix allocated by this package.
+func (m *CSR) Free() error {
+	var retVal error
+	for _, p := range []*cu.DevicePtr{&m.RowOffsets, &m.ColIndices, &m.Values} {
+		if *p == 0 {
+			continue
+		}
+		if err := cu.MemFree(*p); err != nil && retVal == nil {
+			retVal = err
+		}
+		*p = 0
+	}
+	return retVal
+}
+
+// descr creates the cuSPARSE descriptor of m. It must be destroyed with cusparseDestroySpMat.
+func (m *CSR) descr() (C.cusparseSpMatDescr_t, error) {
+	var d C.cusparseSpMatDescr_t
+	dt, err := dataType(m.Dtype)
+	if err != nil {
+		return d, err
+	}
+	err = result(C.cusparseCreateCsr(&d, C.int64_t(m.Rows), C.int64_t(m.Cols), C.int64_t(m.NNZ),
+		m.RowOffsets.Pointer(), m.ColIndices.Pointer(), m.Values.Pointer(),
+		C.CUSPARSE_INDEX_32I, C.CUSPARSE_INDEX_32I, C.CUSPARSE_INDEX_BASE_ZERO, dt))
+	return d, errors.Wrap(err, "Unable to describe a CSR matrix")
+}
+
+// scalar returns v as a scalar of the type dt, for alpha and beta. Only float32 and float64 are supported.
+func scalar(v float64, dt cu.Dtype) (retVal [8]byte, err error) {
+	switch dt {
+	case cu.DtFloat32:
+		*(*float32)(unsafe.Pointer(&retVal[0])) = float32(v)
+	case cu.DtFloat64:
+		*(*float64)(unsafe.Pointer(&retVal[0])) = v
+	default:
+		err = errors.Errorf("unsupported Dtype %v", dt)
+	}
+	return
+}
+
+func dataType(dt cu.Dtype) (C.cudaDataType, error) {
+	switch dt {
+	case cu.DtFloat32:
+		return C.CUDA_R_32F, nil
+	case cu.DtFloat64:
+		return C.CUDA_R_64F, nil
+	case cu.DtFloat16:
+		return C.CUDA_R_16F, nil
+	case cu.DtBFloat16:
+		return C.CUDA_R_16BF, nil
+	case cu.DtComplex64:
+		return C.CUDA_C_32F, nil
+	case cu.DtComplex128:
+		return C.CUDA_C_64F, nil
+	}
+	return 0, errors.Errorf("cuSPARSE does not support %v", dt)
+}
@@ -0,0 +1,138 @@
+package cusparse
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"gorgonia.org/cu"
+)
+
+func TestAllocCSR_Invalid(t *testing.T) {
+	if _, err := AllocCSR(-1, 2, 0, cu.DtFloat32); err == nil {
+		t.Error("Expected an error for a negative size")
+	}
+	if _, err := AllocCSR(2, 2, 1, cu.DtBool); err == nil {
+		t.Error("Expected an error for an unsupported Dtype")
+	}
+}
+
+func TestSpMV_Invalid(t *testing.T) {
+	h := new(Handle)
+	a := &CSR{Rows: 3, Cols: 2, Dtype: cu.DtFloat32}
+	if err := h.SpMV(1, a, Vector{Len: 3, Dtype: cu.DtFloat32}, 0, Vector{Len: 3, Dtype: cu.DtFloat32}); err == nil {
+		t.Error("Expected an error for mismatched shapes")
+	}
+	if err := h.SpMV(1, a, Vector{Len: 2, Dtype: cu.DtFloat64}, 0, Vector{Len: 3, Dtype: cu.DtFloat32}); err == nil {
+		t.Error("Expected an error for mismatched Dtypes")
+	}
+}
+
+func TestSpGEMM(t *testing.T) {
+	if devices, _ := cu.NumDevices(); devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := cu.Device(0).MakeContext(cu.SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+
+	h, err := NewHandle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	// the directed cycle 0 → 1 → 2 → 0, and the edge 0 → 2
+	a, err := AllocCSR(3, 3, 4, cu.DtFloat32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Free()
+	upload := func(dst cu.DevicePtr, p unsafe.Pointer, size int64) {
+		if err := cu.MemcpyHtoD(dst, p, size); err != nil {
+			t.Fatal(err)
+		}
+	}
+	offsets, cols, vals := []int32{0, 2, 3, 4}, []int32{1, 2, 2, 0}, []float32{1, 1, 1, 1}
+	upload(a.RowOffsets, unsafe.Pointer(&offsets[0]), 16)
+	upload(a.ColIndices, unsafe.Pointer(&cols[0]), 16)
+	upload(a.Values, unsafe.Pointer(&vals[0]), 16)
+
+	download := func(m *CSR) ([]int32, []int32, []float32) {
+		offsets, cols, vals := make([]int32, m.Rows+1), make([]int32, m.NNZ), make([]float32, m.NNZ)
+		if err := cu.MemcpyDtoH(unsafe.Pointer(&offsets[0]), m.RowOffsets, int64(len(offsets)*4)); err != nil {
+			t.Fatal(err)
+		}
+		if m.NNZ > 0 {
+			if err := cu.MemcpyDtoH(unsafe.Pointer(&cols[0]), m.ColIndices, int64(m.NNZ*4)); err != nil {
+				t.Fatal(err)
+			}
+			if err := cu.MemcpyDtoH(unsafe.Pointer(&vals[0]), m.Values, int64(m.NNZ*4)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return offsets, cols, vals
+	}
+	equal := func(a, b []int32) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	// paths of length 2: 0 → 2 (through 1), 0 → 0 (through 2), 1 → 0, 2 → 1 and 2 → 2
+	p, err := h.SpGEMM(2, a, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Free()
+	if p.NNZ != 5 {
+		t.Fatalf("Expected 5 entries. Got %d", p.NNZ)
+	}
+	offsets, cols, vals = download(p)
+	if !equal(offsets, []int32{0, 2, 3, 5}) || !equal(cols, []int32{0, 2, 0, 1, 2}) {
+		t.Errorf("Unexpected product: offsets %v, columns %v", offsets, cols)
+	}
+	for _, v := range vals {
+		if v != 2 {
+			t.Errorf("Expected every path to be scaled to 2. Got %v", vals)
+			break
+		}
+	}
+
+	// reversed edges: 0 → 2, 1 → 0, 2 → 0, 2 → 1
+	tr, err := h.Transpose(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tr.Free()
+	offsets, cols, _ = download(tr)
+	if !equal(offsets, []int32{0, 1, 2, 4}) || !equal(cols, []int32{2, 0, 0, 1}) {
+		t.Errorf("Unexpected transpose: offsets %v, columns %v", offsets, cols)
+	}
+
+	rows := make([]int32, 4)
+	coo, err := cu.MemAlloc(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cu.MemFree(coo)
+	if err = h.CSRToCOO(a.RowOffsets, a.NNZ, a.Rows, coo); err != nil {
+		t.Fatal(err)
+	}
+	if err = cu.MemcpyDtoH(unsafe.Pointer(&rows[0]), coo, 16); err != nil {
+		t.Fatal(err)
+	}
+	if !equal(rows, []int32{0, 0, 1, 2}) {
+		t.Errorf("Expected the sources [0 0 1 2]. Got %v", rows)
+	}
+}
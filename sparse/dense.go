@@ -0,0 +1,232 @@
+package cusparse
+
+// #include <cusparse.h>
+import "C"
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// Dense is a dense matrix in device memory, in column major order: the element (i,j) is at Ptr + (j*LD + i)*size.
+type Dense struct {
+	Rows, Cols, LD int
+	Dtype          cu.Dtype
+	Ptr            cu.DevicePtr
+}
+
+// descr creates the cuSPARSE descriptor of m. It must be destroyed with cusparseDestroyDnMat.
+func (m Dense) descr() (C.cusparseDnMatDescr_t, error) {
+	var d C.cusparseDnMatDescr_t
+	dt, err := dataType(m.Dtype)
+	if err != nil {
+		return d, err
+	}
+	if m.LD < m.Rows {
+		return d, errors.Errorf("Invalid leading dimension %d for %d rows", m.LD, m.Rows)
+	}
+	err = result(C.cusparseCreateDnMat(&d, C.int64_t(m.Rows), C.int64_t(m.Cols), C.int64_t(m.LD), m.Ptr.Pointer(), dt, C.CUSPARSE_ORDER_COL))
+	return d, errors.Wrap(err, "Unable to describe a dense matrix")
+}
+
+// SpMM computes c = alpha·a·b + beta·c, where a is sparse and b and c are dense. The matrices must have the same
+// Dtype, either float32 or float64. SpMM waits for the stream of the handle, as it frees its workspace.
+func (h *Handle) SpMM(alpha float64, a *CSR, b Dense, beta float64, c Dense) error {
+	if a.Cols != b.Rows || a.Rows != c.Rows || b.Cols != c.Cols {
+		return errors.Errorf("SpMM: cannot multiply a %d×%d matrix by a %d×%d matrix into a %d×%d matrix", a.Rows, a.Cols, b.Rows, b.Cols, c.Rows, c.Cols)
+	}
+	if a.Dtype != b.Dtype || a.Dtype != c.Dtype {
+		return errors.Errorf("SpMM: the matrices are %v, %v and %v", a.Dtype, b.Dtype, c.Dtype)
+	}
+	alphaBuf, err := scalar(alpha, a.Dtype)
+	if err != nil {
+		return errors.Wrap(err, "SpMM")
+	}
+	betaBuf, _ := scalar(beta, a.Dtype)
+	computeType, _ := dataType(a.Dtype)
+
+	da, err := a.descr()
+	if err != nil {
+		return errors.Wrap(err, "SpMM")
+	}
+	defer C.cusparseDestroySpMat(da)
+	db, err := b.descr()
+	if err != nil {
+		return errors.Wrap(err, "SpMM")
+	}
+	defer C.cusparseDestroyDnMat(db)
+	dc, err := c.descr()
+	if err != nil {
+		return errors.Wrap(err, "SpMM")
+	}
+	defer C.cusparseDestroyDnMat(dc)
+
+	const op = C.CUSPARSE_OPERATION_NON_TRANSPOSE
+	var buf workspace
+	defer h.release(&buf)
+	if err = result(C.cusparseSpMM_bufferSize(h.h, op, op, unsafe.Pointer(&alphaBuf[0]), da, db, unsafe.Pointer(&betaBuf[0]), dc, computeType, C.CUSPARSE_SPMM_ALG_DEFAULT, &buf.size)); err != nil {
+		return errors.Wrap(err, "SpMM")
+	}
+	if err = buf.alloc(); err != nil {
+		return errors.Wrap(err, "SpMM")
+	}
+	err = result(C.cusparseSpMM(h.h, op, op, unsafe.Pointer(&alphaBuf[0]), da, db, unsafe.Pointer(&betaBuf[0]), dc, computeType, C.CUSPARSE_SPMM_ALG_DEFAULT, buf.ptr.Pointer()))
+	return errors.Wrap(err, "SpMM")
+}
+
+// Vector is a dense vector in device memory.
+type Vector struct {
+	Len   int
+	Dtype cu.Dtype
+	Ptr   cu.DevicePtr
+}
+
+// descr creates the cuSPARSE descriptor of v. It must be destroyed with cusparseDestroyDnVec.
+func (v Vector) descr() (C.cusparseDnVecDescr_t, error) {
+	var d C.cusparseDnVecDescr_t
+	dt, err := dataType(v.Dtype)
+	if err != nil {
+		return d, err
+	}
+	err = result(C.cusparseCreateDnVec(&d, C.int64_t(v.Len), v.Ptr.Pointer(), dt))
+	return d, errors.Wrap(err, "Unable to describe a dense vector")
+}
+
+// SpMV computes y = alpha·a·x + beta·y, where a is sparse and x and y are dense vectors. With a the adjacency matrix
+// of a graph, this sums the values of the neighbours of each vertex, which is the aggregation step of graph neural
+// networks and of PageRank. The product by the transpose of a is the product by Transpose(a).
+//
+// The matrix and the vectors must have the same Dtype, either float32 or float64. SpMV waits for the stream of the
+// handle, as it frees its workspace.
+func (h *Handle) SpMV(alpha float64, a *CSR, x Vector, beta float64, y Vector) error {
+	if a.Cols != x.Len || a.Rows != y.Len {
+		return errors.Errorf("SpMV: cannot multiply a %d×%d matrix by a vector of %d into a vector of %d", a.Rows, a.Cols, x.Len, y.Len)
+	}
+	if a.Dtype != x.Dtype || a.Dtype != y.Dtype {
+		return errors.Errorf("SpMV: the matrix and the vectors are %v, %v and %v", a.Dtype, x.Dtype, y.Dtype)
+	}
+	alphaBuf, err := scalar(alpha, a.Dtype)
+	if err != nil {
+		return errors.Wrap(err, "SpMV")
+	}
+	betaBuf, _ := scalar(beta, a.Dtype)
+	computeType, _ := dataType(a.Dtype)
+
+	da, err := a.descr()
+	if err != nil {
+		return errors.Wrap(err, "SpMV")
+	}
+	defer C.cusparseDestroySpMat(da)
+	dx, err := x.descr()
+	if err != nil {
+		return errors.Wrap(err, "SpMV")
+	}
+	defer C.cusparseDestroyDnVec(dx)
+	dy, err := y.descr()
+	if err != nil {
+		return errors.Wrap(err, "SpMV")
+	}
+	defer C.cusparseDestroyDnVec(dy)
+
+	const op = C.CUSPARSE_OPERATION_NON_TRANSPOSE
+	var buf workspace
+	defer h.release(&buf)
+	if err = result(C.cusparseSpMV_bufferSize(h.h, op, unsafe.Pointer(&alphaBuf[0]), da, dx, unsafe.Pointer(&betaBuf[0]), dy, computeType, C.CUSPARSE_SPMV_ALG_DEFAULT, &buf.size)); err != nil {
+		return errors.Wrap(err, "SpMV")
+	}
+	if err = buf.alloc(); err != nil {
+		return errors.Wrap(err, "SpMV")
+	}
+	err = result(C.cusparseSpMV(h.h, op, unsafe.Pointer(&alphaBuf[0]), da, dx, unsafe.Pointer(&betaBuf[0]), dy, computeType, C.CUSPARSE_SPMV_ALG_DEFAULT, buf.ptr.Pointer()))
+	return errors.Wrap(err, "SpMV")
+}
+
+// conversion is the conversion of a dense matrix to CSR, once analyzed: the number of entries of the CSR matrix is
+// known, but its memory is not allocated yet.
+type conversion struct {
+	h   *Handle
+	dn  C.cusparseDnMatDescr_t
+	sp  C.cusparseSpMatDescr_t
+	csr *CSR
+	buf workspace
+}
+
+// analyze starts the conversion of a to CSR. The conversion must be closed.
+func (h *Handle) analyze(a Dense) (_ *conversion, err error) {
+	c := &conversion{h: h, csr: &CSR{Rows: a.Rows, Cols: a.Cols, Dtype: a.Dtype}}
+	defer func() {
+		if err != nil {
+			c.close()
+		}
+	}()
+	if c.dn, err = a.descr(); err != nil {
+		return nil, err
+	}
+	if err = c.csr.alloc(); err != nil {
+		return nil, err
+	}
+	if c.sp, err = c.csr.descr(); err != nil {
+		return nil, err
+	}
+	if err = result(C.cusparseDenseToSparse_bufferSize(h.h, c.dn, c.sp, C.CUSPARSE_DENSETOSPARSE_ALG_DEFAULT, &c.buf.size)); err != nil {
+		return nil, err
+	}
+	if err = c.buf.alloc(); err != nil {
+		return nil, err
+	}
+	if err = result(C.cusparseDenseToSparse_analysis(h.h, c.dn, c.sp, C.CUSPARSE_DENSETOSPARSE_ALG_DEFAULT, c.buf.ptr.Pointer())); err != nil {
+		return nil, err
+	}
+	var rows, cols, nnz C.int64_t
+	if err = result(C.cusparseSpMatGetSize(c.sp, &rows, &cols, &nnz)); err != nil {
+		return nil, err
+	}
+	c.csr.NNZ = int(nnz)
+	return c, nil
+}
+
+// convert finishes the conversion, and hands over the CSR matrix to the caller.
+func (c *conversion) convert() (*CSR, error) {
+	if err := c.csr.allocEntries(); err != nil {
+		return nil, err
+	}
+	if err := result(C.cusparseCsrSetPointers(c.sp, c.csr.RowOffsets.Pointer(), c.csr.ColIndices.Pointer(), c.csr.Values.Pointer())); err != nil {
+		return nil, err
+	}
+	if err := result(C.cusparseDenseToSparse_convert(c.h.h, c.dn, c.sp, C.CUSPARSE_DENSETOSPARSE_ALG_DEFAULT, c.buf.ptr.Pointer())); err != nil {
+		return nil, err
+	}
+	csr := c.csr
+	c.csr = nil
+	return csr, nil
+}
+
+// close waits for the stream of the handle, and frees what the conversion holds, including the CSR matrix unless it
+// was handed over.
+func (c *conversion) close() {
+	c.h.release(&c.buf)
+	if c.csr != nil {
+		c.csr.Free()
+	}
+	if c.sp != nil {
+		C.cusparseDestroySpMat(c.sp)
+	}
+	if c.dn != nil {
+		C.cusparseDestroyDnMat(c.dn)
+	}
+}
+
+// DenseToCSR converts a dense matrix to CSR, keeping its nonzero elements. DenseToCSR waits for the stream of the
+// handle.
+//
+// Free the result with Free.
+func (h *Handle) DenseToCSR(a Dense) (*CSR, error) {
+	c, err := h.analyze(a)
+	if err != nil {
+		return nil, errors.Wrap(err, "DenseToCSR")
+	}
+	defer c.close()
+	csr, err := c.convert()
+	return csr, errors.Wrap(err, "DenseToCSR")
+}
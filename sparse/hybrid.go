@@ -0,0 +1,267 @@
+package cusparse
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/blas"
+	"gorgonia.org/cu"
+	cublas "gorgonia.org/cu/blas"
+)
+
+// Route is the implementation a Hybrid multiplies a matrix with.
+type Route int
+
+const (
+	RouteDense      Route = iota // a dense GEMM of cuBLAS
+	RouteSpMM                    // the conversion to CSR, followed by SpMM
+	RouteStructured              // the StructuredMatmul, for matrices with 2:4 structured sparsity
+)
+
+var routeNames = [...]string{
+	RouteDense:      "Dense",
+	RouteSpMM:       "SpMM",
+	RouteStructured: "Structured",
+}
+
+func (r Route) String() string {
+	if r >= 0 && int(r) < len(routeNames) {
+		return routeNames[r]
+	}
+	return "UnknownRoute"
+}
+
+// StructuredMatmul multiplies matrices with 2:4 structured sparsity - at most two nonzero elements in every group of
+// four consecutive elements of a row - on the sparse tensor cores, for example with cuSPARSELt. This package has no
+// bindings to cuSPARSELt: the structured route of a Hybrid is only taken when one is given (see WithStructured).
+type StructuredMatmul interface {
+	// Structured returns true if a has 2:4 structured sparsity.
+	Structured(a Dense) (bool, error)
+	// Matmul computes c = a·b, where a has 2:4 structured sparsity.
+	Matmul(a, b, c Dense) error
+}
+
+// HybridOpt is an option of a Hybrid.
+type HybridOpt func(hy *Hybrid)
+
+// WithStructured sets the implementation of the structured route.
+func WithStructured(s StructuredMatmul) HybridOpt {
+	return func(hy *Hybrid) { hy.structured = s }
+}
+
+// WithTuneDB sets the database the crossover densities are read from and stored in, so that they are measured once per
+// model of GPU rather than once per process. The database must have the fingerprint of the devices used.
+func WithTuneDB(db *cu.TuneDB) HybridOpt {
+	return func(hy *Hybrid) { hy.db = db }
+}
+
+// Hybrid multiplies dense matrices, routing each multiplication after the density of its left operand: the fraction of
+// its elements that are not zero. Below the crossover density of the device, the operand is converted to CSR and
+// multiplied with SpMM; above it, the operand is multiplied by a dense GEMM, or by the StructuredMatmul if the operand
+// has 2:4 structured sparsity.
+//
+// The cuSPARSE handle and the cuBLAS implementation should work on the same stream. The multiplications wait for the
+// stream of the handle.
+type Hybrid struct {
+	h          *Handle
+	blas       *cublas.Standard
+	structured StructuredMatmul
+	db         *cu.TuneDB
+}
+
+// NewHybrid creates a Hybrid, which multiplies with the cuSPARSE handle and the cuBLAS implementation.
+func NewHybrid(h *Handle, impl *cublas.Standard, opts ...HybridOpt) *Hybrid {
+	hy := &Hybrid{h: h, blas: impl}
+	for _, opt := range opts {
+		opt(hy)
+	}
+	return hy
+}
+
+// crossoverKey is the key of the crossover density in a TuneDB.
+const crossoverKey = "cusparse/hybrid/crossover/float32"
+
+// crossovers holds the crossover densities, by device.
+var crossovers struct {
+	sync.Mutex
+	m map[cu.Device]float64
+}
+
+// SetCrossover sets the crossover density of the device, instead of measuring it. A density of 0 always routes to the
+// dense multiplications, and a density of 1 to SpMM.
+func SetCrossover(dev cu.Device, density float64) {
+	crossovers.Lock()
+	if crossovers.m == nil {
+		crossovers.m = make(map[cu.Device]float64)
+	}
+	crossovers.m[dev] = density
+	crossovers.Unlock()
+}
+
+// Crossover returns the density below which SpMM is faster than a dense GEMM on the current device. It is measured
+// the first time it is needed on a device, unless it was set or found in the TuneDB, and cached afterwards.
+func (hy *Hybrid) Crossover() (float64, error) {
+	dev, err := cu.CurrentDevice()
+	if err != nil {
+		return 0, err
+	}
+	crossovers.Lock()
+	defer crossovers.Unlock()
+	if v, ok := crossovers.m[dev]; ok {
+		return v, nil
+	}
+	var v float64
+	var found bool
+	if hy.db != nil {
+		if found, err = hy.db.Get(crossoverKey, &v); err != nil {
+			return 0, err
+		}
+	}
+	if !found {
+		if v, err = hy.measure(); err != nil {
+			return 0, errors.Wrap(err, "Unable to measure the crossover density")
+		}
+		if hy.db != nil {
+			if err = hy.db.Put(crossoverKey, v); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if crossovers.m == nil {
+		crossovers.m = make(map[cu.Device]float64)
+	}
+	crossovers.m[dev] = v
+	return v, nil
+}
+
+// chooseRoute returns the route of an operand of the given density.
+func chooseRoute(density, crossover float64, structured bool) Route {
+	switch {
+	case density < crossover:
+		return RouteSpMM
+	case structured:
+		return RouteStructured
+	}
+	return RouteDense
+}
+
+// Matmul computes c = a·b, for float32 matrices, and returns the route it took. The density of a is computed on the
+// device, with the first step of its conversion to CSR.
+func (hy *Hybrid) Matmul(a, b, c Dense) (Route, error) {
+	if a.Cols != b.Rows || a.Rows != c.Rows || b.Cols != c.Cols {
+		return 0, errors.Errorf("Matmul: cannot multiply a %d×%d matrix by a %d×%d matrix into a %d×%d matrix", a.Rows, a.Cols, b.Rows, b.Cols, c.Rows, c.Cols)
+	}
+	if a.Dtype != cu.DtFloat32 || b.Dtype != cu.DtFloat32 || c.Dtype != cu.DtFloat32 {
+		return 0, errors.Errorf("Matmul: only float32 matrices are supported. Got %v, %v and %v", a.Dtype, b.Dtype, c.Dtype)
+	}
+	crossover, err := hy.Crossover()
+	if err != nil {
+		return 0, errors.Wrap(err, "Matmul")
+	}
+	conv, err := hy.h.analyze(a)
+	if err != nil {
+		return 0, errors.Wrap(err, "Matmul")
+	}
+	defer conv.close()
+
+	density := 1.0
+	if size := a.Rows * a.Cols; size > 0 {
+		density = float64(conv.csr.NNZ) / float64(size)
+	}
+	structured := false
+	if hy.structured != nil && density >= crossover {
+		if structured, err = hy.structured.Structured(a); err != nil {
+			return 0, errors.Wrap(err, "Matmul")
+		}
+	}
+
+	route := chooseRoute(density, crossover, structured)
+	switch route {
+	case RouteSpMM:
+		csr, err := conv.convert()
+		if err != nil {
+			return route, errors.Wrap(err, "Matmul")
+		}
+		defer csr.Free()
+		err = hy.h.SpMM(1, csr, b, 0, c)
+		return route, errors.Wrap(err, "Matmul")
+	case RouteStructured:
+		return route, errors.Wrap(hy.structured.Matmul(a, b, c), "Matmul")
+	}
+	return route, errors.Wrap(hy.gemm(a, b, c), "Matmul")
+}
+
+func (hy *Hybrid) gemm(a, b, c Dense) error {
+	// an error left by an earlier call makes the BLAS skip the call
+	if err := hy.blas.Err(); err != nil {
+		return errors.Wrap(err, "The BLAS has an outstanding error")
+	}
+	hy.blas.GemmEx(blas.NoTrans, blas.NoTrans, a.Rows, b.Cols, a.Cols, 1, a.Ptr, cu.DtFloat32, a.LD, b.Ptr, cu.DtFloat32, b.LD, 0, c.Ptr, cu.DtFloat32, c.LD, cublas.Compute32F, cublas.GemmDefault)
+	return hy.blas.TakeErr()
+}
+
+// measure returns the crossover density of the current device. It times a dense GEMM and SpMM on square matrices, at a
+// density of about 1%, and extrapolates assuming that the time of SpMM is proportional to the number of entries.
+func (hy *Hybrid) measure() (float64, error) {
+	const n, reps = 1024, 5
+	const entries = n * n / 100
+	host := make([]float32, n*n)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < entries; i++ {
+		host[r.Intn(n*n)] = 1
+	}
+	const size = n * n * 4
+	mem, err := cu.MemAlloc(3 * size)
+	if err != nil {
+		return 0, err
+	}
+	defer cu.MemFree(mem)
+	a := Dense{Rows: n, Cols: n, LD: n, Dtype: cu.DtFloat32, Ptr: mem}
+	b, c := a, a
+	b.Ptr, c.Ptr = mem.Offset(size), mem.Offset(2*size)
+	if err = cu.MemcpyHtoD(a.Ptr, unsafe.Pointer(&host[0]), size); err != nil {
+		return 0, err
+	}
+	if err = cu.MemsetD32(b.Ptr, math.Float32bits(1), n*n); err != nil {
+		return 0, err
+	}
+	csr, err := hy.h.DenseToCSR(a)
+	if err != nil {
+		return 0, err
+	}
+	defer csr.Free()
+
+	timeIt := func(f func() error) (time.Duration, error) {
+		if err := f(); err != nil { // warm up
+			return 0, err
+		}
+		if err := cu.Synchronize(); err != nil {
+			return 0, err
+		}
+		start := time.Now()
+		for i := 0; i < reps; i++ {
+			if err := f(); err != nil {
+				return 0, err
+			}
+		}
+		err := cu.Synchronize()
+		return time.Since(start), err
+	}
+	dense, err := timeIt(func() error { return hy.gemm(a, b, c) })
+	if err != nil {
+		return 0, err
+	}
+	spmm, err := timeIt(func() error { return hy.h.SpMM(1, csr, b, 0, c) })
+	if err != nil {
+		return 0, err
+	}
+	if spmm <= 0 {
+		return 1, nil
+	}
+	crossover := float64(csr.NNZ) / (n * n) * float64(dense) / float64(spmm)
+	return math.Min(crossover, 1), nil
+}
@@ -0,0 +1,92 @@
+package cusparse
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"gorgonia.org/cu"
+	cublas "gorgonia.org/cu/blas"
+)
+
+func TestChooseRoute(t *testing.T) {
+	for _, c := range []struct {
+		density, crossover float64
+		structured         bool
+		route              Route
+	}{
+		{0.01, 0.05, false, RouteSpMM},
+		{0.01, 0.05, true, RouteSpMM},
+		{0.5, 0.05, false, RouteDense},
+		{0.5, 0.05, true, RouteStructured},
+		{0.05, 0.05, false, RouteDense},
+		{0, 0, false, RouteDense},
+		{1, 1, false, RouteDense},
+	} {
+		if got := chooseRoute(c.density, c.crossover, c.structured); got != c.route {
+			t.Errorf("Density %v, crossover %v, structured %v: expected %v. Got %v", c.density, c.crossover, c.structured, c.route, got)
+		}
+	}
+}
+
+func TestHybrid(t *testing.T) {
+	if devices, _ := cu.NumDevices(); devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := cu.Device(0).MakeContext(cu.SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+
+	h, err := NewHandle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	impl := cublas.New()
+	defer impl.Close()
+	hy := NewHybrid(h, impl)
+
+	// column major: A = [0 2 0; 1 0 0], B = [1 2; 3 4; 5 6], A·B = [6 8; 1 2]
+	const size = 6 * 4
+	mem, err := cu.MemAlloc(3 * size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cu.MemFree(mem)
+	a := Dense{Rows: 2, Cols: 3, LD: 2, Dtype: cu.DtFloat32, Ptr: mem}
+	b := Dense{Rows: 3, Cols: 2, LD: 3, Dtype: cu.DtFloat32, Ptr: mem.Offset(size)}
+	c := Dense{Rows: 2, Cols: 2, LD: 2, Dtype: cu.DtFloat32, Ptr: mem.Offset(2 * size)}
+	host := []float32{0, 1, 2, 0, 0, 0, 1, 3, 5, 2, 4, 6}
+	if err = cu.MemcpyHtoD(mem, unsafe.Pointer(&host[0]), int64(len(host)*4)); err != nil {
+		t.Fatal(err)
+	}
+
+	// A has a density of 1/3
+	for _, tc := range []struct {
+		crossover float64
+		route     Route
+	}{{0.5, RouteSpMM}, {0.1, RouteDense}} {
+		SetCrossover(cu.Device(0), tc.crossover)
+		route, err := hy.Matmul(a, b, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if route != tc.route {
+			t.Errorf("Crossover %v: expected the %v route. Got %v", tc.crossover, tc.route, route)
+		}
+		got := make([]float32, 4)
+		if err = cu.MemcpyDtoH(unsafe.Pointer(&got[0]), c.Ptr, 16); err != nil {
+			t.Fatal(err)
+		}
+		if got[0] != 6 || got[1] != 1 || got[2] != 8 || got[3] != 2 {
+			t.Errorf("%v route: expected [6 1 8 2]. Got %v", route, got)
+		}
+		if err = cu.MemsetD32(c.Ptr, 0, 4); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package cusparse
+
+// #include <cusparse.h>
+import "C"
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// workspace is a device buffer of cuSPARSE. The zero value is an empty buffer.
+type workspace struct {
+	ptr  cu.DevicePtr
+	size C.size_t
+}
+
+func (w *workspace) alloc() (err error) {
+	if w.size == 0 {
+		return nil
+	}
+	w.ptr, err = cu.MemAlloc(int64(w.size))
+	return errors.Wrapf(err, "Unable to allocate a workspace of %d bytes", int64(w.size))
+}
+
+func (w *workspace) free() {
+	if w.ptr != 0 {
+		cu.MemFree(w.ptr)
+	}
+}
+
+// release waits for the work enqueued on the stream of the handle, then frees the workspaces, which that work may use.
+func (h *Handle) release(ws ...*workspace) {
+	h.stream.Synchronize()
+	for _, w := range ws {
+		w.free()
+	}
+}
+
+// SpGEMM computes the product of sparse matrices alpha·a·b, and returns it as a new matrix. The number of entries of
+// the product is not known in advance, so the product is computed in phases: the work is estimated, the product is
+// computed into workspaces, and copied to the result once its size is known. The workspaces are allocated, and freed
+// once the product is done: SpGEMM waits for the stream of the handle.
+//
+// a and b must have the same Dtype, either float32 or float64. Free the result with Free.
+func (h *Handle) SpGEMM(alpha float64, a, b *CSR) (*CSR, error) {
+	if a.Cols != b.Rows {
+		return nil, errors.Errorf("SpGEMM: cannot multiply a %d×%d matrix by a %d×%d matrix", a.Rows, a.Cols, b.Rows, b.Cols)
+	}
+	if a.Dtype != b.Dtype {
+		return nil, errors.Errorf("SpGEMM: the matrices are %v and %v", a.Dtype, b.Dtype)
+	}
+	alphaBuf, err := scalar(alpha, a.Dtype)
+	if err != nil {
+		return nil, errors.Wrap(err, "SpGEMM")
+	}
+	var betaBuf [8]byte
+	computeType, _ := dataType(a.Dtype)
+
+	var da, db, dc C.cusparseSpMatDescr_t
+	if da, err = a.descr(); err != nil {
+		return nil, errors.Wrap(err, "SpGEMM")
+	}
+	defer C.cusparseDestroySpMat(da)
+	if db, err = b.descr(); err != nil {
+		return nil, errors.Wrap(err, "SpGEMM")
+	}
+	defer C.cusparseDestroySpMat(db)
+	product := &CSR{Rows: a.Rows, Cols: b.Cols, Dtype: a.Dtype}
+	if dc, err = product.descr(); err != nil {
+		return nil, errors.Wrap(err, "SpGEMM")
+	}
+	defer C.cusparseDestroySpMat(dc)
+
+	var desc C.cusparseSpGEMMDescr_t
+	if err = result(C.cusparseSpGEMM_createDescr(&desc)); err != nil {
+		return nil, errors.Wrap(err, "SpGEMM")
+	}
+	defer C.cusparseSpGEMM_destroyDescr(desc)
+
+	const op = C.CUSPARSE_OPERATION_NON_TRANSPOSE
+	alphaPtr, betaPtr := unsafe.Pointer(&alphaBuf[0]), unsafe.Pointer(&betaBuf[0])
+	var work, compute workspace
+	defer h.release(&work, &compute)
+
+	// each phase is called once for the size of its workspace, and once with it
+	if err = result(C.cusparseSpGEMM_workEstimation(h.h, op, op, alphaPtr, da, db, betaPtr, dc, computeType, C.CUSPARSE_SPGEMM_DEFAULT, desc, &work.size, nil)); err != nil {
+		return nil, errors.Wrap(err, "SpGEMM: work estimation")
+	}
+	if err = work.alloc(); err != nil {
+		return nil, errors.Wrap(err, "SpGEMM")
+	}
+	if err = result(C.cusparseSpGEMM_workEstimation(h.h, op, op, alphaPtr, da, db, betaPtr, dc, computeType, C.CUSPARSE_SPGEMM_DEFAULT, desc, &work.size, work.ptr.Pointer())); err != nil {
+		return nil, errors.Wrap(err, "SpGEMM: work estimation")
+	}
+	if err = result(C.cusparseSpGEMM_compute(h.h, op, op, alphaPtr, da, db, betaPtr, dc, computeType, C.CUSPARSE_SPGEMM_DEFAULT, desc, &compute.size, nil)); err != nil {
+		return nil, errors.Wrap(err, "SpGEMM: compute")
+	}
+	if err = compute.alloc(); err != nil {
+		return nil, errors.Wrap(err, "SpGEMM")
+	}
+	if err = result(C.cusparseSpGEMM_compute(h.h, op, op, alphaPtr, da, db, betaPtr, dc, computeType, C.CUSPARSE_SPGEMM_DEFAULT, desc, &compute.size, compute.ptr.Pointer())); err != nil {
+		return nil, errors.Wrap(err, "SpGEMM: compute")
+	}
+
+	var rows, cols, nnz C.int64_t
+	if err = result(C.cusparseSpMatGetSize(dc, &rows, &cols, &nnz)); err != nil {
+		return nil, errors.Wrap(err, "SpGEMM")
+	}
+	product.NNZ = int(nnz)
+	if err = product.alloc(); err != nil {
+		return nil, errors.Wrap(err, "SpGEMM")
+	}
+	if err = result(C.cusparseCsrSetPointers(dc, product.RowOffsets.Pointer(), product.ColIndices.Pointer(), product.Values.Pointer())); err != nil {
+		product.Free()
+		return nil, errors.Wrap(err, "SpGEMM")
+	}
+	if err = result(C.cusparseSpGEMM_copy(h.h, op, op, alphaPtr, da, db, betaPtr, dc, computeType, C.CUSPARSE_SPGEMM_DEFAULT, desc)); err != nil {
+		h.stream.Synchronize()
+		product.Free()
+		return nil, errors.Wrap(err, "SpGEMM: copy")
+	}
+	return product, nil
+}
+
+// Transpose returns the transpose of a as a new matrix, which has the entries of a sorted by column. For the adjacency
+// matrix of a graph, it is the graph with every edge reversed. Transpose waits for the stream of the handle.
+//
+// Free the result with Free.
+func (h *Handle) Transpose(a *CSR) (*CSR, error) {
+	dt, err := dataType(a.Dtype)
+	if err != nil {
+		return nil, errors.Wrap(err, "Transpose")
+	}
+	t, err := AllocCSR(a.Cols, a.Rows, a.NNZ, a.Dtype)
+	if err != nil {
+		return nil, errors.Wrap(err, "Transpose")
+	}
+
+	// the CSC form of a is the CSR form of its transpose
+	var buf workspace
+	defer h.release(&buf)
+	if err = result(C.cusparseCsr2cscEx2_bufferSize(h.h, C.int(a.Rows), C.int(a.Cols), C.int(a.NNZ),
+		a.Values.Pointer(), (*C.int)(a.RowOffsets.Pointer()), (*C.int)(a.ColIndices.Pointer()),
+		t.Values.Pointer(), (*C.int)(t.RowOffsets.Pointer()), (*C.int)(t.ColIndices.Pointer()),
+		dt, C.CUSPARSE_ACTION_NUMERIC, C.CUSPARSE_INDEX_BASE_ZERO, C.CUSPARSE_CSR2CSC_ALG1, &buf.size)); err != nil {
+		t.Free()
+		return nil, errors.Wrap(err, "Transpose")
+	}
+	if err = buf.alloc(); err != nil {
+		t.Free()
+		return nil, errors.Wrap(err, "Transpose")
+	}
+	if err = result(C.cusparseCsr2cscEx2(h.h, C.int(a.Rows), C.int(a.Cols), C.int(a.NNZ),
+		a.Values.Pointer(), (*C.int)(a.RowOffsets.Pointer()), (*C.int)(a.ColIndices.Pointer()),
+		t.Values.Pointer(), (*C.int)(t.RowOffsets.Pointer()), (*C.int)(t.ColIndices.Pointer()),
+		dt, C.CUSPARSE_ACTION_NUMERIC, C.CUSPARSE_INDEX_BASE_ZERO, C.CUSPARSE_CSR2CSC_ALG1, buf.ptr.Pointer())); err != nil {
+		h.stream.Synchronize()
+		t.Free()
+		return nil, errors.Wrap(err, "Transpose")
+	}
+	return t, nil
+}
+
+// COOToCSR compresses the nnz int32 row indices of a matrix in the coordinate format, which must be sorted, into the
+// rows+1 row offsets of the CSR format. The column indices and the values are the same in both formats. This is how an
+// edge list, sorted by source, becomes an adjacency matrix.
+func (h *Handle) COOToCSR(rowIndices cu.DevicePtr, nnz, rows int, rowOffsets cu.DevicePtr) error {
+	err := result(C.cusparseXcoo2csr(h.h, (*C.int)(rowIndices.Pointer()), C.int(nnz), C.int(rows), (*C.int)(rowOffsets.Pointer()), C.CUSPARSE_INDEX_BASE_ZERO))
+	return errors.Wrap(err, "COOToCSR")
+}
+
+// CSRToCOO expands the rows+1 row offsets of a CSR matrix into the nnz row indices of the coordinate format: the source
+// of each edge of an adjacency matrix.
+func (h *Handle) CSRToCOO(rowOffsets cu.DevicePtr, nnz, rows int, rowIndices cu.DevicePtr) error {
+	err := result(C.cusparseXcsr2coo(h.h, (*C.int)(rowOffsets.Pointer()), C.int(nnz), C.int(rows), (*C.int)(rowIndices.Pointer()), C.CUSPARSE_INDEX_BASE_ZERO))
+	return errors.Wrap(err, "CSRToCOO")
+}
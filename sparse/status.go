@@ -0,0 +1,51 @@
+package cusparse
+
+// #include <cusparse.h>
+import "C"
+
+// Status is the status returned by cuSPARSE.
+type Status int
+
+func (err Status) Error() string  { return err.String() }
+func (err Status) String() string { return resString[err] }
+
+func result(x C.cusparseStatus_t) error {
+	err := Status(x)
+	if err == Success {
+		return nil
+	}
+	if _, ok := resString[err]; !ok {
+		return InternalError
+	}
+	return err
+}
+
+const (
+	Success                Status = C.CUSPARSE_STATUS_SUCCESS
+	NotInitialized         Status = C.CUSPARSE_STATUS_NOT_INITIALIZED
+	AllocFailed            Status = C.CUSPARSE_STATUS_ALLOC_FAILED
+	InvalidValue           Status = C.CUSPARSE_STATUS_INVALID_VALUE
+	ArchMismatch           Status = C.CUSPARSE_STATUS_ARCH_MISMATCH
+	MappingError           Status = C.CUSPARSE_STATUS_MAPPING_ERROR
+	ExecFailed             Status = C.CUSPARSE_STATUS_EXECUTION_FAILED
+	InternalError          Status = C.CUSPARSE_STATUS_INTERNAL_ERROR
+	MatrixTypeNotSupported Status = C.CUSPARSE_STATUS_MATRIX_TYPE_NOT_SUPPORTED
+	ZeroPivot              Status = C.CUSPARSE_STATUS_ZERO_PIVOT
+	NotSupported           Status = C.CUSPARSE_STATUS_NOT_SUPPORTED
+	InsufficientResources  Status = C.CUSPARSE_STATUS_INSUFFICIENT_RESOURCES
+)
+
+var resString = map[Status]string{
+	Success:                "Success",
+	NotInitialized:         "NotInitialized",
+	AllocFailed:            "AllocFailed",
+	InvalidValue:           "InvalidValue",
+	ArchMismatch:           "ArchMismatch",
+	MappingError:           "MappingError",
+	ExecFailed:             "ExecFailed",
+	InternalError:          "InternalError",
+	MatrixTypeNotSupported: "MatrixTypeNotSupported",
+	ZeroPivot:              "ZeroPivot",
+	NotSupported:           "NotSupported",
+	InsufficientResources:  "InsufficientResources",
+}
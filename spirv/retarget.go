@@ -0,0 +1,173 @@
+package spirv
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// nvptxDataLayout is the data layout of the nvptx64-nvidia-cuda target.
+const nvptxDataLayout = "e-i64:64-i128:128-v16:16-v32:32-n16:32:64"
+
+var (
+	reDataLayout = regexp.MustCompile(`(?m)^target datalayout = ".*"$`)
+	reTriple     = regexp.MustCompile(`(?m)^target triple = ".*"$`)
+	reAddrSpace  = regexp.MustCompile(`addrspace\((\d+)\)`)
+	reCast       = regexp.MustCompile(`(?m)^(\s*%[-\w.$]+ = )addrspacecast (.+) ([%@][-\w.$]+) to ([^,]+)(.*)$`)
+	reDeclare    = regexp.MustCompile(`(?m)^declare [^@]*@([-\w.$]+)\(`)
+	reDeclLine   = regexp.MustCompile(`(?m)^declare [^@]*@[-\w.$]+\(.*\n?`)
+	reExternal   = regexp.MustCompile(`(?m)^@([-\w.$]+) = external `)
+)
+
+// spirAddrSpaces maps the address spaces of SPIR to those of NVPTX. The private (0) and generic (4) address spaces of
+// SPIR are both the generic address space of NVPTX, in which allocas are.
+var spirAddrSpaces = map[string]string{
+	"1": "1", // global
+	"2": "4", // constant
+	"3": "3", // local, the shared memory of NVPTX
+	"4": "0", // generic
+}
+
+// workItemBuiltin is a work-item function of OpenCL, and the special registers of PTX it is computed from, for each
+// dimension.
+type workItemBuiltin struct {
+	opencl, spirv string // the names of the function in OpenCL C and in SPIR-V friendly IR
+	expr          string // the PTX registers it is computed from: "tid", "ctaid", "ntid", "nctaid", "global id" or "global size"
+	outside       int    // the value outside of the 3 dimensions
+}
+
+var workItemBuiltins = []workItemBuiltin{
+	{"get_global_id", "__spirv_BuiltInGlobalInvocationId", "global id", 0},
+	{"get_local_id", "__spirv_BuiltInLocalInvocationId", "tid", 0},
+	{"get_group_id", "__spirv_BuiltInWorkgroupId", "ctaid", 0},
+	{"get_local_size", "__spirv_BuiltInWorkgroupSize", "ntid", 1},
+	{"get_num_groups", "__spirv_BuiltInNumWorkgroups", "nctaid", 1},
+	{"get_global_size", "__spirv_BuiltInGlobalSize", "global size", 1},
+}
+
+// mangle returns the Itanium mangled name of a function of one parameter, of the type mangled as param.
+func mangle(name, param string) string { return "_Z" + strconv.Itoa(len(name)) + name + param }
+
+// define writes the definition of the builtin, under the name, with the special registers of PTX.
+func (b workItemBuiltin) define(w *strings.Builder, name string) {
+	fmt.Fprintf(w, "\ndefine internal i64 @%s(i32 %%dim) alwaysinline {\nentry:\n", name)
+	fmt.Fprintf(w, "  switch i32 %%dim, label %%outside [i32 0, label %%x i32 1, label %%y i32 2, label %%z]\n")
+	for _, d := range []string{"x", "y", "z"} {
+		fmt.Fprintf(w, "%s:\n", d)
+		sreg := func(reg string) string {
+			v := "%" + reg + "." + d
+			fmt.Fprintf(w, "  %s = call i32 @llvm.nvvm.read.ptx.sreg.%s.%s()\n", v, reg, d)
+			return v
+		}
+		var v string
+		switch b.expr {
+		case "global id":
+			ctaid, ntid, tid := sreg("ctaid"), sreg("ntid"), sreg("tid")
+			fmt.Fprintf(w, "  %%m.%s = mul i32 %s, %s\n  %%v.%s = add i32 %%m.%s, %s\n", d, ctaid, ntid, d, d, tid)
+			v = "%v." + d
+		case "global size":
+			ntid, nctaid := sreg("ntid"), sreg("nctaid")
+			fmt.Fprintf(w, "  %%v.%s = mul i32 %s, %s\n", d, ntid, nctaid)
+			v = "%v." + d
+		default:
+			v = sreg(b.expr)
+		}
+		fmt.Fprintf(w, "  %%r.%s = zext i32 %s to i64\n  ret i64 %%r.%s\n", d, v, d)
+	}
+	fmt.Fprintf(w, "outside:\n  ret i64 %d\n}\n", b.outside)
+}
+
+// barriers are the names of the barriers of work-groups in OpenCL C and SPIR-V friendly IR, with their parameters.
+var barriers = map[string]string{
+	mangle("barrier", "j"):                  "i32",
+	mangle("work_group_barrier", "j"):       "i32",
+	mangle("__spirv_ControlBarrier", "iii"): "i32, i32, i32",
+}
+
+// retarget rewrites the LLVM IR translated from SPIR-V, which targets SPIR, so that llc compiles it into PTX: the
+// triple, data layout and address spaces are those of nvptx64-nvidia-cuda, the kernels are PTX entries, and the
+// work-item functions and barriers of OpenCL are defined with the special registers and barrier of PTX. It returns an
+// *IncapableError if the module uses other builtins, which have no lowering.
+func retarget(ir string) (string, error) {
+	ir = reDataLayout.ReplaceAllString(ir, `target datalayout = "`+nvptxDataLayout+`"`)
+	ir = reTriple.ReplaceAllString(ir, `target triple = "nvptx64-nvidia-cuda"`)
+	ir = reAddrSpace.ReplaceAllStringFunc(ir, func(as string) string {
+		if to, ok := spirAddrSpaces[reAddrSpace.FindStringSubmatch(as)[1]]; ok {
+			return "addrspace(" + to + ")"
+		}
+		return as
+	})
+	// private and generic pointers are both generic, and casts between them are no longer casts between address spaces
+	ir = reCast.ReplaceAllStringFunc(ir, func(line string) string {
+		m := reCast.FindStringSubmatch(line)
+		if normalizeAS(m[2]) != normalizeAS(strings.TrimSpace(m[4])) {
+			return line
+		}
+		return m[1] + "bitcast " + m[2] + " " + m[3] + " to " + m[4] + m[5]
+	})
+	ir = strings.Replace(ir, "spir_kernel ", "ptx_kernel ", -1)
+	ir = strings.Replace(ir, "spir_func ", "", -1)
+
+	// define the builtins that are declared in place of their declarations, and refuse the others
+	var defs strings.Builder
+	defined := make(map[string]bool)
+	for _, m := range reDeclare.FindAllStringSubmatch(ir, -1) {
+		defined[m[1]] = false
+	}
+	for _, b := range workItemBuiltins {
+		for _, name := range []string{mangle(b.opencl, "j"), mangle(b.spirv, "i")} {
+			if _, ok := defined[name]; ok {
+				b.define(&defs, name)
+				defined[name] = true
+			}
+		}
+	}
+	for name, params := range barriers {
+		if _, ok := defined[name]; ok {
+			fmt.Fprintf(&defs, "\ndefine internal void @%s(%s) alwaysinline {\nentry:\n  call void @llvm.nvvm.barrier0()\n  ret void\n}\n", name, params)
+			defined[name] = true
+		}
+	}
+	var unsupported []string
+	for name, ok := range defined {
+		if !ok && !strings.HasPrefix(name, "llvm.") {
+			unsupported = append(unsupported, name)
+		}
+	}
+	for _, m := range reExternal.FindAllStringSubmatch(ir, -1) {
+		unsupported = append(unsupported, m[1])
+	}
+	if len(unsupported) > 0 {
+		sort.Strings(unsupported)
+		return "", &IncapableError{Reason: "the module uses builtins that have no NVPTX lowering: " + strings.Join(unsupported, ", ")}
+	}
+	if defs.Len() == 0 {
+		return ir, nil
+	}
+
+	ir = reDeclLine.ReplaceAllStringFunc(ir, func(decl string) string {
+		if defined[reDeclare.FindStringSubmatch(decl)[1]] {
+			return ""
+		}
+		return decl
+	})
+	var w strings.Builder
+	w.WriteString(ir)
+	w.WriteString(defs.String())
+	for _, reg := range []string{"tid", "ctaid", "ntid", "nctaid"} {
+		for _, d := range []string{"x", "y", "z"} {
+			if name := "llvm.nvvm.read.ptx.sreg." + reg + "." + d; !strings.Contains(ir, "@"+name+"(") {
+				fmt.Fprintf(&w, "declare i32 @%s()\n", name)
+			}
+		}
+	}
+	if !strings.Contains(ir, "@llvm.nvvm.barrier0(") {
+		w.WriteString("declare void @llvm.nvvm.barrier0()\n")
+	}
+	return w.String(), nil
+}
+
+// normalizeAS returns the type with addrspace(0) dropped, as it is the default address space.
+func normalizeAS(typ string) string { return strings.Replace(typ, " addrspace(0)", "", -1) }
@@ -0,0 +1,164 @@
+// Package spirv is a thin compatibility layer for loading SPIR-V kernels as CUDA modules.
+//
+// The CUDA driver cannot load SPIR-V directly. Instead, the SPIR-V binary is translated back into LLVM IR
+// with llvm-spirv (from the SPIRV-LLVM-Translator project), retargeted from SPIR to nvptx64-nvidia-cuda, then compiled
+// into PTX with LLVM's NVPTX backend (llc). The resulting PTX is loaded like any other module.
+//
+// Retargeting sets the triple and data layout of NVPTX, maps the address spaces of SPIR to those of NVPTX, makes the
+// kernels PTX entries, and defines the work-item functions of OpenCL (get_global_id and the like, or the
+// __spirv_BuiltIn* functions of SPIR-V friendly IR) and its barriers with the special registers of PTX. Only 64 bit
+// (Physical64) modules are supported. Kernels that rely on other OpenCL builtins, which have no NVPTX lowering, cannot
+// be translated. Such failures, as well as a missing toolchain, are reported as an *IncapableError so that callers may
+// fall back to other kernel assets.
+package spirv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// Magic is the magic number that every SPIR-V module begins with.
+const Magic uint32 = 0x07230203
+
+// IncapableError is returned when a SPIR-V module cannot be made to run on a CUDA device.
+type IncapableError struct {
+	Reason string
+	Output string // the output of the failing tool, if any
+}
+
+func (err *IncapableError) Error() string {
+	if err.Output == "" {
+		return "spirv: cannot load SPIR-V module: " + err.Reason
+	}
+	return fmt.Sprintf("spirv: cannot load SPIR-V module: %s\n%s", err.Reason, err.Output)
+}
+
+// IsIncapable returns true if the error is an *IncapableError.
+func IsIncapable(err error) bool {
+	_, ok := errors.Cause(err).(*IncapableError)
+	return ok
+}
+
+// IsSPIRV checks if the binary is a SPIR-V module. Both byte orders are accepted.
+func IsSPIRV(bin []byte) bool {
+	if len(bin) < 4 {
+		return false
+	}
+	return binary.LittleEndian.Uint32(bin) == Magic || binary.BigEndian.Uint32(bin) == Magic
+}
+
+// Toolchain is the set of tools used to translate SPIR-V into PTX.
+type Toolchain struct {
+	Translator   string // path to llvm-spirv
+	Disassembler string // path to llvm-dis
+	Compiler     string // path to llc
+	Arch         string // the target architecture, e.g. "sm_50"
+}
+
+// FindToolchain looks for the tools in $PATH. arch is the target architecture, e.g. "sm_50".
+func FindToolchain(arch string) (*Toolchain, error) {
+	tr, err := exec.LookPath("llvm-spirv")
+	if err != nil {
+		return nil, &IncapableError{Reason: "llvm-spirv (SPIRV-LLVM-Translator) is not in $PATH"}
+	}
+	dis, err := exec.LookPath("llvm-dis")
+	if err != nil {
+		return nil, &IncapableError{Reason: "llvm-dis (LLVM) is not in $PATH"}
+	}
+	llc, err := exec.LookPath("llc")
+	if err != nil {
+		return nil, &IncapableError{Reason: "llc (LLVM) is not in $PATH"}
+	}
+	return &Toolchain{Translator: tr, Disassembler: dis, Compiler: llc, Arch: arch}, nil
+}
+
+// ToPTX translates the SPIR-V module into PTX.
+func (tc *Toolchain) ToPTX(bin []byte) (ptx string, err error) {
+	if !IsSPIRV(bin) {
+		return "", errors.New("spirv: not a SPIR-V module")
+	}
+
+	var dir string
+	if dir, err = ioutil.TempDir("", "spirv"); err != nil {
+		return "", errors.Wrap(err, "ToPTX")
+	}
+	defer os.RemoveAll(dir)
+
+	spv := filepath.Join(dir, "kernel.spv")
+	bc := filepath.Join(dir, "kernel.bc")
+	ll := filepath.Join(dir, "kernel.ll")
+	out := filepath.Join(dir, "kernel.ptx")
+	if err = ioutil.WriteFile(spv, bin, 0600); err != nil {
+		return "", errors.Wrap(err, "ToPTX")
+	}
+	if err = run(tc.Translator, "-r", spv, "-o", bc); err != nil {
+		return "", err
+	}
+	if err = run(tc.Disassembler, bc, "-o", ll); err != nil {
+		return "", err
+	}
+	var ir []byte
+	if ir, err = ioutil.ReadFile(ll); err != nil {
+		return "", errors.Wrap(err, "ToPTX")
+	}
+	var nvptx string
+	if nvptx, err = retarget(string(ir)); err != nil {
+		return "", err
+	}
+	if err = ioutil.WriteFile(ll, []byte(nvptx), 0600); err != nil {
+		return "", errors.Wrap(err, "ToPTX")
+	}
+	if err = run(tc.Compiler, "-march=nvptx64", "-mcpu="+tc.Arch, ll, "-o", out); err != nil {
+		return "", err
+	}
+
+	var p []byte
+	if p, err = ioutil.ReadFile(out); err != nil {
+		return "", errors.Wrap(err, "ToPTX")
+	}
+	return string(p), nil
+}
+
+// Load translates the SPIR-V module and loads it into the current context.
+func (tc *Toolchain) Load(bin []byte) (cu.Module, error) {
+	ptx, err := tc.ToPTX(bin)
+	if err != nil {
+		return cu.Module{}, err
+	}
+	return cu.LoadData(ptx)
+}
+
+// Load loads the SPIR-V module into the current context, targetting the compute capability of the given device.
+func Load(d cu.Device, bin []byte) (cu.Module, error) {
+	major, minor, err := d.ComputeCapability()
+	if err != nil {
+		return cu.Module{}, errors.Wrap(err, "Load")
+	}
+	tc, err := FindToolchain(fmt.Sprintf("sm_%d%d", major, minor))
+	if err != nil {
+		return cu.Module{}, err
+	}
+	return tc.Load(bin)
+}
+
+func run(tool string, args ...string) error {
+	var buf bytes.Buffer
+	cmd := exec.Command(tool, args...)
+	cmd.Stdout = &buf
+	cmd.Stderr = &buf
+	if err := cmd.Run(); err != nil {
+		return &IncapableError{
+			Reason: fmt.Sprintf("%s failed: %v", filepath.Base(tool), err),
+			Output: buf.String(),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package spirv
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestIsSPIRV(t *testing.T) {
+	if !IsSPIRV([]byte{0x03, 0x02, 0x23, 0x07, 0x00}) {
+		t.Errorf("Expected little endian magic to be detected")
+	}
+	if !IsSPIRV([]byte{0x07, 0x23, 0x02, 0x03}) {
+		t.Errorf("Expected big endian magic to be detected")
+	}
+	if IsSPIRV([]byte("// PTX")) {
+		t.Errorf("Expected PTX not to be detected as SPIR-V")
+	}
+	if IsSPIRV(nil) {
+		t.Errorf("Expected empty input not to be detected as SPIR-V")
+	}
+}
+
+func TestFindToolchain(t *testing.T) {
+	path := os.Getenv("PATH")
+	defer os.Setenv("PATH", path)
+	os.Setenv("PATH", "")
+
+	_, err := FindToolchain("sm_50")
+	if !IsIncapable(err) {
+		t.Errorf("Expected an IncapableError when the toolchain is missing. Got %v", err)
+	}
+}
+
+// kernelIR is a kernel as llvm-spirv translates it from SPIR-V: out[get_global_id(0)] = get_local_size(0). PTR is the
+// type of the pointer to global memory, which depends on the version of LLVM.
+const kernelIR = `target datalayout = "e-i64:64-v16:16-v24:32-v32:32-v48:64-v96:128-v192:256-v256:256-v512:512-v1024:1024"
+target triple = "spir64-unknown-unknown"
+
+define spir_kernel void @fill(PTR %out) {
+entry:
+  %id = call spir_func i64 @_Z13get_global_idj(i32 0)
+  %size = call spir_func i64 @_Z14get_local_sizej(i32 0)
+  call spir_func void @_Z7barrierj(i32 1)
+  %p = getelementptr inbounds i64, PTR %out, i64 %id
+  store i64 %size, PTR %p, align 8
+  ret void
+}
+
+declare spir_func i64 @_Z13get_global_idj(i32)
+declare spir_func i64 @_Z14get_local_sizej(i32)
+declare spir_func void @_Z7barrierj(i32)
+`
+
+// llvmIR returns the kernel in the IR of the LLVM of llc, whose pointers are typed before LLVM 15.
+func llvmIR(t *testing.T, llc string) string {
+	out, err := exec.Command(llc, "--version").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ptr := "ptr addrspace(1)"
+	if m := regexp.MustCompile(`LLVM version (\d+)`).FindSubmatch(out); m != nil {
+		if v, _ := strconv.Atoi(string(m[1])); v < 15 {
+			ptr = "i64 addrspace(1)*"
+		}
+	}
+	return strings.Replace(kernelIR, "PTR", ptr, -1)
+}
+
+func checkPTX(t *testing.T, ptx string) {
+	t.Helper()
+	for _, s := range []string{".entry fill", "%ctaid.x", "%ntid.x", "%tid.x", "bar.sync"} {
+		if !strings.Contains(ptx, s) {
+			t.Errorf("Expected the PTX to have %q:\n%s", s, ptx)
+		}
+	}
+}
+
+func TestRetarget(t *testing.T) {
+	llc, err := exec.LookPath("llc")
+	if err != nil {
+		t.Skip("llc is not in $PATH")
+	}
+	ir, err := retarget(llvmIR(t, llc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(ir, `target triple = "nvptx64-nvidia-cuda"`) {
+		t.Errorf("Expected the triple to be retargeted:\n%s", ir)
+	}
+
+	dir, err := ioutil.TempDir("", "spirv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ll, out := filepath.Join(dir, "kernel.ll"), filepath.Join(dir, "kernel.ptx")
+	if err = ioutil.WriteFile(ll, []byte(ir), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = run(llc, "-march=nvptx64", "-mcpu=sm_50", ll, "-o", out); err != nil {
+		t.Fatal(err)
+	}
+	ptx, err := ioutil.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPTX(t, string(ptx))
+}
+
+func TestRetargetUnsupported(t *testing.T) {
+	ir := strings.Replace(kernelIR, "declare spir_func void @_Z7barrierj(i32)", "declare spir_func float @_Z3sinf(float)", 1)
+	_, err := retarget(ir)
+	if !IsIncapable(err) || !strings.Contains(err.Error(), "_Z3sinf") {
+		t.Errorf("Expected an IncapableError naming the unsupported builtin. Got %v", err)
+	}
+}
+
+func TestToPTX(t *testing.T) {
+	tc, err := FindToolchain("sm_50")
+	if err != nil {
+		t.Skip(err)
+	}
+	as, err := exec.LookPath("llvm-as")
+	if err != nil {
+		t.Skip("llvm-as is not in $PATH")
+	}
+
+	// the SPIR-V of the kernel is made by the toolchain, from its IR
+	dir, err := ioutil.TempDir("", "spirv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ll, bc, spv := filepath.Join(dir, "kernel.ll"), filepath.Join(dir, "kernel.bc"), filepath.Join(dir, "kernel.spv")
+	if err = ioutil.WriteFile(ll, []byte(llvmIR(t, tc.Compiler)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = run(as, ll, "-o", bc); err != nil {
+		t.Fatal(err)
+	}
+	if err = run(tc.Translator, bc, "-o", spv); err != nil {
+		t.Fatal(err)
+	}
+	bin, err := ioutil.ReadFile(spv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ptx, err := tc.ToPTX(bin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPTX(t, ptx)
+}
@@ -71,5 +71,5 @@ func (ctx *Ctx) MakeStreamWithPriority(priority int, flags StreamFlags) (Stream,
 
 func (ctx *Ctx) DestroyStream(hStream *Stream) {
 	f := func() error { return result(C.cuStreamDestroy(hStream.s)) }
-	ctx.err = ctx.Do(f)
+	ctx.setErr(ctx.Do(f))
 }
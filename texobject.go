@@ -0,0 +1,202 @@
+package cu
+
+// #include <cuda.h>
+import "C"
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// ResourceDesc describes the memory a texture or surface object reads: an array, a mipmapped array, or linear or
+// pitched device memory. It is made by ArrayResource, MipmappedResource, LinearResource or Pitch2DResource.
+type ResourceDesc struct {
+	Type ResourceType
+
+	Array  Array          // ResourceArray
+	Mipmap MipmappedArray // ResourceMipmappedArray
+
+	// ResourceLinear and ResourcePitch2D
+	Ptr         DevicePtr
+	Format      Format
+	NumChannels uint
+	SizeInBytes int64 // ResourceLinear
+	Width       int64 // ResourcePitch2D, in elements
+	Height      int64 // ResourcePitch2D, in elements
+	Pitch       int64 // ResourcePitch2D, the bytes between the starts of the rows
+}
+
+// ArrayResource describes a CUDA array.
+func ArrayResource(arr Array) ResourceDesc { return ResourceDesc{Type: ResourceArray, Array: arr} }
+
+// MipmappedResource describes a mipmapped CUDA array.
+func MipmappedResource(m MipmappedArray) ResourceDesc {
+	return ResourceDesc{Type: ResourceMipmappedArray, Mipmap: m}
+}
+
+// LinearResource describes size bytes of device memory, read as elements of numChannels channels of the format.
+func LinearResource(ptr DevicePtr, format Format, numChannels uint, size int64) ResourceDesc {
+	return ResourceDesc{Type: ResourceLinear, Ptr: ptr, Format: format, NumChannels: numChannels, SizeInBytes: size}
+}
+
+// Pitch2DResource describes a 2D image in device memory, of width×height elements of numChannels channels of the
+// format, whose rows start pitch bytes apart (see MemAllocPitch).
+func Pitch2DResource(ptr DevicePtr, format Format, numChannels uint, width, height, pitch int64) ResourceDesc {
+	return ResourceDesc{Type: ResourcePitch2D, Ptr: ptr, Format: format, NumChannels: numChannels, Width: width, Height: height, Pitch: pitch}
+}
+
+// resLinear and resPitch2D are the layouts of the members of the union of CUDA_RESOURCE_DESC, which cgo does not
+// expose.
+type resLinear struct {
+	devPtr      C.CUdeviceptr
+	format      C.CUarray_format
+	numChannels C.uint
+	sizeInBytes C.size_t
+}
+
+type resPitch2D struct {
+	devPtr       C.CUdeviceptr
+	format       C.CUarray_format
+	numChannels  C.uint
+	width        C.size_t
+	height       C.size_t
+	pitchInBytes C.size_t
+}
+
+func (r ResourceDesc) c() (*C.CUDA_RESOURCE_DESC, error) {
+	var desc C.CUDA_RESOURCE_DESC
+	desc.resType = C.CUresourcetype(r.Type)
+	res := unsafe.Pointer(&desc.res[0])
+	switch r.Type {
+	case ResourceArray:
+		if r.Array.arr == nil {
+			return nil, errors.New("The resource has no array")
+		}
+		*(*C.CUarray)(res) = r.Array.c()
+	case ResourceMipmappedArray:
+		*(*C.CUmipmappedArray)(res) = r.Mipmap.arr
+	case ResourceLinear:
+		*(*resLinear)(res) = resLinear{
+			devPtr:      C.CUdeviceptr(r.Ptr),
+			format:      C.CUarray_format(r.Format),
+			numChannels: C.uint(r.NumChannels),
+			sizeInBytes: C.size_t(r.SizeInBytes),
+		}
+	case ResourcePitch2D:
+		*(*resPitch2D)(res) = resPitch2D{
+			devPtr:       C.CUdeviceptr(r.Ptr),
+			format:       C.CUarray_format(r.Format),
+			numChannels:  C.uint(r.NumChannels),
+			width:        C.size_t(r.Width),
+			height:       C.size_t(r.Height),
+			pitchInBytes: C.size_t(r.Pitch),
+		}
+	default:
+		return nil, errors.Errorf("Unknown resource type %d", r.Type)
+	}
+	return &desc, nil
+}
+
+// TextureDesc describes how a texture object samples its resource: how the coordinates out of the resource are
+// addressed, and whether the elements are interpolated.
+type TextureDesc struct {
+	AddressMode [3]AddressMode // by dimension
+	FilterMode  FilterMode     // LinearFilterMode interpolates the elements in hardware. It requires floating point reads.
+	Flags       TexRefFlags
+
+	MaxAnisotropy       uint
+	MipmapFilterMode    FilterMode
+	MipmapLevelBias     float32
+	MinMipmapLevelClamp float32
+	MaxMipmapLevelClamp float32
+	BorderColor         [4]float32 // the value of the coordinates out of the resource with BorderMode
+}
+
+func (t TextureDesc) c() *C.CUDA_TEXTURE_DESC {
+	var desc C.CUDA_TEXTURE_DESC
+	for i, m := range t.AddressMode {
+		desc.addressMode[i] = C.CUaddress_mode(m)
+	}
+	desc.filterMode = C.CUfilter_mode(t.FilterMode)
+	desc.flags = C.uint(t.Flags)
+	desc.maxAnisotropy = C.uint(t.MaxAnisotropy)
+	desc.mipmapFilterMode = C.CUfilter_mode(t.MipmapFilterMode)
+	desc.mipmapLevelBias = C.float(t.MipmapLevelBias)
+	desc.minMipmapLevelClamp = C.float(t.MinMipmapLevelClamp)
+	desc.maxMipmapLevelClamp = C.float(t.MaxMipmapLevelClamp)
+	for i, v := range t.BorderColor {
+		desc.borderColor[i] = C.float(v)
+	}
+	return &desc
+}
+
+// TexObject is a texture object: a resource read by kernels through the texture units, which cache reads of 2D
+// neighbourhoods, address coordinates out of the resource, and interpolate the elements in hardware. Kernels take it
+// as a cudaTextureObject_t, which is passed as a uint64:
+//
+//	tex, err := cu.MakeTexObject(cu.ArrayResource(arr), cu.TextureDesc{FilterMode: cu.LinearFilterMode})
+//	args := []unsafe.Pointer{unsafe.Pointer(&tex), unsafe.Pointer(&out)}
+type TexObject uint64
+
+// MakeTexObject creates a texture object of the resource.
+func MakeTexObject(res ResourceDesc, tex TextureDesc) (TexObject, error) {
+	cres, err := res.c()
+	if err != nil {
+		return 0, err
+	}
+	var obj C.CUtexObject
+	if err = result(C.cuTexObjectCreate(&obj, cres, tex.c(), nil)); err != nil {
+		return 0, errors.Wrap(err, "TexObjectCreate")
+	}
+	return TexObject(obj), nil
+}
+
+// Destroy destroys the texture object. The resource is not freed.
+func (t TexObject) Destroy() error {
+	return errors.Wrap(result(C.cuTexObjectDestroy(C.CUtexObject(t))), "TexObjectDestroy")
+}
+
+// SurfObject is a surface object: a CUDA array read and written by kernels with the surface functions. Kernels take it
+// as a cudaSurfaceObject_t, which is passed as a uint64.
+type SurfObject uint64
+
+// MakeSurfObject creates a surface object of the resource, which must be an array created with ArraySurfaceLoadStore.
+func MakeSurfObject(res ResourceDesc) (SurfObject, error) {
+	if res.Type != ResourceArray {
+		return 0, errors.Errorf("Surface objects are made of arrays. Got a resource of type %d", res.Type)
+	}
+	cres, err := res.c()
+	if err != nil {
+		return 0, err
+	}
+	var obj C.CUsurfObject
+	if err = result(C.cuSurfObjectCreate(&obj, cres)); err != nil {
+		return 0, errors.Wrap(err, "SurfObjectCreate")
+	}
+	return SurfObject(obj), nil
+}
+
+// Destroy destroys the surface object. The array is not freed.
+func (s SurfObject) Destroy() error {
+	return errors.Wrap(result(C.cuSurfObjectDestroy(C.CUsurfObject(s))), "SurfObjectDestroy")
+}
+
+// MakeTexObject creates a texture object of the resource in the context.
+func (ctx *Ctx) MakeTexObject(res ResourceDesc, tex TextureDesc) (t TexObject, err error) {
+	err = ctx.Do(func() error {
+		var err error
+		t, err = MakeTexObject(res, tex)
+		return err
+	})
+	return
+}
+
+// MakeSurfObject creates a surface object of the resource in the context.
+func (ctx *Ctx) MakeSurfObject(res ResourceDesc) (s SurfObject, err error) {
+	err = ctx.Do(func() error {
+		var err error
+		s, err = MakeSurfObject(res)
+		return err
+	})
+	return
+}
@@ -0,0 +1,48 @@
+package cu
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestTexObject(t *testing.T) {
+	devices, _ := NumDevices()
+	if devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := Device(0).MakeContext(SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+
+	arr, err := Make3DArray(Array3Desc{Width: 16, Height: 16, Format: Float32, NumChannels: 1, Flags: uint(ArraySurfaceLoadStore)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer arr.Destroy()
+	tex, err := MakeTexObject(ArrayResource(arr), TextureDesc{
+		AddressMode: [3]AddressMode{ClampMode, ClampMode},
+		FilterMode:  LinearFilterMode,
+		Flags:       NormalizeCoordinates,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = tex.Destroy(); err != nil {
+		t.Error(err)
+	}
+	surf, err := MakeSurfObject(ArrayResource(arr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = surf.Destroy(); err != nil {
+		t.Error(err)
+	}
+
+	if _, err = MakeSurfObject(LinearResource(0, Float32, 1, 64)); err == nil {
+		t.Error("Expected surface objects of linear memory to be rejected")
+	}
+}
@@ -0,0 +1,123 @@
+package cu
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// idleThreadTimeout is how long an idle locked thread is kept before it is terminated.
+const idleThreadTimeout = time.Minute
+
+// threadPool is a pool of goroutines locked to OS threads, used to run the contexts created by NewContext.
+//
+// When a context is closed, the thread it ran on is parked, and reused for the next context.
+// Threads beyond the maximum number of idle threads, or idle for longer than idleThreadTimeout, are terminated:
+// the goroutine exits while still locked, so the OS thread is not handed back to the Go scheduler.
+type threadPool struct {
+	tasks chan func()
+
+	sync.Mutex
+	threads int // number of locked threads
+	idle    int
+	maxIdle int
+}
+
+var lockedThreads = &threadPool{tasks: make(chan func()), maxIdle: 4}
+
+// SetMaxIdleThreads sets the maximum number of idle locked OS threads kept for reuse by new contexts. The default is 4.
+func SetMaxIdleThreads(n int) {
+	lockedThreads.Lock()
+	lockedThreads.maxIdle = n
+	lockedThreads.Unlock()
+}
+
+// LockedThreads returns the number of OS threads locked by the contexts created by NewContext, and how many of
+// those are idle.
+func LockedThreads() (threads, idle int) {
+	lockedThreads.Lock()
+	defer lockedThreads.Unlock()
+	return lockedThreads.threads, lockedThreads.idle
+}
+
+// run runs fn on a goroutine locked to an OS thread, reusing an idle thread if there is one.
+func (p *threadPool) run(fn func()) {
+	select {
+	case p.tasks <- fn:
+	default:
+		go p.loop(fn)
+	}
+}
+
+func (p *threadPool) loop(fn func()) {
+	runtime.LockOSThread()
+	p.Lock()
+	p.threads++
+	p.Unlock()
+	defer func() {
+		p.Lock()
+		p.threads--
+		p.Unlock()
+	}()
+
+	for fn != nil {
+		fn()
+
+		// do not leave a (possibly destroyed) context current on a reused thread
+		SetCurrentContext(CUContext{})
+		fn = p.park()
+	}
+}
+
+// park waits for the next function to run. It returns nil if the thread should be terminated.
+func (p *threadPool) park() (fn func()) {
+	p.Lock()
+	if p.idle >= p.maxIdle {
+		p.Unlock()
+		return nil
+	}
+	p.idle++
+	p.Unlock()
+
+	timer := time.NewTimer(idleThreadTimeout)
+	select {
+	case fn = <-p.tasks:
+	case <-timer.C:
+	}
+	timer.Stop()
+
+	p.Lock()
+	p.idle--
+	p.Unlock()
+	return fn
+}
+
+// ctxLoop tracks the state of the goroutine running a Ctx. It is kept apart from the Ctx, so that the running
+// goroutine does not keep the Ctx reachable, and an abandoned Ctx can be finalized (which stops the goroutine).
+type ctxLoop struct {
+	started chan struct{}
+	done    chan struct{}
+}
+
+func newCtxLoop() *ctxLoop {
+	return &ctxLoop{
+		started: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// wait waits for the loop to exit, if it was started.
+func (l *ctxLoop) wait() {
+	select {
+	case <-l.started:
+		<-l.done
+	default:
+	}
+}
+
+// serve runs the work until the work channel is closed.
+func serve(work <-chan func() error, results chan<- error) {
+	for w := range work {
+		results <- w()
+	}
+}
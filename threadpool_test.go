@@ -0,0 +1,34 @@
+package cu
+
+import (
+	"testing"
+	"time"
+)
+
+func TestThreadPool(t *testing.T) {
+	p := &threadPool{tasks: make(chan func()), maxIdle: 1}
+	done := make(chan struct{})
+	p.run(func() { done <- struct{}{} })
+	<-done
+
+	// wait for the thread to be parked
+	for i := 0; i < 100; i++ {
+		p.Lock()
+		idle := p.idle
+		p.Unlock()
+		if idle == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// the parked thread picks up the next function
+	p.run(func() { done <- struct{}{} })
+	<-done
+	p.Lock()
+	threads := p.threads
+	p.Unlock()
+	if threads != 1 {
+		t.Errorf("Expected the idle thread to be reused. %d threads were locked", threads)
+	}
+}
@@ -0,0 +1,194 @@
+package cu
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// streamTraffic are the counters of a stream. They are updated atomically.
+type streamTraffic struct {
+	bytes  [numTransferKinds]int64
+	copies [numTransferKinds]int64
+}
+
+var traffic struct {
+	on uint32 // read atomically
+
+	streams sync.Map // Stream → *streamTraffic
+
+	sync.Mutex
+	since time.Time
+}
+
+// CountTraffic turns the counting of the bytes copied by every stream on or off. Counting costs a few atomic additions
+// per copy. Turning it on resets the counters.
+//
+// The copies counted are the linear ones: Memcpy, MemcpyPeer, MemcpyHtoD, MemcpyDtoH, MemcpyDtoD and their asynchronous
+// versions, whether they are called directly, on a Ctx, or on a BatchedContext, where they are counted when queued.
+// Synchronous copies are counted on NoStream.
+func CountTraffic(on bool) {
+	if !on {
+		atomic.StoreUint32(&traffic.on, 0)
+		return
+	}
+	ResetTraffic()
+	atomic.StoreUint32(&traffic.on, 1)
+}
+
+// ResetTraffic resets the counters of all the streams.
+func ResetTraffic() {
+	traffic.Lock()
+	traffic.streams.Range(func(k, _ interface{}) bool {
+		traffic.streams.Delete(k)
+		return true
+	})
+	traffic.since = time.Now()
+	traffic.Unlock()
+}
+
+// counted counts the copy if err, the result of issuing it, is nil, and returns err.
+func counted(kind TransferKind, stream Stream, n int64, err error) error {
+	if err == nil {
+		countCopy(kind, stream, n)
+	}
+	return err
+}
+
+func countCopy(kind TransferKind, stream Stream, n int64) {
+	if atomic.LoadUint32(&traffic.on) == 0 {
+		return
+	}
+	v, ok := traffic.streams.Load(stream)
+	if !ok {
+		v, _ = traffic.streams.LoadOrStore(stream, new(streamTraffic))
+	}
+	t := v.(*streamTraffic)
+	atomic.AddInt64(&t.bytes[kind], n)
+	atomic.AddInt64(&t.copies[kind], 1)
+}
+
+// Traffic is the number of bytes copied on a stream, by direction, over a period of time. The rates are the rates the
+// copies were issued at: comparing them to the bandwidths of the link (see MeasureLinkProfile) tells whether a program
+// is bound by the copies.
+type Traffic struct {
+	Stream  Stream
+	Bytes   [numTransferKinds]int64 // by TransferKind
+	Copies  [numTransferKinds]int64 // by TransferKind
+	Elapsed time.Duration           // the period over which the bytes were copied
+}
+
+// Total returns the number of bytes copied in all directions.
+func (t Traffic) Total() (total int64) {
+	for _, b := range t.Bytes {
+		total += b
+	}
+	return total
+}
+
+// Rate returns the rate of the copies of the kind, in bytes per second.
+func (t Traffic) Rate(kind TransferKind) float64 {
+	if t.Elapsed <= 0 {
+		return 0
+	}
+	return float64(t.Bytes[kind]) / t.Elapsed.Seconds()
+}
+
+// Utilization returns the rate of the copies of the kind, as a fraction of the bandwidth measured for that kind. It is
+// 0 for the kinds that have no bandwidth in the profile.
+func (t Traffic) Utilization(p LinkProfile, kind TransferKind) float64 {
+	var bw float64
+	switch kind {
+	case HtoD:
+		bw = p.HtoDBandwidth
+	case DtoH:
+		bw = p.DtoHBandwidth
+	case DtoD:
+		bw = p.DtoDBandwidth
+	}
+	if bw <= 0 {
+		return 0
+	}
+	return t.Rate(kind) / bw
+}
+
+// Sub returns the traffic since an earlier snapshot of the same stream, so that rates may be computed over intervals:
+//
+//	prev := cu.StreamTraffic(s)
+//	time.Sleep(time.Second)
+//	rate := cu.StreamTraffic(s).Sub(prev).Rate(cu.HtoD)
+func (t Traffic) Sub(prev Traffic) Traffic {
+	for i := range t.Bytes {
+		t.Bytes[i] -= prev.Bytes[i]
+		t.Copies[i] -= prev.Copies[i]
+	}
+	t.Elapsed -= prev.Elapsed
+	return t
+}
+
+func (t Traffic) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Stream %#x over %v:", t.Stream.Uintptr(), t.Elapsed)
+	for k := range t.Bytes {
+		if t.Copies[k] == 0 {
+			continue
+		}
+		fmt.Fprintf(&buf, " %v %d bytes in %d copies (%.1f MB/s);", TransferKind(k), t.Bytes[k], t.Copies[k], t.Rate(TransferKind(k))/1e6)
+	}
+	return buf.String()
+}
+
+// StreamTraffic returns the traffic of the stream since the counters were reset.
+func StreamTraffic(s Stream) Traffic {
+	retVal := Traffic{Stream: s, Elapsed: trafficElapsed()}
+	if v, ok := traffic.streams.Load(s); ok {
+		retVal.load(v.(*streamTraffic))
+	}
+	return retVal
+}
+
+// TrafficReport returns the traffic of every stream that copied memory since the counters were reset, the busiest
+// first.
+func TrafficReport() []Traffic {
+	elapsed := trafficElapsed()
+	var report []Traffic
+	traffic.streams.Range(func(k, v interface{}) bool {
+		t := Traffic{Stream: k.(Stream), Elapsed: elapsed}
+		t.load(v.(*streamTraffic))
+		report = append(report, t)
+		return true
+	})
+	sort.Slice(report, func(i, j int) bool { return report[i].Total() > report[j].Total() })
+	return report
+}
+
+// TotalTraffic returns the traffic of all the streams together. Its Stream is NoStream.
+func TotalTraffic() Traffic {
+	retVal := Traffic{Elapsed: trafficElapsed()}
+	for _, t := range TrafficReport() {
+		for i := range t.Bytes {
+			retVal.Bytes[i] += t.Bytes[i]
+			retVal.Copies[i] += t.Copies[i]
+		}
+	}
+	return retVal
+}
+
+func (t *Traffic) load(st *streamTraffic) {
+	for i := range t.Bytes {
+		t.Bytes[i] = atomic.LoadInt64(&st.bytes[i])
+		t.Copies[i] = atomic.LoadInt64(&st.copies[i])
+	}
+}
+
+func trafficElapsed() time.Duration {
+	traffic.Lock()
+	defer traffic.Unlock()
+	if traffic.since.IsZero() {
+		return 0
+	}
+	return time.Since(traffic.since)
+}
@@ -0,0 +1,52 @@
+package cu
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTraffic(t *testing.T) {
+	CountTraffic(true)
+	defer CountTraffic(false)
+
+	countCopy(HtoD, NoStream, 1024)
+	countCopy(HtoD, NoStream, 1024)
+	before := StreamTraffic(NoStream)
+	countCopy(DtoH, NoStream, 512)
+	if err := counted(DtoD, NoStream, 256, NotReady); err != NotReady {
+		t.Errorf("Expected the error to be returned. Got %v", err)
+	}
+	time.Sleep(time.Millisecond)
+
+	tr := StreamTraffic(NoStream)
+	if tr.Bytes[HtoD] != 2048 || tr.Copies[HtoD] != 2 || tr.Bytes[DtoH] != 512 || tr.Bytes[DtoD] != 0 {
+		t.Errorf("Unexpected counts %v", tr)
+	}
+	if tr.Total() != 2560 {
+		t.Errorf("Expected 2560 bytes in total. Got %d", tr.Total())
+	}
+	if tr.Rate(HtoD) <= 0 || tr.Rate(HtoD) > 2048/tr.Elapsed.Seconds() {
+		t.Errorf("Unexpected HtoD rate %v over %v", tr.Rate(HtoD), tr.Elapsed)
+	}
+	if util := tr.Utilization(LinkProfile{HtoDBandwidth: tr.Rate(HtoD) * 4}, HtoD); util < 0.24 || util > 0.26 {
+		t.Errorf("Expected a quarter of the link to be used. Got %v", util)
+	}
+
+	diff := tr.Sub(before)
+	if diff.Bytes[HtoD] != 0 || diff.Bytes[DtoH] != 512 || diff.Copies[DtoH] != 1 {
+		t.Errorf("Unexpected counts of the interval %v", diff)
+	}
+	if total := TotalTraffic(); total.Total() != 2560 {
+		t.Errorf("Expected 2560 bytes copied by all the streams. Got %d", total.Total())
+	}
+
+	CountTraffic(false)
+	countCopy(HtoD, NoStream, 1024)
+	if tr = StreamTraffic(NoStream); tr.Bytes[HtoD] != 2048 {
+		t.Errorf("Expected no copies to be counted once counting is off. Got %v", tr)
+	}
+	ResetTraffic()
+	if report := TrafficReport(); len(report) != 0 {
+		t.Errorf("Expected the counters to be reset. Got %v", report)
+	}
+}
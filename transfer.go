@@ -0,0 +1,309 @@
+package cu
+
+import (
+	"fmt"
+	"sort"
+	"time"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// TransferKind is the direction of a memory transfer.
+type TransferKind byte
+
+const (
+	HtoD    TransferKind = iota // host to device
+	DtoH                        // device to host
+	DtoD                        // device to device
+	PtoP                        // between the memory of two contexts (MemcpyPeer)
+	Unified                     // inferred by the driver from unified addresses (Memcpy)
+
+	numTransferKinds = int(Unified) + 1
+)
+
+func (k TransferKind) String() string {
+	switch k {
+	case HtoD:
+		return "HtoD"
+	case DtoH:
+		return "DtoH"
+	case DtoD:
+		return "DtoD"
+	case PtoP:
+		return "PtoP"
+	case Unified:
+		return "Unified"
+	}
+	return fmt.Sprintf("UnknownTransferKind:%d", k)
+}
+
+// Transfer is a pending memory transfer.
+//
+// For HtoD transfers, Host is the source and Dst is the destination.
+// For DtoH transfers, Src is the source and Host is the destination.
+// For DtoD transfers, Src is the source and Dst is the destination.
+type Transfer struct {
+	Kind TransferKind
+	Dst  DevicePtr
+	Src  DevicePtr
+	Host unsafe.Pointer
+	Size int64
+}
+
+// LinkProfile describes the measured characteristics of the link between the host and a device.
+// Bandwidths are in bytes per second. Latency is the fixed cost of issuing a single copy.
+//
+// CopyEngines is the number of asynchronous copy engines of the device. With fewer than two copy engines,
+// uploads and downloads cannot overlap.
+type LinkProfile struct {
+	HtoDBandwidth float64
+	DtoHBandwidth float64
+	DtoDBandwidth float64
+	Latency       time.Duration
+	CopyEngines   int
+}
+
+// Cost estimates the time it takes to perform the transfer.
+func (p LinkProfile) Cost(t Transfer) time.Duration {
+	var bw float64
+	switch t.Kind {
+	case HtoD:
+		bw = p.HtoDBandwidth
+	case DtoH:
+		bw = p.DtoHBandwidth
+	case DtoD:
+		bw = p.DtoDBandwidth
+	}
+	if bw <= 0 {
+		return p.Latency
+	}
+	return p.Latency + time.Duration(float64(t.Size)/bw*float64(time.Second))
+}
+
+// TransferLane is a sequence of transfers that are to be issued in order on a single stream.
+type TransferLane struct {
+	Kind      TransferKind
+	Transfers []Transfer
+	Estimate  time.Duration
+}
+
+// TransferPlan is the result of planning a set of transfers.
+// Each lane is meant to be executed on its own stream, so that the lanes may proceed concurrently.
+type TransferPlan struct {
+	Lanes    []TransferLane
+	Estimate time.Duration // estimated time taken for all the lanes to complete
+}
+
+// TransferPlanner orders and parallelizes pending transfers.
+//
+// Transfers are split by direction so that uploads and downloads do not serialize behind one another on a single stream.
+// Within each direction, transfers that are contiguous on both ends are coalesced into a single copy,
+// which saves the per-copy latency that dominates small transfers.
+type TransferPlanner struct {
+	Profile LinkProfile
+}
+
+// NewTransferPlanner creates a TransferPlanner with the given link profile.
+func NewTransferPlanner(profile LinkProfile) *TransferPlanner {
+	return &TransferPlanner{Profile: profile}
+}
+
+// Plan plans the given HtoD, DtoH and DtoD transfers; the others are dropped. The input slice is not modified.
+func (p *TransferPlanner) Plan(transfers []Transfer) TransferPlan {
+	var byKind [3][]Transfer
+	for _, t := range transfers {
+		if t.Size <= 0 || t.Kind > DtoD {
+			continue
+		}
+		byKind[t.Kind] = append(byKind[t.Kind], t)
+	}
+
+	var plan TransferPlan
+	var hostLinks time.Duration // time taken by HtoD and DtoH lanes when they cannot overlap
+	for kind, ts := range byKind {
+		if len(ts) == 0 {
+			continue
+		}
+		lane := TransferLane{Kind: TransferKind(kind), Transfers: coalesce(ts)}
+		for _, t := range lane.Transfers {
+			lane.Estimate += p.Profile.Cost(t)
+		}
+		if lane.Estimate > plan.Estimate {
+			plan.Estimate = lane.Estimate
+		}
+		if lane.Kind != DtoD {
+			hostLinks += lane.Estimate
+		}
+		plan.Lanes = append(plan.Lanes, lane)
+	}
+	if p.Profile.CopyEngines < 2 && hostLinks > plan.Estimate {
+		plan.Estimate = hostLinks
+	}
+	return plan
+}
+
+// Execute issues the planned transfers. Lane i is issued on streams[i].
+// The transfers are asynchronous; synchronize the streams to wait for the transfers to complete.
+func (plan TransferPlan) Execute(streams []Stream) error {
+	if len(streams) < len(plan.Lanes) {
+		return errors.Errorf("Execute requires %d streams. Got %d instead", len(plan.Lanes), len(streams))
+	}
+	for i, lane := range plan.Lanes {
+		for _, t := range lane.Transfers {
+			var err error
+			switch t.Kind {
+			case HtoD:
+				err = MemcpyHtoDAsync(t.Dst, t.Host, t.Size, streams[i])
+			case DtoH:
+				err = MemcpyDtoHAsync(t.Host, t.Src, t.Size, streams[i])
+			case DtoD:
+				err = MemcpyDtoDAsync(t.Dst, t.Src, t.Size, streams[i])
+			}
+			if err != nil {
+				return errors.Wrapf(err, "Failed to issue %v transfer of %d bytes", t.Kind, t.Size)
+			}
+		}
+	}
+	return nil
+}
+
+// ExecuteOn issues the planned transfers on the streams dedicated to each transfer direction.
+func (plan TransferPlan) ExecuteOn(cs CopyStreams) error {
+	streams := make([]Stream, len(plan.Lanes))
+	for i, lane := range plan.Lanes {
+		streams[i] = cs.For(lane.Kind)
+	}
+	return plan.Execute(streams)
+}
+
+// coalesce merges transfers that are contiguous on both ends. All the transfers are expected to be of the same kind.
+func coalesce(ts []Transfer) []Transfer {
+	retVal := make([]Transfer, len(ts))
+	copy(retVal, ts)
+	sort.SliceStable(retVal, func(i, j int) bool { return transferStart(retVal[i]) < transferStart(retVal[j]) })
+
+	merged := retVal[:1]
+	for _, t := range retVal[1:] {
+		last := &merged[len(merged)-1]
+		if contiguous(*last, t) {
+			last.Size += t.Size
+			continue
+		}
+		merged = append(merged, t)
+	}
+	return merged
+}
+
+func transferStart(t Transfer) uintptr {
+	if t.Kind == DtoH {
+		return uintptr(t.Src)
+	}
+	return uintptr(t.Dst)
+}
+
+func contiguous(a, b Transfer) bool {
+	switch a.Kind {
+	case HtoD:
+		return uintptr(a.Dst)+uintptr(a.Size) == uintptr(b.Dst) && uintptr(a.Host)+uintptr(a.Size) == uintptr(b.Host)
+	case DtoH:
+		return uintptr(a.Src)+uintptr(a.Size) == uintptr(b.Src) && uintptr(a.Host)+uintptr(a.Size) == uintptr(b.Host)
+	case DtoD:
+		return uintptr(a.Dst)+uintptr(a.Size) == uintptr(b.Dst) && uintptr(a.Src)+uintptr(a.Size) == uintptr(b.Src)
+	}
+	return false
+}
+
+// MeasureLinkProfile measures the characteristics of the link between the host and the device of the current context.
+// size is the size (in bytes) of the buffer used to measure the bandwidths.
+//
+// This function requires a context to be current on the calling thread.
+func MeasureLinkProfile(size int64) (profile LinkProfile, err error) {
+	if size <= 0 {
+		return profile, errors.Errorf("Cannot measure link with a buffer of size %d", size)
+	}
+	host := make([]byte, size)
+	h := unsafe.Pointer(&host[0])
+
+	var d0, d1 DevicePtr
+	if d0, err = MemAlloc(size); err != nil {
+		return profile, errors.Wrap(err, "MeasureLinkProfile")
+	}
+	defer MemFree(d0)
+	if d1, err = MemAlloc(size); err != nil {
+		return profile, errors.Wrap(err, "MeasureLinkProfile")
+	}
+	defer MemFree(d1)
+
+	var start, end Event
+	if start, err = MakeEvent(DefaultEvent); err != nil {
+		return profile, errors.Wrap(err, "MeasureLinkProfile")
+	}
+	defer DestroyEvent(&start)
+	if end, err = MakeEvent(DefaultEvent); err != nil {
+		return profile, errors.Wrap(err, "MeasureLinkProfile")
+	}
+	defer DestroyEvent(&end)
+
+	timeit := func(copyfn func() error) (time.Duration, error) {
+		if err := start.Record(NoStream); err != nil {
+			return 0, err
+		}
+		if err := copyfn(); err != nil {
+			return 0, err
+		}
+		if err := end.Record(NoStream); err != nil {
+			return 0, err
+		}
+		if err := end.Synchronize(); err != nil {
+			return 0, err
+		}
+		ms, err := start.Elapsed(end)
+		return time.Duration(ms * float64(time.Millisecond)), err
+	}
+	bandwidth := func(d time.Duration) float64 {
+		if d <= 0 {
+			return 0
+		}
+		return float64(size) / d.Seconds()
+	}
+
+	var d time.Duration
+	if d, err = timeit(func() error { return MemcpyHtoDAsync(d0, h, size, NoStream) }); err != nil {
+		return profile, errors.Wrap(err, "Failed to measure HtoD bandwidth")
+	}
+	profile.HtoDBandwidth = bandwidth(d)
+
+	if d, err = timeit(func() error { return MemcpyDtoHAsync(h, d0, size, NoStream) }); err != nil {
+		return profile, errors.Wrap(err, "Failed to measure DtoH bandwidth")
+	}
+	profile.DtoHBandwidth = bandwidth(d)
+
+	if d, err = timeit(func() error { return MemcpyDtoDAsync(d1, d0, size, NoStream) }); err != nil {
+		return profile, errors.Wrap(err, "Failed to measure DtoD bandwidth")
+	}
+	profile.DtoDBandwidth = bandwidth(d)
+
+	// latency is measured as the average time taken for a tiny copy
+	const latencyRuns = 16
+	if d, err = timeit(func() error {
+		for i := 0; i < latencyRuns; i++ {
+			if err := MemcpyHtoDAsync(d0, h, 1, NoStream); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return profile, errors.Wrap(err, "Failed to measure latency")
+	}
+	profile.Latency = d / latencyRuns
+
+	var dev Device
+	if dev, err = CurrentDevice(); err != nil {
+		return profile, errors.Wrap(err, "MeasureLinkProfile")
+	}
+	if profile.CopyEngines, err = dev.CopyEngines(); err != nil {
+		return profile, errors.Wrap(err, "MeasureLinkProfile")
+	}
+	return profile, nil
+}
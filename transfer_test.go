@@ -0,0 +1,74 @@
+package cu
+
+import (
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferPlanner(t *testing.T) {
+	assert := assert.New(t)
+	host := make([]byte, 4096)
+	h := func(i int) unsafe.Pointer { return unsafe.Pointer(&host[i]) }
+
+	transfers := []Transfer{
+		{Kind: HtoD, Dst: 0x1000, Host: h(0), Size: 1024},
+		{Kind: DtoH, Src: 0x8000, Host: h(2048), Size: 512},
+		{Kind: HtoD, Dst: 0x1400, Host: h(1024), Size: 1024}, // contiguous with the first
+		{Kind: HtoD, Dst: 0x4000, Host: h(3072), Size: 256},
+		{Kind: DtoD, Dst: 0x9000, Src: 0xA000, Size: 128},
+		{Kind: HtoD, Dst: 0x5000, Host: h(3584), Size: 0}, // empty transfers are dropped
+	}
+
+	profile := LinkProfile{
+		HtoDBandwidth: 1024,
+		DtoHBandwidth: 512,
+		DtoDBandwidth: 128,
+		Latency:       time.Millisecond,
+		CopyEngines:   2,
+	}
+	plan := NewTransferPlanner(profile).Plan(transfers)
+
+	assert.Equal(3, len(plan.Lanes))
+	assert.Equal(HtoD, plan.Lanes[0].Kind)
+	assert.Equal(DtoH, plan.Lanes[1].Kind)
+	assert.Equal(DtoD, plan.Lanes[2].Kind)
+
+	// the first and third transfers are coalesced
+	htod := plan.Lanes[0].Transfers
+	assert.Equal(2, len(htod))
+	assert.Equal(DevicePtr(0x1000), htod[0].Dst)
+	assert.Equal(int64(2048), htod[0].Size)
+	assert.Equal(DevicePtr(0x4000), htod[1].Dst)
+
+	// HtoD: 2 copies of latency + 2304 bytes at 1024 B/s
+	want := 2*time.Millisecond + time.Duration(2304.0/1024.0*float64(time.Second))
+	assert.Equal(want, plan.Lanes[0].Estimate)
+	assert.Equal(want, plan.Estimate)
+
+	// the input is not modified
+	assert.Equal(int64(1024), transfers[0].Size)
+}
+
+func TestTransferPlanner_SingleCopyEngine(t *testing.T) {
+	assert := assert.New(t)
+	host := make([]byte, 2048)
+
+	transfers := []Transfer{
+		{Kind: HtoD, Dst: 0x1000, Host: unsafe.Pointer(&host[0]), Size: 1024},
+		{Kind: DtoH, Src: 0x8000, Host: unsafe.Pointer(&host[1024]), Size: 1024},
+	}
+	profile := LinkProfile{HtoDBandwidth: 1024, DtoHBandwidth: 1024}
+
+	// with two copy engines, uploads and downloads overlap
+	profile.CopyEngines = 2
+	plan := NewTransferPlanner(profile).Plan(transfers)
+	assert.Equal(time.Second, plan.Estimate)
+
+	// with a single copy engine, they are serialized
+	profile.CopyEngines = 1
+	plan = NewTransferPlanner(profile).Plan(transfers)
+	assert.Equal(2*time.Second, plan.Estimate)
+}
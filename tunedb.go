@@ -0,0 +1,170 @@
+package cu
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// TuneDBVersion is the version of the format written by TuneDB. Files of other versions are ignored when loaded.
+const TuneDBVersion = 1
+
+// Fingerprint identifies the hardware and driver that tuning results are valid for.
+type Fingerprint struct {
+	DeviceModel
+	Driver int `json:"driver"` // version of the CUDA driver, as returned by Version
+}
+
+// FingerprintOf returns the fingerprint of the device, with the current driver.
+func FingerprintOf(d Device) (Fingerprint, error) {
+	model, err := ModelOf(d)
+	if err != nil {
+		return Fingerprint{}, err
+	}
+	return Fingerprint{DeviceModel: model, Driver: Version()}, nil
+}
+
+// TuneDB is a database of tuning results - the algorithms chosen for convolutions, the backend chosen for GEMMs, the
+// launch configurations of kernels... - keyed by the fingerprint of the hardware they were tuned on.
+//
+// A TuneDB reads and writes entries for one fingerprint, but keeps the entries of the other fingerprints it loaded,
+// so that a file shipped with an application can hold the results of several models of GPU. Entries of other
+// fingerprints are never returned: loading a file tuned on other hardware is safe, and merely finds nothing.
+//
+// Entries are JSON values stored under keys chosen by the callers, for example:
+//
+//	db.Put("cudnn/planner/resnet50", plannerConfig)
+//	db.Put("kernels/softmax/float32", LaunchConfig{...})
+//
+// A TuneDB is safe for concurrent use.
+type TuneDB struct {
+	fp Fingerprint
+
+	sync.Mutex
+	sections map[Fingerprint]map[string]json.RawMessage
+}
+
+type tuneFile struct {
+	Version  int           `json:"version"`
+	Sections []tuneSection `json:"sections"`
+}
+
+type tuneSection struct {
+	Fingerprint Fingerprint                `json:"fingerprint"`
+	Entries     map[string]json.RawMessage `json:"entries"`
+}
+
+// NewTuneDB creates an empty TuneDB for the given fingerprint.
+func NewTuneDB(fp Fingerprint) *TuneDB {
+	return &TuneDB{
+		fp:       fp,
+		sections: map[Fingerprint]map[string]json.RawMessage{fp: make(map[string]json.RawMessage)},
+	}
+}
+
+// Fingerprint returns the fingerprint the entries are read and written for.
+func (db *TuneDB) Fingerprint() Fingerprint { return db.fp }
+
+// Len returns the number of entries for the fingerprint of the database.
+func (db *TuneDB) Len() int {
+	db.Lock()
+	defer db.Unlock()
+	return len(db.sections[db.fp])
+}
+
+// Get decodes the entry stored under the key into v. It returns false if there is no such entry.
+func (db *TuneDB) Get(key string, v interface{}) (bool, error) {
+	db.Lock()
+	raw, ok := db.sections[db.fp][key]
+	db.Unlock()
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return false, errors.Wrapf(err, "Unable to decode the tuning entry %q", key)
+	}
+	return true, nil
+}
+
+// Put stores v under the key, replacing any previous entry.
+func (db *TuneDB) Put(key string, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrapf(err, "Unable to encode the tuning entry %q", key)
+	}
+	db.Lock()
+	db.sections[db.fp][key] = raw
+	db.Unlock()
+	return nil
+}
+
+// Delete removes the entry stored under the key.
+func (db *TuneDB) Delete(key string) {
+	db.Lock()
+	delete(db.sections[db.fp], key)
+	db.Unlock()
+}
+
+// Load merges the entries read from r into the database, replacing the entries with the same keys. It returns the
+// number of entries read for the fingerprint of the database.
+//
+// A file of another version of the format is ignored, and Load returns 0 without an error.
+func (db *TuneDB) Load(r io.Reader) (int, error) {
+	var f tuneFile
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return 0, errors.Wrap(err, "Unable to read the tuning database")
+	}
+	if f.Version != TuneDBVersion {
+		return 0, nil
+	}
+
+	db.Lock()
+	defer db.Unlock()
+	var n int
+	for _, s := range f.Sections {
+		entries := db.sections[s.Fingerprint]
+		if entries == nil {
+			entries = make(map[string]json.RawMessage)
+			db.sections[s.Fingerprint] = entries
+		}
+		for k, v := range s.Entries {
+			entries[k] = v
+		}
+		if s.Fingerprint == db.fp {
+			n += len(s.Entries)
+		}
+	}
+	return n, nil
+}
+
+// Write writes the database to w, including the entries of the other fingerprints it loaded.
+func (db *TuneDB) Write(w io.Writer) error {
+	db.Lock()
+	f := tuneFile{Version: TuneDBVersion}
+	for fp, entries := range db.sections {
+		if len(entries) > 0 {
+			f.Sections = append(f.Sections, tuneSection{Fingerprint: fp, Entries: entries})
+		}
+	}
+	sort.Slice(f.Sections, func(i, j int) bool { return f.Sections[i].Fingerprint.less(f.Sections[j].Fingerprint) })
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	err := enc.Encode(f)
+	db.Unlock()
+	return errors.Wrap(err, "Unable to write the tuning database")
+}
+
+func (fp Fingerprint) less(other Fingerprint) bool {
+	switch {
+	case fp.Name != other.Name:
+		return fp.Name < other.Name
+	case fp.Major != other.Major:
+		return fp.Major < other.Major
+	case fp.Minor != other.Minor:
+		return fp.Minor < other.Minor
+	}
+	return fp.Driver < other.Driver
+}
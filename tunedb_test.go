@@ -0,0 +1,64 @@
+package cu
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTuneDB(t *testing.T) {
+	assert := assert.New(t)
+	a100 := Fingerprint{DeviceModel{"NVIDIA A100-SXM4-40GB", 8, 0}, 12020}
+	t4 := Fingerprint{DeviceModel{"Tesla T4", 7, 5}, 12020}
+	cfg := LaunchConfig{Grid: [3]int{80, 1, 1}, Block: [3]int{512, 1, 1}}
+
+	db := NewTuneDB(a100)
+	assert.Nil(db.Put("kernels/softmax/float32", cfg))
+	assert.Nil(db.Put("blas/gemm/1024x1024x1024", "cublasLt"))
+	var got LaunchConfig
+	ok, err := db.Get("kernels/softmax/float32", &got)
+	assert.True(ok)
+	assert.Nil(err)
+	assert.Equal(cfg, got)
+	ok, _ = db.Get("kernels/softmax/float64", &got)
+	assert.False(ok)
+
+	var buf bytes.Buffer
+	assert.Nil(db.Write(&buf))
+	shipped := buf.String()
+
+	// the file is ignored by other hardware, but kept when written back
+	other := NewTuneDB(t4)
+	n, err := other.Load(strings.NewReader(shipped))
+	assert.Nil(err)
+	assert.Equal(0, n)
+	ok, _ = other.Get("kernels/softmax/float32", &got)
+	assert.False(ok)
+	assert.Nil(other.Put("kernels/softmax/float32", LaunchConfig{Grid: [3]int{40, 1, 1}, Block: [3]int{256, 1, 1}}))
+	buf.Reset()
+	assert.Nil(other.Write(&buf))
+
+	// a driver update invalidates the results
+	updated := a100
+	updated.Driver++
+	n, err = NewTuneDB(updated).Load(bytes.NewReader(buf.Bytes()))
+	assert.Nil(err)
+	assert.Equal(0, n)
+
+	merged := NewTuneDB(a100)
+	n, err = merged.Load(bytes.NewReader(buf.Bytes()))
+	assert.Nil(err)
+	assert.Equal(2, n)
+	ok, _ = merged.Get("kernels/softmax/float32", &got)
+	assert.True(ok)
+	assert.Equal(cfg, got)
+
+	// other versions of the format are ignored
+	n, err = NewTuneDB(a100).Load(strings.NewReader(`{"version": 999, "sections": [{"entries": {"x": 1}}]}`))
+	assert.Nil(err)
+	assert.Equal(0, n)
+	_, err = NewTuneDB(a100).Load(strings.NewReader(`not json`))
+	assert.NotNil(err)
+}
@@ -0,0 +1,135 @@
+package cu
+
+// #include <cuda.h>
+import "C"
+import (
+	"encoding/binary"
+	"fmt"
+	"unsafe"
+)
+
+// PoisonPattern is the 32 bit word written over freshly allocated device memory when uninitialized reads are being
+// detected (see DebugUninit). Read as a float32 it is a NaN, so computations on memory that was never written stand out
+// even where it is not checked.
+const PoisonPattern uint32 = 0x7FBADBAD
+
+// UninitializedError is returned when memory read back from the device still holds the PoisonPattern - it was
+// allocated, but never written by a memset, a copy or a kernel.
+type UninitializedError struct {
+	Ptr    DevicePtr // start of the memory checked
+	Offset int64     // offset of the first uninitialized word, in bytes
+	Words  int       // number of uninitialized words
+}
+
+func (err UninitializedError) Error() string {
+	return fmt.Sprintf("Read of uninitialized memory: %d words at %v are uninitialized, the first at offset %d", err.Words, err.Ptr, err.Offset)
+}
+
+func poisoning() bool { return debugging(DebugUninit) }
+
+// poison fills the memory with the PoisonPattern if uninitialized reads are being detected.
+// The allocators of this package return memory aligned to at least 4 bytes, so the words written start at ptr.
+func poison(ptr DevicePtr, size int64) error {
+	if !poisoning() || size <= 0 {
+		return nil
+	}
+	words := size / 4
+	if words > 0 {
+		if err := result(C.cuMemsetD32(C.CUdeviceptr(ptr), C.uint(PoisonPattern), C.size_t(words))); err != nil {
+			return err
+		}
+	}
+	for i := words * 4; i < size; i++ {
+		b := byte(PoisonPattern >> (8 * uint(i%4)))
+		if err := result(C.cuMemsetD8(C.CUdeviceptr(ptr+DevicePtr(i)), C.uchar(b), 1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// poisonAsync is poison, enqueued on the stream, for memory that is allocated in stream order.
+func poisonAsync(ptr DevicePtr, size int64, stream Stream) error {
+	if !poisoning() || size <= 0 {
+		return nil
+	}
+	words := size / 4
+	if words > 0 {
+		if err := result(C.cuMemsetD32Async(C.CUdeviceptr(ptr), C.uint(PoisonPattern), C.size_t(words), stream.c())); err != nil {
+			return err
+		}
+	}
+	for i := words * 4; i < size; i++ {
+		b := byte(PoisonPattern >> (8 * uint(i%4)))
+		if err := result(C.cuMemsetD8Async(C.CUdeviceptr(ptr+DevicePtr(i)), C.uchar(b), 1, stream.c())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findPoison scans memory copied from the device address src for aligned words holding the PoisonPattern.
+// It returns the offset of the first such word, and the number of them.
+func findPoison(buf []byte, src DevicePtr) (first int64, words int) {
+	first = -1
+	for i := int64((4 - src%4) % 4); i+4 <= int64(len(buf)); i += 4 {
+		if binary.LittleEndian.Uint32(buf[i:]) == PoisonPattern {
+			if words == 0 {
+				first = i
+			}
+			words++
+		}
+	}
+	return first, words
+}
+
+// checkInitialized checks the n bytes copied to host from src, if uninitialized reads are being detected.
+func checkInitialized(host unsafe.Pointer, src DevicePtr, n int64) error {
+	if !poisoning() || n <= 0 || host == nil {
+		return nil
+	}
+	buf := (*[1 << 40]byte)(host)[:n:n]
+	if first, words := findPoison(buf, src); words > 0 {
+		return UninitializedError{Ptr: src, Offset: first, Words: words}
+	}
+	return nil
+}
+
+// AssertInitialized reads back n bytes at ptr, and returns an UninitializedError if any of them were allocated but never
+// written. It only detects anything when uninitialized reads are being detected (see DebugUninit), and must be called
+// with a current context.
+//
+// A word whose value happens to be the PoisonPattern is reported as uninitialized. This is rare outside of float32 data
+// holding NaNs.
+func AssertInitialized(ptr DevicePtr, n int64) error {
+	if !poisoning() || n <= 0 {
+		return nil
+	}
+	buf := make([]byte, n)
+	if err := result(C.cuMemcpyDtoH(unsafe.Pointer(&buf[0]), C.CUdeviceptr(ptr), C.size_t(n))); err != nil {
+		return err
+	}
+	if first, words := findPoison(buf, ptr); words > 0 {
+		return UninitializedError{Ptr: ptr, Offset: first, Words: words}
+	}
+	return nil
+}
+
+// AssertInitialized is AssertInitialized made on the thread of the context.
+func (ctx *Ctx) AssertInitialized(ptr DevicePtr, n int64) error {
+	var err error
+	if doErr := ctx.Do(func() error { err = AssertInitialized(ptr, n); return nil }); doErr != nil {
+		return doErr
+	}
+	return err
+}
+
+// MemcpyDtoH copies memory from the device to the host. The copy is checked for uninitialized memory if uninitialized
+// reads are being detected.
+func MemcpyDtoH(dstHost unsafe.Pointer, srcDevice DevicePtr, ByteCount int64) (err error) {
+	if err = result(C.cuMemcpyDtoH(dstHost, C.CUdeviceptr(srcDevice), C.size_t(ByteCount))); err != nil {
+		return
+	}
+	countCopy(DtoH, NoStream, ByteCount)
+	return checkInitialized(dstHost, srcDevice, ByteCount)
+}
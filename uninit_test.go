@@ -0,0 +1,77 @@
+package cu
+
+import (
+	"encoding/binary"
+	"testing"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+func TestFindPoison(t *testing.T) {
+	buf := make([]byte, 32)
+	if _, words := findPoison(buf, 0); words != 0 {
+		t.Errorf("Found %d poisoned words in zeroed memory", words)
+	}
+
+	binary.LittleEndian.PutUint32(buf[8:], PoisonPattern)
+	binary.LittleEndian.PutUint32(buf[20:], PoisonPattern)
+	if first, words := findPoison(buf, 0x100); first != 8 || words != 2 {
+		t.Errorf("Expected 2 poisoned words from offset 8. Got %d from offset %d", words, first)
+	}
+
+	// the words are aligned on the device: copied from 0x102, the word at offset 8 straddles two words of the device
+	if first, words := findPoison(buf, 0x102); first != -1 || words != 0 {
+		t.Errorf("Expected no aligned poisoned words. Got %d from offset %d", words, first)
+	}
+	binary.LittleEndian.PutUint32(buf[2:], PoisonPattern)
+	if first, words := findPoison(buf, 0x102); first != 2 || words != 1 {
+		t.Errorf("Expected 1 poisoned word at offset 2. Got %d from offset %d", words, first)
+	}
+}
+
+func TestAssertInitialized(t *testing.T) {
+	_, ctx, err := testSetup()
+	if err != nil {
+		if err.Error() == "NoDevice" {
+			t.Skip("NoDevice")
+		}
+		t.Fatal(err)
+	}
+	defer testTeardown(ctx, Module{})
+
+	old := CurrentFeatures()
+	defer SetFeatures(old)
+	f := old
+	f.Debug |= DebugUninit
+	SetFeatures(f)
+
+	const size = 1024
+	mem, err := MemAlloc(size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer MemFree(mem)
+
+	err = AssertInitialized(mem, size)
+	if uerr, ok := errors.Cause(err).(UninitializedError); !ok || uerr.Words != size/4 || uerr.Offset != 0 {
+		t.Fatalf("Expected all of the fresh allocation to be uninitialized. Got %v", err)
+	}
+
+	// initialize the first half only: the copy back must catch the second half
+	host := make([]byte, size)
+	if err = MemcpyHtoD(mem, unsafe.Pointer(&host[0]), size/2); err != nil {
+		t.Fatal(err)
+	}
+	err = MemcpyDtoH(unsafe.Pointer(&host[0]), mem, size)
+	if uerr, ok := errors.Cause(err).(UninitializedError); !ok || uerr.Offset != size/2 || uerr.Words != size/8 {
+		t.Fatalf("Expected the second half to be reported as uninitialized. Got %v", err)
+	}
+
+	if err = MemsetD8(mem, 0, size); err != nil {
+		t.Fatal(err)
+	}
+	if err = AssertInitialized(mem, size); err != nil {
+		t.Errorf("Initialized memory reported as uninitialized: %v", err)
+	}
+}
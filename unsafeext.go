@@ -0,0 +1,13 @@
+// +build unsafeext
+
+package cu
+
+// #include <cuda.h>
+import "C"
+
+// This file supports package unsafeext, and is only built with the unsafeext build tag.
+
+// ResultError returns the error for a CUresult returned by the driver, or nil for CUDA_SUCCESS.
+//
+// This is part of the unstable escape hatch API, see package unsafeext.
+func ResultError(code int) error { return result(C.CUresult(code)) }
@@ -0,0 +1,127 @@
+// +build unsafeext
+
+// Package unsafeext is an escape hatch to the CUDA driver API: it exposes the raw handles of package cu, and calls
+// driver functions that package cu does not bind yet, so that they can be used without forking package cu.
+//
+// The API of this package is unstable, and may change in any release. Nothing is checked: the arguments are passed to
+// the driver as they are, and mistakes crash the process. Functions that package cu binds should be called through it.
+//
+// The package is only built with the unsafeext build tag:
+//
+//	go build -tags unsafeext
+package unsafeext
+
+/*
+#include <stdint.h>
+#include <stdlib.h>
+#include <cuda.h>
+#ifdef _WIN32
+#include <windows.h>
+static void* lookup(const char* name) {
+	HMODULE lib = GetModuleHandleA("nvcuda.dll");
+	return lib ? (void*)GetProcAddress(lib, name) : NULL;
+}
+#else
+#define _GNU_SOURCE
+#include <dlfcn.h>
+static void* lookup(const char* name) { return dlsym(RTLD_DEFAULT, name); }
+#endif
+
+typedef uintptr_t u;
+
+static CUresult call(void* fn, u* a, int n) {
+	switch (n) {
+	case 0: return ((CUresult (*)(void))fn)();
+	case 1: return ((CUresult (*)(u))fn)(a[0]);
+	case 2: return ((CUresult (*)(u, u))fn)(a[0], a[1]);
+	case 3: return ((CUresult (*)(u, u, u))fn)(a[0], a[1], a[2]);
+	case 4: return ((CUresult (*)(u, u, u, u))fn)(a[0], a[1], a[2], a[3]);
+	case 5: return ((CUresult (*)(u, u, u, u, u))fn)(a[0], a[1], a[2], a[3], a[4]);
+	case 6: return ((CUresult (*)(u, u, u, u, u, u))fn)(a[0], a[1], a[2], a[3], a[4], a[5]);
+	case 7: return ((CUresult (*)(u, u, u, u, u, u, u))fn)(a[0], a[1], a[2], a[3], a[4], a[5], a[6]);
+	case 8: return ((CUresult (*)(u, u, u, u, u, u, u, u))fn)(a[0], a[1], a[2], a[3], a[4], a[5], a[6], a[7]);
+	case 9: return ((CUresult (*)(u, u, u, u, u, u, u, u, u))fn)(a[0], a[1], a[2], a[3], a[4], a[5], a[6], a[7], a[8]);
+	case 10: return ((CUresult (*)(u, u, u, u, u, u, u, u, u, u))fn)(a[0], a[1], a[2], a[3], a[4], a[5], a[6], a[7], a[8], a[9]);
+	case 11: return ((CUresult (*)(u, u, u, u, u, u, u, u, u, u, u))fn)(a[0], a[1], a[2], a[3], a[4], a[5], a[6], a[7], a[8], a[9], a[10]);
+	case 12: return ((CUresult (*)(u, u, u, u, u, u, u, u, u, u, u, u))fn)(a[0], a[1], a[2], a[3], a[4], a[5], a[6], a[7], a[8], a[9], a[10], a[11]);
+	}
+	return CUDA_ERROR_INVALID_VALUE;
+}
+*/
+import "C"
+import (
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/cu"
+)
+
+// MaxArgs is the maximum number of arguments of the functions that Proc.Call can call.
+const MaxArgs = 12
+
+// Context returns the CUcontext of the context.
+func Context(ctx cu.CUContext) unsafe.Pointer { return ctx.Pointer() }
+
+// Stream returns the CUstream of the stream.
+func Stream(s cu.Stream) unsafe.Pointer { return s.Pointer() }
+
+// Event returns the CUevent of the event.
+func Event(e cu.Event) unsafe.Pointer { return e.Pointer() }
+
+// Module returns the CUmodule of the module.
+func Module(m cu.Module) unsafe.Pointer { return m.Pointer() }
+
+// Function returns the CUfunction of the function.
+func Function(fn cu.Function) unsafe.Pointer { return fn.Pointer() }
+
+// Array returns the CUarray of the array.
+func Array(arr cu.Array) unsafe.Pointer { return arr.Pointer() }
+
+// DevicePtr returns the CUdeviceptr of the pointer.
+func DevicePtr(p cu.DevicePtr) uint64 { return uint64(p) }
+
+// Device returns the CUdevice of the device.
+func Device(d cu.Device) int32 { return int32(d) }
+
+// Proc is a function of the driver API.
+type Proc struct {
+	name string
+	fn   unsafe.Pointer
+}
+
+// Lookup looks up the named function of the driver API in the loaded driver library.
+//
+// name is the name of the symbol, which is not always the name of the function in the documentation: cuda.h
+// redirects some functions to later versions of them, such as cuMemAlloc to cuMemAlloc_v2.
+func Lookup(name string) (Proc, error) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	fn := C.lookup(cname)
+	if fn == nil {
+		return Proc{}, errors.Errorf("The driver has no function %q", name)
+	}
+	return Proc{name: name, fn: fn}, nil
+}
+
+// Name returns the name of the function.
+func (p Proc) Name() string { return p.name }
+
+// Call calls the function with the given arguments, and returns the error for the CUresult it returns.
+//
+// Each argument is passed as an integer register sized value: pointers, handles, and integer types up to 64 bits can
+// be passed, but functions taking floating point values or structs by value cannot be called. Pointers to Go memory
+// may be passed if they do not point to memory holding Go pointers, and if the driver does not retain them after
+// the call returns (see the cgo pointer passing rules); the caller must keep the memory alive with runtime.KeepAlive.
+func (p Proc) Call(args ...uintptr) error {
+	if p.fn == nil {
+		return errors.New("Call of a Proc that has not been looked up")
+	}
+	if len(args) > MaxArgs {
+		return errors.Errorf("Cannot call %v with %d arguments. At most %d are supported", p.name, len(args), MaxArgs)
+	}
+	var a [MaxArgs]C.uintptr_t
+	for i, arg := range args {
+		a[i] = C.uintptr_t(arg)
+	}
+	return cu.ResultError(int(C.call(p.fn, &a[0], C.int(len(args)))))
+}
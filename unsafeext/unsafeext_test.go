@@ -0,0 +1,44 @@
+// +build unsafeext
+
+package unsafeext
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"gorgonia.org/cu"
+)
+
+func TestLookup(t *testing.T) {
+	if _, err := Lookup("cuNoSuchFunction"); err == nil {
+		t.Error("Expected an error looking up a function that does not exist")
+	}
+	var p Proc
+	if err := p.Call(); err == nil {
+		t.Error("Expected an error calling a Proc that has not been looked up")
+	}
+}
+
+func TestCall(t *testing.T) {
+	devices, _ := cu.NumDevices()
+	if devices == 0 {
+		t.Skip("NoDevice")
+	}
+	p, err := Lookup("cuDriverGetVersion")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v int32
+	if err = p.Call(uintptr(unsafe.Pointer(&v))); err != nil {
+		t.Fatal(err)
+	}
+	runtime.KeepAlive(&v)
+	if int(v) != cu.Version() {
+		t.Errorf("Expected version %d. Got %d", cu.Version(), v)
+	}
+
+	if err = p.Call(make([]uintptr, MaxArgs+1)...); err == nil {
+		t.Error("Expected an error calling with too many arguments")
+	}
+}
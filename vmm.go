@@ -0,0 +1,252 @@
+package cu
+
+// #include <cuda.h>
+import "C"
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// PhysicalMem is a handle to physical device memory created by MemCreate. The memory has no address until it is mapped
+// into a range of virtual addresses reserved with MemAddressReserve (see MemMap).
+type PhysicalMem uint64
+
+func allocationProp(dev Device) C.CUmemAllocationProp {
+	var prop C.CUmemAllocationProp
+	prop._type = C.CU_MEM_ALLOCATION_TYPE_PINNED
+	prop.location._type = C.CU_MEM_LOCATION_TYPE_DEVICE
+	prop.location.id = C.int(dev)
+	return prop
+}
+
+// MemAllocationGranularity returns the granularity of the physical memory of the device, in bytes. The sizes of
+// MemCreate, and the sizes and offsets of MemMap, must be multiples of it. The recommended granularity is the one that
+// performs best; the minimum one wastes less memory.
+func MemAllocationGranularity(dev Device, recommended bool) (int64, error) {
+	prop := allocationProp(dev)
+	option := C.CUmemAllocationGranularity_flags(C.CU_MEM_ALLOC_GRANULARITY_MINIMUM)
+	if recommended {
+		option = C.CU_MEM_ALLOC_GRANULARITY_RECOMMENDED
+	}
+	var gran C.size_t
+	if err := result(C.cuMemGetAllocationGranularity(&gran, &prop, option)); err != nil {
+		return 0, errors.Wrap(err, "MemGetAllocationGranularity")
+	}
+	return int64(gran), nil
+}
+
+// MemAddressReserve reserves a range of size bytes of virtual addresses, aligned to alignment (or to the granularity,
+// if it is 0). addr is a hint of the address wanted: the range is reserved elsewhere if it is not available. No memory
+// is allocated: the range is backed by mapping physical memory into it (see MemMap).
+func MemAddressReserve(size, alignment int64, addr DevicePtr) (DevicePtr, error) {
+	var ptr C.CUdeviceptr
+	if err := result(C.cuMemAddressReserve(&ptr, C.size_t(size), C.size_t(alignment), C.CUdeviceptr(addr), 0)); err != nil {
+		return 0, errors.Wrapf(err, "MemAddressReserve %d bytes", size)
+	}
+	return DevicePtr(ptr), nil
+}
+
+// MemAddressFree frees a range reserved with MemAddressReserve. The range must be unmapped.
+func MemAddressFree(ptr DevicePtr, size int64) error {
+	return errors.Wrap(result(C.cuMemAddressFree(C.CUdeviceptr(ptr), C.size_t(size))), "MemAddressFree")
+}
+
+// MemCreate allocates size bytes of physical memory on the device. size must be a multiple of the granularity (see
+// MemAllocationGranularity).
+func MemCreate(size int64, dev Device) (PhysicalMem, error) {
+	prop := allocationProp(dev)
+	var h C.CUmemGenericAllocationHandle
+	if err := result(C.cuMemCreate(&h, C.size_t(size), &prop, 0)); err != nil {
+		return 0, errors.Wrapf(err, "MemCreate %d bytes on device %d", size, dev)
+	}
+	return PhysicalMem(h), nil
+}
+
+// MemRelease releases the handle of physical memory. The memory is freed once it is unmapped from all the ranges it
+// was mapped into, so the handle may be released as soon as it is mapped.
+func MemRelease(h PhysicalMem) error {
+	return errors.Wrap(result(C.cuMemRelease(C.CUmemGenericAllocationHandle(h))), "MemRelease")
+}
+
+// MemMap maps size bytes of the physical memory, from offset, at ptr, in a range reserved with MemAddressReserve. The
+// memory cannot be accessed until access is given to the devices (see MemSetAccess).
+func MemMap(ptr DevicePtr, size, offset int64, h PhysicalMem) error {
+	err := result(C.cuMemMap(C.CUdeviceptr(ptr), C.size_t(size), C.size_t(offset), C.CUmemGenericAllocationHandle(h), 0))
+	return errors.Wrap(err, "MemMap")
+}
+
+// MemUnmap unmaps the size bytes at ptr, which must be whole mappings made by MemMap.
+func MemUnmap(ptr DevicePtr, size int64) error {
+	return errors.Wrap(result(C.cuMemUnmap(C.CUdeviceptr(ptr), C.size_t(size))), "MemUnmap")
+}
+
+// MemSetAccess sets the access the device has to the size bytes of mapped memory at ptr.
+func MemSetAccess(ptr DevicePtr, size int64, dev Device, flags MemAccessFlags) error {
+	var desc C.CUmemAccessDesc
+	desc.location._type = C.CU_MEM_LOCATION_TYPE_DEVICE
+	desc.location.id = C.int(dev)
+	desc.flags = C.CUmemAccess_flags(flags)
+	return errors.Wrap(result(C.cuMemSetAccess(C.CUdeviceptr(ptr), C.size_t(size), &desc, 1)), "MemSetAccess")
+}
+
+// addrRange is a range of reserved virtual addresses.
+type addrRange struct {
+	ptr  DevicePtr
+	size int64
+}
+
+// GrowableBuffer is device memory that grows in place: its address does not change when it grows, so the data is never
+// copied, and the pointers into it stay valid. It reserves a range of addresses up front, and maps physical memory into
+// the range as it grows. Once the range is full, it tries to reserve the addresses that follow it.
+//
+// The methods of a GrowableBuffer must be called where a context of its device is current. A GrowableBuffer is safe
+// for concurrent use.
+type GrowableBuffer struct {
+	dev  Device
+	base DevicePtr
+	gran int64
+
+	sync.Mutex
+	ranges []addrRange   // the reserved ranges, contiguous, in order
+	chunks []addrRange   // the mapped chunks, contiguous from base, in order
+	mems   []PhysicalMem // the physical memory of the chunks
+	size   int64         // the bytes mapped
+}
+
+// NewGrowableBuffer reserves addresses for a buffer of up to capacity bytes on the device. No memory is allocated until
+// the buffer grows.
+func NewGrowableBuffer(dev Device, capacity int64) (*GrowableBuffer, error) {
+	if capacity <= 0 {
+		return nil, errors.Errorf("Cannot create a growable buffer of %d bytes", capacity)
+	}
+	gran, err := MemAllocationGranularity(dev, true)
+	if err != nil {
+		return nil, err
+	}
+	capacity = roundUp(capacity, gran)
+	base, err := MemAddressReserve(capacity, 0, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewGrowableBuffer")
+	}
+	return &GrowableBuffer{dev: dev, base: base, gran: gran, ranges: []addrRange{{base, capacity}}}, nil
+}
+
+// Ptr returns the address of the buffer. It does not change as the buffer grows.
+func (b *GrowableBuffer) Ptr() DevicePtr { return b.base }
+
+// Len returns the number of bytes of the buffer that are backed by memory.
+func (b *GrowableBuffer) Len() int64 {
+	b.Lock()
+	defer b.Unlock()
+	return b.size
+}
+
+// Cap returns the number of bytes the buffer may grow to without reserving more addresses.
+func (b *GrowableBuffer) Cap() int64 {
+	b.Lock()
+	defer b.Unlock()
+	return b.reserved()
+}
+
+func (b *GrowableBuffer) reserved() (n int64) {
+	for _, r := range b.ranges {
+		n += r.size
+	}
+	return n
+}
+
+// Grow makes the first size bytes of the buffer usable by its device, by mapping memory after the bytes already
+// mapped, in multiples of the granularity. The contents of the buffer are kept. It fails if the addresses past the
+// capacity are taken.
+func (b *GrowableBuffer) Grow(size int64) error {
+	b.Lock()
+	defer b.Unlock()
+	if size <= b.size {
+		return nil
+	}
+	n := roundUp(size-b.size, b.gran)
+	if reserved := b.reserved(); b.size+n > reserved {
+		if err := b.extend(b.size + n - reserved); err != nil {
+			return err
+		}
+	}
+
+	ptr := b.base.Offset(b.size)
+	h, err := MemCreate(n, b.dev)
+	if err != nil {
+		return errors.Wrap(err, "Unable to grow the buffer")
+	}
+	if err = MemMap(ptr, n, 0, h); err != nil {
+		MemRelease(h)
+		return errors.Wrap(err, "Unable to grow the buffer")
+	}
+	if err = MemSetAccess(ptr, n, b.dev, MemAccessReadWrite); err != nil {
+		MemUnmap(ptr, n)
+		MemRelease(h)
+		return errors.Wrap(err, "Unable to grow the buffer")
+	}
+	b.chunks = append(b.chunks, addrRange{ptr, n})
+	b.mems = append(b.mems, h)
+	b.size += n
+	debugf(DebugMem, "GrowableBuffer %v: grew by %d bytes to %d bytes", b.base, n, b.size)
+	return nil
+}
+
+// extend reserves at least n more addresses right after the reserved ranges. The lock is expected to be held.
+func (b *GrowableBuffer) extend(n int64) error {
+	n = roundUp(n, b.gran)
+	last := b.ranges[len(b.ranges)-1]
+	want := last.ptr.Offset(last.size)
+	ptr, err := MemAddressReserve(n, 0, want)
+	if err != nil {
+		return errors.Wrap(err, "Unable to extend the addresses of the buffer")
+	}
+	if ptr != want {
+		MemAddressFree(ptr, n)
+		return errors.Errorf("Unable to extend the addresses of the buffer: the %d bytes at %v are taken", n, want)
+	}
+	b.ranges = append(b.ranges, addrRange{ptr, n})
+	return nil
+}
+
+// Shrink frees the memory of the buffer past its first size bytes. Memory is freed by the chunks it was mapped in by
+// Grow, so the buffer may keep more than size bytes. The addresses stay reserved for the buffer to grow again.
+func (b *GrowableBuffer) Shrink(size int64) error {
+	b.Lock()
+	defer b.Unlock()
+	for len(b.chunks) > 0 {
+		last := len(b.chunks) - 1
+		c := b.chunks[last]
+		if b.size-c.size < size {
+			break
+		}
+		if err := MemUnmap(c.ptr, c.size); err != nil {
+			return errors.Wrap(err, "Unable to shrink the buffer")
+		}
+		if err := MemRelease(b.mems[last]); err != nil {
+			return errors.Wrap(err, "Unable to shrink the buffer")
+		}
+		b.chunks, b.mems = b.chunks[:last], b.mems[:last]
+		b.size -= c.size
+	}
+	debugf(DebugMem, "GrowableBuffer %v: shrunk to %d bytes", b.base, b.size)
+	return nil
+}
+
+// Free frees the memory and the addresses of the buffer. The buffer must not be used afterwards.
+func (b *GrowableBuffer) Free() error {
+	if err := b.Shrink(0); err != nil {
+		return err
+	}
+	b.Lock()
+	defer b.Unlock()
+	for len(b.ranges) > 0 {
+		r := b.ranges[len(b.ranges)-1]
+		if err := MemAddressFree(r.ptr, r.size); err != nil {
+			return err
+		}
+		b.ranges = b.ranges[:len(b.ranges)-1]
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package cu
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+)
+
+func TestGrowableBuffer(t *testing.T) {
+	devices, _ := NumDevices()
+	if devices == 0 {
+		t.Skip("NoDevice")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ctx, err := Device(0).MakeContext(SchedAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Destroy()
+
+	b, err := NewGrowableBuffer(Device(0), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Free()
+	gran := b.Cap()
+	if err = b.Grow(100); err != nil {
+		t.Fatal(err)
+	}
+	if b.Len() != gran {
+		t.Errorf("Expected %d bytes to be mapped. Got %d", gran, b.Len())
+	}
+	if err = MemsetD8(b.Ptr(), 7, 100); err != nil {
+		t.Fatal(err)
+	}
+
+	ptr := b.Ptr()
+	if err = b.Grow(gran + 1); err != nil {
+		t.Skipf("The addresses past the buffer are taken: %v", err)
+	}
+	if b.Ptr() != ptr || b.Len() != 2*gran {
+		t.Errorf("Expected the buffer to grow in place to %d bytes. Got %v, %d bytes", 2*gran, b.Ptr(), b.Len())
+	}
+	got := make([]byte, 100)
+	if err = MemcpyDtoH(unsafe.Pointer(&got[0]), ptr, 100); err != nil {
+		t.Fatal(err)
+	}
+	if got[0] != 7 || got[99] != 7 {
+		t.Errorf("Expected the contents to be kept. Got %v", got[:4])
+	}
+
+	if err = b.Shrink(1); err != nil {
+		t.Fatal(err)
+	}
+	if b.Len() != gran {
+		t.Errorf("Expected the second chunk to be freed. %d bytes are mapped", b.Len())
+	}
+}